@@ -14,7 +14,7 @@
  *
  */
 
-//go:generate ../../hack/generate-controller-registration.sh dns-external ../../charts/external-dns-management/ ../../VERSION ../../examples/controller-registration.yaml         DNSProvider:aws-route53 DNSProvider:alicloud-dns DNSProvider:azure-dns DNSProvider:azure-private-dns DNSProvider:google-clouddns DNSProvider:openstack-designate DNSProvider:cloudflare-dns DNSProvider:netlify-dns DNSProvider:infoblox-dns DNSProvider:remote
+//go:generate ../../hack/generate-controller-registration.sh dns-external ../../charts/external-dns-management/ ../../VERSION ../../examples/controller-registration.yaml         DNSProvider:aws-route53 DNSProvider:alicloud-dns DNSProvider:azure-dns DNSProvider:azure-private-dns DNSProvider:google-clouddns DNSProvider:openstack-designate DNSProvider:cloudflare-dns DNSProvider:ibm-cis DNSProvider:netlify-dns DNSProvider:ns1-dns DNSProvider:linode-dns DNSProvider:infoblox-dns DNSProvider:rfc2136 DNSProvider:scaleway-dns DNSProvider:ultradns DNSProvider:windows-dns DNSProvider:remote
 
 // Package chart enables go:generate support for generating the correct controller registration.
 package chart
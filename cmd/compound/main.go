@@ -37,16 +37,26 @@ import (
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/compound/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ibmcis"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/linode"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ns1"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/remote"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/rfc2136"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/scaleway"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ultradns"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/windowsdns"
 	_ "github.com/gardener/external-dns-management/pkg/controller/remoteaccesscertificates"
 	_ "github.com/gardener/external-dns-management/pkg/controller/replication/dnsprovider"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/dnsentry"
+	"github.com/gardener/external-dns-management/pkg/controller/source/gateways/gatewayapi"
+	"github.com/gardener/external-dns-management/pkg/controller/source/gateways/istio"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/ingress"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/service"
 	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
+	_ "github.com/gardener/external-dns-management/pkg/dns/provider/vault"
 	dnssource "github.com/gardener/external-dns-management/pkg/dns/source"
 	_ "github.com/gardener/external-dns-management/pkg/server/pprof"
 
@@ -72,6 +82,8 @@ func init() {
 	resources.Register(v1alpha1.SchemeBuilder)
 	resources.Register(coordinationv1.SchemeBuilder)
 	resources.Register(networkingv1.SchemeBuilder)
+	resources.Register(gatewayapi.SchemeBuilder)
+	resources.Register(istio.SchemeBuilder)
 
 	embed.RegisterCreateServerFunc(remote.CreateServer)
 }
@@ -33,16 +33,26 @@ import (
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ibmcis/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/linode/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ns1/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/provider/remote/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/rfc2136/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/scaleway/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/ultradns/controller"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/windowsdns/controller"
 	_ "github.com/gardener/external-dns-management/pkg/controller/remoteaccesscertificates"
 	_ "github.com/gardener/external-dns-management/pkg/controller/replication/dnsprovider"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/dnsentry"
+	"github.com/gardener/external-dns-management/pkg/controller/source/gateways/gatewayapi"
+	"github.com/gardener/external-dns-management/pkg/controller/source/gateways/istio"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/ingress"
 	_ "github.com/gardener/external-dns-management/pkg/controller/source/service"
 	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
+	_ "github.com/gardener/external-dns-management/pkg/dns/provider/vault"
 	dnssource "github.com/gardener/external-dns-management/pkg/dns/source"
 	_ "github.com/gardener/external-dns-management/pkg/server/pprof"
 
@@ -68,6 +78,8 @@ func init() {
 	resources.Register(v1alpha1.SchemeBuilder)
 	resources.Register(coordinationv1.SchemeBuilder)
 	resources.Register(networkingv1.SchemeBuilder)
+	resources.Register(gatewayapi.SchemeBuilder)
+	resources.Register(istio.SchemeBuilder)
 }
 
 func migrateExtensionsIngress(c controllermanager.Configuration) controllermanager.Configuration {
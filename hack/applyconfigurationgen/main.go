@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// applyconfigurationgen generates the apply configurations for the API packages.
+// It is a thin wrapper around applyconfiguration-gen, which registers the apply configurations
+// of client-go for the meta/v1 and core/v1 types used by the API. They cannot be passed by the
+// --external-applyconfigurations option of the used code-generator version, as it rejects
+// type packages containing dots.
+package main
+
+import (
+	"flag"
+
+	"github.com/spf13/pflag"
+	"k8s.io/gengo/types"
+	"k8s.io/klog/v2"
+
+	generatorargs "k8s.io/code-generator/cmd/applyconfiguration-gen/args"
+	"k8s.io/code-generator/cmd/applyconfiguration-gen/generators"
+	"k8s.io/code-generator/pkg/util"
+)
+
+const (
+	metav1        = "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1Applied = "k8s.io/client-go/applyconfigurations/meta/v1"
+	corev1        = "k8s.io/api/core/v1"
+	corev1Applied = "k8s.io/client-go/applyconfigurations/core/v1"
+)
+
+var externalApplyConfigurations = map[types.Name]string{
+	{Package: metav1, Name: "Condition"}:                metav1Applied,
+	{Package: metav1, Name: "LabelSelector"}:            metav1Applied,
+	{Package: metav1, Name: "LabelSelectorRequirement"}: metav1Applied,
+	{Package: metav1, Name: "ManagedFieldsEntry"}:       metav1Applied,
+	{Package: metav1, Name: "OwnerReference"}:           metav1Applied,
+	{Package: corev1, Name: "SecretReference"}:          corev1Applied,
+}
+
+func main() {
+	klog.InitFlags(nil)
+	genericArgs, customArgs := generatorargs.NewDefaults()
+	for name, pkg := range externalApplyConfigurations {
+		customArgs.ExternalApplyConfigurations[name] = pkg
+	}
+	genericArgs.GoHeaderFilePath = util.BoilerplatePath()
+	genericArgs.AddFlags(pflag.CommandLine)
+	customArgs.AddFlags(pflag.CommandLine, "")
+	if err := flag.Set("logtostderr", "true"); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+
+	if err := generatorargs.Validate(genericArgs); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+
+	if err := genericArgs.Execute(
+		generators.NameSystems(),
+		generators.DefaultNameSystem(),
+		generators.Packages,
+	); err != nil {
+		klog.Fatalf("Error: %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// crdgen generates the CRD manifests and the CRD registration code for the API packages
+// of the current directory. It is a thin wrapper around the controller-tools CRD generator
+// adding support for CEL validation rules (x-kubernetes-validations) on types and fields,
+// which are not supported by the controller-gen version used here. The rules are given by
+// markers of the form +kubebuilder:validation:XValidation:rule="<rule>",message="<message>"
+// so that the schema validation is kept next to the fields it belongs to.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/controller-tools/pkg/crd"
+	"sigs.k8s.io/controller-tools/pkg/genall"
+	"sigs.k8s.io/controller-tools/pkg/loader"
+	"sigs.k8s.io/controller-tools/pkg/markers"
+)
+
+const crdDir = "crds"
+
+// XValidation adds a CEL validation rule to the schema of a type or field.
+type XValidation struct {
+	Rule    string
+	Message string `marker:",optional"`
+}
+
+func (m XValidation) ApplyToSchema(schema *apiext.JSONSchemaProps) error {
+	schema.XValidations = append(schema.XValidations, apiext.ValidationRule{
+		Rule:    m.Rule,
+		Message: m.Message,
+	})
+	return nil
+}
+
+// generator is the controller-tools CRD generator extended by the additional markers.
+type generator struct {
+	crd.Generator
+}
+
+func (g generator) RegisterMarkers(into *markers.Registry) error {
+	if err := g.Generator.RegisterMarkers(into); err != nil {
+		return err
+	}
+	for _, target := range []markers.TargetType{markers.DescribesField, markers.DescribesType} {
+		def, err := markers.MakeDefinition("kubebuilder:validation:XValidation", target, XValidation{})
+		if err != nil {
+			return err
+		}
+		if err := into.Register(def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// manifestOutput writes the generated manifests to the crd directory
+// fixing the generator attribution, which is unknown for a go run.
+type manifestOutput struct {
+	dir     string
+	version string
+}
+
+func (o manifestOutput) Open(_ *loader.Package, path string) (io.WriteCloser, error) {
+	return &manifest{path: filepath.Join(o.dir, path), version: o.version}, nil
+}
+
+type manifest struct {
+	bytes.Buffer
+	path    string
+	version string
+}
+
+var attribution = regexp.MustCompile(`(controller-gen.kubebuilder.io/version:) .*`)
+
+func (m *manifest) Close() error {
+	data := attribution.ReplaceAll(m.Bytes(), []byte("${1} "+m.version))
+	return ioutil.WriteFile(m.path, data, 0644)
+}
+
+func controllerToolsVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, dep := range info.Deps {
+			if dep.Path == "sigs.k8s.io/controller-tools" {
+				return dep.Version
+			}
+		}
+	}
+	return "(unknown)"
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "crdgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dirs []string) error {
+	if len(dirs) == 0 {
+		entries, err := ioutil.ReadDir(".")
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if e.IsDir() && e.Name() != crdDir && e.Name() != "install" {
+				dirs = append(dirs, e.Name())
+			}
+		}
+	}
+	paths := []string{"."}
+	for _, d := range dirs {
+		paths = append(paths, "./"+d+"/.")
+	}
+
+	if err := os.MkdirAll(crdDir, 0755); err != nil {
+		return err
+	}
+	old, err := filepath.Glob(filepath.Join(crdDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	for _, f := range append(old, filepath.Join(crdDir, "zz_generated_crds.go")) {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	var gen genall.Generator = generator{crd.Generator{CRDVersions: []string{"v1"}}}
+	rt, err := genall.Generators{&gen}.ForRoots(paths...)
+	if err != nil {
+		return err
+	}
+	rt.OutputRules = genall.OutputRules{Default: manifestOutput{dir: crdDir, version: controllerToolsVersion()}}
+	if rt.Run() {
+		return fmt.Errorf("generation of CRDs failed")
+	}
+	return writeRegistration()
+}
+
+// writeRegistration generates the go code registering all CRD manifests.
+func writeRegistration() error {
+	boilerplate, err := findBoilerplate()
+	if err != nil {
+		return err
+	}
+	files, err := filepath.Glob(filepath.Join(crdDir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	buf := &bytes.Buffer{}
+	buf.Write(boilerplate)
+	buf.WriteString(`
+package crds
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+var registry = apiextensions.NewRegistry()
+
+func init() {
+	var data string
+`)
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(buf, "\tdata = `\n%s\n  `\n\tutils.Must(registry.RegisterCRD(data))\n",
+			strings.TrimSuffix(strings.ReplaceAll(string(data), "`", "`+\"`\"+`"), "\n"))
+	}
+	buf.WriteString(`}
+
+func AddToRegistry(r apiextensions.Registry) {
+	registry.AddToRegistry(r)
+}
+`)
+	return ioutil.WriteFile(filepath.Join(crdDir, "zz_generated_crds.go"), buf.Bytes(), 0644)
+}
+
+func findBoilerplate() ([]byte, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		data, err := ioutil.ReadFile(filepath.Join(dir, "hack", "LICENSE_BOILERPLATE.txt"))
+		if err == nil {
+			return data, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, fmt.Errorf("hack/LICENSE_BOILERPLATE.txt not found")
+		}
+		dir = parent
+	}
+}
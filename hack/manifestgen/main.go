@@ -0,0 +1,282 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// manifestgen renders the installation manifests of the DNS controller manager
+// from the CRDs generated for the Go API types (see hack/crdgen):
+//   - the multi-document manifests embedded by the package pkg/manifests
+//     (CRDs, RBAC, examples and a kustomization for them)
+//   - the CRD template of the helm chart
+//
+// It is run by go generate in the directory pkg/manifests after the CRDs
+// have been generated.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	crdDir      = "pkg/apis/dns/crds"
+	exampleDir  = "examples"
+	outputDir   = "pkg/manifests/generated"
+	chartCRDs   = "charts/external-dns-management/templates/crds-v1.yaml"
+	roleName    = "external-dns-management"
+	generatedBy = "# Code generated by hack/manifestgen. DO NOT EDIT.\n"
+)
+
+// examples not describing objects to deploy
+var skippedExamples = map[string]bool{
+	"10-crds.yaml":                 true,
+	"controller-registration.yaml": true,
+}
+
+const chartLabels = `  labels:
+    helm.sh/chart: {{ include "external-dns-management.chart" . }}
+    app.kubernetes.io/name: {{ include "external-dns-management.name" . }}
+    app.kubernetes.io/instance: {{ .Release.Name }}
+    app.kubernetes.io/managed-by: {{ .Release.Service }}
+`
+
+const kustomization = `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- crds.yaml
+- rbac.yaml
+`
+
+type crdManifest struct {
+	name string
+	data []byte
+	crd  *apiext.CustomResourceDefinition
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "manifestgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+	crds, err := readCRDs(filepath.Join(root, crdDir))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(root, outputDir), 0755); err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(generatedBy)
+	for _, m := range crds {
+		writeDocument(buf, m.data)
+	}
+	if err := writeFile(root, outputDir, "crds.yaml", buf.Bytes()); err != nil {
+		return err
+	}
+
+	rbac, err := renderRBAC(crds)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(root, outputDir, "rbac.yaml", rbac); err != nil {
+		return err
+	}
+
+	examples, err := renderExamples(filepath.Join(root, exampleDir))
+	if err != nil {
+		return err
+	}
+	if err := writeFile(root, outputDir, "examples.yaml", examples); err != nil {
+		return err
+	}
+	if err := writeFile(root, outputDir, "kustomization.yaml", []byte(generatedBy+kustomization)); err != nil {
+		return err
+	}
+	return writeFile(root, filepath.Dir(chartCRDs), filepath.Base(chartCRDs), renderChartCRDs(crds))
+}
+
+// findRoot looks up the module root starting at the current directory.
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("go.mod not found")
+		}
+		dir = parent
+	}
+}
+
+func readCRDs(dir string) ([]*crdManifest, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no CRDs found in %s (run go generate for the API packages first)", dir)
+	}
+	sort.Strings(files)
+	var result []*crdManifest
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		crd := &apiext.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, crd); err != nil {
+			return nil, fmt.Errorf("invalid CRD %s: %w", f, err)
+		}
+		result = append(result, &crdManifest{name: crd.Name, data: data, crd: crd})
+	}
+	return result, nil
+}
+
+func writeDocument(buf *bytes.Buffer, data []byte) {
+	data = bytes.TrimPrefix(data, []byte("---\n"))
+	buf.WriteString("---\n")
+	buf.Write(data)
+	if !bytes.HasSuffix(data, []byte("\n")) {
+		buf.WriteString("\n")
+	}
+}
+
+func writeFile(root, dir, name string, data []byte) error {
+	return ioutil.WriteFile(filepath.Join(root, dir, name), data, 0644)
+}
+
+// renderRBAC renders the roles required by the controllers. The rules for the
+// DNS API group are derived from the CRDs, the other rules correspond to the
+// roles of the helm chart.
+func renderRBAC(crds []*crdManifest) ([]byte, error) {
+	apiRule := rbacv1.PolicyRule{
+		Verbs: []string{"get", "list", "update", "watch", "create", "delete"},
+	}
+	groups := map[string]bool{}
+	for _, m := range crds {
+		if !groups[m.crd.Spec.Group] {
+			groups[m.crd.Spec.Group] = true
+			apiRule.APIGroups = append(apiRule.APIGroups, m.crd.Spec.Group)
+		}
+		plural := m.crd.Spec.Names.Plural
+		apiRule.Resources = append(apiRule.Resources, plural)
+		for _, v := range m.crd.Spec.Versions {
+			if v.Subresources != nil && v.Subresources.Status != nil {
+				apiRule.Resources = append(apiRule.Resources, plural+"/status")
+				break
+			}
+		}
+	}
+
+	watch := []string{"get", "list", "watch"}
+	modify := []string{"get", "list", "update", "watch"}
+	clusterRole := &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "ClusterRole"},
+		ObjectMeta: metav1.ObjectMeta{Name: roleName},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"services", "services/finalizers", "secrets"}, Verbs: modify},
+			{APIGroups: []string{""}, Resources: []string{"namespaces"}, Verbs: watch},
+			{APIGroups: []string{"extensions", "networking.k8s.io"}, Resources: []string{"ingresses"}, Verbs: modify},
+			{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"gateways"}, Verbs: modify},
+			{APIGroups: []string{"gateway.networking.k8s.io"}, Resources: []string{"httproutes"}, Verbs: watch},
+			{APIGroups: []string{"networking.istio.io"}, Resources: []string{"gateways"}, Verbs: modify},
+			{APIGroups: []string{"networking.istio.io"}, Resources: []string{"virtualservices"}, Verbs: watch},
+			apiRule,
+			{APIGroups: []string{""}, Resources: []string{"events"}, Verbs: []string{"create", "patch"}},
+			{APIGroups: []string{"apiextensions.k8s.io"}, Resources: []string{"customresourcedefinitions"}, Verbs: []string{"get", "list", "update", "create"}},
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, ResourceNames: []string{"cluster-identity"}, Verbs: []string{"get"}},
+		},
+	}
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacv1.SchemeGroupVersion.String(), Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Name: roleName},
+		Rules: []rbacv1.PolicyRule{
+			{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "create", "update"}},
+			{APIGroups: []string{"coordination.k8s.io"}, Resources: []string{"leases"}, Verbs: []string{"get", "create", "update", "watch"}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(generatedBy)
+	for _, obj := range []interface{}{clusterRole, role} {
+		data, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, err
+		}
+		writeDocument(buf, bytes.ReplaceAll(data, []byte("  creationTimestamp: null\n"), nil))
+	}
+	return buf.Bytes(), nil
+}
+
+func renderExamples(dir string) ([]byte, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	buf := &bytes.Buffer{}
+	buf.WriteString(generatedBy)
+	for _, f := range files {
+		if skippedExamples[filepath.Base(f)] {
+			continue
+		}
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		for _, doc := range strings.Split(string(data), "\n---\n") {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+			writeDocument(buf, []byte(fmt.Sprintf("# Source: %s/%s\n%s", exampleDir, filepath.Base(f), doc)))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// renderChartCRDs renders the CRD template of the helm chart adding the standard labels of the chart.
+func renderChartCRDs(crds []*crdManifest) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("{{- /* Code generated by hack/manifestgen. DO NOT EDIT. */ -}}\n")
+	buf.WriteString(`{{- if and (.Capabilities.APIVersions.Has "apiextensions.k8s.io/v1") .Values.createCRDs }}` + "\n")
+	for _, m := range crds {
+		name := fmt.Sprintf("\n  name: %s\n", m.name)
+		writeDocument(buf, bytes.Replace(m.data, []byte(name), []byte(name+chartLabels), 1))
+	}
+	buf.WriteString("{{- end }}\n")
+	return buf.Bytes()
+}
@@ -217,17 +217,169 @@ spec:
             type: object
           spec:
             properties:
+              caa:
+                description: certification authority authorization records (must be
+                  supported by the provider type)
+                items:
+                  properties:
+                    flags:
+                      description: flags of the record (128 marks the property as
+                        critical)
+                      maximum: 255
+                      minimum: 0
+                      type: integer
+                    tag:
+                      description: property tag (e.g. issue, issuewild, iodef)
+                      pattern: ^[A-Za-z0-9]{1,15}$
+                      type: string
+                    value:
+                      description: property value (e.g. letsencrypt.org for issue,
+                        mailto:security@example.com for iodef)
+                      type: string
+                  required:
+                  - tag
+                  - value
+                  type: object
+                type: array
               cnameLookupInterval:
                 description: lookup interval for CNAMEs that must be resolved to IP
                   addresses
                 format: int64
+                minimum: 0
                 type: integer
               dnsName:
                 description: full qualified domain name
+                maxLength: 254
+                pattern: ^(\*\.|\\052\.)?(_?[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?\.)*_?[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?\.?$
+                type: string
+              https:
+                description: service binding records for HTTPS (must be supported
+                  by the provider type)
+                items:
+                  properties:
+                    params:
+                      description: service parameters (not allowed in alias mode)
+                      properties:
+                        alpn:
+                          description: supported application layer protocol ids (e.g.
+                            h3, h2)
+                          items:
+                            type: string
+                          type: array
+                        ech:
+                          description: base64 encoded encrypted client hello config
+                            list
+                          type: string
+                        ipv4hint:
+                          description: IPv4 address hints
+                          items:
+                            type: string
+                          type: array
+                        ipv6hint:
+                          description: IPv6 address hints
+                          items:
+                            type: string
+                          type: array
+                        mandatory:
+                          description: keys of the parameters which are mandatory
+                            for the clients
+                          items:
+                            type: string
+                          type: array
+                        noDefaultALPN:
+                          description: if set, the default protocol is not supported
+                          type: boolean
+                        port:
+                          description: alternative port of the service
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                      type: object
+                    priority:
+                      description: priority of the record, 0 for alias mode
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    target:
+                      description: target name of the service, "." for the owner name
+                      type: string
+                  required:
+                  - priority
+                  type: object
+                  x-kubernetes-validations:
+                  - message: service parameters not allowed in alias mode (priority
+                      0)
+                    rule: self.priority != 0 || !has(self.params)
+                type: array
+              naptr:
+                description: naming authority pointer records (must be supported by
+                  the provider type)
+                items:
+                  properties:
+                    flags:
+                      description: flags controlling the rewriting and interpretation
+                        (e.g. U, S, A, P)
+                      type: string
+                    order:
+                      description: order in which the records must be processed
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    preference:
+                      description: preference of records with the same order
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    regexp:
+                      description: substitution expression applied to the original
+                        string
+                      type: string
+                    replacement:
+                      description: next domain name to query (empty if regexp is used)
+                      type: string
+                    service:
+                      description: service parameters (e.g. E2U+sip)
+                      type: string
+                  required:
+                  - order
+                  - preference
+                  type: object
+                type: array
+              ownerGroup:
+                description: team or group owning the entry, propagated into the meta
+                  data records and metrics
+                maxLength: 63
                 type: string
               ownerId:
                 description: owner id used to tag entries in external DNS system
                 type: string
+              providerHints:
+                description: optional provider type specific settings for the records
+                  (ignored by other provider types)
+                properties:
+                  cloudflare:
+                    description: settings for records managed by providers of type
+                      cloudflare-dns
+                    properties:
+                      proxied:
+                        description: enables the Cloudflare proxy (CDN) for A, AAAA
+                          and CNAME records
+                        type: boolean
+                    type: object
+                  ultradns:
+                    description: settings for records managed by providers of type
+                      ultradns
+                    properties:
+                      poolOrder:
+                        description: manages the records of A and AAAA record sets
+                          as resource distribution (RD) pool with the given order
+                        enum:
+                        - ROUND_ROBIN
+                        - FIXED
+                        - RANDOM
+                        type: string
+                    type: object
+                type: object
               reference:
                 description: reference to base entry used to inherit attributes from
                 properties:
@@ -240,6 +392,150 @@ spec:
                 required:
                 - name
                 type: object
+              routingPolicy:
+                description: optional routing policy for the records (must be supported
+                  by the provider type)
+                properties:
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: policy specific parameters
+                    type: object
+                  setIdentifier:
+                    description: identifier distinguishing record sets of multiple
+                      entries for the same DNS name (required for weighted, geolocation
+                      and failover routing)
+                    type: string
+                  type:
+                    description: routing policy type (e.g. multivalue, weighted, geolocation
+                      or failover)
+                    minLength: 1
+                    type: string
+                required:
+                - type
+                type: object
+              srv:
+                description: service records, the dns name must have the form _<service>._<proto>.<name>
+                  (must be supported by the provider type)
+                items:
+                  properties:
+                    port:
+                      description: port of the service on the target host
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    priority:
+                      description: priority of the target host, lower values are preferred
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    target:
+                      description: hostname of the target host, "." if the service
+                        is not available
+                      minLength: 1
+                      type: string
+                    weight:
+                      description: relative weight of targets with the same priority
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                  required:
+                  - port
+                  - priority
+                  - target
+                  - weight
+                  type: object
+                type: array
+              sshfp:
+                description: SSH fingerprint records (must be supported by the provider
+                  type)
+                items:
+                  properties:
+                    algorithm:
+                      description: public key algorithm (1=RSA, 2=DSA, 3=ECDSA, 4=Ed25519,
+                        6=Ed448)
+                      enum:
+                      - 1
+                      - 2
+                      - 3
+                      - 4
+                      - 6
+                      type: integer
+                    fingerprint:
+                      description: fingerprint as hexadecimal string
+                      pattern: ^[0-9A-Fa-f]+$
+                      type: string
+                    fingerprintType:
+                      description: fingerprint type (1=SHA-1, 2=SHA-256)
+                      enum:
+                      - 1
+                      - 2
+                      type: integer
+                  required:
+                  - algorithm
+                  - fingerprint
+                  - fingerprintType
+                  type: object
+                type: array
+              svcb:
+                description: general service binding records (must be supported by
+                  the provider type)
+                items:
+                  properties:
+                    params:
+                      description: service parameters (not allowed in alias mode)
+                      properties:
+                        alpn:
+                          description: supported application layer protocol ids (e.g.
+                            h3, h2)
+                          items:
+                            type: string
+                          type: array
+                        ech:
+                          description: base64 encoded encrypted client hello config
+                            list
+                          type: string
+                        ipv4hint:
+                          description: IPv4 address hints
+                          items:
+                            type: string
+                          type: array
+                        ipv6hint:
+                          description: IPv6 address hints
+                          items:
+                            type: string
+                          type: array
+                        mandatory:
+                          description: keys of the parameters which are mandatory
+                            for the clients
+                          items:
+                            type: string
+                          type: array
+                        noDefaultALPN:
+                          description: if set, the default protocol is not supported
+                          type: boolean
+                        port:
+                          description: alternative port of the service
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                      type: object
+                    priority:
+                      description: priority of the record, 0 for alias mode
+                      maximum: 65535
+                      minimum: 0
+                      type: integer
+                    target:
+                      description: target name of the service, "." for the owner name
+                      type: string
+                  required:
+                  - priority
+                  type: object
+                  x-kubernetes-validations:
+                  - message: service parameters not allowed in alias mode (priority
+                      0)
+                    rule: self.priority != 0 || !has(self.params)
+                type: array
               targets:
                 description: target records (CNAME or A records), either text or targets
                   must be specified
@@ -248,18 +544,742 @@ spec:
                 type: array
               text:
                 description: text records, either text or targets must be specified
+                  (handled like txt records with the given values)
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                maximum: 2147483647
+                minimum: 1
+                type: integer
+              txt:
+                description: text records with automatic splitting of long texts into
+                  character strings, either txt or targets must be specified
+                items:
+                  properties:
+                    value:
+                      description: text of the record, texts longer than 255 characters
+                        are split into multiple character strings
+                      maxLength: 4000
+                      minLength: 1
+                      type: string
+                  required:
+                  - value
+                  type: object
+                type: array
+            required:
+            - dnsName
+            type: object
+            x-kubernetes-validations:
+            - message: only text or targets possible
+              rule: '!has(self.targets) || size(self.targets) == 0 || ((!has(self.text)
+                || size(self.text) == 0) && (!has(self.txt) || size(self.txt) == 0))'
+          status:
+            properties:
+              conditions:
+                description: conditions of the entry
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+              errorHistory:
+                description: bounded history of the last errors (oldest first)
+                items:
+                  properties:
+                    message:
+                      description: error message
+                      type: string
+                    reason:
+                      description: reason of the error, if it is classified by the
+                        provider (Throttled, AuthFailed, NotFound, QuotaExceeded,
+                        InvalidRecord, Conflict)
+                      type: string
+                    state:
+                      description: state of the entry caused by the error
+                      type: string
+                    time:
+                      description: timestamp of the error
+                      format: date-time
+                      type: string
+                  required:
+                  - message
+                  - state
+                  - time
+                  type: object
+                type: array
+              flapCount:
+                description: number of target changes within the flap detection window
+                  at the last status update (only set if flap detection is enabled)
+                type: integer
+              lastChanges:
+                description: record set changes last applied for the entry with the
+                  previous and new values (old -> new)
+                items:
+                  type: string
+                type: array
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              nextAttemptTime:
+                description: time of the next attempt to apply the entry, if it is
+                  intentionally delayed by provider throttling or a zone backoff
+                format: date-time
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              plannedChanges:
+                description: changes planned for the entry, but not applied because
+                  of the dry-run mode, a read-only provider or a write freeze
+                items:
+                  type: string
+                type: array
+              provider:
+                description: assigned provider
+                type: string
+              providerType:
+                description: provider type used for the entry
+                type: string
+              queriesPerHour:
+                description: queriesPerHour is the rate of DNS queries for the DNS
+                  name reported by the provider (only set if query metrics are enabled)
+                format: int64
+                type: integer
+              state:
+                description: entry state
+                type: string
+              targets:
+                description: effective targets generated for the entry
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live used for the entry
+                format: int64
+                type: integer
+              zone:
+                description: zone used for the entry
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+  - additionalPrinterColumns:
+    - description: FQDN of DNS Entry
+      jsonPath: .spec.dnsName
+      name: DNS
+      type: string
+    - description: provider type
+      jsonPath: .status.providerType
+      name: TYPE
+      type: string
+    - description: assigned provider (namespace/name)
+      jsonPath: .status.provider
+      name: PROVIDER
+      type: string
+    - description: entry status
+      jsonPath: .status.state
+      name: STATUS
+      type: string
+    - description: entry creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: effective targets
+      jsonPath: .status.targets
+      name: TARGETS
+      type: string
+    - description: owner id used to tag entries in external DNS system
+      jsonPath: .spec.ownerId
+      name: OWNERID
+      type: string
+    - description: time to live
+      jsonPath: .status.ttl
+      name: TTL
+      priority: 2000
+      type: integer
+    - description: zone id
+      jsonPath: .status.zone
+      name: ZONE
+      priority: 2000
+      type: string
+    - description: message describing the reason for the state
+      jsonPath: .status.message
+      name: MESSAGE
+      priority: 2000
+      type: string
+    name: v1beta1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              cnameLookupInterval:
+                description: lookup interval for CNAMEs that must be resolved to IP
+                  addresses
+                format: int64
+                minimum: 0
+                type: integer
+              dnsName:
+                description: full qualified domain name
+                maxLength: 254
+                pattern: ^(\*\.|\\052\.)?(_?[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?\.)*_?[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?\.?$
+                type: string
+              ownerGroup:
+                description: team or group owning the entry, propagated into the meta
+                  data records and metrics
+                maxLength: 63
+                type: string
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              providerHints:
+                description: optional provider type specific settings for the records
+                  (ignored by other provider types)
+                properties:
+                  cloudflare:
+                    description: settings for records managed by providers of type
+                      cloudflare-dns
+                    properties:
+                      proxied:
+                        description: enables the Cloudflare proxy (CDN) for A, AAAA
+                          and CNAME records
+                        type: boolean
+                    type: object
+                  ultradns:
+                    description: settings for records managed by providers of type
+                      ultradns
+                    properties:
+                      poolOrder:
+                        description: manages the records of A and AAAA record sets
+                          as resource distribution (RD) pool with the given order
+                        enum:
+                        - ROUND_ROBIN
+                        - FIXED
+                        - RANDOM
+                        type: string
+                    type: object
+                type: object
+              records:
+                description: records of the other record types
+                properties:
+                  caa:
+                    description: certification authority authorization records (must
+                      be supported by the provider type)
+                    items:
+                      properties:
+                        flags:
+                          description: flags of the record (128 marks the property
+                            as critical)
+                          maximum: 255
+                          minimum: 0
+                          type: integer
+                        tag:
+                          description: property tag (e.g. issue, issuewild, iodef)
+                          pattern: ^[A-Za-z0-9]{1,15}$
+                          type: string
+                        value:
+                          description: property value (e.g. letsencrypt.org for issue,
+                            mailto:security@example.com for iodef)
+                          type: string
+                      required:
+                      - tag
+                      - value
+                      type: object
+                    type: array
+                  https:
+                    description: service binding records for HTTPS (must be supported
+                      by the provider type)
+                    items:
+                      properties:
+                        params:
+                          description: service parameters (not allowed in alias mode)
+                          properties:
+                            alpn:
+                              description: supported application layer protocol ids
+                                (e.g. h3, h2)
+                              items:
+                                type: string
+                              type: array
+                            ech:
+                              description: base64 encoded encrypted client hello config
+                                list
+                              type: string
+                            ipv4hint:
+                              description: IPv4 address hints
+                              items:
+                                type: string
+                              type: array
+                            ipv6hint:
+                              description: IPv6 address hints
+                              items:
+                                type: string
+                              type: array
+                            mandatory:
+                              description: keys of the parameters which are mandatory
+                                for the clients
+                              items:
+                                type: string
+                              type: array
+                            noDefaultALPN:
+                              description: if set, the default protocol is not supported
+                              type: boolean
+                            port:
+                              description: alternative port of the service
+                              maximum: 65535
+                              minimum: 0
+                              type: integer
+                          type: object
+                        priority:
+                          description: priority of the record, 0 for alias mode
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        target:
+                          description: target name of the service, "." for the owner
+                            name
+                          type: string
+                      required:
+                      - priority
+                      type: object
+                      x-kubernetes-validations:
+                      - message: service parameters not allowed in alias mode (priority
+                          0)
+                        rule: self.priority != 0 || !has(self.params)
+                    type: array
+                  naptr:
+                    description: naming authority pointer records (must be supported
+                      by the provider type)
+                    items:
+                      properties:
+                        flags:
+                          description: flags controlling the rewriting and interpretation
+                            (e.g. U, S, A, P)
+                          type: string
+                        order:
+                          description: order in which the records must be processed
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        preference:
+                          description: preference of records with the same order
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        regexp:
+                          description: substitution expression applied to the original
+                            string
+                          type: string
+                        replacement:
+                          description: next domain name to query (empty if regexp
+                            is used)
+                          type: string
+                        service:
+                          description: service parameters (e.g. E2U+sip)
+                          type: string
+                      required:
+                      - order
+                      - preference
+                      type: object
+                    type: array
+                  srv:
+                    description: service records, the dns name must have the form
+                      _<service>._<proto>.<name> (must be supported by the provider
+                      type)
+                    items:
+                      properties:
+                        port:
+                          description: port of the service on the target host
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        priority:
+                          description: priority of the target host, lower values are
+                            preferred
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        target:
+                          description: hostname of the target host, "." if the service
+                            is not available
+                          minLength: 1
+                          type: string
+                        weight:
+                          description: relative weight of targets with the same priority
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                      required:
+                      - port
+                      - priority
+                      - target
+                      - weight
+                      type: object
+                    type: array
+                  sshfp:
+                    description: SSH fingerprint records (must be supported by the
+                      provider type)
+                    items:
+                      properties:
+                        algorithm:
+                          description: public key algorithm (1=RSA, 2=DSA, 3=ECDSA,
+                            4=Ed25519, 6=Ed448)
+                          enum:
+                          - 1
+                          - 2
+                          - 3
+                          - 4
+                          - 6
+                          type: integer
+                        fingerprint:
+                          description: fingerprint as hexadecimal string
+                          pattern: ^[0-9A-Fa-f]+$
+                          type: string
+                        fingerprintType:
+                          description: fingerprint type (1=SHA-1, 2=SHA-256)
+                          enum:
+                          - 1
+                          - 2
+                          type: integer
+                      required:
+                      - algorithm
+                      - fingerprint
+                      - fingerprintType
+                      type: object
+                    type: array
+                  svcb:
+                    description: general service binding records (must be supported
+                      by the provider type)
+                    items:
+                      properties:
+                        params:
+                          description: service parameters (not allowed in alias mode)
+                          properties:
+                            alpn:
+                              description: supported application layer protocol ids
+                                (e.g. h3, h2)
+                              items:
+                                type: string
+                              type: array
+                            ech:
+                              description: base64 encoded encrypted client hello config
+                                list
+                              type: string
+                            ipv4hint:
+                              description: IPv4 address hints
+                              items:
+                                type: string
+                              type: array
+                            ipv6hint:
+                              description: IPv6 address hints
+                              items:
+                                type: string
+                              type: array
+                            mandatory:
+                              description: keys of the parameters which are mandatory
+                                for the clients
+                              items:
+                                type: string
+                              type: array
+                            noDefaultALPN:
+                              description: if set, the default protocol is not supported
+                              type: boolean
+                            port:
+                              description: alternative port of the service
+                              maximum: 65535
+                              minimum: 0
+                              type: integer
+                          type: object
+                        priority:
+                          description: priority of the record, 0 for alias mode
+                          maximum: 65535
+                          minimum: 0
+                          type: integer
+                        target:
+                          description: target name of the service, "." for the owner
+                            name
+                          type: string
+                      required:
+                      - priority
+                      type: object
+                      x-kubernetes-validations:
+                      - message: service parameters not allowed in alias mode (priority
+                          0)
+                        rule: self.priority != 0 || !has(self.params)
+                    type: array
+                  txt:
+                    description: texts of the TXT records, texts longer than 255 characters
+                      are split into multiple character strings
+                    items:
+                      type: string
+                    type: array
+                type: object
+              reference:
+                description: reference to base entry used to inherit attributes from
+                properties:
+                  name:
+                    description: name of the referenced DNSEntry object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSEntry object
+                    type: string
+                required:
+                - name
+                type: object
+              routingPolicy:
+                description: optional routing policy for the records (must be supported
+                  by the provider type)
+                properties:
+                  healthCheckID:
+                    description: id of the health check of a record set (failover
+                      routing)
+                    type: string
+                  location:
+                    description: location of a record set (geolocation routing)
+                    type: string
+                  parameters:
+                    additionalProperties:
+                      type: string
+                    description: additional provider specific parameters
+                    type: object
+                  role:
+                    description: role of a record set (failover routing, primary or
+                      secondary)
+                    type: string
+                  setIdentifier:
+                    description: identifier distinguishing record sets of multiple
+                      entries for the same DNS name (required for weighted, geolocation
+                      and failover routing)
+                    type: string
+                  targetHealthCheckIDs:
+                    additionalProperties:
+                      type: string
+                    description: ids of the health checks per target (multivalue routing)
+                    type: object
+                  type:
+                    description: routing policy type (e.g. multivalue, weighted, geolocation
+                      or failover)
+                    minLength: 1
+                    type: string
+                  weight:
+                    description: weight of a record set (weighted routing)
+                    format: int64
+                    minimum: 0
+                    type: integer
+                required:
+                - type
+                type: object
+              targets:
+                description: target records (CNAME or A records), either txt records
+                  or targets must be specified
+                items:
+                  type: string
+                type: array
+              ttl:
+                description: time to live for records in external DNS system
+                format: int64
+                maximum: 2147483647
+                minimum: 1
+                type: integer
+            required:
+            - dnsName
+            type: object
+            x-kubernetes-validations:
+            - message: only txt records or targets possible
+              rule: '!has(self.targets) || size(self.targets) == 0 || !has(self.records)
+                || !has(self.records.txt) || size(self.records.txt) == 0'
+          status:
+            properties:
+              conditions:
+                description: conditions of the entry
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+              errorHistory:
+                description: bounded history of the last errors (oldest first)
+                items:
+                  properties:
+                    message:
+                      description: error message
+                      type: string
+                    reason:
+                      description: reason of the error, if it is classified by the
+                        provider (Throttled, AuthFailed, NotFound, QuotaExceeded,
+                        InvalidRecord, Conflict)
+                      type: string
+                    state:
+                      description: state of the entry caused by the error
+                      type: string
+                    time:
+                      description: timestamp of the error
+                      format: date-time
+                      type: string
+                  required:
+                  - message
+                  - state
+                  - time
+                  type: object
+                type: array
+              flapCount:
+                description: number of target changes within the flap detection window
+                  at the last status update (only set if flap detection is enabled)
+                type: integer
+              lastChanges:
+                description: record set changes last applied for the entry with the
+                  previous and new values (old -> new)
                 items:
                   type: string
                 type: array
-              ttl:
-                description: time to live for records in external DNS system
-                format: int64
-                type: integer
-            required:
-            - dnsName
-            type: object
-          status:
-            properties:
               lastUpdateTime:
                 description: lastUpdateTime contains the timestamp of the last status
                   update
@@ -268,15 +1288,31 @@ spec:
               message:
                 description: message describing the reason for the state
                 type: string
+              nextAttemptTime:
+                description: time of the next attempt to apply the entry, if it is
+                  intentionally delayed by provider throttling or a zone backoff
+                format: date-time
+                type: string
               observedGeneration:
                 format: int64
                 type: integer
+              plannedChanges:
+                description: changes planned for the entry, but not applied because
+                  of the dry-run mode, a read-only provider or a write freeze
+                items:
+                  type: string
+                type: array
               provider:
                 description: assigned provider
                 type: string
               providerType:
                 description: provider type used for the entry
                 type: string
+              queriesPerHour:
+                description: queriesPerHour is the rate of DNS queries for the DNS
+                  name reported by the provider (only set if query metrics are enabled)
+                format: int64
+                type: integer
               state:
                 description: entry state
                 type: string
@@ -296,8 +1332,8 @@ spec:
         required:
         - spec
         type: object
-    served: true
-    storage: true
+    served: false
+    storage: false
     subresources:
       status: {}
 status:
@@ -545,10 +1581,114 @@ spec:
                   type: string
                 description: attribute values found in DNS
                 type: object
+              conditions:
+                description: conditions of the entry
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+              errorHistory:
+                description: bounded history of the last errors (oldest first)
+                items:
+                  properties:
+                    message:
+                      description: error message
+                      type: string
+                    reason:
+                      description: reason of the error, if it is classified by the
+                        provider (Throttled, AuthFailed, NotFound, QuotaExceeded,
+                        InvalidRecord, Conflict)
+                      type: string
+                    state:
+                      description: state of the entry caused by the error
+                      type: string
+                    time:
+                      description: timestamp of the error
+                      format: date-time
+                      type: string
+                  required:
+                  - message
+                  - state
+                  - time
+                  type: object
+                type: array
               firstFailedDNSLookup:
                 description: First failed DNS looup
                 format: date-time
                 type: string
+              flapCount:
+                description: number of target changes within the flap detection window
+                  at the last status update (only set if flap detection is enabled)
+                type: integer
+              lastChanges:
+                description: record set changes last applied for the entry with the
+                  previous and new values (old -> new)
+                items:
+                  type: string
+                type: array
               lastUpdateTime:
                 description: lastUpdateTime contains the timestamp of the last status
                   update
@@ -561,9 +1701,20 @@ spec:
               message:
                 description: message describing the reason for the state
                 type: string
+              nextAttemptTime:
+                description: time of the next attempt to apply the entry, if it is
+                  intentionally delayed by provider throttling or a zone backoff
+                format: date-time
+                type: string
               observedGeneration:
                 format: int64
                 type: integer
+              plannedChanges:
+                description: changes planned for the entry, but not applied because
+                  of the dry-run mode, a read-only provider or a write freeze
+                items:
+                  type: string
+                type: array
               provider:
                 description: assigned provider
                 type: string
@@ -677,34 +1828,609 @@ spec:
                 required:
                 - dnsName
                 type: object
-              ownerId:
-                description: owner id used to tag entries in external DNS system
-                type: string
-              validUntil:
-                description: optional time this owner should be active if active flag
-                  is not false
-                format: date-time
-                type: string
-            required:
-            - ownerId
+              ownerId:
+                description: owner id used to tag entries in external DNS system
+                type: string
+              validUntil:
+                description: optional time this owner should be active if active flag
+                  is not false
+                format: date-time
+                type: string
+            required:
+            - ownerId
+            type: object
+          status:
+            properties:
+              active:
+                description: state of the ownerid for the DNS controller observing
+                  entry using this owner id
+                type: boolean
+              entries:
+                description: Entry statistic for this owner id
+                properties:
+                  amount:
+                    description: number of entries using this owner id
+                    type: integer
+                  types:
+                    additionalProperties:
+                      type: integer
+                    description: number of entries per provider type
+                    type: object
+                type: object
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.8.0
+  creationTimestamp: null
+  name: dnsproviders.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSProvider
+    listKind: DNSProviderList
+    plural: dnsproviders
+    shortNames:
+    - dnspr
+    singular: dnsprovider
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.type
+      name: TYPE
+      type: string
+    - jsonPath: .status.state
+      name: STATUS
+      type: string
+    - description: creation timestamp
+      jsonPath: .metadata.creationTimestamp
+      name: AGE
+      type: date
+    - description: included domains
+      jsonPath: .status.domains.included
+      name: INCLUDED_DOMAINS
+      type: string
+    - description: provider mode
+      jsonPath: .spec.mode
+      name: MODE
+      priority: 2000
+      type: string
+    - description: included zones
+      jsonPath: .status.zones.included
+      name: INCLUDED_ZONES
+      priority: 2000
+      type: string
+    - description: message describing the reason for the state
+      jsonPath: .status.message
+      name: MESSAGE
+      priority: 2000
+      type: string
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation
+              of an object. Servers should convert recognized schemas to the latest
+              internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this
+              object represents. Servers may infer this from the endpoint the client
+              submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              defaultForNamespaces:
+                description: selector for namespaces whose DNS entries should preferably
+                  be assigned to this provider if several providers are matching the
+                  DNS name with the same domain (providers with a longer matching
+                  domain are still preferred)
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements.
+                      The requirements are ANDed.
+                    items:
+                      description: A label selector requirement is a selector that
+                        contains values, a key, and an operator that relates the key
+                        and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies
+                            to.
+                          type: string
+                        operator:
+                          description: operator represents a key's relationship to
+                            a set of values. Valid operators are In, NotIn, Exists
+                            and DoesNotExist.
+                          type: string
+                        values:
+                          description: values is an array of string values. If the
+                            operator is In or NotIn, the values array must be non-empty.
+                            If the operator is Exists or DoesNotExist, the values
+                            array must be empty. This array is replaced during a strategic
+                            merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: matchLabels is a map of {key,value} pairs. A single
+                      {key,value} in the matchLabels map is equivalent to an element
+                      of matchExpressions, whose key field is "key", the operator
+                      is "In", and the values array contains only "value". The requirements
+                      are ANDed.
+                    type: object
+                type: object
+              defaultTTL:
+                description: default TTL used for DNS entries if not specified explicitly
+                format: int64
+                type: integer
+              dnssec:
+                description: DNSSEC signing of the served zones (only supported by
+                  some provider types)
+                properties:
+                  enabled:
+                    description: enables DNSSEC signing for all served zones
+                    type: boolean
+                required:
+                - enabled
+                type: object
+              domains:
+                description: desired selection of usable domains (by default all zones
+                  and domains in those zones will be served)
+                properties:
+                  exclude:
+                    description: values that should be ignored (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  include:
+                    description: values that should be observed (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              mode:
+                description: 'mode of the provider, in mode ReadOnly zones and records
+                  are read, but no changes are applied, in mode DryRun changes are
+                  planned and reported in the entry status, but not applied (default:
+                  ReadWrite)'
+                enum:
+                - ReadWrite
+                - ReadOnly
+                - DryRun
+                type: string
+              providerConfig:
+                description: optional additional provider specific configuration values
+                type: object
+                x-kubernetes-preserve-unknown-fields: true
+              rateLimit:
+                description: rate limit for create/update operations on DNSEntries
+                  assigned to this provider
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' to exceed the
+                      rate defined by 'RequestsPerDay', while still maintaining a
+                      smoothed rate of 'RequestsPerDay'
+                    type: integer
+                  requestsPerDay:
+                    description: RequestsPerDay is create/update request rate per
+                      DNS entry given by requests per day
+                    type: integer
+                required:
+                - burst
+                - requestsPerDay
+                type: object
+              secretRef:
+                description: access credential for the external DNS system of the
+                  given type
+                properties:
+                  name:
+                    description: Name is unique within a namespace to reference a
+                      secret resource.
+                    type: string
+                  namespace:
+                    description: Namespace defines the space within which the secret
+                      name must be unique.
+                    type: string
+                type: object
+              secretRefs:
+                description: additional access credentials used in the given order
+                  if the credentials of the active secret are failing with authentication
+                  errors (e.g. for key rotations without downtime)
+                items:
+                  description: SecretReference represents a Secret Reference. It has
+                    enough information to retrieve secret in any namespace
+                  properties:
+                    name:
+                      description: Name is unique within a namespace to reference
+                        a secret resource.
+                      type: string
+                    namespace:
+                      description: Namespace defines the space within which the secret
+                        name must be unique.
+                      type: string
+                  type: object
+                type: array
+              type:
+                description: type of the provider (selecting the responsible type
+                  of DNS controller)
+                type: string
+              writePermissionCheck:
+                description: periodic verification of the write permission for the
+                  served zones by a policy simulation (only supported by some provider
+                  types)
+                properties:
+                  enabled:
+                    description: enables the verification of the write permission
+                      for all served zones
+                    type: boolean
+                required:
+                - enabled
+                type: object
+              zoneRateLimit:
+                description: rate limit for the provider API requests (zone state
+                  reads and change requests) per hosted zone
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' requests to
+                      exceed the rate (default 1)
+                    type: integer
+                  interval:
+                    description: Interval is the interval of the allowed requests
+                      (default 1s)
+                    type: string
+                  requests:
+                    description: Requests is the number of provider API requests per
+                      hosted zone allowed per interval
+                    minimum: 1
+                    type: integer
+                required:
+                - requests
+                type: object
+              zones:
+                description: desired selection of usable domains the domain selection
+                  is used for served zones, only (by default all zones will be served)
+                properties:
+                  exclude:
+                    description: values that should be ignored (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  include:
+                    description: values that should be observed (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
             type: object
           status:
             properties:
-              active:
-                description: state of the ownerid for the DNS controller observing
-                  entry using this owner id
-                type: boolean
-              entries:
-                description: Entry statistic for this owner id
+              activeSecretRef:
+                description: secret of the actually used access credentials (only
+                  set if additional secrets are specified)
                 properties:
-                  amount:
-                    description: number of entries using this owner id
-                    type: integer
-                  types:
-                    additionalProperties:
+                  name:
+                    description: Name is unique within a namespace to reference a
+                      secret resource.
+                    type: string
+                  namespace:
+                    description: Namespace defines the space within which the secret
+                      name must be unique.
+                    type: string
+                type: object
+              conditions:
+                description: conditions of the provider
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
                       type: integer
-                    description: number of entries per provider type
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+              defaultTTL:
+                description: actually used default TTL for DNS entries
+                format: int64
+                type: integer
+              dnssec:
+                description: DNSSEC state of the served zones (only set if DNSSEC
+                  is enabled)
+                items:
+                  properties:
+                    chainOfTrust:
+                      description: true if one of the DS records is published in the
+                        parent zone
+                      type: boolean
+                    domain:
+                      description: domain of the hosted zone
+                      type: string
+                    dsRecords:
+                      description: DS records to be published in the parent zone
+                      items:
+                        type: string
+                      type: array
+                    message:
+                      description: message describing the DNSSEC state
+                      type: string
+                    state:
+                      description: DNSSEC signing state of the zone (Signing, Pending,
+                        NotSigning or Error)
+                      type: string
+                    zoneID:
+                      description: id of the hosted zone
+                      type: string
+                  required:
+                  - domain
+                  - state
+                  - zoneID
+                  type: object
+                type: array
+              domains:
+                description: actually served domain selection
+                properties:
+                  excluded:
+                    description: Excluded values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  included:
+                    description: included values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                type: object
+              lastUpdateTime:
+                description: lastUpdateTime contains the timestamp of the last status
+                  update
+                format: date-time
+                type: string
+              message:
+                description: message describing the reason for the actual state of
+                  the provider
+                type: string
+              observedGeneration:
+                format: int64
+                type: integer
+              onboarding:
+                description: report about the setup of the provider when it has become
+                  ready for the first time
+                properties:
+                  domains:
+                    description: included and excluded domains
+                    items:
+                      properties:
+                        domain:
+                          description: included or excluded domain
+                          type: string
+                        included:
+                          description: whether the domain is served by the provider
+                          type: boolean
+                        reason:
+                          description: reason for the selection of the domain
+                          type: string
+                      required:
+                      - domain
+                      - included
+                      - reason
+                      type: object
+                    type: array
+                  rateLimit:
+                    description: rate limit for create/update operations on DNSEntries
+                      in effect
+                    properties:
+                      burst:
+                        description: Burst allows bursts of up to 'burst' to exceed
+                          the rate defined by 'RequestsPerDay', while still maintaining
+                          a smoothed rate of 'RequestsPerDay'
+                        type: integer
+                      requestsPerDay:
+                        description: RequestsPerDay is create/update request rate
+                          per DNS entry given by requests per day
+                        type: integer
+                    required:
+                    - burst
+                    - requestsPerDay
+                    type: object
+                  time:
+                    description: time of the report
+                    format: date-time
+                    type: string
+                  warnings:
+                    description: warnings about the selection and about features not
+                      supported by the provider type
+                    items:
+                      type: string
+                    type: array
+                  zoneRateLimit:
+                    description: rate limit for the provider API requests per hosted
+                      zone in effect
+                    properties:
+                      burst:
+                        description: Burst allows bursts of up to 'burst' requests
+                          to exceed the rate (default 1)
+                        type: integer
+                      interval:
+                        description: Interval is the interval of the allowed requests
+                          (default 1s)
+                        type: string
+                      requests:
+                        description: Requests is the number of provider API requests
+                          per hosted zone allowed per interval
+                        minimum: 1
+                        type: integer
+                    required:
+                    - requests
                     type: object
+                  zones:
+                    description: hosted zones of the provider type found in the account
+                    items:
+                      properties:
+                        domain:
+                          description: domain of the hosted zone
+                          type: string
+                        included:
+                          description: whether the zone is served by the provider
+                          type: boolean
+                        reason:
+                          description: reason for the selection of the zone
+                          type: string
+                        zoneID:
+                          description: id of the hosted zone
+                          type: string
+                      required:
+                      - domain
+                      - included
+                      - reason
+                      - zoneID
+                      type: object
+                    type: array
+                required:
+                - time
+                type: object
+              rateLimit:
+                description: actually used rate limit for create/update operations
+                  on DNSEntries assigned to this provider
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' to exceed the
+                      rate defined by 'RequestsPerDay', while still maintaining a
+                      smoothed rate of 'RequestsPerDay'
+                    type: integer
+                  requestsPerDay:
+                    description: RequestsPerDay is create/update request rate per
+                      DNS entry given by requests per day
+                    type: integer
+                required:
+                - burst
+                - requestsPerDay
+                type: object
+              state:
+                description: state of the provider
+                type: string
+              writePermissions:
+                description: write permission for the served zones (only set if the
+                  write permission check is enabled)
+                items:
+                  properties:
+                    domain:
+                      description: domain of the hosted zone
+                      type: string
+                    message:
+                      description: message describing the write permission state
+                      type: string
+                    state:
+                      description: write permission state of the zone (Granted, Denied
+                        or Unknown)
+                      type: string
+                    zoneID:
+                      description: id of the hosted zone
+                      type: string
+                  required:
+                  - domain
+                  - state
+                  - zoneID
+                  type: object
+                type: array
+              zones:
+                description: actually served zones
+                properties:
+                  excluded:
+                    description: Excluded values (domains or zones)
+                    items:
+                      type: string
+                    type: array
+                  included:
+                    description: included values (domains or zones)
+                    items:
+                      type: string
+                    type: array
                 type: object
             type: object
         required:
@@ -714,34 +2440,6 @@ spec:
     storage: true
     subresources:
       status: {}
-status:
-  acceptedNames:
-    kind: ""
-    plural: ""
-  conditions: []
-  storedVersions: []
-  `
-	utils.Must(registry.RegisterCRD(data))
-	data = `
----
-apiVersion: apiextensions.k8s.io/v1
-kind: CustomResourceDefinition
-metadata:
-  annotations:
-    controller-gen.kubebuilder.io/version: v0.8.0
-  creationTimestamp: null
-  name: dnsproviders.dns.gardener.cloud
-spec:
-  group: dns.gardener.cloud
-  names:
-    kind: DNSProvider
-    listKind: DNSProviderList
-    plural: dnsproviders
-    shortNames:
-    - dnspr
-    singular: dnsprovider
-  scope: Namespaced
-  versions:
   - additionalPrinterColumns:
     - jsonPath: .spec.type
       name: TYPE
@@ -757,6 +2455,11 @@ spec:
       jsonPath: .status.domains.included
       name: INCLUDED_DOMAINS
       type: string
+    - description: provider mode
+      jsonPath: .spec.mode
+      name: MODE
+      priority: 2000
+      type: string
     - description: included zones
       jsonPath: .status.zones.included
       name: INCLUDED_ZONES
@@ -767,9 +2470,10 @@ spec:
       name: MESSAGE
       priority: 2000
       type: string
-    name: v1alpha1
+    name: v1beta1
     schema:
       openAPIV3Schema:
+        description: DNSProvider has the same layout as in version v1alpha1.
         properties:
           apiVersion:
             description: 'APIVersion defines the versioned schema of this representation
@@ -785,10 +2489,67 @@ spec:
             type: object
           spec:
             properties:
+              defaultForNamespaces:
+                description: selector for namespaces whose DNS entries should preferably
+                  be assigned to this provider if several providers are matching the
+                  DNS name with the same domain (providers with a longer matching
+                  domain are still preferred)
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements.
+                      The requirements are ANDed.
+                    items:
+                      description: A label selector requirement is a selector that
+                        contains values, a key, and an operator that relates the key
+                        and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies
+                            to.
+                          type: string
+                        operator:
+                          description: operator represents a key's relationship to
+                            a set of values. Valid operators are In, NotIn, Exists
+                            and DoesNotExist.
+                          type: string
+                        values:
+                          description: values is an array of string values. If the
+                            operator is In or NotIn, the values array must be non-empty.
+                            If the operator is Exists or DoesNotExist, the values
+                            array must be empty. This array is replaced during a strategic
+                            merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: matchLabels is a map of {key,value} pairs. A single
+                      {key,value} in the matchLabels map is equivalent to an element
+                      of matchExpressions, whose key field is "key", the operator
+                      is "In", and the values array contains only "value". The requirements
+                      are ANDed.
+                    type: object
+                type: object
               defaultTTL:
                 description: default TTL used for DNS entries if not specified explicitly
                 format: int64
                 type: integer
+              dnssec:
+                description: DNSSEC signing of the served zones (only supported by
+                  some provider types)
+                properties:
+                  enabled:
+                    description: enables DNSSEC signing for all served zones
+                    type: boolean
+                required:
+                - enabled
+                type: object
               domains:
                 description: desired selection of usable domains (by default all zones
                   and domains in those zones will be served)
@@ -804,6 +2565,16 @@ spec:
                       type: string
                     type: array
                 type: object
+              mode:
+                description: 'mode of the provider, in mode ReadOnly zones and records
+                  are read, but no changes are applied, in mode DryRun changes are
+                  planned and reported in the entry status, but not applied (default:
+                  ReadWrite)'
+                enum:
+                - ReadWrite
+                - ReadOnly
+                - DryRun
+                type: string
               providerConfig:
                 description: optional additional provider specific configuration values
                 type: object
@@ -838,10 +2609,60 @@ spec:
                       name must be unique.
                     type: string
                 type: object
+              secretRefs:
+                description: additional access credentials used in the given order
+                  if the credentials of the active secret are failing with authentication
+                  errors (e.g. for key rotations without downtime)
+                items:
+                  description: SecretReference represents a Secret Reference. It has
+                    enough information to retrieve secret in any namespace
+                  properties:
+                    name:
+                      description: Name is unique within a namespace to reference
+                        a secret resource.
+                      type: string
+                    namespace:
+                      description: Namespace defines the space within which the secret
+                        name must be unique.
+                      type: string
+                  type: object
+                type: array
               type:
                 description: type of the provider (selecting the responsible type
                   of DNS controller)
                 type: string
+              writePermissionCheck:
+                description: periodic verification of the write permission for the
+                  served zones by a policy simulation (only supported by some provider
+                  types)
+                properties:
+                  enabled:
+                    description: enables the verification of the write permission
+                      for all served zones
+                    type: boolean
+                required:
+                - enabled
+                type: object
+              zoneRateLimit:
+                description: rate limit for the provider API requests (zone state
+                  reads and change requests) per hosted zone
+                properties:
+                  burst:
+                    description: Burst allows bursts of up to 'burst' requests to
+                      exceed the rate (default 1)
+                    type: integer
+                  interval:
+                    description: Interval is the interval of the allowed requests
+                      (default 1s)
+                    type: string
+                  requests:
+                    description: Requests is the number of provider API requests per
+                      hosted zone allowed per interval
+                    minimum: 1
+                    type: integer
+                required:
+                - requests
+                type: object
               zones:
                 description: desired selection of usable domains the domain selection
                   is used for served zones, only (by default all zones will be served)
@@ -860,10 +2681,125 @@ spec:
             type: object
           status:
             properties:
+              activeSecretRef:
+                description: secret of the actually used access credentials (only
+                  set if additional secrets are specified)
+                properties:
+                  name:
+                    description: Name is unique within a namespace to reference a
+                      secret resource.
+                    type: string
+                  namespace:
+                    description: Namespace defines the space within which the secret
+                      name must be unique.
+                    type: string
+                type: object
+              conditions:
+                description: conditions of the provider
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource. --- This struct is intended for direct
+                    use as an array at the field path .status.conditions.  For example,
+                    type FooStatus struct{ // Represents the observations of a foo's
+                    current state. // Known .status.conditions.type are: \"Available\",
+                    \"Progressing\", and \"Degraded\" // +patchMergeKey=type // +patchStrategy=merge
+                    // +listType=map // +listMapKey=type Conditions []metav1.Condition
+                    `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\"
+                    protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition
+                        transitioned from one status to another. This should be when
+                        the underlying condition changed.  If that is not known, then
+                        using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating
+                        details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation
+                        that the condition was set based upon. For instance, if .metadata.generation
+                        is currently 12, but the .status.conditions[x].observedGeneration
+                        is 9, the condition is out of date with respect to the current
+                        state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating
+                        the reason for the condition's last transition. Producers
+                        of specific condition types may define expected values and
+                        meanings for this field, and whether the values are considered
+                        a guaranteed API. The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase.
+                        --- Many .condition.type values are consistent across resources
+                        like Available, but because arbitrary conditions can be useful
+                        (see .node.status.conditions), the ability to deconflict is
+                        important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
               defaultTTL:
                 description: actually used default TTL for DNS entries
                 format: int64
                 type: integer
+              dnssec:
+                description: DNSSEC state of the served zones (only set if DNSSEC
+                  is enabled)
+                items:
+                  properties:
+                    chainOfTrust:
+                      description: true if one of the DS records is published in the
+                        parent zone
+                      type: boolean
+                    domain:
+                      description: domain of the hosted zone
+                      type: string
+                    dsRecords:
+                      description: DS records to be published in the parent zone
+                      items:
+                        type: string
+                      type: array
+                    message:
+                      description: message describing the DNSSEC state
+                      type: string
+                    state:
+                      description: DNSSEC signing state of the zone (Signing, Pending,
+                        NotSigning or Error)
+                      type: string
+                    zoneID:
+                      description: id of the hosted zone
+                      type: string
+                  required:
+                  - domain
+                  - state
+                  - zoneID
+                  type: object
+                type: array
               domains:
                 description: actually served domain selection
                 properties:
@@ -890,6 +2826,102 @@ spec:
               observedGeneration:
                 format: int64
                 type: integer
+              onboarding:
+                description: report about the setup of the provider when it has become
+                  ready for the first time
+                properties:
+                  domains:
+                    description: included and excluded domains
+                    items:
+                      properties:
+                        domain:
+                          description: included or excluded domain
+                          type: string
+                        included:
+                          description: whether the domain is served by the provider
+                          type: boolean
+                        reason:
+                          description: reason for the selection of the domain
+                          type: string
+                      required:
+                      - domain
+                      - included
+                      - reason
+                      type: object
+                    type: array
+                  rateLimit:
+                    description: rate limit for create/update operations on DNSEntries
+                      in effect
+                    properties:
+                      burst:
+                        description: Burst allows bursts of up to 'burst' to exceed
+                          the rate defined by 'RequestsPerDay', while still maintaining
+                          a smoothed rate of 'RequestsPerDay'
+                        type: integer
+                      requestsPerDay:
+                        description: RequestsPerDay is create/update request rate
+                          per DNS entry given by requests per day
+                        type: integer
+                    required:
+                    - burst
+                    - requestsPerDay
+                    type: object
+                  time:
+                    description: time of the report
+                    format: date-time
+                    type: string
+                  warnings:
+                    description: warnings about the selection and about features not
+                      supported by the provider type
+                    items:
+                      type: string
+                    type: array
+                  zoneRateLimit:
+                    description: rate limit for the provider API requests per hosted
+                      zone in effect
+                    properties:
+                      burst:
+                        description: Burst allows bursts of up to 'burst' requests
+                          to exceed the rate (default 1)
+                        type: integer
+                      interval:
+                        description: Interval is the interval of the allowed requests
+                          (default 1s)
+                        type: string
+                      requests:
+                        description: Requests is the number of provider API requests
+                          per hosted zone allowed per interval
+                        minimum: 1
+                        type: integer
+                    required:
+                    - requests
+                    type: object
+                  zones:
+                    description: hosted zones of the provider type found in the account
+                    items:
+                      properties:
+                        domain:
+                          description: domain of the hosted zone
+                          type: string
+                        included:
+                          description: whether the zone is served by the provider
+                          type: boolean
+                        reason:
+                          description: reason for the selection of the zone
+                          type: string
+                        zoneID:
+                          description: id of the hosted zone
+                          type: string
+                      required:
+                      - domain
+                      - included
+                      - reason
+                      - zoneID
+                      type: object
+                    type: array
+                required:
+                - time
+                type: object
               rateLimit:
                 description: actually used rate limit for create/update operations
                   on DNSEntries assigned to this provider
@@ -910,6 +2942,30 @@ spec:
               state:
                 description: state of the provider
                 type: string
+              writePermissions:
+                description: write permission for the served zones (only set if the
+                  write permission check is enabled)
+                items:
+                  properties:
+                    domain:
+                      description: domain of the hosted zone
+                      type: string
+                    message:
+                      description: message describing the write permission state
+                      type: string
+                    state:
+                      description: write permission state of the zone (Granted, Denied
+                        or Unknown)
+                      type: string
+                    zoneID:
+                      description: id of the hosted zone
+                      type: string
+                  required:
+                  - domain
+                  - state
+                  - zoneID
+                  type: object
+                type: array
               zones:
                 description: actually served zones
                 properties:
@@ -928,8 +2984,8 @@ spec:
         required:
         - spec
         type: object
-    served: true
-    storage: true
+    served: false
+    storage: false
     subresources:
       status: {}
 status:
@@ -14,7 +14,7 @@
  *
  */
 
-//go:generate bash ../../../vendor/github.com/gardener/controller-manager-library/hack/generate-crds
+//go:generate go run ../../../hack/crdgen
 //go:generate bash ../../../hack/generate-code
 // +kubebuilder:skip
 
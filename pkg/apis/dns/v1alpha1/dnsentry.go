@@ -55,8 +55,12 @@ type DNSEntry struct {
 	Status DNSEntryStatus `json:"status,omitempty"`
 }
 
+// +kubebuilder:validation:XValidation:rule="!has(self.targets) || size(self.targets) == 0 || ((!has(self.text) || size(self.text) == 0) && (!has(self.txt) || size(self.txt) == 0))",message="only text or targets possible"
+
 type DNSEntrySpec struct {
 	// full qualified domain name
+	// +kubebuilder:validation:MaxLength=254
+	// +kubebuilder:validation:Pattern="^(\\*\\.|\\\\052\\.)?(_?[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?\\.)*_?[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?\\.?$"
 	DNSName string `json:"dnsName"`
 	// reference to base entry used to inherit attributes from
 	// +optional
@@ -64,18 +68,200 @@ type DNSEntrySpec struct {
 	// owner id used to tag entries in external DNS system
 	// +optional
 	OwnerId *string `json:"ownerId,omitempty"`
+	// team or group owning the entry, propagated into the meta data records and metrics
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	OwnerGroup *string `json:"ownerGroup,omitempty"`
 	// time to live for records in external DNS system
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
 	// +optional
 	TTL *int64 `json:"ttl,omitempty"`
 	// lookup interval for CNAMEs that must be resolved to IP addresses
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
-	// text records, either text or targets must be specified
+	// text records, either text or targets must be specified (handled like txt records with the given values)
 	// +optional
 	Text []string `json:"text,omitempty"`
+	// text records with automatic splitting of long texts into character strings, either txt or targets must be specified
+	// +optional
+	TXT []TXTRecord `json:"txt,omitempty"`
 	// target records (CNAME or A records), either text or targets must be specified
 	// +optional
 	Targets []string `json:"targets,omitempty"`
+	// optional routing policy for the records (must be supported by the provider type)
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// optional provider type specific settings for the records (ignored by other provider types)
+	// +optional
+	ProviderHints *ProviderHints `json:"providerHints,omitempty"`
+	// service records, the dns name must have the form _<service>._<proto>.<name> (must be supported by the provider type)
+	// +optional
+	SRV []SRVRecord `json:"srv,omitempty"`
+	// SSH fingerprint records (must be supported by the provider type)
+	// +optional
+	SSHFP []SSHFPRecord `json:"sshfp,omitempty"`
+	// naming authority pointer records (must be supported by the provider type)
+	// +optional
+	NAPTR []NAPTRRecord `json:"naptr,omitempty"`
+	// general service binding records (must be supported by the provider type)
+	// +optional
+	SVCB []SVCBRecord `json:"svcb,omitempty"`
+	// service binding records for HTTPS (must be supported by the provider type)
+	// +optional
+	HTTPS []SVCBRecord `json:"https,omitempty"`
+	// certification authority authorization records (must be supported by the provider type)
+	// +optional
+	CAA []CAARecord `json:"caa,omitempty"`
+}
+
+type TXTRecord struct {
+	// text of the record, texts longer than 255 characters are split into multiple character strings
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4000
+	Value string `json:"value"`
+}
+
+type SRVRecord struct {
+	// priority of the target host, lower values are preferred
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Priority int `json:"priority"`
+	// relative weight of targets with the same priority
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Weight int `json:"weight"`
+	// port of the service on the target host
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Port int `json:"port"`
+	// hostname of the target host, "." if the service is not available
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+}
+
+type SSHFPRecord struct {
+	// public key algorithm (1=RSA, 2=DSA, 3=ECDSA, 4=Ed25519, 6=Ed448)
+	// +kubebuilder:validation:Enum=1;2;3;4;6
+	Algorithm int `json:"algorithm"`
+	// fingerprint type (1=SHA-1, 2=SHA-256)
+	// +kubebuilder:validation:Enum=1;2
+	FingerprintType int `json:"fingerprintType"`
+	// fingerprint as hexadecimal string
+	// +kubebuilder:validation:Pattern="^[0-9A-Fa-f]+$"
+	Fingerprint string `json:"fingerprint"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self.priority != 0 || !has(self.params)",message="service parameters not allowed in alias mode (priority 0)"
+
+type SVCBRecord struct {
+	// priority of the record, 0 for alias mode
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Priority int `json:"priority"`
+	// target name of the service, "." for the owner name
+	// +optional
+	Target string `json:"target,omitempty"`
+	// service parameters (not allowed in alias mode)
+	// +optional
+	Params *SVCBParams `json:"params,omitempty"`
+}
+
+type SVCBParams struct {
+	// keys of the parameters which are mandatory for the clients
+	// +optional
+	Mandatory []string `json:"mandatory,omitempty"`
+	// supported application layer protocol ids (e.g. h3, h2)
+	// +optional
+	ALPN []string `json:"alpn,omitempty"`
+	// if set, the default protocol is not supported
+	// +optional
+	NoDefaultALPN bool `json:"noDefaultALPN,omitempty"`
+	// alternative port of the service
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int `json:"port,omitempty"`
+	// IPv4 address hints
+	// +optional
+	IPv4Hint []string `json:"ipv4hint,omitempty"`
+	// base64 encoded encrypted client hello config list
+	// +optional
+	ECH string `json:"ech,omitempty"`
+	// IPv6 address hints
+	// +optional
+	IPv6Hint []string `json:"ipv6hint,omitempty"`
+}
+
+type NAPTRRecord struct {
+	// order in which the records must be processed
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Order int `json:"order"`
+	// preference of records with the same order
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	Preference int `json:"preference"`
+	// flags controlling the rewriting and interpretation (e.g. U, S, A, P)
+	// +optional
+	Flags string `json:"flags,omitempty"`
+	// service parameters (e.g. E2U+sip)
+	// +optional
+	Service string `json:"service,omitempty"`
+	// substitution expression applied to the original string
+	// +optional
+	Regexp string `json:"regexp,omitempty"`
+	// next domain name to query (empty if regexp is used)
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
+}
+
+type CAARecord struct {
+	// flags of the record (128 marks the property as critical)
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=255
+	// +optional
+	Flags int `json:"flags,omitempty"`
+	// property tag (e.g. issue, issuewild, iodef)
+	// +kubebuilder:validation:Pattern="^[A-Za-z0-9]{1,15}$"
+	Tag string `json:"tag"`
+	// property value (e.g. letsencrypt.org for issue, mailto:security@example.com for iodef)
+	Value string `json:"value"`
+}
+
+type RoutingPolicy struct {
+	// routing policy type (e.g. multivalue, weighted, geolocation or failover)
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+	// identifier distinguishing record sets of multiple entries for the same DNS name (required for weighted, geolocation and failover routing)
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// policy specific parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+type ProviderHints struct {
+	// settings for records managed by providers of type cloudflare-dns
+	// +optional
+	Cloudflare *CloudflareProviderHints `json:"cloudflare,omitempty"`
+	// settings for records managed by providers of type ultradns
+	// +optional
+	UltraDNS *UltraDNSProviderHints `json:"ultradns,omitempty"`
+}
+
+type CloudflareProviderHints struct {
+	// enables the Cloudflare proxy (CDN) for A, AAAA and CNAME records
+	// +optional
+	Proxied *bool `json:"proxied,omitempty"`
+}
+
+type UltraDNSProviderHints struct {
+	// manages the records of A and AAAA record sets as resource distribution (RD) pool with the given order
+	// +kubebuilder:validation:Enum=ROUND_ROBIN;FIXED;RANDOM
+	// +optional
+	PoolOrder *string `json:"poolOrder,omitempty"`
 }
 
 type DNSEntryStatus struct {
@@ -83,6 +269,9 @@ type DNSEntryStatus struct {
 	// effective targets generated for the entry
 	// +optional
 	Targets []string `json:"targets,omitempty"`
+	// queriesPerHour is the rate of DNS queries for the DNS name reported by the provider (only set if query metrics are enabled)
+	// +optional
+	QueriesPerHour *int64 `json:"queriesPerHour,omitempty"`
 }
 
 type DNSBaseStatus struct {
@@ -109,6 +298,62 @@ type DNSBaseStatus struct {
 	// time to live used for the entry
 	// +optional
 	TTL *int64 `json:"ttl,omitempty"`
+	// bounded history of the last errors (oldest first)
+	// +optional
+	ErrorHistory []ErrorRecord `json:"errorHistory,omitempty"`
+	// time of the next attempt to apply the entry, if it is intentionally delayed by provider throttling or a zone backoff
+	// +optional
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
+	// changes planned for the entry, but not applied because of the dry-run mode, a read-only provider or a write freeze
+	// +optional
+	PlannedChanges []string `json:"plannedChanges,omitempty"`
+	// number of target changes within the flap detection window at the last status update (only set if flap detection is enabled)
+	// +optional
+	FlapCount int `json:"flapCount,omitempty"`
+	// record set changes last applied for the entry with the previous and new values (old -> new)
+	// +optional
+	LastChanges []string `json:"lastChanges,omitempty"`
+	// conditions of the entry
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// CONDITION_PROVIDER_ASSIGNED is the entry condition reporting whether a provider is responsible for the entry.
+// If not, the reason classifies why no provider matches.
+const CONDITION_PROVIDER_ASSIGNED = "ProviderAssigned"
+
+// Reasons of the condition ProviderAssigned
+const (
+	// PROVIDER_REASON_ASSIGNED is used if a provider is responsible for the entry
+	PROVIDER_REASON_ASSIGNED = "Assigned"
+	// PROVIDER_REASON_NOT_READY is used if the domain of the entry is included by a provider, which is not ready
+	PROVIDER_REASON_NOT_READY = "ProviderNotReady"
+	// PROVIDER_REASON_ACCESS_DENIED is used if the access of the entry to the provider including its domain is denied
+	PROVIDER_REASON_ACCESS_DENIED = "AccessDenied"
+	// PROVIDER_REASON_NO_ZONE is used if the domain of the entry is included by a provider, but no zone of the provider matches
+	PROVIDER_REASON_NO_ZONE = "NoMatchingZone"
+	// PROVIDER_REASON_ZONE_FORWARDED is used if the domain of the entry is part of a subdomain forwarded to another zone
+	PROVIDER_REASON_ZONE_FORWARDED = "ZoneForwarded"
+	// PROVIDER_REASON_DOMAIN_EXCLUDED is used if the domain of the entry is excluded by the domain selection of a provider
+	PROVIDER_REASON_DOMAIN_EXCLUDED = "DomainExcluded"
+	// PROVIDER_REASON_OTHER_CLASS is used if the domain of the entry is included by a provider of another DNS class
+	PROVIDER_REASON_OTHER_CLASS = "ProviderInOtherClass"
+	// PROVIDER_REASON_TYPE_NOT_HANDLED is used if the domain of the entry is included by a provider of a type not handled by the controller
+	PROVIDER_REASON_TYPE_NOT_HANDLED = "ProviderTypeNotHandled"
+	// PROVIDER_REASON_NO_PROVIDER is used if no provider includes the domain of the entry
+	PROVIDER_REASON_NO_PROVIDER = "NoMatchingProvider"
+)
+
+type ErrorRecord struct {
+	// timestamp of the error
+	Time metav1.Time `json:"time"`
+	// state of the entry caused by the error
+	State string `json:"state"`
+	// error message
+	Message string `json:"message"`
+	// reason of the error, if it is classified by the provider (Throttled, AuthFailed, NotFound, QuotaExceeded, InvalidRecord, Conflict)
+	// +optional
+	Reason string `json:"reason,omitempty"`
 }
 
 type EntryReference struct {
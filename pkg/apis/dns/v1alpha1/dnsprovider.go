@@ -40,6 +40,7 @@ type DNSProviderList struct {
 // +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string
 // +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date,description="creation timestamp"
 // +kubebuilder:printcolumn:name=INCLUDED_DOMAINS,JSONPath=".status.domains.included",type=string,description="included domains"
+// +kubebuilder:printcolumn:name=MODE,JSONPath=".spec.mode",type=string,priority=2000,description="provider mode"
 // +kubebuilder:printcolumn:name=INCLUDED_ZONES,JSONPath=".status.zones.included",type=string,priority=2000,description="included zones"
 // +kubebuilder:printcolumn:name=MESSAGE,JSONPath=".status.message",type=string,priority=2000,description="message describing the reason for the state"
 // +genclient
@@ -63,6 +64,10 @@ type DNSProviderSpec struct {
 	ProviderConfig *runtime.RawExtension `json:"providerConfig,omitempty"`
 	// access credential for the external DNS system of the given type
 	SecretRef *corev1.SecretReference `json:"secretRef,omitempty"`
+	// additional access credentials used in the given order if the credentials of the active secret
+	// are failing with authentication errors (e.g. for key rotations without downtime)
+	// +optional
+	SecretRefs []corev1.SecretReference `json:"secretRefs,omitempty"`
 	// desired selection of usable domains
 	// (by default all zones and domains in those zones will be served)
 	// +optional
@@ -78,6 +83,99 @@ type DNSProviderSpec struct {
 	// rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// rate limit for the provider API requests (zone state reads and change requests) per hosted zone
+	// +optional
+	ZoneRateLimit *ZoneRateLimit `json:"zoneRateLimit,omitempty"`
+	// selector for namespaces whose DNS entries should preferably be assigned
+	// to this provider if several providers are matching the DNS name with the same domain
+	// (providers with a longer matching domain are still preferred)
+	// +optional
+	DefaultForNamespaces *metav1.LabelSelector `json:"defaultForNamespaces,omitempty"`
+	// mode of the provider, in mode ReadOnly zones and records are read, but no changes are applied,
+	// in mode DryRun changes are planned and reported in the entry status, but not applied (default: ReadWrite)
+	// +kubebuilder:validation:Enum=ReadWrite;ReadOnly;DryRun
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// DNSSEC signing of the served zones (only supported by some provider types)
+	// +optional
+	DNSSEC *DNSSECConfig `json:"dnssec,omitempty"`
+	// periodic verification of the write permission for the served zones by a policy simulation
+	// (only supported by some provider types)
+	// +optional
+	WritePermissionCheck *WritePermissionCheckConfig `json:"writePermissionCheck,omitempty"`
+}
+
+const (
+	// PROVIDER_MODE_READ_WRITE is the default mode of a provider applying all changes
+	PROVIDER_MODE_READ_WRITE = "ReadWrite"
+	// PROVIDER_MODE_READ_ONLY is the mode of a provider only reading zones and records without applying any changes
+	PROVIDER_MODE_READ_ONLY = "ReadOnly"
+	// PROVIDER_MODE_DRY_RUN is the mode of a provider planning and reporting changes without applying them
+	PROVIDER_MODE_DRY_RUN = "DryRun"
+)
+
+type DNSSECConfig struct {
+	// enables DNSSEC signing for all served zones
+	Enabled bool `json:"enabled"`
+}
+
+const (
+	// DNSSEC_STATE_SIGNING is the DNSSEC state of a zone signed by the provider
+	DNSSEC_STATE_SIGNING = "Signing"
+	// DNSSEC_STATE_PENDING is the DNSSEC state of a zone, whose signing is enabled but not yet active
+	DNSSEC_STATE_PENDING = "Pending"
+	// DNSSEC_STATE_NOT_SIGNING is the DNSSEC state of an unsigned zone
+	DNSSEC_STATE_NOT_SIGNING = "NotSigning"
+	// DNSSEC_STATE_ERROR is the DNSSEC state of a zone whose DNSSEC state cannot be determined or enabled
+	DNSSEC_STATE_ERROR = "Error"
+)
+
+type WritePermissionCheckConfig struct {
+	// enables the verification of the write permission for all served zones
+	Enabled bool `json:"enabled"`
+}
+
+const (
+	// WRITE_PERMISSION_GRANTED is the write permission state of a zone whose record sets may be changed
+	WRITE_PERMISSION_GRANTED = "Granted"
+	// WRITE_PERMISSION_DENIED is the write permission state of a zone whose record sets must not be changed
+	WRITE_PERMISSION_DENIED = "Denied"
+	// WRITE_PERMISSION_UNKNOWN is the write permission state of a zone whose permission cannot be verified
+	WRITE_PERMISSION_UNKNOWN = "Unknown"
+)
+
+type ZoneWritePermissionStatus struct {
+	// id of the hosted zone
+	ZoneID string `json:"zoneID"`
+	// domain of the hosted zone
+	Domain string `json:"domain"`
+	// write permission state of the zone (Granted, Denied or Unknown)
+	State string `json:"state"`
+	// message describing the write permission state
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// CONDITION_DNSSEC_CHAIN_OF_TRUST is the provider condition reporting whether the DS records of
+// all signed zones are published in their parent zones
+const CONDITION_DNSSEC_CHAIN_OF_TRUST = "DNSSECChainOfTrust"
+
+type DNSSECZoneStatus struct {
+	// id of the hosted zone
+	ZoneID string `json:"zoneID"`
+	// domain of the hosted zone
+	Domain string `json:"domain"`
+	// DNSSEC signing state of the zone (Signing, Pending, NotSigning or Error)
+	State string `json:"state"`
+	// DS records to be published in the parent zone
+	// +optional
+	DSRecords []string `json:"dsRecords,omitempty"`
+	// true if one of the DS records is published in the parent zone
+	// +optional
+	ChainOfTrust bool `json:"chainOfTrust,omitempty"`
+	// message describing the DNSSEC state
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 type RateLimit struct {
@@ -88,6 +186,18 @@ type RateLimit struct {
 	Burst int `json:"burst"`
 }
 
+type ZoneRateLimit struct {
+	// Requests is the number of provider API requests per hosted zone allowed per interval
+	// +kubebuilder:validation:Minimum=1
+	Requests int `json:"requests"`
+	// Interval is the interval of the allowed requests (default 1s)
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// Burst allows bursts of up to 'burst' requests to exceed the rate (default 1)
+	// +optional
+	Burst int `json:"burst,omitempty"`
+}
+
 type DNSSelection struct {
 	// values that should be observed (domains or zones)
 	// + optional
@@ -120,6 +230,61 @@ type DNSProviderStatus struct {
 	// actually used rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// DNSSEC state of the served zones (only set if DNSSEC is enabled)
+	// +optional
+	DNSSEC []DNSSECZoneStatus `json:"dnssec,omitempty"`
+	// write permission for the served zones (only set if the write permission check is enabled)
+	// +optional
+	WritePermissions []ZoneWritePermissionStatus `json:"writePermissions,omitempty"`
+	// report about the setup of the provider when it has become ready for the first time
+	// +optional
+	Onboarding *ProviderOnboardingReport `json:"onboarding,omitempty"`
+	// secret of the actually used access credentials (only set if additional secrets are specified)
+	// +optional
+	ActiveSecretRef *corev1.SecretReference `json:"activeSecretRef,omitempty"`
+	// conditions of the provider
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+type ProviderOnboardingReport struct {
+	// time of the report
+	Time metav1.Time `json:"time"`
+	// hosted zones of the provider type found in the account
+	// +optional
+	Zones []OnboardingZone `json:"zones,omitempty"`
+	// included and excluded domains
+	// +optional
+	Domains []OnboardingDomain `json:"domains,omitempty"`
+	// rate limit for create/update operations on DNSEntries in effect
+	// +optional
+	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// rate limit for the provider API requests per hosted zone in effect
+	// +optional
+	ZoneRateLimit *ZoneRateLimit `json:"zoneRateLimit,omitempty"`
+	// warnings about the selection and about features not supported by the provider type
+	// +optional
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type OnboardingZone struct {
+	// id of the hosted zone
+	ZoneID string `json:"zoneID"`
+	// domain of the hosted zone
+	Domain string `json:"domain"`
+	// whether the zone is served by the provider
+	Included bool `json:"included"`
+	// reason for the selection of the zone
+	Reason string `json:"reason"`
+}
+
+type OnboardingDomain struct {
+	// included or excluded domain
+	Domain string `json:"domain"`
+	// whether the domain is served by the provider
+	Included bool `json:"included"`
+	// reason for the selection of the domain
+	Reason string `json:"reason"`
 }
 
 type DNSSelectionStatus struct {
@@ -22,11 +22,48 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CAARecord) DeepCopyInto(out *CAARecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CAARecord.
+func (in *CAARecord) DeepCopy() *CAARecord {
+	if in == nil {
+		return nil
+	}
+	out := new(CAARecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudflareProviderHints) DeepCopyInto(out *CloudflareProviderHints) {
+	*out = *in
+	if in.Proxied != nil {
+		in, out := &in.Proxied, &out.Proxied
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudflareProviderHints.
+func (in *CloudflareProviderHints) DeepCopy() *CloudflareProviderHints {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudflareProviderHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSActivation) DeepCopyInto(out *DNSActivation) {
 	*out = *in
@@ -181,6 +218,34 @@ func (in *DNSBaseStatus) DeepCopyInto(out *DNSBaseStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.ErrorHistory != nil {
+		in, out := &in.ErrorHistory, &out.ErrorHistory
+		*out = make([]ErrorRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextAttemptTime != nil {
+		in, out := &in.NextAttemptTime, &out.NextAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PlannedChanges != nil {
+		in, out := &in.PlannedChanges, &out.PlannedChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastChanges != nil {
+		in, out := &in.LastChanges, &out.LastChanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -268,6 +333,11 @@ func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.OwnerGroup != nil {
+		in, out := &in.OwnerGroup, &out.OwnerGroup
+		*out = new(string)
+		**out = **in
+	}
 	if in.TTL != nil {
 		in, out := &in.TTL, &out.TTL
 		*out = new(int64)
@@ -283,11 +353,60 @@ func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TXT != nil {
+		in, out := &in.TXT, &out.TXT
+		*out = make([]TXTRecord, len(*in))
+		copy(*out, *in)
+	}
 	if in.Targets != nil {
 		in, out := &in.Targets, &out.Targets
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RoutingPolicy != nil {
+		in, out := &in.RoutingPolicy, &out.RoutingPolicy
+		*out = new(RoutingPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderHints != nil {
+		in, out := &in.ProviderHints, &out.ProviderHints
+		*out = new(ProviderHints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SRV != nil {
+		in, out := &in.SRV, &out.SRV
+		*out = make([]SRVRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHFP != nil {
+		in, out := &in.SSHFP, &out.SSHFP
+		*out = make([]SSHFPRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.NAPTR != nil {
+		in, out := &in.NAPTR, &out.NAPTR
+		*out = make([]NAPTRRecord, len(*in))
+		copy(*out, *in)
+	}
+	if in.SVCB != nil {
+		in, out := &in.SVCB, &out.SVCB
+		*out = make([]SVCBRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HTTPS != nil {
+		in, out := &in.HTTPS, &out.HTTPS
+		*out = make([]SVCBRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CAA != nil {
+		in, out := &in.CAA, &out.CAA
+		*out = make([]CAARecord, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -310,6 +429,11 @@ func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.QueriesPerHour != nil {
+		in, out := &in.QueriesPerHour, &out.QueriesPerHour
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -771,9 +895,14 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]corev1.SecretReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = new(DNSSelection)
@@ -794,6 +923,26 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 		*out = new(RateLimit)
 		**out = **in
 	}
+	if in.ZoneRateLimit != nil {
+		in, out := &in.ZoneRateLimit, &out.ZoneRateLimit
+		*out = new(ZoneRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultForNamespaces != nil {
+		in, out := &in.DefaultForNamespaces, &out.DefaultForNamespaces
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNSSEC != nil {
+		in, out := &in.DNSSEC, &out.DNSSEC
+		*out = new(DNSSECConfig)
+		**out = **in
+	}
+	if in.WritePermissionCheck != nil {
+		in, out := &in.WritePermissionCheck, &out.WritePermissionCheck
+		*out = new(WritePermissionCheckConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -831,6 +980,35 @@ func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
 		*out = new(RateLimit)
 		**out = **in
 	}
+	if in.DNSSEC != nil {
+		in, out := &in.DNSSEC, &out.DNSSEC
+		*out = make([]DNSSECZoneStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WritePermissions != nil {
+		in, out := &in.WritePermissions, &out.WritePermissions
+		*out = make([]ZoneWritePermissionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.Onboarding != nil {
+		in, out := &in.Onboarding, &out.Onboarding
+		*out = new(ProviderOnboardingReport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveSecretRef != nil {
+		in, out := &in.ActiveSecretRef, &out.ActiveSecretRef
+		*out = new(corev1.SecretReference)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -844,6 +1022,43 @@ func (in *DNSProviderStatus) DeepCopy() *DNSProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECConfig) DeepCopyInto(out *DNSSECConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECConfig.
+func (in *DNSSECConfig) DeepCopy() *DNSSECConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSECZoneStatus) DeepCopyInto(out *DNSSECZoneStatus) {
+	*out = *in
+	if in.DSRecords != nil {
+		in, out := &in.DSRecords, &out.DSRecords
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSECZoneStatus.
+func (in *DNSSECZoneStatus) DeepCopy() *DNSSECZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSECZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSSelection) DeepCopyInto(out *DNSSelection) {
 	*out = *in
@@ -912,6 +1127,139 @@ func (in *EntryReference) DeepCopy() *EntryReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ErrorRecord) DeepCopyInto(out *ErrorRecord) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ErrorRecord.
+func (in *ErrorRecord) DeepCopy() *ErrorRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(ErrorRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NAPTRRecord) DeepCopyInto(out *NAPTRRecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NAPTRRecord.
+func (in *NAPTRRecord) DeepCopy() *NAPTRRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(NAPTRRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnboardingDomain) DeepCopyInto(out *OnboardingDomain) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnboardingDomain.
+func (in *OnboardingDomain) DeepCopy() *OnboardingDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(OnboardingDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OnboardingZone) DeepCopyInto(out *OnboardingZone) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OnboardingZone.
+func (in *OnboardingZone) DeepCopy() *OnboardingZone {
+	if in == nil {
+		return nil
+	}
+	out := new(OnboardingZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderHints) DeepCopyInto(out *ProviderHints) {
+	*out = *in
+	if in.Cloudflare != nil {
+		in, out := &in.Cloudflare, &out.Cloudflare
+		*out = new(CloudflareProviderHints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UltraDNS != nil {
+		in, out := &in.UltraDNS, &out.UltraDNS
+		*out = new(UltraDNSProviderHints)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderHints.
+func (in *ProviderHints) DeepCopy() *ProviderHints {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderOnboardingReport) DeepCopyInto(out *ProviderOnboardingReport) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]OnboardingZone, len(*in))
+		copy(*out, *in)
+	}
+	if in.Domains != nil {
+		in, out := &in.Domains, &out.Domains
+		*out = make([]OnboardingDomain, len(*in))
+		copy(*out, *in)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(RateLimit)
+		**out = **in
+	}
+	if in.ZoneRateLimit != nil {
+		in, out := &in.ZoneRateLimit, &out.ZoneRateLimit
+		*out = new(ZoneRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderOnboardingReport.
+func (in *ProviderOnboardingReport) DeepCopy() *ProviderOnboardingReport {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderOnboardingReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -1050,6 +1398,176 @@ func (in *ResourceReference) DeepCopy() *ResourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingPolicy) DeepCopyInto(out *RoutingPolicy) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingPolicy.
+func (in *RoutingPolicy) DeepCopy() *RoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SRVRecord) DeepCopyInto(out *SRVRecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SRVRecord.
+func (in *SRVRecord) DeepCopy() *SRVRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SRVRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHFPRecord) DeepCopyInto(out *SSHFPRecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHFPRecord.
+func (in *SSHFPRecord) DeepCopy() *SSHFPRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHFPRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SVCBParams) DeepCopyInto(out *SVCBParams) {
+	*out = *in
+	if in.Mandatory != nil {
+		in, out := &in.Mandatory, &out.Mandatory
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ALPN != nil {
+		in, out := &in.ALPN, &out.ALPN
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int)
+		**out = **in
+	}
+	if in.IPv4Hint != nil {
+		in, out := &in.IPv4Hint, &out.IPv4Hint
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPv6Hint != nil {
+		in, out := &in.IPv6Hint, &out.IPv6Hint
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SVCBParams.
+func (in *SVCBParams) DeepCopy() *SVCBParams {
+	if in == nil {
+		return nil
+	}
+	out := new(SVCBParams)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SVCBRecord) DeepCopyInto(out *SVCBRecord) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = new(SVCBParams)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SVCBRecord.
+func (in *SVCBRecord) DeepCopy() *SVCBRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(SVCBRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TXTRecord) DeepCopyInto(out *TXTRecord) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TXTRecord.
+func (in *TXTRecord) DeepCopy() *TXTRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(TXTRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UltraDNSProviderHints) DeepCopyInto(out *UltraDNSProviderHints) {
+	*out = *in
+	if in.PoolOrder != nil {
+		in, out := &in.PoolOrder, &out.PoolOrder
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UltraDNSProviderHints.
+func (in *UltraDNSProviderHints) DeepCopy() *UltraDNSProviderHints {
+	if in == nil {
+		return nil
+	}
+	out := new(UltraDNSProviderHints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WritePermissionCheckConfig) DeepCopyInto(out *WritePermissionCheckConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WritePermissionCheckConfig.
+func (in *WritePermissionCheckConfig) DeepCopy() *WritePermissionCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WritePermissionCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneInfo) DeepCopyInto(out *ZoneInfo) {
 	*out = *in
@@ -1071,7 +1589,7 @@ func (in *ZonePolicy) DeepCopyInto(out *ZonePolicy) {
 	*out = *in
 	if in.ZoneStateCacheTTL != nil {
 		in, out := &in.ZoneStateCacheTTL, &out.ZoneStateCacheTTL
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
 		**out = **in
 	}
 	return
@@ -1087,6 +1605,27 @@ func (in *ZonePolicy) DeepCopy() *ZonePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneRateLimit) DeepCopyInto(out *ZoneRateLimit) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneRateLimit.
+func (in *ZoneRateLimit) DeepCopy() *ZoneRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZoneSelector) DeepCopyInto(out *ZoneSelector) {
 	*out = *in
@@ -1117,3 +1656,19 @@ func (in *ZoneSelector) DeepCopy() *ZoneSelector {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZoneWritePermissionStatus) DeepCopyInto(out *ZoneWritePermissionStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZoneWritePermissionStatus.
+func (in *ZoneWritePermissionStatus) DeepCopy() *ZoneWritePermissionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ZoneWritePermissionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1
+
+import (
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// TextRecordsAnnotation keeps the number of leading txt records of a converted entry
+// originating from the v1alpha1 field text, so that the conversion back to v1alpha1 is lossless.
+const TextRecordsAnnotation = "conversion.dns.gardener.cloud/text-records"
+
+// routing policy parameters of version v1alpha1 with explicit fields in version v1beta1
+const (
+	weightParameter                    = "weight"
+	roleParameter                      = "role"
+	healthCheckIDParameter             = "healthCheckID"
+	targetHealthCheckIDParameterPrefix = "healthCheckID."
+	locationParameter                  = "location"
+)
+
+// ConvertEntryFromV1alpha1 converts a DNSEntry of version v1alpha1 to version v1beta1.
+func ConvertEntryFromV1alpha1(in *v1alpha1.DNSEntry) *DNSEntry {
+	out := &DNSEntry{TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: DNSEntryKind}}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+
+	spec := in.Spec.DeepCopy()
+	out.Spec = DNSEntrySpec{
+		DNSName:             spec.DNSName,
+		Reference:           spec.Reference,
+		OwnerId:             spec.OwnerId,
+		OwnerGroup:          spec.OwnerGroup,
+		TTL:                 spec.TTL,
+		CNameLookupInterval: spec.CNameLookupInterval,
+		Targets:             spec.Targets,
+		RoutingPolicy:       routingPolicyFromV1alpha1(spec.RoutingPolicy),
+		ProviderHints:       spec.ProviderHints,
+	}
+	records := &DNSRecords{
+		TXT:   spec.Text,
+		SRV:   spec.SRV,
+		SSHFP: spec.SSHFP,
+		NAPTR: spec.NAPTR,
+		SVCB:  spec.SVCB,
+		HTTPS: spec.HTTPS,
+		CAA:   spec.CAA,
+	}
+	for _, r := range spec.TXT {
+		records.TXT = append(records.TXT, r.Value)
+	}
+	if len(spec.Text) > 0 {
+		metav1.SetMetaDataAnnotation(&out.ObjectMeta, TextRecordsAnnotation, strconv.Itoa(len(spec.Text)))
+	}
+	if len(records.TXT) > 0 || len(records.SRV) > 0 || len(records.SSHFP) > 0 || len(records.NAPTR) > 0 ||
+		len(records.SVCB) > 0 || len(records.HTTPS) > 0 || len(records.CAA) > 0 {
+		out.Spec.Records = records
+	}
+	return out
+}
+
+// ConvertEntryToV1alpha1 converts a DNSEntry of version v1beta1 to version v1alpha1.
+func ConvertEntryToV1alpha1(in *DNSEntry) *v1alpha1.DNSEntry {
+	out := &v1alpha1.DNSEntry{TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.DNSEntryKind}}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+
+	texts := 0
+	if value, ok := out.Annotations[TextRecordsAnnotation]; ok {
+		texts, _ = strconv.Atoi(value)
+		delete(out.Annotations, TextRecordsAnnotation)
+		if len(out.Annotations) == 0 {
+			out.Annotations = nil
+		}
+	}
+
+	spec := in.Spec.DeepCopy()
+	out.Spec = v1alpha1.DNSEntrySpec{
+		DNSName:             spec.DNSName,
+		Reference:           spec.Reference,
+		OwnerId:             spec.OwnerId,
+		OwnerGroup:          spec.OwnerGroup,
+		TTL:                 spec.TTL,
+		CNameLookupInterval: spec.CNameLookupInterval,
+		Targets:             spec.Targets,
+		RoutingPolicy:       routingPolicyToV1alpha1(spec.RoutingPolicy),
+		ProviderHints:       spec.ProviderHints,
+	}
+	if records := spec.Records; records != nil {
+		if texts < 0 || texts > len(records.TXT) {
+			texts = len(records.TXT)
+		}
+		if texts > 0 {
+			out.Spec.Text = records.TXT[:texts]
+		}
+		for _, t := range records.TXT[texts:] {
+			out.Spec.TXT = append(out.Spec.TXT, v1alpha1.TXTRecord{Value: t})
+		}
+		out.Spec.SRV = records.SRV
+		out.Spec.SSHFP = records.SSHFP
+		out.Spec.NAPTR = records.NAPTR
+		out.Spec.SVCB = records.SVCB
+		out.Spec.HTTPS = records.HTTPS
+		out.Spec.CAA = records.CAA
+	}
+	return out
+}
+
+func routingPolicyFromV1alpha1(in *v1alpha1.RoutingPolicy) *RoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := &RoutingPolicy{Type: in.Type, SetIdentifier: in.SetIdentifier}
+	for k, v := range in.Parameters {
+		switch {
+		case k == weightParameter:
+			// only canonical weights are converted to keep the conversion lossless
+			if w, err := strconv.ParseInt(v, 10, 64); err == nil && w >= 0 && strconv.FormatInt(w, 10) == v {
+				out.Weight = &w
+				continue
+			}
+		case v == "":
+		case k == roleParameter:
+			out.Role = v
+			continue
+		case k == healthCheckIDParameter:
+			out.HealthCheckID = v
+			continue
+		case k == locationParameter:
+			out.Location = v
+			continue
+		case strings.HasPrefix(k, targetHealthCheckIDParameterPrefix) && len(k) > len(targetHealthCheckIDParameterPrefix):
+			if out.TargetHealthCheckIDs == nil {
+				out.TargetHealthCheckIDs = map[string]string{}
+			}
+			out.TargetHealthCheckIDs[k[len(targetHealthCheckIDParameterPrefix):]] = v
+			continue
+		}
+		if out.Parameters == nil {
+			out.Parameters = map[string]string{}
+		}
+		out.Parameters[k] = v
+	}
+	return out
+}
+
+func routingPolicyToV1alpha1(in *RoutingPolicy) *v1alpha1.RoutingPolicy {
+	if in == nil {
+		return nil
+	}
+	parameters := map[string]string{}
+	for k, v := range in.Parameters {
+		parameters[k] = v
+	}
+	if in.Weight != nil {
+		parameters[weightParameter] = strconv.FormatInt(*in.Weight, 10)
+	}
+	if in.Role != "" {
+		parameters[roleParameter] = in.Role
+	}
+	if in.HealthCheckID != "" {
+		parameters[healthCheckIDParameter] = in.HealthCheckID
+	}
+	if in.Location != "" {
+		parameters[locationParameter] = in.Location
+	}
+	for target, id := range in.TargetHealthCheckIDs {
+		parameters[targetHealthCheckIDParameterPrefix+target] = id
+	}
+	out := &v1alpha1.RoutingPolicy{Type: in.Type, SetIdentifier: in.SetIdentifier}
+	if len(parameters) > 0 {
+		out.Parameters = parameters
+	}
+	return out
+}
+
+// ConvertProviderFromV1alpha1 converts a DNSProvider of version v1alpha1 to version v1beta1.
+func ConvertProviderFromV1alpha1(in *v1alpha1.DNSProvider) *DNSProvider {
+	out := &DNSProvider{TypeMeta: metav1.TypeMeta{APIVersion: SchemeGroupVersion.String(), Kind: DNSProviderKind}}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return out
+}
+
+// ConvertProviderToV1alpha1 converts a DNSProvider of version v1beta1 to version v1alpha1.
+func ConvertProviderToV1alpha1(in *DNSProvider) *v1alpha1.DNSProvider {
+	out := &v1alpha1.DNSProvider{TypeMeta: metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.DNSProviderKind}}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return out
+}
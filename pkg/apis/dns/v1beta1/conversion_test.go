@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func TestEntryConversion(t *testing.T) {
+	alpha := &v1alpha1.DNSEntry{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.DNSEntryKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "e1", Namespace: "default"},
+		Spec: v1alpha1.DNSEntrySpec{
+			DNSName: "www.example.com",
+			Text:    []string{"legacy"},
+			TXT:     []v1alpha1.TXTRecord{{Value: "split"}},
+			SRV:     []v1alpha1.SRVRecord{{Priority: 1, Weight: 2, Port: 443, Target: "a.example.com"}},
+			RoutingPolicy: &v1alpha1.RoutingPolicy{
+				Type:          "weighted",
+				SetIdentifier: "blue",
+				Parameters:    map[string]string{"weight": "10", "healthCheckID.1.2.3.4": "hc", "custom": "x"},
+			},
+		},
+	}
+
+	beta := ConvertEntryFromV1alpha1(alpha)
+	if beta.APIVersion != SchemeGroupVersion.String() || beta.Kind != DNSEntryKind {
+		t.Errorf("Failed: unexpected type meta %v", beta.TypeMeta)
+	}
+	if beta.Spec.Records == nil || !reflect.DeepEqual(beta.Spec.Records.TXT, []string{"legacy", "split"}) || len(beta.Spec.Records.SRV) != 1 {
+		t.Errorf("Failed: unexpected records %v", beta.Spec.Records)
+	}
+	policy := beta.Spec.RoutingPolicy
+	if policy == nil || policy.Weight == nil || *policy.Weight != 10 || policy.TargetHealthCheckIDs["1.2.3.4"] != "hc" ||
+		!reflect.DeepEqual(policy.Parameters, map[string]string{"custom": "x"}) {
+		t.Errorf("Failed: unexpected routing policy %v", policy)
+	}
+
+	back := ConvertEntryToV1alpha1(beta)
+	if !reflect.DeepEqual(back, alpha) {
+		t.Errorf("Failed: conversion not lossless:\n%#v\n%#v", back.Spec, alpha.Spec)
+	}
+}
+
+func TestRoutingPolicyConversionKeepsNonCanonicalParameters(t *testing.T) {
+	alpha := &v1alpha1.RoutingPolicy{Type: "weighted", Parameters: map[string]string{"weight": "010", "role": ""}}
+	beta := routingPolicyFromV1alpha1(alpha)
+	if beta.Weight != nil || len(beta.Parameters) != 2 {
+		t.Errorf("Failed: non-canonical parameters converted: %v", beta)
+	}
+	if back := routingPolicyToV1alpha1(beta); !reflect.DeepEqual(back, alpha) {
+		t.Errorf("Failed: conversion not lossless: %v", back)
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntryList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSEntry `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:unservedversion
+// +kubebuilder:resource:scope=Namespaced,path=dnsentries,shortName=dnse,singular=dnsentry
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=DNS,description="FQDN of DNS Entry",JSONPath=".spec.dnsName",type=string
+// +kubebuilder:printcolumn:name=TYPE,JSONPath=".status.providerType",type=string,description="provider type"
+// +kubebuilder:printcolumn:name=PROVIDER,JSONPath=".status.provider",type=string,description="assigned provider (namespace/name)"
+// +kubebuilder:printcolumn:name=STATUS,JSONPath=".status.state",type=string,description="entry status"
+// +kubebuilder:printcolumn:name=AGE,JSONPath=".metadata.creationTimestamp",type=date,description="entry creation timestamp"
+// +kubebuilder:printcolumn:name=TARGETS,JSONPath=".status.targets",type=string,description="effective targets"
+// +kubebuilder:printcolumn:name=OWNERID,JSONPath=".spec.ownerId",type=string,description="owner id used to tag entries in external DNS system"
+// +kubebuilder:printcolumn:name=TTL,JSONPath=".status.ttl",type=integer,priority=2000,description="time to live"
+// +kubebuilder:printcolumn:name=ZONE,JSONPath=".status.zone",type=string,priority=2000,description="zone id"
+// +kubebuilder:printcolumn:name=MESSAGE,JSONPath=".status.message",type=string,priority=2000,description="message describing the reason for the state"
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSEntry struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSEntrySpec `json:"spec"`
+	// +optional
+	Status v1alpha1.DNSEntryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="!has(self.targets) || size(self.targets) == 0 || !has(self.records) || !has(self.records.txt) || size(self.records.txt) == 0",message="only txt records or targets possible"
+
+type DNSEntrySpec struct {
+	// full qualified domain name
+	// +kubebuilder:validation:MaxLength=254
+	// +kubebuilder:validation:Pattern="^(\\*\\.|\\\\052\\.)?(_?[A-Za-z0-9]([-A-Za-z0-9_]*[A-Za-z0-9])?\\.)*_?[A-Za-z0-9]([-A-Za-z0-9]*[A-Za-z0-9])?\\.?$"
+	DNSName string `json:"dnsName"`
+	// reference to base entry used to inherit attributes from
+	// +optional
+	Reference *v1alpha1.EntryReference `json:"reference,omitempty"`
+	// owner id used to tag entries in external DNS system
+	// +optional
+	OwnerId *string `json:"ownerId,omitempty"`
+	// team or group owning the entry, propagated into the meta data records and metrics
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	OwnerGroup *string `json:"ownerGroup,omitempty"`
+	// time to live for records in external DNS system
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2147483647
+	// +optional
+	TTL *int64 `json:"ttl,omitempty"`
+	// lookup interval for CNAMEs that must be resolved to IP addresses
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	CNameLookupInterval *int64 `json:"cnameLookupInterval,omitempty"`
+	// target records (CNAME or A records), either txt records or targets must be specified
+	// +optional
+	Targets []string `json:"targets,omitempty"`
+	// records of the other record types
+	// +optional
+	Records *DNSRecords `json:"records,omitempty"`
+	// optional routing policy for the records (must be supported by the provider type)
+	// +optional
+	RoutingPolicy *RoutingPolicy `json:"routingPolicy,omitempty"`
+	// optional provider type specific settings for the records (ignored by other provider types)
+	// +optional
+	ProviderHints *v1alpha1.ProviderHints `json:"providerHints,omitempty"`
+}
+
+// DNSRecords are the records of an entry grouped by record type.
+type DNSRecords struct {
+	// texts of the TXT records, texts longer than 255 characters are split into multiple character strings
+	// +optional
+	TXT []string `json:"txt,omitempty"`
+	// service records, the dns name must have the form _<service>._<proto>.<name> (must be supported by the provider type)
+	// +optional
+	SRV []v1alpha1.SRVRecord `json:"srv,omitempty"`
+	// SSH fingerprint records (must be supported by the provider type)
+	// +optional
+	SSHFP []v1alpha1.SSHFPRecord `json:"sshfp,omitempty"`
+	// naming authority pointer records (must be supported by the provider type)
+	// +optional
+	NAPTR []v1alpha1.NAPTRRecord `json:"naptr,omitempty"`
+	// general service binding records (must be supported by the provider type)
+	// +optional
+	SVCB []v1alpha1.SVCBRecord `json:"svcb,omitempty"`
+	// service binding records for HTTPS (must be supported by the provider type)
+	// +optional
+	HTTPS []v1alpha1.SVCBRecord `json:"https,omitempty"`
+	// certification authority authorization records (must be supported by the provider type)
+	// +optional
+	CAA []v1alpha1.CAARecord `json:"caa,omitempty"`
+}
+
+// RoutingPolicy has explicit fields for the common parameters of the routing policy types.
+type RoutingPolicy struct {
+	// routing policy type (e.g. multivalue, weighted, geolocation or failover)
+	// +kubebuilder:validation:MinLength=1
+	Type string `json:"type"`
+	// identifier distinguishing record sets of multiple entries for the same DNS name (required for weighted, geolocation and failover routing)
+	// +optional
+	SetIdentifier string `json:"setIdentifier,omitempty"`
+	// weight of a record set (weighted routing)
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Weight *int64 `json:"weight,omitempty"`
+	// role of a record set (failover routing, primary or secondary)
+	// +optional
+	Role string `json:"role,omitempty"`
+	// id of the health check of a record set (failover routing)
+	// +optional
+	HealthCheckID string `json:"healthCheckID,omitempty"`
+	// ids of the health checks per target (multivalue routing)
+	// +optional
+	TargetHealthCheckIDs map[string]string `json:"targetHealthCheckIDs,omitempty"`
+	// location of a record set (geolocation routing)
+	// +optional
+	Location string `json:"location,omitempty"`
+	// additional provider specific parameters
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
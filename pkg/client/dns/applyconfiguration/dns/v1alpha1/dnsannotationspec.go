@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSAnnotationSpecApplyConfiguration represents an declarative configuration of the DNSAnnotationSpec type for use
+// with apply.
+type DNSAnnotationSpecApplyConfiguration struct {
+	ResourceRef *ResourceReferenceApplyConfiguration `json:"resourceRef,omitempty"`
+	Annotations map[string]string                    `json:"annotations,omitempty"`
+}
+
+// DNSAnnotationSpecApplyConfiguration constructs an declarative configuration of the DNSAnnotationSpec type for use with
+// apply.
+func DNSAnnotationSpec() *DNSAnnotationSpecApplyConfiguration {
+	return &DNSAnnotationSpecApplyConfiguration{}
+}
+
+// WithResourceRef sets the ResourceRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceRef field is set to the value of the last call.
+func (b *DNSAnnotationSpecApplyConfiguration) WithResourceRef(value *ResourceReferenceApplyConfiguration) *DNSAnnotationSpecApplyConfiguration {
+	b.ResourceRef = value
+	return b
+}
+
+// WithAnnotations puts the entries into the Annotations field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Annotations field,
+// overwriting an existing map entries in Annotations field with the same key.
+func (b *DNSAnnotationSpecApplyConfiguration) WithAnnotations(entries map[string]string) *DNSAnnotationSpecApplyConfiguration {
+	if b.Annotations == nil && len(entries) > 0 {
+		b.Annotations = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Annotations[k] = v
+	}
+	return b
+}
@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DNSBaseStatusApplyConfiguration represents an declarative configuration of the DNSBaseStatus type for use
+// with apply.
+type DNSBaseStatusApplyConfiguration struct {
+	ObservedGeneration *int64                               `json:"observedGeneration,omitempty"`
+	State              *string                              `json:"state,omitempty"`
+	Message            *string                              `json:"message,omitempty"`
+	LastUptimeTime     *v1.Time                             `json:"lastUpdateTime,omitempty"`
+	ProviderType       *string                              `json:"providerType,omitempty"`
+	Provider           *string                              `json:"provider,omitempty"`
+	Zone               *string                              `json:"zone,omitempty"`
+	TTL                *int64                               `json:"ttl,omitempty"`
+	ErrorHistory       []ErrorRecordApplyConfiguration      `json:"errorHistory,omitempty"`
+	NextAttemptTime    *v1.Time                             `json:"nextAttemptTime,omitempty"`
+	PlannedChanges     []string                             `json:"plannedChanges,omitempty"`
+	FlapCount          *int                                 `json:"flapCount,omitempty"`
+	LastChanges        []string                             `json:"lastChanges,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration `json:"conditions,omitempty"`
+}
+
+// DNSBaseStatusApplyConfiguration constructs an declarative configuration of the DNSBaseStatus type for use with
+// apply.
+func DNSBaseStatus() *DNSBaseStatusApplyConfiguration {
+	return &DNSBaseStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithObservedGeneration(value int64) *DNSBaseStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithState(value string) *DNSBaseStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithMessage(value string) *DNSBaseStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUptimeTime sets the LastUptimeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUptimeTime field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithLastUptimeTime(value v1.Time) *DNSBaseStatusApplyConfiguration {
+	b.LastUptimeTime = &value
+	return b
+}
+
+// WithProviderType sets the ProviderType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderType field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithProviderType(value string) *DNSBaseStatusApplyConfiguration {
+	b.ProviderType = &value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithProvider(value string) *DNSBaseStatusApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithZone(value string) *DNSBaseStatusApplyConfiguration {
+	b.Zone = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithTTL(value int64) *DNSBaseStatusApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithErrorHistory adds the given value to the ErrorHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ErrorHistory field.
+func (b *DNSBaseStatusApplyConfiguration) WithErrorHistory(values ...*ErrorRecordApplyConfiguration) *DNSBaseStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithErrorHistory")
+		}
+		b.ErrorHistory = append(b.ErrorHistory, *values[i])
+	}
+	return b
+}
+
+// WithNextAttemptTime sets the NextAttemptTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextAttemptTime field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithNextAttemptTime(value v1.Time) *DNSBaseStatusApplyConfiguration {
+	b.NextAttemptTime = &value
+	return b
+}
+
+// WithPlannedChanges adds the given value to the PlannedChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlannedChanges field.
+func (b *DNSBaseStatusApplyConfiguration) WithPlannedChanges(values ...string) *DNSBaseStatusApplyConfiguration {
+	for i := range values {
+		b.PlannedChanges = append(b.PlannedChanges, values[i])
+	}
+	return b
+}
+
+// WithFlapCount sets the FlapCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlapCount field is set to the value of the last call.
+func (b *DNSBaseStatusApplyConfiguration) WithFlapCount(value int) *DNSBaseStatusApplyConfiguration {
+	b.FlapCount = &value
+	return b
+}
+
+// WithLastChanges adds the given value to the LastChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LastChanges field.
+func (b *DNSBaseStatusApplyConfiguration) WithLastChanges(values ...string) *DNSBaseStatusApplyConfiguration {
+	for i := range values {
+		b.LastChanges = append(b.LastChanges, values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DNSBaseStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *DNSBaseStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
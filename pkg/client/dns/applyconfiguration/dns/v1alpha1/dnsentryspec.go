@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSEntrySpecApplyConfiguration represents an declarative configuration of the DNSEntrySpec type for use
+// with apply.
+type DNSEntrySpecApplyConfiguration struct {
+	DNSName             *string                           `json:"dnsName,omitempty"`
+	Reference           *EntryReferenceApplyConfiguration `json:"reference,omitempty"`
+	OwnerId             *string                           `json:"ownerId,omitempty"`
+	OwnerGroup          *string                           `json:"ownerGroup,omitempty"`
+	TTL                 *int64                            `json:"ttl,omitempty"`
+	CNameLookupInterval *int64                            `json:"cnameLookupInterval,omitempty"`
+	Text                []string                          `json:"text,omitempty"`
+	TXT                 []TXTRecordApplyConfiguration     `json:"txt,omitempty"`
+	Targets             []string                          `json:"targets,omitempty"`
+	RoutingPolicy       *RoutingPolicyApplyConfiguration  `json:"routingPolicy,omitempty"`
+	ProviderHints       *ProviderHintsApplyConfiguration  `json:"providerHints,omitempty"`
+	SRV                 []SRVRecordApplyConfiguration     `json:"srv,omitempty"`
+	SSHFP               []SSHFPRecordApplyConfiguration   `json:"sshfp,omitempty"`
+	NAPTR               []NAPTRRecordApplyConfiguration   `json:"naptr,omitempty"`
+	SVCB                []SVCBRecordApplyConfiguration    `json:"svcb,omitempty"`
+	HTTPS               []SVCBRecordApplyConfiguration    `json:"https,omitempty"`
+	CAA                 []CAARecordApplyConfiguration     `json:"caa,omitempty"`
+}
+
+// DNSEntrySpecApplyConfiguration constructs an declarative configuration of the DNSEntrySpec type for use with
+// apply.
+func DNSEntrySpec() *DNSEntrySpecApplyConfiguration {
+	return &DNSEntrySpecApplyConfiguration{}
+}
+
+// WithDNSName sets the DNSName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSName field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithDNSName(value string) *DNSEntrySpecApplyConfiguration {
+	b.DNSName = &value
+	return b
+}
+
+// WithReference sets the Reference field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reference field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithReference(value *EntryReferenceApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	b.Reference = value
+	return b
+}
+
+// WithOwnerId sets the OwnerId field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OwnerId field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithOwnerId(value string) *DNSEntrySpecApplyConfiguration {
+	b.OwnerId = &value
+	return b
+}
+
+// WithOwnerGroup sets the OwnerGroup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OwnerGroup field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithOwnerGroup(value string) *DNSEntrySpecApplyConfiguration {
+	b.OwnerGroup = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithTTL(value int64) *DNSEntrySpecApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithCNameLookupInterval sets the CNameLookupInterval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CNameLookupInterval field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithCNameLookupInterval(value int64) *DNSEntrySpecApplyConfiguration {
+	b.CNameLookupInterval = &value
+	return b
+}
+
+// WithText adds the given value to the Text field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Text field.
+func (b *DNSEntrySpecApplyConfiguration) WithText(values ...string) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		b.Text = append(b.Text, values[i])
+	}
+	return b
+}
+
+// WithTXT adds the given value to the TXT field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the TXT field.
+func (b *DNSEntrySpecApplyConfiguration) WithTXT(values ...*TXTRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTXT")
+		}
+		b.TXT = append(b.TXT, *values[i])
+	}
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *DNSEntrySpecApplyConfiguration) WithTargets(values ...string) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		b.Targets = append(b.Targets, values[i])
+	}
+	return b
+}
+
+// WithRoutingPolicy sets the RoutingPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RoutingPolicy field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithRoutingPolicy(value *RoutingPolicyApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	b.RoutingPolicy = value
+	return b
+}
+
+// WithProviderHints sets the ProviderHints field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderHints field is set to the value of the last call.
+func (b *DNSEntrySpecApplyConfiguration) WithProviderHints(value *ProviderHintsApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	b.ProviderHints = value
+	return b
+}
+
+// WithSRV adds the given value to the SRV field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SRV field.
+func (b *DNSEntrySpecApplyConfiguration) WithSRV(values ...*SRVRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSRV")
+		}
+		b.SRV = append(b.SRV, *values[i])
+	}
+	return b
+}
+
+// WithSSHFP adds the given value to the SSHFP field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SSHFP field.
+func (b *DNSEntrySpecApplyConfiguration) WithSSHFP(values ...*SSHFPRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSSHFP")
+		}
+		b.SSHFP = append(b.SSHFP, *values[i])
+	}
+	return b
+}
+
+// WithNAPTR adds the given value to the NAPTR field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NAPTR field.
+func (b *DNSEntrySpecApplyConfiguration) WithNAPTR(values ...*NAPTRRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNAPTR")
+		}
+		b.NAPTR = append(b.NAPTR, *values[i])
+	}
+	return b
+}
+
+// WithSVCB adds the given value to the SVCB field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SVCB field.
+func (b *DNSEntrySpecApplyConfiguration) WithSVCB(values ...*SVCBRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSVCB")
+		}
+		b.SVCB = append(b.SVCB, *values[i])
+	}
+	return b
+}
+
+// WithHTTPS adds the given value to the HTTPS field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the HTTPS field.
+func (b *DNSEntrySpecApplyConfiguration) WithHTTPS(values ...*SVCBRecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHTTPS")
+		}
+		b.HTTPS = append(b.HTTPS, *values[i])
+	}
+	return b
+}
+
+// WithCAA adds the given value to the CAA field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CAA field.
+func (b *DNSEntrySpecApplyConfiguration) WithCAA(values ...*CAARecordApplyConfiguration) *DNSEntrySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithCAA")
+		}
+		b.CAA = append(b.CAA, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DNSEntryStatusApplyConfiguration represents an declarative configuration of the DNSEntryStatus type for use
+// with apply.
+type DNSEntryStatusApplyConfiguration struct {
+	DNSBaseStatusApplyConfiguration `json:",inline"`
+	Targets                         []string `json:"targets,omitempty"`
+	QueriesPerHour                  *int64   `json:"queriesPerHour,omitempty"`
+}
+
+// DNSEntryStatusApplyConfiguration constructs an declarative configuration of the DNSEntryStatus type for use with
+// apply.
+func DNSEntryStatus() *DNSEntryStatusApplyConfiguration {
+	return &DNSEntryStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithObservedGeneration(value int64) *DNSEntryStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithState(value string) *DNSEntryStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithMessage(value string) *DNSEntryStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUptimeTime sets the LastUptimeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUptimeTime field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithLastUptimeTime(value v1.Time) *DNSEntryStatusApplyConfiguration {
+	b.LastUptimeTime = &value
+	return b
+}
+
+// WithProviderType sets the ProviderType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderType field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithProviderType(value string) *DNSEntryStatusApplyConfiguration {
+	b.ProviderType = &value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithProvider(value string) *DNSEntryStatusApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithZone(value string) *DNSEntryStatusApplyConfiguration {
+	b.Zone = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithTTL(value int64) *DNSEntryStatusApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithErrorHistory adds the given value to the ErrorHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ErrorHistory field.
+func (b *DNSEntryStatusApplyConfiguration) WithErrorHistory(values ...*ErrorRecordApplyConfiguration) *DNSEntryStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithErrorHistory")
+		}
+		b.ErrorHistory = append(b.ErrorHistory, *values[i])
+	}
+	return b
+}
+
+// WithNextAttemptTime sets the NextAttemptTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextAttemptTime field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithNextAttemptTime(value v1.Time) *DNSEntryStatusApplyConfiguration {
+	b.NextAttemptTime = &value
+	return b
+}
+
+// WithPlannedChanges adds the given value to the PlannedChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlannedChanges field.
+func (b *DNSEntryStatusApplyConfiguration) WithPlannedChanges(values ...string) *DNSEntryStatusApplyConfiguration {
+	for i := range values {
+		b.PlannedChanges = append(b.PlannedChanges, values[i])
+	}
+	return b
+}
+
+// WithFlapCount sets the FlapCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlapCount field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithFlapCount(value int) *DNSEntryStatusApplyConfiguration {
+	b.FlapCount = &value
+	return b
+}
+
+// WithLastChanges adds the given value to the LastChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LastChanges field.
+func (b *DNSEntryStatusApplyConfiguration) WithLastChanges(values ...string) *DNSEntryStatusApplyConfiguration {
+	for i := range values {
+		b.LastChanges = append(b.LastChanges, values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DNSEntryStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *DNSEntryStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *DNSEntryStatusApplyConfiguration) WithTargets(values ...string) *DNSEntryStatusApplyConfiguration {
+	for i := range values {
+		b.Targets = append(b.Targets, values[i])
+	}
+	return b
+}
+
+// WithQueriesPerHour sets the QueriesPerHour field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QueriesPerHour field is set to the value of the last call.
+func (b *DNSEntryStatusApplyConfiguration) WithQueriesPerHour(value int64) *DNSEntryStatusApplyConfiguration {
+	b.QueriesPerHour = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSHostedZonePolicySpecApplyConfiguration represents an declarative configuration of the DNSHostedZonePolicySpec type for use
+// with apply.
+type DNSHostedZonePolicySpecApplyConfiguration struct {
+	Selector *ZoneSelectorApplyConfiguration `json:"selector,omitempty"`
+	Policy   *ZonePolicyApplyConfiguration   `json:"policy,omitempty"`
+}
+
+// DNSHostedZonePolicySpecApplyConfiguration constructs an declarative configuration of the DNSHostedZonePolicySpec type for use with
+// apply.
+func DNSHostedZonePolicySpec() *DNSHostedZonePolicySpecApplyConfiguration {
+	return &DNSHostedZonePolicySpecApplyConfiguration{}
+}
+
+// WithSelector sets the Selector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Selector field is set to the value of the last call.
+func (b *DNSHostedZonePolicySpecApplyConfiguration) WithSelector(value *ZoneSelectorApplyConfiguration) *DNSHostedZonePolicySpecApplyConfiguration {
+	b.Selector = value
+	return b
+}
+
+// WithPolicy sets the Policy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Policy field is set to the value of the last call.
+func (b *DNSHostedZonePolicySpecApplyConfiguration) WithPolicy(value *ZonePolicyApplyConfiguration) *DNSHostedZonePolicySpecApplyConfiguration {
+	b.Policy = value
+	return b
+}
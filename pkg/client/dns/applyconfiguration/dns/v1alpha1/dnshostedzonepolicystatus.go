@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSHostedZonePolicyStatusApplyConfiguration represents an declarative configuration of the DNSHostedZonePolicyStatus type for use
+// with apply.
+type DNSHostedZonePolicyStatusApplyConfiguration struct {
+	Count                *int                         `json:"count,omitempty"`
+	Zones                []ZoneInfoApplyConfiguration `json:"zones,omitempty"`
+	LastStatusUpdateTime *v1.Time                     `json:"lastStatusUpdateTime,omitempty"`
+	Message              *string                      `json:"message,omitempty"`
+}
+
+// DNSHostedZonePolicyStatusApplyConfiguration constructs an declarative configuration of the DNSHostedZonePolicyStatus type for use with
+// apply.
+func DNSHostedZonePolicyStatus() *DNSHostedZonePolicyStatusApplyConfiguration {
+	return &DNSHostedZonePolicyStatusApplyConfiguration{}
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *DNSHostedZonePolicyStatusApplyConfiguration) WithCount(value int) *DNSHostedZonePolicyStatusApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithZones adds the given value to the Zones field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Zones field.
+func (b *DNSHostedZonePolicyStatusApplyConfiguration) WithZones(values ...*ZoneInfoApplyConfiguration) *DNSHostedZonePolicyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithZones")
+		}
+		b.Zones = append(b.Zones, *values[i])
+	}
+	return b
+}
+
+// WithLastStatusUpdateTime sets the LastStatusUpdateTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastStatusUpdateTime field is set to the value of the last call.
+func (b *DNSHostedZonePolicyStatusApplyConfiguration) WithLastStatusUpdateTime(value v1.Time) *DNSHostedZonePolicyStatusApplyConfiguration {
+	b.LastStatusUpdateTime = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSHostedZonePolicyStatusApplyConfiguration) WithMessage(value string) *DNSHostedZonePolicyStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSLockSpecApplyConfiguration represents an declarative configuration of the DNSLockSpec type for use
+// with apply.
+type DNSLockSpecApplyConfiguration struct {
+	DNSName    *string           `json:"dnsName,omitempty"`
+	LockId     *string           `json:"lockId,omitempty"`
+	TTL        *int64            `json:"ttl,omitempty"`
+	Timestamp  *v1.Time          `json:"timestamp,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// DNSLockSpecApplyConfiguration constructs an declarative configuration of the DNSLockSpec type for use with
+// apply.
+func DNSLockSpec() *DNSLockSpecApplyConfiguration {
+	return &DNSLockSpecApplyConfiguration{}
+}
+
+// WithDNSName sets the DNSName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSName field is set to the value of the last call.
+func (b *DNSLockSpecApplyConfiguration) WithDNSName(value string) *DNSLockSpecApplyConfiguration {
+	b.DNSName = &value
+	return b
+}
+
+// WithLockId sets the LockId field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LockId field is set to the value of the last call.
+func (b *DNSLockSpecApplyConfiguration) WithLockId(value string) *DNSLockSpecApplyConfiguration {
+	b.LockId = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *DNSLockSpecApplyConfiguration) WithTTL(value int64) *DNSLockSpecApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *DNSLockSpecApplyConfiguration) WithTimestamp(value v1.Time) *DNSLockSpecApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
+
+// WithAttributes puts the entries into the Attributes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Attributes field,
+// overwriting an existing map entries in Attributes field with the same key.
+func (b *DNSLockSpecApplyConfiguration) WithAttributes(entries map[string]string) *DNSLockSpecApplyConfiguration {
+	if b.Attributes == nil && len(entries) > 0 {
+		b.Attributes = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Attributes[k] = v
+	}
+	return b
+}
@@ -0,0 +1,204 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DNSLockStatusApplyConfiguration represents an declarative configuration of the DNSLockStatus type for use
+// with apply.
+type DNSLockStatusApplyConfiguration struct {
+	DNSBaseStatusApplyConfiguration `json:",inline"`
+	Timestamp                       *v1.Time          `json:"timestamp,omitempty"`
+	LockId                          *string           `json:"lockId,omitempty"`
+	Attributes                      map[string]string `json:"attributes,omitempty"`
+	FirstFailedDNSLookup            *v1.Time          `json:"firstFailedDNSLookup,omitempty"`
+}
+
+// DNSLockStatusApplyConfiguration constructs an declarative configuration of the DNSLockStatus type for use with
+// apply.
+func DNSLockStatus() *DNSLockStatusApplyConfiguration {
+	return &DNSLockStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithObservedGeneration(value int64) *DNSLockStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithState(value string) *DNSLockStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithMessage(value string) *DNSLockStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUptimeTime sets the LastUptimeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUptimeTime field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithLastUptimeTime(value v1.Time) *DNSLockStatusApplyConfiguration {
+	b.LastUptimeTime = &value
+	return b
+}
+
+// WithProviderType sets the ProviderType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderType field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithProviderType(value string) *DNSLockStatusApplyConfiguration {
+	b.ProviderType = &value
+	return b
+}
+
+// WithProvider sets the Provider field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Provider field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithProvider(value string) *DNSLockStatusApplyConfiguration {
+	b.Provider = &value
+	return b
+}
+
+// WithZone sets the Zone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zone field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithZone(value string) *DNSLockStatusApplyConfiguration {
+	b.Zone = &value
+	return b
+}
+
+// WithTTL sets the TTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTL field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithTTL(value int64) *DNSLockStatusApplyConfiguration {
+	b.TTL = &value
+	return b
+}
+
+// WithErrorHistory adds the given value to the ErrorHistory field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ErrorHistory field.
+func (b *DNSLockStatusApplyConfiguration) WithErrorHistory(values ...*ErrorRecordApplyConfiguration) *DNSLockStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithErrorHistory")
+		}
+		b.ErrorHistory = append(b.ErrorHistory, *values[i])
+	}
+	return b
+}
+
+// WithNextAttemptTime sets the NextAttemptTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextAttemptTime field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithNextAttemptTime(value v1.Time) *DNSLockStatusApplyConfiguration {
+	b.NextAttemptTime = &value
+	return b
+}
+
+// WithPlannedChanges adds the given value to the PlannedChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PlannedChanges field.
+func (b *DNSLockStatusApplyConfiguration) WithPlannedChanges(values ...string) *DNSLockStatusApplyConfiguration {
+	for i := range values {
+		b.PlannedChanges = append(b.PlannedChanges, values[i])
+	}
+	return b
+}
+
+// WithFlapCount sets the FlapCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FlapCount field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithFlapCount(value int) *DNSLockStatusApplyConfiguration {
+	b.FlapCount = &value
+	return b
+}
+
+// WithLastChanges adds the given value to the LastChanges field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LastChanges field.
+func (b *DNSLockStatusApplyConfiguration) WithLastChanges(values ...string) *DNSLockStatusApplyConfiguration {
+	for i := range values {
+		b.LastChanges = append(b.LastChanges, values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DNSLockStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *DNSLockStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
+
+// WithTimestamp sets the Timestamp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timestamp field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithTimestamp(value v1.Time) *DNSLockStatusApplyConfiguration {
+	b.Timestamp = &value
+	return b
+}
+
+// WithLockId sets the LockId field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LockId field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithLockId(value string) *DNSLockStatusApplyConfiguration {
+	b.LockId = &value
+	return b
+}
+
+// WithAttributes puts the entries into the Attributes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Attributes field,
+// overwriting an existing map entries in Attributes field with the same key.
+func (b *DNSLockStatusApplyConfiguration) WithAttributes(entries map[string]string) *DNSLockStatusApplyConfiguration {
+	if b.Attributes == nil && len(entries) > 0 {
+		b.Attributes = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Attributes[k] = v
+	}
+	return b
+}
+
+// WithFirstFailedDNSLookup sets the FirstFailedDNSLookup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FirstFailedDNSLookup field is set to the value of the last call.
+func (b *DNSLockStatusApplyConfiguration) WithFirstFailedDNSLookup(value v1.Time) *DNSLockStatusApplyConfiguration {
+	b.FirstFailedDNSLookup = &value
+	return b
+}
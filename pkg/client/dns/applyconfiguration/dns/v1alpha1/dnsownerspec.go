@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DNSOwnerSpecApplyConfiguration represents an declarative configuration of the DNSOwnerSpec type for use
+// with apply.
+type DNSOwnerSpecApplyConfiguration struct {
+	OwnerId       *string                          `json:"ownerId,omitempty"`
+	Active        *bool                            `json:"active,omitempty"`
+	DNSActivation *DNSActivationApplyConfiguration `json:"dnsActivation,omitempty"`
+	ValidUntil    *v1.Time                         `json:"validUntil,omitempty"`
+}
+
+// DNSOwnerSpecApplyConfiguration constructs an declarative configuration of the DNSOwnerSpec type for use with
+// apply.
+func DNSOwnerSpec() *DNSOwnerSpecApplyConfiguration {
+	return &DNSOwnerSpecApplyConfiguration{}
+}
+
+// WithOwnerId sets the OwnerId field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OwnerId field is set to the value of the last call.
+func (b *DNSOwnerSpecApplyConfiguration) WithOwnerId(value string) *DNSOwnerSpecApplyConfiguration {
+	b.OwnerId = &value
+	return b
+}
+
+// WithActive sets the Active field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Active field is set to the value of the last call.
+func (b *DNSOwnerSpecApplyConfiguration) WithActive(value bool) *DNSOwnerSpecApplyConfiguration {
+	b.Active = &value
+	return b
+}
+
+// WithDNSActivation sets the DNSActivation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSActivation field is set to the value of the last call.
+func (b *DNSOwnerSpecApplyConfiguration) WithDNSActivation(value *DNSActivationApplyConfiguration) *DNSOwnerSpecApplyConfiguration {
+	b.DNSActivation = value
+	return b
+}
+
+// WithValidUntil sets the ValidUntil field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ValidUntil field is set to the value of the last call.
+func (b *DNSOwnerSpecApplyConfiguration) WithValidUntil(value v1.Time) *DNSOwnerSpecApplyConfiguration {
+	b.ValidUntil = &value
+	return b
+}
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSOwnerStatusEntriesApplyConfiguration represents an declarative configuration of the DNSOwnerStatusEntries type for use
+// with apply.
+type DNSOwnerStatusEntriesApplyConfiguration struct {
+	Amount *int           `json:"amount,omitempty"`
+	ByType map[string]int `json:"types,omitempty"`
+}
+
+// DNSOwnerStatusEntriesApplyConfiguration constructs an declarative configuration of the DNSOwnerStatusEntries type for use with
+// apply.
+func DNSOwnerStatusEntries() *DNSOwnerStatusEntriesApplyConfiguration {
+	return &DNSOwnerStatusEntriesApplyConfiguration{}
+}
+
+// WithAmount sets the Amount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Amount field is set to the value of the last call.
+func (b *DNSOwnerStatusEntriesApplyConfiguration) WithAmount(value int) *DNSOwnerStatusEntriesApplyConfiguration {
+	b.Amount = &value
+	return b
+}
+
+// WithByType puts the entries into the ByType field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ByType field,
+// overwriting an existing map entries in ByType field with the same key.
+func (b *DNSOwnerStatusEntriesApplyConfiguration) WithByType(entries map[string]int) *DNSOwnerStatusEntriesApplyConfiguration {
+	if b.ByType == nil && len(entries) > 0 {
+		b.ByType = make(map[string]int, len(entries))
+	}
+	for k, v := range entries {
+		b.ByType[k] = v
+	}
+	return b
+}
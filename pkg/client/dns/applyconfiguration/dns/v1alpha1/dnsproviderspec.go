@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	v1 "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DNSProviderSpecApplyConfiguration represents an declarative configuration of the DNSProviderSpec type for use
+// with apply.
+type DNSProviderSpecApplyConfiguration struct {
+	Type                 *string                                       `json:"type,omitempty"`
+	ProviderConfig       *runtime.RawExtension                         `json:"providerConfig,omitempty"`
+	SecretRef            *v1.SecretReferenceApplyConfiguration         `json:"secretRef,omitempty"`
+	SecretRefs           []v1.SecretReferenceApplyConfiguration        `json:"secretRefs,omitempty"`
+	Domains              *DNSSelectionApplyConfiguration               `json:"domains,omitempty"`
+	Zones                *DNSSelectionApplyConfiguration               `json:"zones,omitempty"`
+	DefaultTTL           *int64                                        `json:"defaultTTL,omitempty"`
+	RateLimit            *RateLimitApplyConfiguration                  `json:"rateLimit,omitempty"`
+	ZoneRateLimit        *ZoneRateLimitApplyConfiguration              `json:"zoneRateLimit,omitempty"`
+	DefaultForNamespaces *metav1.LabelSelectorApplyConfiguration       `json:"defaultForNamespaces,omitempty"`
+	Mode                 *string                                       `json:"mode,omitempty"`
+	DNSSEC               *DNSSECConfigApplyConfiguration               `json:"dnssec,omitempty"`
+	WritePermissionCheck *WritePermissionCheckConfigApplyConfiguration `json:"writePermissionCheck,omitempty"`
+}
+
+// DNSProviderSpecApplyConfiguration constructs an declarative configuration of the DNSProviderSpec type for use with
+// apply.
+func DNSProviderSpec() *DNSProviderSpecApplyConfiguration {
+	return &DNSProviderSpecApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithType(value string) *DNSProviderSpecApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithProviderConfig sets the ProviderConfig field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderConfig field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithProviderConfig(value runtime.RawExtension) *DNSProviderSpecApplyConfiguration {
+	b.ProviderConfig = &value
+	return b
+}
+
+// WithSecretRef sets the SecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretRef field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithSecretRef(value *v1.SecretReferenceApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.SecretRef = value
+	return b
+}
+
+// WithSecretRefs adds the given value to the SecretRefs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the SecretRefs field.
+func (b *DNSProviderSpecApplyConfiguration) WithSecretRefs(values ...*v1.SecretReferenceApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSecretRefs")
+		}
+		b.SecretRefs = append(b.SecretRefs, *values[i])
+	}
+	return b
+}
+
+// WithDomains sets the Domains field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Domains field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithDomains(value *DNSSelectionApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.Domains = value
+	return b
+}
+
+// WithZones sets the Zones field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zones field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithZones(value *DNSSelectionApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.Zones = value
+	return b
+}
+
+// WithDefaultTTL sets the DefaultTTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTTL field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithDefaultTTL(value int64) *DNSProviderSpecApplyConfiguration {
+	b.DefaultTTL = &value
+	return b
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithRateLimit(value *RateLimitApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
+
+// WithZoneRateLimit sets the ZoneRateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ZoneRateLimit field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithZoneRateLimit(value *ZoneRateLimitApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.ZoneRateLimit = value
+	return b
+}
+
+// WithDefaultForNamespaces sets the DefaultForNamespaces field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultForNamespaces field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithDefaultForNamespaces(value *metav1.LabelSelectorApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.DefaultForNamespaces = value
+	return b
+}
+
+// WithMode sets the Mode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Mode field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithMode(value string) *DNSProviderSpecApplyConfiguration {
+	b.Mode = &value
+	return b
+}
+
+// WithDNSSEC sets the DNSSEC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DNSSEC field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithDNSSEC(value *DNSSECConfigApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.DNSSEC = value
+	return b
+}
+
+// WithWritePermissionCheck sets the WritePermissionCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WritePermissionCheck field is set to the value of the last call.
+func (b *DNSProviderSpecApplyConfiguration) WithWritePermissionCheck(value *WritePermissionCheckConfigApplyConfiguration) *DNSProviderSpecApplyConfiguration {
+	b.WritePermissionCheck = value
+	return b
+}
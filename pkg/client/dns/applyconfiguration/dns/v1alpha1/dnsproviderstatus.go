@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	metav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+)
+
+// DNSProviderStatusApplyConfiguration represents an declarative configuration of the DNSProviderStatus type for use
+// with apply.
+type DNSProviderStatusApplyConfiguration struct {
+	ObservedGeneration *int64                                        `json:"observedGeneration,omitempty"`
+	State              *string                                       `json:"state,omitempty"`
+	Message            *string                                       `json:"message,omitempty"`
+	LastUptimeTime     *v1.Time                                      `json:"lastUpdateTime,omitempty"`
+	Domains            *DNSSelectionStatusApplyConfiguration         `json:"domains,omitempty"`
+	Zones              *DNSSelectionStatusApplyConfiguration         `json:"zones,omitempty"`
+	DefaultTTL         *int64                                        `json:"defaultTTL,omitempty"`
+	RateLimit          *RateLimitApplyConfiguration                  `json:"rateLimit,omitempty"`
+	DNSSEC             []DNSSECZoneStatusApplyConfiguration          `json:"dnssec,omitempty"`
+	WritePermissions   []ZoneWritePermissionStatusApplyConfiguration `json:"writePermissions,omitempty"`
+	Onboarding         *ProviderOnboardingReportApplyConfiguration   `json:"onboarding,omitempty"`
+	ActiveSecretRef    *corev1.SecretReferenceApplyConfiguration     `json:"activeSecretRef,omitempty"`
+	Conditions         []metav1.ConditionApplyConfiguration          `json:"conditions,omitempty"`
+}
+
+// DNSProviderStatusApplyConfiguration constructs an declarative configuration of the DNSProviderStatus type for use with
+// apply.
+func DNSProviderStatus() *DNSProviderStatusApplyConfiguration {
+	return &DNSProviderStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithObservedGeneration(value int64) *DNSProviderStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithState(value string) *DNSProviderStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithMessage(value string) *DNSProviderStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithLastUptimeTime sets the LastUptimeTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastUptimeTime field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithLastUptimeTime(value v1.Time) *DNSProviderStatusApplyConfiguration {
+	b.LastUptimeTime = &value
+	return b
+}
+
+// WithDomains sets the Domains field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Domains field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithDomains(value *DNSSelectionStatusApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	b.Domains = value
+	return b
+}
+
+// WithZones sets the Zones field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Zones field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithZones(value *DNSSelectionStatusApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	b.Zones = value
+	return b
+}
+
+// WithDefaultTTL sets the DefaultTTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTTL field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithDefaultTTL(value int64) *DNSProviderStatusApplyConfiguration {
+	b.DefaultTTL = &value
+	return b
+}
+
+// WithRateLimit sets the RateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RateLimit field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithRateLimit(value *RateLimitApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	b.RateLimit = value
+	return b
+}
+
+// WithDNSSEC adds the given value to the DNSSEC field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DNSSEC field.
+func (b *DNSProviderStatusApplyConfiguration) WithDNSSEC(values ...*DNSSECZoneStatusApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithDNSSEC")
+		}
+		b.DNSSEC = append(b.DNSSEC, *values[i])
+	}
+	return b
+}
+
+// WithWritePermissions adds the given value to the WritePermissions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the WritePermissions field.
+func (b *DNSProviderStatusApplyConfiguration) WithWritePermissions(values ...*ZoneWritePermissionStatusApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithWritePermissions")
+		}
+		b.WritePermissions = append(b.WritePermissions, *values[i])
+	}
+	return b
+}
+
+// WithOnboarding sets the Onboarding field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Onboarding field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithOnboarding(value *ProviderOnboardingReportApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	b.Onboarding = value
+	return b
+}
+
+// WithActiveSecretRef sets the ActiveSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ActiveSecretRef field is set to the value of the last call.
+func (b *DNSProviderStatusApplyConfiguration) WithActiveSecretRef(value *corev1.SecretReferenceApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	b.ActiveSecretRef = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *DNSProviderStatusApplyConfiguration) WithConditions(values ...*metav1.ConditionApplyConfiguration) *DNSProviderStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConditions")
+		}
+		b.Conditions = append(b.Conditions, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSSECZoneStatusApplyConfiguration represents an declarative configuration of the DNSSECZoneStatus type for use
+// with apply.
+type DNSSECZoneStatusApplyConfiguration struct {
+	ZoneID       *string  `json:"zoneID,omitempty"`
+	Domain       *string  `json:"domain,omitempty"`
+	State        *string  `json:"state,omitempty"`
+	DSRecords    []string `json:"dsRecords,omitempty"`
+	ChainOfTrust *bool    `json:"chainOfTrust,omitempty"`
+	Message      *string  `json:"message,omitempty"`
+}
+
+// DNSSECZoneStatusApplyConfiguration constructs an declarative configuration of the DNSSECZoneStatus type for use with
+// apply.
+func DNSSECZoneStatus() *DNSSECZoneStatusApplyConfiguration {
+	return &DNSSECZoneStatusApplyConfiguration{}
+}
+
+// WithZoneID sets the ZoneID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ZoneID field is set to the value of the last call.
+func (b *DNSSECZoneStatusApplyConfiguration) WithZoneID(value string) *DNSSECZoneStatusApplyConfiguration {
+	b.ZoneID = &value
+	return b
+}
+
+// WithDomain sets the Domain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Domain field is set to the value of the last call.
+func (b *DNSSECZoneStatusApplyConfiguration) WithDomain(value string) *DNSSECZoneStatusApplyConfiguration {
+	b.Domain = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *DNSSECZoneStatusApplyConfiguration) WithState(value string) *DNSSECZoneStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithDSRecords adds the given value to the DSRecords field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DSRecords field.
+func (b *DNSSECZoneStatusApplyConfiguration) WithDSRecords(values ...string) *DNSSECZoneStatusApplyConfiguration {
+	for i := range values {
+		b.DSRecords = append(b.DSRecords, values[i])
+	}
+	return b
+}
+
+// WithChainOfTrust sets the ChainOfTrust field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ChainOfTrust field is set to the value of the last call.
+func (b *DNSSECZoneStatusApplyConfiguration) WithChainOfTrust(value bool) *DNSSECZoneStatusApplyConfiguration {
+	b.ChainOfTrust = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *DNSSECZoneStatusApplyConfiguration) WithMessage(value string) *DNSSECZoneStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
@@ -0,0 +1,52 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// DNSSelectionStatusApplyConfiguration represents an declarative configuration of the DNSSelectionStatus type for use
+// with apply.
+type DNSSelectionStatusApplyConfiguration struct {
+	Included []string `json:"included,omitempty"`
+	Excluded []string `json:"excluded,omitempty"`
+}
+
+// DNSSelectionStatusApplyConfiguration constructs an declarative configuration of the DNSSelectionStatus type for use with
+// apply.
+func DNSSelectionStatus() *DNSSelectionStatusApplyConfiguration {
+	return &DNSSelectionStatusApplyConfiguration{}
+}
+
+// WithIncluded adds the given value to the Included field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Included field.
+func (b *DNSSelectionStatusApplyConfiguration) WithIncluded(values ...string) *DNSSelectionStatusApplyConfiguration {
+	for i := range values {
+		b.Included = append(b.Included, values[i])
+	}
+	return b
+}
+
+// WithExcluded adds the given value to the Excluded field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Excluded field.
+func (b *DNSSelectionStatusApplyConfiguration) WithExcluded(values ...string) *DNSSelectionStatusApplyConfiguration {
+	for i := range values {
+		b.Excluded = append(b.Excluded, values[i])
+	}
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ErrorRecordApplyConfiguration represents an declarative configuration of the ErrorRecord type for use
+// with apply.
+type ErrorRecordApplyConfiguration struct {
+	Time    *v1.Time `json:"time,omitempty"`
+	State   *string  `json:"state,omitempty"`
+	Message *string  `json:"message,omitempty"`
+	Reason  *string  `json:"reason,omitempty"`
+}
+
+// ErrorRecordApplyConfiguration constructs an declarative configuration of the ErrorRecord type for use with
+// apply.
+func ErrorRecord() *ErrorRecordApplyConfiguration {
+	return &ErrorRecordApplyConfiguration{}
+}
+
+// WithTime sets the Time field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Time field is set to the value of the last call.
+func (b *ErrorRecordApplyConfiguration) WithTime(value v1.Time) *ErrorRecordApplyConfiguration {
+	b.Time = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *ErrorRecordApplyConfiguration) WithState(value string) *ErrorRecordApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ErrorRecordApplyConfiguration) WithMessage(value string) *ErrorRecordApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *ErrorRecordApplyConfiguration) WithReason(value string) *ErrorRecordApplyConfiguration {
+	b.Reason = &value
+	return b
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// NAPTRRecordApplyConfiguration represents an declarative configuration of the NAPTRRecord type for use
+// with apply.
+type NAPTRRecordApplyConfiguration struct {
+	Order       *int    `json:"order,omitempty"`
+	Preference  *int    `json:"preference,omitempty"`
+	Flags       *string `json:"flags,omitempty"`
+	Service     *string `json:"service,omitempty"`
+	Regexp      *string `json:"regexp,omitempty"`
+	Replacement *string `json:"replacement,omitempty"`
+}
+
+// NAPTRRecordApplyConfiguration constructs an declarative configuration of the NAPTRRecord type for use with
+// apply.
+func NAPTRRecord() *NAPTRRecordApplyConfiguration {
+	return &NAPTRRecordApplyConfiguration{}
+}
+
+// WithOrder sets the Order field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Order field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithOrder(value int) *NAPTRRecordApplyConfiguration {
+	b.Order = &value
+	return b
+}
+
+// WithPreference sets the Preference field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Preference field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithPreference(value int) *NAPTRRecordApplyConfiguration {
+	b.Preference = &value
+	return b
+}
+
+// WithFlags sets the Flags field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Flags field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithFlags(value string) *NAPTRRecordApplyConfiguration {
+	b.Flags = &value
+	return b
+}
+
+// WithService sets the Service field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Service field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithService(value string) *NAPTRRecordApplyConfiguration {
+	b.Service = &value
+	return b
+}
+
+// WithRegexp sets the Regexp field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Regexp field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithRegexp(value string) *NAPTRRecordApplyConfiguration {
+	b.Regexp = &value
+	return b
+}
+
+// WithReplacement sets the Replacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replacement field is set to the value of the last call.
+func (b *NAPTRRecordApplyConfiguration) WithReplacement(value string) *NAPTRRecordApplyConfiguration {
+	b.Replacement = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// OnboardingZoneApplyConfiguration represents an declarative configuration of the OnboardingZone type for use
+// with apply.
+type OnboardingZoneApplyConfiguration struct {
+	ZoneID   *string `json:"zoneID,omitempty"`
+	Domain   *string `json:"domain,omitempty"`
+	Included *bool   `json:"included,omitempty"`
+	Reason   *string `json:"reason,omitempty"`
+}
+
+// OnboardingZoneApplyConfiguration constructs an declarative configuration of the OnboardingZone type for use with
+// apply.
+func OnboardingZone() *OnboardingZoneApplyConfiguration {
+	return &OnboardingZoneApplyConfiguration{}
+}
+
+// WithZoneID sets the ZoneID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ZoneID field is set to the value of the last call.
+func (b *OnboardingZoneApplyConfiguration) WithZoneID(value string) *OnboardingZoneApplyConfiguration {
+	b.ZoneID = &value
+	return b
+}
+
+// WithDomain sets the Domain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Domain field is set to the value of the last call.
+func (b *OnboardingZoneApplyConfiguration) WithDomain(value string) *OnboardingZoneApplyConfiguration {
+	b.Domain = &value
+	return b
+}
+
+// WithIncluded sets the Included field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Included field is set to the value of the last call.
+func (b *OnboardingZoneApplyConfiguration) WithIncluded(value bool) *OnboardingZoneApplyConfiguration {
+	b.Included = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *OnboardingZoneApplyConfiguration) WithReason(value string) *OnboardingZoneApplyConfiguration {
+	b.Reason = &value
+	return b
+}
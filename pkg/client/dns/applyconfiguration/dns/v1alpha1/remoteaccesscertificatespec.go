@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// RemoteAccessCertificateSpecApplyConfiguration represents an declarative configuration of the RemoteAccessCertificateSpec type for use
+// with apply.
+type RemoteAccessCertificateSpecApplyConfiguration struct {
+	Type       *v1alpha1.RemoteAccessCertificateType `json:"type,omitempty"`
+	SecretName *string                               `json:"secretName,omitempty"`
+	DomainName *string                               `json:"domainName,omitempty"`
+	Days       *int                                  `json:"days,omitempty"`
+	Recreate   *bool                                 `json:"recreate,omitempty"`
+}
+
+// RemoteAccessCertificateSpecApplyConfiguration constructs an declarative configuration of the RemoteAccessCertificateSpec type for use with
+// apply.
+func RemoteAccessCertificateSpec() *RemoteAccessCertificateSpecApplyConfiguration {
+	return &RemoteAccessCertificateSpecApplyConfiguration{}
+}
+
+// WithType sets the Type field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Type field is set to the value of the last call.
+func (b *RemoteAccessCertificateSpecApplyConfiguration) WithType(value v1alpha1.RemoteAccessCertificateType) *RemoteAccessCertificateSpecApplyConfiguration {
+	b.Type = &value
+	return b
+}
+
+// WithSecretName sets the SecretName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SecretName field is set to the value of the last call.
+func (b *RemoteAccessCertificateSpecApplyConfiguration) WithSecretName(value string) *RemoteAccessCertificateSpecApplyConfiguration {
+	b.SecretName = &value
+	return b
+}
+
+// WithDomainName sets the DomainName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DomainName field is set to the value of the last call.
+func (b *RemoteAccessCertificateSpecApplyConfiguration) WithDomainName(value string) *RemoteAccessCertificateSpecApplyConfiguration {
+	b.DomainName = &value
+	return b
+}
+
+// WithDays sets the Days field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Days field is set to the value of the last call.
+func (b *RemoteAccessCertificateSpecApplyConfiguration) WithDays(value int) *RemoteAccessCertificateSpecApplyConfiguration {
+	b.Days = &value
+	return b
+}
+
+// WithRecreate sets the Recreate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Recreate field is set to the value of the last call.
+func (b *RemoteAccessCertificateSpecApplyConfiguration) WithRecreate(value bool) *RemoteAccessCertificateSpecApplyConfiguration {
+	b.Recreate = &value
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteAccessCertificateStatusApplyConfiguration represents an declarative configuration of the RemoteAccessCertificateStatus type for use
+// with apply.
+type RemoteAccessCertificateStatusApplyConfiguration struct {
+	NotBefore    *v1.Time `json:"notBefore,omitempty"`
+	NotAfter     *v1.Time `json:"notAfter,omitempty"`
+	SerialNumber *string  `json:"serialNumber,omitempty"`
+	Message      *string  `json:"message,omitempty"`
+	Recreating   *bool    `json:"recreating,omitempty"`
+}
+
+// RemoteAccessCertificateStatusApplyConfiguration constructs an declarative configuration of the RemoteAccessCertificateStatus type for use with
+// apply.
+func RemoteAccessCertificateStatus() *RemoteAccessCertificateStatusApplyConfiguration {
+	return &RemoteAccessCertificateStatusApplyConfiguration{}
+}
+
+// WithNotBefore sets the NotBefore field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotBefore field is set to the value of the last call.
+func (b *RemoteAccessCertificateStatusApplyConfiguration) WithNotBefore(value v1.Time) *RemoteAccessCertificateStatusApplyConfiguration {
+	b.NotBefore = &value
+	return b
+}
+
+// WithNotAfter sets the NotAfter field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NotAfter field is set to the value of the last call.
+func (b *RemoteAccessCertificateStatusApplyConfiguration) WithNotAfter(value v1.Time) *RemoteAccessCertificateStatusApplyConfiguration {
+	b.NotAfter = &value
+	return b
+}
+
+// WithSerialNumber sets the SerialNumber field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SerialNumber field is set to the value of the last call.
+func (b *RemoteAccessCertificateStatusApplyConfiguration) WithSerialNumber(value string) *RemoteAccessCertificateStatusApplyConfiguration {
+	b.SerialNumber = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *RemoteAccessCertificateStatusApplyConfiguration) WithMessage(value string) *RemoteAccessCertificateStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithRecreating sets the Recreating field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Recreating field is set to the value of the last call.
+func (b *RemoteAccessCertificateStatusApplyConfiguration) WithRecreating(value bool) *RemoteAccessCertificateStatusApplyConfiguration {
+	b.Recreating = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// SRVRecordApplyConfiguration represents an declarative configuration of the SRVRecord type for use
+// with apply.
+type SRVRecordApplyConfiguration struct {
+	Priority *int    `json:"priority,omitempty"`
+	Weight   *int    `json:"weight,omitempty"`
+	Port     *int    `json:"port,omitempty"`
+	Target   *string `json:"target,omitempty"`
+}
+
+// SRVRecordApplyConfiguration constructs an declarative configuration of the SRVRecord type for use with
+// apply.
+func SRVRecord() *SRVRecordApplyConfiguration {
+	return &SRVRecordApplyConfiguration{}
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *SRVRecordApplyConfiguration) WithPriority(value int) *SRVRecordApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *SRVRecordApplyConfiguration) WithWeight(value int) *SRVRecordApplyConfiguration {
+	b.Weight = &value
+	return b
+}
+
+// WithPort sets the Port field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Port field is set to the value of the last call.
+func (b *SRVRecordApplyConfiguration) WithPort(value int) *SRVRecordApplyConfiguration {
+	b.Port = &value
+	return b
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *SRVRecordApplyConfiguration) WithTarget(value string) *SRVRecordApplyConfiguration {
+	b.Target = &value
+	return b
+}
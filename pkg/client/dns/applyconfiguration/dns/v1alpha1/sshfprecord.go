@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// SSHFPRecordApplyConfiguration represents an declarative configuration of the SSHFPRecord type for use
+// with apply.
+type SSHFPRecordApplyConfiguration struct {
+	Algorithm       *int    `json:"algorithm,omitempty"`
+	FingerprintType *int    `json:"fingerprintType,omitempty"`
+	Fingerprint     *string `json:"fingerprint,omitempty"`
+}
+
+// SSHFPRecordApplyConfiguration constructs an declarative configuration of the SSHFPRecord type for use with
+// apply.
+func SSHFPRecord() *SSHFPRecordApplyConfiguration {
+	return &SSHFPRecordApplyConfiguration{}
+}
+
+// WithAlgorithm sets the Algorithm field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Algorithm field is set to the value of the last call.
+func (b *SSHFPRecordApplyConfiguration) WithAlgorithm(value int) *SSHFPRecordApplyConfiguration {
+	b.Algorithm = &value
+	return b
+}
+
+// WithFingerprintType sets the FingerprintType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FingerprintType field is set to the value of the last call.
+func (b *SSHFPRecordApplyConfiguration) WithFingerprintType(value int) *SSHFPRecordApplyConfiguration {
+	b.FingerprintType = &value
+	return b
+}
+
+// WithFingerprint sets the Fingerprint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Fingerprint field is set to the value of the last call.
+func (b *SSHFPRecordApplyConfiguration) WithFingerprint(value string) *SSHFPRecordApplyConfiguration {
+	b.Fingerprint = &value
+	return b
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// SVCBRecordApplyConfiguration represents an declarative configuration of the SVCBRecord type for use
+// with apply.
+type SVCBRecordApplyConfiguration struct {
+	Priority *int                          `json:"priority,omitempty"`
+	Target   *string                       `json:"target,omitempty"`
+	Params   *SVCBParamsApplyConfiguration `json:"params,omitempty"`
+}
+
+// SVCBRecordApplyConfiguration constructs an declarative configuration of the SVCBRecord type for use with
+// apply.
+func SVCBRecord() *SVCBRecordApplyConfiguration {
+	return &SVCBRecordApplyConfiguration{}
+}
+
+// WithPriority sets the Priority field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Priority field is set to the value of the last call.
+func (b *SVCBRecordApplyConfiguration) WithPriority(value int) *SVCBRecordApplyConfiguration {
+	b.Priority = &value
+	return b
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *SVCBRecordApplyConfiguration) WithTarget(value string) *SVCBRecordApplyConfiguration {
+	b.Target = &value
+	return b
+}
+
+// WithParams sets the Params field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Params field is set to the value of the last call.
+func (b *SVCBRecordApplyConfiguration) WithParams(value *SVCBParamsApplyConfiguration) *SVCBRecordApplyConfiguration {
+	b.Params = value
+	return b
+}
@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// UltraDNSProviderHintsApplyConfiguration represents an declarative configuration of the UltraDNSProviderHints type for use
+// with apply.
+type UltraDNSProviderHintsApplyConfiguration struct {
+	PoolOrder *string `json:"poolOrder,omitempty"`
+}
+
+// UltraDNSProviderHintsApplyConfiguration constructs an declarative configuration of the UltraDNSProviderHints type for use with
+// apply.
+func UltraDNSProviderHints() *UltraDNSProviderHintsApplyConfiguration {
+	return &UltraDNSProviderHintsApplyConfiguration{}
+}
+
+// WithPoolOrder sets the PoolOrder field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PoolOrder field is set to the value of the last call.
+func (b *UltraDNSProviderHintsApplyConfiguration) WithPoolOrder(value string) *UltraDNSProviderHintsApplyConfiguration {
+	b.PoolOrder = &value
+	return b
+}
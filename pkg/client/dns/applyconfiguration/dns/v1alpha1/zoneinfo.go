@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// ZoneInfoApplyConfiguration represents an declarative configuration of the ZoneInfo type for use
+// with apply.
+type ZoneInfoApplyConfiguration struct {
+	ZoneID       *string `json:"zoneID,omitempty"`
+	ProviderType *string `json:"providerType,omitempty"`
+	DomainName   *string `json:"domainName,omitempty"`
+}
+
+// ZoneInfoApplyConfiguration constructs an declarative configuration of the ZoneInfo type for use with
+// apply.
+func ZoneInfo() *ZoneInfoApplyConfiguration {
+	return &ZoneInfoApplyConfiguration{}
+}
+
+// WithZoneID sets the ZoneID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ZoneID field is set to the value of the last call.
+func (b *ZoneInfoApplyConfiguration) WithZoneID(value string) *ZoneInfoApplyConfiguration {
+	b.ZoneID = &value
+	return b
+}
+
+// WithProviderType sets the ProviderType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProviderType field is set to the value of the last call.
+func (b *ZoneInfoApplyConfiguration) WithProviderType(value string) *ZoneInfoApplyConfiguration {
+	b.ProviderType = &value
+	return b
+}
+
+// WithDomainName sets the DomainName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DomainName field is set to the value of the last call.
+func (b *ZoneInfoApplyConfiguration) WithDomainName(value string) *ZoneInfoApplyConfiguration {
+	b.DomainName = &value
+	return b
+}
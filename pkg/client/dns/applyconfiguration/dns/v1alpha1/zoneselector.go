@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package v1alpha1
+
+// ZoneSelectorApplyConfiguration represents an declarative configuration of the ZoneSelector type for use
+// with apply.
+type ZoneSelectorApplyConfiguration struct {
+	DomainNames   []string `json:"domainNames,omitempty"`
+	ProviderTypes []string `json:"providerTypes,omitempty"`
+	ZoneIDs       []string `json:"zoneIDs,omitempty"`
+}
+
+// ZoneSelectorApplyConfiguration constructs an declarative configuration of the ZoneSelector type for use with
+// apply.
+func ZoneSelector() *ZoneSelectorApplyConfiguration {
+	return &ZoneSelectorApplyConfiguration{}
+}
+
+// WithDomainNames adds the given value to the DomainNames field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DomainNames field.
+func (b *ZoneSelectorApplyConfiguration) WithDomainNames(values ...string) *ZoneSelectorApplyConfiguration {
+	for i := range values {
+		b.DomainNames = append(b.DomainNames, values[i])
+	}
+	return b
+}
+
+// WithProviderTypes adds the given value to the ProviderTypes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ProviderTypes field.
+func (b *ZoneSelectorApplyConfiguration) WithProviderTypes(values ...string) *ZoneSelectorApplyConfiguration {
+	for i := range values {
+		b.ProviderTypes = append(b.ProviderTypes, values[i])
+	}
+	return b
+}
+
+// WithZoneIDs adds the given value to the ZoneIDs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ZoneIDs field.
+func (b *ZoneSelectorApplyConfiguration) WithZoneIDs(values ...string) *ZoneSelectorApplyConfiguration {
+	for i := range values {
+		b.ZoneIDs = append(b.ZoneIDs, values[i])
+	}
+	return b
+}
@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by acgen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=dns.gardener.cloud, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("CAARecord"):
+		return &dnsv1alpha1.CAARecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("CloudflareProviderHints"):
+		return &dnsv1alpha1.CloudflareProviderHintsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSActivation"):
+		return &dnsv1alpha1.DNSActivationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSAnnotation"):
+		return &dnsv1alpha1.DNSAnnotationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSAnnotationSpec"):
+		return &dnsv1alpha1.DNSAnnotationSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSAnnotationStatus"):
+		return &dnsv1alpha1.DNSAnnotationStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSBaseStatus"):
+		return &dnsv1alpha1.DNSBaseStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSEntry"):
+		return &dnsv1alpha1.DNSEntryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSEntrySpec"):
+		return &dnsv1alpha1.DNSEntrySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSEntryStatus"):
+		return &dnsv1alpha1.DNSEntryStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSHostedZonePolicy"):
+		return &dnsv1alpha1.DNSHostedZonePolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSHostedZonePolicySpec"):
+		return &dnsv1alpha1.DNSHostedZonePolicySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSHostedZonePolicyStatus"):
+		return &dnsv1alpha1.DNSHostedZonePolicyStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSLock"):
+		return &dnsv1alpha1.DNSLockApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSLockSpec"):
+		return &dnsv1alpha1.DNSLockSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSLockStatus"):
+		return &dnsv1alpha1.DNSLockStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSOwner"):
+		return &dnsv1alpha1.DNSOwnerApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSOwnerSpec"):
+		return &dnsv1alpha1.DNSOwnerSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSOwnerStatus"):
+		return &dnsv1alpha1.DNSOwnerStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSOwnerStatusEntries"):
+		return &dnsv1alpha1.DNSOwnerStatusEntriesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSProvider"):
+		return &dnsv1alpha1.DNSProviderApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSProviderSpec"):
+		return &dnsv1alpha1.DNSProviderSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSProviderStatus"):
+		return &dnsv1alpha1.DNSProviderStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSSECConfig"):
+		return &dnsv1alpha1.DNSSECConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSSECZoneStatus"):
+		return &dnsv1alpha1.DNSSECZoneStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSSelection"):
+		return &dnsv1alpha1.DNSSelectionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("DNSSelectionStatus"):
+		return &dnsv1alpha1.DNSSelectionStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("EntryReference"):
+		return &dnsv1alpha1.EntryReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ErrorRecord"):
+		return &dnsv1alpha1.ErrorRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NAPTRRecord"):
+		return &dnsv1alpha1.NAPTRRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OnboardingDomain"):
+		return &dnsv1alpha1.OnboardingDomainApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OnboardingZone"):
+		return &dnsv1alpha1.OnboardingZoneApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ProviderHints"):
+		return &dnsv1alpha1.ProviderHintsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ProviderOnboardingReport"):
+		return &dnsv1alpha1.ProviderOnboardingReportApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RateLimit"):
+		return &dnsv1alpha1.RateLimitApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RemoteAccessCertificate"):
+		return &dnsv1alpha1.RemoteAccessCertificateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RemoteAccessCertificateSpec"):
+		return &dnsv1alpha1.RemoteAccessCertificateSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RemoteAccessCertificateStatus"):
+		return &dnsv1alpha1.RemoteAccessCertificateStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ResourceReference"):
+		return &dnsv1alpha1.ResourceReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RoutingPolicy"):
+		return &dnsv1alpha1.RoutingPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SRVRecord"):
+		return &dnsv1alpha1.SRVRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SSHFPRecord"):
+		return &dnsv1alpha1.SSHFPRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SVCBParams"):
+		return &dnsv1alpha1.SVCBParamsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SVCBRecord"):
+		return &dnsv1alpha1.SVCBRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("TXTRecord"):
+		return &dnsv1alpha1.TXTRecordApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UltraDNSProviderHints"):
+		return &dnsv1alpha1.UltraDNSProviderHintsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("WritePermissionCheckConfig"):
+		return &dnsv1alpha1.WritePermissionCheckConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZoneInfo"):
+		return &dnsv1alpha1.ZoneInfoApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZonePolicy"):
+		return &dnsv1alpha1.ZonePolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZoneRateLimit"):
+		return &dnsv1alpha1.ZoneRateLimitApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZoneSelector"):
+		return &dnsv1alpha1.ZoneSelectorApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZoneWritePermissionStatus"):
+		return &dnsv1alpha1.ZoneWritePermissionStatusApplyConfiguration{}
+
+	}
+	return nil
+}
@@ -20,9 +20,12 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
 	scheme "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type DNSAnnotationInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DNSAnnotationList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSAnnotation, err error)
+	Apply(ctx context.Context, dNSAnnotation *dnsv1alpha1.DNSAnnotationApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSAnnotation, err error)
+	ApplyStatus(ctx context.Context, dNSAnnotation *dnsv1alpha1.DNSAnnotationApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSAnnotation, err error)
 	DNSAnnotationExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *dNSAnnotations) Patch(ctx context.Context, name string, pt types.PatchT
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied dNSAnnotation.
+func (c *dNSAnnotations) Apply(ctx context.Context, dNSAnnotation *dnsv1alpha1.DNSAnnotationApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSAnnotation, err error) {
+	if dNSAnnotation == nil {
+		return nil, fmt.Errorf("dNSAnnotation provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(dNSAnnotation)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSAnnotation.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSAnnotation.Name must be provided to Apply")
+	}
+	result = &v1alpha1.DNSAnnotation{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("dnsannotations").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *dNSAnnotations) ApplyStatus(ctx context.Context, dNSAnnotation *dnsv1alpha1.DNSAnnotationApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSAnnotation, err error) {
+	if dNSAnnotation == nil {
+		return nil, fmt.Errorf("dNSAnnotation provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(dNSAnnotation)
+	if err != nil {
+		return nil, err
+	}
+
+	name := dNSAnnotation.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSAnnotation.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.DNSAnnotation{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("dnsannotations").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
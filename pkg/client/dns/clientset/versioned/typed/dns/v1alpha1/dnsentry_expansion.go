@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"encoding/json"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// DNSEntryExpansion provides helpers for the common status and finalizer operations on DNSEntries.
+type DNSEntryExpansion interface {
+	// PatchStatusState sets state and message of the status together with the last update time.
+	PatchStatusState(ctx context.Context, name, state, message string) (*v1alpha1.DNSEntry, error)
+	// PatchStatusCondition adds or updates a condition of the status.
+	// The patch fails with a conflict if the entry has been changed after reading it.
+	PatchStatusCondition(ctx context.Context, name string, condition v1.Condition) (*v1alpha1.DNSEntry, error)
+	// AddFinalizer adds a finalizer if it is not already set.
+	AddFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error)
+	// RemoveFinalizer removes a finalizer if it is set.
+	RemoveFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error)
+}
+
+func (c *dNSEntries) PatchStatusState(ctx context.Context, name, state, message string) (*v1alpha1.DNSEntry, error) {
+	return PatchDNSEntryStatusState(ctx, c, name, state, message)
+}
+
+func (c *dNSEntries) PatchStatusCondition(ctx context.Context, name string, condition v1.Condition) (*v1alpha1.DNSEntry, error) {
+	return PatchDNSEntryStatusCondition(ctx, c, name, condition)
+}
+
+func (c *dNSEntries) AddFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return AddDNSEntryFinalizer(ctx, c, name, finalizer)
+}
+
+func (c *dNSEntries) RemoveFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return RemoveDNSEntryFinalizer(ctx, c, name, finalizer)
+}
+
+// DNSEntryPatcher is the part of a DNSEntryInterface used by the expansion helpers.
+// It allows fake clients to share the implementation.
+type DNSEntryPatcher interface {
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.DNSEntry, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (*v1alpha1.DNSEntry, error)
+}
+
+// PatchDNSEntryStatusState implements DNSEntryExpansion.PatchStatusState for a DNSEntryPatcher.
+func PatchDNSEntryStatusState(ctx context.Context, c DNSEntryPatcher, name, state, message string) (*v1alpha1.DNSEntry, error) {
+	now := v1.Now()
+	patch := map[string]interface{}{
+		"status": map[string]interface{}{
+			"state":          state,
+			"message":        message,
+			"lastUpdateTime": now,
+		},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, name, types.MergePatchType, data, v1.PatchOptions{}, "status")
+}
+
+// PatchDNSEntryStatusCondition implements DNSEntryExpansion.PatchStatusCondition for a DNSEntryPatcher.
+func PatchDNSEntryStatusCondition(ctx context.Context, c DNSEntryPatcher, name string, condition v1.Condition) (*v1alpha1.DNSEntry, error) {
+	entry, err := c.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	conditions := append([]v1.Condition(nil), entry.Status.Conditions...)
+	meta.SetStatusCondition(&conditions, condition)
+	return patchDNSEntryList(ctx, c, entry, "/status/conditions", conditions, "status")
+}
+
+// AddDNSEntryFinalizer implements DNSEntryExpansion.AddFinalizer for a DNSEntryPatcher.
+func AddDNSEntryFinalizer(ctx context.Context, c DNSEntryPatcher, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return patchDNSEntryFinalizers(ctx, c, name, func(finalizers []string) []string { return addString(finalizers, finalizer) })
+}
+
+// RemoveDNSEntryFinalizer implements DNSEntryExpansion.RemoveFinalizer for a DNSEntryPatcher.
+func RemoveDNSEntryFinalizer(ctx context.Context, c DNSEntryPatcher, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return patchDNSEntryFinalizers(ctx, c, name, func(finalizers []string) []string { return removeString(finalizers, finalizer) })
+}
+
+func patchDNSEntryFinalizers(ctx context.Context, c DNSEntryPatcher, name string, modify func([]string) []string) (*v1alpha1.DNSEntry, error) {
+	entry, err := c.Get(ctx, name, v1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	finalizers := modify(entry.Finalizers)
+	if len(finalizers) == len(entry.Finalizers) {
+		return entry, nil
+	}
+	return patchDNSEntryList(ctx, c, entry, "/metadata/finalizers", finalizers)
+}
+
+// patchDNSEntryList replaces a list field by a JSON patch guarded by the resource version of the read entry.
+func patchDNSEntryList(ctx context.Context, c DNSEntryPatcher, entry *v1alpha1.DNSEntry, path string, value interface{}, subresources ...string) (*v1alpha1.DNSEntry, error) {
+	patch := []map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": entry.ResourceVersion},
+		{"op": "add", "path": path, "value": value},
+	}
+	data, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	return c.Patch(ctx, entry.Name, types.JSONPatchType, data, v1.PatchOptions{}, subresources...)
+}
+
+func addString(list []string, s string) []string {
+	for _, e := range list {
+		if e == s {
+			return list
+		}
+	}
+	return append(append([]string(nil), list...), s)
+}
+
+func removeString(list []string, s string) []string {
+	var result []string
+	for _, e := range list {
+		if e != s {
+			result = append(result, e)
+		}
+	}
+	if result == nil && len(list) > 0 {
+		result = []string{}
+	}
+	return result
+}
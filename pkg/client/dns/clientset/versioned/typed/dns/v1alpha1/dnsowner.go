@@ -20,9 +20,12 @@ package v1alpha1
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 	"time"
 
 	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
 	scheme "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/scheme"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
@@ -47,6 +50,8 @@ type DNSOwnerInterface interface {
 	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DNSOwnerList, error)
 	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSOwner, err error)
+	Apply(ctx context.Context, dNSOwner *dnsv1alpha1.DNSOwnerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSOwner, err error)
+	ApplyStatus(ctx context.Context, dNSOwner *dnsv1alpha1.DNSOwnerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSOwner, err error)
 	DNSOwnerExpansion
 }
 
@@ -193,3 +198,59 @@ func (c *dNSOwners) Patch(ctx context.Context, name string, pt types.PatchType,
 		Into(result)
 	return
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied dNSOwner.
+func (c *dNSOwners) Apply(ctx context.Context, dNSOwner *dnsv1alpha1.DNSOwnerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSOwner, err error) {
+	if dNSOwner == nil {
+		return nil, fmt.Errorf("dNSOwner provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(dNSOwner)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSOwner.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSOwner.Name must be provided to Apply")
+	}
+	result = &v1alpha1.DNSOwner{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("dnsowners").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *dNSOwners) ApplyStatus(ctx context.Context, dNSOwner *dnsv1alpha1.DNSOwnerApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSOwner, err error) {
+	if dNSOwner == nil {
+		return nil, fmt.Errorf("dNSOwner provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(dNSOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	name := dNSOwner.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSOwner.Name must be provided to Apply")
+	}
+
+	result = &v1alpha1.DNSOwner{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("dnsowners").
+		Name(*name).
+		SubResource("status").
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -140,3 +143,48 @@ func (c *FakeDNSEntries) Patch(ctx context.Context, name string, pt types.PatchT
 	}
 	return obj.(*v1alpha1.DNSEntry), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied dNSEntry.
+func (c *FakeDNSEntries) Apply(ctx context.Context, dNSEntry *dnsv1alpha1.DNSEntryApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSEntry, err error) {
+	if dNSEntry == nil {
+		return nil, fmt.Errorf("dNSEntry provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(dNSEntry)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSEntry.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSEntry.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnsentriesResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.DNSEntry{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSEntry), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeDNSEntries) ApplyStatus(ctx context.Context, dNSEntry *dnsv1alpha1.DNSEntryApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSEntry, err error) {
+	if dNSEntry == nil {
+		return nil, fmt.Errorf("dNSEntry provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(dNSEntry)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSEntry.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSEntry.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnsentriesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.DNSEntry{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSEntry), err
+}
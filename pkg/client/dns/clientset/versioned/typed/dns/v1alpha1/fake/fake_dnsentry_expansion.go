@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/typed/dns/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func (c *FakeDNSEntries) PatchStatusState(ctx context.Context, name, state, message string) (*v1alpha1.DNSEntry, error) {
+	return dnsv1alpha1.PatchDNSEntryStatusState(ctx, c, name, state, message)
+}
+
+func (c *FakeDNSEntries) PatchStatusCondition(ctx context.Context, name string, condition v1.Condition) (*v1alpha1.DNSEntry, error) {
+	return dnsv1alpha1.PatchDNSEntryStatusCondition(ctx, c, name, condition)
+}
+
+func (c *FakeDNSEntries) AddFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return dnsv1alpha1.AddDNSEntryFinalizer(ctx, c, name, finalizer)
+}
+
+func (c *FakeDNSEntries) RemoveFinalizer(ctx context.Context, name, finalizer string) (*v1alpha1.DNSEntry, error) {
+	return dnsv1alpha1.RemoveDNSEntryFinalizer(ctx, c, name, finalizer)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/fake"
+)
+
+func newTestEntry(finalizers ...string) *v1alpha1.DNSEntry {
+	entry := &v1alpha1.DNSEntry{}
+	entry.Namespace = "default"
+	entry.Name = "test"
+	entry.ResourceVersion = "1"
+	entry.Finalizers = finalizers
+	entry.Spec.DNSName = "test.example.com"
+	return entry
+}
+
+func TestPatchStatusState(t *testing.T) {
+	RegisterTestingT(t)
+
+	entries := fake.NewSimpleClientset(newTestEntry()).DnsV1alpha1().DNSEntries("default")
+	entry, err := entries.PatchStatusState(context.TODO(), "test", "Ready", "dns entry active")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Status.State).To(Equal("Ready"))
+	Expect(entry.Status.Message).NotTo(BeNil())
+	Expect(*entry.Status.Message).To(Equal("dns entry active"))
+	Expect(entry.Status.LastUptimeTime).NotTo(BeNil())
+	Expect(entry.Spec.DNSName).To(Equal("test.example.com"))
+}
+
+func TestPatchStatusCondition(t *testing.T) {
+	RegisterTestingT(t)
+
+	entries := fake.NewSimpleClientset(newTestEntry()).DnsV1alpha1().DNSEntries("default")
+	entry, err := entries.PatchStatusCondition(context.TODO(), "test", v1.Condition{Type: "Ready", Status: v1.ConditionFalse, Reason: "Pending"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Status.Conditions).To(HaveLen(1))
+
+	entry, err = entries.PatchStatusCondition(context.TODO(), "test", v1.Condition{Type: "Ready", Status: v1.ConditionTrue, Reason: "Active"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Status.Conditions).To(HaveLen(1))
+	Expect(entry.Status.Conditions[0].Status).To(Equal(v1.ConditionTrue))
+	Expect(entry.Status.Conditions[0].Reason).To(Equal("Active"))
+	Expect(entry.Status.Conditions[0].LastTransitionTime.IsZero()).To(BeFalse())
+}
+
+func TestPatchStatusConditionConflict(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(newTestEntry())
+	clientset.PrependReactor("get", "dnsentries", func(clienttesting.Action) (bool, runtime.Object, error) {
+		stale := newTestEntry()
+		stale.ResourceVersion = "0"
+		return true, stale, nil
+	})
+	entries := clientset.DnsV1alpha1().DNSEntries("default")
+	_, err := entries.PatchStatusCondition(context.TODO(), "test", v1.Condition{Type: "Ready", Status: v1.ConditionTrue, Reason: "Active"})
+	Expect(err).To(HaveOccurred())
+}
+
+func TestAddFinalizer(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(newTestEntry("other"))
+	entries := clientset.DnsV1alpha1().DNSEntries("default")
+	entry, err := entries.AddFinalizer(context.TODO(), "test", "dns.gardener.cloud/compound")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Finalizers).To(Equal([]string{"other", "dns.gardener.cloud/compound"}))
+
+	clientset.ClearActions()
+	entry, err = entries.AddFinalizer(context.TODO(), "test", "dns.gardener.cloud/compound")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Finalizers).To(Equal([]string{"other", "dns.gardener.cloud/compound"}))
+	Expect(clientset.Actions()).To(HaveLen(1))
+	Expect(clientset.Actions()[0].GetVerb()).To(Equal("get"))
+}
+
+func TestRemoveFinalizer(t *testing.T) {
+	RegisterTestingT(t)
+
+	clientset := fake.NewSimpleClientset(newTestEntry("other", "dns.gardener.cloud/compound"))
+	entries := clientset.DnsV1alpha1().DNSEntries("default")
+	entry, err := entries.RemoveFinalizer(context.TODO(), "test", "dns.gardener.cloud/compound")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Finalizers).To(Equal([]string{"other"}))
+
+	entry, err = entries.RemoveFinalizer(context.TODO(), "test", "other")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.Finalizers).To(BeEmpty())
+
+	clientset.ClearActions()
+	_, err = entries.RemoveFinalizer(context.TODO(), "test", "other")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(clientset.Actions()).To(HaveLen(1))
+}
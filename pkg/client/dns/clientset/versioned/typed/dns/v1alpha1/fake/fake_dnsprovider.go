@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -140,3 +143,48 @@ func (c *FakeDNSProviders) Patch(ctx context.Context, name string, pt types.Patc
 	}
 	return obj.(*v1alpha1.DNSProvider), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied dNSProvider.
+func (c *FakeDNSProviders) Apply(ctx context.Context, dNSProvider *dnsv1alpha1.DNSProviderApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSProvider, err error) {
+	if dNSProvider == nil {
+		return nil, fmt.Errorf("dNSProvider provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(dNSProvider)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSProvider.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSProvider.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnsprovidersResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.DNSProvider{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSProvider), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeDNSProviders) ApplyStatus(ctx context.Context, dNSProvider *dnsv1alpha1.DNSProviderApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.DNSProvider, err error) {
+	if dNSProvider == nil {
+		return nil, fmt.Errorf("dNSProvider provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(dNSProvider)
+	if err != nil {
+		return nil, err
+	}
+	name := dNSProvider.Name
+	if name == nil {
+		return nil, fmt.Errorf("dNSProvider.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnsprovidersResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.DNSProvider{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSProvider), err
+}
@@ -20,8 +20,11 @@ package fake
 
 import (
 	"context"
+	json "encoding/json"
+	"fmt"
 
 	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsv1alpha1 "github.com/gardener/external-dns-management/pkg/client/dns/applyconfiguration/dns/v1alpha1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -140,3 +143,48 @@ func (c *FakeRemoteAccessCertificates) Patch(ctx context.Context, name string, p
 	}
 	return obj.(*v1alpha1.RemoteAccessCertificate), err
 }
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied remoteAccessCertificate.
+func (c *FakeRemoteAccessCertificates) Apply(ctx context.Context, remoteAccessCertificate *dnsv1alpha1.RemoteAccessCertificateApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.RemoteAccessCertificate, err error) {
+	if remoteAccessCertificate == nil {
+		return nil, fmt.Errorf("remoteAccessCertificate provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(remoteAccessCertificate)
+	if err != nil {
+		return nil, err
+	}
+	name := remoteAccessCertificate.Name
+	if name == nil {
+		return nil, fmt.Errorf("remoteAccessCertificate.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(remoteaccesscertificatesResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.RemoteAccessCertificate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RemoteAccessCertificate), err
+}
+
+// ApplyStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating ApplyStatus().
+func (c *FakeRemoteAccessCertificates) ApplyStatus(ctx context.Context, remoteAccessCertificate *dnsv1alpha1.RemoteAccessCertificateApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.RemoteAccessCertificate, err error) {
+	if remoteAccessCertificate == nil {
+		return nil, fmt.Errorf("remoteAccessCertificate provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(remoteAccessCertificate)
+	if err != nil {
+		return nil, err
+	}
+	name := remoteAccessCertificate.Name
+	if name == nil {
+		return nil, fmt.Errorf("remoteAccessCertificate.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(remoteaccesscertificatesResource, c.ns, *name, types.ApplyPatchType, data, "status"), &v1alpha1.RemoteAccessCertificate{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.RemoteAccessCertificate), err
+}
@@ -20,8 +20,6 @@ package v1alpha1
 
 type DNSAnnotationExpansion interface{}
 
-type DNSEntryExpansion interface{}
-
 type DNSHostedZonePolicyExpansion interface{}
 
 type DNSLockExpansion interface{}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// LastAppliedConfigAnnotation is the annotation of the object as applied by kubectl.
+const LastAppliedConfigAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// StripManagedFields returns a transform removing the managed fields of the objects.
+func StripManagedFields() cache.TransformFunc {
+	return func(obj interface{}) (interface{}, error) {
+		if accessor, err := meta.Accessor(obj); err == nil {
+			accessor.SetManagedFields(nil)
+		}
+		return obj, nil
+	}
+}
+
+// StripAnnotations returns a transform removing the given annotations and all annotations
+// with values larger than maxSize bytes (no size limit if maxSize <= 0).
+func StripAnnotations(maxSize int, keys ...string) cache.TransformFunc {
+	return func(obj interface{}) (interface{}, error) {
+		accessor, err := meta.Accessor(obj)
+		if err != nil {
+			return obj, nil
+		}
+		annotations := accessor.GetAnnotations()
+		if len(annotations) == 0 {
+			return obj, nil
+		}
+		stripped := map[string]string{}
+		for k, v := range annotations {
+			if maxSize > 0 && len(v) > maxSize {
+				continue
+			}
+			stripped[k] = v
+		}
+		for _, k := range keys {
+			delete(stripped, k)
+		}
+		if len(stripped) != len(annotations) {
+			accessor.SetAnnotations(stripped)
+		}
+		return obj, nil
+	}
+}
+
+// ChainTransforms returns a transform applying the given transforms in order.
+func ChainTransforms(transforms ...cache.TransformFunc) cache.TransformFunc {
+	return func(obj interface{}) (interface{}, error) {
+		var err error
+		for _, t := range transforms {
+			if obj, err = t(obj); err != nil {
+				return nil, err
+			}
+		}
+		return obj, nil
+	}
+}
+
+// DefaultTransform returns a transform reducing the memory footprint of cached DNSEntries
+// and DNSProviders by removing the managed fields, the last applied configuration and all
+// annotations larger than maxAnnotationSize bytes.
+func DefaultTransform(maxAnnotationSize int) cache.TransformFunc {
+	return ChainTransforms(StripManagedFields(), StripAnnotations(maxAnnotationSize, LastAppliedConfigAnnotation))
+}
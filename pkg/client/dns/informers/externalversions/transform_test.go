@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalversions_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	cache "k8s.io/client-go/tools/cache"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/fake"
+	"github.com/gardener/external-dns-management/pkg/client/dns/informers/externalversions"
+)
+
+func newTestEntry() *v1alpha1.DNSEntry {
+	entry := &v1alpha1.DNSEntry{}
+	entry.Namespace = "default"
+	entry.Name = "test"
+	entry.ManagedFields = []v1.ManagedFieldsEntry{{Manager: "kubectl", Operation: v1.ManagedFieldsOperationApply}}
+	entry.Annotations = map[string]string{
+		externalversions.LastAppliedConfigAnnotation: `{"kind":"DNSEntry"}`,
+		"large": strings.Repeat("x", 100),
+		"small": "kept",
+	}
+	entry.Spec.DNSName = "test.example.com"
+	return entry
+}
+
+func TestStripManagedFields(t *testing.T) {
+	RegisterTestingT(t)
+
+	obj, err := externalversions.StripManagedFields()(newTestEntry())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj.(*v1alpha1.DNSEntry).ManagedFields).To(BeNil())
+	Expect(obj.(*v1alpha1.DNSEntry).Annotations).To(HaveLen(3))
+
+	obj, err = externalversions.StripManagedFields()("no object")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj).To(Equal("no object"))
+}
+
+func TestStripAnnotations(t *testing.T) {
+	RegisterTestingT(t)
+
+	obj, err := externalversions.StripAnnotations(50, externalversions.LastAppliedConfigAnnotation)(newTestEntry())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj.(*v1alpha1.DNSEntry).Annotations).To(Equal(map[string]string{"small": "kept"}))
+
+	obj, err = externalversions.StripAnnotations(0)(newTestEntry())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj.(*v1alpha1.DNSEntry).Annotations).To(HaveLen(3))
+
+	entry := &v1alpha1.DNSEntry{}
+	obj, err = externalversions.StripAnnotations(50, externalversions.LastAppliedConfigAnnotation)(entry)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(obj.(*v1alpha1.DNSEntry).Annotations).To(BeNil())
+}
+
+func TestChainTransforms(t *testing.T) {
+	RegisterTestingT(t)
+
+	var order []string
+	step := func(name string, err error) cache.TransformFunc {
+		return func(obj interface{}) (interface{}, error) {
+			order = append(order, name)
+			return obj, err
+		}
+	}
+	_, err := externalversions.ChainTransforms(step("a", nil), step("b", nil))(newTestEntry())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(order).To(Equal([]string{"a", "b"}))
+
+	order = nil
+	obj, err := externalversions.ChainTransforms(step("a", fmt.Errorf("failed")), step("b", nil))(newTestEntry())
+	Expect(err).To(MatchError("failed"))
+	Expect(obj).To(BeNil())
+	Expect(order).To(Equal([]string{"a"}))
+}
+
+func TestFactoryWithTransform(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset(newTestEntry())
+	factory := externalversions.NewSharedInformerFactoryWithOptions(client, time.Minute, externalversions.WithTransform(externalversions.DefaultTransform(50)))
+	lister := factory.Dns().V1alpha1().DNSEntries().Lister()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	entry, err := lister.DNSEntries("default").Get("test")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.ManagedFields).To(BeNil())
+	Expect(entry.Annotations).To(Equal(map[string]string{"small": "kept"}))
+	Expect(entry.Spec.DNSName).To(Equal("test.example.com"))
+}
+
+func TestFactoryWithoutTransform(t *testing.T) {
+	RegisterTestingT(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := fake.NewSimpleClientset(newTestEntry())
+	factory := externalversions.NewSharedInformerFactory(client, time.Minute)
+	lister := factory.Dns().V1alpha1().DNSEntries().Lister()
+	factory.Start(ctx.Done())
+	factory.WaitForCacheSync(ctx.Done())
+
+	entry, err := lister.DNSEntries("default").Get("test")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(entry.ManagedFields).To(HaveLen(1))
+	Expect(entry.Annotations).To(HaveLen(3))
+}
@@ -17,8 +17,10 @@
 package alicloud
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/requests"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
@@ -50,10 +52,14 @@ func GetRR(dnsname, domain string) string {
 }
 
 type Access interface {
-	ListDomains(consume func(domain alidns.Domain) (bool, error)) error
-	ListRecords(zoneID, domain string, consume func(record alidns.Record) (bool, error)) error
+	ListDomains(ctx context.Context, consume func(domain alidns.Domain) (bool, error)) error
+	ListRecords(ctx context.Context, zoneID, domain string, consume func(record alidns.Record) (bool, error)) error
 
-	raw.Executor
+	CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+	UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+	DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+	NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record
+	GetRecordSet(ctx context.Context, dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error)
 }
 
 type access struct {
@@ -70,6 +76,18 @@ func NewAccess(accessKeyId, accessKeySecret string, metrics provider.Metrics, ra
 	return &access{client, metrics, rateLimiter}, nil
 }
 
+// prepareRequest applies the deadline of the context as read timeout of the request, as the
+// Alibaba Cloud SDK doesn't support contexts. It fails if the context is already done.
+func prepareRequest(ctx context.Context, request requests.AcsRequest) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		request.SetReadTimeout(time.Until(deadline))
+	}
+	return nil
+}
+
 func (this *access) nextPageNumber(pageNumber, pageSize, totalCount int) int {
 	if pageNumber*pageSize >= totalCount {
 		return 0
@@ -77,7 +95,7 @@ func (this *access) nextPageNumber(pageNumber, pageSize, totalCount int) int {
 	return pageNumber + 1
 }
 
-func (this *access) ListDomains(consume func(domain alidns.Domain) (bool, error)) error {
+func (this *access) ListDomains(ctx context.Context, consume func(domain alidns.Domain) (bool, error)) error {
 	request := alidns.CreateDescribeDomainsRequest()
 	request.PageSize = requests.NewInteger(defaultPageSize)
 	nextPage := 1
@@ -87,6 +105,9 @@ func (this *access) ListDomains(consume func(domain alidns.Domain) (bool, error)
 		rt = provider.M_PLISTZONES
 		request.PageNumber = requests.NewInteger(nextPage)
 		this.rateLimiter.Accept()
+		if err := prepareRequest(ctx, request); err != nil {
+			return err
+		}
 		resp, err := this.client.DescribeDomains(request)
 		if err != nil {
 			return err
@@ -102,11 +123,11 @@ func (this *access) ListDomains(consume func(domain alidns.Domain) (bool, error)
 	}
 }
 
-func (this *access) ListRecords(zoneID, domain string, consume func(record alidns.Record) (bool, error)) error {
-	return this.listRecords(zoneID, domain, consume, nil)
+func (this *access) ListRecords(ctx context.Context, zoneID, domain string, consume func(record alidns.Record) (bool, error)) error {
+	return this.listRecords(ctx, zoneID, domain, consume, nil)
 }
 
-func (this *access) listRecords(zoneID, domain string, consume func(record alidns.Record) (bool, error),
+func (this *access) listRecords(ctx context.Context, zoneID, domain string, consume func(record alidns.Record) (bool, error),
 	requestModifier func(request *alidns.DescribeDomainRecordsRequest)) error {
 	request := alidns.CreateDescribeDomainRecordsRequest()
 	request.DomainName = domain
@@ -121,6 +142,9 @@ func (this *access) listRecords(zoneID, domain string, consume func(record alidn
 		rt = provider.M_PLISTRECORDS
 		request.PageNumber = requests.NewInteger(nextPage)
 		this.rateLimiter.Accept()
+		if err := prepareRequest(ctx, request); err != nil {
+			return err
+		}
 		resp, err := this.client.DescribeDomainRecords(request)
 		if err != nil {
 			return err
@@ -136,7 +160,7 @@ func (this *access) listRecords(zoneID, domain string, consume func(record alidn
 	}
 }
 
-func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *access) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
 	req := alidns.CreateAddDomainRecordRequest()
 	req.DomainName = a.DomainName
@@ -146,11 +170,14 @@ func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) erro
 	req.Value = a.Value
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.rateLimiter.Accept()
+	if err := prepareRequest(ctx, req); err != nil {
+		return err
+	}
 	_, err := this.client.AddDomainRecord(req)
 	return err
 }
 
-func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *access) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
 	req := alidns.CreateUpdateDomainRecordRequest()
 	req.RecordId = a.RecordId
@@ -160,20 +187,26 @@ func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) erro
 	req.Value = a.Value
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.rateLimiter.Accept()
+	if err := prepareRequest(ctx, req); err != nil {
+		return err
+	}
 	_, err := this.client.UpdateDomainRecord(req)
 	return err
 }
 
-func (this *access) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *access) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	req := alidns.CreateDeleteDomainRecordRequest()
 	req.RecordId = r.GetId()
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.rateLimiter.Accept()
+	if err := prepareRequest(ctx, req); err != nil {
+		return err
+	}
 	_, err := this.client.DeleteDomainRecord(req)
 	return err
 }
 
-func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+func (this *access) GetRecordSet(ctx context.Context, dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
 	rr := GetRR(dnsName, zone.Domain())
 	requestModifier := func(request *alidns.DescribeDomainRecordsRequest) {
 		request.RRKeyWord = rr
@@ -189,7 +222,7 @@ func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZ
 		return true, nil
 	}
 
-	err := this.listRecords(zone.Id().ID, zone.Domain(), consume, requestModifier)
+	err := this.listRecords(ctx, zone.Id().ID, zone.Domain(), consume, requestModifier)
 	if err != nil {
 		return nil, err
 	}
@@ -200,3 +233,31 @@ func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHosted
 	rr := GetRR(fqdn, zone.Domain())
 	return (*Record)(&alidns.Record{RR: rr, Type: rtype, Value: value, DomainName: zone.Domain(), TTL: int(ttl)})
 }
+
+// executor adapts the access to a raw.Executor for a single execution.
+type executor struct {
+	ctx    context.Context
+	access Access
+}
+
+var _ raw.Executor = &executor{}
+
+func (this *executor) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.CreateRecord(this.ctx, r, zone)
+}
+
+func (this *executor) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.UpdateRecord(this.ctx, r, zone)
+}
+
+func (this *executor) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.DeleteRecord(this.ctx, r, zone)
+}
+
+func (this *executor) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	return this.access.NewRecord(fqdn, rtype, value, zone, ttl)
+}
+
+func (this *executor) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+	return this.access.GetRecordSet(this.ctx, dnsName, rtype, zone)
+}
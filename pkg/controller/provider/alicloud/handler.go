@@ -17,6 +17,7 @@
 package alicloud
 
 import (
+	"context"
 	"github.com/aliyun/alibaba-cloud-sdk-go/sdk/errors"
 	"github.com/aliyun/alibaba-cloud-sdk-go/services/alidns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -34,6 +35,7 @@ type Handler struct {
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.DedicatedDNSAccess = &Handler{}
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	var err error
@@ -71,11 +73,11 @@ func (h *Handler) Release() {
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	raw := []alidns.Domain{}
 	{
@@ -87,7 +89,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 			}
 			return true, nil
 		}
-		err := h.access.ListDomains(f)
+		err := h.access.ListDomains(ctx, f)
 		if err != nil {
 			return nil, perrs.WrapAsHandlerError(err, "list domains failed")
 		}
@@ -106,7 +108,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 				}
 				return true, nil
 			}
-			err := h.access.ListRecords(z.DomainId, z.DomainName, f)
+			err := h.access.ListRecords(ctx, z.DomainId, z.DomainName, f)
 			if err != nil {
 				if checkAccessForbidden(err) {
 					// It is reasonable for some RAM user, it is only allowed to access certain domain's records detail
@@ -123,11 +125,11 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	state := raw.NewState()
 
 	f := func(r alidns.Record) (bool, error) {
@@ -136,7 +138,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 		//fmt.Printf("**** found %s %s: %s\n", a.GetType(), a.GetDNSName(), a.GetValue() )
 		return true, nil
 	}
-	err := h.access.ListRecords(zone.Id().ID, zone.Key(), f)
+	err := h.access.ListRecords(ctx, zone.Id().ID, zone.Key(), f)
 	if err != nil {
 		return nil, perrs.WrapAsHandlerError(err, "list records failed")
 	}
@@ -148,12 +150,30 @@ func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := raw.ExecuteRequests(logger, &h.config, h.access, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := raw.ExecuteRequests(ctx, logger, &h.config, &executor{ctx: ctx, access: h.access}, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	return rtype == dns.RS_CAA || h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+// ClassifyError maps the errors of the Alibaba Cloud API to error reasons.
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	if serverErr, ok := err.(*errors.ServerError); ok {
+		switch serverErr.ErrorCode() {
+		case "Throttling", "Throttling.User", "Throttling.Api":
+			return perrs.ReasonThrottled
+		case "DomainRecordDuplicate":
+			return perrs.ReasonConflict
+		}
+		return perrs.ReasonForHTTPStatus(serverErr.HttpStatus())
+	}
+	return perrs.ReasonUnknown
+}
+
 func checkAccessForbidden(err error) bool {
 	if err != nil {
 		switch err.(type) {
@@ -168,8 +188,8 @@ func checkAccessForbidden(err error) bool {
 	return false
 }
 
-func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
-	rs, err := h.access.GetRecordSet(dnsName, recordType, zone)
+func (h *Handler) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
+	rs, err := h.access.GetRecordSet(ctx, dnsName, recordType, zone)
 	if err != nil {
 		return nil, err
 	}
@@ -180,14 +200,14 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 	return d, nil
 }
 
-func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
-	err := h.DeleteRecordSet(logger, zone, old)
+func (h *Handler) CreateOrUpdateRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
+	err := h.DeleteRecordSet(ctx, logger, zone, old)
 	if err != nil {
 		return err
 	}
 	for _, r := range new {
 		r0 := h.access.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, int64(r.GetTTL()))
-		err = h.access.CreateRecord(r0, zone)
+		err = h.access.CreateRecord(ctx, r0, zone)
 		if err != nil {
 			return err
 		}
@@ -195,10 +215,10 @@ func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provide
 	return err
 }
 
-func (h *Handler) DeleteRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
+func (h *Handler) DeleteRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
 	for _, r := range rs {
 		if r.(*Record).GetId() != "" {
-			err := h.access.DeleteRecord(r.(*Record), zone)
+			err := h.access.DeleteRecord(ctx, r.(*Record), zone)
 			if err != nil {
 				return err
 			}
@@ -34,6 +34,9 @@ func (r *Record) GetValue() string {
 	if r.Type == dns.RS_TXT {
 		return raw.EnsureQuotedText(r.Value)
 	}
+	if r.Type == dns.RS_CAA {
+		return dns.NormalizeCAAValue(r.Value)
+	}
 	return r.Value
 }
 func (r *Record) GetTTL() int      { return r.TTL }
@@ -0,0 +1,252 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/apigateway"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	// aliasTargetCacheTTL is the time-to-live of resolved alias targets
+	aliasTargetCacheTTL = 30 * time.Minute
+	// aliasRegionCacheTTL is the time-to-live of the resources of a region looked up with the AWS API.
+	// It is kept short, as newly created resources are not found before the next lookup.
+	aliasRegionCacheTTL = 2 * time.Minute
+	// aliasLookupTimeout is the timeout for looking up the resources of a region
+	aliasLookupTimeout = 30 * time.Second
+)
+
+type aliasHostedZone struct {
+	id                   string
+	evaluateTargetHealth bool
+}
+
+// aliasTargetKind describes a kind of AWS resource which can be used as alias target.
+type aliasTargetKind struct {
+	name string
+	// pattern matches the hostnames of the kind and must provide the region as first submatch
+	pattern *regexp.Regexp
+	// lookup returns a map from the hostnames of all resources of this kind in the region to their hosted zone ids
+	lookup func(ctx context.Context, sess *session.Session, region string) (map[string]string, error)
+	// fallback provides the hosted zone id for a hostname if the lookup fails
+	fallback             func(hostname, region string) string
+	evaluateTargetHealth bool
+}
+
+var aliasTargetKinds = []*aliasTargetKind{
+	{
+		name:                 "load balancer",
+		pattern:              regexp.MustCompile(`^(?:dualstack\.)?[^.]+\.([a-z0-9-]+)\.elb\.amazonaws\.com(?:\.cn)?$`),
+		lookup:               lookupLoadBalancers,
+		fallback:             fallbackLoadBalancer,
+		evaluateTargetHealth: true,
+	},
+	{
+		name:                 "network load balancer",
+		pattern:              regexp.MustCompile(`^(?:dualstack\.)?[^.]+\.elb\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`),
+		lookup:               lookupLoadBalancers,
+		fallback:             fallbackLoadBalancer,
+		evaluateTargetHealth: true,
+	},
+	{
+		name:    "API gateway",
+		pattern: regexp.MustCompile(`^d-[a-z0-9]+\.execute-api\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`),
+		lookup:  lookupAPIGatewayDomains,
+	},
+	{
+		name:                 "VPC endpoint",
+		pattern:              regexp.MustCompile(`^vpce-[a-z0-9-]+\.(?:[a-z0-9-]+\.)*([a-z0-9-]+)\.vpce\.amazonaws\.com(?:\.cn)?$`),
+		lookup:               lookupVPCEndpoints,
+		evaluateTargetHealth: true,
+	},
+	{
+		name:    "S3 website",
+		pattern: regexp.MustCompile(`^[^.]+(?:\.[^.]+)*\.s3-website[.-]([a-z0-9-]+)\.amazonaws\.com$`),
+		fallback: func(_, region string) string {
+			return s3WebsiteHostedZones[region]
+		},
+	},
+	{
+		name:    "CloudFront distribution",
+		pattern: regexp.MustCompile(`^[a-z0-9]+\.cloudfront\.net()$`),
+		fallback: func(_, _ string) string {
+			return cloudFrontHostedZone
+		},
+	},
+	{
+		name:    "Global Accelerator",
+		pattern: regexp.MustCompile(`^[a-z0-9]+\.awsglobalaccelerator\.com()$`),
+		fallback: func(_, _ string) string {
+			return globalAcceleratorHostedZone
+		},
+		evaluateTargetHealth: true,
+	},
+}
+
+// aliasTargetResolver determines the hosted zones of AWS resources used as alias targets.
+// The hosted zones of load balancers, API gateway domains and VPC endpoints are looked up
+// with the AWS API for the region given by the hostname. All lookups are cached and shared
+// by the entries of the account.
+type aliasTargetResolver struct {
+	ctx     context.Context
+	logger  logger.LogContext
+	sess    *session.Session
+	targets *dnsutils.TTLCache
+	regions *dnsutils.TTLCache
+}
+
+func newAliasTargetResolver(ctx context.Context, logger logger.LogContext, sess *session.Session) *aliasTargetResolver {
+	return &aliasTargetResolver{
+		ctx:     ctx,
+		logger:  logger,
+		sess:    sess,
+		targets: dnsutils.NewTTLCache(aliasTargetCacheTTL),
+		regions: dnsutils.NewTTLCache(aliasRegionCacheTTL),
+	}
+}
+
+// resolve returns the hosted zone for an alias target or nil if the hostname is no supported alias target.
+func (this *aliasTargetResolver) resolve(hostname string) *aliasHostedZone {
+	hostname = strings.ToLower(hostname)
+	if v, ok := this.targets.Get(hostname); ok {
+		return v.(*aliasHostedZone)
+	}
+	for _, kind := range aliasTargetKinds {
+		m := kind.pattern.FindStringSubmatch(hostname)
+		if m == nil {
+			continue
+		}
+		id := this.lookup(kind, hostname, m[1])
+		if id == "" {
+			// not caching a miss, as the resource may have been created recently
+			return nil
+		}
+		zone := &aliasHostedZone{id: id, evaluateTargetHealth: kind.evaluateTargetHealth}
+		this.targets.Set(hostname, zone)
+		return zone
+	}
+	return nil
+}
+
+func (this *aliasTargetResolver) lookup(kind *aliasTargetKind, hostname, region string) string {
+	if kind.lookup != nil && this.sess != nil {
+		key := fmt.Sprintf("%s/%s", kind.name, region)
+		v, err := this.regions.GetOrCompute(key, func() (interface{}, error) {
+			ctx, cancel := context.WithTimeout(this.ctx, aliasLookupTimeout)
+			defer cancel()
+			return kind.lookup(ctx, this.sess, region)
+		})
+		if err == nil {
+			if id := v.(map[string]string)[strings.TrimPrefix(hostname, "dualstack.")]; id != "" {
+				return id
+			}
+		} else {
+			this.logger.Warnf("looking up %s alias targets in region %s failed: %s", kind.name, region, err)
+		}
+	}
+	if kind.fallback != nil {
+		return kind.fallback(hostname, region)
+	}
+	return ""
+}
+
+func regionalConfig(region string) *aws.Config {
+	// reset an endpoint set for the route53 client
+	return aws.NewConfig().WithRegion(region).WithEndpoint("")
+}
+
+func addHostedZone(result map[string]string, hostname, id *string) {
+	if hostname != nil && id != nil {
+		result[strings.ToLower(strings.TrimPrefix(aws.StringValue(hostname), "*."))] = aws.StringValue(id)
+	}
+}
+
+func lookupLoadBalancers(ctx context.Context, sess *session.Session, region string) (map[string]string, error) {
+	result := map[string]string{}
+	cfg := regionalConfig(region)
+	err := elbv2.New(sess, cfg).DescribeLoadBalancersPagesWithContext(ctx, &elbv2.DescribeLoadBalancersInput{},
+		func(out *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+			for _, lb := range out.LoadBalancers {
+				addHostedZone(result, lb.DNSName, lb.CanonicalHostedZoneId)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	err = elb.New(sess, cfg).DescribeLoadBalancersPagesWithContext(ctx, &elb.DescribeLoadBalancersInput{},
+		func(out *elb.DescribeLoadBalancersOutput, lastPage bool) bool {
+			for _, lb := range out.LoadBalancerDescriptions {
+				addHostedZone(result, lb.DNSName, lb.CanonicalHostedZoneNameID)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func fallbackLoadBalancer(hostname, _ string) string {
+	return canonicalHostedZone(hostname)
+}
+
+func lookupAPIGatewayDomains(ctx context.Context, sess *session.Session, region string) (map[string]string, error) {
+	result := map[string]string{}
+	err := apigateway.New(sess, regionalConfig(region)).GetDomainNamesPagesWithContext(ctx, &apigateway.GetDomainNamesInput{},
+		func(out *apigateway.GetDomainNamesOutput, lastPage bool) bool {
+			for _, domain := range out.Items {
+				addHostedZone(result, domain.RegionalDomainName, domain.RegionalHostedZoneId)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func lookupVPCEndpoints(ctx context.Context, sess *session.Session, region string) (map[string]string, error) {
+	result := map[string]string{}
+	err := ec2.New(sess, regionalConfig(region)).DescribeVpcEndpointsPagesWithContext(ctx, &ec2.DescribeVpcEndpointsInput{},
+		func(out *ec2.DescribeVpcEndpointsOutput, lastPage bool) bool {
+			for _, endpoint := range out.VpcEndpoints {
+				for _, entry := range endpoint.DnsEntries {
+					addHostedZone(result, entry.DnsName, entry.HostedZoneId)
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
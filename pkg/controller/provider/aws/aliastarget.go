@@ -17,15 +17,17 @@
 package aws
 
 import (
+	"strings"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/gardener/external-dns-management/pkg/dns"
-	"strings"
 )
 
 var (
 	// original code: https://github.com/kubernetes-sigs/external-dns/blob/master/provider/aws/aws.go
 	// see: https://docs.aws.amazon.com/general/latest/gr/elb.html
+	// The table is only used as fallback if the load balancers cannot be looked up with the AWS API.
 	canonicalHostedZones = map[string]string{
 		// Application Load Balancers and Classic Load Balancers
 		"us-east-2.elb.amazonaws.com":         "Z3AADJGX6KTTL2",
@@ -78,9 +80,42 @@ var (
 		"elb.us-gov-east-1.amazonaws.com":     "Z1ZSMQQ6Q24QQ8",
 		"elb.me-south-1.amazonaws.com":        "Z3QSRYVP46NYYV",
 		"elb.af-south-1.amazonaws.com":        "Z203XCE67M25HM",
-		// Global Accelerator
-		"awsglobalaccelerator.com": "Z2BJ6XQ5FK7U4H",
 	}
+
+	// see: https://docs.aws.amazon.com/general/latest/gr/s3.html#s3_website_region_endpoints
+	// There is no API to look up the hosted zones of the S3 website endpoints.
+	s3WebsiteHostedZones = map[string]string{
+		"us-east-2":      "Z2O1EMRO9K5GLX",
+		"us-east-1":      "Z3AQBSTGFYJSTF",
+		"us-west-1":      "Z2F56UZL2M1ACD",
+		"us-west-2":      "Z3BJ6K6RIION7M",
+		"af-south-1":     "Z83WF9RJE8B12",
+		"ap-east-1":      "ZNB98KWMFR0R6",
+		"ap-south-1":     "Z11RGJOFQNVJUP",
+		"ap-northeast-3": "Z2YQB5RD63NC85",
+		"ap-northeast-2": "Z3W03O7B5YMIYP",
+		"ap-southeast-1": "Z3O0J2DXBE1FTB",
+		"ap-southeast-2": "Z1WCIGYICN2BYD",
+		"ap-northeast-1": "Z2M4EHUR26P7ZW",
+		"ca-central-1":   "Z1QDHH18159H29",
+		"eu-central-1":   "Z21DNDUVLTQW6Q",
+		"eu-west-1":      "Z1BKCTXD74EZPE",
+		"eu-west-2":      "Z3GKZC51ZF0DB4",
+		"eu-south-1":     "Z30OZKI7KPW7MI",
+		"eu-west-3":      "Z3R1K369G5AVDG",
+		"eu-north-1":     "Z3BAZG2TWCNX0D",
+		"me-south-1":     "Z1MPMWCPA7YB62",
+		"sa-east-1":      "Z7KQH4QJS55SO",
+		"us-gov-east-1":  "Z2NIFVYYW2VKV1",
+		"us-gov-west-1":  "Z31GFT0UA1I2HV",
+	}
+)
+
+const (
+	// cloudFrontHostedZone is the hosted zone of all CloudFront distributions
+	cloudFrontHostedZone = "Z2FDTNDATAQYW2"
+	// globalAcceleratorHostedZone is the hosted zone of all Global Accelerator endpoints
+	globalAcceleratorHostedZone = "Z2BJ6XQ5FK7U4H"
 )
 
 func isAliasTarget(r *route53.ResourceRecordSet) bool {
@@ -95,16 +130,16 @@ func buildRecordSetFromAliasTarget(r *route53.ResourceRecordSet) *dns.RecordSet
 	return rs
 }
 
-func buildResourceRecordSetForAliasTarget(name string, rset *dns.RecordSet) *route53.ResourceRecordSet {
+func buildResourceRecordSetForAliasTarget(resolver *aliasTargetResolver, name string, rset *dns.RecordSet) *route53.ResourceRecordSet {
 	target := dns.NormalizeHostname(rset.Records[0].Value)
-	hostedZone := canonicalHostedZone(target)
-	if hostedZone == "" {
+	hostedZone := resolver.resolve(target)
+	if hostedZone == nil {
 		return nil
 	}
 	aliasTarget := &route53.AliasTarget{
 		DNSName:              aws.String(target),
-		HostedZoneId:         aws.String(hostedZone),
-		EvaluateTargetHealth: aws.Bool(true),
+		HostedZoneId:         aws.String(hostedZone.id),
+		EvaluateTargetHealth: aws.Bool(hostedZone.evaluateTargetHealth),
 	}
 
 	return &route53.ResourceRecordSet{
@@ -114,7 +149,8 @@ func buildResourceRecordSetForAliasTarget(name string, rset *dns.RecordSet) *rou
 	}
 }
 
-// canonicalHostedZone returns the matching canonical zone for a given hostname.
+// canonicalHostedZone returns the matching canonical zone of a load balancer for a given hostname
+// from the static table.
 func canonicalHostedZone(hostname string) string {
 	for suffix, zone := range canonicalHostedZones {
 		if strings.HasSuffix(hostname, suffix) {
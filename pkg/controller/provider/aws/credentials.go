@@ -0,0 +1,178 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/endpointcreds"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/defaults"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// rotatableCredentials provides static credentials, which can be replaced without
+// recreating the session and the clients using them.
+type rotatableCredentials struct {
+	lock    sync.Mutex
+	value   credentials.Value
+	rotated bool
+}
+
+var _ credentials.Provider = &rotatableCredentials{}
+
+func newRotatableCredentials(value credentials.Value) *rotatableCredentials {
+	return &rotatableCredentials{value: value}
+}
+
+func (this *rotatableCredentials) Retrieve() (credentials.Value, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.rotated = false
+	return this.value, nil
+}
+
+// IsExpired reports rotated credentials as expired, so that they are retrieved again on the next request.
+func (this *rotatableCredentials) IsExpired() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.rotated
+}
+
+func (this *rotatableCredentials) accessKeyID() string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.value.AccessKeyID
+}
+
+func (this *rotatableCredentials) rotate(value credentials.Value) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.value = value
+	this.rotated = true
+}
+
+// readStaticCredentials reads the static credentials of the handler config.
+// It returns nil if the chain of credential providers is used.
+func readStaticCredentials(c *provider.DNSHandlerConfig) (*credentials.Value, error) {
+	useCredentialsChain, err := c.GetDefaultedBoolProperty("AWS_USE_CREDENTIALS_CHAIN", false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for AWS_USE_CREDENTIALS_CHAIN: %s", err)
+	}
+	if useCredentialsChain {
+		if c.GetProperty("AWS_ACCESS_KEY_ID", "accessKeyID") != "" {
+			return nil, fmt.Errorf("explicit credentials (AWS_ACCESS_KEY_ID or accessKeyID) cannot be used together with AWS_USE_CREDENTIALS_CHAIN=true")
+		}
+		return nil, nil
+	}
+	accessKeyID, err := c.GetRequiredProperty("AWS_ACCESS_KEY_ID", "accessKeyID")
+	if err != nil {
+		return nil, err
+	}
+	secretAccessKey, err := c.GetRequiredProperty("AWS_SECRET_ACCESS_KEY", "secretAccessKey")
+	if err != nil {
+		return nil, err
+	}
+	return &credentials.Value{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    c.GetProperty("AWS_SESSION_TOKEN"),
+		ProviderName:    credentials.StaticProviderName,
+	}, nil
+}
+
+const (
+	podIdentityEndpointEnvVar  = "AWS_CONTAINER_CREDENTIALS_FULL_URI"
+	podIdentityTokenFileEnvVar = "AWS_CONTAINER_AUTHORIZATION_TOKEN_FILE"
+)
+
+// podIdentityCredentials retrieves the credentials of an EKS pod identity association from the
+// EKS pod identity agent. The SDK only supports loopback endpoints and static authorization tokens,
+// so the projected service account token is read again for every retrieval.
+type podIdentityCredentials struct {
+	*endpointcreds.Provider
+	tokenFile string
+}
+
+var _ credentials.ProviderWithContext = &podIdentityCredentials{}
+
+// newPodIdentityCredentials returns the credentials provider for EKS pod identity, if the
+// environment of the pod is configured for it. As for the default chain, credentials in environment
+// variables and web identity tokens (IAM roles for service accounts) take precedence.
+func newPodIdentityCredentials() *podIdentityCredentials {
+	endpoint := os.Getenv(podIdentityEndpointEnvVar)
+	tokenFile := os.Getenv(podIdentityTokenFileEnvVar)
+	if endpoint == "" || tokenFile == "" {
+		return nil
+	}
+	if os.Getenv("AWS_ACCESS_KEY_ID") != "" || os.Getenv("AWS_WEB_IDENTITY_TOKEN_FILE") != "" {
+		return nil
+	}
+	p := endpointcreds.NewProviderClient(*defaults.Config(), defaults.Handlers(), endpoint, func(p *endpointcreds.Provider) {
+		p.ExpiryWindow = 5 * time.Minute
+	})
+	return &podIdentityCredentials{Provider: p.(*endpointcreds.Provider), tokenFile: tokenFile}
+}
+
+func (this *podIdentityCredentials) Retrieve() (credentials.Value, error) {
+	return this.RetrieveWithContext(aws.BackgroundContext())
+}
+
+func (this *podIdentityCredentials) RetrieveWithContext(ctx credentials.Context) (credentials.Value, error) {
+	token, err := os.ReadFile(this.tokenFile)
+	if err != nil {
+		return credentials.Value{ProviderName: endpointcreds.ProviderName}, fmt.Errorf("cannot read pod identity token: %w", err)
+	}
+	this.AuthorizationToken = strings.TrimSpace(string(token))
+	return this.Provider.RetrieveWithContext(ctx)
+}
+
+// assumeRoleConfig optionally specifies a role assumed with the credentials of the secret
+// or the chain of credential providers.
+type assumeRoleConfig struct {
+	RoleARN     string
+	SessionName string
+	ExternalID  string
+}
+
+func readAssumeRoleConfig(c *provider.DNSHandlerConfig) assumeRoleConfig {
+	return assumeRoleConfig{
+		RoleARN:     c.GetProperty("AWS_ROLE_ARN", "roleARN"),
+		SessionName: c.GetProperty("AWS_ROLE_SESSION_NAME", "roleSessionName"),
+		ExternalID:  c.GetProperty("AWS_EXTERNAL_ID", "externalID"),
+	}
+}
+
+// credentials returns the credentials of the assumed role, which are refreshed before they expire.
+func (this assumeRoleConfig) credentials(c client.ConfigProvider) *credentials.Credentials {
+	return stscreds.NewCredentials(c, this.RoleARN, func(p *stscreds.AssumeRoleProvider) {
+		if this.SessionName != "" {
+			p.RoleSessionName = this.SessionName
+		} else {
+			p.RoleSessionName = "dns-controller-manager"
+		}
+		if this.ExternalID != "" {
+			p.ExternalID = aws.String(this.ExternalID)
+		}
+	})
+}
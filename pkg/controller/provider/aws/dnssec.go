@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+type DNSSECConfig struct {
+	// KMSKeyARN is the ARN of the customer managed KMS key (ECC_NIST_P256 in us-east-1) used for the key signing key
+	KMSKeyARN string `json:"kmsKeyARN"`
+}
+
+// keySigningKeyName is the name of the key signing key created for a hosted zone
+const keySigningKeyName = "gardener_dns_ksk"
+
+// keySigningKeyStatusActive is the status of a key signing key used for signing
+const keySigningKeyStatusActive = "ACTIVE"
+
+var _ provider.DNSSECAccess = &Handler{}
+
+func (h *Handler) GetDNSSECState(ctx context.Context, zone provider.DNSHostedZone) (*provider.DNSSECState, error) {
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	output, err := h.r53.GetDNSSECWithContext(ctx, &route53.GetDNSSECInput{HostedZoneId: aws.String(zone.Id().ID)})
+	if err != nil {
+		return nil, err
+	}
+	state := &provider.DNSSECState{}
+	switch serve := aws.StringValue(output.Status.ServeSignature); serve {
+	case "SIGNING":
+		state.State = api.DNSSEC_STATE_SIGNING
+	case "NOT_SIGNING":
+		state.State = api.DNSSEC_STATE_NOT_SIGNING
+	default:
+		state.State = api.DNSSEC_STATE_ERROR
+		state.Message = fmt.Sprintf("serve signature %s: %s", serve, aws.StringValue(output.Status.StatusMessage))
+	}
+	for _, ksk := range output.KeySigningKeys {
+		switch aws.StringValue(ksk.Status) {
+		case keySigningKeyStatusActive:
+			state.DSRecords = append(state.DSRecords, fmt.Sprintf("%d %d %d %s", aws.Int64Value(ksk.KeyTag),
+				aws.Int64Value(ksk.SigningAlgorithmType), aws.Int64Value(ksk.DigestAlgorithmType), strings.ToUpper(aws.StringValue(ksk.DigestValue))))
+		case "ACTION_NEEDED", "INTERNAL_FAILURE":
+			state.State = api.DNSSEC_STATE_ERROR
+			state.Message = fmt.Sprintf("key signing key %s: %s", aws.StringValue(ksk.Name), aws.StringValue(ksk.StatusMessage))
+		}
+	}
+	return state, nil
+}
+
+func (h *Handler) EnableDNSSEC(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone) error {
+	dnssecConfig := h.getAWSConfig().DNSSEC
+	if dnssecConfig == nil || dnssecConfig.KMSKeyARN == "" {
+		return fmt.Errorf("missing KMS key ARN in providerConfig (dnssec.kmsKeyARN)")
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	output, err := h.r53.GetDNSSECWithContext(ctx, &route53.GetDNSSECInput{HostedZoneId: aws.String(zone.Id().ID)})
+	if err != nil {
+		return err
+	}
+	if len(output.KeySigningKeys) == 0 {
+		logger.Infof("creating key signing key %s for zone %s", keySigningKeyName, zone.Id())
+		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+		h.config.RateLimiter.Accept()
+		_, err = h.r53.CreateKeySigningKeyWithContext(ctx, &route53.CreateKeySigningKeyInput{
+			CallerReference:         aws.String(fmt.Sprintf("%s-%d", zone.Id().ID, time.Now().UnixNano())),
+			HostedZoneId:            aws.String(zone.Id().ID),
+			KeyManagementServiceArn: aws.String(dnssecConfig.KMSKeyARN),
+			Name:                    aws.String(keySigningKeyName),
+			Status:                  aws.String(keySigningKeyStatusActive),
+		})
+		if err != nil {
+			return fmt.Errorf("cannot create key signing key: %w", err)
+		}
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	_, err = h.r53.EnableHostedZoneDNSSECWithContext(ctx, &route53.EnableHostedZoneDNSSECInput{HostedZoneId: aws.String(zone.Id().ID)})
+	return err
+}
@@ -17,7 +17,9 @@
 package aws
 
 import (
+	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -36,26 +38,43 @@ type Change struct {
 	*route53.Change
 	Done        provider.DoneHandler
 	UpdateGroup string
+
+	// failover is the routing policy of a failover record set, whose health check is assigned on submit
+	failover *dns.RoutingPolicy
+	// createdHealthCheck is the managed health check created for this change (deleted if the change fails)
+	createdHealthCheck string
+	// obsoleteHealthCheck is the managed health check no longer used after this change (deleted if the change succeeds)
+	obsoleteHealthCheck string
 }
 
 type Execution struct {
 	logger.LogContext
+	ctx         context.Context
 	r53         *route53.Route53
 	rateLimiter flowcontrol.RateLimiter
+	resolver    *aliasTargetResolver
 	zone        provider.DNSHostedZone
 
 	changes   map[string][]*Change
 	batchSize int
+
+	healthChecks        *healthCheckCache
+	createdHealthChecks map[string]string
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
+func NewExecution(ctx context.Context, logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
 	return &Execution{
 		LogContext:  logger,
+		ctx:         ctx,
 		r53:         h.r53,
 		rateLimiter: h.config.RateLimiter,
+		resolver:    h.resolver,
 		zone:        zone,
 		changes:     map[string][]*Change{},
-		batchSize:   h.awsConfig.BatchSize,
+		batchSize:   h.getAWSConfig().BatchSize,
+
+		healthChecks:        h.healthChecks,
+		createdHealthChecks: map[string]string{},
 	}
 }
 
@@ -75,15 +94,48 @@ func buildResourceRecordSet(name string, rset *dns.RecordSet) *route53.ResourceR
 
 func (this *Execution) addChange(action string, req *provider.ChangeRequest, dnsset *dns.DNSSet) {
 	name, rset := dns.MapToProvider(req.Type, dnsset, this.zone.Domain())
-	name = dns.AlignHostname(name)
+	this.addRecordSetChange(action, req, dns.AlignHostname(name), dnsset.UpdateGroup, rset)
+}
+
+// addUpdate upserts the new record set and deletes the parts of the old one,
+// which are not replaced because of a changed routing policy or set identifiers.
+func (this *Execution) addUpdate(req *provider.ChangeRequest) {
+	this.addChange(route53.ChangeActionUpsert, req, req.Addition)
+	if req.Deletion == nil {
+		return
+	}
+	name, oldset := dns.MapToProvider(req.Type, req.Deletion, this.zone.Domain())
+	_, newset := dns.MapToProvider(req.Type, req.Addition, this.zone.Domain())
+	if obsolete := obsoleteRecordSet(oldset, newset); obsolete != nil {
+		this.addRecordSetChange(route53.ChangeActionDelete, req, dns.AlignHostname(name), req.Deletion.UpdateGroup, obsolete)
+	}
+}
+
+func (this *Execution) addRecordSetChange(action string, req *provider.ChangeRequest, name, updateGroup string, rset *dns.RecordSet) {
 	if len(rset.Records) == 0 {
 		return
 	}
 	this.Infof("%s %s record set %s[%s]: %s(%d)", action, rset.Type, name, this.zone.Id(), rset.RecordString(), rset.TTL)
 
+	if rset.RoutingPolicy != nil && rset.RoutingPolicy.Type == dns.RP_MULTIVALUE {
+		rrsets, err := buildResourceRecordSetsForRoutingPolicy(name, rset)
+		if err != nil {
+			this.Errorf("Invalid record set %s[%s] with routing policy %s: %s", name, this.zone.Id(), rset.RoutingPolicy, err)
+			if req.Done != nil {
+				req.Done.SetInvalid(err)
+			}
+			return
+		}
+		for _, rrs := range rrsets {
+			change := &route53.Change{Action: aws.String(action), ResourceRecordSet: rrs}
+			this.addRawChange(name, updateGroup, change, req.Done)
+		}
+		return
+	}
+
 	var rrs *route53.ResourceRecordSet
 	if rset.Type == dns.RS_ALIAS {
-		rrs = buildResourceRecordSetForAliasTarget(name, rset)
+		rrs = buildResourceRecordSetForAliasTarget(this.resolver, name, rset)
 		if rrs == nil {
 			this.Errorf("Corrupted alias record set %s[%s]", name, this.zone.Id())
 			return
@@ -91,13 +143,27 @@ func (this *Execution) addChange(action string, req *provider.ChangeRequest, dns
 	} else {
 		rrs = buildResourceRecordSet(name, rset)
 	}
+	if rset.RoutingPolicy != nil {
+		if err := applyRoutingPolicy(rrs, rset.RoutingPolicy); err != nil {
+			this.Errorf("Invalid record set %s[%s] with routing policy %s: %s", name, this.zone.Id(), rset.RoutingPolicy, err)
+			if req.Done != nil {
+				req.Done.SetInvalid(err)
+			}
+			return
+		}
+	}
 
 	change := &route53.Change{Action: aws.String(action), ResourceRecordSet: rrs}
-	this.addRawChange(name, dnsset.UpdateGroup, change, req.Done)
+	c := this.addRawChange(name, updateGroup, change, req.Done)
+	if rset.RoutingPolicy != nil && rset.RoutingPolicy.Type == dns.RP_FAILOVER {
+		c.failover = rset.RoutingPolicy
+	}
 }
 
-func (this *Execution) addRawChange(name, updateGroup string, change *route53.Change, done provider.DoneHandler) {
-	this.changes[name] = append(this.changes[name], &Change{Change: change, Done: done, UpdateGroup: updateGroup})
+func (this *Execution) addRawChange(name, updateGroup string, change *route53.Change, done provider.DoneHandler) *Change {
+	c := &Change{Change: change, Done: done, UpdateGroup: updateGroup}
+	this.changes[name] = append(this.changes[name], c)
+	return c
 }
 
 func (this *Execution) submitChanges(metrics provider.Metrics) error {
@@ -111,11 +177,19 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	this.Infof("require %d batches for %d dns names", len(limitedChanges), len(this.changes))
 	for i, changes := range limitedChanges {
 		this.Infof("processing batch %d for zone %s with %d requests", i+1, this.zone.Id(), len(changes))
+		changes, failedHealthChecks := this.assignHealthChecks(metrics, changes)
+		failed += failedHealthChecks
+		if len(changes) == 0 {
+			continue
+		}
 		for _, c := range changes {
 			extraInfo := ""
 			if c.ResourceRecordSet.AliasTarget != nil {
 				extraInfo = fmt.Sprintf(" (alias target hosted zone %s)", *c.ResourceRecordSet.AliasTarget.HostedZoneId)
 			}
+			if c.ResourceRecordSet.SetIdentifier != nil {
+				extraInfo = fmt.Sprintf(" (set identifier %s)", *c.ResourceRecordSet.SetIdentifier)
+			}
 			this.Infof("desired change: %s %s %s%s", *c.Action, *c.ResourceRecordSet.Name, *c.ResourceRecordSet.Type, extraInfo)
 		}
 
@@ -129,7 +203,7 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 		metrics.AddZoneRequests(this.zone.Id().ID, provider.M_UPDATERECORDS, 1)
 		this.rateLimiter.Accept()
 		var succeededChanges, failedChanges []*Change
-		_, err := this.r53.ChangeResourceRecordSets(params)
+		_, err := this.r53.ChangeResourceRecordSetsWithContext(this.ctx, params)
 		if err != nil {
 			failedChanges = changes
 			if b, ok := err.(awserr.BatchedErrors); ok {
@@ -151,6 +225,9 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 				}
 			}
 			this.Errorf("%d records in zone %s fail: %s", len(changes), this.zone.Id(), err)
+			for _, c := range failedChanges {
+				this.deleteHealthCheck(c.createdHealthCheck)
+			}
 		}
 		if len(succeededChanges) > 0 {
 			for _, c := range succeededChanges {
@@ -159,6 +236,9 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 				}
 			}
 			this.Infof("%d records in zone %s were successfully updated", len(succeededChanges), this.zone.Id())
+			for _, c := range succeededChanges {
+				this.deleteHealthCheck(c.obsoleteHealthCheck)
+			}
 		}
 	}
 	if failed > 0 {
@@ -171,6 +251,131 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	return nil
 }
 
+// assignHealthChecks assigns the health checks to the failover record sets of a batch. Managed health checks are
+// created if the health check parameters have changed, the replaced ones are deleted after a successful change.
+func (this *Execution) assignHealthChecks(metrics provider.Metrics, changes []*Change) ([]*Change, int) {
+	var valid []*Change
+	failed := 0
+	for _, c := range changes {
+		if c.failover == nil {
+			valid = append(valid, c)
+			continue
+		}
+		if err := this.assignHealthCheck(metrics, c); err != nil {
+			this.Errorf("cannot assign health check to %s %s (set identifier %s): %s",
+				*c.ResourceRecordSet.Name, *c.ResourceRecordSet.Type, c.failover.SetIdentifier, err)
+			failed++
+			if c.Done != nil {
+				c.Done.Failed(err)
+			}
+			continue
+		}
+		valid = append(valid, c)
+	}
+	return valid, failed
+}
+
+func (this *Execution) assignHealthCheck(metrics provider.Metrics, c *Change) error {
+	current := ""
+	if *c.Action != route53.ChangeActionCreate {
+		id, err := this.currentHealthCheckID(metrics, c.ResourceRecordSet)
+		if err != nil {
+			return err
+		}
+		current = id
+	}
+	var currentParameters map[string]string
+	if current != "" {
+		parameters, err := this.healthChecks.getParameters(this.ctx, current)
+		if err != nil {
+			return err
+		}
+		currentParameters = parameters
+	}
+
+	desired := managedHealthCheckParameters(c.failover)
+	if *c.Action == route53.ChangeActionDelete {
+		if desired != nil && current != "" {
+			c.ResourceRecordSet.HealthCheckId = aws.String(current)
+		}
+		if currentParameters != nil {
+			c.obsoleteHealthCheck = current
+		}
+		return nil
+	}
+	if desired != nil {
+		if currentParameters != nil && reflect.DeepEqual(currentParameters, desired) {
+			c.ResourceRecordSet.HealthCheckId = aws.String(current)
+			return nil
+		}
+		key := encodeHealthCheckParameters(desired)
+		id := this.createdHealthChecks[key]
+		if id == "" {
+			var err error
+			id, err = this.healthChecks.create(this.ctx, *c.ResourceRecordSet.Name, c.failover)
+			if err != nil {
+				return err
+			}
+			this.Infof("created health check %s for %s (set identifier %s)", id, *c.ResourceRecordSet.Name, c.failover.SetIdentifier)
+			this.createdHealthChecks[key] = id
+			c.createdHealthCheck = id
+		}
+		c.ResourceRecordSet.HealthCheckId = aws.String(id)
+	}
+	if currentParameters != nil && current != aws.StringValue(c.ResourceRecordSet.HealthCheckId) {
+		c.obsoleteHealthCheck = current
+	}
+	return nil
+}
+
+// currentHealthCheckID returns the id of the health check currently assigned to a failover record set.
+func (this *Execution) currentHealthCheckID(metrics provider.Metrics, rrs *route53.ResourceRecordSet) (string, error) {
+	metrics.AddZoneRequests(this.zone.Id().ID, provider.M_LISTRECORDS, 1)
+	this.rateLimiter.Accept()
+	output, err := this.r53.ListResourceRecordSetsWithContext(this.ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:          aws.String(this.zone.Id().ID),
+		MaxItems:              aws.String("1"),
+		StartRecordIdentifier: rrs.SetIdentifier,
+		StartRecordName:       rrs.Name,
+		StartRecordType:       rrs.Type,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, r := range output.ResourceRecordSets {
+		if dns.NormalizeHostname(aws.StringValue(r.Name)) == dns.NormalizeHostname(*rrs.Name) &&
+			aws.StringValue(r.Type) == *rrs.Type && aws.StringValue(r.SetIdentifier) == *rrs.SetIdentifier {
+			return aws.StringValue(r.HealthCheckId), nil
+		}
+	}
+	return "", nil
+}
+
+// deleteHealthCheck deletes a managed health check. Health checks still in use are kept.
+func (this *Execution) deleteHealthCheck(id string) {
+	if id == "" {
+		return
+	}
+	deleted, err := this.healthChecks.delete(this.ctx, id)
+	if err != nil {
+		if isAWSErrorCode(err, route53.ErrCodeHealthCheckInUse) {
+			this.Infof("health check %s still in use", id)
+			return
+		}
+		this.Warnf("cannot delete health check %s: %s", id, err)
+		return
+	}
+	if !deleted {
+		return
+	}
+	for k, v := range this.createdHealthChecks {
+		if v == id {
+			delete(this.createdHealthChecks, k)
+		}
+	}
+	this.Infof("deleted health check %s", id)
+}
+
 var patternNotFound = regexp.MustCompile("Tried to delete resource record set \\[name='([^']+)', type='([^']+)'\\] but it was not found")
 var patternExists = regexp.MustCompile("Tried to create resource record set \\[name='([^']+)', type='([^']+)'\\] but it already exists")
 
@@ -212,7 +417,7 @@ outer:
 				Changes: mapChanges(unclear),
 			},
 		}
-		_, err = this.r53.ChangeResourceRecordSets(params)
+		_, err = this.r53.ChangeResourceRecordSetsWithContext(this.ctx, params)
 		if err != nil {
 			failed = append(failed, unclear...)
 		} else {
@@ -223,7 +428,7 @@ outer:
 }
 
 func (this *Execution) isFetchedRecordSetEqual(change *Change) bool {
-	output, err := this.r53.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	output, err := this.r53.ListResourceRecordSetsWithContext(this.ctx, &route53.ListResourceRecordSetsInput{
 		HostedZoneId:          aws.String(this.zone.Id().ID),
 		MaxItems:              aws.String("1"),
 		StartRecordIdentifier: nil,
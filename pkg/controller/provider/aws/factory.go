@@ -30,13 +30,15 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var advancedDefaults = provider.AdvancedOptions{
-	BatchSize:  50,
-	MaxRetries: 7,
+	BatchSize:            50,
+	MaxRetries:           7,
+	ZoneStateConcurrency: 4,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetProviderConfigSchemaByExample(&AWSConfig{})
 
 func init() {
 	compound.MustRegister(Factory)
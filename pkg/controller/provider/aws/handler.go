@@ -17,9 +17,13 @@
 package aws
 
 import (
+	"context"
 	"encoding/json"
+	goerrors "errors"
 	"fmt"
+	"reflect"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
@@ -36,29 +40,55 @@ import (
 type Handler struct {
 	provider.DefaultDNSHandler
 	config    provider.DNSHandlerConfig
+	lock      sync.Mutex
 	awsConfig AWSConfig
 	cache     provider.ZoneCache
 	sess      *session.Session
+	creds     *rotatableCredentials
 	r53       *route53.Route53
+	resolver  *aliasTargetResolver
+	cancel    context.CancelFunc
+
+	queryMetrics *queryMetrics
+	healthChecks *healthCheckCache
+	assumeRole   assumeRoleConfig
 }
 
 type AWSConfig struct {
 	BatchSize int `json:"batchSize"`
+	// ChangeNotifications optionally configures the consumption of Route53 change notifications
+	ChangeNotifications *ChangeNotificationsConfig `json:"changeNotifications,omitempty"`
+	// QueryMetrics optionally configures the ingestion of query metrics from the Route53 query logs
+	QueryMetrics *QueryMetricsConfig `json:"queryMetrics,omitempty"`
+	// DNSSEC optionally configures the key signing key used if DNSSEC is enabled for the provider
+	DNSSEC *DNSSECConfig `json:"dnssec,omitempty"`
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.RoutingPolicySupport = &Handler{}
+var _ provider.ConfigUpdateSupport = &Handler{}
+var _ provider.CredentialsUpdateSupport = &Handler{}
+var _ provider.DedicatedDNSAccess = &Handler{}
 
-func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
-	advancedConfig := c.Options.AdvancedOptions.GetAdvancedConfig()
-	c.Logger.Infof("advanced options: %s", advancedConfig)
-
+func parseAWSConfig(c *provider.DNSHandlerConfig, advancedConfig provider.AdvancedConfig) (AWSConfig, error) {
 	awsConfig := AWSConfig{BatchSize: advancedConfig.BatchSize}
 	if c.Config != nil {
 		err := json.Unmarshal(c.Config.Raw, &awsConfig)
 		if err != nil {
-			return nil, fmt.Errorf("unmarshal aws-route providerConfig failed with: %s", err)
+			return awsConfig, fmt.Errorf("unmarshal aws-route providerConfig failed with: %s", err)
 		}
 	}
+	return awsConfig, nil
+}
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	advancedConfig := c.Options.AdvancedOptions.GetAdvancedConfig()
+	c.Logger.Infof("advanced options: %s", advancedConfig)
+
+	awsConfig, err := parseAWSConfig(c, advancedConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	h := &Handler{
 		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
@@ -67,40 +97,47 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	}
 
 	var creds *credentials.Credentials
-	useCredentialsChain, err := c.GetDefaultedBoolProperty("AWS_USE_CREDENTIALS_CHAIN", false)
+	ownAccessKeyID := ""
+	value, err := readStaticCredentials(c)
 	if err != nil {
-		return nil, fmt.Errorf("invalid value for AWS_USE_CREDENTIALS_CHAIN: %s", err)
+		return nil, err
 	}
-	if !useCredentialsChain {
-		accessKeyID, err := c.GetRequiredProperty("AWS_ACCESS_KEY_ID", "accessKeyID")
-		if err != nil {
-			return nil, err
-		}
-		c.Logger.Infof("creating aws-route53 handler for %s", accessKeyID)
-		secretAccessKey, err := c.GetRequiredProperty("AWS_SECRET_ACCESS_KEY", "secretAccessKey")
-		if err != nil {
-			return nil, err
-		}
-		token := c.GetProperty("AWS_SESSION_TOKEN")
-		creds = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, token)
+	if value != nil {
+		c.Logger.Infof("creating aws-route53 handler for %s", value.AccessKeyID)
+		h.creds = newRotatableCredentials(*value)
+		creds = credentials.NewCredentials(h.creds)
+		ownAccessKeyID = value.AccessKeyID
+	} else if podIdentity := newPodIdentityCredentials(); podIdentity != nil {
+		c.Logger.Infof("creating aws-route53 handler using EKS pod identity")
+		creds = credentials.NewCredentials(podIdentity)
 	} else {
-		if c.GetProperty("AWS_ACCESS_KEY_ID", "accessKeyID") != "" {
-			return nil, fmt.Errorf("explicit credentials (AWS_ACCESS_KEY_ID or accessKeyID) cannot be used together with AWS_USE_CREDENTIALS_CHAIN=true")
-		}
 		c.Logger.Infof("creating aws-route53 handler using the chain of credential providers")
 	}
 
-	region := c.GetProperty("AWS_REGION", "region")
+	region := getRegion(c)
 	var endpoint *string
-	if region == "" {
-		region = "us-west-2"
-	}
 	if strings.HasPrefix(region, "us-gov-") {
 		endpoint = aws.String("route53.us-gov.amazonaws.com")
 	}
 
 	// change maxRetries to avoid paging stops because of throttling
 	maxRetries := advancedConfig.MaxRetries
+	h.assumeRole = readAssumeRoleConfig(c)
+	if h.assumeRole.RoleARN != "" {
+		c.Logger.Infof("assuming role %s", h.assumeRole.RoleARN)
+		// the STS client must not use the Route53 endpoint
+		stsSess, err := session.NewSession(&aws.Config{
+			Region:      aws.String(region),
+			Credentials: creds,
+			MaxRetries:  &maxRetries,
+		})
+		if err != nil {
+			return nil, err
+		}
+		creds = h.assumeRole.credentials(stsSess)
+		// changes are done by the session of the assumed role
+		ownAccessKeyID = ""
+	}
 	sess, err := session.NewSession(&aws.Config{
 		Region:      aws.String(region),
 		Credentials: creds,
@@ -112,24 +149,103 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	}
 	h.sess = sess
 	h.r53 = route53.New(sess)
+	h.resolver = newAliasTargetResolver(c.Context, c.Logger, sess)
+	h.healthChecks = newHealthCheckCache(h.r53, c.RateLimiter, c.Metrics)
 
-	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
+	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheSharedZoneState, c.Metrics, h.getZones, h.getZoneState)
 	if err != nil {
 		return nil, err
 	}
 
+	if awsConfig.ChangeNotifications != nil {
+		receiver, err := newSQSNotificationReceiver(sess, awsConfig.ChangeNotifications)
+		if err != nil {
+			return nil, err
+		}
+		var ctx context.Context
+		ctx, h.cancel = context.WithCancel(c.Context)
+		provider.StartZoneChangeNotificationConsumer(ctx, c.Logger, h.ProviderType(), h.cache, receiver, ownAccessKeyID)
+	}
+
+	if awsConfig.QueryMetrics != nil {
+		h.queryMetrics = newQueryMetrics(sess, awsConfig.QueryMetrics)
+	}
+
 	return h, nil
 }
 
+// UpdateConfig takes over a changed batch size or DNSSEC configuration.
+// Changes of the change notifications or query metrics require a new handler.
+func (h *Handler) UpdateConfig(c *provider.DNSHandlerConfig) error {
+	awsConfig, err := parseAWSConfig(c, c.Options.AdvancedOptions.GetAdvancedConfig())
+	if err != nil {
+		return err
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if !reflect.DeepEqual(awsConfig.ChangeNotifications, h.awsConfig.ChangeNotifications) {
+		return fmt.Errorf("change notifications modified")
+	}
+	if !reflect.DeepEqual(awsConfig.QueryMetrics, h.awsConfig.QueryMetrics) {
+		return fmt.Errorf("query metrics modified")
+	}
+	h.awsConfig = awsConfig
+	return nil
+}
+
+// UpdateCredentials swaps rotated static credentials, the session and its clients are kept.
+// Switching to or from the chain of credential providers, a changed region, a changed assumed role
+// or a changed access key used to filter own changes of the change notifications require a new handler.
+func (h *Handler) UpdateCredentials(c *provider.DNSHandlerConfig) error {
+	value, err := readStaticCredentials(c)
+	if err != nil {
+		return err
+	}
+	if value == nil || h.creds == nil {
+		return fmt.Errorf("chain of credential providers used")
+	}
+	if region := getRegion(c); region != aws.StringValue(h.sess.Config.Region) {
+		return fmt.Errorf("region modified")
+	}
+	if readAssumeRoleConfig(c) != h.assumeRole {
+		return fmt.Errorf("assumed role modified")
+	}
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.awsConfig.ChangeNotifications != nil && value.AccessKeyID != h.creds.accessKeyID() {
+		return fmt.Errorf("access key of change notifications modified")
+	}
+	h.creds.rotate(*value)
+	c.Logger.Infof("rotated aws-route53 credentials to %s", value.AccessKeyID)
+	return nil
+}
+
+func getRegion(c *provider.DNSHandlerConfig) string {
+	region := c.GetProperty("AWS_REGION", "region")
+	if region == "" {
+		region = "us-west-2"
+	}
+	return region
+}
+
+func (h *Handler) getAWSConfig() AWSConfig {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.awsConfig
+}
+
 func (h *Handler) Release() {
+	if h.cancel != nil {
+		h.cancel()
+	}
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 
 	rt := provider.M_LISTZONES
@@ -150,7 +266,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	}
 
 	h.config.RateLimiter.Accept()
-	err := h.r53.ListHostedZonesPages(&route53.ListHostedZonesInput{}, aggr)
+	err := h.r53.ListHostedZonesPagesWithContext(ctx, &route53.ListHostedZonesInput{}, aggr)
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +283,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), id, dns.NormalizeHostname(domain), aws.StringValue(z.Id), []string{}, isPrivateZone)
 
 		// call GetZoneState for side effect to calculate forwarded domains
-		_, err := cache.GetZoneState(hostedZone)
+		_, err := cache.GetZoneState(ctx, hostedZone)
 		if err == nil {
 			forwarded := cache.ForwardedDomainsCache().Get(hostedZone.Id())
 			if forwarded != nil {
@@ -200,15 +316,26 @@ func buildRecordSet(r *route53.ResourceRecordSet) *dns.RecordSet {
 	return rs
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
+	var routedErr error
 	aggr := func(r *route53.ResourceRecordSet) {
-		if dns.SupportedRecordType(aws.StringValue(r.Type)) {
+		if h.SupportsRecordType(aws.StringValue(r.Type)) {
+			if isMultiValueAnswer(r) {
+				addMultiValueAnswerRecordSet(dnssets, r)
+				return
+			}
+			if isRoutedRecordSet(r) {
+				if err := h.addRoutedRecordSet(ctx, dnssets, r); err != nil && routedErr == nil {
+					routedErr = err
+				}
+				return
+			}
 			var rs *dns.RecordSet
 			if isAliasTarget(r) {
 				rs = buildRecordSetFromAliasTarget(r)
@@ -218,20 +345,23 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 			dnssets.AddRecordSetFromProvider(aws.StringValue(r.Name), rs)
 		}
 	}
-	forwarded, err := h.handleRecordSets(zone, aggr)
+	forwarded, err := h.handleRecordSets(ctx, zone, aggr)
 	if err != nil {
 		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == "NoSuchHostedZone" {
 			err = &errors.NoSuchHostedZone{ZoneId: zone.Id().ID, Err: err}
 		}
 		return nil, err
 	}
+	if routedErr != nil {
+		return nil, routedErr
+	}
 
 	cache.ForwardedDomainsCache().Set(zone.Id(), forwarded)
 
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
-func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(rs *route53.ResourceRecordSet)) ([]string, error) {
+func (h *Handler) handleRecordSets(ctx context.Context, zone provider.DNSHostedZone, f func(rs *route53.ResourceRecordSet)) ([]string, error) {
 	rt := provider.M_LISTRECORDS
 	inp := (&route53.ListResourceRecordSetsInput{MaxItems: aws.String("300")}).SetHostedZoneId(zone.Id().ID)
 	forwarded := []string{}
@@ -254,7 +384,7 @@ func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(rs *route
 		return true
 	}
 	h.config.RateLimiter.Accept()
-	err := h.r53.ListResourceRecordSetsPages(inp, aggr)
+	err := h.r53.ListResourceRecordSetsPagesWithContext(ctx, inp, aggr)
 	return forwarded, err
 }
 
@@ -262,21 +392,21 @@ func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	exec := NewExecution(logger, h, zone)
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := NewExecution(ctx, logger, h, zone)
 
 	for _, r := range reqs {
 		switch r.Action {
 		case provider.R_CREATE:
 			exec.addChange(route53.ChangeActionCreate, r, r.Addition)
 		case provider.R_UPDATE:
-			exec.addChange(route53.ChangeActionUpsert, r, r.Addition)
+			exec.addUpdate(r)
 		case provider.R_DELETE:
 			exec.addChange(route53.ChangeActionDelete, r, r.Deletion)
 		}
@@ -290,14 +420,56 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 func (h *Handler) MapTarget(t provider.Target) provider.Target {
 	if t.GetRecordType() == dns.RS_CNAME {
-		hostedZone := canonicalHostedZone(t.GetHostName())
-		if hostedZone != "" {
+		if h.resolver.resolve(t.GetHostName()) != nil {
 			return dnsutils.NewTarget(dns.RS_ALIAS, t.GetHostName(), t.GetTTL())
 		}
 	}
 	return t
 }
 
+func (h *Handler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return validateRoutingPolicy(policy)
+}
+
+func (h *Handler) SupportedRoutingPolicyTypes() []string {
+	return []string{dns.RP_MULTIVALUE, dns.RP_WEIGHTED, dns.RP_GEOLOCATION, dns.RP_FAILOVER}
+}
+
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	switch rtype {
+	case dns.RS_SRV, dns.RS_NAPTR, dns.RS_SVCB, dns.RS_HTTPS, dns.RS_CAA:
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+// ClassifyError maps the error codes of the AWS API to error reasons.
+func (h *Handler) ClassifyError(err error) errors.ErrorReason {
+	var awsErr awserr.Error
+	if !goerrors.As(err, &awsErr) {
+		return errors.ReasonUnknown
+	}
+	switch awsErr.Code() {
+	case route53.ErrCodeThrottlingException, "Throttling", route53.ErrCodePriorRequestNotComplete:
+		return errors.ReasonThrottled
+	case "AccessDenied", "AccessDeniedException", "InvalidClientTokenId", "SignatureDoesNotMatch", "ExpiredToken", "UnrecognizedClientException":
+		return errors.ReasonAuthFailed
+	case route53.ErrCodeNoSuchHostedZone, route53.ErrCodeNoSuchHealthCheck:
+		return errors.ReasonNotFound
+	case route53.ErrCodeLimitsExceeded, route53.ErrCodeTooManyHealthChecks, route53.ErrCodeTooManyHostedZones:
+		return errors.ReasonQuotaExceeded
+	case route53.ErrCodeInvalidChangeBatch, route53.ErrCodeInvalidInput:
+		return errors.ReasonInvalidRecord
+	case route53.ErrCodeConcurrentModification:
+		return errors.ReasonConflict
+	}
+	var reqErr awserr.RequestFailure
+	if goerrors.As(err, &reqErr) {
+		return errors.ReasonForHTTPStatus(reqErr.StatusCode())
+	}
+	return errors.ReasonUnknown
+}
+
 // AssociateVPCWithHostedZone associates a VPC with a private hosted zone
 // in use by external controller
 func (h *Handler) AssociateVPCWithHostedZone(vpcId string, vpcRegion string, hostedZoneId string) (*route53.AssociateVPCWithHostedZoneOutput, error) {
@@ -379,9 +551,9 @@ func (h *Handler) DeleteVPCAssociationAuthorization(hostedZoneId string, vpcId s
 	return out, nil
 }
 
-func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
+func (h *Handler) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
 	name := dns.AlignHostname(dnsName)
-	sets, err := h.r53.ListResourceRecordSets(&route53.ListResourceRecordSetsInput{
+	sets, err := h.r53.ListResourceRecordSetsWithContext(ctx, &route53.ListResourceRecordSetsInput{
 		HostedZoneId:          aws.String(zone.Id().ID),
 		MaxItems:              aws.String("1"),
 		StartRecordIdentifier: nil,
@@ -393,8 +565,19 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 	}
 
 	dnssets := dns.DNSSets{}
+	var routedErr error
 	aggr := func(r *route53.ResourceRecordSet) {
-		if dns.SupportedRecordType(aws.StringValue(r.Type)) {
+		if h.SupportsRecordType(aws.StringValue(r.Type)) {
+			if isMultiValueAnswer(r) {
+				addMultiValueAnswerRecordSet(dnssets, r)
+				return
+			}
+			if isRoutedRecordSet(r) {
+				if err := h.addRoutedRecordSet(ctx, dnssets, r); err != nil && routedErr == nil {
+					routedErr = err
+				}
+				return
+			}
 			var rs *dns.RecordSet
 			if isAliasTarget(r) {
 				rs = buildRecordSetFromAliasTarget(r)
@@ -409,22 +592,25 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 			aggr(r)
 		}
 	}
+	if routedErr != nil {
+		return nil, routedErr
+	}
 	if set := dnssets[dnsName]; set != nil {
 		return provider.FromDedicatedRecordSet(dnsName, set.Sets[recordType]), nil
 	}
 	return nil, nil
 }
 
-func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
-	return h.executeRecordSetChange(route53.ChangeActionUpsert, logger, zone, new)
+func (h *Handler) CreateOrUpdateRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
+	return h.executeRecordSetChange(ctx, route53.ChangeActionUpsert, logger, zone, new)
 }
 
-func (h *Handler) DeleteRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
-	return h.executeRecordSetChange(route53.ChangeActionDelete, logger, zone, rs)
+func (h *Handler) DeleteRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
+	return h.executeRecordSetChange(ctx, route53.ChangeActionDelete, logger, zone, rs)
 }
 
-func (h *Handler) executeRecordSetChange(action string, logger logger.LogContext, zone provider.DNSHostedZone, rawrs provider.DedicatedRecordSet) error {
-	exec := NewExecution(logger, h, zone)
+func (h *Handler) executeRecordSetChange(ctx context.Context, action string, logger logger.LogContext, zone provider.DNSHostedZone, rawrs provider.DedicatedRecordSet) error {
+	exec := NewExecution(ctx, logger, h, zone)
 	dnsName, rs := provider.ToDedicatedRecordset(rawrs)
 	dnsset := dns.NewDNSSet(dnsName)
 	dnsset.Sets[rs.Type] = rs
@@ -0,0 +1,269 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// healthCheckParameterPrefix is the prefix of the failover routing policy parameters
+// describing a health check managed by the provider (`healthCheck.<property>`)
+const healthCheckParameterPrefix = "healthCheck."
+
+// parameters of a managed health check
+const (
+	healthCheckTypeParameter = healthCheckParameterPrefix + "type"
+	healthCheckHostParameter = healthCheckParameterPrefix + "host"
+	healthCheckPortParameter = healthCheckParameterPrefix + "port"
+	healthCheckPathParameter = healthCheckParameterPrefix + "path"
+)
+
+// healthCheckParametersTagKey is the tag of a managed health check containing its routing policy parameters
+const healthCheckParametersTagKey = "dns.gardener.cloud/health-check-parameters"
+
+// buildHealthCheckConfig builds the configuration of a managed health check from the routing policy parameters.
+func buildHealthCheckConfig(policy *dns.RoutingPolicy) (*route53.HealthCheckConfig, error) {
+	config := &route53.HealthCheckConfig{
+		RequestInterval:  aws.Int64(30),
+		FailureThreshold: aws.Int64(3),
+	}
+	var port int64
+	switch t := policy.Parameters[healthCheckTypeParameter]; t {
+	case route53.HealthCheckTypeHttp:
+		port = 80
+	case route53.HealthCheckTypeHttps:
+		port = 443
+	case route53.HealthCheckTypeTcp:
+	case "":
+		return nil, fmt.Errorf("missing parameter %q for managed health check", healthCheckTypeParameter)
+	default:
+		return nil, fmt.Errorf("invalid health check type %q (expected %s, %s or %s)", t,
+			route53.HealthCheckTypeHttp, route53.HealthCheckTypeHttps, route53.HealthCheckTypeTcp)
+	}
+	config.Type = aws.String(policy.Parameters[healthCheckTypeParameter])
+	for k, v := range policy.Parameters {
+		if !strings.HasPrefix(k, healthCheckParameterPrefix) {
+			continue
+		}
+		switch k {
+		case healthCheckTypeParameter:
+		case healthCheckHostParameter:
+			if net.ParseIP(v) != nil {
+				config.IPAddress = aws.String(v)
+			} else if v != "" {
+				config.FullyQualifiedDomainName = aws.String(v)
+			}
+		case healthCheckPortParameter:
+			p, err := strconv.ParseInt(v, 10, 64)
+			if err != nil || p < 1 || p > 65535 {
+				return nil, fmt.Errorf("invalid health check port %q", v)
+			}
+			port = p
+		case healthCheckPathParameter:
+			if *config.Type == route53.HealthCheckTypeTcp {
+				return nil, fmt.Errorf("parameter %q not supported for health check type %s", k, *config.Type)
+			}
+			if !strings.HasPrefix(v, "/") {
+				return nil, fmt.Errorf("invalid health check path %q (must start with /)", v)
+			}
+			config.ResourcePath = aws.String(v)
+		default:
+			return nil, fmt.Errorf("unsupported health check parameter %q (expected %s, %s, %s or %s)", k,
+				healthCheckTypeParameter, healthCheckHostParameter, healthCheckPortParameter, healthCheckPathParameter)
+		}
+	}
+	if config.IPAddress == nil && config.FullyQualifiedDomainName == nil {
+		return nil, fmt.Errorf("missing parameter %q for managed health check", healthCheckHostParameter)
+	}
+	if port == 0 {
+		return nil, fmt.Errorf("missing parameter %q for health check type %s", healthCheckPortParameter, *config.Type)
+	}
+	config.Port = aws.Int64(port)
+	return config, nil
+}
+
+// managedHealthCheckParameters returns the parameters of the managed health check of a failover routing policy
+// or nil if the health check is not managed by the provider.
+func managedHealthCheckParameters(policy *dns.RoutingPolicy) map[string]string {
+	var parameters map[string]string
+	for k, v := range policy.Parameters {
+		if strings.HasPrefix(k, healthCheckParameterPrefix) {
+			if parameters == nil {
+				parameters = map[string]string{}
+			}
+			parameters[k] = v
+		}
+	}
+	return parameters
+}
+
+func encodeHealthCheckParameters(parameters map[string]string) string {
+	values := url.Values{}
+	for k, v := range parameters {
+		values.Set(k, v)
+	}
+	return values.Encode()
+}
+
+func decodeHealthCheckParameters(value string) (map[string]string, error) {
+	values, err := url.ParseQuery(value)
+	if err != nil {
+		return nil, err
+	}
+	parameters := map[string]string{}
+	for k := range values {
+		parameters[k] = values.Get(k)
+	}
+	return parameters, nil
+}
+
+func isAWSErrorCode(err error, code string) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == code
+}
+
+// healthCheckCache caches the routing policy parameters of health checks referenced by failover record sets.
+// The configuration of managed health checks is never changed, they are replaced instead.
+type healthCheckCache struct {
+	lock        sync.Mutex
+	r53         *route53.Route53
+	rateLimiter flowcontrol.RateLimiter
+	metrics     provider.Metrics
+	// parameters maps the health check id to the parameters of managed health checks (nil for foreign health checks)
+	parameters map[string]map[string]string
+}
+
+func newHealthCheckCache(r53 *route53.Route53, rateLimiter flowcontrol.RateLimiter, metrics provider.Metrics) *healthCheckCache {
+	return &healthCheckCache{
+		r53:         r53,
+		rateLimiter: rateLimiter,
+		metrics:     metrics,
+		parameters:  map[string]map[string]string{},
+	}
+}
+
+// getParameters returns the routing policy parameters of a managed health check or nil for foreign health checks.
+func (c *healthCheckCache) getParameters(ctx context.Context, id string) (map[string]string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if parameters, ok := c.parameters[id]; ok {
+		return parameters, nil
+	}
+	c.metrics.AddGenericRequests(provider.M_HEALTHCHECKS, 1)
+	c.rateLimiter.Accept()
+	output, err := c.r53.ListTagsForResourceWithContext(ctx, &route53.ListTagsForResourceInput{
+		ResourceId:   aws.String(id),
+		ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+	})
+	if err != nil {
+		if isAWSErrorCode(err, route53.ErrCodeNoSuchHealthCheck) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot get tags of health check %s: %w", id, err)
+	}
+	var parameters map[string]string
+	if output.ResourceTagSet != nil {
+		for _, tag := range output.ResourceTagSet.Tags {
+			if aws.StringValue(tag.Key) == healthCheckParametersTagKey {
+				parameters, err = decodeHealthCheckParameters(aws.StringValue(tag.Value))
+				if err != nil {
+					return nil, fmt.Errorf("invalid tag %s of health check %s: %w", healthCheckParametersTagKey, id, err)
+				}
+			}
+		}
+	}
+	c.parameters[id] = parameters
+	return parameters, nil
+}
+
+// create creates a managed health check for the failover record set.
+func (c *healthCheckCache) create(ctx context.Context, name string, policy *dns.RoutingPolicy) (string, error) {
+	config, err := buildHealthCheckConfig(policy)
+	if err != nil {
+		return "", err
+	}
+	parameters := managedHealthCheckParameters(policy)
+	reference := fmt.Sprintf("%s-%d", policy.SetIdentifier, time.Now().UnixNano())
+	if len(reference) > 64 {
+		reference = reference[len(reference)-64:]
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.metrics.AddGenericRequests(provider.M_HEALTHCHECKS, 2)
+	c.rateLimiter.Accept()
+	output, err := c.r53.CreateHealthCheckWithContext(ctx, &route53.CreateHealthCheckInput{
+		CallerReference:   aws.String(reference),
+		HealthCheckConfig: config,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot create health check for %s (set identifier %s): %w", name, policy.SetIdentifier, err)
+	}
+	id := aws.StringValue(output.HealthCheck.Id)
+	c.rateLimiter.Accept()
+	_, err = c.r53.ChangeTagsForResourceWithContext(ctx, &route53.ChangeTagsForResourceInput{
+		ResourceId:   aws.String(id),
+		ResourceType: aws.String(route53.TagResourceTypeHealthcheck),
+		AddTags: []*route53.Tag{
+			{Key: aws.String("Name"), Value: aws.String(fmt.Sprintf("%s (%s)", dns.NormalizeHostname(name), policy.SetIdentifier))},
+			{Key: aws.String(healthCheckParametersTagKey), Value: aws.String(encodeHealthCheckParameters(parameters))},
+		},
+	})
+	if err != nil {
+		c.rateLimiter.Accept()
+		_, _ = c.r53.DeleteHealthCheckWithContext(ctx, &route53.DeleteHealthCheckInput{HealthCheckId: aws.String(id)})
+		return "", fmt.Errorf("cannot tag health check %s: %w", id, err)
+	}
+	c.parameters[id] = parameters
+	return id, nil
+}
+
+// delete deletes a managed health check. Foreign health checks are never deleted.
+func (c *healthCheckCache) delete(ctx context.Context, id string) (bool, error) {
+	parameters, err := c.getParameters(ctx, id)
+	if err != nil || parameters == nil {
+		return false, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.metrics.AddGenericRequests(provider.M_HEALTHCHECKS, 1)
+	c.rateLimiter.Accept()
+	_, err = c.r53.DeleteHealthCheckWithContext(ctx, &route53.DeleteHealthCheckInput{HealthCheckId: aws.String(id)})
+	if err != nil && !isAWSErrorCode(err, route53.ErrCodeNoSuchHealthCheck) {
+		return false, err
+	}
+	delete(c.parameters, id)
+	return true, nil
+}
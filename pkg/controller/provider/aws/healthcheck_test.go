@@ -0,0 +1,494 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const route53Namespace = "https://route53.amazonaws.com/doc/2013-04-01/"
+
+type testTag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+type testRecordSet struct {
+	Name          string   `xml:"Name"`
+	Type          string   `xml:"Type"`
+	SetIdentifier string   `xml:"SetIdentifier,omitempty"`
+	Failover      string   `xml:"Failover,omitempty"`
+	TTL           int64    `xml:"TTL"`
+	Values        []string `xml:"ResourceRecords>ResourceRecord>Value"`
+	HealthCheckId string   `xml:"HealthCheckId,omitempty"`
+}
+
+type testChange struct {
+	Action    string        `xml:"Action"`
+	RecordSet testRecordSet `xml:"ResourceRecordSet"`
+}
+
+// testRoute53 serves the Route 53 API calls used for failover record sets with managed health checks
+// and for zone ownership markers.
+type testRoute53 struct {
+	lock sync.Mutex
+	next int
+	// healthChecks maps the ids of the existing health checks to their tags
+	healthChecks map[string][]testTag
+	// zoneTags maps the ids of the hosted zones to their tags
+	zoneTags    map[string][]testTag
+	tagChanges  int
+	records     []testRecordSet
+	changes     [][]testChange
+	deleted     []string
+	failChanges bool
+}
+
+func (s *testRoute53) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/2013-04-01/")
+	switch {
+	case r.Method == http.MethodPost && path == "healthcheck":
+		s.next++
+		id := fmt.Sprintf("hc-%d", s.next)
+		s.healthChecks[id] = nil
+		w.WriteHeader(http.StatusCreated)
+		writeXML(w, fmt.Sprintf(`<CreateHealthCheckResponse xmlns="%s"><HealthCheck><Id>%s</Id><CallerReference>ref</CallerReference>`+
+			`<HealthCheckVersion>1</HealthCheckVersion></HealthCheck></CreateHealthCheckResponse>`, route53Namespace, id))
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "healthcheck/"):
+		id := strings.TrimPrefix(path, "healthcheck/")
+		if _, ok := s.healthChecks[id]; !ok {
+			writeError(w, http.StatusNotFound, route53.ErrCodeNoSuchHealthCheck)
+			return
+		}
+		delete(s.healthChecks, id)
+		s.deleted = append(s.deleted, id)
+		writeXML(w, fmt.Sprintf(`<DeleteHealthCheckResponse xmlns="%s"/>`, route53Namespace))
+	case strings.HasPrefix(path, "tags/healthcheck/"):
+		id := strings.TrimPrefix(path, "tags/healthcheck/")
+		tags, ok := s.healthChecks[id]
+		if !ok {
+			writeError(w, http.StatusNotFound, route53.ErrCodeNoSuchHealthCheck)
+			return
+		}
+		if r.Method == http.MethodPost {
+			var req struct {
+				AddTags []testTag `xml:"AddTags>Tag"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			_ = xml.Unmarshal(body, &req)
+			s.healthChecks[id] = append(tags, req.AddTags...)
+			writeXML(w, fmt.Sprintf(`<ChangeTagsForResourceResponse xmlns="%s"/>`, route53Namespace))
+			return
+		}
+		data, _ := xml.Marshal(tags)
+		writeXML(w, fmt.Sprintf(`<ListTagsForResourceResponse xmlns="%s"><ResourceTagSet><ResourceId>%s</ResourceId>`+
+			`<ResourceType>healthcheck</ResourceType><Tags>%s</Tags></ResourceTagSet></ListTagsForResourceResponse>`,
+			route53Namespace, id, strings.ReplaceAll(string(data), "testTag", "Tag")))
+	case strings.HasPrefix(path, "tags/hostedzone/"):
+		id := strings.TrimPrefix(path, "tags/hostedzone/")
+		tags := s.zoneTags[id]
+		if r.Method == http.MethodPost {
+			var req struct {
+				AddTags []testTag `xml:"AddTags>Tag"`
+			}
+			body, _ := io.ReadAll(r.Body)
+			_ = xml.Unmarshal(body, &req)
+			for _, add := range req.AddTags {
+				found := false
+				for i := range tags {
+					if tags[i].Key == add.Key {
+						tags[i].Value, found = add.Value, true
+					}
+				}
+				if !found {
+					tags = append(tags, add)
+				}
+			}
+			s.zoneTags[id] = tags
+			s.tagChanges++
+			writeXML(w, fmt.Sprintf(`<ChangeTagsForResourceResponse xmlns="%s"/>`, route53Namespace))
+			return
+		}
+		data, _ := xml.Marshal(tags)
+		writeXML(w, fmt.Sprintf(`<ListTagsForResourceResponse xmlns="%s"><ResourceTagSet><ResourceId>%s</ResourceId>`+
+			`<ResourceType>hostedzone</ResourceType><Tags>%s</Tags></ResourceTagSet></ListTagsForResourceResponse>`,
+			route53Namespace, id, strings.ReplaceAll(string(data), "testTag", "Tag")))
+	case r.Method == http.MethodGet && strings.HasSuffix(path, "/rrset"):
+		q := r.URL.Query()
+		var data []byte
+		for _, rs := range s.records {
+			if rs.Name == q.Get("name") && rs.Type == q.Get("type") && rs.SetIdentifier == q.Get("identifier") {
+				data, _ = xml.Marshal(&rs)
+			}
+		}
+		writeXML(w, fmt.Sprintf(`<ListResourceRecordSetsResponse xmlns="%s"><ResourceRecordSets>%s</ResourceRecordSets>`+
+			`<IsTruncated>false</IsTruncated><MaxItems>1</MaxItems></ListResourceRecordSetsResponse>`,
+			route53Namespace, strings.ReplaceAll(string(data), "testRecordSet", "ResourceRecordSet")))
+	case r.Method == http.MethodPost && strings.HasSuffix(path, "/rrset/"):
+		var req struct {
+			Changes []testChange `xml:"ChangeBatch>Changes>Change"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		_ = xml.Unmarshal(body, &req)
+		if s.failChanges {
+			writeError(w, http.StatusBadRequest, "InvalidInput")
+			return
+		}
+		s.changes = append(s.changes, req.Changes)
+		writeXML(w, fmt.Sprintf(`<ChangeResourceRecordSetsResponse xmlns="%s"><ChangeInfo><Id>/change/C1</Id><Status>PENDING</Status>`+
+			`<SubmittedAt>2022-01-01T00:00:00Z</SubmittedAt></ChangeInfo></ChangeResourceRecordSetsResponse>`, route53Namespace))
+	default:
+		writeError(w, http.StatusNotFound, "NotFound")
+	}
+}
+
+func writeXML(w http.ResponseWriter, body string) {
+	_, _ = w.Write([]byte(body))
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.WriteHeader(status)
+	writeXML(w, fmt.Sprintf(`<ErrorResponse xmlns="%s"><Error><Type>Sender</Type><Code>%s</Code><Message>%s</Message></Error>`+
+		`<RequestId>req</RequestId></ErrorResponse>`, route53Namespace, code, code))
+}
+
+// addManagedHealthCheck adds a health check tagged with the given routing policy parameters.
+func (s *testRoute53) addManagedHealthCheck(id string, parameters map[string]string) {
+	s.healthChecks[id] = []testTag{{Key: healthCheckParametersTagKey, Value: encodeHealthCheckParameters(parameters)}}
+}
+
+type testDoneHandler struct {
+	succeeded int
+	failed    []error
+}
+
+func (h *testDoneHandler) SetInvalid(err error)         { h.failed = append(h.failed, err) }
+func (h *testDoneHandler) Failed(err error)             { h.failed = append(h.failed, err) }
+func (h *testDoneHandler) Throttled(_ time.Duration)    {}
+func (h *testDoneHandler) Blocked(_ string, _ []string) {}
+func (h *testDoneHandler) Succeeded()                   { h.succeeded++ }
+
+func newTestRoute53(t *testing.T) (*testRoute53, *route53.Route53) {
+	s := &testRoute53{healthChecks: map[string][]testTag{}, zoneTags: map[string][]testTag{}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:    aws.String(server.URL),
+		Region:      aws.String("us-east-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		MaxRetries:  aws.Int(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, route53.New(sess)
+}
+
+func newTestExecution(r53 *route53.Route53) *Execution {
+	rateLimiter := flowcontrol.NewFakeAlwaysRateLimiter()
+	return &Execution{
+		LogContext:          logger.New(),
+		ctx:                 context.TODO(),
+		r53:                 r53,
+		rateLimiter:         rateLimiter,
+		zone:                provider.NewDNSHostedZone(TYPE_CODE, "Z1", "example.com", "", nil, false),
+		changes:             map[string][]*Change{},
+		batchSize:           50,
+		healthChecks:        newHealthCheckCache(r53, rateLimiter, &provider.NullMetrics{}),
+		createdHealthChecks: map[string]string{},
+	}
+}
+
+func failoverSet(role string, parameters map[string]string) *dns.DNSSet {
+	p := map[string]string{roleParameter: role}
+	for k, v := range parameters {
+		p[k] = v
+	}
+	set := dns.NewDNSSet("service.example.com")
+	set.SetIdentifier = role
+	set.SetRecordSet(dns.RS_A, 60, "1.2.3.4")
+	set.Sets[dns.RS_A].RoutingPolicy = dns.NewRoutingPolicy(dns.RP_FAILOVER, p)
+	set.Sets[dns.RS_A].RoutingPolicy.SetIdentifier = role
+	return set
+}
+
+var httpsCheck = map[string]string{
+	healthCheckTypeParameter: "HTTPS",
+	healthCheckHostParameter: "1.2.3.4",
+	healthCheckPathParameter: "/healthz",
+}
+
+func TestBuildHealthCheckConfig(t *testing.T) {
+	RegisterTestingT(t)
+
+	policy := func(parameters map[string]string) *dns.RoutingPolicy {
+		return dns.NewRoutingPolicy(dns.RP_FAILOVER, parameters)
+	}
+
+	config, err := buildHealthCheckConfig(policy(httpsCheck))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(aws.StringValue(config.Type)).To(Equal(route53.HealthCheckTypeHttps))
+	Expect(aws.StringValue(config.IPAddress)).To(Equal("1.2.3.4"))
+	Expect(config.FullyQualifiedDomainName).To(BeNil())
+	Expect(aws.Int64Value(config.Port)).To(Equal(int64(443)))
+	Expect(aws.StringValue(config.ResourcePath)).To(Equal("/healthz"))
+
+	config, err = buildHealthCheckConfig(policy(map[string]string{
+		healthCheckTypeParameter: "TCP",
+		healthCheckHostParameter: "backend.example.com",
+		healthCheckPortParameter: "5432",
+	}))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(aws.StringValue(config.FullyQualifiedDomainName)).To(Equal("backend.example.com"))
+	Expect(aws.Int64Value(config.Port)).To(Equal(int64(5432)))
+
+	for _, parameters := range []map[string]string{
+		{healthCheckHostParameter: "1.2.3.4"},
+		{healthCheckTypeParameter: "UDP", healthCheckHostParameter: "1.2.3.4"},
+		{healthCheckTypeParameter: "HTTP"},
+		{healthCheckTypeParameter: "TCP", healthCheckHostParameter: "1.2.3.4"},
+		{healthCheckTypeParameter: "TCP", healthCheckHostParameter: "1.2.3.4", healthCheckPortParameter: "80", healthCheckPathParameter: "/"},
+		{healthCheckTypeParameter: "HTTP", healthCheckHostParameter: "1.2.3.4", healthCheckPortParameter: "70000"},
+		{healthCheckTypeParameter: "HTTP", healthCheckHostParameter: "1.2.3.4", healthCheckPathParameter: "healthz"},
+		{healthCheckTypeParameter: "HTTP", healthCheckHostParameter: "1.2.3.4", healthCheckParameterPrefix + "interval": "10"},
+	} {
+		_, err := buildHealthCheckConfig(policy(parameters))
+		Expect(err).To(HaveOccurred(), "%v", parameters)
+	}
+}
+
+func TestValidateFailover(t *testing.T) {
+	RegisterTestingT(t)
+
+	validate := func(parameters map[string]string) error {
+		p := dns.NewRoutingPolicy(dns.RP_FAILOVER, parameters)
+		p.SetIdentifier = "a"
+		return validateRoutingPolicy(p)
+	}
+	Expect(validate(map[string]string{roleParameter: rolePrimary})).To(Succeed())
+	Expect(validate(map[string]string{roleParameter: roleSecondary, healthCheckIDParameter: "foreign"})).To(Succeed())
+	managed := map[string]string{roleParameter: rolePrimary}
+	for k, v := range httpsCheck {
+		managed[k] = v
+	}
+	Expect(validate(managed)).To(Succeed())
+
+	Expect(validate(map[string]string{})).To(MatchError(ContainSubstring("missing parameter")))
+	Expect(validate(map[string]string{roleParameter: "tertiary"})).To(MatchError(ContainSubstring("invalid role")))
+	Expect(validate(map[string]string{roleParameter: rolePrimary, healthCheckIDParameter: ""})).To(HaveOccurred())
+	Expect(validate(map[string]string{roleParameter: rolePrimary, "weight": "1"})).To(MatchError(ContainSubstring("unsupported parameter")))
+	managed[healthCheckIDParameter] = "foreign"
+	Expect(validate(managed)).To(MatchError(ContainSubstring("mutually exclusive")))
+}
+
+func TestRestoreHealthCheckParameters(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.addManagedHealthCheck("managed", httpsCheck)
+	s.healthChecks["foreign"] = []testTag{{Key: "Name", Value: "other"}}
+	h := &Handler{healthChecks: newHealthCheckCache(r53, flowcontrol.NewFakeAlwaysRateLimiter(), &provider.NullMetrics{})}
+
+	dnssets := dns.DNSSets{}
+	record := func(id, role string) *route53.ResourceRecordSet {
+		return &route53.ResourceRecordSet{
+			Name:            aws.String("service.example.com."),
+			Type:            aws.String(dns.RS_A),
+			TTL:             aws.Int64(60),
+			SetIdentifier:   aws.String(role),
+			Failover:        aws.String(strings.ToUpper(role)),
+			HealthCheckId:   aws.String(id),
+			ResourceRecords: []*route53.ResourceRecord{{Value: aws.String("1.2.3.4")}},
+		}
+	}
+	Expect(h.addRoutedRecordSet(context.TODO(), dnssets, record("managed", rolePrimary))).To(Succeed())
+	Expect(h.addRoutedRecordSet(context.TODO(), dnssets, record("foreign", roleSecondary))).To(Succeed())
+
+	primary := dnssets[dns.DNSSetKey("service.example.com", rolePrimary)].Sets[dns.RS_A].RoutingPolicy
+	Expect(primary.Type).To(Equal(dns.RP_FAILOVER))
+	Expect(primary.SetIdentifier).To(Equal(rolePrimary))
+	Expect(primary.Parameters).To(Equal(map[string]string{
+		roleParameter:            rolePrimary,
+		healthCheckTypeParameter: "HTTPS",
+		healthCheckHostParameter: "1.2.3.4",
+		healthCheckPathParameter: "/healthz",
+	}))
+	secondary := dnssets[dns.DNSSetKey("service.example.com", roleSecondary)].Sets[dns.RS_A].RoutingPolicy
+	Expect(secondary.Parameters).To(Equal(map[string]string{roleParameter: roleSecondary, healthCheckIDParameter: "foreign"}))
+}
+
+func TestCreateFailoverRecordSetWithManagedHealthCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	primary := failoverSet(rolePrimary, httpsCheck)
+	exec.addChange(route53.ChangeActionCreate, provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, primary, done), primary)
+	secondary := failoverSet(roleSecondary, map[string]string{healthCheckIDParameter: "foreign"})
+	exec.addChange(route53.ChangeActionCreate, provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, secondary, done), secondary)
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(Succeed())
+	Expect(done.succeeded).To(Equal(2))
+	Expect(s.healthChecks).To(HaveKey("hc-1"))
+	Expect(s.healthChecks["hc-1"]).To(ContainElement(testTag{Key: healthCheckParametersTagKey, Value: encodeHealthCheckParameters(httpsCheck)}))
+	Expect(s.changes).To(HaveLen(1))
+	healthChecks := map[string]string{}
+	for _, c := range s.changes[0] {
+		Expect(c.Action).To(Equal(route53.ChangeActionCreate))
+		healthChecks[c.RecordSet.Failover] = c.RecordSet.HealthCheckId
+	}
+	Expect(healthChecks).To(Equal(map[string]string{"PRIMARY": "hc-1", "SECONDARY": "foreign"}))
+}
+
+func TestDeleteCreatedHealthCheckOnFailedChange(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.failChanges = true
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	primary := failoverSet(rolePrimary, httpsCheck)
+	exec.addChange(route53.ChangeActionCreate, provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, primary, done), primary)
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(HaveOccurred())
+	Expect(done.failed).To(HaveLen(1))
+	Expect(s.deleted).To(Equal([]string{"hc-1"}))
+	Expect(s.healthChecks).To(BeEmpty())
+}
+
+func TestReuseUnchangedManagedHealthCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.addManagedHealthCheck("hc-current", httpsCheck)
+	s.records = []testRecordSet{{Name: "service.example.com.", Type: dns.RS_A, SetIdentifier: rolePrimary, Failover: "PRIMARY",
+		TTL: 60, Values: []string{"1.2.3.4"}, HealthCheckId: "hc-current"}}
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	old := failoverSet(rolePrimary, httpsCheck)
+	new := failoverSet(rolePrimary, httpsCheck)
+	new.Sets[dns.RS_A].TTL = 120
+	exec.addUpdate(provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, old, new, done))
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(Succeed())
+	Expect(done.succeeded).To(Equal(1))
+	Expect(s.changes).To(HaveLen(1))
+	Expect(s.changes[0][0].RecordSet.HealthCheckId).To(Equal("hc-current"))
+	Expect(s.next).To(Equal(0))
+	Expect(s.deleted).To(BeEmpty())
+}
+
+func TestReplaceChangedManagedHealthCheck(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.addManagedHealthCheck("hc-current", httpsCheck)
+	s.records = []testRecordSet{{Name: "service.example.com.", Type: dns.RS_A, SetIdentifier: rolePrimary, Failover: "PRIMARY",
+		TTL: 60, Values: []string{"1.2.3.4"}, HealthCheckId: "hc-current"}}
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	changed := map[string]string{}
+	for k, v := range httpsCheck {
+		changed[k] = v
+	}
+	changed[healthCheckPathParameter] = "/ready"
+	old := failoverSet(rolePrimary, httpsCheck)
+	new := failoverSet(rolePrimary, changed)
+	exec.addUpdate(provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, old, new, done))
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(Succeed())
+	Expect(s.changes[0][0].RecordSet.HealthCheckId).To(Equal("hc-1"))
+	Expect(s.deleted).To(Equal([]string{"hc-current"}))
+	Expect(s.healthChecks).To(HaveKey("hc-1"))
+}
+
+func TestWithdrawFailoverRecordSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.addManagedHealthCheck("hc-current", httpsCheck)
+	s.healthChecks["foreign"] = nil
+	s.records = []testRecordSet{
+		{Name: "service.example.com.", Type: dns.RS_A, SetIdentifier: rolePrimary, Failover: "PRIMARY",
+			TTL: 60, Values: []string{"1.2.3.4"}, HealthCheckId: "hc-current"},
+		{Name: "service.example.com.", Type: dns.RS_A, SetIdentifier: roleSecondary, Failover: "SECONDARY",
+			TTL: 60, Values: []string{"1.2.3.4"}, HealthCheckId: "foreign"},
+	}
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	primary := failoverSet(rolePrimary, httpsCheck)
+	exec.addChange(route53.ChangeActionDelete, provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, primary, nil, done), primary)
+	secondary := failoverSet(roleSecondary, map[string]string{healthCheckIDParameter: "foreign"})
+	exec.addChange(route53.ChangeActionDelete, provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, secondary, nil, done), secondary)
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(Succeed())
+	Expect(done.succeeded).To(Equal(2))
+	Expect(s.changes).To(HaveLen(1))
+	for _, c := range s.changes[0] {
+		Expect(c.Action).To(Equal(route53.ChangeActionDelete))
+		if c.RecordSet.Failover == "PRIMARY" {
+			// the deletion must match the current record set including its health check
+			Expect(c.RecordSet.HealthCheckId).To(Equal("hc-current"))
+		}
+	}
+	// only the managed health check is deleted together with the record set
+	Expect(s.deleted).To(Equal([]string{"hc-current"}))
+	Expect(s.healthChecks).To(HaveKey("foreign"))
+}
+
+func TestKeepManagedHealthCheckOnFailedWithdrawal(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, r53 := newTestRoute53(t)
+	s.addManagedHealthCheck("hc-current", httpsCheck)
+	s.records = []testRecordSet{{Name: "service.example.com.", Type: dns.RS_A, SetIdentifier: rolePrimary, Failover: "PRIMARY",
+		TTL: 60, Values: []string{"1.2.3.4"}, HealthCheckId: "hc-current"}}
+	s.failChanges = true
+	exec := newTestExecution(r53)
+	done := &testDoneHandler{}
+	primary := failoverSet(rolePrimary, httpsCheck)
+	exec.addChange(route53.ChangeActionDelete, provider.NewChangeRequest(provider.R_DELETE, dns.RS_A, primary, nil, done), primary)
+
+	Expect(exec.submitChanges(&provider.NullMetrics{})).To(HaveOccurred())
+	Expect(done.failed).To(HaveLen(1))
+	Expect(s.deleted).To(BeEmpty())
+	Expect(s.healthChecks).To(HaveKey("hc-current"))
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// ChangeNotificationsConfig configures the consumption of Route53 change notifications.
+type ChangeNotificationsConfig struct {
+	// SQSQueueURL is the URL of the SQS queue receiving the EventBridge events of
+	// Route53 API calls recorded by CloudTrail.
+	SQSQueueURL string `json:"sqsQueueURL"`
+	// Region is the region of the SQS queue (defaults to the region of the provider).
+	Region string `json:"region,omitempty"`
+}
+
+// cloudTrailEvent is the relevant part of an EventBridge event "AWS API Call via CloudTrail".
+type cloudTrailEvent struct {
+	Source     string `json:"source"`
+	DetailType string `json:"detail-type"`
+	Detail     struct {
+		EventName    string `json:"eventName"`
+		ErrorCode    string `json:"errorCode"`
+		UserIdentity struct {
+			AccessKeyID string `json:"accessKeyId"`
+		} `json:"userIdentity"`
+		RequestParameters struct {
+			HostedZoneID string `json:"hostedZoneId"`
+		} `json:"requestParameters"`
+	} `json:"detail"`
+}
+
+type sqsNotificationReceiver struct {
+	queueURL string
+	sqs      *sqs.SQS
+}
+
+var _ provider.ZoneChangeNotificationReceiver = &sqsNotificationReceiver{}
+
+func newSQSNotificationReceiver(sess *session.Session, cfg *ChangeNotificationsConfig) (*sqsNotificationReceiver, error) {
+	if cfg.SQSQueueURL == "" {
+		return nil, fmt.Errorf("missing sqsQueueURL for change notifications")
+	}
+	awsConfig := &aws.Config{}
+	if cfg.Region != "" {
+		awsConfig.Region = aws.String(cfg.Region)
+	}
+	return &sqsNotificationReceiver{queueURL: cfg.SQSQueueURL, sqs: sqs.New(sess, awsConfig)}, nil
+}
+
+func (r *sqsNotificationReceiver) Receive(ctx context.Context) ([]provider.ZoneChangeNotification, func(), error) {
+	out, err := r.sqs.ReceiveMessageWithContext(ctx, &sqs.ReceiveMessageInput{
+		QueueUrl:            aws.String(r.queueURL),
+		MaxNumberOfMessages: aws.Int64(10),
+		WaitTimeSeconds:     aws.Int64(20),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var notifications []provider.ZoneChangeNotification
+	var entries []*sqs.DeleteMessageBatchRequestEntry
+	for i, msg := range out.Messages {
+		entries = append(entries, &sqs.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(fmt.Sprintf("%d", i)),
+			ReceiptHandle: msg.ReceiptHandle,
+		})
+		if n := parseCloudTrailEvent(aws.StringValue(msg.Body)); n != nil {
+			notifications = append(notifications, *n)
+		}
+	}
+	ack := func() {
+		if len(entries) == 0 {
+			return
+		}
+		// unacknowledged messages are only delivered again, so errors can be ignored
+		_, _ = r.sqs.DeleteMessageBatchWithContext(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: aws.String(r.queueURL),
+			Entries:  entries,
+		})
+	}
+	return notifications, ack, nil
+}
+
+func parseCloudTrailEvent(body string) *provider.ZoneChangeNotification {
+	event := &cloudTrailEvent{}
+	if err := json.Unmarshal([]byte(body), event); err != nil {
+		return nil
+	}
+	if event.Source != "aws.route53" || event.Detail.ErrorCode != "" {
+		return nil
+	}
+	switch event.Detail.EventName {
+	case "ChangeResourceRecordSets", "DeleteHostedZone":
+	default:
+		return nil
+	}
+	comp := strings.Split(event.Detail.RequestParameters.HostedZoneID, "/")
+	return &provider.ZoneChangeNotification{
+		ZoneID:    comp[len(comp)-1],
+		Principal: event.Detail.UserIdentity.AccessKeyID,
+	}
+}
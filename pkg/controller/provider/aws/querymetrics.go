@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// QueryMetricsConfig configures the ingestion of DNS query metrics from the Route53 query logs.
+type QueryMetricsConfig struct {
+	// LogGroupName is the CloudWatch Logs log group of the query logs.
+	// By default, the log group of the query logging configuration of the hosted zone is used.
+	LogGroupName string `json:"logGroupName,omitempty"`
+	// Region is the region of the log group (defaults to us-east-1, the only region supported for public hosted zones).
+	Region string `json:"region,omitempty"`
+}
+
+// queryCountsInsightsQuery counts the queries per query name of a hosted zone. The fields of the Route53 query log
+// format are: log format version, timestamp, hosted zone id, query name, query type, response code, ...
+const queryCountsInsightsQuery = `parse @message "* * * * *" as version, timestamp, zone, name, rest | filter zone = %q | stats count(*) as queries by name | limit 10000`
+
+type queryMetrics struct {
+	config QueryMetricsConfig
+	logs   *cloudwatchlogs.CloudWatchLogs
+}
+
+func newQueryMetrics(sess *session.Session, cfg *QueryMetricsConfig) *queryMetrics {
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &queryMetrics{config: *cfg, logs: cloudwatchlogs.New(sess, &aws.Config{Region: aws.String(region)})}
+}
+
+var _ provider.QueryMetricsAccess = &Handler{}
+
+func (h *Handler) GetQueryCounts(ctx context.Context, zone provider.DNSHostedZone, start, end time.Time) (map[string]int64, error) {
+	if h.queryMetrics == nil {
+		return nil, nil
+	}
+	logGroupName, err := h.getQueryLogGroupName(ctx, zone)
+	if err != nil || logGroupName == "" {
+		return nil, err
+	}
+
+	h.config.RateLimiter.Accept()
+	started, err := h.queryMetrics.logs.StartQueryWithContext(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupName: aws.String(logGroupName),
+		QueryString:  aws.String(fmt.Sprintf(queryCountsInsightsQuery, zone.Id().ID)),
+		StartTime:    aws.Int64(start.Unix()),
+		EndTime:      aws.Int64(end.Unix()),
+	})
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_QUERYMETRICS, 1)
+	if err != nil {
+		return nil, fmt.Errorf("starting query on log group %s failed: %w", logGroupName, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(2 * time.Second):
+		}
+		out, err := h.queryMetrics.logs.GetQueryResultsWithContext(ctx, &cloudwatchlogs.GetQueryResultsInput{QueryId: started.QueryId})
+		if err != nil {
+			return nil, err
+		}
+		switch aws.StringValue(out.Status) {
+		case cloudwatchlogs.QueryStatusScheduled, cloudwatchlogs.QueryStatusRunning:
+			continue
+		case cloudwatchlogs.QueryStatusComplete:
+			return parseQueryCounts(out.Results), nil
+		default:
+			return nil, fmt.Errorf("query on log group %s ended with status %s", logGroupName, aws.StringValue(out.Status))
+		}
+	}
+}
+
+// getQueryLogGroupName returns the configured log group or the one of the query logging configuration of the zone.
+func (h *Handler) getQueryLogGroupName(ctx context.Context, zone provider.DNSHostedZone) (string, error) {
+	if h.queryMetrics.config.LogGroupName != "" {
+		return h.queryMetrics.config.LogGroupName, nil
+	}
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.ListQueryLoggingConfigsWithContext(ctx, &route53.ListQueryLoggingConfigsInput{HostedZoneId: aws.String(zone.Id().ID)})
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_QUERYMETRICS, 1)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range out.QueryLoggingConfigs {
+		// arn:aws:logs:<region>:<account>:log-group:<name>:*
+		arn := aws.StringValue(c.CloudWatchLogsLogGroupArn)
+		if i := strings.Index(arn, ":log-group:"); i >= 0 {
+			return strings.TrimSuffix(arn[i+len(":log-group:"):], ":*"), nil
+		}
+	}
+	return "", nil
+}
+
+func parseQueryCounts(results [][]*cloudwatchlogs.ResultField) map[string]int64 {
+	counts := map[string]int64{}
+	for _, row := range results {
+		name := ""
+		var count int64
+		for _, f := range row {
+			switch aws.StringValue(f.Field) {
+			case "name":
+				name = strings.ToLower(strings.TrimSuffix(aws.StringValue(f.Value), "."))
+			case "queries":
+				count, _ = strconv.ParseInt(aws.StringValue(f.Value), 10, 64)
+			}
+		}
+		if name != "" {
+			counts[name] += count
+		}
+	}
+	return counts
+}
@@ -0,0 +1,351 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// healthCheckIDParameterPrefix is the prefix of the routing policy parameters
+// assigning a health check to a record value (`healthCheckID.<value>`)
+const healthCheckIDParameterPrefix = "healthCheckID."
+
+// weightParameter is the routing policy parameter for the weight of a weighted record set
+const weightParameter = "weight"
+
+// maxWeight is the maximum weight of a weighted record set supported by Route53
+const maxWeight = 255
+
+// routing policy parameters for the location of a geolocation record set
+const (
+	continentParameter   = "continent"
+	countryParameter     = "country"
+	subdivisionParameter = "subdivision"
+)
+
+// defaultCountry is the country code of the default geolocation record set used for unmatched locations
+const defaultCountry = "*"
+
+// routing policy parameters of a failover record set
+const (
+	roleParameter          = "role"
+	healthCheckIDParameter = "healthCheckID"
+)
+
+// failover roles
+const (
+	rolePrimary   = "primary"
+	roleSecondary = "secondary"
+)
+
+func validateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	switch policy.Type {
+	case dns.RP_MULTIVALUE:
+		for k, v := range policy.Parameters {
+			if !strings.HasPrefix(k, healthCheckIDParameterPrefix) || len(k) == len(healthCheckIDParameterPrefix) {
+				return fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s<target>)", k, policy.Type, healthCheckIDParameterPrefix)
+			}
+			if v == "" {
+				return fmt.Errorf("empty health check id for parameter %q", k)
+			}
+		}
+		return nil
+	case dns.RP_WEIGHTED:
+		if err := validateSetIdentifier(policy); err != nil {
+			return err
+		}
+		for k := range policy.Parameters {
+			if k != weightParameter {
+				return fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s)", k, policy.Type, weightParameter)
+			}
+		}
+		_, err := parseWeight(policy)
+		return err
+	case dns.RP_GEOLOCATION:
+		if err := validateSetIdentifier(policy); err != nil {
+			return err
+		}
+		_, err := parseGeoLocation(policy)
+		return err
+	case dns.RP_FAILOVER:
+		if err := validateSetIdentifier(policy); err != nil {
+			return err
+		}
+		return validateFailover(policy)
+	default:
+		return fmt.Errorf("routing policy %s not supported by provider type %s", policy.Type, TYPE_CODE)
+	}
+}
+
+func validateSetIdentifier(policy *dns.RoutingPolicy) error {
+	if policy.SetIdentifier == "" {
+		return fmt.Errorf("missing set identifier for routing policy %s", policy.Type)
+	}
+	if len(policy.SetIdentifier) > 128 {
+		return fmt.Errorf("set identifier %q too long (at most 128 characters)", policy.SetIdentifier)
+	}
+	return nil
+}
+
+func validateFailover(policy *dns.RoutingPolicy) error {
+	switch role := policy.Parameters[roleParameter]; role {
+	case rolePrimary, roleSecondary:
+	case "":
+		return fmt.Errorf("missing parameter %q for routing policy %s", roleParameter, policy.Type)
+	default:
+		return fmt.Errorf("invalid role %q for routing policy %s (expected %s or %s)", role, policy.Type, rolePrimary, roleSecondary)
+	}
+	managed := false
+	for k, v := range policy.Parameters {
+		switch {
+		case k == roleParameter:
+		case k == healthCheckIDParameter:
+			if v == "" {
+				return fmt.Errorf("empty health check id for parameter %q", k)
+			}
+		case strings.HasPrefix(k, healthCheckParameterPrefix):
+			managed = true
+		default:
+			return fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s, %s or %s<property>)",
+				k, policy.Type, roleParameter, healthCheckIDParameter, healthCheckParameterPrefix)
+		}
+	}
+	if !managed {
+		return nil
+	}
+	if _, ok := policy.Parameters[healthCheckIDParameter]; ok {
+		return fmt.Errorf("parameter %s and managed health check parameters %s<property> are mutually exclusive",
+			healthCheckIDParameter, healthCheckParameterPrefix)
+	}
+	_, err := buildHealthCheckConfig(policy)
+	return err
+}
+
+func parseGeoLocation(policy *dns.RoutingPolicy) (*route53.GeoLocation, error) {
+	geo := &route53.GeoLocation{}
+	for k, v := range policy.Parameters {
+		if v == "" {
+			return nil, fmt.Errorf("empty value for parameter %q of routing policy %s", k, policy.Type)
+		}
+		switch k {
+		case continentParameter:
+			geo.ContinentCode = aws.String(v)
+		case countryParameter:
+			geo.CountryCode = aws.String(v)
+		case subdivisionParameter:
+			geo.SubdivisionCode = aws.String(v)
+		default:
+			return nil, fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s, %s or %s)",
+				k, policy.Type, continentParameter, countryParameter, subdivisionParameter)
+		}
+	}
+	switch {
+	case geo.ContinentCode == nil && geo.CountryCode == nil:
+		return nil, fmt.Errorf("routing policy %s requires parameter %s or %s", policy.Type, continentParameter, countryParameter)
+	case geo.ContinentCode != nil && geo.CountryCode != nil:
+		return nil, fmt.Errorf("parameters %s and %s of routing policy %s are mutually exclusive", continentParameter, countryParameter, policy.Type)
+	case geo.SubdivisionCode != nil && (geo.CountryCode == nil || *geo.CountryCode == defaultCountry):
+		return nil, fmt.Errorf("parameter %s of routing policy %s requires a specific %s", subdivisionParameter, policy.Type, countryParameter)
+	}
+	return geo, nil
+}
+
+func parseWeight(policy *dns.RoutingPolicy) (int64, error) {
+	value, ok := policy.Parameters[weightParameter]
+	if !ok {
+		return 0, fmt.Errorf("missing parameter %q for routing policy %s", weightParameter, policy.Type)
+	}
+	weight, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || weight < 0 || weight > maxWeight {
+		return 0, fmt.Errorf("invalid weight %q for routing policy %s (expected integer between 0 and %d)", value, policy.Type, maxWeight)
+	}
+	return weight, nil
+}
+
+func isMultiValueAnswer(r *route53.ResourceRecordSet) bool {
+	return r.SetIdentifier != nil && aws.BoolValue(r.MultiValueAnswer)
+}
+
+// isRoutedRecordSet checks for a weighted, geolocation or failover record set.
+func isRoutedRecordSet(r *route53.ResourceRecordSet) bool {
+	return r.SetIdentifier != nil && (r.Weight != nil || r.GeoLocation != nil || r.Failover != nil)
+}
+
+// addRoutedRecordSet adds a weighted, geolocation or failover record set as separate DNS set identified by its set identifier.
+// The parameters of managed health checks are restored from the health check tags.
+func (h *Handler) addRoutedRecordSet(ctx context.Context, dnssets dns.DNSSets, r *route53.ResourceRecordSet) error {
+	var rs *dns.RecordSet
+	if isAliasTarget(r) {
+		rs = buildRecordSetFromAliasTarget(r)
+	} else {
+		rs = buildRecordSet(r)
+	}
+	switch {
+	case r.Failover != nil:
+		parameters := map[string]string{
+			roleParameter: strings.ToLower(*r.Failover),
+		}
+		if r.HealthCheckId != nil {
+			managed, err := h.healthChecks.getParameters(ctx, *r.HealthCheckId)
+			if err != nil {
+				return err
+			}
+			if managed == nil {
+				parameters[healthCheckIDParameter] = *r.HealthCheckId
+			}
+			for k, v := range managed {
+				parameters[k] = v
+			}
+		}
+		rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_FAILOVER, parameters)
+	case r.Weight != nil:
+		rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_WEIGHTED, map[string]string{
+			weightParameter: strconv.FormatInt(aws.Int64Value(r.Weight), 10),
+		})
+	default:
+		parameters := map[string]string{}
+		if r.GeoLocation.ContinentCode != nil {
+			parameters[continentParameter] = *r.GeoLocation.ContinentCode
+		}
+		if r.GeoLocation.CountryCode != nil {
+			parameters[countryParameter] = *r.GeoLocation.CountryCode
+		}
+		if r.GeoLocation.SubdivisionCode != nil {
+			parameters[subdivisionParameter] = *r.GeoLocation.SubdivisionCode
+		}
+		rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_GEOLOCATION, parameters)
+	}
+	rs.RoutingPolicy.SetIdentifier = aws.StringValue(r.SetIdentifier)
+	dnssets.AddRecordSetFromProvider(aws.StringValue(r.Name), rs)
+	return nil
+}
+
+// applyRoutingPolicy sets set identifier and weight, location or failover role of a routed record set.
+// Managed health checks of failover record sets are assigned on submitting the changes.
+func applyRoutingPolicy(rrs *route53.ResourceRecordSet, policy *dns.RoutingPolicy) error {
+	if err := validateRoutingPolicy(policy); err != nil {
+		return err
+	}
+	rrs.SetIdentifier = aws.String(policy.SetIdentifier)
+	switch policy.Type {
+	case dns.RP_WEIGHTED:
+		weight, _ := parseWeight(policy)
+		rrs.Weight = aws.Int64(weight)
+	case dns.RP_GEOLOCATION:
+		rrs.GeoLocation, _ = parseGeoLocation(policy)
+	case dns.RP_FAILOVER:
+		rrs.Failover = aws.String(strings.ToUpper(policy.Parameters[roleParameter]))
+		if id := policy.Parameters[healthCheckIDParameter]; id != "" {
+			rrs.HealthCheckId = aws.String(id)
+		}
+	}
+	return nil
+}
+
+// addMultiValueAnswerRecordSet merges multi value answer record sets with the same name and type
+// into a single record set with the multivalue routing policy.
+func addMultiValueAnswerRecordSet(dnssets dns.DNSSets, r *route53.ResourceRecordSet) {
+	name := dns.NormalizeHostname(aws.StringValue(r.Name))
+	rtype := aws.StringValue(r.Type)
+	var rs *dns.RecordSet
+	if set := dnssets[name]; set != nil {
+		rs = set.Sets[rtype]
+	}
+	if rs == nil || rs.RoutingPolicy == nil || rs.RoutingPolicy.Type != dns.RP_MULTIVALUE {
+		rs = dns.NewRecordSet(rtype, aws.Int64Value(r.TTL), nil)
+		rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_MULTIVALUE, nil)
+		dnssets.AddRecordSetFromProvider(aws.StringValue(r.Name), rs)
+	}
+	for _, rr := range r.ResourceRecords {
+		value := aws.StringValue(rr.Value)
+		rs.Add(&dns.Record{Value: value})
+		if r.HealthCheckId != nil {
+			rs.RoutingPolicy.Parameters[healthCheckIDParameterPrefix+value] = *r.HealthCheckId
+		}
+	}
+}
+
+// buildResourceRecordSetsForRoutingPolicy builds a resource record set for every record of
+// a record set with routing policy. The record value is used as set identifier.
+func buildResourceRecordSetsForRoutingPolicy(name string, rset *dns.RecordSet) ([]*route53.ResourceRecordSet, error) {
+	if err := validateRoutingPolicy(rset.RoutingPolicy); err != nil {
+		return nil, err
+	}
+	switch rset.Type {
+	case dns.RS_CNAME, dns.RS_ALIAS:
+		return nil, fmt.Errorf("routing policy %s not supported for record type %s", rset.RoutingPolicy.Type, rset.Type)
+	}
+	rrsets := []*route53.ResourceRecordSet{}
+	for _, r := range rset.Records {
+		rrs := &route53.ResourceRecordSet{
+			Name:             aws.String(name),
+			Type:             aws.String(rset.Type),
+			TTL:              aws.Int64(rset.TTL),
+			SetIdentifier:    aws.String(r.Value),
+			MultiValueAnswer: aws.Bool(true),
+			ResourceRecords:  []*route53.ResourceRecord{{Value: aws.String(r.Value)}},
+		}
+		if id := rset.RoutingPolicy.Parameters[healthCheckIDParameterPrefix+r.Value]; id != "" {
+			rrs.HealthCheckId = aws.String(id)
+		}
+		rrsets = append(rrsets, rrs)
+	}
+	return rrsets, nil
+}
+
+// obsoleteRecordSet returns the part of an old record set, which is not replaced by upserting the new one.
+func obsoleteRecordSet(old, new *dns.RecordSet) *dns.RecordSet {
+	if old == nil || (old.RoutingPolicy == nil && new.RoutingPolicy == nil) {
+		return nil
+	}
+	if old.RoutingPolicy == nil || new.RoutingPolicy == nil || old.RoutingPolicy.Type != new.RoutingPolicy.Type {
+		return old
+	}
+	if new.RoutingPolicy.Type != dns.RP_MULTIVALUE {
+		if old.RoutingPolicy.SetIdentifier != new.RoutingPolicy.SetIdentifier {
+			return old
+		}
+		// the record set with set identifier is replaced completely
+		return nil
+	}
+	obsolete := old.Clone()
+	obsolete.Records = nil
+	for _, r := range old.Records {
+		found := false
+		for _, n := range new.Records {
+			if n.Value == r.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			obsolete.Add(r.Clone())
+		}
+	}
+	if len(obsolete.Records) == 0 {
+		return nil
+	}
+	return obsolete
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// changeRecordSetsAction is the IAM action needed for changing the record sets of a hosted zone
+const changeRecordSetsAction = "route53:ChangeResourceRecordSets"
+
+var _ provider.WritePermissionProbeSupport = &Handler{}
+
+// ProbeWritePermission simulates the IAM policies of the caller for changing the record sets of the zone.
+func (h *Handler) ProbeWritePermission(ctx context.Context, zone provider.DNSHostedZone) error {
+	// IAM and STS must not use the Route53 endpoint
+	sess := h.sess.Copy(&aws.Config{Endpoint: aws.String("")})
+
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_WRITEPERMISSION, 1)
+	h.config.RateLimiter.Accept()
+	identity, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return perrs.NewWritePermissionUnverifiable(fmt.Errorf("cannot get caller identity: %w", err))
+	}
+	principal, err := policySourceARN(aws.StringValue(identity.Arn))
+	if err != nil {
+		return perrs.NewWritePermissionUnverifiable(err)
+	}
+	resource, err := hostedZoneARN(principal, zone.Id().ID)
+	if err != nil {
+		return perrs.NewWritePermissionUnverifiable(err)
+	}
+
+	h.config.RateLimiter.Accept()
+	output, err := iam.New(sess).SimulatePrincipalPolicyWithContext(ctx, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal),
+		ActionNames:     aws.StringSlice([]string{changeRecordSetsAction}),
+		ResourceArns:    aws.StringSlice([]string{resource}),
+	})
+	if err != nil {
+		return perrs.NewWritePermissionUnverifiable(fmt.Errorf("cannot simulate policies of %s: %w", principal, err))
+	}
+	for _, result := range output.EvaluationResults {
+		if decision := aws.StringValue(result.EvalDecision); decision != iam.PolicyEvaluationDecisionTypeAllowed {
+			return fmt.Errorf("action %s %s for %s", changeRecordSetsAction, decision, principal)
+		}
+	}
+	return nil
+}
+
+// policySourceARN returns the ARN of the IAM user or role for the ARN of the caller identity.
+// For an assumed role the role ARN is derived without the path of the role.
+func policySourceARN(caller string) (string, error) {
+	a, err := arn.Parse(caller)
+	if err != nil {
+		return "", fmt.Errorf("invalid caller ARN %q: %w", caller, err)
+	}
+	switch {
+	case a.Service == "iam" && strings.HasPrefix(a.Resource, "user/"):
+		return caller, nil
+	case a.Service == "iam" && strings.HasPrefix(a.Resource, "role/"):
+		return caller, nil
+	case a.Service == "sts" && strings.HasPrefix(a.Resource, "assumed-role/"):
+		parts := strings.Split(a.Resource, "/")
+		if len(parts) < 2 || parts[1] == "" {
+			return "", fmt.Errorf("invalid assumed role ARN %q", caller)
+		}
+		a.Service = "iam"
+		a.Resource = "role/" + parts[1]
+		return a.String(), nil
+	default:
+		return "", fmt.Errorf("policy simulation not supported for caller %s", caller)
+	}
+}
+
+// hostedZoneARN returns the ARN of the hosted zone in the partition of the principal.
+func hostedZoneARN(principal, zoneID string) (string, error) {
+	a, err := arn.Parse(principal)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(zoneID, "/")
+	return arn.ARN{Partition: a.Partition, Service: "route53", Resource: "hostedzone/" + parts[len(parts)-1]}.String(), nil
+}
@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/route53"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	// zoneManagedByTagKey is the hosted zone tag identifying the managing controller
+	zoneManagedByTagKey = dns.ANNOTATION_GROUP + "/managed-by"
+	// zoneOwnerTagKey is the hosted zone tag identifying the owner id of the managing controller
+	zoneOwnerTagKey = dns.ANNOTATION_GROUP + "/owner"
+)
+
+var _ provider.ZoneOwnershipAccess = &Handler{}
+
+func (h *Handler) MarkZoneOwnership(ctx context.Context, zone provider.DNSHostedZone, marker provider.ZoneOwnershipMarker) (bool, error) {
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_ZONEMETADATA, 1)
+	h.config.RateLimiter.Accept()
+	output, err := h.r53.ListTagsForResourceWithContext(ctx, &route53.ListTagsForResourceInput{
+		ResourceId:   aws.String(zone.Id().ID),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+	})
+	if err != nil {
+		return false, err
+	}
+	current := map[string]string{}
+	if output.ResourceTagSet != nil {
+		for _, tag := range output.ResourceTagSet.Tags {
+			current[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+		}
+	}
+	if owner := current[zoneOwnerTagKey]; owner != "" && owner != marker.Owner {
+		return false, &provider.ForeignZoneOwnershipError{Marker: provider.ZoneOwnershipMarker{ManagedBy: current[zoneManagedByTagKey], Owner: owner}}
+	}
+	expected := map[string]string{
+		zoneManagedByTagKey: marker.ManagedBy,
+		zoneOwnerTagKey:     marker.Owner,
+	}
+	for key, value := range current {
+		if expected[key] == value {
+			delete(expected, key)
+		}
+	}
+	if len(expected) == 0 {
+		return false, nil
+	}
+	var tags []*route53.Tag
+	for key, value := range expected {
+		tags = append(tags, &route53.Tag{Key: aws.String(key), Value: aws.String(value)})
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_ZONEMETADATA, 1)
+	h.config.RateLimiter.Accept()
+	_, err = h.r53.ChangeTagsForResourceWithContext(ctx, &route53.ChangeTagsForResourceInput{
+		ResourceId:   aws.String(zone.Id().ID),
+		ResourceType: aws.String(route53.TagResourceTypeHostedzone),
+		AddTags:      tags,
+	})
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+func newZoneMarkerTestHandler(t *testing.T) (*testRoute53, *Handler) {
+	s, r53 := newTestRoute53(t)
+	return s, &Handler{
+		config: provider.DNSHandlerConfig{Metrics: &provider.NullMetrics{}, RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter()},
+		r53:    r53,
+	}
+}
+
+var (
+	testZone   = provider.NewDNSHostedZone(TYPE_CODE, "Z1", "example.com", "", nil, false)
+	testMarker = provider.ZoneOwnershipMarker{ManagedBy: provider.ZONE_MARKER_MANAGED_BY, Owner: "me"}
+)
+
+func TestMarkZoneOwnership(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, h := newZoneMarkerTestHandler(t)
+	s.zoneTags["Z1"] = []testTag{{Key: "team", Value: "dns"}}
+
+	mod, err := h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mod).To(BeTrue())
+	Expect(s.zoneTags["Z1"]).To(ConsistOf(
+		testTag{Key: "team", Value: "dns"},
+		testTag{Key: zoneManagedByTagKey, Value: provider.ZONE_MARKER_MANAGED_BY},
+		testTag{Key: zoneOwnerTagKey, Value: "me"},
+	))
+
+	mod, err = h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mod).To(BeFalse())
+	Expect(s.tagChanges).To(Equal(1))
+}
+
+func TestMarkZoneOwnershipUpdatesOutdatedMarker(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, h := newZoneMarkerTestHandler(t)
+	s.zoneTags["Z1"] = []testTag{{Key: zoneManagedByTagKey, Value: "old"}, {Key: zoneOwnerTagKey, Value: "me"}}
+
+	mod, err := h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mod).To(BeTrue())
+	Expect(s.zoneTags["Z1"]).To(ConsistOf(
+		testTag{Key: zoneManagedByTagKey, Value: provider.ZONE_MARKER_MANAGED_BY},
+		testTag{Key: zoneOwnerTagKey, Value: "me"},
+	))
+}
+
+func TestMarkZoneOwnershipKeepsForeignOwner(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, h := newZoneMarkerTestHandler(t)
+	foreign := []testTag{{Key: zoneManagedByTagKey, Value: provider.ZONE_MARKER_MANAGED_BY}, {Key: zoneOwnerTagKey, Value: "other"}}
+	s.zoneTags["Z1"] = foreign
+
+	mod, err := h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(mod).To(BeFalse())
+	var foreignErr *provider.ForeignZoneOwnershipError
+	Expect(errors.As(err, &foreignErr)).To(BeTrue())
+	Expect(foreignErr.Marker).To(Equal(provider.ZoneOwnershipMarker{ManagedBy: provider.ZONE_MARKER_MANAGED_BY, Owner: "other"}))
+	Expect(s.zoneTags["Z1"]).To(Equal(foreign))
+	Expect(s.tagChanges).To(Equal(0))
+}
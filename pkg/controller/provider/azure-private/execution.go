@@ -17,7 +17,8 @@
 package azureprivate
 
 import (
-	"strconv"
+	"context"
+	"strings"
 
 	azure "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -34,6 +35,7 @@ type Change struct {
 
 type Execution struct {
 	logger.LogContext
+	ctx           context.Context
 	handler       *Handler
 	resourceGroup string
 	zoneName      string
@@ -41,8 +43,8 @@ type Execution struct {
 	changes map[string][]*Change
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, resourceGroup string, zoneName string) *Execution {
-	return &Execution{LogContext: logger, handler: h, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
+func NewExecution(ctx context.Context, logger logger.LogContext, h *Handler, resourceGroup string, zoneName string) *Execution {
+	return &Execution{LogContext: logger, ctx: ctx, handler: h, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
 }
 
 type buildStatus int
@@ -75,7 +77,11 @@ func (exec *Execution) buildRecordSet(req *provider.ChangeRequest) (buildStatus,
 	}
 
 	exec.Infof("Desired %s: %s record set %s[%s] with TTL %d: %s", req.Action, rset.Type, name, exec.zoneName, rset.TTL, rset.RecordString())
-	return exec.buildMappedRecordSet(name, rset)
+	status, recordType, recordSet := exec.buildMappedRecordSet(name, rset)
+	if status == bs_ok && req.Action != provider.R_DELETE {
+		recordSet.Metadata = utils.AddResourceTags(recordSet.Metadata, exec.handler.getResourceTags())
+	}
+	return status, recordType, recordSet
 }
 
 func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (buildStatus, azure.RecordType, *azure.RecordSet) {
@@ -105,14 +111,27 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 		recordType = azure.TXT
 		txtrecords := []azure.TxtRecord{}
 		for _, r := range rset.Records {
-			// AzureDNS stores value as given, i.e. including quotes, so text value must be unquoted
-			unquoted, err := strconv.Unquote(r.Value)
+			// AzureDNS stores value as given, i.e. including quotes, so the character strings must be unquoted
+			chunks, err := dns.SplitTextValue(r.Value)
 			if err != nil {
-				unquoted = r.Value
+				chunks = []string{r.Value}
 			}
-			txtrecords = append(txtrecords, azure.TxtRecord{Value: &[]string{unquoted}})
+			txtrecords = append(txtrecords, azure.TxtRecord{Value: &chunks})
 		}
 		properties.TxtRecords = &txtrecords
+	case dns.RS_SRV:
+		recordType = azure.SRV
+		srvrecords := []azure.SrvRecord{}
+		for _, r := range rset.Records {
+			priority, weight, port, target, err := dns.ParseSRVValue(r.Value)
+			if err != nil {
+				return bs_invalidType, "", nil
+			}
+			priority32, weight32, port32 := int32(priority), int32(weight), int32(port)
+			target = strings.TrimSuffix(target, ".")
+			srvrecords = append(srvrecords, azure.SrvRecord{Priority: &priority32, Weight: &weight32, Port: &port32, Target: &target})
+		}
+		properties.SrvRecords = &srvrecords
 	default:
 		return bs_invalidType, "", nil
 	}
@@ -133,7 +152,7 @@ func (exec *Execution) apply(action string, recordType azure.RecordType, rset *a
 
 func (exec *Execution) update(recordType azure.RecordType, rset *azure.RecordSet, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName,
+	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.ctx, exec.resourceGroup, exec.zoneName,
 		recordType, *rset.Name, *rset, "", "")
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
@@ -142,7 +161,7 @@ func (exec *Execution) update(recordType azure.RecordType, rset *azure.RecordSet
 
 func (exec *Execution) delete(recordType azure.RecordType, rset *azure.RecordSet, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, recordType, *rset.Name, "")
+	_, err := exec.handler.recordsClient.Delete(exec.ctx, exec.resourceGroup, exec.zoneName, recordType, *rset.Name, "")
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_DELETERECORDS, 1)
 	return err
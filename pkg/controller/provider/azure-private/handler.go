@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	azure "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -34,6 +35,7 @@ import (
 type Handler struct {
 	provider.DefaultDNSHandler
 	config        provider.DNSHandlerConfig
+	lock          sync.Mutex
 	cache         provider.ZoneCache
 	ctx           context.Context
 	zonesClient   *azure.PrivateZonesClient
@@ -41,6 +43,7 @@ type Handler struct {
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.ConfigUpdateSupport = &Handler{}
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h := &Handler{
@@ -81,18 +84,32 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	return h, nil
 }
 
+// UpdateConfig takes over changed resource tags, they are added to record sets on their next update.
+func (h *Handler) UpdateConfig(c *provider.DNSHandlerConfig) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.config.ResourceTags = c.ResourceTags
+	return nil
+}
+
+func (h *Handler) getResourceTags() map[string]string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.config.ResourceTags
+}
+
 func (h *Handler) Release() {
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	zones := provider.DNSHostedZones{}
 	h.config.RateLimiter.Accept()
-	results, err := h.zonesClient.ListComplete(h.ctx, nil)
+	results, err := h.zonesClient.ListComplete(ctx, nil)
 	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
 	if err != nil {
 		return nil, perrs.WrapAsHandlerError(err, "Listing DNS zones failed")
@@ -122,16 +139,16 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
 	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
 	h.config.RateLimiter.Accept()
-	results, err := h.recordsClient.ListComplete(h.ctx, resourceGroup, zoneName, nil, "")
+	results, err := h.recordsClient.ListComplete(ctx, resourceGroup, zoneName, nil, "")
 	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
 	if err != nil {
 		return nil, perrs.WrapfAsHandlerError(err, "Listing DNS zone state for zone %s failed", zoneName)
@@ -161,12 +178,30 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 		if item.TxtRecords != nil {
 			rs := dns.NewRecordSet(dns.RS_TXT, *item.TTL, nil)
 			for _, record := range *item.TxtRecords {
-				quoted := strings.Join(*record.Value, "\n")
-				// AzureDNS stores values unquoted, but it is expected to be quoted in dns.Record
-				if len(quoted) > 0 && quoted[0] != '"' && quoted[len(quoted)-1] != '"' {
-					quoted = strconv.Quote(quoted)
+				// AzureDNS stores the character strings unquoted, but they are expected to be quoted in dns.Record
+				quoted := make([]string, 0, len(*record.Value))
+				for _, chunk := range *record.Value {
+					if len(chunk) > 0 && chunk[0] != '"' && chunk[len(chunk)-1] != '"' {
+						chunk = strconv.Quote(chunk)
+					}
+					quoted = append(quoted, chunk)
 				}
-				rs.Add(&dns.Record{Value: quoted})
+				rs.Add(&dns.Record{Value: strings.Join(quoted, " ")})
+			}
+			dnssets.AddRecordSetFromProvider(fullName, rs)
+		}
+
+		if item.SrvRecords != nil {
+			rs := dns.NewRecordSet(dns.RS_SRV, *item.TTL, nil)
+			for _, record := range *item.SrvRecords {
+				if record.Priority == nil || record.Weight == nil || record.Port == nil || record.Target == nil {
+					continue
+				}
+				target := "."
+				if t := *record.Target; t != "" && t != "." {
+					target = dns.AlignHostname(t)
+				}
+				rs.Add(&dns.Record{Value: fmt.Sprintf("%d %d %d %s", *record.Priority, *record.Weight, *record.Port, target)})
 			}
 			dnssets.AddRecordSetFromProvider(fullName, rs)
 		}
@@ -178,19 +213,27 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	return rtype == dns.RS_SRV || h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	return utils.ClassifyError(err)
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
 	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
-	exec := NewExecution(logger, h, resourceGroup, zoneName)
+	exec := NewExecution(ctx, logger, h, resourceGroup, zoneName)
 
 	var succeeded, failed int
 	for _, r := range reqs {
@@ -0,0 +1,210 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	// aliasLookupTTL is the time-to-live of the looked up alias target resources.
+	// It is kept short, as newly created resources are not found before the next lookup.
+	aliasLookupTTL = 2 * time.Minute
+	// aliasLookupTimeout is the timeout for listing the alias target resources of the subscription
+	aliasLookupTimeout = 30 * time.Second
+
+	// aliasTargetMetadata is the metadata key of an alias record set storing the target hostname
+	aliasTargetMetadata = "gardenerAliasTarget"
+)
+
+// aliasTargetKind describes an Azure resource type usable as target of alias record sets.
+type aliasTargetKind struct {
+	name         string
+	suffix       string
+	resourcePath string
+	apiVersion   string
+	hostnames    func(r *armResource) []string
+}
+
+var aliasTargetKinds = []*aliasTargetKind{
+	{
+		name:         "Traffic Manager profile",
+		suffix:       ".trafficmanager.net",
+		resourcePath: "providers/Microsoft.Network/trafficmanagerprofiles",
+		apiVersion:   "2018-04-01",
+		hostnames: func(r *armResource) []string {
+			if r.Properties.DNSConfig == nil {
+				return nil
+			}
+			return []string{r.Properties.DNSConfig.Fqdn}
+		},
+	},
+	{
+		name:         "Front Door",
+		suffix:       ".azurefd.net",
+		resourcePath: "providers/Microsoft.Network/frontDoors",
+		apiVersion:   "2020-05-01",
+		hostnames: func(r *armResource) []string {
+			result := []string{r.Properties.Cname}
+			for _, e := range r.Properties.FrontendEndpoints {
+				result = append(result, e.Properties.HostName)
+			}
+			return result
+		},
+	},
+}
+
+type armResource struct {
+	ID         string `json:"id"`
+	Properties struct {
+		DNSConfig *struct {
+			Fqdn string `json:"fqdn"`
+		} `json:"dnsConfig"`
+		Cname             string `json:"cname"`
+		FrontendEndpoints []struct {
+			Properties struct {
+				HostName string `json:"hostName"`
+			} `json:"properties"`
+		} `json:"frontendEndpoints"`
+	} `json:"properties"`
+}
+
+type armResourceList struct {
+	Value    []armResource `json:"value"`
+	NextLink string        `json:"nextLink"`
+}
+
+// aliasTargetResolver validates Traffic Manager profiles and Front Door endpoints used as
+// targets and determines their resource ids for alias record sets.
+type aliasTargetResolver struct {
+	ctx            context.Context
+	logger         logger.LogContext
+	client         autorest.Client
+	baseURI        string
+	subscriptionID string
+	lookups        *dnsutils.TTLCache
+}
+
+func newAliasTargetResolver(ctx context.Context, logger logger.LogContext, client autorest.Client, baseURI, subscriptionID string) *aliasTargetResolver {
+	return &aliasTargetResolver{
+		ctx:            ctx,
+		logger:         logger,
+		client:         client,
+		baseURI:        baseURI,
+		subscriptionID: subscriptionID,
+		lookups:        dnsutils.NewTTLCache(aliasLookupTTL),
+	}
+}
+
+func findAliasTargetKind(hostname string) *aliasTargetKind {
+	for _, kind := range aliasTargetKinds {
+		if strings.HasSuffix(hostname, kind.suffix) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// resolve returns the resource id for an alias target or an empty string if there is no such resource in the subscription.
+func (this *aliasTargetResolver) resolve(hostname string) (string, error) {
+	hostname = strings.ToLower(hostname)
+	kind := findAliasTargetKind(hostname)
+	if kind == nil {
+		return "", nil
+	}
+	v, err := this.lookups.GetOrCompute(kind.name, func() (interface{}, error) {
+		return this.lookup(kind)
+	})
+	if err != nil {
+		return "", fmt.Errorf("looking up %s alias targets failed: %w", kind.name, err)
+	}
+	return v.(map[string]string)[hostname], nil
+}
+
+// hostname returns the hostname for a resource id of an alias record set if it has already been looked up.
+func (this *aliasTargetResolver) hostname(id string) string {
+	for _, kind := range aliasTargetKinds {
+		if v, ok := this.lookups.Get(kind.name); ok {
+			for hostname, rid := range v.(map[string]string) {
+				if strings.EqualFold(rid, id) {
+					return hostname
+				}
+			}
+		}
+	}
+	return ""
+}
+
+func (this *aliasTargetResolver) lookup(kind *aliasTargetKind) (map[string]string, error) {
+	parent := this.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, aliasLookupTimeout)
+	defer cancel()
+
+	result := map[string]string{}
+	preparers := []autorest.PrepareDecorator{
+		autorest.AsGet(),
+		autorest.WithBaseURL(this.baseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/"+kind.resourcePath,
+			map[string]interface{}{"subscriptionId": autorest.Encode("path", this.subscriptionID)}),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": kind.apiVersion}),
+	}
+	for {
+		req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), preparers...)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := this.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		list := armResourceList{}
+		err = autorest.Respond(resp,
+			autorestazure.WithErrorUnlessStatusCode(http.StatusOK),
+			autorest.ByUnmarshallingJSON(&list),
+			autorest.ByClosing())
+		if err != nil {
+			return nil, err
+		}
+		for i := range list.Value {
+			r := &list.Value[i]
+			for _, hostname := range kind.hostnames(r) {
+				if hostname != "" {
+					result[strings.ToLower(hostname)] = r.ID
+				}
+			}
+		}
+		if list.NextLink == "" {
+			break
+		}
+		preparers = []autorest.PrepareDecorator{autorest.AsGet(), autorest.WithBaseURL(list.NextLink)}
+	}
+	this.logger.Infof("found %d %s alias targets", len(result), kind.name)
+	return result, nil
+}
@@ -17,7 +17,8 @@
 package azure
 
 import (
-	"strconv"
+	"context"
+	"strings"
 
 	azure "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -34,6 +35,7 @@ type Change struct {
 
 type Execution struct {
 	logger.LogContext
+	ctx           context.Context
 	handler       *Handler
 	resourceGroup string
 	zoneName      string
@@ -41,18 +43,19 @@ type Execution struct {
 	changes map[string][]*Change
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, resourceGroup string, zoneName string) *Execution {
-	return &Execution{LogContext: logger, handler: h, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
+func NewExecution(ctx context.Context, logger logger.LogContext, h *Handler, resourceGroup string, zoneName string) *Execution {
+	return &Execution{LogContext: logger, ctx: ctx, handler: h, resourceGroup: resourceGroup, zoneName: zoneName, changes: map[string][]*Change{}}
 }
 
 type buildStatus int
 
 const (
-	bs_ok          buildStatus = 0
-	bs_invalidType buildStatus = 1
-	bs_empty       buildStatus = 2
-	bs_dryrun      buildStatus = 3
-	bs_invalidName buildStatus = 4
+	bs_ok            buildStatus = 0
+	bs_invalidType   buildStatus = 1
+	bs_empty         buildStatus = 2
+	bs_dryrun        buildStatus = 3
+	bs_invalidName   buildStatus = 4
+	bs_invalidTarget buildStatus = 5
 )
 
 func (exec *Execution) buildRecordSet(req *provider.ChangeRequest) (buildStatus, azure.RecordType, *azure.RecordSet) {
@@ -75,7 +78,34 @@ func (exec *Execution) buildRecordSet(req *provider.ChangeRequest) (buildStatus,
 	}
 
 	exec.Infof("Desired %s: %s record set %s[%s] with TTL %d: %s", req.Action, rset.Type, name, exec.zoneName, rset.TTL, rset.RecordString())
-	return exec.buildMappedRecordSet(name, rset)
+	var status buildStatus
+	var recordType azure.RecordType
+	var recordSet *azure.RecordSet
+	if rset.Type == dns.RS_ALIAS && req.Action != provider.R_DELETE {
+		status, recordType, recordSet = exec.buildAliasRecordSet(name, rset)
+	} else {
+		status, recordType, recordSet = exec.buildMappedRecordSet(name, rset)
+	}
+	if status == bs_ok && req.Action != provider.R_DELETE {
+		recordSet.Metadata = utils.AddResourceTags(recordSet.Metadata, exec.handler.getResourceTags())
+	}
+	return status, recordType, recordSet
+}
+
+// buildAliasRecordSet builds a CNAME alias record set pointing to the resource id of the target
+func (exec *Execution) buildAliasRecordSet(name string, rset *dns.RecordSet) (buildStatus, azure.RecordType, *azure.RecordSet) {
+	target := rset.Records[0].Value
+	id, err := exec.handler.resolver.resolve(target)
+	if err != nil || id == "" {
+		exec.Warnf("alias target %s not resolvable: %v", target, err)
+		return bs_invalidTarget, "", &azure.RecordSet{Name: &name}
+	}
+	properties := azure.RecordSetProperties{
+		TTL:            &rset.TTL,
+		TargetResource: &azure.SubResource{ID: &id},
+		Metadata:       map[string]*string{aliasTargetMetadata: &target},
+	}
+	return bs_ok, azure.CNAME, &azure.RecordSet{Name: &name, RecordSetProperties: &properties}
 }
 
 func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (buildStatus, azure.RecordType, *azure.RecordSet) {
@@ -101,18 +131,46 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 	case dns.RS_CNAME:
 		recordType = azure.CNAME
 		properties.CnameRecord = &azure.CnameRecord{Cname: &rset.Records[0].Value}
+	case dns.RS_ALIAS:
+		// only used for deletion, see buildAliasRecordSet
+		recordType = azure.CNAME
 	case dns.RS_TXT:
 		recordType = azure.TXT
 		txtrecords := []azure.TxtRecord{}
 		for _, r := range rset.Records {
-			// AzureDNS stores value as given, i.e. including quotes, so text value must be unquoted
-			unquoted, err := strconv.Unquote(r.Value)
+			// AzureDNS stores value as given, i.e. including quotes, so the character strings must be unquoted
+			chunks, err := dns.SplitTextValue(r.Value)
 			if err != nil {
-				unquoted = r.Value
+				chunks = []string{r.Value}
 			}
-			txtrecords = append(txtrecords, azure.TxtRecord{Value: &[]string{unquoted}})
+			txtrecords = append(txtrecords, azure.TxtRecord{Value: &chunks})
 		}
 		properties.TxtRecords = &txtrecords
+	case dns.RS_SRV:
+		recordType = azure.SRV
+		srvrecords := []azure.SrvRecord{}
+		for _, r := range rset.Records {
+			priority, weight, port, target, err := dns.ParseSRVValue(r.Value)
+			if err != nil {
+				return bs_invalidType, "", nil
+			}
+			priority32, weight32, port32 := int32(priority), int32(weight), int32(port)
+			target = strings.TrimSuffix(target, ".")
+			srvrecords = append(srvrecords, azure.SrvRecord{Priority: &priority32, Weight: &weight32, Port: &port32, Target: &target})
+		}
+		properties.SrvRecords = &srvrecords
+	case dns.RS_CAA:
+		recordType = azure.CAA
+		caarecords := []azure.CaaRecord{}
+		for _, r := range rset.Records {
+			flags, tag, value, err := dns.ParseCAAValue(r.Value)
+			if err != nil {
+				return bs_invalidType, "", nil
+			}
+			flags32 := int32(flags)
+			caarecords = append(caarecords, azure.CaaRecord{Flags: &flags32, Tag: &tag, Value: &value})
+		}
+		properties.CaaRecords = &caarecords
 	default:
 		return bs_invalidType, "", nil
 	}
@@ -133,7 +191,7 @@ func (exec *Execution) apply(action string, recordType azure.RecordType, rset *a
 
 func (exec *Execution) update(recordType azure.RecordType, rset *azure.RecordSet, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name,
+	_, err := exec.handler.recordsClient.CreateOrUpdate(exec.ctx, exec.resourceGroup, exec.zoneName, *rset.Name,
 		recordType, *rset, "", "")
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
@@ -142,7 +200,7 @@ func (exec *Execution) update(recordType azure.RecordType, rset *azure.RecordSet
 
 func (exec *Execution) delete(recordType azure.RecordType, rset *azure.RecordSet, metrics provider.Metrics) error {
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.recordsClient.Delete(exec.handler.ctx, exec.resourceGroup, exec.zoneName, *rset.Name, recordType, "")
+	_, err := exec.handler.recordsClient.Delete(exec.ctx, exec.resourceGroup, exec.zoneName, *rset.Name, recordType, "")
 	zoneID := utils.MakeZoneID(exec.resourceGroup, exec.zoneName)
 	metrics.AddZoneRequests(zoneID, provider.M_DELETERECORDS, 1)
 	return err
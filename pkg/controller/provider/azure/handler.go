@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	azure "github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2018-05-01/dns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -29,18 +30,22 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 type Handler struct {
 	provider.DefaultDNSHandler
 	config        provider.DNSHandlerConfig
+	lock          sync.Mutex
 	cache         provider.ZoneCache
 	ctx           context.Context
 	zonesClient   *azure.ZonesClient
 	recordsClient *azure.RecordSetsClient
+	resolver      *aliasTargetResolver
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.ConfigUpdateSupport = &Handler{}
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h := &Handler{
@@ -72,6 +77,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
 	h.zonesClient = &zonesClient
 	h.recordsClient = &recordsClient
+	h.resolver = newAliasTargetResolver(c.Context, c.Logger, zonesClient.Client, zonesClient.BaseURI, subscriptionID)
 
 	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
 	if err != nil {
@@ -81,18 +87,32 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	return h, nil
 }
 
+// UpdateConfig takes over changed resource tags, they are added to record sets on their next update.
+func (h *Handler) UpdateConfig(c *provider.DNSHandlerConfig) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.config.ResourceTags = c.ResourceTags
+	return nil
+}
+
+func (h *Handler) getResourceTags() map[string]string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.config.ResourceTags
+}
+
 func (h *Handler) Release() {
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	zones := provider.DNSHostedZones{}
 	h.config.RateLimiter.Accept()
-	results, err := h.zonesClient.ListComplete(h.ctx, nil)
+	results, err := h.zonesClient.ListComplete(ctx, nil)
 	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
 	if err != nil {
 		return nil, perrs.WrapAsHandlerError(err, "Listing DNS zones failed")
@@ -113,7 +133,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 			continue
 		}
 
-		forwarded := h.collectForwardedSubzones(resourceGroup, *item.Name)
+		forwarded := h.collectForwardedSubzones(ctx, resourceGroup, *item.Name)
 
 		// ResourceGroup needed for requests to Azure. Remember by adding to Id. Split by calling SplitZoneID().
 		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, dns.NormalizeHostname(*item.Name), "", forwarded, false)
@@ -124,12 +144,12 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) collectForwardedSubzones(resourceGroup, zoneName string) []string {
+func (h *Handler) collectForwardedSubzones(ctx context.Context, resourceGroup, zoneName string) []string {
 	forwarded := []string{}
 	// There should only few NS entries. Therefore no paging is performed for simplicity.
 	var top int32 = 1000
 	h.config.RateLimiter.Accept()
-	result, err := h.recordsClient.ListByType(h.ctx, resourceGroup, zoneName, azure.NS, &top, "")
+	result, err := h.recordsClient.ListByType(ctx, resourceGroup, zoneName, azure.NS, &top, "")
 	zoneID := utils.MakeZoneID(resourceGroup, zoneName)
 	h.config.Metrics.AddZoneRequests(zoneID, provider.M_LISTRECORDS, 1)
 	if err != nil {
@@ -147,16 +167,16 @@ func (h *Handler) collectForwardedSubzones(resourceGroup, zoneName string) []str
 	return forwarded
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
 	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
 	h.config.RateLimiter.Accept()
-	results, err := h.recordsClient.ListAllByDNSZoneComplete(h.ctx, resourceGroup, zoneName, nil, "")
+	results, err := h.recordsClient.ListAllByDNSZoneComplete(ctx, resourceGroup, zoneName, nil, "")
 	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
 	if err != nil {
 		return nil, perrs.WrapfAsHandlerError(err, "Listing DNS zone state for zone %s failed", zoneName)
@@ -169,6 +189,13 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 		// We expect recordName.DNSZone. However Azure only return recordName . Reverse is dropZoneName() needed for calls to Azure
 		fullName := fmt.Sprintf("%s.%s", *item.Name, zoneName)
 
+		if isAliasRecordSet(item) {
+			rs := dns.NewRecordSet(dns.RS_ALIAS, *item.TTL, nil)
+			rs.Add(&dns.Record{Value: h.aliasTargetHostname(item)})
+			dnssets.AddRecordSetFromProvider(fullName, rs)
+			continue
+		}
+
 		if item.ARecords != nil {
 			rs := dns.NewRecordSet(dns.RS_A, *item.TTL, nil)
 			for _, record := range *item.ARecords {
@@ -186,12 +213,41 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 		if item.TxtRecords != nil {
 			rs := dns.NewRecordSet(dns.RS_TXT, *item.TTL, nil)
 			for _, record := range *item.TxtRecords {
-				quoted := strings.Join(*record.Value, "\n")
-				// AzureDNS stores values unquoted, but it is expected to be quoted in dns.Record
-				if len(quoted) > 0 && quoted[0] != '"' && quoted[len(quoted)-1] != '"' {
-					quoted = strconv.Quote(quoted)
+				// AzureDNS stores the character strings unquoted, but they are expected to be quoted in dns.Record
+				quoted := make([]string, 0, len(*record.Value))
+				for _, chunk := range *record.Value {
+					if len(chunk) > 0 && chunk[0] != '"' && chunk[len(chunk)-1] != '"' {
+						chunk = strconv.Quote(chunk)
+					}
+					quoted = append(quoted, chunk)
 				}
-				rs.Add(&dns.Record{Value: quoted})
+				rs.Add(&dns.Record{Value: strings.Join(quoted, " ")})
+			}
+			dnssets.AddRecordSetFromProvider(fullName, rs)
+		}
+
+		if item.SrvRecords != nil {
+			rs := dns.NewRecordSet(dns.RS_SRV, *item.TTL, nil)
+			for _, record := range *item.SrvRecords {
+				if record.Priority == nil || record.Weight == nil || record.Port == nil || record.Target == nil {
+					continue
+				}
+				target := "."
+				if t := *record.Target; t != "" && t != "." {
+					target = dns.AlignHostname(t)
+				}
+				rs.Add(&dns.Record{Value: fmt.Sprintf("%d %d %d %s", *record.Priority, *record.Weight, *record.Port, target)})
+			}
+			dnssets.AddRecordSetFromProvider(fullName, rs)
+		}
+
+		if item.CaaRecords != nil {
+			rs := dns.NewRecordSet(dns.RS_CAA, *item.TTL, nil)
+			for _, record := range *item.CaaRecords {
+				if record.Flags == nil || record.Tag == nil || record.Value == nil {
+					continue
+				}
+				rs.Add(&dns.Record{Value: dns.CAAValue(int(*record.Flags), *record.Tag, *record.Value)})
 			}
 			dnssets.AddRecordSetFromProvider(fullName, rs)
 		}
@@ -203,19 +259,31 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	switch rtype {
+	case dns.RS_SRV, dns.RS_CAA:
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	return utils.ClassifyError(err)
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
 	resourceGroup, zoneName := utils.SplitZoneID(zone.Id().ID)
-	exec := NewExecution(logger, h, resourceGroup, zoneName)
+	exec := NewExecution(ctx, logger, h, resourceGroup, zoneName)
 
 	var succeeded, failed int
 	for _, r := range reqs {
@@ -234,6 +302,13 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 				r.Done.SetInvalid(err)
 			}
 			continue
+		} else if status == bs_invalidTarget {
+			failed++
+			err := fmt.Errorf("Unresolvable alias target for dns name: %s", *rset.Name)
+			if r.Done != nil {
+				r.Done.Failed(err)
+			}
+			continue
 		}
 
 		err := exec.apply(r.Action, recordType, rset, h.config.Metrics)
@@ -266,3 +341,33 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+func (h *Handler) MapTarget(t provider.Target) provider.Target {
+	if t.GetRecordType() == dns.RS_CNAME && findAliasTargetKind(strings.ToLower(t.GetHostName())) != nil {
+		id, err := h.resolver.resolve(t.GetHostName())
+		if err != nil {
+			h.config.Logger.Warnf("cannot validate alias target %s -> using CNAME: %s", t.GetHostName(), err)
+		} else if id == "" {
+			h.config.Logger.Warnf("alias target %s not found in subscription -> using CNAME", t.GetHostName())
+		} else {
+			return dnsutils.NewTarget(dns.RS_ALIAS, t.GetHostName(), t.GetTTL())
+		}
+	}
+	return t
+}
+
+// isAliasRecordSet returns true for CNAME alias record sets pointing to an Azure resource
+func isAliasRecordSet(item azure.RecordSet) bool {
+	return item.RecordSetProperties != nil && item.TargetResource != nil && item.TargetResource.ID != nil &&
+		item.Type != nil && strings.HasSuffix(*item.Type, "/"+string(azure.CNAME))
+}
+
+func (h *Handler) aliasTargetHostname(item azure.RecordSet) string {
+	if target := item.Metadata[aliasTargetMetadata]; target != nil && *target != "" {
+		return *target
+	}
+	if target := h.resolver.hostname(*item.TargetResource.ID); target != "" {
+		return target
+	}
+	return *item.TargetResource.ID
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/adal"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/Azure/go-autorest/autorest/azure/auth"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// environment variables injected by the Azure workload identity webhook
+const (
+	envClientID           = "AZURE_CLIENT_ID"
+	envTenantID           = "AZURE_TENANT_ID"
+	envFederatedTokenFile = "AZURE_FEDERATED_TOKEN_FILE"
+	envAuthorityHost      = "AZURE_AUTHORITY_HOST"
+)
+
+var getenv = os.Getenv
+
+// newAuthorizer creates the authorizer for the credentials selected by the properties:
+//   - AZURE_USE_WORKLOAD_IDENTITY: federated token of a workload identity, client ID, tenant ID and token file
+//     default to the environment injected by the Azure workload identity webhook
+//   - AZURE_USE_MANAGED_IDENTITY: managed identity of the node, AZURE_CLIENT_ID optionally selects a user-assigned identity
+//   - otherwise: client secret of a service principal
+func newAuthorizer(c *provider.DNSHandlerConfig) (autorest.Authorizer, error) {
+	useWorkloadIdentity, err := c.GetDefaultedBoolProperty("AZURE_USE_WORKLOAD_IDENTITY", false, "useWorkloadIdentity")
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for AZURE_USE_WORKLOAD_IDENTITY: %s", err)
+	}
+	useManagedIdentity, err := c.GetDefaultedBoolProperty("AZURE_USE_MANAGED_IDENTITY", false, "useManagedIdentity")
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for AZURE_USE_MANAGED_IDENTITY: %s", err)
+	}
+	if useWorkloadIdentity || useManagedIdentity {
+		if useWorkloadIdentity && useManagedIdentity {
+			return nil, fmt.Errorf("AZURE_USE_WORKLOAD_IDENTITY and AZURE_USE_MANAGED_IDENTITY cannot be used together")
+		}
+		if c.GetProperty("AZURE_CLIENT_SECRET", "clientSecret") != "" {
+			return nil, fmt.Errorf("explicit client secret (AZURE_CLIENT_SECRET or clientSecret) cannot be used together with a workload or managed identity")
+		}
+	}
+
+	var authorizer autorest.Authorizer
+	switch {
+	case useWorkloadIdentity:
+		authorizer, err = newWorkloadIdentityAuthorizer(c)
+		if err != nil {
+			return nil, perrs.WrapAsHandlerError(err, "Creating Azure authorizer with workload identity failed")
+		}
+	case useManagedIdentity:
+		config := auth.NewMSIConfig()
+		config.ClientID = c.GetProperty("AZURE_CLIENT_ID", "clientID")
+		authorizer, err = config.Authorizer()
+		if err != nil {
+			return nil, perrs.WrapAsHandlerError(err, "Creating Azure authorizer with managed identity failed")
+		}
+	default:
+		// see https://docs.microsoft.com/en-us/go/azure/azure-sdk-go-authorization
+		clientID, err := c.GetRequiredProperty("AZURE_CLIENT_ID", "clientID")
+		if err != nil {
+			return nil, err
+		}
+		clientSecret, err := c.GetRequiredProperty("AZURE_CLIENT_SECRET", "clientSecret")
+		if err != nil {
+			return nil, err
+		}
+		tenantID, err := c.GetRequiredProperty("AZURE_TENANT_ID", "tenantID")
+		if err != nil {
+			return nil, err
+		}
+		authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
+		if err != nil {
+			return nil, perrs.WrapAsHandlerError(err, "Creating Azure authorizer with client credentials failed")
+		}
+	}
+	return authorizer, nil
+}
+
+func newWorkloadIdentityAuthorizer(c *provider.DNSHandlerConfig) (autorest.Authorizer, error) {
+	clientID := propertyOrEnv(c, envClientID, "AZURE_CLIENT_ID", "clientID")
+	if clientID == "" {
+		return nil, fmt.Errorf("AZURE_CLIENT_ID or clientID required for workload identity")
+	}
+	tenantID := propertyOrEnv(c, envTenantID, "AZURE_TENANT_ID", "tenantID")
+	if tenantID == "" {
+		return nil, fmt.Errorf("AZURE_TENANT_ID or tenantID required for workload identity")
+	}
+	tokenFile := propertyOrEnv(c, envFederatedTokenFile, "AZURE_FEDERATED_TOKEN_FILE", "federatedTokenFile")
+	if tokenFile == "" {
+		return nil, fmt.Errorf("AZURE_FEDERATED_TOKEN_FILE or federatedTokenFile required for workload identity")
+	}
+	authorityHost := getenv(envAuthorityHost)
+	if authorityHost == "" {
+		authorityHost = azure.PublicCloud.ActiveDirectoryEndpoint
+	}
+	oauthConfig, err := adal.NewOAuthConfig(authorityHost, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	token, err := adal.NewServicePrincipalTokenWithSecret(*oauthConfig, clientID, azure.PublicCloud.ResourceManagerEndpoint,
+		&federatedTokenSecret{tokenFile: tokenFile})
+	if err != nil {
+		return nil, err
+	}
+	return autorest.NewBearerAuthorizer(token), nil
+}
+
+// propertyOrEnv returns the value of a property, or of the environment variable if the property is not set.
+func propertyOrEnv(c *provider.DNSHandlerConfig, env, key string, altKeys ...string) string {
+	if value := c.GetProperty(key, altKeys...); value != "" {
+		return value
+	}
+	return getenv(env)
+}
+
+// federatedTokenSecret authenticates with the federated token of a workload identity as client assertion.
+// The token file is read again for every token refresh, as it is rotated by the kubelet.
+type federatedTokenSecret struct {
+	tokenFile string
+}
+
+var _ adal.ServicePrincipalSecret = &federatedTokenSecret{}
+
+func (this *federatedTokenSecret) SetAuthenticationValues(_ *adal.ServicePrincipalToken, values *url.Values) error {
+	token, err := os.ReadFile(this.tokenFile)
+	if err != nil {
+		return fmt.Errorf("cannot read federated token: %w", err)
+	}
+	values.Set("client_assertion", strings.TrimSpace(string(token)))
+	values.Set("client_assertion_type", "urn:ietf:params:oauth:client-assertion-type:jwt-bearer")
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (this federatedTokenSecret) MarshalJSON() ([]byte, error) {
+	return nil, fmt.Errorf("marshalling federatedTokenSecret is not supported")
+}
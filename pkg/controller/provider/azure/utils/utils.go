@@ -17,12 +17,12 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
@@ -60,31 +60,42 @@ func SplitZoneID(zoneid string) (string, string) {
 	return parts[0], parts[1]
 }
 
-// GetSubscriptionIDAndAuthorizer extracts credentials from config
+// GetSubscriptionIDAndAuthorizer extracts credentials from config.
+// Instead of a client secret, a workload identity or a managed identity can be selected, see newAuthorizer.
 func GetSubscriptionIDAndAuthorizer(c *provider.DNSHandlerConfig) (subscriptionID string, authorizer autorest.Authorizer, err error) {
 	subscriptionID, err = c.GetRequiredProperty("AZURE_SUBSCRIPTION_ID", "subscriptionID")
 	if err != nil {
 		return
 	}
+	authorizer, err = newAuthorizer(c)
+	return
+}
 
-	// see https://docs.microsoft.com/en-us/go/azure/azure-sdk-go-authorization
-	clientID, err := c.GetRequiredProperty("AZURE_CLIENT_ID", "clientID")
-	if err != nil {
-		return
+// AddResourceTags adds the resource tags to the metadata of a record set.
+// Existing metadata entries are kept.
+func AddResourceTags(metadata map[string]*string, tags map[string]string) map[string]*string {
+	if len(tags) == 0 {
+		return metadata
 	}
-	clientSecret, err := c.GetRequiredProperty("AZURE_CLIENT_SECRET", "clientSecret")
-	if err != nil {
-		return
+	if metadata == nil {
+		metadata = map[string]*string{}
 	}
-	tenantID, err := c.GetRequiredProperty("AZURE_TENANT_ID", "tenantID")
-	if err != nil {
-		return
+	for k, v := range tags {
+		if _, ok := metadata[k]; !ok {
+			value := v
+			metadata[k] = &value
+		}
 	}
+	return metadata
+}
 
-	authorizer, err = auth.NewClientCredentialsConfig(clientID, clientSecret, tenantID).Authorizer()
-	if err != nil {
-		err = perrs.WrapAsHandlerError(err, "Creating Azure authorizer with client credentials failed")
-		return
+// ClassifyError maps the HTTP status code of Azure API errors to error reasons.
+func ClassifyError(err error) perrs.ErrorReason {
+	var detailedErr autorest.DetailedError
+	if errors.As(err, &detailedErr) {
+		if code, ok := detailedErr.StatusCode.(int); ok {
+			return perrs.ReasonForHTTPStatus(code)
+		}
 	}
-	return
+	return perrs.ReasonUnknown
 }
@@ -16,7 +16,17 @@
 
 package utils
 
-import "testing"
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
 
 func TestDropZoneName(t *testing.T) {
 	table := []struct {
@@ -41,3 +51,61 @@ func TestDropZoneName(t *testing.T) {
 		}
 	}
 }
+
+func TestAddResourceTags(t *testing.T) {
+	alias := "target"
+	metadata := AddResourceTags(map[string]*string{"alias": &alias}, map[string]string{"alias": "x", "environment": "prod"})
+	if len(metadata) != 2 {
+		t.Errorf("Failed: unexpected metadata size: %d", len(metadata))
+	}
+	if *metadata["alias"] != "target" {
+		t.Errorf("Failed: existing metadata overwritten: %s", *metadata["alias"])
+	}
+	if *metadata["environment"] != "prod" {
+		t.Errorf("Failed: unexpected tag value: %s", *metadata["environment"])
+	}
+	if AddResourceTags(nil, nil) != nil {
+		t.Errorf("Failed: unexpected metadata for empty tags")
+	}
+}
+
+func TestFederatedTokenSecret(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("jwt\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	values := url.Values{}
+	if err := (&federatedTokenSecret{tokenFile: file}).SetAuthenticationValues(nil, &values); err != nil {
+		t.Fatalf("Failed: %s", err)
+	}
+	if values.Get("client_assertion") != "jwt" {
+		t.Errorf("Failed: unexpected client assertion: %q", values.Get("client_assertion"))
+	}
+	if err := (&federatedTokenSecret{tokenFile: file + "x"}).SetAuthenticationValues(nil, &values); err == nil {
+		t.Errorf("Failed: missing error for missing token file")
+	}
+}
+
+func TestWorkloadIdentity(t *testing.T) {
+	getenv = func(key string) string {
+		return map[string]string{envClientID: "client", envTenantID: "tenant", envFederatedTokenFile: "/var/run/token"}[key]
+	}
+	defer func() { getenv = os.Getenv }()
+
+	config := func(props utils.Properties) *provider.DNSHandlerConfig {
+		return &provider.DNSHandlerConfig{Logger: logger.New(), Properties: props}
+	}
+	if _, err := newAuthorizer(config(utils.Properties{"AZURE_USE_WORKLOAD_IDENTITY": "true"})); err != nil {
+		t.Errorf("Failed: %s", err)
+	}
+	if _, err := newAuthorizer(config(utils.Properties{"AZURE_USE_WORKLOAD_IDENTITY": "true", "AZURE_CLIENT_SECRET": "secret"})); err == nil {
+		t.Errorf("Failed: missing error for workload identity with client secret")
+	}
+	if _, err := newAuthorizer(config(utils.Properties{"useWorkloadIdentity": "true", "useManagedIdentity": "true"})); err == nil {
+		t.Errorf("Failed: missing error for workload and managed identity")
+	}
+	getenv = func(string) string { return "" }
+	if _, err := newAuthorizer(config(utils.Properties{"AZURE_USE_WORKLOAD_IDENTITY": "true"})); err == nil {
+		t.Errorf("Failed: missing error for missing client ID")
+	}
+}
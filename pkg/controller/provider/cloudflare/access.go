@@ -17,112 +17,90 @@
 package cloudflare
 
 import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
 	"github.com/cloudflare/cloudflare-go"
-	"k8s.io/client-go/util/flowcontrol"
 
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
 )
 
-type Access interface {
-	ListZones(consume func(zone cloudflare.Zone) (bool, error)) error
-	ListRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error)) error
+// zonesPageSize is the maximum page size of the zone list
+const zonesPageSize = 50
 
-	raw.Executor
-}
+// tunnelDomain is the domain of Cloudflare Tunnel endpoints. CNAME records for tunnels must be proxied.
+const tunnelDomain = ".cfargotunnel.com"
+
+// tunnelIDPattern matches a Cloudflare Tunnel UUID given as target
+var tunnelIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 
-type access struct {
+type backend struct {
 	*cloudflare.API
-	metrics     provider.Metrics
-	rateLimiter flowcontrol.RateLimiter
 }
 
-func NewAccess(apiToken string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) (Access, error) {
-	api, err := cloudflare.NewWithAPIToken(apiToken)
-	if err != nil {
-		return nil, err
+var _ sdk.Backend = &backend{}
+var _ sdk.RecordSetGetter = &backend{}
+
+// ListZones reads the zone list page by page. ListZones of the Cloudflare client
+// is not used, as it requests the pages concurrently without synchronization.
+func (this *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	zones := []sdk.Zone{}
+	for page := 1; ; page++ {
+		results, err := this.API.ListZonesContext(ctx, cloudflare.WithPagination(cloudflare.PaginationOptions{Page: page, PerPage: zonesPageSize}))
+		if err != nil {
+			return nil, err
+		}
+		for _, z := range results.Result {
+			zones = append(zones, sdk.Zone{ID: z.ID, Domain: z.Name})
+		}
+		if page >= results.TotalPages {
+			return zones, nil
+		}
 	}
-	return &access{API: api, metrics: metrics, rateLimiter: rateLimiter}, nil
 }
 
-func (this *access) ListZones(consume func(zone cloudflare.Zone) (bool, error)) error {
-	this.metrics.AddGenericRequests(provider.M_LISTZONES, 1)
-	this.rateLimiter.Accept()
-	results, err := this.API.ListZones()
-	if err != nil {
-		return err
-	}
-	for _, z := range results {
-		if cont, err := consume(z); !cont || err != nil {
-			return err
-		}
-	}
-	return nil
+func (this *backend) ListRecords(ctx context.Context, zoneID string) (raw.RecordSet, error) {
+	return this.listRecords(zoneID, cloudflare.DNSRecord{})
 }
 
-func (this *access) ListRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error)) error {
-	return this.listRecords(zoneId, consume, cloudflare.DNSRecord{})
+func (this *backend) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, rtype string) (raw.RecordSet, error) {
+	return this.listRecords(zone.Id().ID, cloudflare.DNSRecord{Type: rtype, Name: dnsName})
 }
 
-func (this *access) listRecords(zoneId string, consume func(record cloudflare.DNSRecord) (bool, error),
-	record cloudflare.DNSRecord) error {
-	this.metrics.AddZoneRequests(zoneId, provider.M_LISTRECORDS, 1)
-	this.rateLimiter.Accept()
-	results, err := this.DNSRecords(zoneId, record)
+func (this *backend) listRecords(zoneID string, filter cloudflare.DNSRecord) (raw.RecordSet, error) {
+	results, err := this.DNSRecords(zoneID, filter)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, z := range results {
-		if cont, err := consume(z); !cont || err != nil {
-			return err
-		}
+	rs := raw.RecordSet{}
+	for i := range results {
+		rs = append(rs, (*Record)(&results[i]))
 	}
-	return nil
+	return rs, nil
 }
 
-func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
-	ttl := r.GetTTL()
-	testTTL(&ttl)
-	dnsRecord := cloudflare.DNSRecord{
-		Type:    r.GetType(),
-		Name:    r.GetDNSName(),
-		Content: r.GetValue(),
-		TTL:     ttl,
-		ZoneID:  a.ZoneID,
-	}
-	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
-	this.rateLimiter.Accept()
-	_, err := this.CreateDNSRecord(a.ZoneID, dnsRecord)
+	_, err := this.CreateDNSRecord(a.ZoneID, newDNSRecord(a))
 	return err
 }
 
-func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
-	ttl := r.GetTTL()
-	testTTL(&ttl)
-	dnsRecord := cloudflare.DNSRecord{
-		Type:    r.GetType(),
-		Name:    r.GetDNSName(),
-		Content: r.GetValue(),
-		TTL:     ttl,
-		ZoneID:  a.ZoneID,
-	}
-	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_UPDATERECORDS, 1)
-	this.rateLimiter.Accept()
-	err := this.UpdateDNSRecord(a.ZoneID, r.GetId(), dnsRecord)
-	return err
+	return this.UpdateDNSRecord(a.ZoneID, r.GetId(), newDNSRecord(a))
 }
 
-func (this *access) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
-	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_DELETERECORDS, 1)
-	this.rateLimiter.Accept()
-	err := this.DeleteDNSRecord(a.ZoneID, r.GetId())
-	return err
+	return this.DeleteDNSRecord(a.ZoneID, r.GetId())
 }
 
-func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+func (this *backend) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
 	return (*Record)(&cloudflare.DNSRecord{
 		Type:    rtype,
 		Name:    fqdn,
@@ -132,23 +110,70 @@ func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHosted
 	})
 }
 
-func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
-	rs := raw.RecordSet{}
-	consume := func(record cloudflare.DNSRecord) (bool, error) {
-		a := (*Record)(&record)
-		rs = append(rs, a)
-		return true, nil
+// newDNSRecord creates the record to be sent to the Cloudflare API.
+func newDNSRecord(a *Record) cloudflare.DNSRecord {
+	ttl := a.GetTTL()
+	proxied := a.Proxied || isTunnelRecord(a.GetType(), a.GetValue())
+	testTTL(&ttl, proxied)
+	dnsRecord := cloudflare.DNSRecord{
+		Type:    a.GetType(),
+		Name:    a.GetDNSName(),
+		Content: a.GetValue(),
+		TTL:     ttl,
+		Proxied: proxied,
+		ZoneID:  a.ZoneID,
+	}
+	setStructuredData(&dnsRecord)
+	return dnsRecord
+}
+
+// setStructuredData sets the structured data required by the Cloudflare API for some record types.
+func setStructuredData(r *cloudflare.DNSRecord) {
+	switch r.Type {
+	case dns.RS_SVCB, dns.RS_HTTPS:
+		setServiceBindingData(r)
+	case dns.RS_CAA:
+		setCAAData(r)
 	}
+}
 
-	err := this.listRecords(zone.Id().ID, consume, cloudflare.DNSRecord{Type: rtype, Name: dnsName})
+// setServiceBindingData sets the structured data for SVCB and HTTPS records
+// from the value in presentation format (<priority> <target> <params>).
+func setServiceBindingData(r *cloudflare.DNSRecord) {
+	fields := strings.SplitN(r.Content, " ", 3)
+	if len(fields) < 2 {
+		return
+	}
+	priority, err := strconv.Atoi(fields[0])
 	if err != nil {
-		return nil, err
+		return
 	}
-	return rs, nil
+	value := ""
+	if len(fields) == 3 {
+		value = fields[2]
+	}
+	r.Content = ""
+	r.Data = map[string]interface{}{"priority": priority, "target": fields[1], "value": value}
+}
+
+// setCAAData sets the structured data for CAA records from the value in presentation format (<flags> <tag> "<value>").
+func setCAAData(r *cloudflare.DNSRecord) {
+	flags, tag, value, err := dns.ParseCAAValue(r.Content)
+	if err != nil {
+		return
+	}
+	r.Content = ""
+	r.Data = map[string]interface{}{"flags": flags, "tag": tag, "value": value}
+}
+
+// isTunnelRecord returns true for CNAME records pointing to a Cloudflare Tunnel
+func isTunnelRecord(rtype, value string) bool {
+	return rtype == dns.RS_CNAME && strings.HasSuffix(strings.TrimSuffix(value, "."), tunnelDomain)
 }
 
-func testTTL(ttl *int) {
-	if *ttl < 120 {
+func testTTL(ttl *int, proxied bool) {
+	// proxied records always use automatic TTL
+	if *ttl < 120 || proxied {
 		*ttl = 1
 	}
 }
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package cloudflare
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// DNSSECSetting is the DNSSEC setting of a Cloudflare zone
+type DNSSECSetting struct {
+	Status     string `json:"status"`
+	KeyTag     int    `json:"key_tag"`
+	Algorithm  string `json:"algorithm"`
+	DigestType string `json:"digest_type"`
+	Digest     string `json:"digest"`
+}
+
+func (this *backend) GetDNSSEC(zoneId string) (*DNSSECSetting, error) {
+	raw, err := this.API.Raw(http.MethodGet, "/zones/"+zoneId+"/dnssec", nil)
+	if err != nil {
+		return nil, err
+	}
+	setting := &DNSSECSetting{}
+	if err := json.Unmarshal(raw, setting); err != nil {
+		return nil, fmt.Errorf("cannot parse DNSSEC setting: %w", err)
+	}
+	return setting, nil
+}
+
+func (this *backend) EnableDNSSEC(zoneId string) error {
+	_, err := this.API.Raw(http.MethodPatch, "/zones/"+zoneId+"/dnssec", map[string]string{"status": "active"})
+	return err
+}
+
+var _ provider.DNSSECAccess = &Handler{}
+
+func (h *Handler) GetDNSSECState(ctx context.Context, zone provider.DNSHostedZone) (*provider.DNSSECState, error) {
+	h.Request(zone.Id().ID, provider.M_DNSSEC)
+	setting, err := h.backend.GetDNSSEC(zone.Id().ID)
+	if err != nil {
+		return nil, err
+	}
+	state := &provider.DNSSECState{}
+	switch setting.Status {
+	case "active":
+		state.State = api.DNSSEC_STATE_SIGNING
+	case "pending":
+		state.State = api.DNSSEC_STATE_PENDING
+	case "disabled", "pending-disabled":
+		state.State = api.DNSSEC_STATE_NOT_SIGNING
+	default:
+		state.State = api.DNSSEC_STATE_ERROR
+		state.Message = fmt.Sprintf("DNSSEC status %s", setting.Status)
+	}
+	if setting.Digest != "" && state.State != api.DNSSEC_STATE_NOT_SIGNING {
+		state.DSRecords = []string{fmt.Sprintf("%d %s %s %s", setting.KeyTag, setting.Algorithm, setting.DigestType, strings.ToUpper(setting.Digest))}
+	}
+	return state, nil
+}
+
+func (h *Handler) EnableDNSSEC(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone) error {
+	h.Request(zone.Id().ID, provider.M_DNSSEC)
+	return h.backend.EnableDNSSEC(zone.Id().ID)
+}
@@ -17,32 +17,28 @@
 package cloudflare
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/cloudflare/cloudflare-go"
-	"github.com/gardener/controller-manager-library/pkg/logger"
+
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
-	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 type Handler struct {
-	provider.DefaultDNSHandler
-	config provider.DNSHandlerConfig
-	cache  provider.ZoneCache
-	access Access
+	*sdk.Handler
+	backend *backend
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.ProviderHintsSupport = &Handler{}
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
-	var err error
-
-	h := &Handler{
-		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
-		config:            *c,
-	}
-
 	apiToken, err := c.GetRequiredProperty("CLOUDFLARE_API_TOKEN", "apiToken")
 	if err != nil {
 		return nil, err
@@ -53,148 +49,72 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	//	return nil, err
 	//}
 
-	access, err := NewAccess(apiToken, c.Metrics, c.RateLimiter)
-	if err != nil {
-		return nil, err
-	}
-
-	h.access = access
-
-	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZonesOnly, c.Metrics, h.getZones, h.getZoneState)
+	api, err := cloudflare.NewWithAPIToken(apiToken)
 	if err != nil {
 		return nil, err
 	}
-
-	return h, nil
+	return NewHandlerForAPI(TYPE_CODE, c, api)
 }
 
-func (h *Handler) Release() {
-	h.cache.Release()
-}
-
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
-}
-
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
-	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
-	rawZones := []cloudflare.Zone{}
-	{
-		f := func(zone cloudflare.Zone) (bool, error) {
-			if blockedZones.Contains(zone.ID) {
-				h.config.Logger.Infof("ignoring blocked zone id: %s", zone.ID)
-			} else {
-				rawZones = append(rawZones, zone)
-			}
-			return true, nil
-		}
-		err := h.access.ListZones(f)
-		if err != nil {
-			return nil, err
-		}
+// NewHandlerForAPI creates a handler of the given provider type for a client of a Cloudflare compatible API,
+// like the API of IBM Cloud Internet Services.
+func NewHandlerForAPI(providerType string, c *provider.DNSHandlerConfig, api *cloudflare.API) (provider.DNSHandler, error) {
+	b := &backend{API: api}
+	options := sdk.Options{
+		RecordTypes:     []string{dns.RS_SVCB, dns.RS_HTTPS, dns.RS_CAA},
+		SkipZone:        sdk.IsAccessForbidden,
+		AdjustRecordSet: adjustRecordSet,
+		ClassifyError:   classifyError,
 	}
-
-	zones := provider.DNSHostedZones{}
-
-	for _, z := range rawZones {
-		forwarded := []string{}
-		f := func(r cloudflare.DNSRecord) (bool, error) {
-			if r.Type == dns.RS_NS {
-				name := r.Name
-				if name != z.Name {
-					forwarded = append(forwarded, name)
-				}
-			}
-			return true, nil
-		}
-		err := h.access.ListRecords(z.ID, f)
-		if err != nil {
-			if checkAccessForbidden(err) {
-				// It is possible to deny access to certain zones in the account
-				// As a result, z zone should not be appended to the hosted zones
-				continue
-			}
-			return nil, err
-		}
-		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.ID, z.Name, z.ID, forwarded, false)
-		zones = append(zones, hostedZone)
-	}
-
-	return zones, nil
-}
-
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
-}
-
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
-	state := raw.NewState()
-
-	f := func(r cloudflare.DNSRecord) (bool, error) {
-		a := (*Record)(&r)
-		state.AddRecord(a)
-		return true, nil
-	}
-	err := h.access.ListRecords(zone.Id().ID, f)
+	sh, err := sdk.NewHandler(providerType, c, b, options)
 	if err != nil {
 		return nil, err
 	}
-	state.CalculateDNSSets()
-	return state, nil
+	return &Handler{Handler: sh, backend: b}, nil
 }
 
-func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
-	return h.cache.ReportZoneStateConflict(zone, err)
-}
+// httpStatusPattern extracts the HTTP status code from the errors of the Cloudflare client
+var httpStatusPattern = regexp.MustCompile(`HTTP status (\d+)`)
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := raw.ExecuteRequests(logger, &h.config, h.access, zone, state, reqs)
-	h.cache.ApplyRequests(logger, err, zone, reqs)
-	return err
+func classifyError(err error) perrs.ErrorReason {
+	if m := httpStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return perrs.ReasonForHTTPStatus(code)
+	}
+	return perrs.ReasonUnknown
 }
 
-func checkAccessForbidden(err error) bool {
-	if err != nil && strings.Contains(err.Error(), "403") {
-		return true
+func adjustRecordSet(rs *dns.RecordSet) {
+	if rs.ProviderHints != nil && rs.ProviderHints.CloudflareProxied {
+		// proxied records always use automatic TTL
+		rs.IgnoreTTL = true
 	}
-	return false
 }
 
-func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
-	rs, err := h.access.GetRecordSet(dnsName, recordType, zone)
-	if err != nil {
-		return nil, err
-	}
-	d := provider.DedicatedRecordSet{}
-	for _, r := range rs {
-		d = append(d, r)
+// MapTarget maps a Cloudflare Tunnel UUID given as target to the CNAME of the tunnel endpoint
+func (h *Handler) MapTarget(t provider.Target) provider.Target {
+	if t.GetRecordType() == dns.RS_CNAME && tunnelIDPattern.MatchString(t.GetHostName()) {
+		return dnsutils.NewTarget(dns.RS_CNAME, strings.ToLower(t.GetHostName())+tunnelDomain, t.GetTTL())
 	}
-	return d, nil
+	return t
 }
 
-func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
-	err := h.DeleteRecordSet(logger, zone, old)
-	if err != nil {
-		return err
+// ProviderHintsFor enables the Cloudflare proxy for A, AAAA and CNAME record sets if requested by the entry.
+// Record sets of Cloudflare Tunnel endpoints are always proxied.
+func (h *Handler) ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints {
+	switch rs.Type {
+	case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME:
+	default:
+		return nil
 	}
-	for _, r := range new {
-		r0 := h.access.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, int64(r.GetTTL()))
-		err = h.access.CreateRecord(r0, zone)
-		if err != nil {
-			return err
+	proxied := hints != nil && hints.CloudflareProxied
+	for _, r := range rs.Records {
+		if isTunnelRecord(rs.Type, r.Value) {
+			proxied = true
 		}
 	}
-	return err
-}
-
-func (h *Handler) DeleteRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
-	for _, r := range rs {
-		if r.(*Record).GetId() != "" {
-			err := h.access.DeleteRecord(r.(*Record), zone)
-			if err != nil {
-				return err
-			}
-		}
+	if !proxied {
+		return nil
 	}
-	return nil
+	return &dns.ProviderHints{CloudflareProxied: true}
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
 )
 
 type Record cloudflare.DNSRecord
@@ -28,12 +29,20 @@ type Record cloudflare.DNSRecord
 func (r *Record) GetType() string    { return r.Type }
 func (r *Record) GetId() string      { return r.ID }
 func (r *Record) GetDNSName() string { return r.Name }
-func (r *Record) GetValue() string {
-	if r.Type == dns.RS_TXT {
-		return raw.EnsureQuotedText(r.Content)
+func (r *Record) GetValue() string   { return sdk.NormalizeValue(r.Type, r.Content) }
+func (r *Record) GetTTL() int        { return r.TTL }
+func (r *Record) SetTTL(ttl int)     { r.TTL = ttl }
+func (r *Record) Copy() raw.Record   { n := *r; return &n }
+
+var _ raw.ProviderHintsRecord = &Record{}
+
+func (r *Record) GetProviderHints() *dns.ProviderHints {
+	if !r.Proxied {
+		return nil
 	}
-	return r.Content
+	return &dns.ProviderHints{CloudflareProxied: true}
+}
+
+func (r *Record) SetProviderHints(hints *dns.ProviderHints) {
+	r.Proxied = hints != nil && hints.CloudflareProxied
 }
-func (r *Record) GetTTL() int      { return r.TTL }
-func (r *Record) SetTTL(ttl int)   { r.TTL = ttl }
-func (r *Record) Copy() raw.Record { n := *r; return &n }
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/compute/metadata"
+	"golang.org/x/oauth2/google"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// metadataServer allows to replace the access to the metadata server in tests
+var metadataServer = struct {
+	onGCE     func() bool
+	projectID func() (string, error)
+	email     func(serviceAccount string) (string, error)
+}{metadata.OnGCE, metadata.ProjectID, metadata.Email}
+
+// credentialsFile contains the fields of a credentials file relevant for the handler.
+type credentialsFile struct {
+	Type                           string `json:"type"`
+	ClientEmail                    string `json:"client_email"`
+	ServiceAccountImpersonationURL string `json:"service_account_impersonation_url"`
+}
+
+// readCredentials creates the credentials from the provider secret. Supported are
+//   - a service account key (`serviceaccount.json`),
+//   - a credential configuration of a workload identity federation (`credentialsConfig`
+//     or `serviceaccount.json` with type `external_account`),
+//   - the credentials of the metadata server (`useMetadataServer`), e.g. for GKE workload identity.
+//
+// The tokens of all credentials are refreshed automatically. Besides the credentials the
+// email of the service account is returned, if known.
+func readCredentials(ctx context.Context, c *provider.DNSHandlerConfig, scopes []string) (*google.Credentials, string, error) {
+	useMetadataServer, err := c.GetDefaultedBoolProperty("useMetadataServer", false, "USE_METADATA_SERVER")
+	if err != nil {
+		return nil, "", err
+	}
+	project := c.GetProperty("project", "projectID")
+	data := c.GetProperty("serviceaccount.json", "credentialsConfig")
+
+	if useMetadataServer {
+		if data != "" {
+			return nil, "", fmt.Errorf("'useMetadataServer' cannot be combined with 'serviceaccount.json' or 'credentialsConfig'")
+		}
+		return metadataServerCredentials(project, scopes)
+	}
+	if data == "" {
+		return nil, "", fmt.Errorf("'serviceaccount.json' or 'credentialsConfig' required in secret (or 'useMetadataServer')")
+	}
+
+	file := credentialsFile{}
+	if err := json.Unmarshal([]byte(data), &file); err != nil {
+		return nil, "", fmt.Errorf("serviceaccount is invalid: %s", err)
+	}
+	credentials, err := google.CredentialsFromJSON(ctx, []byte(data), scopes...)
+	if err != nil {
+		return nil, "", fmt.Errorf("serviceaccount is invalid: %s", err)
+	}
+	if project != "" {
+		credentials.ProjectID = project
+	}
+	if credentials.ProjectID == "" {
+		return nil, "", fmt.Errorf("'project' required in secret for credentials of type %q without project", file.Type)
+	}
+	email := file.ClientEmail
+	if email == "" {
+		email = impersonatedServiceAccount(file.ServiceAccountImpersonationURL)
+	}
+	return credentials, email, nil
+}
+
+// metadataServerCredentials uses the access tokens of the service account attached
+// to the node or, with GKE workload identity, to the kubernetes service account of the controller.
+func metadataServerCredentials(project string, scopes []string) (*google.Credentials, string, error) {
+	if !metadataServer.onGCE() {
+		return nil, "", fmt.Errorf("metadata server not available")
+	}
+	if project == "" {
+		var err error
+		project, err = metadataServer.projectID()
+		if err != nil {
+			return nil, "", fmt.Errorf("cannot get project from metadata server: %w", err)
+		}
+	}
+	email, _ := metadataServer.email("")
+	return &google.Credentials{
+		ProjectID:   project,
+		TokenSource: google.ComputeTokenSource("", scopes...),
+	}, email, nil
+}
+
+// impersonatedServiceAccount extracts the email of the service account from
+// an URL like `https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/<email>:generateAccessToken`.
+func impersonatedServiceAccount(url string) string {
+	i := strings.LastIndex(url, "/serviceAccounts/")
+	if i < 0 {
+		return ""
+	}
+	email := url[i+len("/serviceAccounts/"):]
+	if j := strings.Index(email, ":"); j >= 0 {
+		email = email[:j]
+	}
+	return email
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. exec file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use exec file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const externalAccount = `{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/pool/providers/provider",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "https://sts.googleapis.com/v1/token",
+  "service_account_impersonation_url": "https://iamcredentials.googleapis.com/v1/projects/-/serviceAccounts/dns@my-project.iam.gserviceaccount.com:generateAccessToken",
+  "credential_source": {"file": "/var/run/secrets/tokens/gcp-token"}
+}`
+
+func TestReadCredentials(t *testing.T) {
+	config := func(props utils.Properties) *provider.DNSHandlerConfig {
+		return &provider.DNSHandlerConfig{Logger: logger.New(), Properties: props}
+	}
+	ctx := context.Background()
+
+	credentials, email, err := readCredentials(ctx, config(utils.Properties{"credentialsConfig": externalAccount, "project": "my-project"}), nil)
+	if err != nil {
+		t.Fatalf("Failed: %s", err)
+	}
+	if credentials.ProjectID != "my-project" || email != "dns@my-project.iam.gserviceaccount.com" {
+		t.Errorf("Failed: unexpected project %q or email %q", credentials.ProjectID, email)
+	}
+	if _, _, err := readCredentials(ctx, config(utils.Properties{"credentialsConfig": externalAccount}), nil); err == nil {
+		t.Errorf("Failed: missing error for missing project")
+	}
+	if _, _, err := readCredentials(ctx, config(utils.Properties{}), nil); err == nil {
+		t.Errorf("Failed: missing error for missing credentials")
+	}
+	if _, _, err := readCredentials(ctx, config(utils.Properties{"useMetadataServer": "true", "serviceaccount.json": "{}"}), nil); err == nil {
+		t.Errorf("Failed: missing error for metadata server with service account")
+	}
+}
+
+func TestMetadataServerCredentials(t *testing.T) {
+	old := metadataServer
+	defer func() { metadataServer = old }()
+	metadataServer.onGCE = func() bool { return true }
+	metadataServer.projectID = func() (string, error) { return "node-project", nil }
+	metadataServer.email = func(string) (string, error) { return "node@node-project.iam.gserviceaccount.com", nil }
+
+	credentials, email, err := readCredentials(context.Background(), &provider.DNSHandlerConfig{Properties: utils.Properties{"useMetadataServer": "true"}}, nil)
+	if err != nil {
+		t.Fatalf("Failed: %s", err)
+	}
+	if credentials.ProjectID != "node-project" || email != "node@node-project.iam.gserviceaccount.com" {
+		t.Errorf("Failed: unexpected project %q or email %q", credentials.ProjectID, email)
+	}
+
+	metadataServer.onGCE = func() bool { return false }
+	if _, _, err := readCredentials(context.Background(), &provider.DNSHandlerConfig{Properties: utils.Properties{"useMetadataServer": "true"}}, nil); err == nil {
+		t.Errorf("Failed: missing error without metadata server")
+	}
+}
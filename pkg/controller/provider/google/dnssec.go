@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package google
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	googledns "google.golang.org/api/dns/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// dnssecAlgorithms maps the Cloud DNS algorithm names to the DNSSEC algorithm numbers
+var dnssecAlgorithms = map[string]int{
+	"rsasha1":         5,
+	"rsasha256":       8,
+	"rsasha512":       10,
+	"ecdsap256sha256": 13,
+	"ecdsap384sha384": 14,
+}
+
+// digestTypes maps the Cloud DNS digest type names to the DS digest type numbers
+var digestTypes = map[string]int{
+	"sha1":   1,
+	"sha256": 2,
+	"sha384": 4,
+}
+
+var _ provider.DNSSECAccess = &Handler{}
+
+func (h *Handler) GetDNSSECState(ctx context.Context, zone provider.DNSHostedZone) (*provider.DNSSECState, error) {
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	mz, err := h.service.ManagedZones.Get(projectID, zoneName).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	state := &provider.DNSSECState{State: api.DNSSEC_STATE_NOT_SIGNING}
+	if mz.Visibility == "private" {
+		state.State = api.DNSSEC_STATE_ERROR
+		state.Message = "DNSSEC not supported for private zones"
+		return state, nil
+	}
+	if mz.DnssecConfig == nil {
+		return state, nil
+	}
+	switch mz.DnssecConfig.State {
+	case "on":
+		state.State = api.DNSSEC_STATE_SIGNING
+	case "transfer":
+		state.State = api.DNSSEC_STATE_PENDING
+		state.Message = "zone in DNSSEC transfer state"
+	default:
+		return state, nil
+	}
+
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	f := func(resp *googledns.DnsKeysListResponse) error {
+		for _, key := range resp.DnsKeys {
+			if key.Type != "keySigning" || !key.IsActive {
+				continue
+			}
+			for _, d := range key.Digests {
+				if digestTypes[d.Type] != 2 {
+					continue
+				}
+				state.DSRecords = append(state.DSRecords, fmt.Sprintf("%d %d %d %s", key.KeyTag,
+					dnssecAlgorithms[key.Algorithm], digestTypes[d.Type], strings.ToUpper(d.Digest)))
+			}
+		}
+		return nil
+	}
+	if err := h.service.DnsKeys.List(projectID, zoneName).Pages(ctx, f); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+func (h *Handler) EnableDNSSEC(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone) error {
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_DNSSEC, 1)
+	h.config.RateLimiter.Accept()
+	patch := &googledns.ManagedZone{DnssecConfig: &googledns.ManagedZoneDnsSecConfig{State: "on"}}
+	_, err := h.service.ManagedZones.Patch(projectID, zoneName, patch).Context(ctx).Do()
+	return err
+}
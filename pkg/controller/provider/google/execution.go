@@ -17,6 +17,7 @@
 package google
 
 import (
+	"context"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	googledns "google.golang.org/api/dns/v1"
@@ -31,24 +32,32 @@ const (
 
 type Execution struct {
 	logger.LogContext
+	ctx     context.Context
 	handler *Handler
 	zone    provider.DNSHostedZone
 
 	change *googledns.Change
 	done   []provider.DoneHandler
+
+	dnssets dns.DNSSets
+	geo     map[string]*geoRecordSet
+	geoKeys []string
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
+func NewExecution(ctx context.Context, logger logger.LogContext, h *Handler, zone provider.DNSHostedZone, state provider.DNSZoneState) *Execution {
 	change := &googledns.Change{
 		Additions: []*googledns.ResourceRecordSet{},
 		Deletions: []*googledns.ResourceRecordSet{},
 	}
 	return &Execution{
 		LogContext: logger,
+		ctx:        ctx,
 		handler:    h,
 		zone:       zone,
 		change:     change,
 		done:       []provider.DoneHandler{},
+		dnssets:    state.GetDNSSets(),
+		geo:        map[string]*geoRecordSet{},
 	}
 }
 
@@ -66,6 +75,10 @@ func (this *Execution) addChange(req *provider.ChangeRequest) {
 		return
 	}
 	name = dns.AlignHostname(name)
+	if (newset != nil && isGeoRoutingPolicy(newset.RoutingPolicy)) || (oldset != nil && isGeoRoutingPolicy(oldset.RoutingPolicy)) {
+		this.addGeoChange(req, name, newset, oldset)
+		return
+	}
 	switch req.Action {
 	case provider.R_CREATE:
 		this.Infof("%s %s record set %s[%s]: %s(%d)", req.Action, req.Type, name, this.zone.Id(), newset.RecordString(), newset.TTL)
@@ -81,7 +94,61 @@ func (this *Execution) addChange(req *provider.ChangeRequest) {
 	this.done = append(this.done, req.Done)
 }
 
+func (this *Execution) addGeoChange(req *provider.ChangeRequest, name string, newset, oldset *dns.RecordSet) {
+	var rtype string
+	if newset != nil {
+		rtype = newset.Type
+	} else {
+		rtype = oldset.Type
+	}
+	key := name + "/" + rtype
+	geo := this.geo[key]
+	if geo == nil {
+		geo = newGeoRecordSet(name, rtype, this.currentGeoItems(name, rtype))
+		this.geo[key] = geo
+		this.geoKeys = append(this.geoKeys, key)
+	}
+	switch req.Action {
+	case provider.R_CREATE, provider.R_UPDATE:
+		location := newset.RoutingPolicy.SetIdentifier
+		this.Infof("%s %s record set %s[%s] for location %s: %s(%d)", req.Action, req.Type, name, this.zone.Id(), location, newset.RecordString(), newset.TTL)
+		geo.newItems[location] = newset
+	case provider.R_DELETE:
+		location := oldset.RoutingPolicy.SetIdentifier
+		this.Infof("%s %s record set %s[%s] for location %s: %s", req.Action, req.Type, name, this.zone.Id(), location, oldset.RecordString())
+		delete(geo.newItems, location)
+	}
+	this.done = append(this.done, req.Done)
+}
+
+// currentGeoItems returns the locations of the current resource record set with geolocation routing policy.
+func (this *Execution) currentGeoItems(name, rtype string) map[string]*dns.RecordSet {
+	items := map[string]*dns.RecordSet{}
+	for _, dnsset := range this.dnssets {
+		for ty := range dnsset.Sets {
+			n, rs := dns.MapToProvider(ty, dnsset, this.zone.Domain())
+			if dns.AlignHostname(n) == name && rs.Type == rtype && isGeoRoutingPolicy(rs.RoutingPolicy) {
+				items[rs.RoutingPolicy.SetIdentifier] = rs
+			}
+		}
+	}
+	return items
+}
+
+func (this *Execution) addGeoChanges() {
+	for _, key := range this.geoKeys {
+		geo := this.geo[key]
+		if old := mapGeoRecordSet(geo.name, geo.oldItems); old != nil {
+			this.change.Deletions = append(this.change.Deletions, old)
+		}
+		if new := mapGeoRecordSet(geo.name, geo.newItems); new != nil {
+			this.change.Additions = append(this.change.Additions, new)
+		}
+	}
+}
+
 func (this *Execution) submitChanges(metrics provider.Metrics) error {
+	this.addGeoChanges()
 	if len(this.change.Additions) == 0 && len(this.change.Deletions) == 0 {
 		return nil
 	}
@@ -97,7 +164,7 @@ func (this *Execution) submitChanges(metrics provider.Metrics) error {
 	metrics.AddZoneRequests(this.zone.Id().ID, provider.M_UPDATERECORDS, 1)
 	this.handler.config.RateLimiter.Accept()
 	projectID, zoneName := SplitZoneID(this.zone.Id().ID)
-	if _, err := this.handler.service.Changes.Create(projectID, zoneName, this.change).Do(); err != nil {
+	if _, err := this.handler.service.Changes.Create(projectID, zoneName, this.change).Context(this.ctx).Do(); err != nil {
 		this.Error(err)
 		for _, d := range this.done {
 			if d != nil {
@@ -30,7 +30,8 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetProviderConfigSchemaByExample(&GoogleConfig{})
 
 func init() {
 	compound.MustRegister(Factory)
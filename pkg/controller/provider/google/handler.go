@@ -18,9 +18,13 @@ package google
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
+	"sync"
 
 	"k8s.io/client-go/util/flowcontrol"
 
@@ -32,8 +36,12 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 
+	"google.golang.org/api/cloudresourcemanager/v1"
 	googledns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/googleapi"
 )
 
 type Handler struct {
@@ -41,13 +49,31 @@ type Handler struct {
 	config      provider.DNSHandlerConfig
 	cache       provider.ZoneCache
 	credentials *google.Credentials
+	tokens      *rotatingTokenSource
 	client      *http.Client
+	scopes      []string
 	ctx         context.Context
 	service     *googledns.Service
+	projects    *cloudresourcemanager.ProjectsService
 	rateLimiter flowcontrol.RateLimiter
+	resolver    *targetResourceResolver
+	cancel      context.CancelFunc
+
+	queryMetrics *queryMetrics
+	// notificationEmail is the service account email used to filter own changes of the change notifications
+	notificationEmail string
+}
+
+type GoogleConfig struct {
+	// ChangeNotifications optionally configures the consumption of Cloud DNS change notifications
+	ChangeNotifications *ChangeNotificationsConfig `json:"changeNotifications,omitempty"`
+	// QueryMetrics optionally configures the ingestion of query metrics from the Cloud DNS query logs
+	QueryMetrics *QueryMetricsConfig `json:"queryMetrics,omitempty"`
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.RoutingPolicySupport = &Handler{}
+var _ provider.CredentialsUpdateSupport = &Handler{}
 
 func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	var err error
@@ -59,50 +85,129 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 	}
 	scopes := []string{
 		//	"https://www.googleapis.com/auth/compute",
+		"https://www.googleapis.com/auth/compute.readonly",
 		//	"https://www.googleapis.com/auth/cloud-platform",
 		"https://www.googleapis.com/auth/ndev.clouddns.readwrite",
+		// needed for testing the IAM permissions of the write permission check
+		"https://www.googleapis.com/auth/cloud-platform.read-only",
 		//	"https://www.googleapis.com/auth/devstorage.full_control",
 	}
 
-	json := h.config.Properties["serviceaccount.json"]
-	if json == "" {
-		return nil, fmt.Errorf("'serviceaccount.json' required in secret")
+	googleConfig := GoogleConfig{}
+	if config.Config != nil {
+		if err := json.Unmarshal(config.Config.Raw, &googleConfig); err != nil {
+			return nil, fmt.Errorf("unmarshal google-clouddns providerConfig failed with: %s", err)
+		}
+	}
+	if googleConfig.ChangeNotifications != nil {
+		scopes = append(scopes, "https://www.googleapis.com/auth/pubsub")
+	}
+	if googleConfig.QueryMetrics != nil {
+		scopes = append(scopes, "https://www.googleapis.com/auth/logging.read")
 	}
 
 	//c:=*http.DefaultClient
 	//h.ctx=context.WithValue(config.Context,oauth2.HTTPClient,&c)
 	h.ctx = config.Context
 
-	h.credentials, err = google.CredentialsFromJSON(h.ctx, []byte(json), scopes...)
-	//cfg, err:=google.JWTConfigFromJSON([]byte(json))
+	var serviceAccountEmail string
+	h.credentials, serviceAccountEmail, err = readCredentials(h.ctx, config, scopes)
 	if err != nil {
-		return nil, fmt.Errorf("serviceaccount is invalid: %s", err)
+		return nil, err
 	}
-	h.client = oauth2.NewClient(h.ctx, h.credentials.TokenSource)
+	// the transport asks the rotating token source for every request, so that rotated credentials are used immediately
+	h.tokens = &rotatingTokenSource{source: h.credentials.TokenSource}
+	h.client = &http.Client{Transport: &oauth2.Transport{Source: h.tokens}}
+	h.scopes = scopes
 	//h.client=cfg.Client(ctx)
 
 	h.service, err = googledns.New(h.client)
 	if err != nil {
 		return nil, err
 	}
+	resourceManager, err := cloudresourcemanager.New(h.client)
+	if err != nil {
+		return nil, err
+	}
+	h.projects = resourceManager.Projects
+	h.resolver = newTargetResourceResolver(h.ctx, config.Logger, h.client, h.credentials.ProjectID)
 
-	h.cache, err = config.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, config.Metrics, h.getZones, h.getZoneState)
+	h.cache, err = config.ZoneCacheFactory.CreateIncrementalZoneCache(config.Metrics, h.getZones, h.getZoneState, &incrementalSync{h: h})
 	if err != nil {
 		return nil, err
 	}
 
+	if googleConfig.ChangeNotifications != nil {
+		receiver, err := newPubSubNotificationReceiver(h.client, googleConfig.ChangeNotifications)
+		if err != nil {
+			return nil, err
+		}
+		var ctx context.Context
+		ctx, h.cancel = context.WithCancel(h.ctx)
+		h.notificationEmail = serviceAccountEmail
+		provider.StartZoneChangeNotificationConsumer(ctx, config.Logger, h.ProviderType(), h.cache, receiver, serviceAccountEmail)
+	}
+
+	if googleConfig.QueryMetrics != nil {
+		h.queryMetrics, err = newQueryMetrics(h.client, googleConfig.QueryMetrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return h, nil
 }
 
+// UpdateCredentials swaps the token source of rotated credentials, the clients are kept.
+// A changed project or a changed service account used to filter own changes of the change
+// notifications require a new handler.
+func (h *Handler) UpdateCredentials(c *provider.DNSHandlerConfig) error {
+	credentials, email, err := readCredentials(h.ctx, c, h.scopes)
+	if err != nil {
+		return err
+	}
+	if credentials.ProjectID != h.credentials.ProjectID {
+		return fmt.Errorf("project modified")
+	}
+	if h.cancel != nil && email != h.notificationEmail {
+		return fmt.Errorf("service account of change notifications modified")
+	}
+	h.tokens.rotate(credentials.TokenSource)
+	c.Logger.Infof("rotated google-clouddns credentials (service account %s)", email)
+	return nil
+}
+
+// rotatingTokenSource delegates to a token source, which can be replaced.
+type rotatingTokenSource struct {
+	lock   sync.Mutex
+	source oauth2.TokenSource
+}
+
+func (s *rotatingTokenSource) Token() (*oauth2.Token, error) {
+	s.lock.Lock()
+	source := s.source
+	s.lock.Unlock()
+	return source.Token()
+}
+
+func (s *rotatingTokenSource) rotate(source oauth2.TokenSource) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.source = source
+}
+
 func (h *Handler) Release() {
+	if h.cancel != nil {
+		h.cancel()
+	}
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 
 	rt := provider.M_LISTZONES
@@ -122,7 +227,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	}
 
 	h.config.RateLimiter.Accept()
-	if err := h.service.ManagedZones.List(h.credentials.ProjectID).Pages(h.ctx, f); err != nil {
+	if err := h.service.ManagedZones.List(h.credentials.ProjectID).Pages(ctx, f); err != nil {
 		return nil, err
 	}
 
@@ -132,7 +237,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, dns.NormalizeHostname(z.DnsName), "", []string{}, false)
 
 		// call GetZoneState for side effect to calculate forwarded domains
-		_, err := cache.GetZoneState(hostedZone)
+		_, err := cache.GetZoneState(ctx, hostedZone)
 		if err == nil {
 			forwarded := cache.ForwardedDomainsCache().Get(hostedZone.Id())
 			if forwarded != nil {
@@ -146,7 +251,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(r *googledns.ResourceRecordSet)) ([]string, error) {
+func (h *Handler) handleRecordSets(ctx context.Context, zone provider.DNSHostedZone, f func(r *googledns.ResourceRecordSet)) ([]string, error) {
 	rt := provider.M_LISTRECORDS
 	forwarded := []string{}
 	aggr := func(resp *googledns.ResourceRecordSetsListResponse) error {
@@ -165,28 +270,26 @@ func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(r *google
 	}
 	h.config.RateLimiter.Accept()
 	projectID, zoneName := SplitZoneID(zone.Id().ID)
-	err := h.service.ResourceRecordSets.List(projectID, zoneName).Pages(h.ctx, aggr)
+	err := h.service.ResourceRecordSets.List(projectID, zoneName).Pages(ctx, aggr)
 	return forwarded, err
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
 	f := func(r *googledns.ResourceRecordSet) {
-		if dns.SupportedRecordType(r.Type) {
-			rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
-			for _, rr := range r.Rrdatas {
-				rs.Add(&dns.Record{Value: rr})
+		if h.SupportsRecordType(r.Type) {
+			for _, rs := range buildRecordSets(r) {
+				dnssets.AddRecordSetFromProvider(r.Name, rs)
 			}
-			dnssets.AddRecordSetFromProvider(r.Name, rs)
 		}
 	}
 
-	forwarded, err := h.handleRecordSets(zone, f)
+	forwarded, err := h.handleRecordSets(ctx, zone, f)
 	if err != nil {
 		return nil, err
 	}
@@ -199,14 +302,14 @@ func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	exec := NewExecution(logger, h, zone)
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := NewExecution(ctx, logger, h, zone, state)
 	for _, r := range reqs {
 		exec.addChange(r)
 	}
@@ -217,6 +320,57 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 	return exec.submitChanges(h.config.Metrics)
 }
 
+func (h *Handler) MapTarget(t provider.Target) provider.Target {
+	if t.GetRecordType() == dns.RS_RESOURCE {
+		addr, err := h.resolver.resolve(t.GetHostName())
+		if err != nil {
+			h.config.Logger.Warnf("cannot resolve target resource %s: %s", t.GetHostName(), err)
+			return t
+		}
+		if net.ParseIP(addr).To4() != nil {
+			return dnsutils.NewTarget(dns.RS_A, addr, t.GetTTL())
+		}
+		return dnsutils.NewTarget(dns.RS_AAAA, addr, t.GetTTL())
+	}
+	return t
+}
+
+func (h *Handler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return validateRoutingPolicy(policy)
+}
+
+func (h *Handler) SupportedRoutingPolicyTypes() []string {
+	return []string{dns.RP_GEOLOCATION}
+}
+
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	switch rtype {
+	case dns.RS_SRV, dns.RS_SVCB, dns.RS_HTTPS, dns.RS_CAA:
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+// ClassifyError maps the errors of the Google API to error reasons.
+// Rate limits and quotas are both reported with status code 403 and distinguished by the error reason.
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) {
+		return perrs.ReasonUnknown
+	}
+	for _, item := range apiErr.Errors {
+		switch item.Reason {
+		case "rateLimitExceeded", "userRateLimitExceeded":
+			return perrs.ReasonThrottled
+		case "quotaExceeded":
+			return perrs.ReasonQuotaExceeded
+		case "alreadyExists", "conflict":
+			return perrs.ReasonConflict
+		}
+	}
+	return perrs.ReasonForHTTPStatus(apiErr.Code)
+}
+
 func (h *Handler) makeZoneID(name string) string {
 	return fmt.Sprintf("%s/%s", h.credentials.ProjectID, name)
 }
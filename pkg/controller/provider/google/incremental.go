@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// incrementalSync synchronizes zone states with the Cloud DNS changes API.
+// The sync marker is the sequence number of the last change of the zone.
+type incrementalSync struct {
+	h *Handler
+}
+
+var _ provider.IncrementalZoneStateUpdater = &incrementalSync{}
+
+var errMarkerReached = fmt.Errorf("marker reached")
+
+func (s *incrementalSync) GetSyncMarker(ctx context.Context, zone provider.DNSHostedZone) (string, error) {
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	s.h.config.RateLimiter.Accept()
+	s.h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTCHANGES, 1)
+	resp, err := s.h.service.Changes.List(projectID, zoneName).SortBy("changeSequence").SortOrder("descending").MaxResults(1).Context(ctx).Do()
+	if err != nil {
+		return "", err
+	}
+	if len(resp.Changes) == 0 {
+		return "", fmt.Errorf("no changes found for zone %s", zone.Id())
+	}
+	return resp.Changes[0].Id, nil
+}
+
+func (s *incrementalSync) UpdateZoneState(ctx context.Context, zone provider.DNSHostedZone, state provider.DNSZoneState, marker string) (string, error) {
+	last, err := strconv.ParseInt(marker, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid sync marker %q", marker)
+	}
+
+	// changes are listed newest first until the last known change is reached
+	changes := []*googledns.Change{}
+	found := false
+	rt := provider.M_LISTCHANGES
+	f := func(resp *googledns.ChangesListResponse) error {
+		s.h.config.Metrics.AddZoneRequests(zone.Id().ID, rt, 1)
+		rt = provider.M_PLISTCHANGES
+		for _, c := range resp.Changes {
+			seq, err := strconv.ParseInt(c.Id, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid change id %q", c.Id)
+			}
+			if seq <= last {
+				found = true
+				return errMarkerReached
+			}
+			if c.Status != "done" {
+				return fmt.Errorf("change %s is still %s", c.Id, c.Status)
+			}
+			changes = append(changes, c)
+		}
+		return nil
+	}
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	s.h.config.RateLimiter.Accept()
+	err = s.h.service.Changes.List(projectID, zoneName).SortBy("changeSequence").SortOrder("descending").Pages(ctx, f)
+	if err != nil && err != errMarkerReached {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("change %s not found in change log", marker)
+	}
+	if len(changes) == 0 {
+		return marker, nil
+	}
+
+	dnssets := state.GetDNSSets()
+	for i := len(changes) - 1; i >= 0; i-- {
+		c := changes[i]
+		for _, r := range c.Deletions {
+			if err := s.checkRecordSet(zone, r); err != nil {
+				return "", err
+			}
+			if s.h.SupportsRecordType(r.Type) {
+				for _, rs := range buildRecordSets(r) {
+					name, rs := dns.MapFromProvider(dns.NormalizeHostname(r.Name), rs)
+					dnssets.RemoveRecordSet(dns.DNSSetKey(name, rs.RoutingPolicy.GetSetIdentifier()), rs.Type)
+				}
+			}
+		}
+		for _, r := range c.Additions {
+			if err := s.checkRecordSet(zone, r); err != nil {
+				return "", err
+			}
+			if s.h.SupportsRecordType(r.Type) {
+				for _, rs := range buildRecordSets(r) {
+					dnssets.AddRecordSetFromProvider(r.Name, rs)
+				}
+			}
+		}
+	}
+	return changes[0].Id, nil
+}
+
+// checkRecordSet rejects changes of delegations, as the forwarded domains are only calculated on full synchronization.
+func (s *incrementalSync) checkRecordSet(zone provider.DNSHostedZone, r *googledns.ResourceRecordSet) error {
+	if r.Type == dns.RS_NS && dns.NormalizeHostname(r.Name) != zone.Domain() {
+		return fmt.Errorf("changed delegation %s", r.Name)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package google
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	pubsub "google.golang.org/api/pubsub/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// ChangeNotificationsConfig configures the consumption of Cloud DNS change notifications.
+type ChangeNotificationsConfig struct {
+	// Subscription is the Pub/Sub subscription (projects/<project>/subscriptions/<name>) receiving
+	// the Cloud DNS audit log entries exported by a log sink.
+	Subscription string `json:"subscription"`
+}
+
+// auditLogEntry is the relevant part of a Cloud Audit Logs entry of Cloud DNS
+type auditLogEntry struct {
+	Resource struct {
+		Type   string            `json:"type"`
+		Labels map[string]string `json:"labels"`
+	} `json:"resource"`
+	ProtoPayload struct {
+		MethodName         string `json:"methodName"`
+		ResourceName       string `json:"resourceName"`
+		AuthenticationInfo struct {
+			PrincipalEmail string `json:"principalEmail"`
+		} `json:"authenticationInfo"`
+		Status *struct {
+			Code int `json:"code"`
+		} `json:"status"`
+	} `json:"protoPayload"`
+}
+
+type pubsubNotificationReceiver struct {
+	subscription string
+	service      *pubsub.Service
+}
+
+var _ provider.ZoneChangeNotificationReceiver = &pubsubNotificationReceiver{}
+
+func newPubSubNotificationReceiver(client *http.Client, cfg *ChangeNotificationsConfig) (*pubsubNotificationReceiver, error) {
+	if cfg.Subscription == "" {
+		return nil, fmt.Errorf("missing subscription for change notifications")
+	}
+	service, err := pubsub.New(client)
+	if err != nil {
+		return nil, err
+	}
+	return &pubsubNotificationReceiver{subscription: cfg.Subscription, service: service}, nil
+}
+
+func (r *pubsubNotificationReceiver) Receive(ctx context.Context) ([]provider.ZoneChangeNotification, func(), error) {
+	resp, err := r.service.Projects.Subscriptions.Pull(r.subscription, &pubsub.PullRequest{MaxMessages: 100}).Context(ctx).Do()
+	if err != nil {
+		return nil, nil, err
+	}
+	var notifications []provider.ZoneChangeNotification
+	var ackIDs []string
+	for _, msg := range resp.ReceivedMessages {
+		ackIDs = append(ackIDs, msg.AckId)
+		if msg.Message == nil {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(msg.Message.Data)
+		if err != nil {
+			continue
+		}
+		if n := parseAuditLogEntry(data); n != nil {
+			notifications = append(notifications, *n)
+		}
+	}
+	ack := func() {
+		if len(ackIDs) == 0 {
+			return
+		}
+		// unacknowledged messages are only delivered again, so errors can be ignored
+		_, _ = r.service.Projects.Subscriptions.Acknowledge(r.subscription, &pubsub.AcknowledgeRequest{AckIds: ackIDs}).Context(ctx).Do()
+	}
+	return notifications, ack, nil
+}
+
+func parseAuditLogEntry(data []byte) *provider.ZoneChangeNotification {
+	entry := &auditLogEntry{}
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil
+	}
+	if entry.ProtoPayload.Status != nil && entry.ProtoPayload.Status.Code != 0 {
+		return nil
+	}
+	switch entry.ProtoPayload.MethodName {
+	case "dns.changes.create", "dns.managedZones.delete",
+		"dns.resourceRecordSets.create", "dns.resourceRecordSets.patch", "dns.resourceRecordSets.delete":
+	default:
+		return nil
+	}
+	project := entry.Resource.Labels["project_id"]
+	zone := entry.Resource.Labels["zone_name"]
+	if project == "" || zone == "" {
+		// fallback: projects/<project>/managedZones/<zone>[/...]
+		parts := strings.Split(entry.ProtoPayload.ResourceName, "/")
+		if len(parts) < 4 || parts[0] != "projects" || parts[2] != "managedZones" {
+			return nil
+		}
+		project, zone = parts[1], parts[3]
+	}
+	return &provider.ZoneChangeNotification{
+		ZoneID:    fmt.Sprintf("%s/%s", project, zone),
+		Principal: entry.ProtoPayload.AuthenticationInfo.PrincipalEmail,
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	logging "google.golang.org/api/logging/v2"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// QueryMetricsConfig configures the ingestion of DNS query metrics from the Cloud DNS query logs.
+type QueryMetricsConfig struct {
+	// MaxEntries limits the number of log entries evaluated per zone and period (default 100000).
+	MaxEntries int `json:"maxEntries,omitempty"`
+}
+
+const defaultQueryMetricsMaxEntries = 100000
+
+var errQueryMetricsLimit = errors.New("query metrics limit reached")
+
+// queryLogPayload is the relevant part of the payload of a Cloud DNS query log entry
+type queryLogPayload struct {
+	QueryName string `json:"queryName"`
+}
+
+type queryMetrics struct {
+	maxEntries int
+	service    *logging.Service
+}
+
+func newQueryMetrics(client *http.Client, cfg *QueryMetricsConfig) (*queryMetrics, error) {
+	service, err := logging.New(client)
+	if err != nil {
+		return nil, err
+	}
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = defaultQueryMetricsMaxEntries
+	}
+	return &queryMetrics{maxEntries: maxEntries, service: service}, nil
+}
+
+var _ provider.QueryMetricsAccess = &Handler{}
+
+func (h *Handler) GetQueryCounts(ctx context.Context, zone provider.DNSHostedZone, start, end time.Time) (map[string]int64, error) {
+	if h.queryMetrics == nil {
+		return nil, nil
+	}
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	req := &logging.ListLogEntriesRequest{
+		ResourceNames: []string{"projects/" + projectID},
+		Filter: fmt.Sprintf(`resource.type="dns_query" AND resource.labels.target_name=%q AND timestamp>=%q AND timestamp<%q`,
+			zoneName, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)),
+		PageSize: 1000,
+	}
+
+	counts := map[string]int64{}
+	evaluated := 0
+	f := func(resp *logging.ListLogEntriesResponse) error {
+		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_QUERYMETRICS, 1)
+		for _, e := range resp.Entries {
+			payload := queryLogPayload{}
+			if err := json.Unmarshal(e.JsonPayload, &payload); err != nil || payload.QueryName == "" {
+				continue
+			}
+			counts[strings.ToLower(strings.TrimSuffix(payload.QueryName, "."))]++
+		}
+		evaluated += len(resp.Entries)
+		if evaluated >= h.queryMetrics.maxEntries {
+			return errQueryMetricsLimit
+		}
+		h.config.RateLimiter.Accept()
+		return nil
+	}
+
+	h.config.RateLimiter.Accept()
+	if err := h.queryMetrics.service.Entries.List(req).Pages(ctx, f); err != nil {
+		if err != errQueryMetricsLimit {
+			return nil, err
+		}
+		h.config.Logger.Infof("query metrics of zone %s limited to %d log entries", zone.Id(), evaluated)
+	}
+	return counts, nil
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package google
+
+import (
+	"fmt"
+	"sort"
+
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// locationParameter is the routing policy parameter for the Google Cloud region of a geolocation record set
+const locationParameter = "location"
+
+func validateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	switch policy.Type {
+	case dns.RP_GEOLOCATION:
+		for k := range policy.Parameters {
+			if k != locationParameter {
+				return fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s)", k, policy.Type, locationParameter)
+			}
+		}
+		location := policy.Parameters[locationParameter]
+		if location == "" {
+			return fmt.Errorf("missing parameter %q for routing policy %s", locationParameter, policy.Type)
+		}
+		// Cloud DNS identifies the items of a geolocation policy by their location only
+		if policy.SetIdentifier != location {
+			return fmt.Errorf("set identifier %q must match location %q for routing policy %s", policy.SetIdentifier, location, policy.Type)
+		}
+		return nil
+	default:
+		return fmt.Errorf("routing policy %s not supported by provider type %s", policy.Type, TYPE_CODE)
+	}
+}
+
+func isGeoRoutingPolicy(policy *dns.RoutingPolicy) bool {
+	return policy != nil && policy.Type == dns.RP_GEOLOCATION
+}
+
+// buildRecordSets builds the record sets for a resource record set.
+// A resource record set with geolocation routing policy results in
+// a separate record set for every location identified by the location.
+func buildRecordSets(r *googledns.ResourceRecordSet) []*dns.RecordSet {
+	if r.RoutingPolicy == nil || r.RoutingPolicy.Geo == nil {
+		rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
+		for _, rr := range r.Rrdatas {
+			rs.Add(&dns.Record{Value: rr})
+		}
+		return []*dns.RecordSet{rs}
+	}
+	sets := []*dns.RecordSet{}
+	for _, item := range r.RoutingPolicy.Geo.Items {
+		rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
+		for _, rr := range item.Rrdatas {
+			rs.Add(&dns.Record{Value: rr})
+		}
+		rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_GEOLOCATION, map[string]string{locationParameter: item.Location})
+		rs.RoutingPolicy.SetIdentifier = item.Location
+		sets = append(sets, rs)
+	}
+	return sets
+}
+
+// geoRecordSet collects the items of a resource record set with geolocation routing policy.
+// Cloud DNS maintains all locations of a DNS name and type in a single resource record set.
+// Therefore, the requests for the single locations are merged into a replacement of the
+// complete resource record set.
+type geoRecordSet struct {
+	name     string
+	rtype    string
+	oldItems map[string]*dns.RecordSet
+	newItems map[string]*dns.RecordSet
+}
+
+func newGeoRecordSet(name, rtype string, current map[string]*dns.RecordSet) *geoRecordSet {
+	newItems := map[string]*dns.RecordSet{}
+	for k, v := range current {
+		newItems[k] = v
+	}
+	return &geoRecordSet{name: name, rtype: rtype, oldItems: current, newItems: newItems}
+}
+
+func mapGeoRecordSet(dnsname string, items map[string]*dns.RecordSet) *googledns.ResourceRecordSet {
+	if len(items) == 0 {
+		return nil
+	}
+	locations := make([]string, 0, len(items))
+	for location := range items {
+		locations = append(locations, location)
+	}
+	sort.Strings(locations)
+
+	var result *googledns.ResourceRecordSet
+	geo := &googledns.RRSetRoutingPolicyGeoPolicy{}
+	for _, location := range locations {
+		r := mapRecordSet(dnsname, items[location])
+		if result == nil {
+			// Cloud DNS supports only a single TTL for all locations
+			result = r
+			result.Rrdatas = nil
+		}
+		geo.Items = append(geo.Items, &googledns.RRSetRoutingPolicyGeoPolicyGeoPolicyItem{
+			Location: location,
+			Rrdatas:  r.Rrdatas,
+		})
+	}
+	result.RoutingPolicy = &googledns.RRSetRoutingPolicy{Geo: geo}
+	return result
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const (
+	// targetResourceLookupTTL is the time-to-live of the looked up addresses of target resources
+	targetResourceLookupTTL = 2 * time.Minute
+	// targetResourceLookupTimeout is the timeout for getting a single target resource
+	targetResourceLookupTimeout = 30 * time.Second
+
+	computeBaseURL = "https://compute.googleapis.com/compute/v1/"
+)
+
+// targetResourcePattern matches forwarding rules and addresses, either regional or global,
+// optionally qualified by project or given as full self link.
+var targetResourcePattern = regexp.MustCompile(`^(?:projects/([^/]+)/)?((?:regions/[^/]+|global)/(?:forwardingRules|addresses)/[^/]+)$`)
+
+type computeResource struct {
+	IPAddress string `json:"IPAddress"`
+	Address   string `json:"address"`
+}
+
+// targetResourceResolver determines the IP addresses of forwarding rules and addresses
+// referenced by the target resource annotation of DNS entries.
+type targetResourceResolver struct {
+	ctx     context.Context
+	logger  logger.LogContext
+	client  *http.Client
+	project string
+	lookups *dnsutils.TTLCache
+
+	lock sync.Mutex
+	// last known addresses, used if a lookup fails temporarily
+	last map[string]string
+}
+
+func newTargetResourceResolver(ctx context.Context, logger logger.LogContext, client *http.Client, project string) *targetResourceResolver {
+	return &targetResourceResolver{
+		ctx:     ctx,
+		logger:  logger,
+		client:  client,
+		project: project,
+		lookups: dnsutils.NewTTLCache(targetResourceLookupTTL),
+		last:    map[string]string{},
+	}
+}
+
+// parseTargetResource returns the compute API path for a resource reference
+func (this *targetResourceResolver) parseTargetResource(ref string) (string, error) {
+	ref = strings.TrimPrefix(strings.TrimPrefix(ref, "https://www.googleapis.com/compute/v1/"), computeBaseURL)
+	m := targetResourcePattern.FindStringSubmatch(strings.Trim(ref, "/"))
+	if m == nil {
+		return "", fmt.Errorf("invalid target resource %q: expected [projects/<project>/](regions/<region>|global)/(forwardingRules|addresses)/<name>", ref)
+	}
+	project := m[1]
+	if project == "" {
+		project = this.project
+	}
+	return fmt.Sprintf("projects/%s/%s", project, m[2]), nil
+}
+
+// resolve returns the IP address of a referenced forwarding rule or address
+func (this *targetResourceResolver) resolve(ref string) (string, error) {
+	path, err := this.parseTargetResource(ref)
+	if err != nil {
+		return "", err
+	}
+	v, err := this.lookups.GetOrCompute(path, func() (interface{}, error) {
+		return this.lookup(path)
+	})
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if err != nil {
+		if addr := this.last[path]; addr != "" {
+			this.logger.Warnf("lookup of target resource %s failed -> keeping address %s: %s", path, addr, err)
+			return addr, nil
+		}
+		return "", err
+	}
+	addr := v.(string)
+	if old := this.last[path]; old != "" && old != addr {
+		this.logger.Infof("address of target resource %s changed from %s to %s", path, old, addr)
+	}
+	this.last[path] = addr
+	return addr, nil
+}
+
+func (this *targetResourceResolver) lookup(path string) (string, error) {
+	parent := this.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(parent, targetResourceLookupTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, computeBaseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := this.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("getting %s failed with status %d: %s", path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	r := computeResource{}
+	if err := json.Unmarshal(body, &r); err != nil {
+		return "", fmt.Errorf("cannot decode %s: %w", path, err)
+	}
+	addr := r.IPAddress
+	if addr == "" {
+		addr = r.Address
+	}
+	if net.ParseIP(addr) == nil {
+		return "", fmt.Errorf("target resource %s has no valid IP address: %q", path, addr)
+	}
+	return addr, nil
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// recordSetPermissions are the IAM permissions needed for changing the record sets of a managed zone
+var recordSetPermissions = []string{
+	"dns.changes.create",
+	"dns.resourceRecordSets.create",
+	"dns.resourceRecordSets.update",
+	"dns.resourceRecordSets.delete",
+}
+
+var _ provider.WritePermissionProbeSupport = &Handler{}
+
+// ProbeWritePermission tests the IAM permissions of the caller for changing the record sets
+// in the project of the zone.
+func (h *Handler) ProbeWritePermission(ctx context.Context, zone provider.DNSHostedZone) error {
+	projectID, _ := SplitZoneID(zone.Id().ID)
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_WRITEPERMISSION, 1)
+	h.config.RateLimiter.Accept()
+	request := &cloudresourcemanager.TestIamPermissionsRequest{Permissions: recordSetPermissions}
+	response, err := h.projects.TestIamPermissions(projectID, request).Context(ctx).Do()
+	if err != nil {
+		return perrs.NewWritePermissionUnverifiable(fmt.Errorf("cannot test IAM permissions in project %s: %w", projectID, err))
+	}
+	if missing := missingPermissions(recordSetPermissions, response.Permissions); len(missing) > 0 {
+		return fmt.Errorf("missing permissions %s in project %s", strings.Join(missing, ", "), projectID)
+	}
+	return nil
+}
+
+// missingPermissions returns the required permissions not contained in the granted permissions.
+func missingPermissions(required, granted []string) []string {
+	set := map[string]struct{}{}
+	for _, p := range granted {
+		set[p] = struct{}{}
+	}
+	var missing []string
+	for _, p := range required {
+		if _, ok := set[p]; !ok {
+			missing = append(missing, p)
+		}
+	}
+	return missing
+}
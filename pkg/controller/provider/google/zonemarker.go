@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package google
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	googledns "google.golang.org/api/dns/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	// zoneManagedByLabel is the managed zone label identifying the managing controller
+	zoneManagedByLabel = "gardener-dns-managed-by"
+	// zoneOwnerLabel is the managed zone label identifying the owner id of the managing controller
+	zoneOwnerLabel = "gardener-dns-owner"
+)
+
+var invalidLabelChars = regexp.MustCompile("[^a-z0-9_-]")
+
+// labelValue converts a value to a valid label value (lower case letters, digits, '_' and '-', at most 63 characters).
+func labelValue(value string) string {
+	value = invalidLabelChars.ReplaceAllString(strings.ToLower(value), "_")
+	if len(value) > 63 {
+		value = value[:63]
+	}
+	return value
+}
+
+var _ provider.ZoneOwnershipAccess = &Handler{}
+
+func (h *Handler) MarkZoneOwnership(ctx context.Context, zone provider.DNSHostedZone, marker provider.ZoneOwnershipMarker) (bool, error) {
+	projectID, zoneName := SplitZoneID(zone.Id().ID)
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_ZONEMETADATA, 1)
+	h.config.RateLimiter.Accept()
+	mz, err := h.service.ManagedZones.Get(projectID, zoneName).Context(ctx).Do()
+	if err != nil {
+		return false, err
+	}
+	expected := map[string]string{
+		zoneManagedByLabel: labelValue(marker.ManagedBy),
+		zoneOwnerLabel:     labelValue(marker.Owner),
+	}
+	if owner := mz.Labels[zoneOwnerLabel]; owner != "" && owner != expected[zoneOwnerLabel] {
+		return false, &provider.ForeignZoneOwnershipError{Marker: provider.ZoneOwnershipMarker{ManagedBy: mz.Labels[zoneManagedByLabel], Owner: owner}}
+	}
+	labels := map[string]string{}
+	mod := false
+	for key, value := range mz.Labels {
+		labels[key] = value
+	}
+	for key, value := range expected {
+		if labels[key] != value {
+			labels[key] = value
+			mod = true
+		}
+	}
+	if !mod {
+		return false, nil
+	}
+	h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_ZONEMETADATA, 1)
+	h.config.RateLimiter.Accept()
+	patch := &googledns.ManagedZone{Labels: labels}
+	if _, err := h.service.ManagedZones.Patch(projectID, zoneName, patch).Context(ctx).Do(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
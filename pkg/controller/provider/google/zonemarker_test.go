@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package google
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	googledns "google.golang.org/api/dns/v1"
+	"google.golang.org/api/option"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// testManagedZones serves GET and PATCH requests for the labels of managed zones.
+type testManagedZones struct {
+	lock    sync.Mutex
+	labels  map[string]map[string]string
+	patches int
+}
+
+func (s *testManagedZones) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// path: /dns/v1/projects/{project}/managedZones/{zone}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/dns/v1/projects/"), "/")
+	if len(parts) != 3 || parts[1] != "managedZones" {
+		http.NotFound(w, r)
+		return
+	}
+	id := parts[0] + "/" + parts[2]
+	labels, ok := s.labels[id]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		_ = json.NewEncoder(w).Encode(&googledns.ManagedZone{Name: parts[2], Labels: labels})
+	case http.MethodPatch:
+		var patch googledns.ManagedZone
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		s.labels[id] = patch.Labels
+		s.patches++
+		_ = json.NewEncoder(w).Encode(&googledns.Operation{Id: "op", Status: "done"})
+	default:
+		http.Error(w, "unsupported", http.StatusMethodNotAllowed)
+	}
+}
+
+func newZoneMarkerTestHandler(t *testing.T, labels map[string]string) (*testManagedZones, *Handler) {
+	s := &testManagedZones{labels: map[string]map[string]string{"my-project/my-zone": labels}}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	service, err := googledns.NewService(context.TODO(), option.WithEndpoint(server.URL+"/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s, &Handler{
+		config:  provider.DNSHandlerConfig{Metrics: &provider.NullMetrics{}, RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter()},
+		service: service,
+	}
+}
+
+var (
+	testZone   = provider.NewDNSHostedZone(TYPE_CODE, "my-project/my-zone", "example.com", "", nil, false)
+	testMarker = provider.ZoneOwnershipMarker{ManagedBy: provider.ZONE_MARKER_MANAGED_BY, Owner: "My.Owner"}
+)
+
+func TestLabelValue(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(labelValue("My.Owner")).To(Equal("my_owner"))
+	Expect(labelValue("dns-controller-manager")).To(Equal("dns-controller-manager"))
+	Expect(labelValue(strings.Repeat("a", 70))).To(HaveLen(63))
+}
+
+func TestMarkZoneOwnership(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, h := newZoneMarkerTestHandler(t, map[string]string{"team": "dns"})
+
+	mod, err := h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mod).To(BeTrue())
+	Expect(s.labels["my-project/my-zone"]).To(Equal(map[string]string{
+		"team":             "dns",
+		zoneManagedByLabel: "dns-controller-manager",
+		zoneOwnerLabel:     "my_owner",
+	}))
+
+	mod, err = h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(mod).To(BeFalse())
+	Expect(s.patches).To(Equal(1))
+}
+
+func TestMarkZoneOwnershipKeepsForeignOwner(t *testing.T) {
+	RegisterTestingT(t)
+
+	s, h := newZoneMarkerTestHandler(t, map[string]string{zoneManagedByLabel: "dns-controller-manager", zoneOwnerLabel: "other"})
+
+	mod, err := h.MarkZoneOwnership(context.TODO(), testZone, testMarker)
+	Expect(mod).To(BeFalse())
+	var foreignErr *provider.ForeignZoneOwnershipError
+	Expect(errors.As(err, &foreignErr)).To(BeTrue())
+	Expect(foreignErr.Marker).To(Equal(provider.ZoneOwnershipMarker{ManagedBy: "dns-controller-manager", Owner: "other"}))
+	Expect(s.labels["my-project/my-zone"][zoneOwnerLabel]).To(Equal("other"))
+	Expect(s.patches).To(Equal(0))
+}
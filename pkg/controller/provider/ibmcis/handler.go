@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ibmcis
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cfapi "github.com/cloudflare/cloudflare-go"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	defaultEndpoint    = "https://api.cis.cloud.ibm.com/v1/"
+	defaultIAMEndpoint = "https://iam.cloud.ibm.com/identity/token"
+)
+
+// NewHandler creates a handler for the DNS zones of an IBM Cloud Internet Services instance.
+// The CIS API is compatible with the Cloudflare API, but the requests are scoped to the instance
+// given by its CRN and authenticated with an IBM Cloud IAM access token.
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	api, err := newAPI(c)
+	if err != nil {
+		return nil, err
+	}
+	return cloudflare.NewHandlerForAPI(TYPE_CODE, c, api)
+}
+
+// newAPI creates a Cloudflare API client for the CIS instance of the provider.
+func newAPI(c *provider.DNSHandlerConfig, opts ...cfapi.Option) (*cfapi.API, error) {
+	apiKey, err := c.GetRequiredProperty("IBMCLOUD_API_KEY", "apiKey")
+	if err != nil {
+		return nil, err
+	}
+	crn, err := c.GetRequiredProperty("CIS_CRN", "crn")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := c.GetProperty("CIS_ENDPOINT", "endpoint")
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	iamEndpoint := c.GetProperty("IBMCLOUD_IAM_ENDPOINT", "iamEndpoint")
+	if iamEndpoint == "" {
+		iamEndpoint = defaultIAMEndpoint
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	tokens := newTokenSource(iamEndpoint, apiKey, httpClient)
+	client := &http.Client{
+		Timeout:   httpClient.Timeout,
+		Transport: &transport{base: http.DefaultTransport, tokens: tokens},
+	}
+	// the API token is not used, the IAM access token is set by the transport.
+	// Failed requests are not retried by the client, but by the reconciliation of the entries.
+	opts = append([]cfapi.Option{cfapi.HTTPClient(client), cfapi.UserAgent("external-dns-manager"), cfapi.UsingRetryPolicy(0, 1, 1)}, opts...)
+	api, err := cfapi.NewWithAPIToken("iam", opts...)
+	if err != nil {
+		return nil, err
+	}
+	api.SetAuthType(0)
+	api.BaseURL = strings.TrimSuffix(endpoint, "/") + "/" + url.QueryEscape(crn)
+	return api, nil
+}
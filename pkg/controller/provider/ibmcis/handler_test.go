@@ -0,0 +1,261 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ibmcis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	cfapi "github.com/cloudflare/cloudflare-go"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const testCRN = "crn:v1:bluemix:public:internet-svcs:global:a/1234:5678::"
+
+type testRecord struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+	TTL     int    `json:"ttl"`
+	Proxied bool   `json:"proxied"`
+	ZoneID  string `json:"zone_id"`
+}
+
+type testServer struct {
+	lock      sync.Mutex
+	tokens    int
+	lifetime  time.Duration
+	zones     []map[string]string
+	records   map[string][]*testRecord
+	created   []*testRecord
+	throttled bool
+	requests  []string
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if r.URL.Path == "/identity/token" {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "urn:ibm:params:oauth:grant-type:apikey" || r.Form.Get("apikey") != "key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":"BXNIM0415E","errorMessage":"Provided API key could not be found."}`))
+			return
+		}
+		s.tokens++
+		writeJSON(w, &tokenResponse{AccessToken: "token" + strconv.Itoa(s.tokens), Expiration: time.Now().Add(s.lifetime).Unix()})
+		return
+	}
+
+	prefix := "/v1/" + url.QueryEscape(testCRN)
+	path := strings.TrimPrefix(r.URL.EscapedPath(), prefix)
+	s.requests = append(s.requests, r.Method+" "+path+"?"+r.URL.RawQuery)
+	if !strings.HasPrefix(r.URL.EscapedPath(), prefix+"/") {
+		writeError(w, http.StatusNotFound, 7003, "Could not route to instance")
+		return
+	}
+	if r.Header.Get("X-Auth-User-Token") != "Bearer token"+strconv.Itoa(s.tokens) {
+		writeError(w, http.StatusUnauthorized, 10000, "Authentication error")
+		return
+	}
+	if s.throttled {
+		w.Header().Set("Retry-After", "2")
+		writeError(w, http.StatusTooManyRequests, 971, "Please wait and consider throttling your request speed")
+		return
+	}
+	no, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if no == 0 {
+		no = 1
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case r.Method == http.MethodGet && path == "/zones":
+		writePage(w, s.zones, len(s.zones), no, 50)
+	case r.Method == http.MethodGet && len(parts) == 3 && parts[2] == "dns_records":
+		records := s.records[parts[1]]
+		writePage(w, records, len(records), no, 100)
+	case r.Method == http.MethodPost && len(parts) == 3 && parts[2] == "dns_records":
+		record := &testRecord{}
+		if err := json.NewDecoder(r.Body).Decode(record); err != nil {
+			writeError(w, http.StatusBadRequest, 1004, "invalid record")
+			return
+		}
+		record.ID = "new"
+		s.created = append(s.created, record)
+		writeJSON(w, map[string]interface{}{"success": true, "errors": []interface{}{}, "result": record})
+	default:
+		writeError(w, http.StatusNotFound, 7003, "Could not route")
+	}
+}
+
+// writePage writes a page of a list in the envelope of the Cloudflare API.
+func writePage(w http.ResponseWriter, list interface{}, length, no, size int) {
+	data, _ := json.Marshal(list)
+	var elems []json.RawMessage
+	_ = json.Unmarshal(data, &elems)
+	start := (no - 1) * size
+	if start > length {
+		start = length
+	}
+	end := start + size
+	if end > length {
+		end = length
+	}
+	writeJSON(w, map[string]interface{}{
+		"success": true,
+		"errors":  []interface{}{},
+		"result":  elems[start:end],
+		"result_info": map[string]int{
+			"page": no, "per_page": size, "count": end - start, "total_count": length, "total_pages": (length + size - 1) / size,
+		},
+	})
+}
+
+func writeError(w http.ResponseWriter, status, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(fmt.Sprintf(`{"success":false,"errors":[{"code":%d,"message":%q}],"result":null}`, code, msg)))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestHandler(t *testing.T, s *testServer, apiKey string) provider.DNSHandler {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	config := &provider.DNSHandlerConfig{
+		Logger: logger.New(),
+		Properties: utils.Properties{
+			"apiKey":      apiKey,
+			"crn":         testCRN,
+			"endpoint":    server.URL + "/v1/",
+			"iamEndpoint": server.URL + "/identity/token",
+		},
+		Metrics:          &provider.NullMetrics{},
+		RateLimiter:      flowcontrol.NewFakeAlwaysRateLimiter(),
+		ZoneCacheFactory: *provider.NewTestZoneCacheFactory(0, 0),
+		Options:          &provider.FactoryOptions{},
+	}
+	// the client side rate limit of the Cloudflare client would slow down the tests
+	api, err := newAPI(config, cfapi.UsingRateLimit(1000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := cloudflare.NewHandlerForAPI(TYPE_CODE, config, api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(h.Release)
+	return h
+}
+
+func TestZonesWithPagination(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{lifetime: time.Hour, records: map[string][]*testRecord{}}
+	for i := 0; i < 55; i++ {
+		s.zones = append(s.zones, map[string]string{"id": fmt.Sprintf("z%d", i), "name": fmt.Sprintf("sub%d.example.com", i)})
+	}
+	h := newTestHandler(t, s, "key")
+
+	zones, err := h.GetZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(zones).To(HaveLen(55))
+	ids := map[string]bool{}
+	for _, z := range zones {
+		ids[z.Id().ID] = true
+	}
+	Expect(ids).To(HaveKey("z54"))
+	Expect(s.requests[:2]).To(Equal([]string{"GET /zones?page=1&per_page=50", "GET /zones?page=2&per_page=50"}))
+	// the IAM access token is cached
+	Expect(s.tokens).To(Equal(1))
+}
+
+func TestZoneStateMapping(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{lifetime: time.Minute, records: map[string][]*testRecord{}}
+	for i := 0; i < 120; i++ {
+		s.records["z1"] = append(s.records["z1"], &testRecord{ID: strconv.Itoa(i), Type: "A", Name: fmt.Sprintf("r%d.example.com", i), Content: "1.2.3.4", TTL: 300, ZoneID: "z1"})
+	}
+	s.records["z1"] = append(s.records["z1"],
+		&testRecord{ID: "ns", Type: "NS", Name: "sub.example.com", Content: "ns1.other.net", TTL: 3600, ZoneID: "z1"},
+		&testRecord{ID: "txt", Type: "TXT", Name: "txt.example.com", Content: "hello world", TTL: 600, ZoneID: "z1"},
+		&testRecord{ID: "proxied", Type: "CNAME", Name: "www.example.com", Content: "origin.example.net", TTL: 1, Proxied: true, ZoneID: "z1"},
+	)
+	h := newTestHandler(t, s, "key")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "z1", "example.com", "", nil, false)
+
+	state, err := h.GetZoneState(context.Background(), zone)
+	Expect(err).To(BeNil())
+	sets := state.GetDNSSets()
+	Expect(sets).To(HaveLen(122))
+	Expect(sets["r119.example.com"].Sets[dns.RS_A].Records[0].Value).To(Equal("1.2.3.4"))
+	Expect(sets["txt.example.com"].Sets[dns.RS_TXT].Records[0].Value).To(Equal("\"hello world\""))
+	www := sets["www.example.com"].Sets[dns.RS_CNAME]
+	Expect(www.Records[0].Value).To(Equal("origin.example.net"))
+	Expect(www.ProviderHints).To(Equal(&dns.ProviderHints{CloudflareProxied: true}))
+	Expect(s.requests).To(ContainElements("GET /zones/z1/dns_records?page=1&per_page=100", "GET /zones/z1/dns_records?page=2&per_page=100"))
+	// access tokens expiring within the refresh margin are renewed
+	Expect(s.tokens).To(Equal(2))
+
+	set := dns.NewDNSSet("new.example.com")
+	set.Sets[dns.RS_A] = dns.NewRecordSet(dns.RS_A, 120, []*dns.Record{{Value: "5.6.7.8"}})
+	reqs := []*provider.ChangeRequest{provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, set, nil)}
+	Expect(h.ExecuteRequests(context.Background(), logger.New(), zone, state, reqs)).To(Succeed())
+	Expect(s.created).To(Equal([]*testRecord{{ID: "new", Type: "A", Name: "new.example.com", Content: "5.6.7.8", TTL: 120, ZoneID: "z1"}}))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{lifetime: time.Hour}
+	h := newTestHandler(t, s, "invalid")
+	_, err := h.GetZones(context.Background())
+	Expect(err).To(MatchError(ContainSubstring("cannot get IAM access token")))
+	Expect(perrs.Reason(err)).To(Equal(perrs.ReasonAuthFailed))
+
+	s = &testServer{lifetime: time.Hour, throttled: true}
+	h = newTestHandler(t, s, "key")
+	_, err = h.GetZones(context.Background())
+	Expect(err).To(HaveOccurred())
+	Expect(h.ClassifyError(err)).To(Equal(perrs.ReasonThrottled))
+	Expect(perrs.RetryAfter(err)).To(Equal(2 * time.Second))
+	// throttled requests are not retried by the Cloudflare client
+	Expect(s.requests).To(HaveLen(1))
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ibmcis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+// tokenRefreshMargin is the time before the expiration of an access token, when it is refreshed
+const tokenRefreshMargin = 5 * time.Minute
+
+// tokenSource provides IBM Cloud IAM access tokens for an API key.
+// The token is cached and refreshed shortly before its expiration.
+type tokenSource struct {
+	endpoint string
+	apiKey   string
+	http     *http.Client
+
+	lock    sync.Mutex
+	token   string
+	expires time.Time
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Expiration  int64  `json:"expiration"`
+}
+
+func newTokenSource(endpoint, apiKey string, client *http.Client) *tokenSource {
+	return &tokenSource{endpoint: endpoint, apiKey: apiKey, http: client}
+}
+
+// Token returns a valid access token.
+func (this *tokenSource) Token(ctx context.Context) (string, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.token != "" && time.Now().Add(tokenRefreshMargin).Before(this.expires) {
+		return this.token, nil
+	}
+	form := url.Values{}
+	form.Set("grant_type", "urn:ibm:params:oauth:grant-type:apikey")
+	form.Set("apikey", this.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := this.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("cannot get IAM access token: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("cannot get IAM access token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		err := fmt.Errorf("cannot get IAM access token: HTTP status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+		return "", perrs.Classify(err, iamErrorReason(resp.StatusCode))
+	}
+	tr := &tokenResponse{}
+	if err := json.Unmarshal(data, tr); err != nil {
+		return "", fmt.Errorf("cannot parse IAM access token response: %w", err)
+	}
+	if tr.AccessToken == "" {
+		return "", fmt.Errorf("IAM token response without access token")
+	}
+	this.token = tr.AccessToken
+	this.expires = time.Unix(tr.Expiration, 0)
+	return this.token, nil
+}
+
+// iamErrorReason maps the status code of a failed token request to an error reason.
+// IAM rejects invalid or disabled API keys with status 400.
+func iamErrorReason(code int) perrs.ErrorReason {
+	switch code {
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return perrs.ReasonAuthFailed
+	}
+	return perrs.ReasonForHTTPStatus(code)
+}
+
+// transport authenticates the requests to the CIS API with the IAM access token.
+type transport struct {
+	base   http.RoundTripper
+	tokens *tokenSource
+}
+
+func (this *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := this.tokens.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("X-Auth-User-Token", "Bearer "+token)
+	resp, err := this.base.RoundTrip(req)
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		// the Cloudflare client drops the headers of error responses, so the
+		// delay requested by the API is reported with the error of the request
+		resp.Body.Close()
+		err := fmt.Errorf("HTTP status %d: too many requests", resp.StatusCode)
+		return nil, &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: sdk.RetryAfter(resp.Header), Err: err}
+	}
+	return resp, err
+}
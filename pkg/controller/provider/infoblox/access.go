@@ -19,6 +19,7 @@ package infoblox
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -38,8 +39,6 @@ type access struct {
 	view    string
 }
 
-var _ raw.Executor = (*access)(nil)
-
 func NewAccess(client ibclient.IBConnector, view string, metrics provider.Metrics) *access {
 	return &access{
 		IBConnector: client,
@@ -48,21 +47,42 @@ func NewAccess(client ibclient.IBConnector, view string, metrics provider.Metric
 	}
 }
 
-func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+// contextRequestor sends the requests with a context, as the connector of the Infoblox client doesn't support contexts.
+type contextRequestor struct {
+	ibclient.HttpRequestor
+	ctx context.Context
+}
+
+func (r *contextRequestor) SendRequest(req *http.Request) ([]byte, error) {
+	return r.HttpRequestor.SendRequest(req.WithContext(r.ctx))
+}
+
+// connector returns a copy of the connector sending its requests with the given context.
+func (this *access) connector(ctx context.Context) ibclient.IBConnector {
+	c, ok := this.IBConnector.(*ibclient.Connector)
+	if !ok {
+		return this.IBConnector
+	}
+	clone := *c
+	clone.Requestor = &contextRequestor{HttpRequestor: c.Requestor, ctx: ctx}
+	return &clone
+}
+
+func (this *access) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
-	_, err := this.CreateObject(r.(ibclient.IBObject))
+	_, err := this.connector(ctx).CreateObject(r.(ibclient.IBObject))
 	return err
 }
 
-func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *access) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
-	_, err := this.UpdateObject(r.(Record).PrepareUpdate().(ibclient.IBObject), r.GetId())
+	_, err := this.connector(ctx).UpdateObject(r.(Record).PrepareUpdate().(ibclient.IBObject), r.GetId())
 	return err
 }
 
-func (this *access) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *access) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_DELETERECORDS, 1)
-	_, err := this.DeleteObject(r.GetId())
+	_, err := this.connector(ctx).DeleteObject(r.GetId())
 	return err
 }
 
@@ -102,7 +122,7 @@ func (this *access) NewRecord(fqdn string, rtype string, value string, zone prov
 	return
 }
 
-func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+func (this *access) GetRecordSet(ctx context.Context, dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
 	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
 	c := this.IBConnector.(*ibclient.Connector)
 
@@ -117,7 +137,7 @@ func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZ
 		if forceProxy {
 			urlStr += "&_proxy_search=GM"
 		}
-		req, err := http.NewRequest("GET", urlStr, new(bytes.Buffer))
+		req, err := http.NewRequestWithContext(ctx, "GET", urlStr, new(bytes.Buffer))
 		if err != nil {
 			return nil, err
 		}
@@ -148,3 +168,31 @@ func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZ
 	}
 	return rs2, nil
 }
+
+// executor adapts the access to a raw.Executor for a single execution.
+type executor struct {
+	ctx    context.Context
+	access *access
+}
+
+var _ raw.Executor = &executor{}
+
+func (this *executor) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.CreateRecord(this.ctx, r, zone)
+}
+
+func (this *executor) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.UpdateRecord(this.ctx, r, zone)
+}
+
+func (this *executor) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.access.DeleteRecord(this.ctx, r, zone)
+}
+
+func (this *executor) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	return this.access.NewRecord(fqdn, rtype, value, zone, ttl)
+}
+
+func (this *executor) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+	return this.access.GetRecordSet(this.ctx, dnsName, rtype, zone)
+}
@@ -24,7 +24,8 @@ import (
 
 const TYPE_CODE = "infoblox-dns"
 
-var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler)
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetProviderConfigSchemaByExample(&InfobloxConfig{})
 
 func init() {
 	compound.MustRegister(Factory)
@@ -20,15 +20,18 @@ package infoblox
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
 
 	ibclient "github.com/infobloxopen/infoblox-go-client/v2"
@@ -57,6 +60,7 @@ type InfobloxConfig struct {
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.DedicatedDNSAccess = &Handler{}
 
 func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 
@@ -177,11 +181,11 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 // Infoblox does not support zone forwarding???
 // Just removed the forwarding stuff from code
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	var raw []ibclient.ZoneAuth
 	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
 	obj := ibclient.NewZoneAuth(ibclient.ZoneAuth{})
-	err := h.access.GetObject(obj, "", &ibclient.QueryParams{}, &raw)
+	err := h.access.connector(ctx).GetObject(obj, "", &ibclient.QueryParams{}, &raw)
 	if err != nil {
 		return nil, err
 	}
@@ -202,7 +206,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 				View: *h.infobloxConfig.View,
 			},
 		)
-		err = h.access.GetObject(objN, "", &ibclient.QueryParams{}, &resN)
+		err = h.access.connector(ctx).GetObject(objN, "", &ibclient.QueryParams{}, &resN)
 		if err != nil {
 			return nil, fmt.Errorf("could not fetch NS records from zone '%s': %s", z.Fqdn, err)
 		}
@@ -218,7 +222,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	state := raw.NewState()
 	rt := provider.M_LISTRECORDS
 
@@ -227,7 +231,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	objA := ibclient.NewEmptyRecordA()
 	objA.Zone = zone.Key()
 	objA.View = *h.infobloxConfig.View
-	err := h.access.GetObject(objA, "", &ibclient.QueryParams{}, &resA)
+	err := h.access.connector(ctx).GetObject(objA, "", &ibclient.QueryParams{}, &resA)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch A records from zone '%s': %s", zone.Key(), err)
 	}
@@ -240,7 +244,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	objAAAA := ibclient.NewEmptyRecordAAAA()
 	objAAAA.Zone = zone.Key()
 	objAAAA.View = *h.infobloxConfig.View
-	err = h.access.GetObject(objAAAA, "", &ibclient.QueryParams{}, &resAAAA)
+	err = h.access.connector(ctx).GetObject(objAAAA, "", &ibclient.QueryParams{}, &resAAAA)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch AAAA records from zone '%s': %s", zone.Key(), err)
 	}
@@ -253,7 +257,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	objC := ibclient.NewEmptyRecordCNAME()
 	objC.Zone = zone.Key()
 	objC.View = *h.infobloxConfig.View
-	err = h.access.GetObject(objC, "", &ibclient.QueryParams{}, &resC)
+	err = h.access.connector(ctx).GetObject(objC, "", &ibclient.QueryParams{}, &resC)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch CNAME records from zone '%s': %s", zone.Key(), err)
 	}
@@ -269,7 +273,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 			View: *h.infobloxConfig.View,
 		},
 	)
-	err = h.access.GetObject(objT, "", &ibclient.QueryParams{}, &resT)
+	err = h.access.connector(ctx).GetObject(objT, "", &ibclient.QueryParams{}, &resT)
 	if err != nil {
 		return nil, fmt.Errorf("could not fetch TXT records from zone '%s': %s", zone.Key(), err)
 	}
@@ -281,14 +285,30 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return state, nil
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := raw.ExecuteRequests(logger, &h.config, h.access, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := raw.ExecuteRequests(ctx, logger, &h.config, &executor{ctx: ctx, access: h.access}, zone, state, reqs)
 	h.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
-	rs, err := h.access.GetRecordSet(dnsName, recordType, zone)
+// wapiStatusPattern extracts the HTTP status code from WAPI request errors
+var wapiStatusPattern = regexp.MustCompile(`^WAPI request error: (\d+)`)
+
+// ClassifyError maps the HTTP status code of WAPI request errors to error reasons.
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	var notFound *ibclient.NotFoundError
+	if errors.As(err, &notFound) {
+		return perrs.ReasonNotFound
+	}
+	if m := wapiStatusPattern.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return perrs.ReasonForHTTPStatus(code)
+	}
+	return perrs.ReasonUnknown
+}
+
+func (h *Handler) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
+	rs, err := h.access.GetRecordSet(ctx, dnsName, recordType, zone)
 	if err != nil {
 		return nil, err
 	}
@@ -299,14 +319,14 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 	return d, nil
 }
 
-func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
-	err := h.DeleteRecordSet(logger, zone, old)
+func (h *Handler) CreateOrUpdateRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
+	err := h.DeleteRecordSet(ctx, logger, zone, old)
 	if err != nil {
 		return err
 	}
 	for _, r := range new {
 		r0 := h.access.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, int64(r.GetTTL()))
-		err = h.access.CreateRecord(r0, zone)
+		err = h.access.CreateRecord(ctx, r0, zone)
 		if err != nil {
 			return err
 		}
@@ -314,10 +334,10 @@ func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provide
 	return err
 }
 
-func (h *Handler) DeleteRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
+func (h *Handler) DeleteRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
 	for _, r := range rs {
 		if r.(Record).GetId() != "" {
-			err := h.access.DeleteRecord(r.(Record), zone)
+			err := h.access.DeleteRecord(ctx, r.(Record), zone)
 			if err != nil {
 				return err
 			}
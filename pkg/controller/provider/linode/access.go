@@ -0,0 +1,273 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package linode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const (
+	defaultEndpoint = "https://api.linode.com/v4/"
+	pageSize        = 500
+)
+
+// Domain is a domain (zone) of the Linode Domains API.
+type Domain struct {
+	ID     int    `json:"id"`
+	Domain string `json:"domain"`
+	// Type is `master` for zones managed by Linode and `slave` for secondary zones
+	Type string `json:"type"`
+}
+
+type page struct {
+	Data  json.RawMessage `json:"data"`
+	Page  int             `json:"page"`
+	Pages int             `json:"pages"`
+}
+
+// APIError is an error response of the Linode API.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("linode api error %d: %s", e.StatusCode, e.Message)
+}
+
+type backend struct {
+	endpoint *url.URL
+	apiToken string
+	http     *http.Client
+
+	lock    sync.Mutex
+	domains map[string]string
+}
+
+var _ sdk.Backend = &backend{}
+
+func newBackend(endpoint, apiToken string) (*backend, error) {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Linode endpoint %q: %w", endpoint, err)
+	}
+	return &backend{
+		endpoint: u,
+		apiToken: apiToken,
+		http:     &http.Client{Timeout: 60 * time.Second},
+		domains:  map[string]string{},
+	}, nil
+}
+
+func (this *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	var domains []*Domain
+	err := this.list(ctx, "domains", func(data json.RawMessage) error {
+		var list []*Domain
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		domains = append(domains, list...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	zones := []sdk.Zone{}
+	for _, d := range domains {
+		if d.Type != "master" {
+			// records of secondary zones are transferred from the primary name server
+			continue
+		}
+		id := strconv.Itoa(d.ID)
+		this.domains[id] = d.Domain
+		zones = append(zones, sdk.Zone{ID: id, Domain: d.Domain})
+	}
+	return zones, nil
+}
+
+func (this *backend) ListRecords(ctx context.Context, zoneID string) (raw.RecordSet, error) {
+	domain, err := this.domain(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	rs := raw.RecordSet{}
+	err = this.list(ctx, recordsPath(zoneID), func(data json.RawMessage) error {
+		var list []*Record
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		for _, r := range list {
+			r.domain = domain
+			rs = append(rs, r)
+		}
+		return nil
+	})
+	return rs, err
+}
+
+// domain returns the domain name of a zone. It is usually known from listing the zones.
+func (this *backend) domain(ctx context.Context, zoneID string) (string, error) {
+	this.lock.Lock()
+	domain := this.domains[zoneID]
+	this.lock.Unlock()
+	if domain != "" {
+		return domain, nil
+	}
+
+	d := &Domain{}
+	if err := this.do(ctx, http.MethodGet, "domains/"+url.PathEscape(zoneID), nil, d); err != nil {
+		return "", err
+	}
+	this.lock.Lock()
+	this.domains[zoneID] = d.Domain
+	this.lock.Unlock()
+	return d.Domain, nil
+}
+
+func (this *backend) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	r := &Record{
+		Type:   rtype,
+		Name:   relativeName(fqdn, zone.Domain()),
+		TTLSec: supportedTTL(ttl),
+		domain: zone.Domain(),
+	}
+	r.setValue(value)
+	return r
+}
+
+func (this *backend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.do(ctx, http.MethodPost, recordsPath(zone.Id().ID), r.(*Record).request(), nil)
+}
+
+func (this *backend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.do(ctx, http.MethodPut, recordsPath(zone.Id().ID)+"/"+r.GetId(), r.(*Record).request(), nil)
+}
+
+func (this *backend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.do(ctx, http.MethodDelete, recordsPath(zone.Id().ID)+"/"+r.GetId(), nil, nil)
+}
+
+func recordsPath(zoneID string) string {
+	return fmt.Sprintf("domains/%s/records", url.PathEscape(zoneID))
+}
+
+// list reads all pages of a paginated list.
+func (this *backend) list(ctx context.Context, path string, add func(data json.RawMessage) error) error {
+	for no := 1; ; no++ {
+		p := &page{}
+		if err := this.do(ctx, http.MethodGet, fmt.Sprintf("%s?page=%d&page_size=%d", path, no, pageSize), nil, p); err != nil {
+			return err
+		}
+		if err := add(p.Data); err != nil {
+			return err
+		}
+		if p.Page >= p.Pages {
+			return nil
+		}
+	}
+}
+
+func (this *backend) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	u, err := this.endpoint.Parse(path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+this.apiToken)
+	req.Header.Set("User-Agent", "external-dns-manager")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := this.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: errorMessage(data)}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: sdk.RetryAfter(resp.Header), Err: apiErr}
+		}
+		return apiErr
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// errorMessage extracts the reasons of an error response of the form `{"errors": [{"reason": "...", "field": "..."}]}`.
+func errorMessage(data []byte) string {
+	msg := struct {
+		Errors []struct {
+			Reason string `json:"reason"`
+			Field  string `json:"field"`
+		} `json:"errors"`
+	}{}
+	if json.Unmarshal(data, &msg) != nil || len(msg.Errors) == 0 {
+		return strings.TrimSpace(string(data))
+	}
+	var reasons []string
+	for _, e := range msg.Errors {
+		if e.Field != "" {
+			reasons = append(reasons, e.Field+": "+e.Reason)
+		} else {
+			reasons = append(reasons, e.Reason)
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
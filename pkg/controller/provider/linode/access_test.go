@@ -0,0 +1,230 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package linode
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type testServer struct {
+	domains  []*Domain
+	records  map[string][]*Record
+	requests []string
+	bodies   []*recordRequest
+	status   int
+	header   http.Header
+	body     string
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+	if r.Header.Get("Authorization") != "Bearer secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"errors":[{"reason":"Invalid Token"}]}`))
+		return
+	}
+	if s.status != 0 {
+		for k, v := range s.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.body))
+		return
+	}
+	no, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v4/"), "/")
+	switch {
+	case r.Method == http.MethodGet && len(parts) == 1 && parts[0] == "domains":
+		writePage(w, s.domains, no, size)
+	case r.Method == http.MethodGet && len(parts) == 2:
+		for _, d := range s.domains {
+			if strconv.Itoa(d.ID) == parts[1] {
+				writeJSON(w, d)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"errors":[{"reason":"Not found"}]}`))
+	case r.Method == http.MethodGet && len(parts) == 3:
+		writePage(w, s.records[parts[1]], no, size)
+	case r.Method == http.MethodPost || r.Method == http.MethodPut:
+		req := &recordRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.bodies = append(s.bodies, req)
+		writeJSON(w, req)
+	case r.Method == http.MethodDelete:
+		writeJSON(w, map[string]interface{}{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func writePage(w http.ResponseWriter, list interface{}, no, size int) {
+	data, _ := json.Marshal(list)
+	var elems []json.RawMessage
+	_ = json.Unmarshal(data, &elems)
+	pages := (len(elems) + size - 1) / size
+	if pages == 0 {
+		pages = 1
+	}
+	start := (no - 1) * size
+	if start > len(elems) {
+		start = len(elems)
+	}
+	end := start + size
+	if end > len(elems) {
+		end = len(elems)
+	}
+	data, _ = json.Marshal(elems[start:end])
+	writeJSON(w, &page{Data: data, Page: no, Pages: pages})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestBackend(t *testing.T, s *testServer, apiToken string) *backend {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	b, err := newBackend(server.URL+"/v4", apiToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestListZonesAndRecords(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{records: map[string][]*Record{}}
+	for i := 0; i < pageSize+5; i++ {
+		s.domains = append(s.domains, &Domain{ID: 1000 + i, Domain: "sub" + strconv.Itoa(i) + ".example.com", Type: "master"})
+	}
+	s.domains = append(s.domains, &Domain{ID: 1, Domain: "example.org", Type: "master"}, &Domain{ID: 2, Domain: "secondary.org", Type: "slave"})
+	s.records["1"] = []*Record{
+		{ID: 11, Type: "A", Name: "", Target: "1.2.3.4", TTLSec: 300},
+		{ID: 12, Type: "CNAME", Name: "www", Target: "example.org", TTLSec: 300},
+		{ID: 13, Type: "TXT", Name: "comment", Target: "hello world", TTLSec: 3600},
+		{ID: 14, Type: "CAA", Name: "", Target: "letsencrypt.org", Tag: "issue", TTLSec: 300},
+	}
+	b := newTestBackend(t, s, "secret")
+
+	zones, err := b.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(zones).To(HaveLen(pageSize + 6))
+	Expect(zones[0].ID).To(Equal("1000"))
+	Expect(zones[pageSize+5].Domain).To(Equal("example.org"))
+	Expect(s.requests).To(Equal([]string{"GET /v4/domains?page=1&page_size=500", "GET /v4/domains?page=2&page_size=500"}))
+
+	records, err := b.ListRecords(context.Background(), "1")
+	Expect(err).To(BeNil())
+	Expect(records).To(HaveLen(4))
+	Expect(records[0].GetDNSName()).To(Equal("example.org"))
+	Expect(records[0].GetId()).To(Equal("11"))
+	Expect(records[1].GetDNSName()).To(Equal("www.example.org"))
+	Expect(records[1].GetValue()).To(Equal("example.org"))
+	Expect(records[2].GetValue()).To(Equal("\"hello world\""))
+	Expect(records[2].GetTTL()).To(Equal(3600))
+	Expect(records[3].GetValue()).To(Equal(dns.CAAValue(0, "issue", "letsencrypt.org")))
+
+	// domain names of unknown zones are looked up
+	b = newTestBackend(t, s, "secret")
+	records, err = b.ListRecords(context.Background(), "1")
+	Expect(err).To(BeNil())
+	Expect(records[1].GetDNSName()).To(Equal("www.example.org"))
+}
+
+func TestChangeRecords(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{}
+	b := newTestBackend(t, s, "secret")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "1", "example.org", "", nil, false)
+
+	txt := b.NewRecord("comment.example.org", dns.RS_TXT, "\"hello world\"", zone, 200)
+	Expect(b.CreateRecord(context.Background(), txt, zone)).To(Succeed())
+	caa := b.NewRecord("example.org", dns.RS_CAA, dns.CAAValue(0, "issue", "letsencrypt.org"), zone, 300)
+	Expect(b.CreateRecord(context.Background(), caa, zone)).To(Succeed())
+
+	old := &Record{ID: 12, Type: "CNAME", Name: "www", Target: "example.org", TTLSec: 300, domain: "example.org"}
+	updated := old.Copy()
+	updated.SetTTL(4000)
+	Expect(b.UpdateRecord(context.Background(), updated, zone)).To(Succeed())
+	Expect(b.DeleteRecord(context.Background(), old, zone)).To(Succeed())
+
+	Expect(s.requests).To(Equal([]string{
+		"POST /v4/domains/1/records",
+		"POST /v4/domains/1/records",
+		"PUT /v4/domains/1/records/12",
+		"DELETE /v4/domains/1/records/12",
+	}))
+	Expect(s.bodies).To(Equal([]*recordRequest{
+		{Type: "TXT", Name: "comment", Target: "hello world", TTLSec: 300},
+		{Type: "CAA", Name: "", Target: "letsencrypt.org", Tag: "issue", TTLSec: 300},
+		{Type: "CNAME", Name: "www", Target: "example.org", TTLSec: 7200},
+	}))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newTestBackend(t, &testServer{}, "invalid")
+	_, err := b.ListZones(context.Background())
+	Expect(err).To(MatchError(ContainSubstring("Invalid Token")))
+	Expect(classifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+
+	s := &testServer{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"5"}}, body: `{"errors":[{"reason":"Too Many Requests"}]}`}
+	b = newTestBackend(t, s, "secret")
+	_, err = b.ListZones(context.Background())
+	Expect(perrs.IsThrottlingError(err)).To(BeTrue())
+	var perr *perrs.ProviderError
+	Expect(err).To(BeAssignableToTypeOf(perr))
+	Expect(err.(*perrs.ProviderError).RetryAfter).To(Equal(5 * time.Second))
+
+	s = &testServer{status: http.StatusForbidden, body: `{"errors":[{"reason":"Unauthorized","field":"domains"}]}`}
+	b = newTestBackend(t, s, "secret")
+	_, err = b.ListRecords(context.Background(), "1")
+	Expect(err).To(MatchError(ContainSubstring("domains: Unauthorized")))
+	Expect(isAccessForbidden(err)).To(BeTrue())
+}
+
+func TestSupportedTTL(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(supportedTTL(0)).To(Equal(30))
+	Expect(supportedTTL(300)).To(Equal(300))
+	Expect(supportedTTL(301)).To(Equal(3600))
+	Expect(supportedTTL(10000000)).To(Equal(2419200))
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package linode
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	apiToken, err := c.GetRequiredProperty("LINODE_TOKEN", "apiToken")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := c.GetProperty("LINODE_URL", "endpoint")
+
+	backend, err := newBackend(endpoint, apiToken)
+	if err != nil {
+		return nil, err
+	}
+	options := sdk.Options{
+		RecordTypes:   []string{dns.RS_CAA},
+		SkipZone:      isAccessForbidden,
+		ClassifyError: classifyError,
+	}
+	return sdk.NewHandler(TYPE_CODE, c, backend, options)
+}
+
+// classifyError maps the HTTP status code of the Linode API responses to error reasons.
+func classifyError(err error) perrs.ErrorReason {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return perrs.ReasonForHTTPStatus(apiErr.StatusCode)
+	}
+	return perrs.ReasonUnknown
+}
+
+// isAccessForbidden checks for zones not accessible with the scopes of the token.
+func isAccessForbidden(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package linode
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+// supportedTTLs are the TTL values accepted by Linode, other values are rounded up by the API.
+var supportedTTLs = []int{30, 120, 300, 3600, 7200, 14400, 28800, 57600, 86400, 172800, 345600, 604800, 1209600, 2419200}
+
+// Record is a record of the Linode Domains API.
+// The name is relative to the domain, the empty name denotes the apex of the domain.
+// CAA records keep the tag in a separate field, the flags are always 0.
+type Record struct {
+	ID     int    `json:"id"`
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	Tag    string `json:"tag,omitempty"`
+	TTLSec int    `json:"ttl_sec"`
+
+	domain string
+}
+
+var _ raw.Record = &Record{}
+
+// recordRequest is the request body for creating and updating records.
+type recordRequest struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Target string `json:"target"`
+	Tag    string `json:"tag,omitempty"`
+	TTLSec int    `json:"ttl_sec"`
+}
+
+func (r *Record) GetType() string  { return r.Type }
+func (r *Record) GetId() string    { return strconv.Itoa(r.ID) }
+func (r *Record) GetTTL() int      { return r.TTLSec }
+func (r *Record) SetTTL(ttl int)   { r.TTLSec = supportedTTL(int64(ttl)) }
+func (r *Record) Copy() raw.Record { n := *r; return &n }
+
+func (r *Record) GetDNSName() string {
+	if r.Name == "" {
+		return r.domain
+	}
+	return r.Name + "." + r.domain
+}
+
+func (r *Record) GetValue() string {
+	switch r.Type {
+	case dns.RS_CAA:
+		return dns.CAAValue(0, r.Tag, r.Target)
+	case dns.RS_CNAME, dns.RS_NS:
+		return strings.TrimSuffix(r.Target, ".")
+	}
+	return sdk.NormalizeValue(r.Type, r.Target)
+}
+
+// setValue maps a value in presentation format to the target (and tag) fields.
+func (r *Record) setValue(value string) {
+	switch r.Type {
+	case dns.RS_TXT:
+		if len(value) >= 2 && strings.HasPrefix(value, "\"") && strings.HasSuffix(value, "\"") {
+			value = value[1 : len(value)-1]
+		}
+	case dns.RS_CAA:
+		if _, tag, caaValue, err := dns.ParseCAAValue(value); err == nil {
+			r.Tag = tag
+			value = caaValue
+		}
+	}
+	r.Target = value
+}
+
+func (r *Record) request() *recordRequest {
+	return &recordRequest{
+		Type:   r.Type,
+		Name:   r.Name,
+		Target: r.Target,
+		Tag:    r.Tag,
+		TTLSec: r.TTLSec,
+	}
+}
+
+// relativeName returns the name of a record relative to the domain of the zone.
+func relativeName(fqdn, domain string) string {
+	if fqdn == domain {
+		return ""
+	}
+	return strings.TrimSuffix(fqdn, "."+domain)
+}
+
+// supportedTTL rounds up a TTL to the next value supported by Linode.
+func supportedTTL(ttl int64) int {
+	for _, t := range supportedTTLs {
+		if ttl <= int64(t) {
+			return t
+		}
+	}
+	return supportedTTLs[len(supportedTTLs)-1]
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package mock
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// This file contains a minimal authoritative DNS server answering
+// plain queries for the zones of the mock provider.
+
+var recordTypes = map[string]uint16{
+	dns.RS_A:     miekgdns.TypeA,
+	dns.RS_AAAA:  miekgdns.TypeAAAA,
+	dns.RS_CNAME: miekgdns.TypeCNAME,
+	dns.RS_NS:    miekgdns.TypeNS,
+	dns.RS_TXT:   miekgdns.TypeTXT,
+	dns.RS_SRV:   miekgdns.TypeSRV,
+	dns.RS_CAA:   miekgdns.TypeCAA,
+}
+
+// dnsServer answers DNS queries for the zones of an InMemory via UDP and TCP.
+// Meta data records and record sets with routing policies are not served.
+type dnsServer struct {
+	logger  logger.LogContext
+	mock    *provider.InMemory
+	servers []*miekgdns.Server
+}
+
+func startDNSServer(logger logger.LogContext, address string, mock *provider.InMemory) (*dnsServer, error) {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return nil, fmt.Errorf("cannot listen on udp %s: %w", address, err)
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("cannot listen on tcp %s: %w", address, err)
+	}
+	s := newDNSServer(logger, mock, conn, listener)
+	logger.Infof("serving mock zones via DNS on %s", address)
+	return s, nil
+}
+
+// newDNSServer serves the zones on the given connections and returns once both servers are started.
+func newDNSServer(logger logger.LogContext, mock *provider.InMemory, conn net.PacketConn, listener net.Listener) *dnsServer {
+	s := &dnsServer{logger: logger, mock: mock}
+	started := make(chan struct{}, 2)
+	for _, server := range []*miekgdns.Server{{PacketConn: conn}, {Listener: listener}} {
+		server.Handler = miekgdns.HandlerFunc(s.serveDNS)
+		server.NotifyStartedFunc = func() { started <- struct{}{} }
+		s.servers = append(s.servers, server)
+		go func(server *miekgdns.Server) {
+			if err := server.ActivateAndServe(); err != nil {
+				logger.Warnf("dns server stopped: %s", err)
+			}
+		}(server)
+	}
+	<-started
+	<-started
+	return s
+}
+
+func (s *dnsServer) shutdown() {
+	for _, server := range s.servers {
+		_ = server.Shutdown()
+	}
+}
+
+// serveDNS answers a query. Other opcodes and queries with more than one
+// question are already rejected by the server.
+func (s *dnsServer) serveDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+	resp := new(miekgdns.Msg)
+	resp.SetReply(r)
+	resp.Authoritative = true
+	q := r.Question[0]
+	resp.Rcode, resp.Answer, resp.Ns = s.answer(strings.ToLower(strings.TrimSuffix(q.Name, ".")), q.Qtype)
+	if resp.Rcode == miekgdns.RcodeRefused {
+		resp.Authoritative = false
+	}
+	if w.LocalAddr().Network() == "udp" {
+		resp.Truncate(miekgdns.MinMsgSize)
+	}
+	if err := w.WriteMsg(resp); err != nil {
+		s.logger.Warnf("cannot write dns response to %s: %s", w.RemoteAddr(), err)
+	}
+}
+
+// answer looks up the records for a query and returns the response code and
+// the records of the answer and authority section.
+func (s *dnsServer) answer(name string, qtype uint16) (int, []miekgdns.RR, []miekgdns.RR) {
+	zone, sets := s.mock.Lookup(name)
+	if zone == nil {
+		return miekgdns.RcodeRefused, nil, nil
+	}
+	soa := zoneSOA(zone)
+	if qtype == miekgdns.TypeSOA && name == zone.Domain() {
+		return miekgdns.RcodeSuccess, []miekgdns.RR{soa}, nil
+	}
+	var answer []miekgdns.RR
+	for rtype, rs := range sets {
+		t, ok := recordTypes[rtype]
+		if !ok || rs.RoutingPolicy != nil {
+			continue
+		}
+		if qtype != miekgdns.TypeANY && t != miekgdns.TypeCNAME && t != qtype {
+			continue
+		}
+		for _, r := range rs.Records {
+			rr, err := miekgdns.NewRR(fmt.Sprintf("%s %d IN %s %s", miekgdns.Fqdn(name), rs.TTL, rtype, r.Value))
+			if err != nil || rr == nil {
+				s.logger.Warnf("cannot serve %s record %s: %s", rtype, name, err)
+				continue
+			}
+			answer = append(answer, rr)
+		}
+	}
+	if len(answer) > 0 {
+		return miekgdns.RcodeSuccess, answer, nil
+	}
+	if len(sets) == 0 && name != zone.Domain() {
+		return miekgdns.RcodeNameError, nil, []miekgdns.RR{soa}
+	}
+	return miekgdns.RcodeSuccess, nil, []miekgdns.RR{soa}
+}
+
+func zoneSOA(zone provider.DNSHostedZone) miekgdns.RR {
+	domain := miekgdns.Fqdn(zone.Domain())
+	return &miekgdns.SOA{
+		Hdr:     miekgdns.RR_Header{Name: domain, Rrtype: miekgdns.TypeSOA, Class: miekgdns.ClassINET, Ttl: 60},
+		Ns:      "ns." + domain,
+		Mbox:    "hostmaster." + domain,
+		Serial:  1,
+		Refresh: 3600,
+		Retry:   600,
+		Expire:  86400,
+		Minttl:  60,
+	}
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package mock
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	miekgdns "github.com/miekg/dns"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+func newTestDNSServer(t *testing.T) (udpAddr, tcpAddr string) {
+	mock := provider.NewInMemory()
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "Z1", "example.org", "", nil, false)
+	mock.AddZone(zone)
+	create := func(name, rtype string, values ...string) {
+		set := dns.NewDNSSet(name)
+		set.SetRecordSet(rtype, 300, values...)
+		Expect(mock.Apply(zone.Id(), provider.NewChangeRequest(provider.R_CREATE, rtype, nil, set, nil), &provider.NullMetrics{})).To(Succeed())
+	}
+	create("www.example.org", dns.RS_A, "1.2.3.4", "1.2.3.5")
+	create("www.example.org", dns.RS_AAAA, "2001:db8::1")
+	create("alias.example.org", dns.RS_CNAME, "www.example.org")
+	create("txt.example.org", dns.RS_TXT, `"hello world"`)
+	create("_sip._tcp.example.org", dns.RS_SRV, "10 20 5060 sip.example.org")
+	create("*.wild.example.org", dns.RS_A, "5.6.7.8")
+	var large []string
+	for i := 0; i < 20; i++ {
+		large = append(large, fmt.Sprintf(`"%d-%s"`, i, strings.Repeat("x", 60)))
+	}
+	create("large.example.org", dns.RS_TXT, large...)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	s := newDNSServer(logger.New(), mock, conn, listener)
+	t.Cleanup(s.shutdown)
+	return conn.LocalAddr().String(), listener.Addr().String()
+}
+
+func query(network, addr, name string, qtype uint16) *miekgdns.Msg {
+	m := new(miekgdns.Msg)
+	m.SetQuestion(name, qtype)
+	resp, _, err := (&miekgdns.Client{Net: network}).Exchange(m, addr)
+	Expect(err).NotTo(HaveOccurred())
+	return resp
+}
+
+func answers(resp *miekgdns.Msg) []string {
+	var result []string
+	for _, rr := range resp.Answer {
+		result = append(result, strings.ReplaceAll(rr.String(), "\t", " "))
+	}
+	return result
+}
+
+func TestDNSServer(t *testing.T) {
+	RegisterTestingT(t)
+	udpAddr, tcpAddr := newTestDNSServer(t)
+
+	resp := query("udp", udpAddr, "WWW.example.org.", miekgdns.TypeA)
+	Expect(resp.Rcode).To(Equal(miekgdns.RcodeSuccess))
+	Expect(resp.Authoritative).To(BeTrue())
+	Expect(answers(resp)).To(ConsistOf("www.example.org. 300 IN A 1.2.3.4", "www.example.org. 300 IN A 1.2.3.5"))
+
+	resp = query("tcp", tcpAddr, "www.example.org.", miekgdns.TypeAAAA)
+	Expect(answers(resp)).To(ConsistOf("www.example.org. 300 IN AAAA 2001:db8::1"))
+
+	resp = query("udp", udpAddr, "alias.example.org.", miekgdns.TypeA)
+	Expect(answers(resp)).To(ConsistOf("alias.example.org. 300 IN CNAME www.example.org."))
+
+	resp = query("udp", udpAddr, "txt.example.org.", miekgdns.TypeTXT)
+	Expect(answers(resp)).To(ConsistOf(`txt.example.org. 300 IN TXT "hello world"`))
+
+	resp = query("udp", udpAddr, "_sip._tcp.example.org.", miekgdns.TypeSRV)
+	Expect(answers(resp)).To(ConsistOf("_sip._tcp.example.org. 300 IN SRV 10 20 5060 sip.example.org."))
+
+	resp = query("udp", udpAddr, "host.wild.example.org.", miekgdns.TypeA)
+	Expect(answers(resp)).To(ConsistOf("host.wild.example.org. 300 IN A 5.6.7.8"))
+
+	resp = query("udp", udpAddr, "example.org.", miekgdns.TypeSOA)
+	Expect(answers(resp)).To(ConsistOf("example.org. 60 IN SOA ns.example.org. hostmaster.example.org. 1 3600 600 86400 60"))
+
+	// no data and unknown names are answered with the SOA record in the authority section
+	resp = query("udp", udpAddr, "www.example.org.", miekgdns.TypeTXT)
+	Expect(resp.Rcode).To(Equal(miekgdns.RcodeSuccess))
+	Expect(resp.Answer).To(BeEmpty())
+	Expect(resp.Ns).To(HaveLen(1))
+	resp = query("udp", udpAddr, "unknown.example.org.", miekgdns.TypeA)
+	Expect(resp.Rcode).To(Equal(miekgdns.RcodeNameError))
+	Expect(resp.Ns).To(HaveLen(1))
+	Expect(resp.Ns[0].Header().Rrtype).To(Equal(miekgdns.TypeSOA))
+
+	resp = query("udp", udpAddr, "www.other.org.", miekgdns.TypeA)
+	Expect(resp.Rcode).To(Equal(miekgdns.RcodeRefused))
+	Expect(resp.Authoritative).To(BeFalse())
+
+	// large responses are truncated for UDP
+	m := new(miekgdns.Msg)
+	m.SetQuestion("large.example.org.", miekgdns.TypeTXT)
+	resp, _, err := (&miekgdns.Client{Net: "udp"}).Exchange(m, udpAddr)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(resp.Truncated).To(BeTrue())
+	Expect(len(resp.Answer)).To(BeNumerically("<", 20))
+	resp = query("tcp", tcpAddr, "large.example.org.", miekgdns.TypeTXT)
+	Expect(resp.Truncated).To(BeFalse())
+	Expect(resp.Answer).To(HaveLen(20))
+}
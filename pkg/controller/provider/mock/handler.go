@@ -19,6 +19,7 @@ package mock
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -36,6 +37,7 @@ type Handler struct {
 	mock        *provider.InMemory
 	mockConfig  MockConfig
 	rateLimiter flowcontrol.RateLimiter
+	dnsServer   *dnsServer
 }
 
 type MockZone struct {
@@ -49,9 +51,18 @@ type MockConfig struct {
 	Zones           []MockZone `json:"zones"`
 	FailGetZones    bool       `json:"failGetZones"`
 	FailDeleteEntry bool       `json:"failDeleteEntry"`
+	// FailWritePermissionProbe lets the readiness probe of the provider fail
+	FailWritePermissionProbe bool `json:"failWritePermissionProbe,omitempty"`
+	// Transactional applies all change requests of a batch or none of them
+	Transactional bool `json:"transactional,omitempty"`
+	// PersistenceFile optionally stores the zones in a JSON file, so that they survive restarts
+	PersistenceFile string `json:"persistenceFile,omitempty"`
+	// DNSServerAddress optionally serves the zones via DNS on the given address (e.g. `:5353`)
+	DNSServerAddress string `json:"dnsServerAddress,omitempty"`
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.WritePermissionProbeSupport = &Handler{}
 
 // TestMock allows tests to access mocked DNSHosted Zones
 var TestMock = map[string]*provider.InMemory{}
@@ -66,6 +77,9 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		rateLimiter:       config.RateLimiter,
 	}
 
+	if config.Config == nil {
+		return nil, fmt.Errorf("mock providerConfig required")
+	}
 	err := json.Unmarshal(config.Config.Raw, &h.mockConfig)
 	if err != nil {
 		return nil, fmt.Errorf("unmarshal mock providerConfig failed with: %s", err)
@@ -87,23 +101,40 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		}
 	}
 
+	if h.mockConfig.PersistenceFile != "" {
+		if err := mock.SetPersistence(provider.NewFileInMemoryPersistence(h.mockConfig.PersistenceFile)); err != nil {
+			return nil, fmt.Errorf("cannot restore mock zones from %s: %s", h.mockConfig.PersistenceFile, err)
+		}
+	}
+
 	h.cache, err = config.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, config.Metrics, h.getZones, h.getZoneState)
 	if err != nil {
 		return nil, err
 	}
 
+	if h.mockConfig.DNSServerAddress != "" {
+		h.dnsServer, err = startDNSServer(config.Logger, h.mockConfig.DNSServerAddress, mock)
+		if err != nil {
+			h.cache.Release()
+			return nil, err
+		}
+	}
+
 	return h, nil
 }
 
 func (h *Handler) Release() {
+	if h.dnsServer != nil {
+		h.dnsServer.shutdown()
+	}
 	h.cache.Release()
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	if h.mockConfig.FailGetZones {
 		return nil, fmt.Errorf("forced error by mockConfig.FailGetZones")
 	}
@@ -112,26 +143,40 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	h.config.RateLimiter.Accept()
 	return h.mock.CloneZoneState(zone)
 }
 
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	return true
+}
+
+func (h *Handler) ProbeWritePermission(ctx context.Context, zone provider.DNSHostedZone) error {
+	if h.mockConfig.FailWritePermissionProbe {
+		return fmt.Errorf("write permission denied for zone %s", zone.Id().ID)
+	}
+	return nil
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
 	err := h.executeRequests(logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
 func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	if h.mockConfig.Transactional {
+		return h.executeTransaction(logger, zone, reqs)
+	}
 	var succeeded, failed int
 	for _, r := range reqs {
 		h.config.RateLimiter.Accept()
@@ -164,3 +209,40 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+func (h *Handler) executeTransaction(logger logger.LogContext, zone provider.DNSHostedZone, reqs []*provider.ChangeRequest) error {
+	h.config.RateLimiter.Accept()
+	var err error
+	for _, r := range reqs {
+		if h.mockConfig.FailDeleteEntry && r.Action == provider.R_DELETE {
+			err = &provider.TransactionError{Request: r, Err: fmt.Errorf("forced error by mockConfig.FailDeleteEntry")}
+			break
+		}
+	}
+	if err == nil {
+		err = h.mock.ApplyTransaction(zone.Id(), reqs, h.config.Metrics)
+	}
+	if err != nil {
+		logger.Infof("Transaction for %d records in zone %s failed with %s", len(reqs), zone.Id(), err)
+		var failed *provider.TransactionError
+		errors.As(err, &failed)
+		for _, r := range reqs {
+			if r.Done == nil {
+				continue
+			}
+			if failed != nil && r != failed.Request {
+				r.Done.Failed(fmt.Errorf("rolled back: %s", err))
+			} else {
+				r.Done.Failed(err)
+			}
+		}
+		return err
+	}
+	for _, r := range reqs {
+		if r.Done != nil {
+			r.Done.Succeeded()
+		}
+	}
+	logger.Infof("Succeeded transaction for %d records in zone %s", len(reqs), zone.Id())
+	return nil
+}
@@ -17,30 +17,25 @@
 package netlify
 
 import (
+	"context"
+
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/strfmt"
 	"github.com/netlify/open-api/go/models"
 	"github.com/netlify/open-api/go/plumbing"
 	"github.com/netlify/open-api/go/plumbing/operations"
-	"k8s.io/client-go/util/flowcontrol"
 
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
 )
 
-type Access interface {
-	ListZones(consume func(zone models.DNSZone) (bool, error)) error
-	ListRecords(zoneId string, consume func(record models.DNSRecord) (bool, error)) error
-
-	raw.Executor
+type backend struct {
+	client   operations.ClientService
+	authInfo runtime.ClientAuthInfoWriter
 }
 
-type access struct {
-	client      operations.ClientService
-	authInfo    runtime.ClientAuthInfoWriter
-	metrics     provider.Metrics
-	rateLimiter flowcontrol.RateLimiter
-}
+var _ sdk.Backend = &backend{}
 
 func ClientCredentials(apiToken string) runtime.ClientAuthInfoWriter {
 	return runtime.ClientAuthInfoWriterFunc(func(r runtime.ClientRequest, _ strfmt.Registry) error {
@@ -50,45 +45,38 @@ func ClientCredentials(apiToken string) runtime.ClientAuthInfoWriter {
 	})
 }
 
-func NewAccess(apiToken string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) (Access, error) {
-	client := plumbing.Default.Operations
-	clientCredentials := ClientCredentials(apiToken)
-	return &access{client: client, authInfo: clientCredentials, metrics: metrics, rateLimiter: rateLimiter}, nil
+func NewBackend(apiToken string) sdk.Backend {
+	return &backend{client: plumbing.Default.Operations, authInfo: ClientCredentials(apiToken)}
 }
 
-func (this *access) ListZones(consume func(zone models.DNSZone) (bool, error)) error {
-	this.metrics.AddGenericRequests(provider.M_LISTZONES, 1)
-	this.rateLimiter.Accept()
-	results, err := this.client.GetDNSZones(nil, this.authInfo)
+func (this *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	params := operations.NewGetDNSZonesParamsWithContext(ctx)
+	results, err := this.client.GetDNSZones(params, this.authInfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	zones := []sdk.Zone{}
 	for _, z := range results.Payload {
-		if cont, err := consume(*z); !cont || err != nil {
-			return err
-		}
+		zones = append(zones, sdk.Zone{ID: z.ID, Domain: z.Name})
 	}
-	return nil
+	return zones, nil
 }
 
-func (this *access) ListRecords(zoneID string, consume func(record models.DNSRecord) (bool, error)) error {
-	this.metrics.AddZoneRequests(zoneID, provider.M_LISTRECORDS, 1)
-	this.rateLimiter.Accept()
-	params := operations.NewGetDNSRecordsParams()
+func (this *backend) ListRecords(ctx context.Context, zoneID string) (raw.RecordSet, error) {
+	params := operations.NewGetDNSRecordsParamsWithContext(ctx)
 	params.ZoneID = zoneID
 	results, err := this.client.GetDNSRecords(params, this.authInfo)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	for _, z := range results.Payload {
-		if cont, err := consume(*z); !cont || err != nil {
-			return err
-		}
+	rs := raw.RecordSet{}
+	for _, r := range results.Payload {
+		rs = append(rs, (*Record)(r))
 	}
-	return nil
+	return rs, nil
 }
 
-func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
 	ttl := r.GetTTL()
 	testTTL(&ttl)
@@ -98,37 +86,33 @@ func (this *access) CreateRecord(r raw.Record, zone provider.DNSHostedZone) erro
 		Value:    r.GetValue(),
 		TTL:      int64(ttl),
 	}
-	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_CREATERECORDS, 1)
-	this.rateLimiter.Accept()
-	createParams := operations.NewCreateDNSRecordParams()
+	createParams := operations.NewCreateDNSRecordParamsWithContext(ctx)
 	createParams.SetZoneID(a.DNSZoneID)
 	createParams.SetDNSRecord(&dnsRecord)
 	_, err := this.client.CreateDNSRecord(createParams, this.authInfo)
 	return err
 }
 
-func (this *access) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	// Netlify does not support updating a record
 	// Delete the existing record and re-create it
-	err := this.DeleteRecord(r, zone)
+	err := this.DeleteRecord(ctx, r, zone)
 	if err != nil {
 		return err
 	}
-	return this.CreateRecord(r, zone)
+	return this.CreateRecord(ctx, r, zone)
 }
 
-func (this *access) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+func (this *backend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
 	a := r.(*Record)
-	deleteParams := operations.NewDeleteDNSRecordParams()
+	deleteParams := operations.NewDeleteDNSRecordParamsWithContext(ctx)
 	deleteParams.SetZoneID(a.DNSZoneID)
 	deleteParams.SetDNSRecordID(a.ID)
-	this.metrics.AddZoneRequests(zone.Id().ID, provider.M_DELETERECORDS, 1)
-	this.rateLimiter.Accept()
 	_, err := this.client.DeleteDNSRecord(deleteParams, this.authInfo)
 	return err
 }
 
-func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+func (this *backend) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
 	return (*Record)(&models.DNSRecord{
 		Type:      rtype,
 		Hostname:  fqdn,
@@ -138,24 +122,6 @@ func (this *access) NewRecord(fqdn, rtype, value string, zone provider.DNSHosted
 	})
 }
 
-func (this *access) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
-	rs := raw.RecordSet{}
-	consume := func(record models.DNSRecord) (bool, error) {
-		a := (*Record)(&record)
-		if a.Type == rtype && a.Hostname == dnsName {
-			rs = append(rs, a)
-		}
-		return true, nil
-	}
-
-	// no filtering provided by API, we have to list complete zone and filter
-	err := this.ListRecords(zone.Id().ID, consume)
-	if err != nil {
-		return nil, err
-	}
-	return rs, nil
-}
-
 func testTTL(ttl *int) {
 	if *ttl < 1 {
 		*ttl = 1
@@ -19,8 +19,8 @@ package netlify
 import (
 	"github.com/netlify/open-api/go/models"
 
-	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
 )
 
 type Record models.DNSRecord
@@ -28,12 +28,7 @@ type Record models.DNSRecord
 func (r *Record) GetType() string    { return r.Type }
 func (r *Record) GetId() string      { return r.ID }
 func (r *Record) GetDNSName() string { return r.Hostname }
-func (r *Record) GetValue() string {
-	if r.Type == dns.RS_TXT {
-		return raw.EnsureQuotedText(r.Value)
-	}
-	return r.Value
-}
-func (r *Record) GetTTL() int      { return int(r.TTL) }  // The Netlify Record uses int64 for TTL
-func (r *Record) SetTTL(ttl int)   { r.TTL = int64(ttl) } // The Netlify Record uses int64 for TTL
-func (r *Record) Copy() raw.Record { n := *r; return &n }
+func (r *Record) GetValue() string   { return sdk.NormalizeValue(r.Type, r.Value) }
+func (r *Record) GetTTL() int        { return int(r.TTL) }  // The Netlify Record uses int64 for TTL
+func (r *Record) SetTTL(ttl int)     { r.TTL = int64(ttl) } // The Netlify Record uses int64 for TTL
+func (r *Record) Copy() raw.Record   { n := *r; return &n }
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ns1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	// weightParameter is the routing policy parameter for the weight of a weighted record set
+	weightParameter = "weight"
+	// metaWeight is the answer metadata field for the weight used by the weighted_shuffle filter
+	metaWeight = "weight"
+	// metaNote is the answer metadata field used to carry the set identifier of an answer
+	metaNote = "note"
+	// setIdentifierNotePrefix marks the set identifier in the note metadata of answers of weighted record sets
+	setIdentifierNotePrefix = "set-identifier="
+)
+
+// weightedFilters is the filter chain for records with weighted answers.
+var weightedFilters = []*Filter{
+	{Filter: "weighted_shuffle", Config: map[string]interface{}{}},
+	{Filter: "select_first_n", Config: map[string]interface{}{"N": 1}},
+}
+
+func validateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	switch policy.Type {
+	case dns.RP_WEIGHTED:
+		if policy.SetIdentifier == "" {
+			return fmt.Errorf("missing set identifier for routing policy %s", policy.Type)
+		}
+		for k := range policy.Parameters {
+			if k != weightParameter {
+				return fmt.Errorf("unsupported parameter %q for routing policy %s (expected %s)", k, policy.Type, weightParameter)
+			}
+		}
+		_, err := parseWeight(policy)
+		return err
+	default:
+		return fmt.Errorf("routing policy %s not supported by provider type %s", policy.Type, TYPE_CODE)
+	}
+}
+
+func parseWeight(policy *dns.RoutingPolicy) (int64, error) {
+	value, ok := policy.Parameters[weightParameter]
+	if !ok {
+		return 0, fmt.Errorf("missing parameter %q for routing policy %s", weightParameter, policy.Type)
+	}
+	weight, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || weight < 0 {
+		return 0, fmt.Errorf("invalid weight %q for routing policy %s (expected non-negative integer)", value, policy.Type)
+	}
+	return weight, nil
+}
+
+// answerValue maps the rdata fields of an answer to the record value of the dns model.
+func answerValue(rtype string, answer []string) string {
+	switch rtype {
+	case dns.RS_TXT:
+		return raw.EnsureQuotedText(strings.Join(answer, ""))
+	case dns.RS_CNAME:
+		return dns.NormalizeHostname(strings.Join(answer, ""))
+	case dns.RS_SRV:
+		if len(answer) == 4 {
+			answer = append(answer[:3:3], dns.AlignHostname(answer[3]))
+		}
+	}
+	return strings.Join(answer, " ")
+}
+
+// answerFields maps a record value of the dns model to the rdata fields of an answer.
+func answerFields(rtype, value string) []string {
+	switch rtype {
+	case dns.RS_TXT:
+		chunks, err := dns.SplitTextValue(value)
+		if err != nil {
+			return []string{value}
+		}
+		return []string{strings.Join(chunks, "")}
+	case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME:
+		return []string{value}
+	}
+	return strings.Fields(value)
+}
+
+// answerSetIdentifier returns the set identifier carried in the note metadata of an answer.
+func answerSetIdentifier(a *Answer) string {
+	if note, ok := a.Meta[metaNote].(string); ok && strings.HasPrefix(note, setIdentifierNotePrefix) {
+		return note[len(setIdentifierNotePrefix):]
+	}
+	return ""
+}
+
+func answerWeight(a *Answer) string {
+	switch w := a.Meta[metaWeight].(type) {
+	case float64:
+		return strconv.FormatInt(int64(w), 10)
+	case string:
+		return w
+	}
+	return "0"
+}
+
+// buildRecordSets builds the record sets for a record. Answers with a set identifier in their
+// metadata result in a separate weighted record set for every set identifier.
+func buildRecordSets(r *Record) []*dns.RecordSet {
+	sets := map[string]*dns.RecordSet{}
+	var ids []string
+	for _, a := range r.Answers {
+		id := answerSetIdentifier(a)
+		rs := sets[id]
+		if rs == nil {
+			rs = dns.NewRecordSet(r.Type, r.TTL, nil)
+			if id != "" {
+				rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_WEIGHTED, map[string]string{weightParameter: answerWeight(a)})
+				rs.RoutingPolicy.SetIdentifier = id
+			}
+			sets[id] = rs
+			ids = append(ids, id)
+		}
+		rs.Add(&dns.Record{Value: answerValue(r.Type, a.Answer)})
+	}
+	result := make([]*dns.RecordSet, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, sets[id])
+	}
+	return result
+}
+
+// shortRecordSet builds the record set of a basic record from its short answers in the zone details.
+func shortRecordSet(r *ZoneRecord) *dns.RecordSet {
+	rs := dns.NewRecordSet(r.Type, r.TTL, nil)
+	for _, a := range r.ShortAnswers {
+		fields := strings.Fields(a)
+		if r.Type == dns.RS_TXT {
+			fields = []string{a}
+		}
+		rs.Add(&dns.Record{Value: answerValue(r.Type, fields)})
+	}
+	return rs
+}
+
+// buildAnswers builds the answers for the record sets of a record identified by their set identifier.
+// The metadata of existing answers with the same rdata and set identifier is preserved, so that
+// metadata maintained outside of the dns-controller-manager (e.g. for filters) is not lost.
+func buildAnswers(rtype string, items map[string]*dns.RecordSet, current *Record) []*Answer {
+	existing := map[string]*Answer{}
+	if current != nil {
+		for _, a := range current.Answers {
+			existing[answerSetIdentifier(a)+"/"+strings.Join(a.Answer, " ")] = a
+		}
+	}
+
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	answers := []*Answer{}
+	for _, id := range ids {
+		rs := items[id]
+		for _, r := range rs.Records {
+			fields := answerFields(rtype, r.Value)
+			a := &Answer{Answer: fields, Meta: map[string]interface{}{}}
+			if old := existing[id+"/"+strings.Join(fields, " ")]; old != nil {
+				for k, v := range old.Meta {
+					a.Meta[k] = v
+				}
+			}
+			if id != "" {
+				weight, _ := parseWeight(rs.RoutingPolicy)
+				a.Meta[metaWeight] = weight
+				a.Meta[metaNote] = setIdentifierNotePrefix + id
+			}
+			if len(a.Meta) == 0 {
+				a.Meta = nil
+			}
+			answers = append(answers, a)
+		}
+	}
+	return answers
+}
@@ -0,0 +1,290 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ns1
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const defaultEndpoint = "https://api.nsone.net/v1/"
+
+// Zone is a zone as returned by the zone list of the NS1 API.
+type Zone struct {
+	ID   string `json:"id"`
+	Zone string `json:"zone"`
+}
+
+// ZoneRecord is the short form of a record in the zone details of the NS1 API.
+type ZoneRecord struct {
+	ID           string   `json:"id"`
+	Domain       string   `json:"domain"`
+	Type         string   `json:"type"`
+	TTL          int64    `json:"ttl"`
+	Tier         int      `json:"tier"`
+	ShortAnswers []string `json:"short_answers"`
+}
+
+type zoneDetails struct {
+	Zone    string        `json:"zone"`
+	Records []*ZoneRecord `json:"records"`
+}
+
+// Answer is a single answer of a record with its optional metadata.
+type Answer struct {
+	Answer []string               `json:"answer"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Filter is an element of the filter chain of a record.
+type Filter struct {
+	Filter string                 `json:"filter"`
+	Config map[string]interface{} `json:"config"`
+}
+
+// Record is a record with all answers of a domain name and type.
+type Record struct {
+	Zone    string                 `json:"zone"`
+	Domain  string                 `json:"domain"`
+	Type    string                 `json:"type"`
+	TTL     int64                  `json:"ttl"`
+	Answers []*Answer              `json:"answers"`
+	Filters []*Filter              `json:"filters,omitempty"`
+	Meta    map[string]interface{} `json:"meta,omitempty"`
+}
+
+// APIError is an error response of the NS1 API.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is the delay until the rate limit of the account is replenished (only set for status 429)
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("ns1 api error %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound checks whether an error is an NS1 API error reporting a missing zone or record.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// classifyError maps rate limit responses to throttling errors.
+// The delay until the next attempt is returned if available.
+func classifyError(err error) (time.Duration, error) {
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		return 0, err
+	}
+	return apiErr.RetryAfter, perrs.NewThrottlingError(err)
+}
+
+type client struct {
+	endpoint    *url.URL
+	apiKey      string
+	http        *http.Client
+	metrics     provider.Metrics
+	rateLimiter flowcontrol.RateLimiter
+}
+
+func newClient(endpoint, apiKey string, metrics provider.Metrics, rateLimiter flowcontrol.RateLimiter) (*client, error) {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NS1 endpoint %q: %w", endpoint, err)
+	}
+	return &client{
+		endpoint:    u,
+		apiKey:      apiKey,
+		http:        &http.Client{Timeout: 60 * time.Second},
+		metrics:     metrics,
+		rateLimiter: rateLimiter,
+	}, nil
+}
+
+func (c *client) ListZones(ctx context.Context) ([]*Zone, error) {
+	var zones []*Zone
+	for next := "zones"; next != ""; {
+		c.metrics.AddGenericRequests(provider.M_LISTZONES, 1)
+		var page []*Zone
+		var err error
+		next, err = c.doPage(ctx, http.MethodGet, next, nil, &page)
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, page...)
+	}
+	return zones, nil
+}
+
+func (c *client) ListRecords(ctx context.Context, zone string) ([]*ZoneRecord, error) {
+	var records []*ZoneRecord
+	for next := "zones/" + url.PathEscape(zone); next != ""; {
+		c.metrics.AddZoneRequests(zone, provider.M_LISTRECORDS, 1)
+		details := &zoneDetails{}
+		var err error
+		next, err = c.doPage(ctx, http.MethodGet, next, nil, details)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, details.Records...)
+	}
+	return records, nil
+}
+
+func (c *client) GetRecord(ctx context.Context, zone, domain, rtype string) (*Record, error) {
+	c.metrics.AddZoneRequests(zone, provider.M_LISTRECORDS, 1)
+	record := &Record{}
+	err := c.do(ctx, http.MethodGet, recordPath(zone, domain, rtype), nil, record)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (c *client) CreateRecord(ctx context.Context, record *Record) error {
+	c.metrics.AddZoneRequests(record.Zone, provider.M_CREATERECORDS, 1)
+	return c.do(ctx, http.MethodPut, recordPath(record.Zone, record.Domain, record.Type), record, nil)
+}
+
+func (c *client) UpdateRecord(ctx context.Context, record *Record) error {
+	c.metrics.AddZoneRequests(record.Zone, provider.M_UPDATERECORDS, 1)
+	return c.do(ctx, http.MethodPost, recordPath(record.Zone, record.Domain, record.Type), record, nil)
+}
+
+func (c *client) DeleteRecord(ctx context.Context, zone, domain, rtype string) error {
+	c.metrics.AddZoneRequests(zone, provider.M_DELETERECORDS, 1)
+	return c.do(ctx, http.MethodDelete, recordPath(zone, domain, rtype), nil, nil)
+}
+
+func recordPath(zone, domain, rtype string) string {
+	return fmt.Sprintf("zones/%s/%s/%s", url.PathEscape(zone), url.PathEscape(domain), url.PathEscape(rtype))
+}
+
+func (c *client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	_, err := c.doPage(ctx, method, path, in, out)
+	return err
+}
+
+// doPage executes a request and returns the URL of the next page for paginated responses.
+func (c *client) doPage(ctx context.Context, method, path string, in, out interface{}) (string, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return "", err
+		}
+		body = bytes.NewReader(data)
+	}
+	u, err := c.endpoint.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	if u.Host != c.endpoint.Host {
+		return "", fmt.Errorf("unexpected host of NS1 API URL %q", u)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-NSONE-Key", c.apiKey)
+	req.Header.Set("User-Agent", "external-dns-manager")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.rateLimiter.Accept()
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(data))}
+		msg := struct {
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(data, &msg) == nil && msg.Message != "" {
+			apiErr.Message = msg.Message
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			apiErr.RetryAfter = retryAfter(resp.Header)
+		}
+		return "", apiErr
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return "", err
+		}
+	}
+	return nextLink(resp.Header), nil
+}
+
+// nextLink returns the URL of the next page announced in the Link header of a paginated response.
+func nextLink(header http.Header) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		for _, param := range parts[1:] {
+			if strings.ReplaceAll(strings.TrimSpace(param), " ", "") == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// retryAfter returns the delay requested by the header `Retry-After`. Otherwise it is estimated from the
+// rate limit headers, NS1 replenishes the limit of X-Ratelimit-Limit requests continuously over X-Ratelimit-Period seconds.
+func retryAfter(header http.Header) time.Duration {
+	if d := sdk.RetryAfter(header); d > 0 {
+		return d
+	}
+	limit, err := strconv.Atoi(header.Get("X-Ratelimit-Limit"))
+	if err != nil || limit <= 0 {
+		return 0
+	}
+	period, err := strconv.Atoi(header.Get("X-Ratelimit-Period"))
+	if err != nil || period <= 0 {
+		return 0
+	}
+	return time.Duration(period) * time.Second / time.Duration(limit)
+}
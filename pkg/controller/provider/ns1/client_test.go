@@ -0,0 +1,299 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ns1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+const testPageSize = 2
+
+type testServer struct {
+	url       string
+	zones     []*Zone
+	details   map[string][]*ZoneRecord
+	records   map[string]*Record
+	throttled bool
+	requests  []string
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+	if r.Header.Get("X-NSONE-Key") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+		return
+	}
+	if s.throttled {
+		w.Header().Set("X-Ratelimit-Limit", "10")
+		w.Header().Set("X-Ratelimit-Period", "20")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"message":"rate limit exceeded"}`))
+		return
+	}
+	after, _ := strconv.Atoi(r.URL.Query().Get("after"))
+	path := strings.TrimPrefix(r.URL.Path, "/v1/")
+	parts := strings.Split(path, "/")
+	switch {
+	case path == "zones":
+		s.page(w, r, after, len(s.zones))
+		writeJSON(w, s.zones[after:end(after, len(s.zones))])
+	case len(parts) == 2 && s.details[parts[1]] != nil:
+		records := s.details[parts[1]]
+		s.page(w, r, after, len(records))
+		writeJSON(w, &zoneDetails{Zone: parts[1], Records: records[after:end(after, len(records))]})
+	case len(parts) == 4:
+		switch r.Method {
+		case http.MethodGet:
+			if record := s.records[path]; record != nil {
+				writeJSON(w, record)
+				return
+			}
+		case http.MethodPut, http.MethodPost:
+			record := &Record{}
+			if err := json.NewDecoder(r.Body).Decode(record); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.records[path] = record
+			writeJSON(w, record)
+			return
+		case http.MethodDelete:
+			if s.records[path] != nil {
+				delete(s.records, path)
+				return
+			}
+		}
+		fallthrough
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"record not found"}`))
+	}
+}
+
+// page announces the next page in the Link header if there are more elements after the current page.
+func (s *testServer) page(w http.ResponseWriter, r *http.Request, after, length int) {
+	if next := end(after, length); next < length {
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?after=%d>; rel="next"`, s.url, r.URL.Path, next))
+	}
+}
+
+func end(after, length int) int {
+	if after+testPageSize > length {
+		return length
+	}
+	return after + testPageSize
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestHandler(t *testing.T, s *testServer, apiKey string) *Handler {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	s.url = server.URL
+	c, err := newClient(server.URL+"/v1", apiKey, &provider.NullMetrics{}, flowcontrol.NewFakeAlwaysRateLimiter())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		client:            c,
+		config: provider.DNSHandlerConfig{
+			Logger:  logger.New(),
+			Options: &provider.FactoryOptions{},
+		},
+	}
+	h.cache, err = provider.NewTestZoneCacheFactory(time.Minute, 0).CreateZoneCache(provider.CacheZoneState, &provider.NullMetrics{}, h.getZones, h.getZoneState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestListZonesWithPagination(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{details: map[string][]*ZoneRecord{}}
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("z%d.example.com", i)
+		s.zones = append(s.zones, &Zone{ID: strconv.Itoa(i), Zone: name})
+		s.details[name] = []*ZoneRecord{}
+	}
+	h := newTestHandler(t, s, "secret")
+
+	zones, err := h.client.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(zones).To(HaveLen(5))
+	Expect(zones[4].Zone).To(Equal("z4.example.com"))
+	Expect(s.requests).To(Equal([]string{"GET /v1/zones", "GET /v1/zones?after=2", "GET /v1/zones?after=4"}))
+
+	hostedZones, err := h.GetZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(hostedZones).To(HaveLen(5))
+	Expect(hostedZones[0].Id()).To(Equal(dns.NewZoneID(TYPE_CODE, "z0.example.com")))
+	Expect(hostedZones[0].Domain()).To(Equal("z0.example.com"))
+}
+
+func TestZoneStateMapping(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{
+		zones: []*Zone{{ID: "1", Zone: "example.com"}},
+		details: map[string][]*ZoneRecord{"example.com": {
+			{Domain: "example.com", Type: "NS", TTL: 3600, Tier: 1, ShortAnswers: []string{"dns1.p01.nsone.net"}},
+			{Domain: "sub.example.com", Type: "NS", TTL: 3600, Tier: 1, ShortAnswers: []string{"ns.other.net"}},
+			{Domain: "a.example.com", Type: "A", TTL: 300, Tier: 1, ShortAnswers: []string{"1.2.3.4", "1.2.3.5"}},
+			{Domain: "txt.example.com", Type: "TXT", TTL: 600, Tier: 1, ShortAnswers: []string{"hello world"}},
+			{Domain: "srv.example.com", Type: "SRV", TTL: 300, Tier: 1, ShortAnswers: []string{"10 5 443 target.example.com"}},
+			{Domain: "w.example.com", Type: "CNAME", TTL: 60, Tier: 3},
+		}},
+		records: map[string]*Record{
+			"zones/example.com/w.example.com/CNAME": {Zone: "example.com", Domain: "w.example.com", Type: "CNAME", TTL: 60,
+				Answers: []*Answer{
+					{Answer: []string{"blue.example.com"}, Meta: map[string]interface{}{metaWeight: 10, metaNote: setIdentifierNotePrefix + "blue"}},
+					{Answer: []string{"green.example.com"}, Meta: map[string]interface{}{metaWeight: 0, metaNote: setIdentifierNotePrefix + "green"}},
+				},
+				Filters: weightedFilters,
+			},
+		},
+	}
+	h := newTestHandler(t, s, "secret")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.com", "example.com", "example.com", nil, false)
+
+	state, err := h.GetZoneState(context.Background(), zone)
+	Expect(err).To(BeNil())
+	sets := state.GetDNSSets()
+	Expect(sets).To(HaveLen(5))
+	Expect(sets).NotTo(HaveKey("sub.example.com"))
+	Expect(h.cache.ForwardedDomainsCache().Get(zone.Id())).To(Equal([]string{"sub.example.com"}))
+
+	a := sets["a.example.com"].Sets[dns.RS_A]
+	Expect(a.TTL).To(Equal(int64(300)))
+	Expect(a.RecordString()).To(ContainSubstring("1.2.3.5"))
+	Expect(sets["txt.example.com"].Sets[dns.RS_TXT].Records[0].Value).To(Equal("\"hello world\""))
+	Expect(sets["srv.example.com"].Sets[dns.RS_SRV].Records[0].Value).To(Equal("10 5 443 target.example.com."))
+
+	blue := sets[dns.DNSSetKey("w.example.com", "blue")].Sets[dns.RS_CNAME]
+	Expect(blue.Records[0].Value).To(Equal("blue.example.com"))
+	Expect(blue.RoutingPolicy.Parameters).To(Equal(map[string]string{weightParameter: "10"}))
+	green := sets[dns.DNSSetKey("w.example.com", "green")].Sets[dns.RS_CNAME]
+	Expect(green.RoutingPolicy.Parameters).To(Equal(map[string]string{weightParameter: "0"}))
+}
+
+type testDoneHandler struct {
+	err       error
+	throttled bool
+	succeeded bool
+}
+
+func (d *testDoneHandler) SetInvalid(err error)                    { d.err = err }
+func (d *testDoneHandler) Failed(err error)                        { d.err = err }
+func (d *testDoneHandler) Throttled(retryAfter time.Duration)      { d.throttled = true }
+func (d *testDoneHandler) Succeeded()                              { d.succeeded = true }
+func (d *testDoneHandler) Blocked(reason string, planned []string) {}
+
+func weightedSet(name, id, target string, weight int) *dns.DNSSet {
+	rs := dns.NewRecordSet(dns.RS_CNAME, 120, []*dns.Record{{Value: target}})
+	rs.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_WEIGHTED, map[string]string{weightParameter: strconv.Itoa(weight)})
+	rs.RoutingPolicy.SetIdentifier = id
+	set := dns.NewDNSSet(name)
+	set.SetIdentifier = id
+	set.Sets[dns.RS_CNAME] = rs
+	return set
+}
+
+func TestExecuteRequests(t *testing.T) {
+	RegisterTestingT(t)
+
+	path := "zones/example.com/w.example.com/CNAME"
+	s := &testServer{records: map[string]*Record{
+		path: {Zone: "example.com", Domain: "w.example.com", Type: "CNAME", TTL: 60,
+			Answers: []*Answer{{Answer: []string{"blue.example.com"}, Meta: map[string]interface{}{metaWeight: 10, metaNote: setIdentifierNotePrefix + "blue", "up": true}}},
+			Filters: []*Filter{{Filter: "up", Config: map[string]interface{}{}}},
+		},
+	}}
+	h := newTestHandler(t, s, "secret")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.com", "example.com", "example.com", nil, false)
+
+	added, updated := &testDoneHandler{}, &testDoneHandler{}
+	reqs := []*provider.ChangeRequest{
+		provider.NewChangeRequest(provider.R_CREATE, dns.RS_CNAME, nil, weightedSet("w.example.com", "green", "green.example.com", 5), added),
+		provider.NewChangeRequest(provider.R_UPDATE, dns.RS_CNAME, weightedSet("w.example.com", "blue", "blue.example.com", 10), weightedSet("w.example.com", "blue", "blue.example.com", 20), updated),
+	}
+	Expect(h.executeRequests(context.Background(), logger.New(), zone, nil, reqs)).To(Succeed())
+	Expect(added.succeeded && updated.succeeded).To(BeTrue())
+
+	record := s.records[path]
+	Expect(record.TTL).To(Equal(int64(120)))
+	Expect(record.Filters).To(Equal([]*Filter{{Filter: "up", Config: map[string]interface{}{}}}))
+	Expect(record.Answers).To(HaveLen(2))
+	Expect(record.Answers[0].Answer).To(Equal([]string{"blue.example.com"}))
+	Expect(record.Answers[0].Meta).To(Equal(map[string]interface{}{metaWeight: float64(20), metaNote: setIdentifierNotePrefix + "blue", "up": true}))
+	Expect(record.Answers[1].Meta).To(Equal(map[string]interface{}{metaWeight: float64(5), metaNote: setIdentifierNotePrefix + "green"}))
+
+	deleted := &testDoneHandler{}
+	reqs = []*provider.ChangeRequest{
+		provider.NewChangeRequest(provider.R_DELETE, dns.RS_CNAME, weightedSet("w.example.com", "blue", "blue.example.com", 20), nil, deleted),
+		provider.NewChangeRequest(provider.R_DELETE, dns.RS_CNAME, weightedSet("w.example.com", "green", "green.example.com", 5), nil, deleted),
+	}
+	Expect(h.executeRequests(context.Background(), logger.New(), zone, nil, reqs)).To(Succeed())
+	Expect(deleted.succeeded).To(BeTrue())
+	Expect(s.records).NotTo(HaveKey(path))
+	Expect(s.requests[len(s.requests)-1]).To(Equal("DELETE /v1/" + path))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	h := newTestHandler(t, &testServer{}, "invalid")
+	_, err := h.client.ListZones(context.Background())
+	Expect(err).To(MatchError(ContainSubstring("Unauthorized")))
+	Expect(h.ClassifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+
+	h = newTestHandler(t, &testServer{throttled: true}, "secret")
+	_, err = h.client.ListRecords(context.Background(), "example.com")
+	retryAfter, err := classifyError(err)
+	Expect(perrs.IsThrottlingError(err)).To(BeTrue())
+	Expect(retryAfter).To(Equal(2 * time.Second))
+
+	_, err = h.client.GetRecord(context.Background(), "example.com", "a.example.com", "A")
+	Expect(err).To(HaveOccurred())
+
+	Expect(nextLink(http.Header{"Link": []string{`<https://api.nsone.net/v1/zones?after=b>; rel="next", <https://api.nsone.net/v1/zones>; rel="first"`}})).
+		To(Equal("https://api.nsone.net/v1/zones?after=b"))
+	_, err = h.client.doPage(context.Background(), http.MethodGet, "https://other.example.com/v1/zones", nil, nil)
+	Expect(err).To(MatchError(ContainSubstring("unexpected host")))
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ns1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// itemChange is the desired record set for a set identifier of a record, nil for a deletion.
+type itemChange struct {
+	setIdentifier string
+	rs            *dns.RecordSet
+}
+
+// recordChange collects the changes of all record sets of a domain name and type.
+// NS1 maintains all answers of a domain name and type in a single record, so the
+// changes are merged into a replacement of the answers of the complete record.
+type recordChange struct {
+	domain  string
+	rtype   string
+	changes []itemChange
+	done    []provider.DoneHandler
+}
+
+type execution struct {
+	logger.LogContext
+	ctx     context.Context
+	handler *Handler
+	zone    provider.DNSHostedZone
+
+	records map[string]*recordChange
+	keys    []string
+}
+
+func newExecution(ctx context.Context, logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *execution {
+	return &execution{
+		LogContext: logger,
+		ctx:        ctx,
+		handler:    h,
+		zone:       zone,
+		records:    map[string]*recordChange{},
+	}
+}
+
+func (this *execution) addChange(req *provider.ChangeRequest) {
+	var name string
+	var newset, oldset *dns.RecordSet
+
+	if req.Addition != nil {
+		name, newset = dns.MapToProvider(req.Type, req.Addition, this.zone.Domain())
+	}
+	if req.Deletion != nil {
+		name, oldset = dns.MapToProvider(req.Type, req.Deletion, this.zone.Domain())
+	}
+	if name == "" || (newset.Length() == 0 && oldset.Length() == 0) {
+		return
+	}
+
+	var change itemChange
+	var rtype string
+	switch req.Action {
+	case provider.R_CREATE, provider.R_UPDATE:
+		rtype = newset.Type
+		change = itemChange{setIdentifier: setIdentifier(newset), rs: newset}
+		this.Infof("%s %s record set %s[%s]: %s(%d)", req.Action, req.Type, name, this.zone.Id(), newset.RecordString(), newset.TTL)
+	case provider.R_DELETE:
+		rtype = oldset.Type
+		change = itemChange{setIdentifier: setIdentifier(oldset)}
+		this.Infof("%s %s record set %s[%s]: %s", req.Action, req.Type, name, this.zone.Id(), oldset.RecordString())
+	default:
+		return
+	}
+
+	key := name + "/" + rtype
+	rc := this.records[key]
+	if rc == nil {
+		rc = &recordChange{domain: name, rtype: rtype}
+		this.records[key] = rc
+		this.keys = append(this.keys, key)
+	}
+	rc.changes = append(rc.changes, change)
+	rc.done = append(rc.done, req.Done)
+}
+
+func setIdentifier(rs *dns.RecordSet) string {
+	if rs.RoutingPolicy != nil {
+		return rs.RoutingPolicy.SetIdentifier
+	}
+	return ""
+}
+
+func (this *execution) submitChanges() error {
+	failed, throttled := 0, 0
+	for _, key := range this.keys {
+		rc := this.records[key]
+		err := this.submitRecordChange(rc)
+		var retryAfter time.Duration
+		retryAfter, err = classifyError(err)
+		for _, d := range rc.done {
+			if d == nil {
+				continue
+			}
+			switch {
+			case err == nil:
+				d.Succeeded()
+			case perrs.IsThrottlingError(err):
+				d.Throttled(retryAfter)
+			default:
+				d.Failed(err)
+			}
+		}
+		if err != nil {
+			this.Errorf("changing %s %s in zone %s failed: %s", rc.rtype, rc.domain, this.zone.Id(), err)
+			failed++
+			if perrs.IsThrottlingError(err) {
+				throttled++
+			}
+		}
+	}
+	if failed > 0 {
+		err := fmt.Errorf("%d of %d record changes failed", failed, len(this.keys))
+		if throttled == failed {
+			err = perrs.NewThrottlingError(err)
+		}
+		return err
+	}
+	if len(this.keys) > 0 {
+		this.Infof("%d records in zone %s were successfully updated", len(this.keys), this.zone.Id())
+	}
+	return nil
+}
+
+func (this *execution) submitRecordChange(rc *recordChange) error {
+	client := this.handler.client
+	current, err := client.GetRecord(this.ctx, this.zone.Key(), rc.domain, rc.rtype)
+	if err != nil {
+		if !IsNotFound(err) {
+			return err
+		}
+		current = nil
+	}
+
+	items := map[string]*dns.RecordSet{}
+	var ttl int64
+	if current != nil {
+		ttl = current.TTL
+		for _, rs := range buildRecordSets(current) {
+			items[setIdentifier(rs)] = rs
+		}
+	}
+	for _, c := range rc.changes {
+		if c.rs == nil {
+			delete(items, c.setIdentifier)
+			continue
+		}
+		items[c.setIdentifier] = c.rs
+		// NS1 supports only a single TTL for all answers of a record
+		if c.rs.TTL > 0 {
+			ttl = c.rs.TTL
+		}
+	}
+
+	answers := buildAnswers(rc.rtype, items, current)
+	if len(answers) == 0 {
+		if current == nil {
+			return nil
+		}
+		this.Infof("deleting record %s %s in zone %s", rc.rtype, rc.domain, this.zone.Id())
+		return client.DeleteRecord(this.ctx, this.zone.Key(), rc.domain, rc.rtype)
+	}
+
+	record := &Record{
+		Zone:    this.zone.Key(),
+		Domain:  rc.domain,
+		Type:    rc.rtype,
+		TTL:     ttl,
+		Answers: answers,
+	}
+	if current != nil {
+		record.Meta = current.Meta
+		record.Filters = current.Filters
+	}
+	if _, plain := items[""]; !plain && len(items) > 0 && len(record.Filters) == 0 {
+		record.Filters = weightedFilters
+	}
+	if current == nil {
+		this.Infof("creating record %s %s in zone %s with %d answer(s)", rc.rtype, rc.domain, this.zone.Id(), len(answers))
+		return client.CreateRecord(this.ctx, record)
+	}
+	this.Infof("updating record %s %s in zone %s with %d answer(s)", rc.rtype, rc.domain, this.zone.Id(), len(answers))
+	return client.UpdateRecord(this.ctx, record)
+}
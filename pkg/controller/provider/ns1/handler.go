@@ -0,0 +1,187 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ns1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type Handler struct {
+	provider.DefaultDNSHandler
+	config provider.DNSHandlerConfig
+	cache  provider.ZoneCache
+	client *client
+}
+
+var _ provider.DNSHandler = &Handler{}
+var _ provider.RoutingPolicySupport = &Handler{}
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	h := &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
+		config:            *c,
+	}
+
+	apiKey, err := c.GetRequiredProperty("NS1_API_KEY", "apiKey")
+	if err != nil {
+		return nil, err
+	}
+	endpoint := c.GetProperty("NS1_ENDPOINT", "endpoint")
+
+	h.client, err = newClient(endpoint, apiKey, c.Metrics, c.RateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	h.cache, err = c.ZoneCacheFactory.CreateZoneCache(provider.CacheZoneState, c.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+func (h *Handler) Release() {
+	h.cache.Release()
+}
+
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
+}
+
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
+
+	raw, err := h.client.ListZones(ctx)
+	if err != nil {
+		_, err = classifyError(err)
+		return nil, err
+	}
+
+	zones := provider.DNSHostedZones{}
+	for _, z := range raw {
+		// NS1 addresses zones by their name
+		if blockedZones.Contains(z.Zone) {
+			h.config.Logger.Infof("ignoring blocked zone id: %s", z.Zone)
+			continue
+		}
+		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.Zone, dns.NormalizeHostname(z.Zone), z.Zone, []string{}, false)
+
+		// call GetZoneState for side effect to calculate forwarded domains
+		_, err := cache.GetZoneState(ctx, hostedZone)
+		if err == nil {
+			forwarded := cache.ForwardedDomainsCache().Get(hostedZone.Id())
+			if forwarded != nil {
+				hostedZone = provider.CopyDNSHostedZone(hostedZone, forwarded)
+			}
+		}
+		zones = append(zones, hostedZone)
+	}
+	return zones, nil
+}
+
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
+}
+
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+	records, err := h.client.ListRecords(ctx, zone.Key())
+	if err != nil {
+		_, err = classifyError(err)
+		return nil, err
+	}
+
+	dnssets := dns.DNSSets{}
+	forwarded := []string{}
+	for _, r := range records {
+		name := dns.NormalizeHostname(r.Domain)
+		if r.Type == dns.RS_NS && name != zone.Domain() {
+			forwarded = append(forwarded, name)
+		}
+		if !h.SupportsRecordType(r.Type) {
+			continue
+		}
+		if r.Tier <= 1 {
+			// basic records have no metadata, the short answers are sufficient
+			dnssets.AddRecordSetFromProvider(name, shortRecordSet(r))
+			continue
+		}
+		full, err := h.client.GetRecord(ctx, zone.Key(), r.Domain, r.Type)
+		if err != nil {
+			_, err = classifyError(err)
+			return nil, err
+		}
+		for _, rs := range buildRecordSets(full) {
+			dnssets.AddRecordSetFromProvider(name, rs)
+		}
+	}
+	cache.ForwardedDomainsCache().Set(zone.Id(), forwarded)
+
+	return provider.NewDNSZoneState(dnssets), nil
+}
+
+func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
+	return h.cache.ReportZoneStateConflict(zone, err)
+}
+
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
+	h.cache.ApplyRequests(logger, err, zone, reqs)
+	return err
+}
+
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := newExecution(ctx, logger, h, zone)
+	for _, r := range reqs {
+		exec.addChange(r)
+	}
+	if h.config.DryRun {
+		logger.Infof("no changes in dryrun mode for NS1")
+		return nil
+	}
+	return exec.submitChanges()
+}
+
+func (h *Handler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return validateRoutingPolicy(policy)
+}
+
+func (h *Handler) SupportedRoutingPolicyTypes() []string {
+	return []string{dns.RP_WEIGHTED}
+}
+
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	if rtype == dns.RS_SRV {
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return perrs.ReasonForHTTPStatus(apiErr.StatusCode)
+	}
+	return perrs.ReasonUnknown
+}
@@ -17,6 +17,7 @@
 package openstack
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -37,22 +38,22 @@ import (
 // interface between provider and OpenStack DNS API
 type designateClientInterface interface {
 	// ForEachZone calls handler for each zone managed by the Designate
-	ForEachZone(handler func(zone *zones.Zone) error) error
+	ForEachZone(ctx context.Context, handler func(zone *zones.Zone) error) error
 
 	// ForEachRecordSet calls handler for each recordset in the given DNS zone
-	ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error
+	ForEachRecordSet(ctx context.Context, zoneID string, handler func(recordSet *recordsets.RecordSet) error) error
 
 	// ForEachRecordSet calls handler for each recordset in the given DNS zone restricted to rrtype
-	ForEachRecordSetFilterByTypeAndName(zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error
+	ForEachRecordSetFilterByTypeAndName(ctx context.Context, zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error
 
 	// CreateRecordSet creates recordset in the given DNS zone
-	CreateRecordSet(zoneID string, opts recordsets.CreateOpts) (string, error)
+	CreateRecordSet(ctx context.Context, zoneID string, opts recordsets.CreateOpts) (string, error)
 
 	// UpdateRecordSet updates recordset in the given DNS zone
-	UpdateRecordSet(zoneID, recordSetID string, opts recordsets.UpdateOpts) error
+	UpdateRecordSet(ctx context.Context, zoneID, recordSetID string, opts recordsets.UpdateOpts) error
 
 	// DeleteRecordSet deletes recordset in the given DNS zone
-	DeleteRecordSet(zoneID, recordSetID string) error
+	DeleteRecordSet(ctx context.Context, zoneID, recordSetID string) error
 }
 
 // implementation of the designateClientInterface
@@ -142,9 +143,33 @@ func createDesignateServiceClient(logger logger.LogContext, clientAuthConfig *cl
 	return client, nil
 }
 
+// withContext returns a service client whose requests are bound to ctx.
+// The provider client is copied, as gophercloud only supports a client wide
+// context. Reauthentication is delegated to the shared provider client, so
+// that the token obtained is used by all copies.
+func (c designateClient) withContext(ctx context.Context) *gophercloud.ServiceClient {
+	original := c.serviceClient.ProviderClient
+	pc := *original
+	pc.Context = ctx
+	if original.ReauthFunc != nil {
+		pc.ReauthFunc = func() error {
+			if original.Token() == pc.Token() {
+				if err := original.ReauthFunc(); err != nil {
+					return err
+				}
+			}
+			pc.CopyTokenFrom(original)
+			return nil
+		}
+	}
+	sc := *c.serviceClient
+	sc.ProviderClient = &pc
+	return &sc
+}
+
 // ForEachZone calls handler for each zone managed by the Designate
-func (c designateClient) ForEachZone(handler func(zone *zones.Zone) error) error {
-	pager := zones.List(c.serviceClient, zones.ListOpts{})
+func (c designateClient) ForEachZone(ctx context.Context, handler func(zone *zones.Zone) error) error {
+	pager := zones.List(c.withContext(ctx), zones.ListOpts{})
 	rt := provider.M_LISTZONES
 	return pager.EachPage(
 		func(page pagination.Page) (bool, error) {
@@ -166,13 +191,13 @@ func (c designateClient) ForEachZone(handler func(zone *zones.Zone) error) error
 }
 
 // ForEachRecordSet calls handler for each recordset in the given DNS zone
-func (c designateClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
-	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
+func (c designateClient) ForEachRecordSet(ctx context.Context, zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
+	return c.ForEachRecordSetFilterByTypeAndName(ctx, zoneID, "", "", handler)
 }
 
 // ForEachRecordSet calls handler for each recordset in the given DNS zone restricted to rrtype
-func (c designateClient) ForEachRecordSetFilterByTypeAndName(zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error {
-	pager := recordsets.ListByZone(c.serviceClient, zoneID, recordsets.ListOpts{Type: rrtype, Name: name})
+func (c designateClient) ForEachRecordSetFilterByTypeAndName(ctx context.Context, zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error {
+	pager := recordsets.ListByZone(c.withContext(ctx), zoneID, recordsets.ListOpts{Type: rrtype, Name: name})
 	rt := provider.M_LISTRECORDS
 	return pager.EachPage(
 		func(page pagination.Page) (bool, error) {
@@ -194,8 +219,8 @@ func (c designateClient) ForEachRecordSetFilterByTypeAndName(zoneID string, rrty
 }
 
 // CreateRecordSet creates recordset in the given DNS zone
-func (c designateClient) CreateRecordSet(zoneID string, opts recordsets.CreateOpts) (string, error) {
-	r, err := recordsets.Create(c.serviceClient, zoneID, opts).Extract()
+func (c designateClient) CreateRecordSet(ctx context.Context, zoneID string, opts recordsets.CreateOpts) (string, error) {
+	r, err := recordsets.Create(c.withContext(ctx), zoneID, opts).Extract()
 	c.metrics.AddZoneRequests(zoneID, provider.M_CREATERECORDS, 1)
 	if err != nil {
 		return "", err
@@ -204,15 +229,15 @@ func (c designateClient) CreateRecordSet(zoneID string, opts recordsets.CreateOp
 }
 
 // UpdateRecordSet updates recordset in the given DNS zone
-func (c designateClient) UpdateRecordSet(zoneID, recordSetID string, opts recordsets.UpdateOpts) error {
-	_, err := recordsets.Update(c.serviceClient, zoneID, recordSetID, opts).Extract()
+func (c designateClient) UpdateRecordSet(ctx context.Context, zoneID, recordSetID string, opts recordsets.UpdateOpts) error {
+	_, err := recordsets.Update(c.withContext(ctx), zoneID, recordSetID, opts).Extract()
 	c.metrics.AddZoneRequests(zoneID, provider.M_UPDATERECORDS, 1)
 	return err
 }
 
 // DeleteRecordSet deletes recordset in the given DNS zone
-func (c designateClient) DeleteRecordSet(zoneID, recordSetID string) error {
-	err := recordsets.Delete(c.serviceClient, zoneID, recordSetID).ExtractErr()
+func (c designateClient) DeleteRecordSet(ctx context.Context, zoneID, recordSetID string) error {
+	err := recordsets.Delete(c.withContext(ctx), zoneID, recordSetID).ExtractErr()
 	c.metrics.AddZoneRequests(zoneID, provider.M_DELETERECORDS, 1)
 	return err
 }
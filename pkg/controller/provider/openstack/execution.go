@@ -17,6 +17,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -33,14 +34,15 @@ type Change struct {
 
 type Execution struct {
 	logger.LogContext
+	ctx     context.Context
 	handler *Handler
 	zone    provider.DNSHostedZone
 
 	changes map[string][]*Change
 }
 
-func NewExecution(logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
-	return &Execution{LogContext: logger, handler: h, zone: zone, changes: map[string][]*Change{}}
+func NewExecution(ctx context.Context, logger logger.LogContext, h *Handler, zone provider.DNSHostedZone) *Execution {
+	return &Execution{LogContext: logger, ctx: ctx, handler: h, zone: zone, changes: map[string][]*Change{}}
 }
 
 type buildStatus int
@@ -79,8 +81,14 @@ func (exec *Execution) buildMappedRecordSet(name string, rset *dns.RecordSet) (b
 
 	for _, r := range rset.Records {
 		value := r.Value
-		if rset.Type == dns.RS_CNAME {
+		switch rset.Type {
+		case dns.RS_CNAME:
 			value = dns.AlignHostname(value)
+		case dns.RS_CAA:
+			// Designate expects the property value unquoted
+			if flags, tag, caaValue, err := dns.ParseCAAValue(value); err == nil {
+				value = fmt.Sprintf("%d %s %s", flags, tag, caaValue)
+			}
 		}
 		osRSet.Records = append(osRSet.Records, value)
 	}
@@ -109,7 +117,7 @@ func (exec *Execution) create(rset *recordsets.RecordSet) error {
 		Records: rset.Records,
 	}
 	exec.handler.config.RateLimiter.Accept()
-	_, err := exec.handler.client.CreateRecordSet(exec.zone.Id().ID, opts)
+	_, err := exec.handler.client.CreateRecordSet(exec.ctx, exec.zone.Id().ID, opts)
 	return err
 }
 
@@ -120,7 +128,7 @@ func (exec *Execution) lookupRecordSetID(rset *recordsets.RecordSet) (string, er
 		return nil
 	}
 	exec.handler.config.RateLimiter.Accept()
-	err := exec.handler.client.ForEachRecordSetFilterByTypeAndName(exec.zone.Id().ID, rset.Type, dns.AlignHostname(rset.Name), handler)
+	err := exec.handler.client.ForEachRecordSetFilterByTypeAndName(exec.ctx, exec.zone.Id().ID, rset.Type, dns.AlignHostname(rset.Name), handler)
 	if err != nil {
 		return "", fmt.Errorf("RecordSet lookup for %s %s failed with: %s", rset.Type, rset.Name, err)
 	}
@@ -141,7 +149,7 @@ func (exec *Execution) update(rset *recordsets.RecordSet) error {
 		Records: rset.Records,
 	}
 	exec.handler.config.RateLimiter.Accept()
-	err = exec.handler.client.UpdateRecordSet(exec.zone.Id().ID, recordSetID, opts)
+	err = exec.handler.client.UpdateRecordSet(exec.ctx, exec.zone.Id().ID, recordSetID, opts)
 	return err
 }
 
@@ -151,6 +159,6 @@ func (exec *Execution) delete(rset *recordsets.RecordSet) error {
 		return err
 	}
 	exec.handler.config.RateLimiter.Accept()
-	err = exec.handler.client.DeleteRecordSet(exec.zone.Id().ID, recordSetID)
+	err = exec.handler.client.DeleteRecordSet(exec.ctx, exec.zone.Id().ID, recordSetID)
 	return err
 }
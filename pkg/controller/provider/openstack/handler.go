@@ -18,16 +18,19 @@ package openstack
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 )
 
 // Handler is the main DNSHandler struct.
@@ -147,11 +150,11 @@ func (h *Handler) Release() {
 }
 
 // GetZones returns a list of hosted zones from the cache.
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
 	hostedZones := provider.DNSHostedZones{}
 
@@ -161,7 +164,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 			return nil
 		}
 
-		forwarded := h.collectForwardedSubzones(zone)
+		forwarded := h.collectForwardedSubzones(ctx, zone)
 
 		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zone.ID, dns.NormalizeHostname(zone.Name), "", forwarded, false)
 		hostedZones = append(hostedZones, hostedZone)
@@ -169,14 +172,14 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	}
 
 	h.config.RateLimiter.Accept()
-	if err := h.client.ForEachZone(zoneHandler); err != nil {
+	if err := h.client.ForEachZone(ctx, zoneHandler); err != nil {
 		return nil, fmt.Errorf("listing DNS zones failed. Details: %s", err.Error())
 	}
 
 	return hostedZones, nil
 }
 
-func (h *Handler) collectForwardedSubzones(zone *zones.Zone) []string {
+func (h *Handler) collectForwardedSubzones(ctx context.Context, zone *zones.Zone) []string {
 	forwarded := []string{}
 
 	recordSetHandler := func(recordSet *recordsets.RecordSet) error {
@@ -188,7 +191,7 @@ func (h *Handler) collectForwardedSubzones(zone *zones.Zone) []string {
 	}
 
 	h.config.RateLimiter.Accept()
-	if err := h.client.ForEachRecordSetFilterByTypeAndName(zone.ID, "NS", "", recordSetHandler); err != nil {
+	if err := h.client.ForEachRecordSetFilterByTypeAndName(ctx, zone.ID, "NS", "", recordSetHandler); err != nil {
 		logger.Infof("Failed fetching NS records for %s: %s", zone.Name, err.Error())
 		// just ignoring it
 		return forwarded
@@ -198,21 +201,24 @@ func (h *Handler) collectForwardedSubzones(zone *zones.Zone) []string {
 }
 
 // GetZoneState returns the state for a given zone.
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	dnssets := dns.DNSSets{}
 
 	recordSetHandler := func(recordSet *recordsets.RecordSet) error {
 		switch recordSet.Type {
-		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT:
+		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT, dns.RS_SRV, dns.RS_SSHFP, dns.RS_NAPTR, dns.RS_CAA:
 			rs := dns.NewRecordSet(recordSet.Type, int64(recordSet.TTL), nil)
 			for _, record := range recordSet.Records {
 				value := record
-				if recordSet.Type == dns.RS_CNAME {
+				switch recordSet.Type {
+				case dns.RS_CNAME:
 					value = dns.NormalizeHostname(value)
+				case dns.RS_CAA:
+					value = dns.NormalizeCAAValue(value)
 				}
 				rs.Add(&dns.Record{Value: value})
 			}
@@ -222,26 +228,43 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	}
 
 	h.config.RateLimiter.Accept()
-	if err := h.client.ForEachRecordSet(zone.Id().ID, recordSetHandler); err != nil {
+	if err := h.client.ForEachRecordSet(ctx, zone.Id().ID, recordSetHandler); err != nil {
 		return nil, fmt.Errorf("Listing DNS zones failed for %s. Details: %s", zone.Id(), err.Error())
 	}
 
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	switch rtype {
+	case dns.RS_SRV, dns.RS_SSHFP, dns.RS_NAPTR, dns.RS_CAA:
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+// ClassifyError maps the HTTP status code of Designate API errors to error reasons.
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	var codeErr gophercloud.StatusCodeError
+	if errors.As(err, &codeErr) {
+		return perrs.ReasonForHTTPStatus(codeErr.GetStatusCode())
+	}
+	return perrs.ReasonUnknown
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
 // ExecuteRequests applies a given change request to a given hosted zone.
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	exec := NewExecution(logger, h, zone)
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := NewExecution(ctx, logger, h, zone)
 
 	var succeeded, failed int
 	for _, r := range reqs {
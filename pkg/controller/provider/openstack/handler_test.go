@@ -17,6 +17,7 @@
 package openstack
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -81,7 +82,7 @@ var _ designateClientInterface = &designateMockClient{}
 
 var mockMetrics provider.Metrics = &provider.NullMetrics{}
 
-func (c *designateMockClient) ForEachZone(handler func(zone *zones.Zone) error) error {
+func (c *designateMockClient) ForEachZone(_ context.Context, handler func(zone *zones.Zone) error) error {
 	for _, tz := range c.tzmap {
 		if err := handler(tz.zone); err != nil {
 			return err
@@ -90,11 +91,11 @@ func (c *designateMockClient) ForEachZone(handler func(zone *zones.Zone) error)
 	return nil
 }
 
-func (c *designateMockClient) ForEachRecordSet(zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
-	return c.ForEachRecordSetFilterByTypeAndName(zoneID, "", "", handler)
+func (c *designateMockClient) ForEachRecordSet(ctx context.Context, zoneID string, handler func(recordSet *recordsets.RecordSet) error) error {
+	return c.ForEachRecordSetFilterByTypeAndName(ctx, zoneID, "", "", handler)
 }
 
-func (c *designateMockClient) ForEachRecordSetFilterByTypeAndName(zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error {
+func (c *designateMockClient) ForEachRecordSetFilterByTypeAndName(_ context.Context, zoneID string, rrtype string, name string, handler func(recordSet *recordsets.RecordSet) error) error {
 	tz := c.tzmap[zoneID]
 	if tz == nil {
 		return nil
@@ -115,7 +116,7 @@ func (c *designateMockClient) ForEachRecordSetFilterByTypeAndName(zoneID string,
 	return nil
 }
 
-func (c *designateMockClient) CreateRecordSet(zoneID string, opts recordsets.CreateOpts) (string, error) {
+func (c *designateMockClient) CreateRecordSet(_ context.Context, zoneID string, opts recordsets.CreateOpts) (string, error) {
 	tz := c.tzmap[zoneID]
 	if tz == nil {
 		return "", fmt.Errorf("Zone %s not found", zoneID)
@@ -155,7 +156,7 @@ func (c *designateMockClient) getRecordSet(zoneID, recordSetID string) (*testzon
 	return tz, rs, nil
 }
 
-func (c *designateMockClient) UpdateRecordSet(zoneID, recordSetID string, opts recordsets.UpdateOpts) error {
+func (c *designateMockClient) UpdateRecordSet(_ context.Context, zoneID, recordSetID string, opts recordsets.UpdateOpts) error {
 	_, rs, err := c.getRecordSet(zoneID, recordSetID)
 	if err != nil {
 		return err
@@ -165,7 +166,7 @@ func (c *designateMockClient) UpdateRecordSet(zoneID, recordSetID string, opts r
 	return nil
 }
 
-func (c *designateMockClient) DeleteRecordSet(zoneID, recordSetID string) error {
+func (c *designateMockClient) DeleteRecordSet(_ context.Context, zoneID, recordSetID string) error {
 	tz, rs, err := c.getRecordSet(zoneID, recordSetID)
 	if err != nil {
 		return err
@@ -217,7 +218,7 @@ func newPreparedMockHandler(t *testing.T) *Handler {
 			ID:   "z2",
 			Name: "z2.test.",
 		})
-	_, err := h.client.CreateRecordSet("z2", recordsets.CreateOpts{
+	_, err := h.client.CreateRecordSet(context.TODO(), "z2", recordsets.CreateOpts{
 		Name:    "excluded.z2.test.",
 		Type:    "NS",
 		TTL:     3600,
@@ -231,7 +232,7 @@ func newPreparedMockHandler(t *testing.T) *Handler {
 
 func TestGetZones(t *testing.T) {
 	h := newPreparedMockHandler(t)
-	hostedZones, err := h.GetZones()
+	hostedZones, err := h.GetZones(context.TODO())
 	if err != nil {
 		t.Error(err)
 	}
@@ -280,7 +281,7 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 	hostedZone, err := getDNSHostedZone(h, "z1")
 	Ω(err).Should(BeNil(), "Get Zone z1 failed")
 
-	zoneState, err := h.GetZoneState(hostedZone)
+	zoneState, err := h.GetZoneState(context.TODO(), hostedZone)
 	Ω(err).Should(BeNil(), "Initial GetZoneState failed")
 	dnssets := zoneState.GetDNSSets()
 	Ω(len(dnssets)).Should(Equal(0), "dnssets should be empty initially")
@@ -318,7 +319,7 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 		},
 	}
 	for _, opts := range initial {
-		_, err = h.client.CreateRecordSet("z1", opts)
+		_, err = h.client.CreateRecordSet(context.TODO(), "z1", opts)
 		Ω(err).Should(BeNil(), fmt.Sprintf("CreateRecordSet failed for %s %s", opts.Name, opts.Type))
 	}
 
@@ -346,7 +347,7 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 		},
 	}
 
-	zoneState2, err := h.GetZoneState(hostedZone)
+	zoneState2, err := h.GetZoneState(context.TODO(), hostedZone)
 	Ω(err).Should(BeNil(), "GetZoneState failed")
 	actualDnssets := zoneState2.GetDNSSets()
 	Ω(actualDnssets).Should(Equal(expectedDnssets))
@@ -399,7 +400,7 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 			Deletion: expectedDnssets["sub3.z1.test"],
 		},
 	}
-	err = h.ExecuteRequests(tlog, hostedZone, zoneState2, reqs)
+	err = h.ExecuteRequests(context.TODO(), tlog, hostedZone, zoneState2, reqs)
 	Ω(err).Should(BeNil(), "ExecuteRequests failed")
 
 	expectedDnssets2 := dns.DNSSets{
@@ -419,7 +420,7 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 		},
 	}
 
-	zoneState3, err := h.GetZoneState(hostedZone)
+	zoneState3, err := h.GetZoneState(context.TODO(), hostedZone)
 	if err != nil {
 		t.Errorf("Second GetZoneState for z1 failed with: %v", err)
 		return
@@ -17,6 +17,8 @@
 package remote
 
 import (
+	"time"
+
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -30,13 +32,20 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 }
 
 var advancedDefaults = provider.AdvancedOptions{
-	BatchSize:  50,
-	MaxRetries: 7,
+	BatchSize:            50,
+	MaxRetries:           7,
+	ZoneStateConcurrency: 4,
+}
+
+var timeoutDefaults = provider.TimeoutOptions{
+	GetZones:        60 * time.Second,
+	GetZoneState:    300 * time.Second,
+	ExecuteRequests: 300 * time.Second,
 }
 
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults).SetTimeoutOptions(timeoutDefaults))
 
 func init() {
 	compound.MustRegister(Factory)
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -30,11 +31,14 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/encoding/gzip"
 	"google.golang.org/grpc/status"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -50,6 +54,13 @@ type Handler struct {
 	client          common.RemoteProviderClient
 	sess            *session.Session
 	r53             *route53.Route53
+
+	lock            sync.Mutex
+	protocolVersion int32
+	capabilities    *common.Capabilities
+	syncStates      map[string]*zoneSyncState
+
+	journal *journal
 }
 
 var _ provider.DNSHandler = &Handler{}
@@ -62,6 +73,8 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
 		config:            *c,
 		clientID:          getClientID(),
+		journal:           newJournal(),
+		syncStates:        map[string]*zoneSyncState{},
 	}
 
 	serverEndpoint, err := c.GetRequiredProperty("REMOTE_ENDPOINT", "remoteEndpoint")
@@ -82,7 +95,8 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		return nil, err
 	}
 	overrideServerName := c.GetDefaultedProperty("OVERRIDE_SERVER_NAME", "", "overrideServerName")
-	c.Logger.Infof("creating remote handler for %s, namespace: %s, overrideServerName: %s", serverEndpoint, h.remoteNamespace, overrideServerName)
+	compression := c.GetDefaultedProperty("COMPRESSION", "", "compression")
+	c.Logger.Infof("creating remote handler for %s, namespace: %s, overrideServerName: %s, compression: %s", serverEndpoint, h.remoteNamespace, overrideServerName, compression)
 
 	creds, err := h.loadTLSCredentials([]byte(serverCA_PEM), []byte(clientCert_PEM), []byte(clientKey_PEM))
 	if err != nil {
@@ -95,7 +109,15 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		}
 	}
 
-	h.connection, err = grpc.Dial(serverEndpoint, grpc.WithTransportCredentials(creds))
+	dialOptions := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	switch compression {
+	case "", "none":
+	case gzip.Name:
+		dialOptions = append(dialOptions, grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)))
+	default:
+		return nil, fmt.Errorf("unsupported compression %q (supported: %s)", compression, gzip.Name)
+	}
+	h.connection, err = grpc.Dial(serverEndpoint, dialOptions...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,15 +175,20 @@ func (h *Handler) Release() {
 	}
 }
 
-func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
-	return h.cache.GetZones()
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
 }
 
 func (h *Handler) login(ctx context.Context) error {
 	h.config.RateLimiter.Accept()
 	response, err := h.client.Login(ctx, &common.LoginRequest{
-		Namespace: h.remoteNamespace,
-		CliendID:  h.clientID,
+		Namespace:       h.remoteNamespace,
+		CliendID:        h.clientID,
+		ProtocolVersion: common.ProtocolVersion,
+		Capabilities: &common.Capabilities{
+			RecordTypes:        dns.ManagedRecordTypes,
+			RoutingPolicyTypes: dns.RoutingPolicyTypes,
+		},
 	})
 	if err != nil {
 		if s, ok := status.FromError(err); ok {
@@ -174,9 +201,60 @@ func (h *Handler) login(ctx context.Context) error {
 		return err
 	}
 	h.currentToken = response.Token
+	h.updateProtocol(response)
 	return nil
 }
 
+// updateProtocol stores the protocol version and capabilities announced by the remote server.
+// Servers before protocol version 2 announce neither.
+func (h *Handler) updateProtocol(response *common.LoginResponse) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	version := common.NegotiateProtocolVersion(response.ProtocolVersion)
+	if version != h.protocolVersion {
+		h.config.Logger.Infof("remote protocol version %d", version)
+	}
+	h.protocolVersion = version
+	h.capabilities = nil
+	if version >= common.ProtocolVersion2 {
+		h.capabilities = response.Capabilities
+	}
+}
+
+func (h *Handler) getCapabilities() *common.Capabilities {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.capabilities
+}
+
+func (h *Handler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	capabilities := h.getCapabilities()
+	if capabilities == nil {
+		return h.DefaultDNSHandler.ValidateRoutingPolicy(policy)
+	}
+	for _, ptype := range capabilities.RoutingPolicyTypes {
+		if ptype == policy.Type {
+			// parameters are validated by the remote server
+			return nil
+		}
+	}
+	return fmt.Errorf("routing policy %s not supported by remote server", policy.Type)
+}
+
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	capabilities := h.getCapabilities()
+	if capabilities == nil {
+		return h.DefaultDNSHandler.SupportsRecordType(rtype)
+	}
+	for _, t := range capabilities.RecordTypes {
+		if t == rtype {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handler) retryOnInvalidTokenError(ctx context.Context, f func(token string) error) error {
 	var err error
 	if h.currentToken != "" {
@@ -198,21 +276,20 @@ func (h *Handler) retryOnInvalidTokenError(ctx context.Context, f func(token str
 	return err
 }
 
-func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
 	var remoteZones *common.Zones
 	err := h.retryOnInvalidTokenError(ctx, func(token string) error {
 		var err error
 		h.config.RateLimiter.Accept()
 		remoteZones, err = h.client.GetZones(ctx, &common.GetZonesRequest{Token: token})
 		h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
+		_, err = throttlingError(err)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
+	h.replayJournal(ctx, h.config.Logger)
 
 	zones := provider.DNSHostedZones{}
 	for _, z := range remoteZones.Zone {
@@ -222,28 +299,29 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 	return zones, nil
 }
 
-func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
-	return h.cache.GetZoneState(zone)
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
 }
 
-func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
-	defer cancel()
-
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
 	var remoteState *common.ZoneState
+	syncToken := h.getSyncToken(zone.Id().ID)
 	err := h.retryOnInvalidTokenError(ctx, func(token string) error {
 		var err error
 		h.config.RateLimiter.Accept()
-		remoteState, err = h.client.GetZoneState(ctx, &common.GetZoneStateRequest{Token: token, Zoneid: zone.Id().ID})
+		remoteState, err = h.client.GetZoneState(ctx, &common.GetZoneStateRequest{Token: token, Zoneid: zone.Id().ID, SyncToken: syncToken})
 		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
+		_, err = throttlingError(err)
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	dnssets := conversion.UnmarshalDNSSets(remoteState.DnsSets)
-
+	dnssets, err := h.applyZoneState(zone.Id().ID, syncToken, remoteState)
+	if err != nil {
+		return nil, err
+	}
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
@@ -251,18 +329,16 @@ func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
 
-func (h *Handler) ExecuteRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	err := h.executeRequests(logger, zone, state, reqs)
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	err := h.executeRequests(ctx, logger, zone, state, reqs)
 	h.cache.ApplyRequests(logger, err, zone, reqs)
 	return err
 }
 
-func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+func (h *Handler) executeRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
 	if len(reqs) == 0 {
 		return nil
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
-	defer cancel()
 
 	var changeRequests []*common.ChangeRequest
 	for _, req := range reqs {
@@ -280,7 +356,13 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 		}
 	}
 
+	if n := h.journal.supersede(zone.Id(), changeRequests); n > 0 {
+		logger.Infof("%d journaled changes superseded", n)
+	}
+	h.replayJournal(ctx, logger)
+
 	var response *common.ExecuteResponse
+	var retryAfter time.Duration
 	err := h.retryOnInvalidTokenError(ctx, func(token string) error {
 		var err error
 		h.config.RateLimiter.Accept()
@@ -290,25 +372,23 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 			ChangeRequest: changeRequests,
 		}
 		response, err = h.client.Execute(ctx, executeRequest)
+		retryAfter, err = throttlingError(err)
 		return err
 	})
+	if response == nil && isUnavailable(err) {
+		return h.journalRequests(logger, zone, state, reqs, changeRequests, err)
+	}
+	if response == nil && perrs.IsThrottlingError(err) {
+		logger.Infof("remote server throttled %d changes (retry after %s)", len(reqs), retryAfter)
+		for _, req := range reqs {
+			if req.Done != nil {
+				req.Done.Throttled(retryAfter)
+			}
+		}
+	}
 	if response != nil {
 		for i, changeResponse := range response.ChangeResponse {
-			done := reqs[i].Done
-			if done != nil {
-				switch changeResponse.State {
-				case common.ChangeResponse_NOT_PROCESSED:
-					logger.Infof("not processed: %d", i)
-				case common.ChangeResponse_SUCCEEDED:
-					done.Succeeded()
-				case common.ChangeResponse_INVALID:
-					done.SetInvalid(fmt.Errorf("remote: %s", changeResponse.ErrorMessage))
-				case common.ChangeResponse_FAILED:
-					done.Failed(fmt.Errorf("remote: %s", changeResponse.ErrorMessage))
-				case common.ChangeResponse_THROTTLED:
-					done.Throttled()
-				}
-			}
+			reportChangeResponse(logger, reqs[i].Done, i, changeResponse)
 		}
 		for _, log := range response.LogMessage {
 			ts := time.Unix(log.Timestamp/1e9, log.Timestamp%1e9)
@@ -326,3 +406,59 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 	}
 	return err
 }
+
+// ClassifyError maps the status codes of the remote server to error reasons.
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	s, ok := status.FromError(err)
+	if !ok {
+		return perrs.ReasonUnknown
+	}
+	switch s.Code() {
+	case codes.ResourceExhausted:
+		return perrs.ReasonThrottled
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return perrs.ReasonAuthFailed
+	case codes.NotFound:
+		return perrs.ReasonNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return perrs.ReasonConflict
+	case codes.InvalidArgument:
+		return perrs.ReasonInvalidRecord
+	}
+	return perrs.ReasonUnknown
+}
+
+// throttlingError maps the backpressure signaled by the remote server (status code ResourceExhausted)
+// to a throttling error. The delay suggested by the server is returned if available.
+// reportChangeResponse reports the result of the i-th change of an execute request to its done handler.
+func reportChangeResponse(logger logger.LogContext, done provider.DoneHandler, i int, changeResponse *common.ChangeResponse) {
+	if done == nil {
+		return
+	}
+	switch changeResponse.State {
+	case common.ChangeResponse_NOT_PROCESSED:
+		logger.Infof("not processed: %d", i)
+	case common.ChangeResponse_SUCCEEDED:
+		done.Succeeded()
+	case common.ChangeResponse_INVALID:
+		done.SetInvalid(fmt.Errorf("remote: %s", changeResponse.ErrorMessage))
+	case common.ChangeResponse_FAILED:
+		done.Failed(fmt.Errorf("remote: %s", changeResponse.ErrorMessage))
+	case common.ChangeResponse_THROTTLED:
+		done.Throttled(0)
+	}
+}
+
+func throttlingError(err error) (time.Duration, error) {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.ResourceExhausted {
+		return 0, err
+	}
+	var retryAfter time.Duration
+	for _, detail := range s.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.RetryDelay != nil {
+			retryAfter = info.RetryDelay.AsDuration()
+		}
+	}
+	return retryAfter, perrs.NewThrottlingError(err)
+}
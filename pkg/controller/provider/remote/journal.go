@@ -0,0 +1,285 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxJournalEntries limits the number of journaled change requests
+	maxJournalEntries = 1000
+	// maxJournalAge is the maximum age of journaled change requests to be replayed
+	maxJournalAge = 1 * time.Hour
+	// journalRetryAfter is the delay reported for journaled change requests
+	journalRetryAfter = 30 * time.Second
+)
+
+// journalEntry is a change request which could not be sent to the unreachable remote server.
+type journalEntry struct {
+	zone    provider.DNSHostedZone
+	change  *common.ChangeRequest
+	done    provider.DoneHandler
+	base    *dns.RecordSet
+	created time.Time
+}
+
+func (e *journalEntry) setKey() string {
+	return dns.DNSSetKey(e.change.Change.DnsName, e.change.Change.SetIdentifier)
+}
+
+// journal records the change requests during an outage of the remote server
+// to replay them on reconnect. There is at most one entry per zone, DNS set and record type,
+// newer change requests replace older ones.
+type journal struct {
+	lock    sync.Mutex
+	entries map[string]*journalEntry
+}
+
+func newJournal() *journal {
+	return &journal{entries: map[string]*journalEntry{}}
+}
+
+func journalKey(zoneid dns.ZoneID, change *common.ChangeRequest) string {
+	return fmt.Sprintf("%s|%s|%s", zoneid, dns.DNSSetKey(change.Change.DnsName, change.Change.SetIdentifier), change.Change.RecordType)
+}
+
+func (j *journal) isEmpty() bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	return len(j.entries) == 0
+}
+
+// add records the change requests together with the record sets expected in the remote zone for conflict detection
+// and the done handlers of the requests to report the result of the replay.
+// It returns false if the journal is full.
+func (j *journal) add(zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest, changes []*common.ChangeRequest) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	var sets dns.DNSSets
+	if state != nil {
+		sets = state.GetDNSSets()
+	}
+	now := time.Now()
+	for i, change := range changes {
+		key := journalKey(zone.Id(), change)
+		if j.entries[key] == nil && len(j.entries) >= maxJournalEntries {
+			return false
+		}
+		entry := &journalEntry{zone: zone, change: change, done: reqs[i].Done, created: now}
+		if old := j.entries[key]; old != nil {
+			// keep the record set expected before the first journaled change
+			entry.base = old.base
+		} else if set := sets[entry.setKey()]; set != nil {
+			if rs := set.Sets[change.Change.RecordType]; rs != nil {
+				entry.base = rs.Clone()
+			}
+		}
+		j.entries[key] = entry
+	}
+	return true
+}
+
+// supersede removes the entries replaced by new change requests for the same record sets.
+func (j *journal) supersede(zoneid dns.ZoneID, changes []*common.ChangeRequest) int {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	count := 0
+	for _, change := range changes {
+		key := journalKey(zoneid, change)
+		if j.entries[key] != nil {
+			delete(j.entries, key)
+			count++
+		}
+	}
+	return count
+}
+
+// takeZones removes and returns all entries grouped by zone. Outdated entries are dropped.
+func (j *journal) takeZones() (map[dns.ZoneID][]*journalEntry, int) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	outdated := 0
+	result := map[dns.ZoneID][]*journalEntry{}
+	for key, entry := range j.entries {
+		delete(j.entries, key)
+		if time.Since(entry.created) > maxJournalAge {
+			outdated++
+			continue
+		}
+		result[entry.zone.Id()] = append(result[entry.zone.Id()], entry)
+	}
+	return result, outdated
+}
+
+// restore adds entries again, which could not be replayed. Newer entries are kept.
+func (j *journal) restore(entries []*journalEntry) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	for _, entry := range entries {
+		key := journalKey(entry.zone.Id(), entry.change)
+		if j.entries[key] == nil {
+			j.entries[key] = entry
+		}
+	}
+}
+
+func isUnavailable(err error) bool {
+	s, ok := status.FromError(err)
+	return ok && s.Code() == codes.Unavailable
+}
+
+// journalRequests records the change requests if the remote server is unreachable.
+// The changes are reported as throttled instead of failed, as they are replayed on reconnect.
+func (h *Handler) journalRequests(logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState,
+	reqs []*provider.ChangeRequest, changes []*common.ChangeRequest, err error) error {
+	if !h.journal.add(zone, state, reqs, changes) {
+		logger.Warnf("journal for unreachable remote server is full, %d changes not journaled", len(changes))
+		return err
+	}
+	logger.Infof("remote server unreachable: journaled %d changes for replay", len(changes))
+	for _, req := range reqs {
+		if req.Done != nil {
+			req.Done.Throttled(journalRetryAfter)
+		}
+	}
+	// keeps the cached zone state
+	return perrs.NewThrottlingError(err)
+}
+
+// replayJournal sends the journaled change requests after the remote server is reachable again.
+// A change is dropped as conflicting if the record set in the remote zone has been modified in the meantime.
+// The results are reported to the done handlers of the journaled requests, so that dropped or failed changes
+// are reconciled again.
+func (h *Handler) replayJournal(ctx context.Context, logger logger.LogContext) {
+	if h.journal.isEmpty() {
+		return
+	}
+	zones, outdated := h.journal.takeZones()
+	if outdated > 0 {
+		logger.Warnf("dropped %d outdated journaled changes", outdated)
+	}
+	for zoneid, entries := range zones {
+		if err := h.replayZone(ctx, logger, entries); err != nil {
+			logger.Warnf("replay of %d journaled changes for zone %s failed: %s", len(entries), zoneid, err)
+			if isUnavailable(err) {
+				h.journal.restore(entries)
+				continue
+			}
+			for _, entry := range entries {
+				if entry.done != nil {
+					entry.done.Failed(fmt.Errorf("replay of journaled change failed: %w", err))
+				}
+			}
+		}
+	}
+}
+
+func (h *Handler) replayZone(ctx context.Context, logger logger.LogContext, entries []*journalEntry) error {
+	zone := entries[0].zone
+	var remoteState *common.ZoneState
+	syncToken := h.getSyncToken(zone.Id().ID)
+	err := h.retryOnInvalidTokenError(ctx, func(token string) error {
+		var err error
+		h.config.RateLimiter.Accept()
+		remoteState, err = h.client.GetZoneState(ctx, &common.GetZoneStateRequest{Token: token, Zoneid: zone.Id().ID, SyncToken: syncToken})
+		h.config.Metrics.AddZoneRequests(zone.Id().ID, provider.M_LISTRECORDS, 1)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	sets, err := h.applyZoneState(zone.Id().ID, syncToken, remoteState)
+	if err != nil {
+		return err
+	}
+
+	var changes []*common.ChangeRequest
+	var replayed []*journalEntry
+	conflicts := 0
+	for _, entry := range entries {
+		var current *dns.RecordSet
+		if set := sets[entry.setKey()]; set != nil {
+			current = set.Sets[entry.change.Change.RecordType]
+		}
+		if !sameRecordSet(entry.base, current) {
+			conflicts++
+			logger.Warnf("conflict: %s %s of zone %s modified during outage, dropping journaled change",
+				entry.change.Change.RecordType, entry.setKey(), zone.Id())
+			if entry.done != nil {
+				entry.done.Failed(fmt.Errorf("conflict: %s %s modified in remote zone during outage", entry.change.Change.RecordType, entry.setKey()))
+			}
+			continue
+		}
+		changes = append(changes, entry.change)
+		replayed = append(replayed, entry)
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var response *common.ExecuteResponse
+	err = h.retryOnInvalidTokenError(ctx, func(token string) error {
+		var err error
+		h.config.RateLimiter.Accept()
+		response, err = h.client.Execute(ctx, &common.ExecuteRequest{Token: token, Zoneid: zone.Id().ID, ChangeRequest: changes})
+		return err
+	})
+	if err != nil {
+		if isUnavailable(err) {
+			// keep the conflicting entries dropped, they are reported already
+			logger.Warnf("replay of %d journaled changes for zone %s failed: %s", len(changes), zone.Id(), err)
+			h.journal.restore(replayed)
+			return nil
+		}
+		return err
+	}
+	failed := 0
+	for i, r := range response.ChangeResponse {
+		if r.State != common.ChangeResponse_SUCCEEDED {
+			failed++
+		}
+		if i < len(replayed) {
+			reportChangeResponse(logger, replayed[i].done, i, r)
+		}
+	}
+	logger.Infof("replayed %d journaled changes for zone %s (%d conflicts, %d failed)", len(changes), zone.Id(), conflicts, failed)
+	h.cache.InvalidateZoneState(zone.Id())
+	return nil
+}
+
+func sameRecordSet(a, b *dns.RecordSet) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Match(b)
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/gomega"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/client-go/util/flowcontrol"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+)
+
+var (
+	testZone1 = provider.NewDNSHostedZone(TYPE_CODE, "z1", "example.com", "", nil, false)
+	testZone2 = provider.NewDNSHostedZone(TYPE_CODE, "z2", "example.org", "", nil, false)
+)
+
+// recordingDone records the results reported for a change request.
+type recordingDone struct {
+	results []string
+}
+
+func (d *recordingDone) SetInvalid(err error)      { d.results = append(d.results, "invalid: "+err.Error()) }
+func (d *recordingDone) Failed(err error)          { d.results = append(d.results, "failed: "+err.Error()) }
+func (d *recordingDone) Throttled(_ time.Duration) { d.results = append(d.results, "throttled") }
+func (d *recordingDone) Blocked(reason string, _ []string) {
+	d.results = append(d.results, "blocked: "+reason)
+}
+func (d *recordingDone) Succeeded() { d.results = append(d.results, "succeeded") }
+
+func newARequest(t *testing.T, name string, done provider.DoneHandler, values ...string) (*provider.ChangeRequest, *common.ChangeRequest) {
+	set := dns.NewDNSSet(name)
+	set.SetRecordSet(dns.RS_A, 300, values...)
+	req := provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, nil, set, done)
+	change, err := conversion.MarshalChangeRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req, change
+}
+
+func newZoneState(values map[string]string) provider.DNSZoneState {
+	sets := dns.DNSSets{}
+	for name, value := range values {
+		sets.AddRecordSet(name, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: value}}))
+	}
+	return provider.NewDNSZoneState(sets)
+}
+
+func TestJournalAdd(t *testing.T) {
+	RegisterTestingT(t)
+
+	j := newJournal()
+	Expect(j.isEmpty()).To(BeTrue())
+	done1 := &recordingDone{}
+	req1, change1 := newARequest(t, "a.example.com", done1, "1.1.1.2")
+	req2, change2 := newARequest(t, "b.example.com", nil, "2.2.2.2")
+	Expect(j.add(testZone1, newZoneState(map[string]string{"a.example.com": "1.1.1.1"}), []*provider.ChangeRequest{req1, req2}, []*common.ChangeRequest{change1, change2})).To(BeTrue())
+	Expect(j.isEmpty()).To(BeFalse())
+	Expect(j.entries).To(HaveLen(2))
+
+	entry := j.entries[journalKey(testZone1.Id(), change1)]
+	Expect(entry.change).To(BeIdenticalTo(change1))
+	Expect(entry.done).To(BeIdenticalTo(done1))
+	Expect(entry.base.Records).To(HaveLen(1))
+	Expect(entry.base.Records[0].Value).To(Equal("1.1.1.1"))
+	// the record set did not exist before
+	Expect(j.entries[journalKey(testZone1.Id(), change2)].base).To(BeNil())
+
+	// a newer change replaces the entry, but keeps the record set expected before the first change
+	done3 := &recordingDone{}
+	req3, change3 := newARequest(t, "a.example.com", done3, "1.1.1.3")
+	Expect(j.add(testZone1, newZoneState(map[string]string{"a.example.com": "1.1.1.2"}), []*provider.ChangeRequest{req3}, []*common.ChangeRequest{change3})).To(BeTrue())
+	Expect(j.entries).To(HaveLen(2))
+	entry = j.entries[journalKey(testZone1.Id(), change3)]
+	Expect(entry.change).To(BeIdenticalTo(change3))
+	Expect(entry.done).To(BeIdenticalTo(done3))
+	Expect(entry.base.Records[0].Value).To(Equal("1.1.1.1"))
+
+	// the same record set in another zone is a separate entry
+	Expect(j.add(testZone2, nil, []*provider.ChangeRequest{req3}, []*common.ChangeRequest{change3})).To(BeTrue())
+	Expect(j.entries).To(HaveLen(3))
+}
+
+func TestJournalAddFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	j := newJournal()
+	for i := 0; i < maxJournalEntries; i++ {
+		req, change := newARequest(t, fmt.Sprintf("r%d.example.com", i), nil, "1.1.1.1")
+		Expect(j.add(testZone1, nil, []*provider.ChangeRequest{req}, []*common.ChangeRequest{change})).To(BeTrue())
+	}
+	req, change := newARequest(t, "new.example.com", nil, "1.1.1.1")
+	Expect(j.add(testZone1, nil, []*provider.ChangeRequest{req}, []*common.ChangeRequest{change})).To(BeFalse())
+	// existing entries can still be replaced
+	req, change = newARequest(t, "r0.example.com", nil, "1.1.1.2")
+	Expect(j.add(testZone1, nil, []*provider.ChangeRequest{req}, []*common.ChangeRequest{change})).To(BeTrue())
+	Expect(j.entries).To(HaveLen(maxJournalEntries))
+}
+
+func TestJournalSupersede(t *testing.T) {
+	RegisterTestingT(t)
+
+	j := newJournal()
+	req1, change1 := newARequest(t, "a.example.com", nil, "1.1.1.1")
+	req2, change2 := newARequest(t, "b.example.com", nil, "2.2.2.2")
+	j.add(testZone1, nil, []*provider.ChangeRequest{req1, req2}, []*common.ChangeRequest{change1, change2})
+
+	_, newer := newARequest(t, "a.example.com", nil, "1.1.1.2")
+	_, other := newARequest(t, "c.example.com", nil, "3.3.3.3")
+	Expect(j.supersede(testZone2.Id(), []*common.ChangeRequest{newer})).To(Equal(0))
+	Expect(j.supersede(testZone1.Id(), []*common.ChangeRequest{newer, other})).To(Equal(1))
+	Expect(j.entries).To(HaveLen(1))
+	Expect(j.entries).To(HaveKey(journalKey(testZone1.Id(), change2)))
+}
+
+func TestJournalTakeZonesAndRestore(t *testing.T) {
+	RegisterTestingT(t)
+
+	j := newJournal()
+	req1, change1 := newARequest(t, "a.example.com", nil, "1.1.1.1")
+	req2, change2 := newARequest(t, "b.example.com", nil, "2.2.2.2")
+	req3, change3 := newARequest(t, "c.example.com", nil, "3.3.3.3")
+	j.add(testZone1, nil, []*provider.ChangeRequest{req1, req2}, []*common.ChangeRequest{change1, change2})
+	j.add(testZone2, nil, []*provider.ChangeRequest{req3}, []*common.ChangeRequest{change3})
+	j.entries[journalKey(testZone1.Id(), change2)].created = time.Now().Add(-maxJournalAge - time.Minute)
+
+	zones, outdated := j.takeZones()
+	Expect(outdated).To(Equal(1))
+	Expect(j.isEmpty()).To(BeTrue())
+	Expect(zones).To(HaveLen(2))
+	Expect(zones[testZone1.Id()]).To(HaveLen(1))
+	Expect(zones[testZone1.Id()][0].change).To(BeIdenticalTo(change1))
+	Expect(zones[testZone2.Id()]).To(HaveLen(1))
+	Expect(zones[testZone2.Id()][0].change).To(BeIdenticalTo(change3))
+
+	// entries journaled in the meantime are newer and kept
+	req4, change4 := newARequest(t, "a.example.com", nil, "1.1.1.4")
+	j.add(testZone1, nil, []*provider.ChangeRequest{req4}, []*common.ChangeRequest{change4})
+	j.restore(append(zones[testZone1.Id()], zones[testZone2.Id()]...))
+	Expect(j.entries).To(HaveLen(2))
+	Expect(j.entries[journalKey(testZone1.Id(), change1)].change).To(BeIdenticalTo(change4))
+	Expect(j.entries[journalKey(testZone2.Id(), change3)].change).To(BeIdenticalTo(change3))
+}
+
+// fakeRemoteClient serves a zone state and records the executed change requests.
+type fakeRemoteClient struct {
+	zoneState    *common.ZoneState
+	zoneStateErr error
+	executeErr   error
+	executed     []*common.ChangeRequest
+}
+
+var _ common.RemoteProviderClient = &fakeRemoteClient{}
+
+func (c *fakeRemoteClient) Login(_ context.Context, _ *common.LoginRequest, _ ...grpc.CallOption) (*common.LoginResponse, error) {
+	return &common.LoginResponse{Token: "token"}, nil
+}
+
+func (c *fakeRemoteClient) GetZones(_ context.Context, _ *common.GetZonesRequest, _ ...grpc.CallOption) (*common.Zones, error) {
+	return &common.Zones{}, nil
+}
+
+func (c *fakeRemoteClient) GetZoneState(_ context.Context, _ *common.GetZoneStateRequest, _ ...grpc.CallOption) (*common.ZoneState, error) {
+	return c.zoneState, c.zoneStateErr
+}
+
+func (c *fakeRemoteClient) Execute(_ context.Context, in *common.ExecuteRequest, _ ...grpc.CallOption) (*common.ExecuteResponse, error) {
+	if c.executeErr != nil {
+		return nil, c.executeErr
+	}
+	response := &common.ExecuteResponse{}
+	for _, change := range in.ChangeRequest {
+		c.executed = append(c.executed, change)
+		state := common.ChangeResponse_SUCCEEDED
+		if change.Change.DnsName == "failing.example.com" {
+			state = common.ChangeResponse_FAILED
+		}
+		response.ChangeResponse = append(response.ChangeResponse, &common.ChangeResponse{State: state, ErrorMessage: "error"})
+	}
+	return response, nil
+}
+
+func newTestHandler(t *testing.T, client *fakeRemoteClient) *Handler {
+	h := &Handler{
+		config: provider.DNSHandlerConfig{
+			Metrics:     &provider.NullMetrics{},
+			RateLimiter: flowcontrol.NewFakeAlwaysRateLimiter(),
+		},
+		currentToken: "token",
+		client:       client,
+		journal:      newJournal(),
+		syncStates:   map[string]*zoneSyncState{},
+	}
+	var err error
+	h.cache, err = provider.NewTestZoneCacheFactory(time.Minute, time.Minute).CreateZoneCache(provider.CacheZoneState, h.config.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestReplayJournal(t *testing.T) {
+	RegisterTestingT(t)
+
+	// the remote zone was modified for conflict.example.com during the outage
+	client := &fakeRemoteClient{zoneState: &common.ZoneState{DnsSets: conversion.MarshalDNSSets(newZoneState(map[string]string{
+		"a.example.com":        "1.1.1.1",
+		"conflict.example.com": "9.9.9.9",
+		"failing.example.com":  "5.5.5.5",
+	}).GetDNSSets())}}
+	h := newTestHandler(t, client)
+	before := newZoneState(map[string]string{
+		"a.example.com":        "1.1.1.1",
+		"conflict.example.com": "3.3.3.3",
+		"failing.example.com":  "5.5.5.5",
+	})
+	done1, done2, done3, done4 := &recordingDone{}, &recordingDone{}, &recordingDone{}, &recordingDone{}
+	req1, change1 := newARequest(t, "a.example.com", done1, "1.1.1.2")
+	req2, change2 := newARequest(t, "conflict.example.com", done2, "3.3.3.4")
+	req3, change3 := newARequest(t, "failing.example.com", done3, "5.5.5.6")
+	req4, change4 := newARequest(t, "new.example.com", done4, "4.4.4.4")
+	h.journal.add(testZone1, before, []*provider.ChangeRequest{req1, req2, req3, req4}, []*common.ChangeRequest{change1, change2, change3, change4})
+
+	h.replayJournal(context.TODO(), logger.New())
+	Expect(h.journal.isEmpty()).To(BeTrue())
+	Expect(client.executed).To(ConsistOf(change1, change3, change4))
+	Expect(done1.results).To(Equal([]string{"succeeded"}))
+	Expect(done2.results).To(Equal([]string{"failed: conflict: A conflict.example.com modified in remote zone during outage"}))
+	Expect(done3.results).To(Equal([]string{"failed: remote: error"}))
+	Expect(done4.results).To(Equal([]string{"succeeded"}))
+}
+
+func TestReplayJournalUnavailable(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &fakeRemoteClient{
+		zoneState:  &common.ZoneState{DnsSets: conversion.MarshalDNSSets(newZoneState(map[string]string{"conflict.example.com": "9.9.9.9"}).GetDNSSets())},
+		executeErr: status.Error(codes.Unavailable, "unavailable"),
+	}
+	h := newTestHandler(t, client)
+	done1, done2 := &recordingDone{}, &recordingDone{}
+	req1, change1 := newARequest(t, "a.example.com", done1, "1.1.1.1")
+	req2, change2 := newARequest(t, "conflict.example.com", done2, "3.3.3.4")
+	h.journal.add(testZone1, nil, []*provider.ChangeRequest{req1, req2}, []*common.ChangeRequest{change1, change2})
+
+	// the conflicting change is dropped, the other one is kept for the next replay
+	h.replayJournal(context.TODO(), logger.New())
+	Expect(h.journal.entries).To(HaveLen(1))
+	Expect(h.journal.entries).To(HaveKey(journalKey(testZone1.Id(), change1)))
+	Expect(done1.results).To(BeEmpty())
+	Expect(done2.results).To(HaveLen(1))
+	Expect(done2.results[0]).To(HavePrefix("failed: conflict:"))
+
+	// the zone state cannot be read either
+	client.zoneStateErr = status.Error(codes.Unavailable, "unavailable")
+	h.replayJournal(context.TODO(), logger.New())
+	Expect(h.journal.entries).To(HaveLen(1))
+	Expect(done1.results).To(BeEmpty())
+}
+
+func TestReplayJournalFailure(t *testing.T) {
+	RegisterTestingT(t)
+
+	client := &fakeRemoteClient{zoneStateErr: fmt.Errorf("zone not found")}
+	h := newTestHandler(t, client)
+	done1, done2 := &recordingDone{}, &recordingDone{}
+	req1, change1 := newARequest(t, "a.example.com", done1, "1.1.1.1")
+	req2, change2 := newARequest(t, "b.example.com", done2, "2.2.2.2")
+	h.journal.add(testZone1, nil, []*provider.ChangeRequest{req1, req2}, []*common.ChangeRequest{change1, change2})
+
+	// the failure is reported to all entries, so that they are reconciled again
+	h.replayJournal(context.TODO(), logger.New())
+	Expect(h.journal.isEmpty()).To(BeTrue())
+	Expect(client.executed).To(BeEmpty())
+	Expect(done1.results).To(Equal([]string{"failed: replay of journaled change failed: zone not found"}))
+	Expect(done2.results).To(Equal(done1.results))
+}
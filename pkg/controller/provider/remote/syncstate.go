@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"fmt"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+)
+
+// zoneSyncState is the last zone state received from the remote server,
+// used as base for delta transfers.
+type zoneSyncState struct {
+	token   string
+	dnssets dns.DNSSets
+}
+
+// getSyncToken returns the sync token of the last zone state received for the zone,
+// if the remote server supports delta transfers.
+func (h *Handler) getSyncToken(zoneid string) string {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if h.protocolVersion < common.ProtocolVersion3 {
+		return ""
+	}
+	if state := h.syncStates[zoneid]; state != nil {
+		return state.token
+	}
+	return ""
+}
+
+// applyZoneState builds the DNS sets from a full or delta zone state and keeps them as base for the next transfer.
+func (h *Handler) applyZoneState(zoneid, syncToken string, remoteState *common.ZoneState) (dns.DNSSets, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	var dnssets dns.DNSSets
+	if remoteState.Delta {
+		base := h.syncStates[zoneid]
+		if base == nil || base.token != syncToken {
+			delete(h.syncStates, zoneid)
+			return nil, fmt.Errorf("unexpected delta zone state for zone %s", zoneid)
+		}
+		dnssets = base.dnssets.Clone()
+		for _, key := range remoteState.DeletedDnsSets {
+			delete(dnssets, key)
+		}
+		for key, set := range remoteState.DnsSets {
+			dnssets[key] = conversion.UnmarshalDNSSet(set)
+		}
+	} else {
+		dnssets = conversion.UnmarshalDNSSets(remoteState.DnsSets)
+	}
+
+	if remoteState.SyncToken != "" {
+		h.syncStates[zoneid] = &zoneSyncState{token: remoteState.SyncToken, dnssets: dnssets.Clone()}
+	} else {
+		delete(h.syncStates, zoneid)
+	}
+	return dnssets, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"context"
+
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+// backend reads the zones by zone transfers and changes them by dynamic updates.
+// The zones cannot be listed by the DNS protocol, they are configured explicitly.
+type backend struct {
+	client *client
+	zones  []string
+}
+
+var _ sdk.Backend = &backend{}
+var _ sdk.BatchBackend = &backend{}
+
+func (b *backend) ListZones(_ context.Context) ([]sdk.Zone, error) {
+	var zones []sdk.Zone
+	for _, z := range b.zones {
+		zones = append(zones, sdk.Zone{ID: z, Domain: z})
+	}
+	return zones, nil
+}
+
+func (b *backend) ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error) {
+	rrs, err := b.client.transfer(ctx, zoneKey)
+	if err != nil {
+		return nil, err
+	}
+	var records raw.RecordSet
+	for _, rr := range rrs {
+		if r := recordFromRR(rr); r != nil {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (b *backend) NewRecord(fqdn, rtype, value string, _ provider.DNSHostedZone, ttl int64) raw.Record {
+	return &Record{Type: rtype, Name: fqdn, Value: value, TTL: int(ttl)}
+}
+
+func (b *backend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return b.ApplyBatch(ctx, &raw.Batch{Additions: raw.RecordSet{r}}, zone)
+}
+
+func (b *backend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return b.ApplyBatch(ctx, &raw.Batch{Updates: raw.RecordSet{r}}, zone)
+}
+
+func (b *backend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return b.ApplyBatch(ctx, &raw.Batch{Deletions: raw.RecordSet{r}}, zone)
+}
+
+// ApplyBatch sends all changes of the batch with a single update message, which is applied
+// atomically by the DNS server. Deletions are applied first, so that record sets can change
+// their type (e.g. from A to CNAME). Records are updated by deleting and adding them again
+// with the new TTL.
+func (b *backend) ApplyBatch(ctx context.Context, batch *raw.Batch, zone provider.DNSHostedZone) error {
+	collect := func(rrs []miekgdns.RR, records raw.RecordSet) ([]miekgdns.RR, error) {
+		for _, r := range records {
+			rr, err := r.(*Record).rr()
+			if err != nil {
+				return nil, err
+			}
+			rrs = append(rrs, rr)
+		}
+		return rrs, nil
+	}
+	deletions, err := collect(nil, batch.Deletions)
+	if err != nil {
+		return err
+	}
+	if deletions, err = collect(deletions, batch.Updates); err != nil {
+		return err
+	}
+	additions, err := collect(nil, batch.Updates)
+	if err != nil {
+		return err
+	}
+	if additions, err = collect(additions, batch.Additions); err != nil {
+		return err
+	}
+	if len(deletions) == 0 && len(additions) == 0 {
+		return nil
+	}
+	return b.client.update(ctx, zone.Key(), deletions, additions)
+}
@@ -0,0 +1,300 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	testKeyName = "external-dns."
+	testSecret  = "GcNS3mF1cTaDVy8xkO4U1jFFmRMVAUNm2xqUmPcXDo0="
+)
+
+// testServer is a name server for the zone example.org accepting zone transfers
+// and dynamic updates signed with the test key.
+type testServer struct {
+	lock    sync.Mutex
+	records []miekgdns.RR
+	updates []*miekgdns.Msg
+	rcode   int
+	addr    string
+}
+
+func (s *testServer) ServeDNS(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	resp := new(miekgdns.Msg)
+	resp.SetReply(r)
+	if r.IsTsig() == nil || w.TsigStatus() != nil {
+		resp.Rcode = miekgdns.RcodeNotAuth
+		_ = w.WriteMsg(resp)
+		return
+	}
+	if s.rcode != 0 {
+		resp.Rcode = s.rcode
+		_ = w.WriteMsg(resp.SetTsig(testKeyName, miekgdns.HmacSHA256, tsigFudge, time.Now().Unix()))
+		return
+	}
+	switch {
+	case r.Opcode == miekgdns.OpcodeQuery && r.Question[0].Qtype == miekgdns.TypeAXFR:
+		soa, _ := miekgdns.NewRR("example.org. 3600 IN SOA ns1.example.org. admin.example.org. 1 7200 3600 1209600 300")
+		ch := make(chan *miekgdns.Envelope)
+		done := make(chan struct{})
+		go func() {
+			_ = new(miekgdns.Transfer).Out(w, r, ch)
+			close(done)
+		}()
+		// send the records in two messages
+		half := len(s.records) / 2
+		ch <- &miekgdns.Envelope{RR: append([]miekgdns.RR{soa}, s.records[:half]...)}
+		ch <- &miekgdns.Envelope{RR: append(append([]miekgdns.RR{}, s.records[half:]...), soa)}
+		close(ch)
+		<-done
+		return
+	case r.Opcode == miekgdns.OpcodeUpdate:
+		s.updates = append(s.updates, r)
+		for _, u := range r.Ns {
+			if u.Header().Class == miekgdns.ClassNONE {
+				d := miekgdns.Copy(u)
+				d.Header().Class = miekgdns.ClassINET
+				for i, rr := range s.records {
+					if miekgdns.IsDuplicate(rr, d) {
+						s.records = append(s.records[:i], s.records[i+1:]...)
+						break
+					}
+				}
+			} else {
+				s.records = append(s.records, u)
+			}
+		}
+	default:
+		resp.Rcode = miekgdns.RcodeNotImplemented
+	}
+	resp.SetTsig(testKeyName, miekgdns.HmacSHA256, tsigFudge, time.Now().Unix())
+	_ = w.WriteMsg(resp)
+}
+
+func newTestServer(t *testing.T, records ...string) *testServer {
+	s := &testServer{}
+	for _, r := range records {
+		rr, err := miekgdns.NewRR(r)
+		Expect(err).NotTo(HaveOccurred())
+		s.records = append(s.records, rr)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	started := make(chan struct{})
+	server := &miekgdns.Server{
+		Listener:          l,
+		Handler:           s,
+		TsigSecret:        map[string]string{testKeyName: testSecret},
+		NotifyStartedFunc: func() { close(started) },
+		// updates are rejected by the default accept function
+		MsgAcceptFunc: func(miekgdns.Header) miekgdns.MsgAcceptAction { return miekgdns.MsgAccept },
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	<-started
+	t.Cleanup(func() { _ = server.Shutdown() })
+	s.addr = l.Addr().String()
+	return s
+}
+
+func newTestBackend(s *testServer, secret string) *backend {
+	key, err := newTSIGKey("external-dns", "", secret)
+	Expect(err).NotTo(HaveOccurred())
+	return &backend{client: newClient(s.addr, key, 5*time.Second), zones: []string{"example.org"}}
+}
+
+func TestListRecords(t *testing.T) {
+	RegisterTestingT(t)
+	s := newTestServer(t,
+		"www.example.org. 300 IN A 1.2.3.4",
+		"www.example.org. 300 IN A 1.2.3.5",
+		"WWW.example.org. 300 IN AAAA 2001:db8::1",
+		"alias.example.org. 600 IN CNAME www.example.org.",
+		"sub.example.org. 3600 IN NS ns1.other.org.",
+		`txt.example.org. 60 IN TXT "hello world" "with \"quotes\""`,
+		"_sip._tcp.example.org. 60 IN SRV 10 20 5060 sip.example.org.",
+		`example.org. 3600 IN CAA 0 issue "letsencrypt.org"`,
+		"example.org. 3600 IN MX 10 mail.example.org.",
+	)
+	b := newTestBackend(s, testSecret)
+
+	records, err := b.ListRecords(context.Background(), "example.org")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(records).To(ConsistOf(
+		&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.4", TTL: 300},
+		&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.5", TTL: 300},
+		&Record{Type: dns.RS_AAAA, Name: "www.example.org", Value: "2001:db8::1", TTL: 300},
+		&Record{Type: dns.RS_CNAME, Name: "alias.example.org", Value: "www.example.org", TTL: 600},
+		&Record{Type: dns.RS_NS, Name: "sub.example.org", Value: "ns1.other.org", TTL: 3600},
+		&Record{Type: dns.RS_TXT, Name: "txt.example.org", Value: `"hello world" "with \"quotes\""`, TTL: 60},
+		&Record{Type: dns.RS_SRV, Name: "_sip._tcp.example.org", Value: "10 20 5060 sip.example.org", TTL: 60},
+		&Record{Type: dns.RS_CAA, Name: "example.org", Value: `0 issue "letsencrypt.org"`, TTL: 3600},
+	))
+}
+
+func TestApplyBatch(t *testing.T) {
+	RegisterTestingT(t)
+	s := newTestServer(t,
+		"www.example.org. 300 IN A 1.2.3.4",
+		"www.example.org. 300 IN A 1.2.3.5",
+		"old.example.org. 300 IN CNAME www.example.org.",
+	)
+	b := newTestBackend(s, testSecret)
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.org", "example.org", "example.org", nil, false)
+
+	batch := &raw.Batch{
+		Deletions: raw.RecordSet{&Record{Type: dns.RS_CNAME, Name: "old.example.org", Value: "www.example.org", TTL: 300}},
+		Updates: raw.RecordSet{
+			&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.4", TTL: 600},
+			&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.5", TTL: 600},
+		},
+		Additions: raw.RecordSet{
+			&Record{Type: dns.RS_TXT, Name: "txt.example.org", Value: `"a\\b" "c"`, TTL: 60},
+			&Record{Type: dns.RS_CAA, Name: "example.org", Value: `0 issue "letsencrypt.org"`, TTL: 3600},
+		},
+	}
+	Expect(b.ApplyBatch(context.Background(), batch, zone)).To(Succeed())
+
+	Expect(s.updates).To(HaveLen(1))
+	update := s.updates[0]
+	Expect(update.Question[0].Name).To(Equal("example.org."))
+	Expect(update.IsTsig()).NotTo(BeNil())
+	Expect(update.Ns).To(HaveLen(7))
+	for i, u := range update.Ns {
+		if i < 3 {
+			Expect(u.Header().Class).To(Equal(uint16(miekgdns.ClassNONE)))
+			Expect(u.Header().Ttl).To(BeZero())
+		} else {
+			Expect(u.Header().Class).To(Equal(uint16(miekgdns.ClassINET)))
+		}
+	}
+
+	records, err := b.ListRecords(context.Background(), "example.org")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(records).To(ConsistOf(
+		&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.4", TTL: 600},
+		&Record{Type: dns.RS_A, Name: "www.example.org", Value: "1.2.3.5", TTL: 600},
+		&Record{Type: dns.RS_TXT, Name: "txt.example.org", Value: `"a\\b" "c"`, TTL: 60},
+		&Record{Type: dns.RS_CAA, Name: "example.org", Value: `0 issue "letsencrypt.org"`, TTL: 3600},
+	))
+
+	Expect(b.ApplyBatch(context.Background(), &raw.Batch{}, zone)).To(Succeed())
+	Expect(s.updates).To(HaveLen(1))
+
+	err = b.ApplyBatch(context.Background(), &raw.Batch{Additions: raw.RecordSet{
+		&Record{Type: dns.RS_A, Name: "invalid.example.org", Value: "::1", TTL: 60},
+	}}, zone)
+	Expect(err).To(MatchError(ContainSubstring("invalid IPv4 address")))
+	Expect(s.updates).To(HaveLen(1))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+	s := newTestServer(t, "www.example.org. 300 IN A 1.2.3.4")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.org", "example.org", "example.org", nil, false)
+	batch := &raw.Batch{Additions: raw.RecordSet{&Record{Type: dns.RS_A, Name: "new.example.org", Value: "1.2.3.6", TTL: 60}}}
+
+	// the server doesn't know the secret, the response is not signed
+	b := newTestBackend(s, "c2VjcmV0")
+	err := b.ApplyBatch(context.Background(), batch, zone)
+	Expect(err).To(MatchError(&ResponseError{Rcode: miekgdns.RcodeNotAuth}))
+	Expect(classifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+	_, err = b.ListRecords(context.Background(), "example.org")
+	Expect(err).To(MatchError(&ResponseError{Rcode: miekgdns.RcodeNotAuth}))
+
+	b = newTestBackend(s, testSecret)
+	for rcode, reason := range map[int]perrs.ErrorReason{
+		miekgdns.RcodeRefused:       perrs.ReasonAuthFailed,
+		miekgdns.RcodeNotZone:       perrs.ReasonNotFound,
+		miekgdns.RcodeYXRrset:       perrs.ReasonConflict,
+		miekgdns.RcodeFormatError:   perrs.ReasonInvalidRecord,
+		miekgdns.RcodeServerFailure: perrs.ReasonUnknown,
+	} {
+		s.rcode = rcode
+		err = b.ApplyBatch(context.Background(), batch, zone)
+		Expect(err).To(MatchError(&ResponseError{Rcode: rcode}))
+		Expect(classifyError(err)).To(Equal(reason))
+	}
+	s.rcode = miekgdns.RcodeRefused
+	_, err = b.ListRecords(context.Background(), "example.org")
+	Expect(err).To(MatchError("dns server responded with REFUSED"))
+
+	// responses signed with another key are rejected
+	other, err := newTSIGKey("external-dns", "", "c2VjcmV0")
+	Expect(err).NotTo(HaveOccurred())
+	s.rcode = 0
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).NotTo(HaveOccurred())
+	server := &miekgdns.Server{Listener: l, Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, r *miekgdns.Msg) {
+		resp := new(miekgdns.Msg)
+		resp.SetReply(r)
+		_ = w.WriteMsg(resp.SetTsig(testKeyName, miekgdns.HmacSHA256, tsigFudge, time.Now().Unix()))
+	}), TsigSecret: map[string]string{testKeyName: testSecret},
+		MsgAcceptFunc: func(miekgdns.Header) miekgdns.MsgAcceptAction { return miekgdns.MsgAccept }}
+	go func() { _ = server.ActivateAndServe() }()
+	defer func() { _ = server.Shutdown() }()
+	c := newClient(l.Addr().String(), other, 5*time.Second)
+	err = c.update(context.Background(), "example.org", nil, nil)
+	var tsigErr *TSIGError
+	Expect(err).To(BeAssignableToTypeOf(tsigErr))
+	Expect(classifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+}
+
+func TestNewTSIGKey(t *testing.T) {
+	RegisterTestingT(t)
+	key, err := newTSIGKey("External-DNS", "", testSecret)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(key).To(Equal(&tsigKey{name: "external-dns.", algorithm: miekgdns.HmacSHA256, secret: testSecret}))
+	key, err = newTSIGKey("external-dns", "HMAC-SHA512", " "+testSecret+"\n")
+	Expect(err).NotTo(HaveOccurred())
+	Expect(key.algorithm).To(Equal(miekgdns.HmacSHA512))
+	Expect(key.secret).To(Equal(testSecret))
+
+	_, err = newTSIGKey("external-dns", "gss-tsig", testSecret)
+	Expect(err).To(MatchError(ContainSubstring("gss-tsig is not supported")))
+	_, err = newTSIGKey("external-dns", "hmac-md5", testSecret)
+	Expect(err).To(MatchError(`unsupported TSIG algorithm "hmac-md5"`))
+	_, err = newTSIGKey("", "", testSecret)
+	Expect(err).To(MatchError("TSIG key name missing"))
+	_, err = newTSIGKey("external-dns", "", "no base64!")
+	Expect(err).To(MatchError(ContainSubstring("must be base64 encoded")))
+	_, err = newTSIGKey("external-dns", "", "")
+	Expect(err).To(MatchError("TSIG secret missing"))
+}
+
+func TestNewClient(t *testing.T) {
+	RegisterTestingT(t)
+	Expect(newClient("ns1.example.org", nil, time.Second).server).To(Equal("ns1.example.org:53"))
+	Expect(newClient("ns1.example.org:5353", nil, time.Second).server).To(Equal("ns1.example.org:5353"))
+	Expect(newClient("::1", nil, time.Second).server).To(Equal("[::1]:53"))
+}
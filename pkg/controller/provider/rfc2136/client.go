@@ -0,0 +1,131 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+// ResponseError is an error response of the DNS server.
+type ResponseError struct {
+	Rcode int
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("dns server responded with %s", rcodeName(e.Rcode))
+}
+
+func rcodeName(rcode int) string {
+	if name, ok := miekgdns.RcodeToString[rcode]; ok {
+		return name
+	}
+	return fmt.Sprintf("RCODE%d", rcode)
+}
+
+// client sends DNS messages to a DNS server using TCP, so that large zone transfers
+// and updates don't need a fallback for truncated UDP responses.
+type client struct {
+	server  string
+	key     *tsigKey
+	timeout time.Duration
+}
+
+func newClient(server string, key *tsigKey, timeout time.Duration) *client {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, "53")
+	}
+	return &client{server: server, key: key, timeout: timeout}
+}
+
+// transfer reads all records of a zone by a zone transfer (AXFR).
+// The records are returned without the SOA records framing the transfer.
+func (c *client) transfer(ctx context.Context, zone string) ([]miekgdns.RR, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.server)
+	if err != nil {
+		return nil, err
+	}
+	t := &miekgdns.Transfer{
+		Conn:         &miekgdns.Conn{Conn: conn},
+		ReadTimeout:  c.timeout,
+		WriteTimeout: c.timeout,
+		TsigSecret:   c.key.secrets(),
+	}
+	m := new(miekgdns.Msg)
+	m.SetAxfr(miekgdns.Fqdn(zone))
+	c.key.sign(m)
+	envelopes, err := t.In(m, c.server)
+	if err != nil {
+		conn.Close()
+		return nil, wrapTSIGError(err)
+	}
+
+	var records []miekgdns.RR
+	for e := range envelopes {
+		if e.Error != nil {
+			// drain the channel, the transfer is stopped after an error
+			for range envelopes {
+			}
+			return nil, transferError(e.Error)
+		}
+		for _, r := range e.RR {
+			if r.Header().Rrtype != miekgdns.TypeSOA {
+				records = append(records, r)
+			}
+		}
+	}
+	return records, nil
+}
+
+// transferError maps the error of a refused zone transfer to a ResponseError.
+// miekg/dns reports the response code of the first message of a transfer only as text.
+func transferError(err error) error {
+	var rcode int
+	if _, scanErr := fmt.Sscanf(err.Error(), "dns: bad xfr rcode: %d", &rcode); scanErr == nil {
+		return &ResponseError{Rcode: rcode}
+	}
+	return wrapTSIGError(err)
+}
+
+// update sends a dynamic update for a zone. The deletions are sent before the additions,
+// the DNS server applies the update atomically.
+func (c *client) update(ctx context.Context, zone string, deletions, additions []miekgdns.RR) error {
+	m := new(miekgdns.Msg)
+	m.SetUpdate(miekgdns.Fqdn(zone))
+	if len(deletions) > 0 {
+		m.Remove(deletions)
+	}
+	if len(additions) > 0 {
+		m.Insert(additions)
+	}
+	c.key.sign(m)
+
+	dc := &miekgdns.Client{Net: "tcp", Timeout: c.timeout, TsigSecret: c.key.secrets()}
+	resp, _, err := dc.ExchangeContext(ctx, m, c.server)
+	if err != nil {
+		return wrapTSIGError(err)
+	}
+	if resp.Rcode != miekgdns.RcodeSuccess {
+		return &ResponseError{Rcode: resp.Rcode}
+	}
+	return nil
+}
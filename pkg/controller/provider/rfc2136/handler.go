@@ -0,0 +1,100 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const requestTimeout = 60 * time.Second
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	server, err := c.GetRequiredProperty("RFC2136_SERVER", "server")
+	if err != nil {
+		return nil, err
+	}
+	zoneList, err := c.GetRequiredProperty("RFC2136_ZONES", "zones")
+	if err != nil {
+		return nil, err
+	}
+	var zones []string
+	for _, z := range strings.Split(zoneList, ",") {
+		if z = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(z), ".")); z != "" {
+			zones = append(zones, z)
+		}
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("no zones configured in property RFC2136_ZONES")
+	}
+
+	var key *tsigKey
+	keyName := c.GetProperty("RFC2136_TSIG_KEY_NAME", "tsigKeyName")
+	if keyName != "" {
+		secret, err := c.GetRequiredProperty("RFC2136_TSIG_SECRET", "tsigSecret")
+		if err != nil {
+			return nil, err
+		}
+		key, err = newTSIGKey(keyName, c.GetProperty("RFC2136_TSIG_ALGORITHM", "tsigAlgorithm"), secret)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		c.Logger.Warnf("no TSIG key configured, updates and zone transfers are not signed")
+	}
+
+	backend := &backend{
+		client: newClient(server, key, requestTimeout),
+		zones:  zones,
+	}
+	options := sdk.Options{
+		RecordTypes:   []string{dns.RS_SRV, dns.RS_CAA},
+		ClassifyError: classifyError,
+	}
+	return sdk.NewHandler(TYPE_CODE, c, backend, options)
+}
+
+// classifyError maps the response codes of the DNS server to error reasons.
+func classifyError(err error) perrs.ErrorReason {
+	var tsigErr *TSIGError
+	if errors.As(err, &tsigErr) {
+		return perrs.ReasonAuthFailed
+	}
+	var respErr *ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.Rcode {
+		case miekgdns.RcodeRefused, miekgdns.RcodeNotAuth:
+			return perrs.ReasonAuthFailed
+		case miekgdns.RcodeNotZone, miekgdns.RcodeNameError:
+			return perrs.ReasonNotFound
+		case miekgdns.RcodeYXDomain, miekgdns.RcodeYXRrset, miekgdns.RcodeNXRrset:
+			return perrs.ReasonConflict
+		case miekgdns.RcodeFormatError:
+			return perrs.ReasonInvalidRecord
+		}
+	}
+	return perrs.ReasonUnknown
+}
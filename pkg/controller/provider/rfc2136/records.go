@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+var recordTypes = map[string]uint16{
+	dns.RS_A:     miekgdns.TypeA,
+	dns.RS_AAAA:  miekgdns.TypeAAAA,
+	dns.RS_CNAME: miekgdns.TypeCNAME,
+	dns.RS_NS:    miekgdns.TypeNS,
+	dns.RS_TXT:   miekgdns.TypeTXT,
+	dns.RS_SRV:   miekgdns.TypeSRV,
+	dns.RS_CAA:   miekgdns.TypeCAA,
+}
+
+// Record is a resource record of a zone. The value is kept in presentation format.
+// Records have no identity on the DNS server, they are addressed by name, type and value.
+type Record struct {
+	Type  string
+	Name  string
+	Value string
+	TTL   int
+}
+
+var _ raw.Record = &Record{}
+
+func (r *Record) GetType() string    { return r.Type }
+func (r *Record) GetId() string      { return r.Name + " " + r.Type + " " + r.Value }
+func (r *Record) GetDNSName() string { return r.Name }
+func (r *Record) GetValue() string   { return r.Value }
+func (r *Record) GetTTL() int        { return r.TTL }
+func (r *Record) SetTTL(ttl int)     { r.TTL = ttl }
+func (r *Record) Copy() raw.Record   { n := *r; return &n }
+
+// recordFromRR converts a resource record of a zone transfer to the presentation
+// format used by the zone state. Records of unsupported types are ignored.
+func recordFromRR(rr miekgdns.RR) *Record {
+	hdr := rr.Header()
+	if hdr.Class != miekgdns.ClassINET {
+		return nil
+	}
+	var rtype, value string
+	switch r := rr.(type) {
+	case *miekgdns.A:
+		rtype, value = dns.RS_A, r.A.String()
+	case *miekgdns.AAAA:
+		rtype, value = dns.RS_AAAA, r.AAAA.String()
+	case *miekgdns.CNAME:
+		rtype, value = dns.RS_CNAME, strings.TrimSuffix(r.Target, ".")
+	case *miekgdns.NS:
+		rtype, value = dns.RS_NS, strings.TrimSuffix(r.Ns, ".")
+	case *miekgdns.TXT:
+		// the character strings are already escaped by miekg/dns
+		chunks := make([]string, len(r.Txt))
+		for i, c := range r.Txt {
+			chunks[i] = "\"" + c + "\""
+		}
+		rtype, value = dns.RS_TXT, strings.Join(chunks, " ")
+	case *miekgdns.SRV:
+		target := r.Target
+		if target != "." {
+			target = strings.TrimSuffix(target, ".")
+		}
+		rtype, value = dns.RS_SRV, fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, target)
+	case *miekgdns.CAA:
+		rtype, value = dns.RS_CAA, dns.CAAValue(int(r.Flag), r.Tag, r.Value)
+	default:
+		return nil
+	}
+	return &Record{
+		Type:  rtype,
+		Name:  strings.ToLower(strings.TrimSuffix(hdr.Name, ".")),
+		Value: value,
+		TTL:   int(hdr.Ttl),
+	}
+}
+
+// rr returns the resource record for an update. The class and TTL of deletions
+// are set by the update message.
+func (r *Record) rr() (miekgdns.RR, error) {
+	rtype, ok := recordTypes[r.Type]
+	if !ok {
+		return nil, fmt.Errorf("unsupported record type %s", r.Type)
+	}
+	hdr := miekgdns.RR_Header{Name: miekgdns.Fqdn(r.Name), Rrtype: rtype, Class: miekgdns.ClassINET, Ttl: uint32(r.TTL)}
+	rr, err := r.rdata(hdr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s record %s: %w", r.Type, r.Name, err)
+	}
+	return rr, nil
+}
+
+// rdata maps a value in presentation format to the resource record.
+func (r *Record) rdata(hdr miekgdns.RR_Header) (miekgdns.RR, error) {
+	switch hdr.Rrtype {
+	case miekgdns.TypeA:
+		ip := net.ParseIP(r.Value).To4()
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IPv4 address %q", r.Value)
+		}
+		return &miekgdns.A{Hdr: hdr, A: ip}, nil
+	case miekgdns.TypeAAAA:
+		ip := net.ParseIP(r.Value)
+		if ip == nil || ip.To4() != nil {
+			return nil, fmt.Errorf("invalid IPv6 address %q", r.Value)
+		}
+		return &miekgdns.AAAA{Hdr: hdr, AAAA: ip}, nil
+	case miekgdns.TypeCNAME:
+		return &miekgdns.CNAME{Hdr: hdr, Target: miekgdns.Fqdn(r.Value)}, nil
+	case miekgdns.TypeNS:
+		return &miekgdns.NS{Hdr: hdr, Ns: miekgdns.Fqdn(r.Value)}, nil
+	case miekgdns.TypeTXT:
+		chunks, err := dns.SplitTextValue(r.Value)
+		if err != nil {
+			chunks = []string{r.Value}
+		}
+		txt := make([]string, len(chunks))
+		for i, c := range chunks {
+			if len(c) > dns.TXT_CHUNK_SIZE {
+				return nil, fmt.Errorf("character string of TXT record too long")
+			}
+			// backslashes start escape sequences for miekg/dns
+			txt[i] = strings.ReplaceAll(c, "\\", "\\\\")
+		}
+		return &miekgdns.TXT{Hdr: hdr, Txt: txt}, nil
+	case miekgdns.TypeSRV:
+		priority, weight, port, target, err := dns.ParseSRVValue(r.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &miekgdns.SRV{Hdr: hdr, Priority: uint16(priority), Weight: uint16(weight), Port: uint16(port),
+			Target: miekgdns.Fqdn(target)}, nil
+	case miekgdns.TypeCAA:
+		flags, tag, value, err := dns.ParseCAAValue(r.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &miekgdns.CAA{Hdr: hdr, Flag: uint8(flags), Tag: strings.ToLower(tag), Value: value}, nil
+	}
+	return nil, fmt.Errorf("unsupported record type %d", hdr.Rrtype)
+}
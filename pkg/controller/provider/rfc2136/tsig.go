@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package rfc2136
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+const (
+	defaultAlgorithm = "hmac-sha256"
+	gssTSIGAlgorithm = "gss-tsig"
+	tsigFudge        = 300
+)
+
+var tsigAlgorithms = map[string]bool{
+	miekgdns.HmacSHA1:   true,
+	miekgdns.HmacSHA224: true,
+	miekgdns.HmacSHA256: true,
+	miekgdns.HmacSHA384: true,
+	miekgdns.HmacSHA512: true,
+}
+
+// tsigKey is a shared secret used to sign the messages (TSIG, RFC 8945).
+type tsigKey struct {
+	name      string
+	algorithm string
+	secret    string
+}
+
+func newTSIGKey(name, algorithm, secret string) (*tsigKey, error) {
+	if algorithm == "" {
+		algorithm = defaultAlgorithm
+	}
+	algorithm = miekgdns.CanonicalName(algorithm)
+	if strings.TrimSuffix(algorithm, ".") == gssTSIGAlgorithm {
+		return nil, fmt.Errorf("TSIG algorithm %s is not supported, use a TSIG key with a HMAC algorithm", gssTSIGAlgorithm)
+	}
+	if !tsigAlgorithms[algorithm] {
+		return nil, fmt.Errorf("unsupported TSIG algorithm %q", strings.TrimSuffix(algorithm, "."))
+	}
+	if name == "" {
+		return nil, fmt.Errorf("TSIG key name missing")
+	}
+	secret = strings.TrimSpace(secret)
+	data, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("TSIG secret must be base64 encoded: %w", err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("TSIG secret missing")
+	}
+	return &tsigKey{name: miekgdns.CanonicalName(name), algorithm: algorithm, secret: secret}, nil
+}
+
+// secrets returns the key in the form expected by the clients of miekg/dns.
+func (k *tsigKey) secrets() map[string]string {
+	if k == nil {
+		return nil
+	}
+	return map[string]string{k.name: k.secret}
+}
+
+// sign adds a TSIG record to the message, it is signed when the message is sent.
+func (k *tsigKey) sign(m *miekgdns.Msg) {
+	if k != nil {
+		m.SetTsig(k.name, k.algorithm, tsigFudge, time.Now().Unix())
+	}
+}
+
+// TSIGError is a failed TSIG verification of a response.
+type TSIGError struct {
+	Err error
+}
+
+func (e *TSIGError) Error() string {
+	return fmt.Sprintf("TSIG verification failed: %s", e.Err)
+}
+
+func (e *TSIGError) Unwrap() error {
+	return e.Err
+}
+
+// wrapTSIGError marks the errors of miekg/dns caused by the TSIG verification.
+func wrapTSIGError(err error) error {
+	for _, e := range []error{miekgdns.ErrSig, miekgdns.ErrTime, miekgdns.ErrSecret, miekgdns.ErrKeyAlg} {
+		if errors.Is(err, e) {
+			return &TSIGError{Err: err}
+		}
+	}
+	return err
+}
@@ -0,0 +1,289 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package scaleway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const (
+	defaultEndpoint = "https://api.scaleway.com/domain/v2beta1/"
+	pageSize        = 100
+)
+
+// DNSZone is a DNS zone of the Scaleway Domains API.
+// Zones of subdomains are reported with the registered domain and the subdomain.
+type DNSZone struct {
+	Domain    string `json:"domain"`
+	Subdomain string `json:"subdomain"`
+}
+
+// Name returns the fully qualified name of the zone, which is used as zone id by the API.
+func (z *DNSZone) Name() string {
+	if z.Subdomain == "" {
+		return z.Domain
+	}
+	return z.Subdomain + "." + z.Domain
+}
+
+type zonesPage struct {
+	DNSZones   []*DNSZone `json:"dns_zones"`
+	TotalCount int        `json:"total_count"`
+}
+
+type recordsPage struct {
+	Records    []*Record `json:"records"`
+	TotalCount int       `json:"total_count"`
+}
+
+// change is a single change of an update request, exactly one of the fields is set.
+type change struct {
+	Add    *addChange    `json:"add,omitempty"`
+	Set    *setChange    `json:"set,omitempty"`
+	Delete *deleteChange `json:"delete,omitempty"`
+}
+
+type addChange struct {
+	Records []*Record `json:"records"`
+}
+
+type setChange struct {
+	ID      string    `json:"id"`
+	Records []*Record `json:"records"`
+}
+
+type deleteChange struct {
+	ID string `json:"id"`
+}
+
+type updateRequest struct {
+	Changes                 []*change `json:"changes"`
+	DisallowNewZoneCreation bool      `json:"disallow_new_zone_creation"`
+	ReturnAllRecords        bool      `json:"return_all_records"`
+}
+
+// APIError is an error response of the Scaleway API.
+type APIError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.Type != "" {
+		return fmt.Sprintf("scaleway api error %d (%s): %s", e.StatusCode, e.Type, e.Message)
+	}
+	return fmt.Sprintf("scaleway api error %d: %s", e.StatusCode, e.Message)
+}
+
+type backend struct {
+	endpoint  *url.URL
+	secretKey string
+	projectID string
+	http      *http.Client
+}
+
+var _ sdk.Backend = &backend{}
+var _ sdk.BatchBackend = &backend{}
+
+func newBackend(endpoint, secretKey, projectID string) (*backend, error) {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Scaleway endpoint %q: %w", endpoint, err)
+	}
+	return &backend{
+		endpoint:  u,
+		secretKey: secretKey,
+		projectID: projectID,
+		http:      &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+func (this *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	query := url.Values{}
+	if this.projectID != "" {
+		query.Set("project_id", this.projectID)
+	}
+	zones := []sdk.Zone{}
+	for no := 1; ; no++ {
+		p := &zonesPage{}
+		if err := this.do(ctx, http.MethodGet, pagePath("dns-zones", query, no), nil, p); err != nil {
+			return nil, err
+		}
+		for _, z := range p.DNSZones {
+			zones = append(zones, sdk.Zone{ID: z.Name(), Domain: z.Name()})
+		}
+		if len(p.DNSZones) == 0 || no*pageSize >= p.TotalCount {
+			return zones, nil
+		}
+	}
+}
+
+func (this *backend) ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error) {
+	rs := raw.RecordSet{}
+	for no := 1; ; no++ {
+		p := &recordsPage{}
+		if err := this.do(ctx, http.MethodGet, pagePath(recordsPath(zoneKey), nil, no), nil, p); err != nil {
+			return nil, err
+		}
+		for _, r := range p.Records {
+			r.zone = zoneKey
+			rs = append(rs, r)
+		}
+		if len(p.Records) == 0 || no*pageSize >= p.TotalCount {
+			return rs, nil
+		}
+	}
+}
+
+func (this *backend) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	r := &Record{
+		Type: rtype,
+		Name: relativeName(fqdn, zone.Domain()),
+		TTL:  int(ttl),
+		zone: zone.Key(),
+	}
+	r.setValue(value)
+	return r
+}
+
+func (this *backend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.ApplyBatch(ctx, &raw.Batch{Additions: raw.RecordSet{r}}, zone)
+}
+
+func (this *backend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.ApplyBatch(ctx, &raw.Batch{Updates: raw.RecordSet{r}}, zone)
+}
+
+func (this *backend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	return this.ApplyBatch(ctx, &raw.Batch{Deletions: raw.RecordSet{r}}, zone)
+}
+
+// ApplyBatch submits all changes of the batch with a single update request, which is applied
+// atomically by the Scaleway API. Deletions are applied first, so that record sets can change
+// their type (e.g. from A to CNAME). Existing records are updated by their id.
+func (this *backend) ApplyBatch(ctx context.Context, batch *raw.Batch, zone provider.DNSHostedZone) error {
+	var changes []*change
+	for _, r := range batch.Deletions {
+		changes = append(changes, &change{Delete: &deleteChange{ID: r.GetId()}})
+	}
+	for _, r := range batch.Updates {
+		changes = append(changes, &change{Set: &setChange{ID: r.GetId(), Records: []*Record{r.(*Record).request()}}})
+	}
+	if len(batch.Additions) > 0 {
+		add := &addChange{}
+		for _, r := range batch.Additions {
+			add.Records = append(add.Records, r.(*Record).request())
+		}
+		changes = append(changes, &change{Add: add})
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	req := &updateRequest{Changes: changes, DisallowNewZoneCreation: true}
+	return this.do(ctx, http.MethodPatch, recordsPath(zone.Key()), req, nil)
+}
+
+func recordsPath(zone string) string {
+	return fmt.Sprintf("dns-zones/%s/records", url.PathEscape(zone))
+}
+
+func pagePath(path string, query url.Values, no int) string {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("page", strconv.Itoa(no))
+	q.Set("page_size", strconv.Itoa(pageSize))
+	return path + "?" + q.Encode()
+}
+
+func (this *backend) do(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	u, err := this.endpoint.Parse(path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Auth-Token", this.secretKey)
+	req.Header.Set("User-Agent", "external-dns-manager")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := this.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := newAPIError(resp.StatusCode, data)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: sdk.RetryAfter(resp.Header), Err: apiErr}
+		}
+		return apiErr
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+// newAPIError extracts the error of a response of the form `{"type": "...", "message": "..."}`.
+func newAPIError(status int, data []byte) *APIError {
+	msg := struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	}{}
+	if json.Unmarshal(data, &msg) != nil || msg.Message == "" {
+		return &APIError{StatusCode: status, Type: msg.Type, Message: strings.TrimSpace(string(data))}
+	}
+	return &APIError{StatusCode: status, Type: msg.Type, Message: msg.Message}
+}
@@ -0,0 +1,209 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package scaleway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+type testServer struct {
+	zones    []*DNSZone
+	records  map[string][]*Record
+	requests []*updateRequest
+	status   int
+	header   http.Header
+	body     string
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Auth-Token") != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"type":"denied_authentication","message":"authentication is denied"}`))
+		return
+	}
+	if s.status != 0 {
+		for k, v := range s.header {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(s.status)
+		_, _ = w.Write([]byte(s.body))
+		return
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/dns-zones":
+		var zones []*DNSZone
+		for _, z := range s.zones {
+			if p := r.URL.Query().Get("project_id"); p == "" || p == "project" {
+				zones = append(zones, z)
+			}
+		}
+		start, end := bounds(len(zones), page, pageSize)
+		writeJSON(w, &zonesPage{DNSZones: zones[start:end], TotalCount: len(zones)})
+	case r.Method == http.MethodGet:
+		records := s.records[r.URL.Path]
+		start, end := bounds(len(records), page, pageSize)
+		writeJSON(w, &recordsPage{Records: records[start:end], TotalCount: len(records)})
+	case r.Method == http.MethodPatch:
+		req := &updateRequest{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.requests = append(s.requests, req)
+		writeJSON(w, &recordsPage{})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// bounds returns the start and end index of a page of a list with the given length.
+func bounds(length, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start > length {
+		start = length
+	}
+	end := start + pageSize
+	if end > length {
+		end = length
+	}
+	return start, end
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestBackend(t *testing.T, s *testServer, secretKey string) *backend {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	b, err := newBackend(server.URL, secretKey, "project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestListZonesAndRecords(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{records: map[string][]*Record{}}
+	for i := 0; i < pageSize+5; i++ {
+		s.zones = append(s.zones, &DNSZone{Domain: "example.com", Subdomain: "sub" + strconv.Itoa(i)})
+	}
+	s.zones = append(s.zones, &DNSZone{Domain: "example.org"})
+	s.records["/dns-zones/example.org/records"] = []*Record{
+		{ID: "1", Type: "A", Name: "", Data: "1.2.3.4", TTL: 300},
+		{ID: "2", Type: "CNAME", Name: "www", Data: "example.org.", TTL: 300},
+		{ID: "3", Type: "TXT", Name: "comment", Data: "\"hello\"", TTL: 600},
+	}
+	b := newTestBackend(t, s, "secret")
+
+	zones, err := b.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(zones).To(HaveLen(pageSize + 6))
+	Expect(zones[0].ID).To(Equal("sub0.example.com"))
+	Expect(zones[pageSize+5].Domain).To(Equal("example.org"))
+
+	records, err := b.ListRecords(context.Background(), "example.org")
+	Expect(err).To(BeNil())
+	Expect(records).To(HaveLen(3))
+	Expect(records[0].GetDNSName()).To(Equal("example.org"))
+	Expect(records[1].GetDNSName()).To(Equal("www.example.org"))
+	Expect(records[1].GetValue()).To(Equal("example.org"))
+	Expect(records[2].GetValue()).To(Equal("\"hello\""))
+	Expect(records[2].GetTTL()).To(Equal(600))
+}
+
+func TestApplyBatch(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{}
+	b := newTestBackend(t, s, "secret")
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.org", "example.org", "example.org", nil, false)
+
+	old := &Record{ID: "1", Type: "A", Name: "a", Data: "1.2.3.4", TTL: 300, zone: "example.org"}
+	updated := old.Copy()
+	updated.SetTTL(600)
+	batch := &raw.Batch{
+		Additions: raw.RecordSet{b.NewRecord("www.example.org", "CNAME", "example.org", zone, 120)},
+		Updates:   raw.RecordSet{updated},
+		Deletions: raw.RecordSet{&Record{ID: "2", Type: "TXT", Name: "b", Data: "\"x\"", TTL: 300}},
+	}
+	Expect(b.ApplyBatch(context.Background(), batch, zone)).To(Succeed())
+	Expect(s.requests).To(HaveLen(1))
+
+	req := s.requests[0]
+	Expect(req.DisallowNewZoneCreation).To(BeTrue())
+	Expect(req.Changes).To(HaveLen(3))
+	Expect(req.Changes[0].Delete).To(Equal(&deleteChange{ID: "2"}))
+	Expect(req.Changes[1].Set).To(Equal(&setChange{ID: "1", Records: []*Record{{Type: "A", Name: "a", Data: "1.2.3.4", TTL: 600}}}))
+	Expect(req.Changes[2].Add).To(Equal(&addChange{Records: []*Record{{Type: "CNAME", Name: "www", Data: "example.org.", TTL: 120}}}))
+
+	Expect(b.ApplyBatch(context.Background(), &raw.Batch{}, zone)).To(Succeed())
+	Expect(s.requests).To(HaveLen(1))
+}
+
+func TestErrors(t *testing.T) {
+	RegisterTestingT(t)
+
+	b := newTestBackend(t, &testServer{}, "invalid")
+	_, err := b.ListZones(context.Background())
+	Expect(err).NotTo(BeNil())
+	Expect(classifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+
+	s := &testServer{status: http.StatusTooManyRequests, header: http.Header{"Retry-After": []string{"3"}}, body: `{"type":"too_many_requests","message":"rate limit exceeded"}`}
+	b = newTestBackend(t, s, "secret")
+	_, err = b.ListZones(context.Background())
+	Expect(perrs.IsThrottlingError(err)).To(BeTrue())
+	var perr *perrs.ProviderError
+	Expect(err).To(BeAssignableToTypeOf(perr))
+	Expect(err.(*perrs.ProviderError).RetryAfter).To(Equal(3 * time.Second))
+
+	s = &testServer{status: http.StatusForbidden, body: `{"type":"quotas_exceeded","message":"quota exceeded"}`}
+	b = newTestBackend(t, s, "secret")
+	_, err = b.ListRecords(context.Background(), "example.org")
+	Expect(classifyError(err)).To(Equal(perrs.ReasonQuotaExceeded))
+	Expect(isAccessForbidden(err)).To(BeFalse())
+
+	s = &testServer{status: http.StatusForbidden, body: `{"type":"permissions_denied","message":"insufficient permissions"}`}
+	b = newTestBackend(t, s, "secret")
+	_, err = b.ListRecords(context.Background(), "example.org")
+	Expect(classifyError(err)).To(Equal(perrs.ReasonAuthFailed))
+	Expect(isAccessForbidden(err)).To(BeTrue())
+}
+
+func TestValidateRegion(t *testing.T) {
+	RegisterTestingT(t)
+
+	Expect(validateRegion("nl-ams")).To(Succeed())
+	Expect(validateRegion("us-east-1")).NotTo(Succeed())
+}
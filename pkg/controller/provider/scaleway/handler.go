@@ -0,0 +1,89 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package scaleway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const (
+	defaultRegion = "fr-par"
+	// batchSize is the maximum number of record changes submitted with a single request
+	batchSize = 100
+)
+
+// regions are the regions of Scaleway. The Domains API itself is global, the region
+// is only validated to detect credentials configured for an unknown region.
+var regions = []string{"fr-par", "nl-ams", "pl-waw"}
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	secretKey, err := c.GetRequiredProperty("SCW_SECRET_KEY", "secretKey")
+	if err != nil {
+		return nil, err
+	}
+	projectID := c.GetProperty("SCW_DEFAULT_PROJECT_ID", "projectId")
+	region := c.GetDefaultedProperty("SCW_DEFAULT_REGION", defaultRegion, "region")
+	if err := validateRegion(region); err != nil {
+		return nil, err
+	}
+	endpoint := c.GetProperty("SCW_API_URL", "endpoint")
+
+	backend, err := newBackend(endpoint, secretKey, projectID)
+	if err != nil {
+		return nil, err
+	}
+	options := sdk.Options{
+		RecordTypes:   []string{dns.RS_CAA},
+		SkipZone:      isAccessForbidden,
+		BatchSize:     batchSize,
+		ClassifyError: classifyError,
+	}
+	return sdk.NewHandler(TYPE_CODE, c, backend, options)
+}
+
+func validateRegion(region string) error {
+	for _, r := range regions {
+		if r == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid Scaleway region %q (supported: %v)", region, regions)
+}
+
+// classifyError maps the HTTP status code of the Scaleway API responses to error reasons.
+func classifyError(err error) perrs.ErrorReason {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Type == "quotas_exceeded" {
+			return perrs.ReasonQuotaExceeded
+		}
+		return perrs.ReasonForHTTPStatus(apiErr.StatusCode)
+	}
+	return perrs.ReasonUnknown
+}
+
+// isAccessForbidden checks for zones not accessible with the permission sets of the API key.
+func isAccessForbidden(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusForbidden && apiErr.Type != "quotas_exceeded"
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package scaleway
+
+import (
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+// Record is a record of the Scaleway Domains API.
+// The name is relative to the zone, the empty name denotes the apex of the zone.
+// Targets of CNAME and NS records are fully qualified with a trailing dot.
+type Record struct {
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	Data string `json:"data"`
+	TTL  int    `json:"ttl"`
+
+	zone string
+}
+
+var _ raw.Record = &Record{}
+
+func (r *Record) GetType() string  { return r.Type }
+func (r *Record) GetId() string    { return r.ID }
+func (r *Record) GetTTL() int      { return r.TTL }
+func (r *Record) SetTTL(ttl int)   { r.TTL = ttl }
+func (r *Record) Copy() raw.Record { n := *r; return &n }
+
+func (r *Record) GetDNSName() string {
+	if r.Name == "" || r.Name == "@" {
+		return r.zone
+	}
+	return r.Name + "." + r.zone
+}
+
+func (r *Record) GetValue() string {
+	switch r.Type {
+	case dns.RS_CNAME, dns.RS_NS:
+		return strings.TrimSuffix(r.Data, ".")
+	}
+	return sdk.NormalizeValue(r.Type, r.Data)
+}
+
+// setValue maps a value in presentation format to the data field.
+func (r *Record) setValue(value string) {
+	switch r.Type {
+	case dns.RS_CNAME, dns.RS_NS:
+		value = strings.TrimSuffix(value, ".") + "."
+	}
+	r.Data = value
+}
+
+// request returns the record without id as used in the changes of an update request.
+func (r *Record) request() *Record {
+	return &Record{
+		Type: r.Type,
+		Name: r.Name,
+		Data: r.Data,
+		TTL:  r.TTL,
+	}
+}
+
+// relativeName returns the name of a record relative to the domain of the zone.
+func relativeName(fqdn, domain string) string {
+	if fqdn == domain {
+		return ""
+	}
+	return strings.TrimSuffix(fqdn, "."+domain)
+}
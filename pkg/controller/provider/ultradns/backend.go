@@ -0,0 +1,93 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package ultradns
+
+import (
+	"context"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+// backend maps the rrsets of the UltraDNS API to the sdk.
+type backend struct {
+	client *client
+	logger logger.LogContext
+}
+
+var _ sdk.RecordSetBackend = &backend{}
+
+func (b *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	list, err := b.client.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	zones := []sdk.Zone{}
+	for _, z := range list {
+		// UltraDNS addresses zones by their fully qualified name
+		name := z.Properties.Name
+		id := dns.NormalizeHostname(name)
+		zones = append(zones, sdk.Zone{ID: id, Domain: id, Key: dns.AlignHostname(name)})
+	}
+	return zones, nil
+}
+
+func (b *backend) ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error) {
+	rrsets, err := b.client.ListRRSets(ctx, zoneKey)
+	if err != nil {
+		return nil, err
+	}
+	records := raw.RecordSet{}
+	for _, rrset := range rrsets {
+		rtype := rrType(rrset.RRType)
+		order, ok := poolOrder(rrset)
+		if !ok {
+			// pools with health checks are maintained outside of the dns-controller-manager
+			b.logger.Infof("ignoring %s rrset %s with unsupported pool profile", rtype, dns.NormalizeHostname(rrset.OwnerName))
+			continue
+		}
+		records = append(records, newRecords(rtype, rrset, order)...)
+	}
+	return records, nil
+}
+
+func (b *backend) ApplyRecordSetChange(ctx context.Context, change *sdk.RecordSetChange, zone provider.DNSHostedZone) error {
+	rs := change.RecordSet
+	owner := dns.AlignHostname(change.Name)
+	switch change.Action {
+	case provider.R_CREATE:
+		return b.client.CreateRRSet(ctx, zone.Key(), rs.Type, owner, buildRRSet(owner, rs))
+	case provider.R_UPDATE:
+		if isPool(change.Old) && !isPool(rs) {
+			// the profile of a pool is not removed by an update, the rrset is replaced
+			if err := b.client.DeleteRRSet(ctx, zone.Key(), rs.Type, owner); err != nil && !IsNotFound(err) {
+				return err
+			}
+			return b.client.CreateRRSet(ctx, zone.Key(), rs.Type, owner, buildRRSet(owner, rs))
+		}
+		return b.client.UpdateRRSet(ctx, zone.Key(), rs.Type, owner, buildRRSet(owner, rs))
+	default:
+		err := b.client.DeleteRRSet(ctx, zone.Key(), rs.Type, owner)
+		if IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+}
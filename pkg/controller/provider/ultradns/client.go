@@ -0,0 +1,350 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package ultradns
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+const (
+	defaultEndpoint = "https://api.ultradns.com/"
+	pageSize        = 1000
+	// tokenExpiryMargin is the time before the expiry of an access token it is renewed
+	tokenExpiryMargin = 60 * time.Second
+)
+
+// Zone is a zone as returned by the zone list of the UltraDNS API.
+type Zone struct {
+	Properties struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"properties"`
+}
+
+type resultInfo struct {
+	TotalCount    int `json:"totalCount"`
+	Offset        int `json:"offset"`
+	ReturnedCount int `json:"returnedCount"`
+}
+
+type zoneList struct {
+	Zones      []*Zone    `json:"zones"`
+	ResultInfo resultInfo `json:"resultInfo"`
+}
+
+// RRSet is a resource record set of a zone. The owner name is fully qualified, the type is reported
+// with its numeric value (e.g. `A (1)`). Pools are described by the profile of the record set.
+type RRSet struct {
+	OwnerName string                 `json:"ownerName,omitempty"`
+	RRType    string                 `json:"rrtype,omitempty"`
+	TTL       int64                  `json:"ttl"`
+	RData     []string               `json:"rdata"`
+	Profile   map[string]interface{} `json:"profile,omitempty"`
+}
+
+type rrsetList struct {
+	RRSets     []*RRSet   `json:"rrSets"`
+	ResultInfo resultInfo `json:"resultInfo"`
+}
+
+// APIError is an error response of the UltraDNS API.
+type APIError struct {
+	StatusCode int
+	ErrorCode  int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	if e.ErrorCode != 0 {
+		return fmt.Sprintf("ultradns api error %d (code %d): %s", e.StatusCode, e.ErrorCode, e.Message)
+	}
+	return fmt.Sprintf("ultradns api error %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound checks whether an error is an UltraDNS API error reporting a missing zone or record set.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// credentials are either a username and password used to request access tokens,
+// or a static access token.
+type credentials struct {
+	username string
+	password string
+	token    string
+}
+
+type client struct {
+	endpoint    *url.URL
+	credentials credentials
+	http        *http.Client
+
+	lock         sync.Mutex
+	accessToken  string
+	refreshToken string
+	expiry       time.Time
+}
+
+func newClient(endpoint string, credentials credentials) (*client, error) {
+	if endpoint == "" {
+		endpoint = defaultEndpoint
+	}
+	if !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid UltraDNS endpoint %q: %w", endpoint, err)
+	}
+	return &client{
+		endpoint:    u,
+		credentials: credentials,
+		http:        &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+func (c *client) ListZones(ctx context.Context) ([]*Zone, error) {
+	var zones []*Zone
+	for offset := 0; ; {
+		list := &zoneList{}
+		query := url.Values{"q": []string{"zone_type:PRIMARY"}}
+		if err := c.do(ctx, http.MethodGet, pagePath("zones", query, offset), nil, list); err != nil {
+			return nil, err
+		}
+		zones = append(zones, list.Zones...)
+		offset += len(list.Zones)
+		if len(list.Zones) == 0 || offset >= list.ResultInfo.TotalCount {
+			return zones, nil
+		}
+	}
+}
+
+func (c *client) ListRRSets(ctx context.Context, zone string) ([]*RRSet, error) {
+	var rrsets []*RRSet
+	for offset := 0; ; {
+		list := &rrsetList{}
+		if err := c.do(ctx, http.MethodGet, pagePath(zonePath(zone)+"/rrsets", nil, offset), nil, list); err != nil {
+			return nil, err
+		}
+		rrsets = append(rrsets, list.RRSets...)
+		offset += len(list.RRSets)
+		if len(list.RRSets) == 0 || offset >= list.ResultInfo.TotalCount {
+			return rrsets, nil
+		}
+	}
+}
+
+func (c *client) CreateRRSet(ctx context.Context, zone, rtype, owner string, rrset *RRSet) error {
+	return c.do(ctx, http.MethodPost, rrsetPath(zone, rtype, owner), rrset, nil)
+}
+
+func (c *client) UpdateRRSet(ctx context.Context, zone, rtype, owner string, rrset *RRSet) error {
+	return c.do(ctx, http.MethodPut, rrsetPath(zone, rtype, owner), rrset, nil)
+}
+
+func (c *client) DeleteRRSet(ctx context.Context, zone, rtype, owner string) error {
+	return c.do(ctx, http.MethodDelete, rrsetPath(zone, rtype, owner), nil, nil)
+}
+
+func zonePath(zone string) string {
+	return "zones/" + url.PathEscape(zone)
+}
+
+func rrsetPath(zone, rtype, owner string) string {
+	return fmt.Sprintf("%s/rrsets/%s/%s", zonePath(zone), url.PathEscape(rtype), url.PathEscape(owner))
+}
+
+func pagePath(path string, query url.Values, offset int) string {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(pageSize))
+	return path + "?" + q.Encode()
+}
+
+// do sends a request with a valid access token. If the token is rejected, it is renewed
+// once with the username and password.
+func (c *client) do(ctx context.Context, method, path string, in, out interface{}) error {
+	token, err := c.token(ctx, false)
+	if err != nil {
+		return err
+	}
+	err = c.send(ctx, method, path, token, in, out)
+	if apiErr, ok := err.(*APIError); ok && apiErr.StatusCode == http.StatusUnauthorized && c.credentials.token == "" {
+		if token, err = c.token(ctx, true); err != nil {
+			return err
+		}
+		err = c.send(ctx, method, path, token, in, out)
+	}
+	return err
+}
+
+func (c *client) send(ctx context.Context, method, path, token string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	u, err := c.endpoint.Parse(path)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return c.roundTrip(req, out)
+}
+
+func (c *client) roundTrip(req *http.Request, out interface{}) error {
+	req.Header.Set("User-Agent", "external-dns-manager")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := newAPIError(resp.StatusCode, data)
+		if resp.StatusCode == http.StatusTooManyRequests {
+			return &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: sdk.RetryAfter(resp.Header), Err: apiErr}
+		}
+		return apiErr
+	}
+	if out != nil && len(data) > 0 {
+		return json.Unmarshal(data, out)
+	}
+	return nil
+}
+
+type tokenResponse struct {
+	AccessToken  string          `json:"accessToken"`
+	RefreshToken string          `json:"refreshToken"`
+	ExpiresIn    json.RawMessage `json:"expiresIn"`
+}
+
+// token returns the access token, which is requested or refreshed if it is expired or renewal is forced.
+// A refresh token is used before falling back to the password grant.
+func (c *client) token(ctx context.Context, renew bool) (string, error) {
+	if c.credentials.token != "" {
+		return c.credentials.token, nil
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if !renew && c.accessToken != "" && time.Now().Add(tokenExpiryMargin).Before(c.expiry) {
+		return c.accessToken, nil
+	}
+	if c.refreshToken != "" && !renew {
+		err := c.requestToken(ctx, url.Values{"grant_type": []string{"refresh_token"}, "refresh_token": []string{c.refreshToken}})
+		if err == nil {
+			return c.accessToken, nil
+		}
+	}
+	err := c.requestToken(ctx, url.Values{
+		"grant_type": []string{"password"},
+		"username":   []string{c.credentials.username},
+		"password":   []string{c.credentials.password},
+	})
+	if err != nil {
+		c.accessToken, c.refreshToken = "", ""
+		return "", fmt.Errorf("authentication of user %s failed: %w", c.credentials.username, err)
+	}
+	return c.accessToken, nil
+}
+
+func (c *client) requestToken(ctx context.Context, form url.Values) error {
+	u, err := c.endpoint.Parse("authorization/token")
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp := &tokenResponse{}
+	if err := c.roundTrip(req, resp); err != nil {
+		return err
+	}
+	if resp.AccessToken == "" {
+		return fmt.Errorf("no access token returned")
+	}
+	expiresIn, err := strconv.Atoi(strings.Trim(string(resp.ExpiresIn), `"`))
+	if err != nil || expiresIn <= 0 {
+		expiresIn = 3600
+	}
+	c.accessToken = resp.AccessToken
+	c.refreshToken = resp.RefreshToken
+	c.expiry = time.Now().Add(time.Duration(expiresIn) * time.Second)
+	return nil
+}
+
+// newAPIError extracts the error of a response. UltraDNS reports errors as list of the form
+// `[{"errorCode": 123, "errorMessage": "..."}]`, the authorization endpoint as single object.
+func newAPIError(status int, data []byte) *APIError {
+	type errorMessage struct {
+		ErrorCode        int    `json:"errorCode"`
+		ErrorMessage     string `json:"errorMessage"`
+		ErrorDescription string `json:"error_description"`
+	}
+	var msgs []errorMessage
+	if json.Unmarshal(data, &msgs) != nil {
+		msg := errorMessage{}
+		if json.Unmarshal(data, &msg) == nil {
+			msgs = []errorMessage{msg}
+		}
+	}
+	apiErr := &APIError{StatusCode: status, Message: strings.TrimSpace(string(data))}
+	if len(msgs) > 0 {
+		if msgs[0].ErrorMessage != "" {
+			apiErr.Message = msgs[0].ErrorMessage
+		} else if msgs[0].ErrorDescription != "" {
+			apiErr.Message = msgs[0].ErrorDescription
+		}
+		apiErr.ErrorCode = msgs[0].ErrorCode
+	}
+	return apiErr
+}
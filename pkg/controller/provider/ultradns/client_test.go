@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package ultradns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+type testServer struct {
+	tokens    int
+	expired   bool
+	throttled bool
+	rrsets    []*RRSet
+	requests  []string
+}
+
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	if r.URL.Path == "/authorization/token" {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "password" || r.Form.Get("username") != "user" || r.Form.Get("password") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":60001,"errorMessage":"invalid_grant:Invalid username & password combination.","error":"invalid_grant"}`))
+			return
+		}
+		s.tokens++
+		s.expired = false
+		writeJSON(w, map[string]interface{}{"accessToken": "token" + strconv.Itoa(s.tokens), "refreshToken": "refresh", "expiresIn": "3600"})
+		return
+	}
+	if s.expired || r.Header.Get("Authorization") != "Bearer token"+strconv.Itoa(s.tokens) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`[{"errorCode":60001,"errorMessage":"invalid_grant:token not found, expired or invalid"}]`))
+		return
+	}
+	if s.throttled {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`[{"errorCode":429,"errorMessage":"Too many requests"}]`))
+		return
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	switch r.URL.Path {
+	case "/zones":
+		writeJSON(w, map[string]interface{}{
+			"zones":      []map[string]interface{}{{"properties": map[string]string{"name": "example.com.", "type": "PRIMARY"}}},
+			"resultInfo": resultInfo{TotalCount: 1, ReturnedCount: 1},
+		})
+	case "/zones/example.com./rrsets":
+		end := offset + 2
+		if end > len(s.rrsets) {
+			end = len(s.rrsets)
+		}
+		writeJSON(w, &rrsetList{RRSets: s.rrsets[offset:end], ResultInfo: resultInfo{TotalCount: len(s.rrsets), Offset: offset, ReturnedCount: end - offset}})
+	case "/zones/example.com./rrsets/A/www.example.com.":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`[{"errorCode":70002,"errorMessage":"Data not found."}]`))
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func newTestClient(t *testing.T, s *testServer, creds credentials) *client {
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+	c, err := newClient(server.URL, creds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestPasswordAuthentication(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{}
+	c := newTestClient(t, s, credentials{username: "user", password: "secret"})
+
+	zones, err := c.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(zones).To(HaveLen(1))
+	Expect(zones[0].Properties.Name).To(Equal("example.com."))
+	_, err = c.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(s.tokens).To(Equal(1))
+
+	// rejected tokens are renewed once
+	s.expired = true
+	_, err = c.ListZones(context.Background())
+	Expect(err).To(BeNil())
+	Expect(s.tokens).To(Equal(2))
+
+	c = newTestClient(t, s, credentials{username: "user", password: "wrong"})
+	_, err = c.ListZones(context.Background())
+	Expect(err).To(MatchError(ContainSubstring("authentication of user user failed")))
+}
+
+func TestTokenAuthenticationAndThrottling(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{tokens: 7, throttled: true}
+	c := newTestClient(t, s, credentials{token: "token7"})
+
+	_, err := c.ListZones(context.Background())
+	Expect(err).NotTo(BeNil())
+	Expect(perrs.IsThrottlingError(err)).To(BeTrue())
+	Expect(perrs.RetryAfter(err)).To(Equal(2 * time.Second))
+	Expect(classifyError(err)).To(Equal(perrs.ReasonThrottled))
+	Expect(s.requests).To(Equal([]string{"GET /zones"}))
+}
+
+func TestListRRSetsAndPools(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{rrsets: []*RRSet{
+		{OwnerName: "example.com.", RRType: "NS (2)", TTL: 86400, RData: []string{"pdns1.ultradns.net."}},
+		{OwnerName: "www.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"1.2.3.4", "1.2.3.5"}, Profile: map[string]interface{}{profileContext: rdPoolContext, "order": "RANDOM"}},
+		{OwnerName: "sb.example.com.", RRType: "A (1)", TTL: 300, RData: []string{"1.2.3.6"}, Profile: map[string]interface{}{profileContext: "http://schemas.ultradns.com/SBPool.jsonschema"}},
+		{OwnerName: "txt.example.com.", RRType: "TXT (16)", TTL: 600, RData: []string{"hello world"}},
+		{OwnerName: "alias.example.com.", RRType: "CNAME (5)", TTL: 600, RData: []string{"www.example.com."}},
+	}}
+	c := newTestClient(t, s, credentials{username: "user", password: "secret"})
+
+	b := &backend{client: c, logger: logger.New()}
+	records, err := b.ListRecords(context.Background(), "example.com.")
+	Expect(err).To(BeNil())
+	Expect(values(records)).To(Equal([]string{
+		"NS example.com pdns1.ultradns.net ",
+		"A www.example.com 1.2.3.4 RANDOM",
+		"A www.example.com 1.2.3.5 RANDOM",
+		"TXT txt.example.com \"hello world\" ",
+		"CNAME alias.example.com www.example.com ",
+	}))
+
+	rs := dns.NewRecordSet(dns.RS_A, 300, nil)
+	rs.Add(&dns.Record{Value: "1.2.3.4"}, &dns.Record{Value: "1.2.3.5"})
+	rs.ProviderHints = &dns.ProviderHints{UltraDNSPoolOrder: "RANDOM"}
+	Expect(buildRRSet("www.example.com.", rs).Profile).To(Equal(map[string]interface{}{
+		profileContext: rdPoolContext, "order": "RANDOM", "description": "www.example.com.",
+	}))
+
+	txt := dns.NewRecordSet(dns.RS_TXT, 600, nil)
+	txt.Add(&dns.Record{Value: records[3].GetValue()})
+	Expect(buildRRSet("txt.example.com.", txt).RData).To(Equal([]string{"hello world"}))
+
+	cname := dns.NewRecordSet(dns.RS_CNAME, 600, nil)
+	cname.Add(&dns.Record{Value: records[4].GetValue()})
+	Expect(buildRRSet("alias.example.com.", cname).RData).To(Equal([]string{"www.example.com."}))
+}
+
+func TestReplacePoolByRRSet(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := &testServer{}
+	c := newTestClient(t, s, credentials{username: "user", password: "secret"})
+	b := &backend{client: c, logger: logger.New()}
+	zone := provider.NewDNSHostedZone(TYPE_CODE, "example.com", "example.com", "example.com.", nil, false)
+
+	pool := dns.NewRecordSet(dns.RS_A, 300, nil)
+	pool.Add(&dns.Record{Value: "1.2.3.4"})
+	pool.ProviderHints = &dns.ProviderHints{UltraDNSPoolOrder: "FIXED"}
+	rs := dns.NewRecordSet(dns.RS_A, 300, nil)
+	rs.Add(&dns.Record{Value: "1.2.3.4"})
+
+	err := b.ApplyRecordSetChange(context.Background(), &sdk.RecordSetChange{Action: provider.R_UPDATE, Name: "www.example.com", RecordSet: rs, Old: pool}, zone)
+	Expect(err).To(BeNil())
+	err = b.ApplyRecordSetChange(context.Background(), &sdk.RecordSetChange{Action: provider.R_UPDATE, Name: "www.example.com", RecordSet: pool, Old: rs}, zone)
+	Expect(err).To(BeNil())
+	err = b.ApplyRecordSetChange(context.Background(), &sdk.RecordSetChange{Action: provider.R_DELETE, Name: "gone.example.com", RecordSet: rs, Old: rs}, zone)
+	Expect(err).To(BeNil())
+	Expect(s.requests[1:]).To(Equal([]string{
+		"DELETE /zones/example.com./rrsets/A/www.example.com.",
+		"POST /zones/example.com./rrsets/A/www.example.com.",
+		"PUT /zones/example.com./rrsets/A/www.example.com.",
+		"DELETE /zones/example.com./rrsets/A/gone.example.com.",
+	}))
+}
+
+func values(records raw.RecordSet) []string {
+	result := []string{}
+	for _, r := range records {
+		order := ""
+		if hints := raw.GetProviderHints(r); hints != nil {
+			order = hints.UltraDNSPoolOrder
+		}
+		result = append(result, strings.Join([]string{r.GetType(), r.GetDNSName(), r.GetValue(), order}, " "))
+	}
+	return result
+}
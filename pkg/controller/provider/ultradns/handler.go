@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package ultradns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+type Handler struct {
+	*sdk.Handler
+}
+
+var _ provider.DNSHandler = &Handler{}
+var _ provider.ProviderHintsSupport = &Handler{}
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	creds := credentials{token: c.GetProperty("ULTRADNS_TOKEN", "token")}
+	if creds.token == "" {
+		var err error
+		creds.username, err = c.GetRequiredProperty("ULTRADNS_USERNAME", "username")
+		if err != nil {
+			return nil, fmt.Errorf("either ULTRADNS_TOKEN or ULTRADNS_USERNAME and ULTRADNS_PASSWORD must be set: %w", err)
+		}
+		creds.password, err = c.GetRequiredProperty("ULTRADNS_PASSWORD", "password")
+		if err != nil {
+			return nil, err
+		}
+	}
+	endpoint := c.GetProperty("ULTRADNS_ENDPOINT", "endpoint")
+
+	client, err := newClient(endpoint, creds)
+	if err != nil {
+		return nil, err
+	}
+	options := sdk.Options{
+		CacheType:     provider.CacheZoneState,
+		RecordTypes:   []string{dns.RS_SRV, dns.RS_CAA},
+		ClassifyError: classifyError,
+	}
+	sh, err := sdk.NewRecordSetHandler(TYPE_CODE, c, &backend{client: client, logger: c.Logger}, options)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{Handler: sh}, nil
+}
+
+// ProviderHintsFor manages A and AAAA record sets as resource distribution pool if a pool order is requested by the entry.
+func (h *Handler) ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints {
+	if hints == nil || hints.UltraDNSPoolOrder == "" {
+		return nil
+	}
+	switch rs.Type {
+	case dns.RS_A, dns.RS_AAAA:
+	default:
+		return nil
+	}
+	for _, o := range poolOrders {
+		if o == hints.UltraDNSPoolOrder {
+			return &dns.ProviderHints{UltraDNSPoolOrder: o}
+		}
+	}
+	return nil
+}
+
+// classifyError maps the HTTP status code of the UltraDNS API responses to error reasons.
+func classifyError(err error) perrs.ErrorReason {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return perrs.ReasonForHTTPStatus(apiErr.StatusCode)
+	}
+	return perrs.ReasonUnknown
+}
@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package ultradns
+
+import (
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+const (
+	// rdPoolContext is the schema of the profile of resource distribution pools
+	rdPoolContext = "http://schemas.ultradns.com/RDPool.jsonschema"
+	// profileContext is the profile field with the schema of a pool
+	profileContext = "@context"
+)
+
+// poolOrders are the orders supported for resource distribution pools.
+var poolOrders = []string{"ROUND_ROBIN", "FIXED", "RANDOM"}
+
+// rrType returns the record type of an rrset without its numeric value.
+func rrType(rrtype string) string {
+	if i := strings.Index(rrtype, " "); i > 0 {
+		return rrtype[:i]
+	}
+	return rrtype
+}
+
+// poolOrder returns the order of a resource distribution pool. ok is false for rrsets
+// with profiles of other pools (e.g. SiteBacker or Traffic Controller pools).
+func poolOrder(rrset *RRSet) (order string, ok bool) {
+	if len(rrset.Profile) == 0 {
+		return "", true
+	}
+	if rrset.Profile[profileContext] != rdPoolContext {
+		return "", false
+	}
+	order, _ = rrset.Profile["order"].(string)
+	return order, true
+}
+
+// Record is a single value of an rrset. The pool order of resource distribution pools is kept as provider hint.
+type Record struct {
+	name  string
+	rtype string
+	ttl   int
+	value string
+	hints *dns.ProviderHints
+}
+
+var _ raw.Record = &Record{}
+var _ raw.ProviderHintsRecord = &Record{}
+
+// GetId returns the type and owner name, which address an rrset.
+func (r *Record) GetId() string                             { return r.rtype + "/" + dns.AlignHostname(r.name) }
+func (r *Record) GetType() string                           { return r.rtype }
+func (r *Record) GetValue() string                          { return r.value }
+func (r *Record) GetDNSName() string                        { return r.name }
+func (r *Record) GetTTL() int                               { return r.ttl }
+func (r *Record) SetTTL(ttl int)                            { r.ttl = ttl }
+func (r *Record) Copy() raw.Record                          { n := *r; return &n }
+func (r *Record) GetProviderHints() *dns.ProviderHints      { return r.hints }
+func (r *Record) SetProviderHints(hints *dns.ProviderHints) { r.hints = hints }
+
+// newRecords maps the rdata of an rrset to records.
+func newRecords(rtype string, rrset *RRSet, order string) raw.RecordSet {
+	var hints *dns.ProviderHints
+	if order != "" {
+		hints = &dns.ProviderHints{UltraDNSPoolOrder: order}
+	}
+	records := raw.RecordSet{}
+	for _, v := range rrset.RData {
+		records = append(records, &Record{
+			name:  dns.NormalizeHostname(rrset.OwnerName),
+			rtype: rtype,
+			ttl:   int(rrset.TTL),
+			value: rdataValue(rtype, v),
+			hints: hints,
+		})
+	}
+	return records
+}
+
+// buildRRSet maps a record set of the dns model to an rrset. Record sets with a pool order
+// are managed as resource distribution pool.
+func buildRRSet(name string, rs *dns.RecordSet) *RRSet {
+	rrset := &RRSet{TTL: rs.TTL, RData: []string{}}
+	for _, r := range rs.Records {
+		rrset.RData = append(rrset.RData, rdata(rs.Type, r.Value))
+	}
+	if rs.ProviderHints != nil && rs.ProviderHints.UltraDNSPoolOrder != "" {
+		rrset.Profile = map[string]interface{}{
+			profileContext: rdPoolContext,
+			"order":        rs.ProviderHints.UltraDNSPoolOrder,
+			"description":  name,
+		}
+	}
+	return rrset
+}
+
+func isPool(rs *dns.RecordSet) bool {
+	return rs != nil && rs.ProviderHints != nil && rs.ProviderHints.UltraDNSPoolOrder != ""
+}
+
+// rdataValue maps the rdata of a record to the record value of the dns model.
+func rdataValue(rtype, rdata string) string {
+	switch rtype {
+	case dns.RS_TXT:
+		return raw.EnsureQuotedText(rdata)
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.NormalizeHostname(rdata)
+	case dns.RS_SRV:
+		fields := strings.Fields(rdata)
+		if len(fields) == 4 {
+			fields[3] = dns.AlignHostname(fields[3])
+		}
+		return strings.Join(fields, " ")
+	case dns.RS_CAA:
+		return dns.NormalizeCAAValue(rdata)
+	}
+	return rdata
+}
+
+// rdata maps a record value of the dns model to the rdata of a record.
+func rdata(rtype, value string) string {
+	switch rtype {
+	case dns.RS_TXT:
+		chunks, err := dns.SplitTextValue(value)
+		if err != nil {
+			return value
+		}
+		return strings.Join(chunks, "")
+	case dns.RS_CNAME:
+		return dns.AlignHostname(value)
+	}
+	return value
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package windowsdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/sdk"
+)
+
+func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+	cfg := winrmConfig{}
+	var err error
+	cfg.host, err = c.GetRequiredProperty("WINRM_HOST", "host")
+	if err != nil {
+		return nil, err
+	}
+	cfg.port, err = c.GetDefaultedIntProperty("WINRM_PORT", defaultPort, "port")
+	if err != nil || cfg.port <= 0 || cfg.port > 65535 {
+		return nil, fmt.Errorf("invalid WINRM_PORT: %s", c.GetProperty("WINRM_PORT", "port"))
+	}
+	cfg.auth = strings.ToLower(c.GetProperty("WINRM_AUTH", "auth"))
+	switch cfg.auth {
+	case "":
+		cfg.auth = authNTLM
+	case authNTLM, authBasic:
+	default:
+		return nil, fmt.Errorf("invalid WINRM_AUTH %q: must be %q or %q", cfg.auth, authNTLM, authBasic)
+	}
+	cfg.username = c.GetProperty("WINRM_USERNAME", "username")
+	cfg.password = c.GetProperty("WINRM_PASSWORD", "password")
+	cfg.clientCert = c.GetProperty("WINRM_CLIENT_CERT", "clientCert")
+	cfg.clientKey = c.GetProperty("WINRM_CLIENT_KEY", "clientKey")
+	switch {
+	case cfg.clientCert != "":
+		if cfg.clientKey == "" {
+			return nil, fmt.Errorf("'WINRM_CLIENT_KEY' (or 'clientKey') is required for certificate authentication")
+		}
+	case cfg.username == "" || cfg.password == "":
+		return nil, fmt.Errorf("either 'WINRM_USERNAME' and 'WINRM_PASSWORD' (or 'username' and 'password') or 'WINRM_CLIENT_CERT' and 'WINRM_CLIENT_KEY' must be set")
+	}
+	cfg.caCert = c.GetProperty("WINRM_CA_CERT", "caCert")
+	cfg.insecure, err = c.GetDefaultedBoolProperty("WINRM_INSECURE", false, "insecure")
+	if err != nil {
+		return nil, fmt.Errorf("invalid WINRM_INSECURE: %w", err)
+	}
+
+	client, err := newWinRMClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	b := &backend{
+		client:  client,
+		scripts: scripts{dnsServer: c.GetProperty("DNS_SERVER", "dnsServer")},
+	}
+	options := sdk.Options{
+		CacheType:     provider.CacheZoneState,
+		RecordTypes:   []string{dns.RS_SRV},
+		ClassifyError: classifyError,
+	}
+	return sdk.NewRecordSetHandler(TYPE_CODE, c, b, options)
+}
+
+// backend runs the PowerShell scripts of the DnsServer module on the WinRM host.
+type backend struct {
+	client  *winrmClient
+	scripts scripts
+}
+
+var _ sdk.RecordSetBackend = &backend{}
+
+func (b *backend) ListZones(ctx context.Context) ([]sdk.Zone, error) {
+	output, err := b.client.RunPowerShell(ctx, b.scripts.ListZones())
+	if err != nil {
+		return nil, err
+	}
+	list, err := parseZones(output)
+	if err != nil {
+		return nil, err
+	}
+	zones := []sdk.Zone{}
+	for _, z := range list {
+		// zones are addressed by their name
+		id := dns.NormalizeHostname(strings.ToLower(z.Name))
+		zones = append(zones, sdk.Zone{ID: id, Domain: id})
+	}
+	return zones, nil
+}
+
+func (b *backend) ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error) {
+	output, err := b.client.RunPowerShell(ctx, b.scripts.ListRecords(zoneKey))
+	if err != nil {
+		return nil, err
+	}
+	list, err := parseRecords(output)
+	if err != nil {
+		return nil, err
+	}
+	records := raw.RecordSet{}
+	for _, r := range list {
+		r.zone = zoneKey
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// ApplyRecordSetChange replaces all records of the record set by a single script, so that records are not
+// changed partially if the script fails.
+func (b *backend) ApplyRecordSetChange(ctx context.Context, change *sdk.RecordSetChange, zone provider.DNSHostedZone) error {
+	rs := change.RecordSet
+	values := []string{}
+	if change.Action != provider.R_DELETE {
+		for _, r := range rs.Records {
+			values = append(values, r.Value)
+		}
+	}
+	script, err := b.scripts.ReplaceRecords(zone.Key(), relativeName(zone.Domain(), change.Name), rs.Type, rs.TTL, values)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.RunPowerShell(ctx, script)
+	return err
+}
+
+// classifyError maps the errors of WinRM requests and PowerShell scripts to error reasons.
+func classifyError(err error) perrs.ErrorReason {
+	var werr *WinRMError
+	if errors.As(err, &werr) {
+		return perrs.ReasonForHTTPStatus(werr.StatusCode)
+	}
+	var serr *ScriptError
+	if errors.As(err, &serr) {
+		msg := strings.ToLower(serr.Message)
+		switch {
+		case strings.Contains(msg, "access denied"), strings.Contains(msg, "access is denied"):
+			return perrs.ReasonAuthFailed
+		case strings.Contains(msg, "was not found"):
+			return perrs.ReasonNotFound
+		}
+	}
+	return perrs.ReasonUnknown
+}
@@ -0,0 +1,254 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package windowsdns
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+// scriptPrologue stops scripts on the first error and reports it as plain text on the standard error output.
+// The parameters of a script are passed as base64 encoded JSON document, so that no values of entries are
+// spliced into the script itself. `$server` are the common parameters of the DnsServer cmdlets.
+const scriptPrologue = `$ProgressPreference = 'SilentlyContinue'
+$ErrorActionPreference = 'Stop'
+try {
+$p = [Text.Encoding]::UTF8.GetString([Convert]::FromBase64String('%s')) | ConvertFrom-Json
+$server = @{}
+if ($p.dnsServer) { $server.ComputerName = [string]$p.dnsServer }
+`
+
+const scriptEpilogue = `
+} catch {
+  [Console]::Error.WriteLine($_.Exception.Message)
+  exit 1
+}
+`
+
+// Zone is a primary zone of the DNS server.
+type Zone struct {
+	Name string `json:"name"`
+}
+
+// Record is a resource record of a zone. The name is relative to the zone (`@` for the zone apex),
+// the value is the record data in presentation format.
+type Record struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	TTL   int64  `json:"ttl"`
+	Value string `json:"value"`
+
+	zone string
+}
+
+var _ raw.Record = &Record{}
+
+// GetId returns the relative name, records are only addressed by their name and type.
+func (r *Record) GetId() string      { return r.Name }
+func (r *Record) GetType() string    { return r.Type }
+func (r *Record) GetValue() string   { return recordValue(r.Type, r.Value) }
+func (r *Record) GetDNSName() string { return recordName(r.zone, strings.ToLower(r.Name)) }
+func (r *Record) GetTTL() int        { return int(r.TTL) }
+func (r *Record) SetTTL(ttl int)     { r.TTL = int64(ttl) }
+func (r *Record) Copy() raw.Record   { n := *r; return &n }
+
+// scripts builds the PowerShell scripts using the DnsServer module cmdlets.
+// If a DNS server is configured, the cmdlets are run against it instead of the WinRM host.
+type scripts struct {
+	dnsServer string
+}
+
+// scriptParams are the parameters of a script.
+type scriptParams struct {
+	DNSServer string         `json:"dnsServer,omitempty"`
+	Zone      string         `json:"zone,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Type      string         `json:"type,omitempty"`
+	TTL       int64          `json:"ttl,omitempty"`
+	Records   []recordParams `json:"records,omitempty"`
+}
+
+// recordParams are the parameters of the cmdlet Add-DnsServerResourceRecord for a record value,
+// including the switch parameter for the record type.
+type recordParams map[string]interface{}
+
+func (s scripts) wrap(params scriptParams, script string) string {
+	params.DNSServer = s.dnsServer
+	// marshalling strings, numbers and maps of them cannot fail
+	data, _ := json.Marshal(params)
+	return fmt.Sprintf(scriptPrologue, base64.StdEncoding.EncodeToString(data)) + script + scriptEpilogue
+}
+
+// ListZones lists the primary forward lookup zones, which are not created automatically.
+func (s scripts) ListZones() string {
+	return s.wrap(scriptParams{}, `$zones = @(Get-DnsServerZone @server | Where-Object { $_.ZoneType -eq 'Primary' -and -not $_.IsAutoCreated -and -not $_.IsReverseLookupZone -and $_.ZoneName -ne 'TrustAnchors' } | ForEach-Object {
+  [pscustomobject]@{ name = [string]$_.ZoneName }
+})
+ConvertTo-Json -Compress -InputObject $zones`)
+}
+
+// ListRecords lists the records of the supported types of a zone.
+func (s scripts) ListRecords(zone string) string {
+	return s.wrap(scriptParams{Zone: zone}, `$records = @(Get-DnsServerResourceRecord -ZoneName $p.zone @server | ForEach-Object {
+  $d = $_.RecordData
+  $v = switch ([string]$_.RecordType) {
+    'A' { $d.IPv4Address.IPAddressToString }
+    'AAAA' { $d.IPv6Address.IPAddressToString }
+    'CNAME' { $d.HostNameAlias }
+    'NS' { $d.NameServer }
+    'TXT' { $d.DescriptiveText }
+    'SRV' { '{0} {1} {2} {3}' -f $d.Priority, $d.Weight, $d.Port, $d.DomainName }
+    default { $null }
+  }
+  if ($null -ne $v) {
+    [pscustomobject]@{ name = [string]$_.HostName; type = [string]$_.RecordType; ttl = [int64]$_.TimeToLive.TotalSeconds; value = [string]$v }
+  }
+})
+ConvertTo-Json -Compress -InputObject $records`)
+}
+
+// replaceRecordsScript removes the existing records and adds the new ones. If a cmdlet fails, the added
+// records are removed and the previous ones are restored, so that a failed change doesn't leave the
+// record set deleted.
+const replaceRecordsScript = `$old = @(Get-DnsServerResourceRecord -ZoneName $p.zone -Name $p.name -RRType $p.type @server -ErrorAction SilentlyContinue)
+$added = @()
+try {
+  $old | Remove-DnsServerResourceRecord -ZoneName $p.zone @server -Force
+  foreach ($r in $p.records) {
+    $record = @{}
+    $r.PSObject.Properties | ForEach-Object { $record[$_.Name] = $_.Value }
+    $added += Add-DnsServerResourceRecord -ZoneName $p.zone -Name $p.name @server @record -TimeToLive (New-TimeSpan -Seconds $p.ttl) -PassThru
+  }
+} catch {
+  $added | Remove-DnsServerResourceRecord -ZoneName $p.zone @server -Force -ErrorAction SilentlyContinue
+  $old | ForEach-Object { Add-DnsServerResourceRecord -ZoneName $p.zone @server -InputObject $_ -ErrorAction SilentlyContinue }
+  throw
+}`
+
+// ReplaceRecords replaces all records of a type of a name by the given records.
+// Without records, the existing records are only removed.
+func (s scripts) ReplaceRecords(zone, name, rtype string, ttl int64, values []string) (string, error) {
+	params := scriptParams{Zone: zone, Name: name, Type: rtype, TTL: ttl, Records: []recordParams{}}
+	for _, v := range values {
+		record, err := recordArgs(rtype, v)
+		if err != nil {
+			return "", err
+		}
+		params.Records = append(params.Records, record)
+	}
+	return s.wrap(params, replaceRecordsScript), nil
+}
+
+// recordArgs returns the parameters of the cmdlet Add-DnsServerResourceRecord for a record value.
+func recordArgs(rtype, value string) (recordParams, error) {
+	switch rtype {
+	case dns.RS_A:
+		return recordParams{"A": true, "IPv4Address": value}, nil
+	case dns.RS_AAAA:
+		return recordParams{"AAAA": true, "IPv6Address": value}, nil
+	case dns.RS_CNAME:
+		return recordParams{"CName": true, "HostNameAlias": dns.AlignHostname(value)}, nil
+	case dns.RS_TXT:
+		chunks, err := dns.SplitTextValue(value)
+		if err != nil {
+			return nil, err
+		}
+		return recordParams{"Txt": true, "DescriptiveText": strings.Join(chunks, "")}, nil
+	case dns.RS_SRV:
+		fields := strings.Fields(value)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid SRV record %q", value)
+		}
+		numbers := make([]uint64, 3)
+		for i, f := range fields[:3] {
+			n, err := strconv.ParseUint(f, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid SRV record %q", value)
+			}
+			numbers[i] = n
+		}
+		return recordParams{"Srv": true, "Priority": numbers[0], "Weight": numbers[1], "Port": numbers[2],
+			"DomainName": dns.AlignHostname(fields[3])}, nil
+	}
+	return nil, fmt.Errorf("record type %s not supported", rtype)
+}
+
+func parseZones(output string) ([]*Zone, error) {
+	zones := []*Zone{}
+	if err := parseJSON(output, &zones); err != nil {
+		return nil, err
+	}
+	return zones, nil
+}
+
+func parseRecords(output string) ([]*Record, error) {
+	records := []*Record{}
+	if err := parseJSON(output, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func parseJSON(output string, out interface{}) error {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(output), out); err != nil {
+		return fmt.Errorf("invalid script output: %w", err)
+	}
+	return nil
+}
+
+// recordName returns the fully qualified name of a record name relative to the zone.
+func recordName(zone, name string) string {
+	if name == "@" || name == "" {
+		return zone
+	}
+	return dns.NormalizeHostname(name) + "." + zone
+}
+
+// relativeName returns the name of a record relative to the zone.
+func relativeName(zone, name string) string {
+	if name == zone {
+		return "@"
+	}
+	return strings.TrimSuffix(name, "."+zone)
+}
+
+// recordValue maps the record data to the record value of the dns model.
+func recordValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_TXT:
+		return raw.EnsureQuotedText(value)
+	case dns.RS_CNAME, dns.RS_NS:
+		return dns.NormalizeHostname(value)
+	case dns.RS_SRV:
+		fields := strings.Fields(value)
+		if len(fields) == 4 {
+			fields[3] = dns.AlignHostname(fields[3])
+		}
+		return strings.Join(fields, " ")
+	}
+	return value
+}
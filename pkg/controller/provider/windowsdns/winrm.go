@@ -0,0 +1,411 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package windowsdns
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	"github.com/Azure/go-ntlmssp"
+)
+
+const (
+	defaultPort = 5986
+
+	nsSoap       = "http://www.w3.org/2003/05/soap-envelope"
+	nsAddressing = "http://schemas.xmlsoap.org/ws/2004/08/addressing"
+	nsWSMan      = "http://schemas.dmtf.org/wbem/wsman/1/wsman.xsd"
+	nsShell      = "http://schemas.microsoft.com/wbem/wsman/1/windows/shell"
+
+	resourceURICmd = nsShell + "/cmd"
+
+	actionCreate  = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Create"
+	actionDelete  = "http://schemas.xmlsoap.org/ws/2004/09/transfer/Delete"
+	actionCommand = nsShell + "/Command"
+	actionReceive = nsShell + "/Receive"
+	actionSignal  = nsShell + "/Signal"
+
+	signalTerminate  = nsShell + "/signal/terminate"
+	commandStateDone = nsShell + "/CommandState/Done"
+
+	// faultCodeTimedOut is the WS-Management fault code of a receive request without output until the operation timeout
+	faultCodeTimedOut = "2150858793"
+
+	operationTimeout = 60 * time.Second
+
+	// authNTLM negotiates NTLM authentication, if offered by the WinRM service, and falls back to basic authentication
+	authNTLM = "ntlm"
+	// authBasic always uses basic authentication
+	authBasic = "basic"
+)
+
+// winrmConfig are the connection settings of the WinRM endpoint.
+// Either username and password (NTLM or basic authentication) or a client certificate must be given.
+type winrmConfig struct {
+	host       string
+	port       int
+	auth       string
+	username   string
+	password   string
+	clientCert string
+	clientKey  string
+	caCert     string
+	insecure   bool
+}
+
+// WinRMError is a SOAP fault or an unexpected HTTP response of the WinRM endpoint.
+type WinRMError struct {
+	StatusCode int
+	// Code is the WS-Management fault code if available
+	Code    string
+	Message string
+}
+
+func (e *WinRMError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("winrm error %d (code %s): %s", e.StatusCode, e.Code, e.Message)
+	}
+	return fmt.Sprintf("winrm error %d: %s", e.StatusCode, e.Message)
+}
+
+// ScriptError is reported for PowerShell scripts terminated with a non-zero exit code.
+type ScriptError struct {
+	ExitCode int
+	Message  string
+}
+
+func (e *ScriptError) Error() string {
+	return fmt.Sprintf("powershell script failed with exit code %d: %s", e.ExitCode, e.Message)
+}
+
+// winrmClient runs PowerShell scripts with the WinRM shell protocol (MS-WSMV).
+// For every script a remote shell is opened and deleted again.
+type winrmClient struct {
+	endpoint string
+	config   winrmConfig
+	http     *http.Client
+}
+
+func newWinRMClient(config winrmConfig) (*winrmClient, error) {
+	if config.port == 0 {
+		config.port = defaultPort
+	}
+	tlscfg := &tls.Config{InsecureSkipVerify: config.insecure}
+	if config.caCert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.caCert)) {
+			return nil, fmt.Errorf("invalid CA certificate for WinRM endpoint")
+		}
+		tlscfg.RootCAs = pool
+	}
+	if config.clientCert != "" {
+		cert, err := tls.X509KeyPair([]byte(config.clientCert), []byte(config.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate for WinRM endpoint: %w", err)
+		}
+		tlscfg.Certificates = []tls.Certificate{cert}
+	}
+	var transport http.RoundTripper = &http.Transport{TLSClientConfig: tlscfg, Proxy: http.ProxyFromEnvironment}
+	if config.username != "" && config.auth != authBasic {
+		// the negotiator converts the basic authentication of the requests to NTLM, which is enabled
+		// by default for the WinRM service. Message encryption is not needed, as HTTPS is used.
+		transport = ntlmssp.Negotiator{RoundTripper: transport}
+	}
+	return &winrmClient{
+		endpoint: fmt.Sprintf("https://%s:%d/wsman", config.host, config.port),
+		config:   config,
+		http: &http.Client{
+			Timeout:   operationTimeout + 30*time.Second,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// RunPowerShell runs a script with powershell.exe in a new remote shell and returns its standard output.
+func (c *winrmClient) RunPowerShell(ctx context.Context, script string) (string, error) {
+	shellID, err := c.createShell(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer c.deleteShell(shellID)
+
+	commandID, err := c.command(ctx, shellID, "powershell.exe", "-NoProfile", "-NonInteractive", "-EncodedCommand", encodeCommand(script))
+	if err != nil {
+		return "", err
+	}
+	stdout, stderr, exitCode, err := c.receive(ctx, shellID, commandID)
+	c.signal(shellID, commandID)
+	if err != nil {
+		return "", err
+	}
+	if exitCode != 0 {
+		return "", &ScriptError{ExitCode: exitCode, Message: cleanStderr(stderr)}
+	}
+	return stdout, nil
+}
+
+func (c *winrmClient) createShell(ctx context.Context) (string, error) {
+	options := `<w:OptionSet><w:Option Name="WINRS_NOPROFILE">TRUE</w:Option><w:Option Name="WINRS_CODEPAGE">65001</w:Option></w:OptionSet>`
+	body := `<rsp:Shell><rsp:InputStreams>stdin</rsp:InputStreams><rsp:OutputStreams>stdout stderr</rsp:OutputStreams></rsp:Shell>`
+	resp := &createResponse{}
+	if err := c.send(ctx, actionCreate, "", options, body, resp); err != nil {
+		return "", err
+	}
+	if id := resp.Body.Shell.ShellID; id != "" {
+		return id, nil
+	}
+	for _, s := range resp.Body.ResourceCreated.Selectors {
+		if s.Name == "ShellId" {
+			return s.Value, nil
+		}
+	}
+	return "", fmt.Errorf("no shell id returned by WinRM endpoint")
+}
+
+func (c *winrmClient) command(ctx context.Context, shellID, command string, args ...string) (string, error) {
+	options := `<w:OptionSet><w:Option Name="WINRS_CONSOLEMODE_STDIN">TRUE</w:Option><w:Option Name="WINRS_SKIP_CMD_SHELL">TRUE</w:Option></w:OptionSet>`
+	body := fmt.Sprintf(`<rsp:CommandLine><rsp:Command>%s</rsp:Command><rsp:Arguments>%s</rsp:Arguments></rsp:CommandLine>`,
+		escape(command), escape(strings.Join(args, " ")))
+	resp := &commandResponse{}
+	if err := c.send(ctx, actionCommand, shellID, options, body, resp); err != nil {
+		return "", err
+	}
+	if resp.Body.CommandResponse.CommandID == "" {
+		return "", fmt.Errorf("no command id returned by WinRM endpoint")
+	}
+	return resp.Body.CommandResponse.CommandID, nil
+}
+
+// receive collects the output of a command until it is done.
+func (c *winrmClient) receive(ctx context.Context, shellID, commandID string) (string, string, int, error) {
+	body := fmt.Sprintf(`<rsp:Receive><rsp:DesiredStream CommandId="%s">stdout stderr</rsp:DesiredStream></rsp:Receive>`, escape(commandID))
+	var stdout, stderr bytes.Buffer
+	for {
+		resp := &receiveResponse{}
+		err := c.send(ctx, actionReceive, shellID, "", body, resp)
+		if err != nil {
+			if werr, ok := err.(*WinRMError); ok && werr.Code == faultCodeTimedOut {
+				continue
+			}
+			return "", "", 0, err
+		}
+		for _, s := range resp.Body.ReceiveResponse.Streams {
+			data, err := base64.StdEncoding.DecodeString(s.Value)
+			if err != nil {
+				return "", "", 0, fmt.Errorf("invalid %s stream returned by WinRM endpoint: %w", s.Name, err)
+			}
+			if s.Name == "stderr" {
+				stderr.Write(data)
+			} else {
+				stdout.Write(data)
+			}
+		}
+		if state := resp.Body.ReceiveResponse.CommandState; state.State == commandStateDone {
+			return stdout.String(), stderr.String(), state.ExitCode, nil
+		}
+	}
+}
+
+func (c *winrmClient) signal(shellID, commandID string) {
+	body := fmt.Sprintf(`<rsp:Signal CommandId="%s"><rsp:Code>%s</rsp:Code></rsp:Signal>`, escape(commandID), signalTerminate)
+	_ = c.send(context.Background(), actionSignal, shellID, "", body, nil)
+}
+
+func (c *winrmClient) deleteShell(shellID string) {
+	_ = c.send(context.Background(), actionDelete, shellID, "", "", nil)
+}
+
+func (c *winrmClient) send(ctx context.Context, action, shellID, options, body string, out interface{}) error {
+	selector := ""
+	if shellID != "" {
+		selector = fmt.Sprintf(`<w:SelectorSet><w:Selector Name="ShellId">%s</w:Selector></w:SelectorSet>`, escape(shellID))
+	}
+	envelope := fmt.Sprintf(`<env:Envelope xmlns:env="%s" xmlns:a="%s" xmlns:w="%s" xmlns:rsp="%s">`+
+		`<env:Header>`+
+		`<a:To>%s</a:To>`+
+		`<a:ReplyTo><a:Address env:mustUnderstand="true">%s/role/anonymous</a:Address></a:ReplyTo>`+
+		`<w:MaxEnvelopeSize env:mustUnderstand="true">153600</w:MaxEnvelopeSize>`+
+		`<a:MessageID>uuid:%s</a:MessageID>`+
+		`<w:Locale xml:lang="en-US" env:mustUnderstand="false"/>`+
+		`<w:OperationTimeout>PT%dS</w:OperationTimeout>`+
+		`<w:ResourceURI env:mustUnderstand="true">%s</w:ResourceURI>`+
+		`<a:Action env:mustUnderstand="true">%s</a:Action>`+
+		`%s%s`+
+		`</env:Header>`+
+		`<env:Body>%s</env:Body>`+
+		`</env:Envelope>`,
+		nsSoap, nsAddressing, nsWSMan, nsShell, escape(c.endpoint), nsAddressing, newMessageID(),
+		int(operationTimeout.Seconds()), resourceURICmd, action, selector, options, body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(envelope))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/soap+xml;charset=UTF-8")
+	req.Header.Set("User-Agent", "external-dns-manager")
+	if c.config.username != "" {
+		req.SetBasicAuth(c.config.username, c.config.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return newWinRMError(resp.StatusCode, data)
+	}
+	if out != nil {
+		if err := xml.Unmarshal(data, out); err != nil {
+			return fmt.Errorf("invalid response of WinRM endpoint: %w", err)
+		}
+	}
+	return nil
+}
+
+type selector struct {
+	Name  string `xml:"Name,attr"`
+	Value string `xml:",chardata"`
+}
+
+type createResponse struct {
+	Body struct {
+		ResourceCreated struct {
+			Selectors []selector `xml:"ReferenceParameters>SelectorSet>Selector"`
+		} `xml:"ResourceCreated"`
+		Shell struct {
+			ShellID string `xml:"ShellId"`
+		} `xml:"Shell"`
+	} `xml:"Body"`
+}
+
+type commandResponse struct {
+	Body struct {
+		CommandResponse struct {
+			CommandID string `xml:"CommandId"`
+		} `xml:"CommandResponse"`
+	} `xml:"Body"`
+}
+
+type receiveResponse struct {
+	Body struct {
+		ReceiveResponse struct {
+			Streams []struct {
+				Name  string `xml:"Name,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"Stream"`
+			CommandState struct {
+				State    string `xml:"State,attr"`
+				ExitCode int    `xml:"ExitCode"`
+			} `xml:"CommandState"`
+		} `xml:"ReceiveResponse"`
+	} `xml:"Body"`
+}
+
+type faultResponse struct {
+	Body struct {
+		Fault struct {
+			Reason string `xml:"Reason>Text"`
+			Detail struct {
+				WSManFault struct {
+					Code    string `xml:"Code,attr"`
+					Message string `xml:"Message"`
+				} `xml:"WSManFault"`
+			} `xml:"Detail"`
+		} `xml:"Fault"`
+	} `xml:"Body"`
+}
+
+// newWinRMError extracts the SOAP fault of a response. Authentication failures are answered without fault.
+func newWinRMError(status int, data []byte) *WinRMError {
+	werr := &WinRMError{StatusCode: status, Message: http.StatusText(status)}
+	fault := &faultResponse{}
+	if xml.Unmarshal(data, fault) == nil {
+		f := fault.Body.Fault
+		werr.Code = f.Detail.WSManFault.Code
+		if msg := strings.TrimSpace(f.Detail.WSManFault.Message); msg != "" {
+			werr.Message = msg
+		} else if msg := strings.TrimSpace(f.Reason); msg != "" {
+			werr.Message = msg
+		}
+	}
+	return werr
+}
+
+// encodeCommand encodes a script for the argument `-EncodedCommand` of powershell.exe (base64 of UTF-16LE).
+func encodeCommand(script string) string {
+	codes := utf16.Encode([]rune(script))
+	data := make([]byte, 2*len(codes))
+	for i, c := range codes {
+		data[2*i] = byte(c)
+		data[2*i+1] = byte(c >> 8)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// cleanStderr extracts the error messages of the standard error output. powershell.exe reports
+// errors not written by the script itself as CLIXML.
+func cleanStderr(stderr string) string {
+	stderr = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(stderr), "#< CLIXML"))
+	if !strings.HasPrefix(stderr, "<Objs") {
+		return stderr
+	}
+	objs := struct {
+		Strings []struct {
+			Stream string `xml:"S,attr"`
+			Value  string `xml:",chardata"`
+		} `xml:"S"`
+	}{}
+	if xml.Unmarshal([]byte(stderr), &objs) != nil {
+		return stderr
+	}
+	msg := ""
+	for _, s := range objs.Strings {
+		if s.Stream == "Error" {
+			msg += strings.NewReplacer("_x000D_", "", "_x000A_", " ").Replace(s.Value)
+		}
+	}
+	return strings.TrimSpace(msg)
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func newMessageID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
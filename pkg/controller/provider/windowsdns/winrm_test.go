@@ -0,0 +1,253 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package windowsdns
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type testWinRM struct {
+	output   string
+	stderr   string
+	exitCode int
+	timeouts int
+	scripts  []string
+	actions  []string
+}
+
+func (s *testWinRM) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	data, _ := io.ReadAll(r.Body)
+	envelope := struct {
+		Action  string `xml:"Header>Action"`
+		Command struct {
+			Arguments string `xml:"Arguments"`
+		} `xml:"Body>CommandLine"`
+	}{}
+	if err := xml.Unmarshal(data, &envelope); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	action := envelope.Action[strings.LastIndex(envelope.Action, "/")+1:]
+	s.actions = append(s.actions, action)
+	w.Header().Set("Content-Type", "application/soap+xml;charset=UTF-8")
+	switch action {
+	case "Create":
+		_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:rsp="http://schemas.microsoft.com/wbem/wsman/1/windows/shell"><s:Body><rsp:Shell><rsp:ShellId>S1</rsp:ShellId></rsp:Shell></s:Body></s:Envelope>`))
+	case "Command":
+		args := strings.Fields(envelope.Command.Arguments)
+		s.scripts = append(s.scripts, decodeCommand(args[len(args)-1]))
+		_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:rsp="http://schemas.microsoft.com/wbem/wsman/1/windows/shell"><s:Body><rsp:CommandResponse><rsp:CommandId>C1</rsp:CommandId></rsp:CommandResponse></s:Body></s:Envelope>`))
+	case "Receive":
+		if s.timeouts > 0 {
+			s.timeouts--
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body><s:Fault><s:Reason><s:Text xml:lang="en-US">The WS-Management service cannot complete the operation within the time specified in OperationTimeout.</s:Text></s:Reason><s:Detail><f:WSManFault xmlns:f="http://schemas.microsoft.com/wbem/wsman/1/wsmanfault" Code="2150858793"/></s:Detail></s:Fault></s:Body></s:Envelope>`))
+			return
+		}
+		_, _ = fmt.Fprintf(w, `<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope" xmlns:rsp="http://schemas.microsoft.com/wbem/wsman/1/windows/shell"><s:Body><rsp:ReceiveResponse>`+
+			`<rsp:Stream Name="stdout" CommandId="C1">%s</rsp:Stream><rsp:Stream Name="stderr" CommandId="C1">%s</rsp:Stream>`+
+			`<rsp:CommandState CommandId="C1" State="http://schemas.microsoft.com/wbem/wsman/1/windows/shell/CommandState/Done"><rsp:ExitCode>%d</rsp:ExitCode></rsp:CommandState>`+
+			`</rsp:ReceiveResponse></s:Body></s:Envelope>`,
+			base64.StdEncoding.EncodeToString([]byte(s.output)), base64.StdEncoding.EncodeToString([]byte(s.stderr)), s.exitCode)
+	default:
+		_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body/></s:Envelope>`))
+	}
+}
+
+func decodeCommand(encoded string) string {
+	data, _ := base64.StdEncoding.DecodeString(encoded)
+	codes := make([]uint16, len(data)/2)
+	for i := range codes {
+		codes[i] = uint16(data[2*i]) | uint16(data[2*i+1])<<8
+	}
+	return string(utf16.Decode(codes))
+}
+
+func newTestClient(t *testing.T, server *testWinRM, password string) *winrmClient {
+	ts := httptest.NewTLSServer(server)
+	t.Cleanup(ts.Close)
+	host, port := ts.Listener.Addr().String(), 0
+	if i := strings.LastIndex(host, ":"); i > 0 {
+		_, _ = fmt.Sscanf(host[i+1:], "%d", &port)
+		host = host[:i]
+	}
+	client, err := newWinRMClient(winrmConfig{host: host, port: port, username: "admin", password: password, insecure: true})
+	Expect(err).NotTo(HaveOccurred())
+	return client
+}
+
+func TestRunPowerShell(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := &testWinRM{output: `[{"name":"www","type":"A","ttl":300,"value":"1.2.3.4"},{"name":"@","type":"TXT","ttl":600,"value":"it's me"}]`, timeouts: 1}
+	client := newTestClient(t, server, "secret")
+	s := scripts{dnsServer: "dns01"}
+	output, err := client.RunPowerShell(context.TODO(), s.ListRecords("example.com"))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(server.actions).To(Equal([]string{"Create", "Command", "Receive", "Receive", "Signal", "Delete"}))
+	Expect(server.scripts[0]).To(ContainSubstring("Get-DnsServerResourceRecord -ZoneName $p.zone @server"))
+	Expect(paramsOf(server.scripts[0])).To(Equal(scriptParams{DNSServer: "dns01", Zone: "example.com"}))
+
+	records, err := parseRecords(output)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(records).To(HaveLen(2))
+	Expect(recordName("example.com", records[0].Name)).To(Equal("www.example.com"))
+	Expect(recordName("example.com", records[1].Name)).To(Equal("example.com"))
+	Expect(recordValue(records[1].Type, records[1].Value)).To(Equal(`"it's me"`))
+
+	server.output, server.exitCode = "", 1
+	server.stderr = `#< CLIXML
+<Objs Version="1.1.0.1" xmlns="http://schemas.microsoft.com/powershell/2004/04"><S S="Error">Failed to get the zone information for example.com on server dns01._x000D__x000A_</S></Objs>`
+	_, err = client.RunPowerShell(context.TODO(), s.ListZones())
+	Expect(err).To(MatchError("powershell script failed with exit code 1: Failed to get the zone information for example.com on server dns01."))
+
+	client = newTestClient(t, &testWinRM{}, "wrong")
+	_, err = client.RunPowerShell(context.TODO(), s.ListZones())
+	Expect(err).To(MatchError(&WinRMError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"}))
+}
+
+// paramsOf decodes the parameters passed to a script.
+func paramsOf(script string) scriptParams {
+	m := regexp.MustCompile(`FromBase64String\('([A-Za-z0-9+/=]*)'\)`).FindStringSubmatch(script)
+	Expect(m).To(HaveLen(2))
+	data, err := base64.StdEncoding.DecodeString(m[1])
+	Expect(err).NotTo(HaveOccurred())
+	params := scriptParams{}
+	Expect(json.Unmarshal(data, &params)).To(Succeed())
+	return params
+}
+
+func TestReplaceRecords(t *testing.T) {
+	RegisterTestingT(t)
+
+	s := scripts{}
+	script, err := s.ReplaceRecords("example.com", relativeName("example.com", "_sip._tcp.example.com"), dns.RS_SRV, 300, []string{"10 5 5060 sip.example.com"})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(paramsOf(script)).To(Equal(scriptParams{Zone: "example.com", Name: "_sip._tcp", Type: dns.RS_SRV, TTL: 300, Records: []recordParams{
+		{"Srv": true, "Priority": float64(10), "Weight": float64(5), "Port": float64(5060), "DomainName": "sip.example.com."},
+	}}))
+	// the previous records are restored if adding the new ones fails
+	removed := strings.Index(script, "$old | Remove-DnsServerResourceRecord")
+	added := strings.Index(script, "Add-DnsServerResourceRecord -ZoneName $p.zone -Name $p.name @server @record")
+	restored := strings.Index(script, "Add-DnsServerResourceRecord -ZoneName $p.zone @server -InputObject $_")
+	Expect(removed).To(BeNumerically(">", strings.Index(script, "try {\n  $old")))
+	Expect(added).To(BeNumerically(">", removed))
+	Expect(restored).To(BeNumerically(">", strings.Index(script, "} catch {\n  $added")))
+
+	script, err = s.ReplaceRecords("example.com", relativeName("example.com", "example.com"), dns.RS_TXT, 60, []string{`"it's me"`})
+	Expect(err).NotTo(HaveOccurred())
+	Expect(paramsOf(script).Records).To(Equal([]recordParams{{"Txt": true, "DescriptiveText": "it's me"}}))
+
+	// values are never spliced into the script
+	for _, v := range []string{"'", "\u2018", "\u2019", "\u201a", "\u201b", "$("} {
+		evil := `"` + v + `; Remove-Item -Recurse #"`
+		script, err = s.ReplaceRecords("example.com", "x"+v+"y", dns.RS_TXT, 60, []string{evil})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(script).NotTo(ContainSubstring("Remove-Item"))
+		Expect(script).NotTo(ContainSubstring("x" + v + "y"))
+		params := paramsOf(script)
+		Expect(params.Name).To(Equal("x" + v + "y"))
+		Expect(params.Records[0]["DescriptiveText"]).To(ContainSubstring("Remove-Item"))
+	}
+
+	_, err = s.ReplaceRecords("example.com", "www", dns.RS_SRV, 60, []string{"invalid"})
+	Expect(err).To(HaveOccurred())
+}
+
+// ntlmServer asks for NTLM authentication and accepts the authenticate message of the expected user.
+type ntlmServer struct {
+	testWinRM
+	handshakes int
+}
+
+func (s *ntlmServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	data, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "Negotiate "))
+	switch {
+	case !strings.HasPrefix(auth, "Negotiate ") || len(data) < 12:
+		w.Header().Set("WWW-Authenticate", "Negotiate")
+		w.WriteHeader(http.StatusUnauthorized)
+	case data[8] == 1:
+		// challenge message with unicode and NTLM flags and an empty target info
+		challenge := make([]byte, 52)
+		copy(challenge, "NTLMSSP\x00")
+		challenge[8] = 2
+		binary.LittleEndian.PutUint32(challenge[16:], 48)
+		binary.LittleEndian.PutUint32(challenge[20:], 0x00800201)
+		binary.LittleEndian.PutUint16(challenge[40:], 4)
+		binary.LittleEndian.PutUint16(challenge[42:], 4)
+		binary.LittleEndian.PutUint32(challenge[44:], 48)
+		w.Header().Set("WWW-Authenticate", "Negotiate "+base64.StdEncoding.EncodeToString(challenge))
+		w.WriteHeader(http.StatusUnauthorized)
+	case data[8] == 3:
+		length, offset := binary.LittleEndian.Uint16(data[36:]), binary.LittleEndian.Uint32(data[40:])
+		codes := make([]uint16, length/2)
+		for i := range codes {
+			codes[i] = binary.LittleEndian.Uint16(data[int(offset)+2*i:])
+		}
+		if string(utf16.Decode(codes)) != "admin" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		s.handshakes++
+		// continue with the WinRM protocol, which checks for basic authentication
+		r.SetBasicAuth("admin", "secret")
+		s.testWinRM.ServeHTTP(w, r)
+	}
+}
+
+func TestNTLMAuthentication(t *testing.T) {
+	RegisterTestingT(t)
+
+	server := &ntlmServer{testWinRM: testWinRM{output: "[]"}}
+	ts := httptest.NewTLSServer(server)
+	t.Cleanup(ts.Close)
+	addr := ts.Listener.Addr().(*net.TCPAddr)
+	client, err := newWinRMClient(winrmConfig{host: addr.IP.String(), port: addr.Port, auth: authNTLM, username: "admin", password: "secret", insecure: true})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = client.RunPowerShell(context.TODO(), scripts{}.ListZones())
+	Expect(err).NotTo(HaveOccurred())
+	Expect(server.handshakes).To(Equal(len(server.actions)))
+	Expect(server.actions).To(Equal([]string{"Create", "Command", "Receive", "Signal", "Delete"}))
+
+	// basic authentication is not negotiated
+	client, err = newWinRMClient(winrmConfig{host: addr.IP.String(), port: addr.Port, auth: authBasic, username: "admin", password: "secret", insecure: true})
+	Expect(err).NotTo(HaveOccurred())
+	_, err = client.RunPowerShell(context.TODO(), scripts{}.ListZones())
+	Expect(err).To(MatchError(&WinRMError{StatusCode: http.StatusUnauthorized, Message: "Unauthorized"}))
+}
@@ -220,6 +220,8 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 
 	provider.Spec = *spec
 	provider.Spec.SecretRef = nil
+	// alternative secrets are not replicated
+	provider.Spec.SecretRefs = nil
 
 	if this.namespace == "" {
 		provider.Namespace = obj.GetNamespace()
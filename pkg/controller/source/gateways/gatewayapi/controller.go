@@ -0,0 +1,37 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package gatewayapi
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+var (
+	_MAIN_RESOURCE       = resources.NewGroupKind(GroupName, "Gateway")
+	_HTTPROUTES_RESOURCE = resources.NewGroupKind(GroupName, "HTTPRoute")
+)
+
+func init() {
+	source.DNSSourceController(source.NewDNSSouceTypeForCreator("k8s-gateway-dns", _MAIN_RESOURCE, NewGatewaySource), nil).
+		FinalizerDomain("dns.gardener.cloud").
+		Reconciler(HTTPRoutesReconciler, "httproutes").
+		ReconcilerWatchesByGK("httproutes", _HTTPROUTES_RESOURCE).
+		// the Gateway API CRDs are not installed in every cluster
+		ActivateExplicitly().
+		MustRegister(source.CONTROLLER_GROUP_DNS_SOURCES)
+}
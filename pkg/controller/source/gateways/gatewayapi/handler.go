@@ -0,0 +1,235 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package gatewayapi
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+type GatewaySource struct {
+	source.DefaultDNSSource
+	routes     resources.Interface
+	namespaces resources.Interface
+}
+
+func NewGatewaySource(c controller.Interface) (source.DNSSource, error) {
+	routes, err := c.GetMainCluster().Resources().GetByGK(_HTTPROUTES_RESOURCE)
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := c.GetMainCluster().Resources().GetByExample(&corev1.Namespace{})
+	if err != nil {
+		return nil, err
+	}
+	return &GatewaySource{DefaultDNSSource: source.NewDefaultDNSSource(nil), routes: routes, namespaces: namespaces}, nil
+}
+
+func (this *GatewaySource) GetDNSInfo(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*source.DNSInfo, error) {
+	gateway, ok := obj.Data().(*Gateway)
+	if !ok {
+		return nil, fmt.Errorf("unexpected gateway type: %#v", obj.Data())
+	}
+	targets, err := GetTargets(gateway)
+	if err != nil {
+		return nil, err
+	}
+	info := &source.DNSInfo{Targets: targets}
+	hosts, err := this.extractHosts(obj.ObjectName(), gateway)
+	if err != nil {
+		return nil, err
+	}
+	info.Names = utils.StringSet{}
+	all := current.AnnotatedNames.Contains("all") || current.AnnotatedNames.Contains("*")
+	for host := range hosts {
+		if all || current.AnnotatedNames.Contains(host) {
+			info.Names.Add(host)
+		}
+	}
+	_, del := current.AnnotatedNames.DiffFrom(info.Names)
+	del.Remove("all")
+	del.Remove("*")
+	if len(del) > 0 {
+		return info, fmt.Errorf("annotated dns names %s not declared by gateway or its HTTP routes", del)
+	}
+	return info, nil
+}
+
+// extractHosts collects the hostnames of the listeners of the gateway and of all HTTP routes attached to it.
+func (this *GatewaySource) extractHosts(name resources.ObjectName, gateway *Gateway) (utils.StringSet, error) {
+	objs, err := this.routes.ListCached(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	routes := make([]*HTTPRoute, 0, len(objs))
+	for _, obj := range objs {
+		if route, ok := obj.Data().(*HTTPRoute); ok {
+			routes = append(routes, route)
+		}
+	}
+	return GetHosts(name, gateway, routes, this.namespaceLabels)
+}
+
+// namespaceLabels returns the labels of a namespace.
+func (this *GatewaySource) namespaceLabels(namespace string) (labels.Set, error) {
+	obj, err := this.namespaces.GetCached(resources.NewObjectName(namespace))
+	if err != nil {
+		return nil, err
+	}
+	return obj.GetLabels(), nil
+}
+
+// GetHosts returns the hostnames of the listeners of the gateway and of the HTTP routes attached to it.
+// A route is attached, if it references the gateway, is allowed by the `allowedRoutes` of a referenced listener
+// and has been accepted by the gateway according to its status.
+func GetHosts(name resources.ObjectName, gateway *Gateway, routes []*HTTPRoute, namespaceLabels func(namespace string) (labels.Set, error)) (utils.StringSet, error) {
+	spec, err := gateway.GetSpec()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway spec: %w", err)
+	}
+	hosts := utils.StringSet{}
+	for _, l := range spec.Listeners {
+		if l.Hostname != nil && *l.Hostname != "" {
+			hosts.Add(*l.Hostname)
+		}
+	}
+
+	for _, route := range routes {
+		routeSpec, err := route.GetSpec()
+		if err != nil {
+			continue
+		}
+		attached := false
+		for _, ref := range routeSpec.ParentRefs {
+			if !ref.IsGateway() || !resources.EqualsObjectName(ref.GatewayName(route.Namespace), name) {
+				continue
+			}
+			allowed, err := isRouteAllowed(name, spec, route.Namespace, ref.SectionName, namespaceLabels)
+			if err != nil {
+				return nil, err
+			}
+			if allowed {
+				attached = true
+				break
+			}
+		}
+		if !attached || !IsRouteAccepted(name, route) {
+			continue
+		}
+		for _, h := range routeSpec.Hostnames {
+			if h != "" {
+				hosts.Add(h)
+			}
+		}
+	}
+	return hosts, nil
+}
+
+// isRouteAllowed checks whether the listeners of the gateway (or the listener with the given section name)
+// allow routes of the given namespace.
+func isRouteAllowed(name resources.ObjectName, spec *GatewaySpec, namespace string, sectionName *string, namespaceLabels func(namespace string) (labels.Set, error)) (bool, error) {
+	for _, l := range spec.Listeners {
+		if sectionName != nil && *sectionName != l.Name {
+			continue
+		}
+		from := NamespacesFromSame
+		var selector *metav1.LabelSelector
+		if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil {
+			if l.AllowedRoutes.Namespaces.From != nil {
+				from = *l.AllowedRoutes.Namespaces.From
+			}
+			selector = l.AllowedRoutes.Namespaces.Selector
+		}
+		switch from {
+		case NamespacesFromAll:
+			return true, nil
+		case NamespacesFromSame:
+			if namespace == name.Namespace() {
+				return true, nil
+			}
+		case NamespacesFromSelector:
+			if selector == nil {
+				continue
+			}
+			sel, err := metav1.LabelSelectorAsSelector(selector)
+			if err != nil {
+				return false, fmt.Errorf("invalid namespace selector of listener %s: %w", l.Name, err)
+			}
+			nsLabels, err := namespaceLabels(namespace)
+			if err != nil {
+				return false, fmt.Errorf("cannot get namespace %s: %w", namespace, err)
+			}
+			if sel.Matches(nsLabels) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// IsRouteAccepted checks whether the gateway has accepted the route according to the route status.
+func IsRouteAccepted(name resources.ObjectName, route *HTTPRoute) bool {
+	status, err := route.GetStatus()
+	if err != nil {
+		return false
+	}
+	for _, parent := range status.Parents {
+		if !parent.ParentRef.IsGateway() || !resources.EqualsObjectName(parent.ParentRef.GatewayName(route.Namespace), name) {
+			continue
+		}
+		if meta.IsStatusConditionTrue(parent.Conditions, RouteConditionAccepted) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetTargets returns the addresses assigned to the gateway.
+func GetTargets(gateway *Gateway) (utils.StringSet, error) {
+	status, err := gateway.GetStatus()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gateway status: %w", err)
+	}
+	set := utils.StringSet{}
+	for _, a := range status.Addresses {
+		if a.Value != "" {
+			set.Add(a.Value)
+		}
+	}
+	return set, nil
+}
+
+// GetParentGateways returns the names of the gateways referenced by a route in the given namespace.
+func GetParentGateways(namespace string, spec *HTTPRouteSpec) resources.ObjectNameSet {
+	set := resources.ObjectNameSet{}
+	for _, ref := range spec.ParentRefs {
+		if !ref.IsGateway() || ref.Name == "" {
+			continue
+		}
+		set.Add(ref.GatewayName(namespace))
+	}
+	return set
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package gatewayapi
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newGateway(namespace, name, spec string) *Gateway {
+	return &Gateway{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       runtime.RawExtension{Raw: []byte(spec)},
+	}
+}
+
+func newRoute(namespace, name, spec, status string) *HTTPRoute {
+	return &HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       runtime.RawExtension{Raw: []byte(spec)},
+		Status:     runtime.RawExtension{Raw: []byte(status)},
+	}
+}
+
+// accepted returns a route status with the given Accepted condition for a parent gateway.
+func accepted(namespace, name string, status metav1.ConditionStatus) string {
+	return fmt.Sprintf(`{"parents":[{"parentRef":{"namespace":%q,"name":%q},"conditions":[{"type":"Accepted","status":%q}]}]}`, namespace, name, status)
+}
+
+var namespaceLabels = map[string]labels.Set{
+	"default": {"team": "a"},
+	"shop":    {"team": "a", "expose": "true"},
+	"other":   {"team": "b"},
+}
+
+func getNamespaceLabels(namespace string) (labels.Set, error) {
+	l, ok := namespaceLabels[namespace]
+	if !ok {
+		return nil, fmt.Errorf("namespace %s not found", namespace)
+	}
+	return l, nil
+}
+
+func TestGetHostsOfListeners(t *testing.T) {
+	RegisterTestingT(t)
+
+	gateway := newGateway("default", "gw", `{"listeners":[{"name":"a","hostname":"a.example.com"},{"name":"b","hostname":""},{"name":"c"}]}`)
+	hosts, err := GetHosts(resources.NewObjectName("default", "gw"), gateway, nil, getNamespaceLabels)
+	Expect(err).NotTo(HaveOccurred())
+	Expect(hosts).To(Equal(utils.NewStringSet("a.example.com")))
+
+	_, err = GetHosts(resources.NewObjectName("default", "gw"), newGateway("default", "gw", `{"listeners":"invalid"}`), nil, getNamespaceLabels)
+	Expect(err).To(HaveOccurred())
+}
+
+func TestGetHostsOfRoutes(t *testing.T) {
+	name := resources.NewObjectName("default", "gw")
+	table := []struct {
+		title     string
+		listeners string
+		route     *HTTPRoute
+		expected  utils.StringSet
+	}{
+		{
+			title:     "accepted route of the same namespace",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"gw"}],"hostnames":["r.example.com",""]}`, accepted("default", "gw", metav1.ConditionTrue)),
+			expected:  utils.NewStringSet("r.example.com"),
+		},
+		{
+			title:     "route not accepted by the gateway",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionFalse)),
+		},
+		{
+			title:     "route without status",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"gw"}],"hostnames":["r.example.com"]}`, ""),
+		},
+		{
+			title:     "route accepted by another gateway",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "other", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route referencing another gateway",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"other"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route referencing a parent of another kind",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"kind":"Service","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route of another namespace with default allowed routes",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("shop", "r", `{"parentRefs":[{"namespace":"default","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route of another namespace with routes allowed from all namespaces",
+			listeners: `[{"name":"http","allowedRoutes":{"namespaces":{"from":"All"}}}]`,
+			route:     newRoute("shop", "r", `{"parentRefs":[{"namespace":"default","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+			expected:  utils.NewStringSet("r.example.com"),
+		},
+		{
+			title:     "route of a namespace matching the selector",
+			listeners: `[{"name":"http","allowedRoutes":{"namespaces":{"from":"Selector","selector":{"matchLabels":{"expose":"true"}}}}}]`,
+			route:     newRoute("shop", "r", `{"parentRefs":[{"namespace":"default","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+			expected:  utils.NewStringSet("r.example.com"),
+		},
+		{
+			title:     "route of a namespace not matching the selector",
+			listeners: `[{"name":"http","allowedRoutes":{"namespaces":{"from":"Selector","selector":{"matchLabels":{"expose":"true"}}}}}]`,
+			route:     newRoute("other", "r", `{"parentRefs":[{"namespace":"default","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route of the same namespace with routes only allowed from other namespaces",
+			listeners: `[{"name":"http","allowedRoutes":{"namespaces":{"from":"Selector","selector":{"matchLabels":{"expose":"true"}}}}}]`,
+			route:     newRoute("default", "r", `{"parentRefs":[{"name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route referencing the listener allowing its namespace",
+			listeners: `[{"name":"internal"},{"name":"public","allowedRoutes":{"namespaces":{"from":"All"}}}]`,
+			route:     newRoute("shop", "r", `{"parentRefs":[{"namespace":"default","name":"gw","sectionName":"public"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+			expected:  utils.NewStringSet("r.example.com"),
+		},
+		{
+			title:     "route referencing the listener not allowing its namespace",
+			listeners: `[{"name":"internal"},{"name":"public","allowedRoutes":{"namespaces":{"from":"All"}}}]`,
+			route:     newRoute("shop", "r", `{"parentRefs":[{"namespace":"default","name":"gw","sectionName":"internal"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+		{
+			title:     "route with invalid spec",
+			listeners: `[{"name":"http"}]`,
+			route:     newRoute("default", "r", `{"parentRefs":"gw"}`, accepted("default", "gw", metav1.ConditionTrue)),
+		},
+	}
+	for _, entry := range table {
+		t.Run(entry.title, func(t *testing.T) {
+			RegisterTestingT(t)
+
+			gateway := newGateway("default", "gw", `{"listeners":`+entry.listeners+`}`)
+			hosts, err := GetHosts(name, gateway, []*HTTPRoute{entry.route}, getNamespaceLabels)
+			Expect(err).NotTo(HaveOccurred())
+			expected := entry.expected
+			if expected == nil {
+				expected = utils.StringSet{}
+			}
+			Expect(hosts).To(Equal(expected))
+		})
+	}
+}
+
+func TestGetHostsWithUnknownNamespace(t *testing.T) {
+	RegisterTestingT(t)
+
+	gateway := newGateway("default", "gw", `{"listeners":[{"name":"http","allowedRoutes":{"namespaces":{"from":"Selector","selector":{"matchLabels":{"expose":"true"}}}}}]}`)
+	route := newRoute("unknown", "r", `{"parentRefs":[{"namespace":"default","name":"gw"}],"hostnames":["r.example.com"]}`, accepted("default", "gw", metav1.ConditionTrue))
+	_, err := GetHosts(resources.NewObjectName("default", "gw"), gateway, []*HTTPRoute{route}, getNamespaceLabels)
+	Expect(err).To(MatchError(ContainSubstring("namespace unknown not found")))
+}
+
+func TestGetParentGateways(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec, err := newRoute("shop", "r", `{"parentRefs":[{"name":"a"},{"namespace":"default","name":"b"},{"kind":"Service","name":"c"},{"group":"example.com","name":"d"},{"name":""}]}`, "").GetSpec()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(GetParentGateways("shop", spec)).To(Equal(resources.NewObjectNameSet(
+		resources.NewObjectName("shop", "a"),
+		resources.NewObjectName("default", "b"),
+	)))
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package gatewayapi
+
+import (
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+)
+
+// HTTPRoutesReconciler triggers the gateways referenced by changed HTTP routes.
+func HTTPRoutesReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return newHTTPRoutesReconciler(func(name resources.ObjectName) {
+		c.EnqueueKey(resources.NewClusterKey(c.GetMainCluster().GetId(), _MAIN_RESOURCE, name.Namespace(), name.Name()))
+	}), nil
+}
+
+func newHTTPRoutesReconciler(enqueue func(gateway resources.ObjectName)) *httpRoutesReconciler {
+	return &httpRoutesReconciler{
+		enqueue: enqueue,
+		parents: map[resources.ObjectName]resources.ObjectNameSet{},
+	}
+}
+
+var _ reconcile.Interface = &httpRoutesReconciler{}
+
+type httpRoutesReconciler struct {
+	reconcile.DefaultReconciler
+	enqueue func(gateway resources.ObjectName)
+
+	lock sync.Mutex
+	// parents are the gateways last referenced by a route, needed to trigger them on detach or deletion
+	parents map[resources.ObjectName]resources.ObjectNameSet
+}
+
+func (r *httpRoutesReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	route, ok := obj.Data().(*HTTPRoute)
+	if !ok {
+		return reconcile.Succeeded(logger)
+	}
+	spec, err := route.GetSpec()
+	if err != nil {
+		return reconcile.Failed(logger, err)
+	}
+	gateways := GetParentGateways(route.Namespace, spec)
+	r.triggerGateways(logger, obj.ObjectName(), gateways)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *httpRoutesReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	r.triggerGateways(logger, key.ObjectName(), nil)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *httpRoutesReconciler) triggerGateways(logger logger.LogContext, route resources.ObjectName, gateways resources.ObjectNameSet) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	all := resources.ObjectNameSet{}
+	all.AddSet(r.parents[route])
+	all.AddSet(gateways)
+	if len(gateways) > 0 {
+		r.parents[route] = gateways
+	} else {
+		delete(r.parents, route)
+	}
+	for name := range all {
+		logger.Infof("trigger gateway %s for HTTP route %s", name, route)
+		r.enqueue(name)
+	}
+}
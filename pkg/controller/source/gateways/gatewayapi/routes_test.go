@@ -0,0 +1,57 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package gatewayapi
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/gomega"
+)
+
+func TestHTTPRoutesReconcilerTriggersGateways(t *testing.T) {
+	RegisterTestingT(t)
+
+	triggered := resources.ObjectNameSet{}
+	r := newHTTPRoutesReconciler(func(name resources.ObjectName) { triggered.Add(name) })
+	log := logger.New()
+	route := resources.NewObjectName("shop", "r")
+	gw1 := resources.NewObjectName("default", "gw1")
+	gw2 := resources.NewObjectName("default", "gw2")
+	gw3 := resources.NewObjectName("shop", "gw3")
+
+	// attach to two gateways
+	r.triggerGateways(log, route, resources.NewObjectNameSet(gw1, gw2))
+	Expect(triggered).To(Equal(resources.NewObjectNameSet(gw1, gw2)))
+
+	// move from gw1 to gw3: the detached gateway is triggered, too
+	triggered = resources.ObjectNameSet{}
+	r.triggerGateways(log, route, resources.NewObjectNameSet(gw2, gw3))
+	Expect(triggered).To(Equal(resources.NewObjectNameSet(gw1, gw2, gw3)))
+
+	// deletion triggers the gateways last referenced
+	triggered = resources.ObjectNameSet{}
+	Expect(r.Deleted(log, resources.NewClusterKey("default", _HTTPROUTES_RESOURCE, "shop", "r")).IsSucceeded()).To(BeTrue())
+	Expect(triggered).To(Equal(resources.NewObjectNameSet(gw2, gw3)))
+	Expect(r.parents).To(BeEmpty())
+
+	// nothing to trigger for unknown routes
+	triggered = resources.ObjectNameSet{}
+	r.Deleted(log, resources.NewClusterKey("default", _HTTPROUTES_RESOURCE, "shop", "r"))
+	Expect(triggered).To(BeEmpty())
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package gatewayapi
+
+import (
+	"encoding/json"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Gateway API resources are defined by CRDs of the Gateway API project. Only the metadata is
+// typed here, spec and status are kept as raw JSON. So updates of the metadata (finalizers,
+// annotations) done by the source controller preserve all fields, independent of the Gateway API
+// version installed in the cluster. The relevant parts are decoded on demand.
+
+const GroupName = "gateway.networking.k8s.io"
+
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta1"}
+
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Gateway{},
+		&GatewayList{},
+		&HTTPRoute{},
+		&HTTPRouteList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              runtime.RawExtension `json:"spec"`
+	Status            runtime.RawExtension `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type GatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Gateway `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HTTPRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              runtime.RawExtension `json:"spec"`
+	Status            runtime.RawExtension `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type HTTPRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HTTPRoute `json:"items"`
+}
+
+// GatewaySpec is the relevant part of the spec of a Gateway.
+// +k8s:deepcopy-gen=false
+type GatewaySpec struct {
+	Listeners []Listener `json:"listeners,omitempty"`
+}
+
+// Listener is the relevant part of a listener of a Gateway.
+// +k8s:deepcopy-gen=false
+type Listener struct {
+	Name          string         `json:"name"`
+	Hostname      *string        `json:"hostname,omitempty"`
+	AllowedRoutes *AllowedRoutes `json:"allowedRoutes,omitempty"`
+}
+
+// AllowedRoutes restricts the routes which may be attached to a listener.
+// +k8s:deepcopy-gen=false
+type AllowedRoutes struct {
+	Namespaces *RouteNamespaces `json:"namespaces,omitempty"`
+}
+
+// RouteNamespaces selects the namespaces of the routes which may be attached to a listener.
+// +k8s:deepcopy-gen=false
+type RouteNamespaces struct {
+	// From is one of "All", "Same" (default) or "Selector".
+	From     *string               `json:"from,omitempty"`
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+const (
+	NamespacesFromAll      = "All"
+	NamespacesFromSame     = "Same"
+	NamespacesFromSelector = "Selector"
+)
+
+// GatewayStatus is the relevant part of the status of a Gateway.
+// +k8s:deepcopy-gen=false
+type GatewayStatus struct {
+	Addresses []GatewayAddress `json:"addresses,omitempty"`
+}
+
+// GatewayAddress is an address assigned to a Gateway.
+// +k8s:deepcopy-gen=false
+type GatewayAddress struct {
+	Type  *string `json:"type,omitempty"`
+	Value string  `json:"value"`
+}
+
+// HTTPRouteSpec is the relevant part of the spec of a HTTPRoute.
+// +k8s:deepcopy-gen=false
+type HTTPRouteSpec struct {
+	ParentRefs []ParentReference `json:"parentRefs,omitempty"`
+	Hostnames  []string          `json:"hostnames,omitempty"`
+}
+
+// HTTPRouteStatus is the relevant part of the status of a HTTPRoute.
+// +k8s:deepcopy-gen=false
+type HTTPRouteStatus struct {
+	Parents []RouteParentStatus `json:"parents,omitempty"`
+}
+
+// RouteParentStatus is the status of a route for one of its parents, as reported by the gateway controller.
+// +k8s:deepcopy-gen=false
+type RouteParentStatus struct {
+	ParentRef  ParentReference    `json:"parentRef"`
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// RouteConditionAccepted is the condition type reporting whether a route has been accepted by its parent.
+const RouteConditionAccepted = "Accepted"
+
+// ParentReference references a parent (usually a Gateway) of a route.
+// +k8s:deepcopy-gen=false
+type ParentReference struct {
+	Group       *string `json:"group,omitempty"`
+	Kind        *string `json:"kind,omitempty"`
+	Namespace   *string `json:"namespace,omitempty"`
+	Name        string  `json:"name"`
+	SectionName *string `json:"sectionName,omitempty"`
+}
+
+// GetSpec decodes the relevant part of the spec of the gateway.
+func (g *Gateway) GetSpec() (*GatewaySpec, error) {
+	spec := &GatewaySpec{}
+	return spec, decodeRaw(g.Spec, spec)
+}
+
+// GetStatus decodes the relevant part of the status of the gateway.
+func (g *Gateway) GetStatus() (*GatewayStatus, error) {
+	status := &GatewayStatus{}
+	return status, decodeRaw(g.Status, status)
+}
+
+// GetSpec decodes the relevant part of the spec of the route.
+func (r *HTTPRoute) GetSpec() (*HTTPRouteSpec, error) {
+	spec := &HTTPRouteSpec{}
+	return spec, decodeRaw(r.Spec, spec)
+}
+
+// GetStatus decodes the relevant part of the status of the route.
+func (r *HTTPRoute) GetStatus() (*HTTPRouteStatus, error) {
+	status := &HTTPRouteStatus{}
+	return status, decodeRaw(r.Status, status)
+}
+
+// IsGateway checks whether the parent reference refers to a Gateway.
+func (r ParentReference) IsGateway() bool {
+	return (r.Group == nil || *r.Group == GroupName) && (r.Kind == nil || *r.Kind == "Gateway")
+}
+
+// GatewayName returns the name of the referenced gateway for a route in the given namespace.
+func (r ParentReference) GatewayName(namespace string) resources.ObjectName {
+	if r.Namespace != nil && *r.Namespace != "" {
+		namespace = *r.Namespace
+	}
+	return resources.NewObjectName(namespace, r.Name)
+}
+
+func decodeRaw(raw runtime.RawExtension, obj interface{}) error {
+	if len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, obj)
+}
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package istio
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/gardener/external-dns-management/pkg/dns/source"
+)
+
+type GatewaySource struct {
+	source.DefaultDNSSource
+	virtualServices resources.Interface
+	services        resources.Interface
+}
+
+func NewGatewaySource(c controller.Interface) (source.DNSSource, error) {
+	virtualServices, err := c.GetMainCluster().Resources().GetByGK(_VIRTUALSERVICES_RESOURCE)
+	if err != nil {
+		return nil, err
+	}
+	services, err := c.GetMainCluster().Resources().GetByGK(_SERVICES_RESOURCE)
+	if err != nil {
+		return nil, err
+	}
+	return &GatewaySource{
+		DefaultDNSSource: source.NewDefaultDNSSource(nil),
+		virtualServices:  virtualServices,
+		services:         services,
+	}, nil
+}
+
+func (this *GatewaySource) GetDNSInfo(logger logger.LogContext, obj resources.Object, current *source.DNSCurrentState) (*source.DNSInfo, error) {
+	gateway, ok := obj.Data().(*Gateway)
+	if !ok {
+		return nil, fmt.Errorf("unexpected istio gateway type: %#v", obj.Data())
+	}
+	spec, err := gateway.GetSpec()
+	if err != nil {
+		return nil, fmt.Errorf("invalid istio gateway spec: %w", err)
+	}
+	targets, err := this.getTargets(spec)
+	if err != nil {
+		return nil, err
+	}
+	info := &source.DNSInfo{Targets: targets}
+	hosts, err := this.extractHosts(obj.ObjectName(), spec)
+	if err != nil {
+		return nil, err
+	}
+	info.Names = utils.StringSet{}
+	all := current.AnnotatedNames.Contains("all") || current.AnnotatedNames.Contains("*")
+	for host := range hosts {
+		if all || current.AnnotatedNames.Contains(host) {
+			info.Names.Add(host)
+		}
+	}
+	_, del := current.AnnotatedNames.DiffFrom(info.Names)
+	del.Remove("all")
+	del.Remove("*")
+	if len(del) > 0 {
+		return info, fmt.Errorf("annotated dns names %s not declared by istio gateway or its virtual services", del)
+	}
+	return info, nil
+}
+
+// extractHosts collects the hosts of the servers of the gateway and of all virtual services bound to it.
+func (this *GatewaySource) extractHosts(name resources.ObjectName, spec *GatewaySpec) (utils.StringSet, error) {
+	list, err := this.virtualServices.ListCached(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	virtualServices := make([]*VirtualService, 0, len(list))
+	for _, obj := range list {
+		if vs, ok := obj.Data().(*VirtualService); ok {
+			virtualServices = append(virtualServices, vs)
+		}
+	}
+	return GetHosts(name, spec, virtualServices), nil
+}
+
+// GetHosts returns the hosts of the servers of the gateway and of the given virtual services bound to it.
+func GetHosts(name resources.ObjectName, spec *GatewaySpec, virtualServices []*VirtualService) utils.StringSet {
+	hosts := utils.StringSet{}
+	for _, s := range spec.Servers {
+		for _, h := range s.Hosts {
+			if h = HostName(h); h != "" && h != "*" {
+				hosts.Add(h)
+			}
+		}
+	}
+
+	for _, vs := range virtualServices {
+		vspec, err := vs.GetSpec()
+		if err != nil {
+			continue
+		}
+		if !GetGateways(vs.Namespace, vspec).Contains(name) {
+			continue
+		}
+		for _, h := range vspec.Hosts {
+			if h != "" && h != "*" {
+				hosts.Add(h)
+			}
+		}
+	}
+	return hosts
+}
+
+// getTargets returns the load balancer addresses of the services of the ingress gateway selected by the gateway.
+func (this *GatewaySource) getTargets(spec *GatewaySpec) (utils.StringSet, error) {
+	if len(spec.Selector) == 0 {
+		return utils.StringSet{}, nil
+	}
+	list, err := this.services.ListCached(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	services := make([]*api.Service, 0, len(list))
+	for _, obj := range list {
+		if svc, ok := obj.Data().(*api.Service); ok {
+			services = append(services, svc)
+		}
+	}
+	return GetTargets(spec, services), nil
+}
+
+// GetTargets returns the load balancer addresses of the given services exposing the ingress gateway selected by the gateway.
+func GetTargets(spec *GatewaySpec, services []*api.Service) utils.StringSet {
+	set := utils.StringSet{}
+	for _, svc := range services {
+		if !SelectsService(spec, svc) {
+			continue
+		}
+		for _, i := range svc.Status.LoadBalancer.Ingress {
+			if i.Hostname != "" && i.IP == "" {
+				set.Add(i.Hostname)
+			} else if i.IP != "" {
+				set.Add(i.IP)
+			}
+		}
+	}
+	return set
+}
+
+// SelectsService checks whether the service of type LoadBalancer exposes the ingress gateway pods selected by the gateway.
+func SelectsService(spec *GatewaySpec, svc *api.Service) bool {
+	if len(spec.Selector) == 0 || len(svc.Spec.Selector) == 0 || svc.Spec.Type != api.ServiceTypeLoadBalancer {
+		return false
+	}
+	return labels.SelectorFromSet(spec.Selector).Matches(labels.Set(svc.Spec.Selector))
+}
+
+// GetGateways returns the names of the gateways referenced by a virtual service in the given namespace.
+func GetGateways(namespace string, spec *VirtualServiceSpec) resources.ObjectNameSet {
+	set := resources.ObjectNameSet{}
+	for _, ref := range spec.Gateways {
+		if ref == "" || ref == "mesh" {
+			continue
+		}
+		ns := namespace
+		name := ref
+		if i := strings.Index(ref, "/"); i >= 0 {
+			ns = ref[:i]
+			name = ref[i+1:]
+		}
+		set.Add(resources.NewObjectName(ns, name))
+	}
+	return set
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package istio
+
+import (
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/gomega"
+	api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newVirtualService(namespace, name, spec string) *VirtualService {
+	return &VirtualService{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       runtime.RawExtension{Raw: []byte(spec)},
+	}
+}
+
+func gatewaySpec(t *testing.T, spec string) *GatewaySpec {
+	gateway := &Gateway{Spec: runtime.RawExtension{Raw: []byte(spec)}}
+	result, err := gateway.GetSpec()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestGetHostsOfGateway(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec := gatewaySpec(t, `{"servers":[{"hosts":["a.example.com","shop/b.example.com","*/c.example.com"]},{"hosts":["*","shop/*",""]}]}`)
+	hosts := GetHosts(resources.NewObjectName("istio-system", "gw"), spec, nil)
+	Expect(hosts).To(Equal(utils.NewStringSet("a.example.com", "b.example.com", "c.example.com")))
+}
+
+func TestGetHostsOfVirtualServices(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec := gatewaySpec(t, `{"servers":[{"hosts":["gw.example.com"]}]}`)
+	virtualServices := []*VirtualService{
+		// bound by name in the same namespace
+		newVirtualService("istio-system", "a", `{"hosts":["a.example.com","*",""],"gateways":["gw"]}`),
+		// bound with namespace
+		newVirtualService("shop", "b", `{"hosts":["b.example.com"],"gateways":["mesh","istio-system/gw"]}`),
+		// bound by name, but in another namespace
+		newVirtualService("shop", "c", `{"hosts":["c.example.com"],"gateways":["gw"]}`),
+		// only bound to the sidecars
+		newVirtualService("istio-system", "d", `{"hosts":["d.example.com"],"gateways":["mesh"]}`),
+		// without gateways
+		newVirtualService("istio-system", "e", `{"hosts":["e.example.com"]}`),
+		// bound to another gateway
+		newVirtualService("istio-system", "f", `{"hosts":["f.example.com"],"gateways":["istio-system/other"]}`),
+		// invalid spec
+		newVirtualService("istio-system", "g", `{"hosts":"g.example.com","gateways":["gw"]}`),
+	}
+	hosts := GetHosts(resources.NewObjectName("istio-system", "gw"), spec, virtualServices)
+	Expect(hosts).To(Equal(utils.NewStringSet("gw.example.com", "a.example.com", "b.example.com")))
+}
+
+func TestGetGateways(t *testing.T) {
+	RegisterTestingT(t)
+
+	spec := &VirtualServiceSpec{Gateways: []string{"a", "other/b", "mesh", ""}}
+	Expect(GetGateways("shop", spec)).To(Equal(resources.NewObjectNameSet(
+		resources.NewObjectName("shop", "a"),
+		resources.NewObjectName("other", "b"),
+	)))
+}
+
+func newService(name string, stype api.ServiceType, selector map[string]string, ingress ...api.LoadBalancerIngress) *api.Service {
+	svc := &api.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "istio-system", Name: name},
+		Spec:       api.ServiceSpec{Type: stype, Selector: selector},
+	}
+	svc.Status.LoadBalancer.Ingress = ingress
+	return svc
+}
+
+func TestGetTargets(t *testing.T) {
+	RegisterTestingT(t)
+
+	selector := map[string]string{"istio": "ingressgateway"}
+	services := []*api.Service{
+		newService("lb", api.ServiceTypeLoadBalancer, map[string]string{"istio": "ingressgateway", "app": "istio"},
+			api.LoadBalancerIngress{IP: "1.2.3.4"}, api.LoadBalancerIngress{Hostname: "lb.example.com"},
+			api.LoadBalancerIngress{IP: "5.6.7.8", Hostname: "ignored.example.com"}),
+		newService("cluster-ip", api.ServiceTypeClusterIP, selector, api.LoadBalancerIngress{IP: "10.0.0.1"}),
+		newService("other", api.ServiceTypeLoadBalancer, map[string]string{"istio": "other"}, api.LoadBalancerIngress{IP: "10.0.0.2"}),
+		newService("no-selector", api.ServiceTypeLoadBalancer, nil, api.LoadBalancerIngress{IP: "10.0.0.3"}),
+	}
+
+	spec := gatewaySpec(t, `{"selector":{"istio":"ingressgateway"}}`)
+	Expect(GetTargets(spec, services)).To(Equal(utils.NewStringSet("1.2.3.4", "lb.example.com", "5.6.7.8")))
+
+	spec = gatewaySpec(t, `{}`)
+	Expect(GetTargets(spec, services)).To(BeEmpty())
+}
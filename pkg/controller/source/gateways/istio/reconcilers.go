@@ -0,0 +1,146 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package istio
+
+import (
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// gatewayTrigger enqueues the gateways related to an object. The gateways last related
+// to an object are remembered to trigger them on detach or deletion.
+type gatewayTrigger struct {
+	controller controller.Interface
+	kind       string
+
+	lock     sync.Mutex
+	gateways map[resources.ObjectName]resources.ObjectNameSet
+}
+
+func newGatewayTrigger(c controller.Interface, kind string) gatewayTrigger {
+	return gatewayTrigger{
+		controller: c,
+		kind:       kind,
+		gateways:   map[resources.ObjectName]resources.ObjectNameSet{},
+	}
+}
+
+func (t *gatewayTrigger) triggerGateways(logger logger.LogContext, obj resources.ObjectName, gateways resources.ObjectNameSet) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	all := resources.ObjectNameSet{}
+	all.AddSet(t.gateways[obj])
+	all.AddSet(gateways)
+	if len(gateways) > 0 {
+		t.gateways[obj] = gateways
+	} else {
+		delete(t.gateways, obj)
+	}
+	for name := range all {
+		logger.Infof("trigger istio gateway %s for %s %s", name, t.kind, obj)
+		t.controller.EnqueueKey(resources.NewClusterKey(t.controller.GetMainCluster().GetId(), _MAIN_RESOURCE, name.Namespace(), name.Name()))
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// VirtualServicesReconciler triggers the gateways referenced by changed virtual services.
+func VirtualServicesReconciler(c controller.Interface) (reconcile.Interface, error) {
+	return &virtualServicesReconciler{gatewayTrigger: newGatewayTrigger(c, "virtual service")}, nil
+}
+
+var _ reconcile.Interface = &virtualServicesReconciler{}
+
+type virtualServicesReconciler struct {
+	reconcile.DefaultReconciler
+	gatewayTrigger
+}
+
+func (r *virtualServicesReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	vs, ok := obj.Data().(*VirtualService)
+	if !ok {
+		return reconcile.Succeeded(logger)
+	}
+	spec, err := vs.GetSpec()
+	if err != nil {
+		return reconcile.Failed(logger, err)
+	}
+	r.triggerGateways(logger, obj.ObjectName(), GetGateways(vs.Namespace, spec))
+	return reconcile.Succeeded(logger)
+}
+
+func (r *virtualServicesReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	r.triggerGateways(logger, key.ObjectName(), nil)
+	return reconcile.Succeeded(logger)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ServicesReconciler triggers the gateways selecting the ingress gateway pods of changed services.
+func ServicesReconciler(c controller.Interface) (reconcile.Interface, error) {
+	gateways, err := c.GetMainCluster().Resources().GetByGK(_MAIN_RESOURCE)
+	if err != nil {
+		return nil, err
+	}
+	return &servicesReconciler{gatewayTrigger: newGatewayTrigger(c, "service"), istioGateways: gateways}, nil
+}
+
+var _ reconcile.Interface = &servicesReconciler{}
+
+type servicesReconciler struct {
+	reconcile.DefaultReconciler
+	gatewayTrigger
+	istioGateways resources.Interface
+}
+
+func (r *servicesReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	svc, ok := obj.Data().(*api.Service)
+	if !ok {
+		return reconcile.Succeeded(logger)
+	}
+	list, err := r.istioGateways.ListCached(labels.Everything())
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	gateways := resources.ObjectNameSet{}
+	for _, o := range list {
+		gateway, ok := o.Data().(*Gateway)
+		if !ok {
+			continue
+		}
+		spec, err := gateway.GetSpec()
+		if err != nil {
+			continue
+		}
+		if SelectsService(spec, svc) {
+			gateways.Add(o.ObjectName())
+		}
+	}
+	r.triggerGateways(logger, obj.ObjectName(), gateways)
+	return reconcile.Succeeded(logger)
+}
+
+func (r *servicesReconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	r.triggerGateways(logger, key.ObjectName(), nil)
+	return reconcile.Succeeded(logger)
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package istio
+
+import (
+	"encoding/json"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Istio resources are defined by CRDs of the Istio project. As for the Gateway API, only the
+// metadata is typed here, spec and status are kept as raw JSON and decoded on demand.
+
+const GroupName = "networking.istio.io"
+
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1beta1"}
+
+var (
+	SchemeBuilder = runtime.NewSchemeBuilder(addKnownTypes)
+	AddToScheme   = SchemeBuilder.AddToScheme
+)
+
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Gateway{},
+		&GatewayList{},
+		&VirtualService{},
+		&VirtualServiceList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Gateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              runtime.RawExtension `json:"spec"`
+	Status            runtime.RawExtension `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type GatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Gateway `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VirtualService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              runtime.RawExtension `json:"spec"`
+	Status            runtime.RawExtension `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type VirtualServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualService `json:"items"`
+}
+
+// GatewaySpec is the relevant part of the spec of a Gateway.
+// +k8s:deepcopy-gen=false
+type GatewaySpec struct {
+	// Selector selects the pods of the ingress gateway
+	Selector map[string]string `json:"selector,omitempty"`
+	Servers  []Server          `json:"servers,omitempty"`
+}
+
+// Server is the relevant part of a server of a Gateway.
+// +k8s:deepcopy-gen=false
+type Server struct {
+	// Hosts are given as `[<namespace>/]<dnsname>`
+	Hosts []string `json:"hosts,omitempty"`
+}
+
+// VirtualServiceSpec is the relevant part of the spec of a VirtualService.
+// +k8s:deepcopy-gen=false
+type VirtualServiceSpec struct {
+	Hosts []string `json:"hosts,omitempty"`
+	// Gateways are given as `[<namespace>/]<name>`, the reserved name `mesh` denotes the sidecars
+	Gateways []string `json:"gateways,omitempty"`
+}
+
+// GetSpec decodes the relevant part of the spec of the gateway.
+func (g *Gateway) GetSpec() (*GatewaySpec, error) {
+	spec := &GatewaySpec{}
+	return spec, decodeRaw(g.Spec, spec)
+}
+
+// GetSpec decodes the relevant part of the spec of the virtual service.
+func (v *VirtualService) GetSpec() (*VirtualServiceSpec, error) {
+	spec := &VirtualServiceSpec{}
+	return spec, decodeRaw(v.Spec, spec)
+}
+
+// HostName returns the DNS name of a server host, i.e. without the namespace part.
+func HostName(host string) string {
+	if i := strings.Index(host, "/"); i >= 0 {
+		return host[i+1:]
+	}
+	return host
+}
+
+func decodeRaw(raw runtime.RawExtension, obj interface{}) error {
+	if len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, obj)
+}
@@ -27,4 +27,31 @@ const CLASS_ANNOTATION = ANNOTATION_GROUP + "/class"
 const REALM_ANNOTATION = ANNOTATION_GROUP + "/realms"
 const NOT_RATE_LIMITED_ANNOTATION = ANNOTATION_GROUP + "/not-rate-limited"
 
+// TARGET_RESOURCE_ANNOTATION references a provider specific cloud resource (e.g. a GCP forwarding rule)
+// whose addresses are used as targets of a DNS entry
+const TARGET_RESOURCE_ANNOTATION = ANNOTATION_GROUP + "/target-resource"
+
+// RESOURCE_TAGS_ANNOTATION is a comma separated list of tags (key=value) of a DNS provider
+// to be attached to the objects created by the provider, if supported by the provider type
+const RESOURCE_TAGS_ANNOTATION = ANNOTATION_GROUP + "/resource-tags"
+
+// ZONE_STATE_CACHE_TTL_ANNOTATION overrides the TTL of the cached zone states for all zones of a DNS provider
+// (a DNSHostedZonePolicy with zoneStateCacheTTL takes precedence)
+const ZONE_STATE_CACHE_TTL_ANNOTATION = ANNOTATION_GROUP + "/zone-state-cache-ttl"
+
 const OPT_SETUP = "setup"
+
+// SOURCE_ANNOTATION describes the source object (<kind>.<group>/<namespace>/<name>) a DNS entry has been generated for
+const SOURCE_ANNOTATION = ANNOTATION_GROUP + "/source"
+
+// SOURCE_UID_LABEL contains the uid of the source object a DNS entry has been generated for
+const SOURCE_UID_LABEL = ANNOTATION_GROUP + "/source-uid"
+
+// SOURCE_HASH_ANNOTATION contains the hash of the entry spec as generated by the source controller
+const SOURCE_HASH_ANNOTATION = ANNOTATION_GROUP + "/source-hash"
+
+// ORPHANED_SOURCE_ANNOTATION describes the source object a retained DNS entry has been orphaned from
+const ORPHANED_SOURCE_ANNOTATION = ANNOTATION_GROUP + "/orphaned-source"
+
+// SOURCE_OVERRIDE_ANNOTATION allows modifications of a generated DNS entry by others than its source if set to "true"
+const SOURCE_OVERRIDE_ANNOTATION = ANNOTATION_GROUP + "/source-override"
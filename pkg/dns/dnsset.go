@@ -67,21 +67,34 @@ func (dnssets DNSSets) AddRecordSetFromProvider(dnsname string, rs *RecordSet) {
 	dnssets.AddRecordSet(name, rs)
 }
 
+// DNSSetKey returns the key of a DNS set in a DNSSets map.
+// Record sets with a set identifier (e.g. weighted record sets) are
+// maintained as separate DNS sets for the same DNS name.
+func DNSSetKey(name, setIdentifier string) string {
+	if setIdentifier == "" {
+		return name
+	}
+	return name + "#" + setIdentifier
+}
+
 func (dnssets DNSSets) AddRecordSet(name string, rs *RecordSet) {
-	dnsset := dnssets[name]
+	setIdentifier := rs.RoutingPolicy.GetSetIdentifier()
+	key := DNSSetKey(name, setIdentifier)
+	dnsset := dnssets[key]
 	if dnsset == nil {
 		dnsset = NewDNSSet(name)
-		dnssets[name] = dnsset
+		dnsset.SetIdentifier = setIdentifier
+		dnssets[key] = dnsset
 	}
 	dnsset.Sets[rs.Type] = rs
 }
 
-func (dnssets DNSSets) RemoveRecordSet(name string, recordSetType string) {
-	dnsset := dnssets[name]
+func (dnssets DNSSets) RemoveRecordSet(key string, recordSetType string) {
+	dnsset := dnssets[key]
 	if dnsset != nil {
 		delete(dnsset.Sets, recordSetType)
 		if len(dnsset.Sets) == 0 {
-			delete(dnssets, name)
+			delete(dnssets, key)
 		}
 	}
 }
@@ -107,24 +120,33 @@ func (dnssets DNSSets) GetOwners() utils.StringSet {
 }
 
 const (
-	ATTR_OWNER  = "owner"
-	ATTR_PREFIX = "prefix"
-	ATTR_CNAMES = "cnames"
-	ATTR_KIND   = "kind"
+	ATTR_OWNER       = "owner"
+	ATTR_OWNER_GROUP = "ownergroup"
+	ATTR_PREFIX      = "prefix"
+	ATTR_CNAMES      = "cnames"
+	ATTR_KIND        = "kind"
+	ATTR_CREATED     = "created"
 
 	ATTR_TIMESTAMP = "ts"
 	ATTR_LOCKID    = "lockid"
 )
 
 type DNSSet struct {
-	Name        string
-	Kind        string
-	UpdateGroup string
-	Sets        RecordSets
+	Name string
+	// SetIdentifier is set for DNS sets sharing the DNS name with other sets (e.g. weighted record sets)
+	SetIdentifier string
+	Kind          string
+	UpdateGroup   string
+	Sets          RecordSets
+}
+
+// Key returns the key of the DNS set in a DNSSets map.
+func (this *DNSSet) Key() string {
+	return DNSSetKey(this.Name, this.SetIdentifier)
 }
 
 func (this *DNSSet) Clone() *DNSSet {
-	return &DNSSet{Name: this.Name, Sets: this.Sets.Clone(), UpdateGroup: this.UpdateGroup, Kind: this.Kind}
+	return &DNSSet{Name: this.Name, SetIdentifier: this.SetIdentifier, Sets: this.Sets.Clone(), UpdateGroup: this.UpdateGroup, Kind: this.Kind}
 }
 
 func (this *DNSSet) getAttr(ty string, name string) string {
@@ -190,6 +212,20 @@ func (this *DNSSet) GetOwner() string {
 	return this.GetMetaAttr(ATTR_OWNER)
 }
 
+func (this *DNSSet) GetOwnerGroup() string {
+	return this.GetMetaAttr(ATTR_OWNER_GROUP)
+}
+
+// SetOwnerGroup sets the owner group or removes it for an empty group.
+func (this *DNSSet) SetOwnerGroup(group string) *DNSSet {
+	if group == "" {
+		this.DeleteMetaAttr(ATTR_OWNER_GROUP)
+	} else {
+		this.SetMetaAttr(ATTR_OWNER_GROUP, group)
+	}
+	return this
+}
+
 func (this *DNSSet) SetOwner(ownerid string) *DNSSet {
 	this.SetMetaAttr(ATTR_OWNER, ownerid)
 	return this
@@ -222,7 +258,7 @@ func (this *DNSSet) SetRecordSet(rtype string, ttl int64, values ...string) {
 	for i, r := range values {
 		records[i] = &Record{Value: r}
 	}
-	this.Sets[rtype] = &RecordSet{rtype, ttl, false, records}
+	this.Sets[rtype] = &RecordSet{rtype, ttl, false, records, nil, nil}
 }
 
 func NewDNSSet(name string) *DNSSet {
@@ -0,0 +1,245 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// testProviderObject provides the provider data of a DNSProviderObject without cluster access.
+type testProviderObject struct {
+	resources.Object
+	provider *api.DNSProvider
+}
+
+func (o *testProviderObject) Data() resources.ObjectData {
+	return o.provider
+}
+
+func (o *testProviderObject) ObjectName() resources.ObjectName {
+	return resources.NewObjectName(o.provider.Namespace, o.provider.Name)
+}
+
+func (o *testProviderObject) GetNamespace() string {
+	return o.provider.Namespace
+}
+
+func newTestProvider(name, config string) *dnsutils.DNSProviderObject {
+	p := &api.DNSProvider{}
+	p.Namespace = "default"
+	p.Name = name
+	p.Spec.Type = "test"
+	if config != "" {
+		p.Spec.ProviderConfig = &runtime.RawExtension{Raw: []byte(config)}
+	}
+	return &dnsutils.DNSProviderObject{Object: &testProviderObject{provider: p}}
+}
+
+type updateTestHandler struct {
+	DefaultDNSHandler
+	err         error
+	configs     []*DNSHandlerConfig
+	credentials []utils.Properties
+}
+
+func (h *updateTestHandler) GetZones(ctx context.Context) (DNSHostedZones, error) {
+	return nil, nil
+}
+
+func (h *updateTestHandler) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	return nil, nil
+}
+
+func (h *updateTestHandler) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
+	return false
+}
+
+func (h *updateTestHandler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	return nil
+}
+
+func (h *updateTestHandler) Release() {
+}
+
+func (h *updateTestHandler) update(config *DNSHandlerConfig) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.configs = append(h.configs, config)
+	return nil
+}
+
+func (h *updateTestHandler) updateCredentials(config *DNSHandlerConfig) error {
+	if h.err != nil {
+		return h.err
+	}
+	h.credentials = append(h.credentials, config.Properties)
+	return nil
+}
+
+type configUpdateTestHandler struct {
+	updateTestHandler
+}
+
+func (h *configUpdateTestHandler) UpdateConfig(config *DNSHandlerConfig) error {
+	return h.update(config)
+}
+
+type credentialsUpdateTestHandler struct {
+	updateTestHandler
+}
+
+func (h *credentialsUpdateTestHandler) UpdateCredentials(config *DNSHandlerConfig) error {
+	return h.updateCredentials(config)
+}
+
+type fullUpdateTestHandler struct {
+	updateTestHandler
+}
+
+func (h *fullUpdateTestHandler) UpdateConfig(config *DNSHandlerConfig) error {
+	return h.update(config)
+}
+
+func (h *fullUpdateTestHandler) UpdateCredentials(config *DNSHandlerConfig) error {
+	return h.updateCredentials(config)
+}
+
+var _ = ginkgov2.Describe("Account update", func() {
+	var (
+		cache    *AccountCache
+		provider *dnsutils.DNSProviderObject
+		props    utils.Properties
+	)
+
+	newAccount := func(handler DNSHandler) *DNSAccount {
+		a := NewDNSAccount(props, handler, cache.Hash(props, "test", provider.Spec().ProviderConfig, nil))
+		a.credentialsHash = cache.CredentialsHash(props, "test")
+		a.clients.Add(provider.ObjectName())
+		cache.cache[a.key] = a
+		return a
+	}
+
+	update := func(last *DNSAccount, provider *dnsutils.DNSProviderObject, props utils.Properties) bool {
+		hash := cache.Hash(props, "test", provider.Spec().ProviderConfig, nil)
+		return cache.updateAccount(logger.New(), last, provider, props, nil, hash, cache.CredentialsHash(props, "test"))
+	}
+
+	rotated := utils.Properties{"key": "rotated"}
+
+	ginkgov2.BeforeEach(func() {
+		cache = NewAccountCache(0, nil)
+		provider = newTestProvider("test", `{"a":1}`)
+		props = utils.Properties{"key": "secret"}
+	})
+
+	ginkgov2.It("updates the config in place if supported", func() {
+		handler := &configUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(handler)
+		changed := newTestProvider("test", `{"a":2}`)
+
+		Expect(update(last, changed, props)).To(BeTrue())
+		Expect(handler.configs).To(HaveLen(1))
+		Expect(handler.configs[0].Config).To(Equal(changed.Spec().ProviderConfig))
+		Expect(last.key).NotTo(Equal(last.hash))
+		Expect(cache.cache).To(HaveKeyWithValue(last.key, last))
+		Expect(cache.cache).To(HaveLen(1))
+	})
+
+	ginkgov2.It("recreates the account for rotated credentials without credentials update support", func() {
+		handler := &configUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(handler)
+		key := last.key
+
+		Expect(update(last, provider, rotated)).To(BeFalse())
+		Expect(handler.configs).To(BeEmpty())
+		Expect(last.key).To(Equal(key))
+		Expect(last.config).To(Equal(props))
+	})
+
+	ginkgov2.It("takes over rotated credentials in place if supported", func() {
+		handler := &credentialsUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(handler)
+		last.setAuthFailure(fmt.Errorf("invalid credentials"))
+
+		Expect(update(last, provider, rotated)).To(BeTrue())
+		Expect(handler.credentials).To(Equal([]utils.Properties{rotated}))
+		Expect(last.config).To(Equal(rotated))
+		Expect(last.credentialsHash).To(Equal(cache.CredentialsHash(rotated, "test")))
+		Expect(last.AuthFailure()).To(BeNil())
+		Expect(cache.cache).To(HaveKeyWithValue(cache.Hash(rotated, "test", provider.Spec().ProviderConfig, nil), last))
+		Expect(cache.cache).To(HaveLen(1))
+	})
+
+	ginkgov2.It("recreates the account for a changed config without config update support", func() {
+		handler := &credentialsUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(handler)
+
+		Expect(update(last, newTestProvider("test", `{"a":2}`), props)).To(BeFalse())
+		Expect(handler.credentials).To(BeEmpty())
+	})
+
+	ginkgov2.It("requires both update supports if config and credentials have changed", func() {
+		configOnly := &configUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		Expect(update(newAccount(configOnly), newTestProvider("test", `{"a":2}`), rotated)).To(BeFalse())
+		Expect(configOnly.configs).To(BeEmpty())
+
+		cache = NewAccountCache(0, nil)
+		credentialsOnly := &credentialsUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		Expect(update(newAccount(credentialsOnly), newTestProvider("test", `{"a":2}`), rotated)).To(BeFalse())
+		Expect(credentialsOnly.credentials).To(BeEmpty())
+
+		cache = NewAccountCache(0, nil)
+		full := &fullUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(full)
+		Expect(update(last, newTestProvider("test", `{"a":2}`), rotated)).To(BeTrue())
+		Expect(full.configs).To(HaveLen(1))
+		Expect(full.credentials).To(Equal([]utils.Properties{rotated}))
+		Expect(last.credentialsHash).To(Equal(cache.CredentialsHash(rotated, "test")))
+	})
+
+	ginkgov2.It("recreates the account if the credentials update fails", func() {
+		handler := &credentialsUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test"), err: fmt.Errorf("failed")}}
+		last := newAccount(handler)
+		credentialsHash := last.credentialsHash
+
+		Expect(update(last, provider, rotated)).To(BeFalse())
+		Expect(last.credentialsHash).To(Equal(credentialsHash))
+		Expect(last.config).To(Equal(props))
+	})
+
+	ginkgov2.It("does not update a shared account", func() {
+		handler := &fullUpdateTestHandler{updateTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		last := newAccount(handler)
+		last.clients.Add(resources.NewObjectName("default", "other"))
+
+		Expect(update(last, newTestProvider("test", `{"a":2}`), rotated)).To(BeFalse())
+		Expect(handler.configs).To(BeEmpty())
+		Expect(handler.credentials).To(BeEmpty())
+	})
+})
@@ -0,0 +1,140 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+const (
+	// adaptiveDecreaseFactor is the factor applied to the current rate on throttling responses
+	adaptiveDecreaseFactor = 0.5
+	// adaptiveMinFraction is the lower bound of the adapted rate as fraction of the configured rate
+	adaptiveMinFraction = 0.01
+	// adaptiveIncreaseFraction is the fraction of the configured rate added after each quiet period
+	adaptiveIncreaseFraction = 0.1
+	// adaptiveDecreaseInterval suppresses multiple decreases caused by concurrently failing requests
+	adaptiveDecreaseInterval = 1 * time.Second
+	// adaptiveIncreaseInterval is the period without throttling needed for an additive increase
+	adaptiveIncreaseInterval = 10 * time.Second
+)
+
+// ThrottlingFeedback is implemented by rate limiters adapting their rate to
+// the throttling responses of a provider API.
+type ThrottlingFeedback interface {
+	ReportThrottling()
+	ReportSuccess()
+}
+
+// AdaptiveRateLimiter is a token bucket rate limiter using an AIMD (additive increase,
+// multiplicative decrease) strategy: the rate is halved on throttling responses and
+// gradually raised again up to the configured rate while requests succeed.
+type AdaptiveRateLimiter struct {
+	lock       sync.Mutex
+	limiter    *rate.Limiter
+	maxQPS     float64
+	minQPS     float64
+	qps        float64
+	lastChange time.Time
+	now        func() time.Time
+}
+
+var _ flowcontrol.RateLimiter = &AdaptiveRateLimiter{}
+var _ ThrottlingFeedback = &AdaptiveRateLimiter{}
+
+func NewAdaptiveRateLimiter(qps float32, burst int) *AdaptiveRateLimiter {
+	return newAdaptiveRateLimiter(qps, burst, time.Now)
+}
+
+func newAdaptiveRateLimiter(qps float32, burst int, now func() time.Time) *AdaptiveRateLimiter {
+	return &AdaptiveRateLimiter{
+		limiter:    rate.NewLimiter(rate.Limit(qps), burst),
+		maxQPS:     float64(qps),
+		minQPS:     float64(qps) * adaptiveMinFraction,
+		qps:        float64(qps),
+		lastChange: now(),
+		now:        now,
+	}
+}
+
+func (this *AdaptiveRateLimiter) TryAccept() bool {
+	return this.limiter.Allow()
+}
+
+func (this *AdaptiveRateLimiter) Accept() {
+	_ = this.limiter.Wait(context.Background())
+}
+
+func (this *AdaptiveRateLimiter) Wait(ctx context.Context) error {
+	return this.limiter.Wait(ctx)
+}
+
+func (this *AdaptiveRateLimiter) Stop() {
+}
+
+// QPS returns the currently adapted rate.
+func (this *AdaptiveRateLimiter) QPS() float32 {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return float32(this.qps)
+}
+
+// ReportThrottling decreases the rate multiplicatively, but at most once per decrease interval.
+func (this *AdaptiveRateLimiter) ReportThrottling() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	now := this.now()
+	if this.qps < this.maxQPS && now.Sub(this.lastChange) < adaptiveDecreaseInterval {
+		return
+	}
+	qps := this.qps * adaptiveDecreaseFactor
+	if qps < this.minQPS {
+		qps = this.minQPS
+	}
+	this.setQPS(now, qps)
+}
+
+// ReportSuccess increases the rate additively if there was no change during the increase interval.
+func (this *AdaptiveRateLimiter) ReportSuccess() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.qps >= this.maxQPS {
+		return
+	}
+	now := this.now()
+	if now.Sub(this.lastChange) < adaptiveIncreaseInterval {
+		return
+	}
+	qps := this.qps + this.maxQPS*adaptiveIncreaseFraction
+	if qps > this.maxQPS {
+		qps = this.maxQPS
+	}
+	this.setQPS(now, qps)
+}
+
+func (this *AdaptiveRateLimiter) setQPS(now time.Time, qps float64) {
+	this.qps = qps
+	this.lastChange = now
+	this.limiter.SetLimit(rate.Limit(qps))
+}
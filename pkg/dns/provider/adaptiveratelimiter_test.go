@@ -0,0 +1,67 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("AdaptiveRateLimiter", func() {
+	var (
+		now     time.Time
+		limiter *AdaptiveRateLimiter
+	)
+
+	ginkgov2.BeforeEach(func() {
+		now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+		limiter = newAdaptiveRateLimiter(10, 20, func() time.Time { return now })
+	})
+
+	ginkgov2.It("halves rate on throttling, but only once per decrease interval", func() {
+		limiter.ReportThrottling()
+		Expect(limiter.QPS()).To(BeNumerically("==", 5))
+		limiter.ReportThrottling()
+		Expect(limiter.QPS()).To(BeNumerically("==", 5))
+		now = now.Add(adaptiveDecreaseInterval)
+		limiter.ReportThrottling()
+		Expect(limiter.QPS()).To(BeNumerically("==", 2.5))
+	})
+
+	ginkgov2.It("does not fall below minimum rate", func() {
+		for i := 0; i < 20; i++ {
+			now = now.Add(adaptiveDecreaseInterval)
+			limiter.ReportThrottling()
+		}
+		Expect(limiter.QPS()).To(BeNumerically("~", 0.1, 1e-6))
+	})
+
+	ginkgov2.It("recovers additively up to configured rate", func() {
+		limiter.ReportThrottling()
+		limiter.ReportSuccess()
+		Expect(limiter.QPS()).To(BeNumerically("==", 5))
+		now = now.Add(adaptiveIncreaseInterval)
+		limiter.ReportSuccess()
+		Expect(limiter.QPS()).To(BeNumerically("==", 6))
+		for i := 0; i < 10; i++ {
+			now = now.Add(adaptiveIncreaseInterval)
+			limiter.ReportSuccess()
+		}
+		Expect(limiter.QPS()).To(BeNumerically("==", 10))
+	})
+})
@@ -18,44 +18,78 @@ package provider
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 )
 
 type AdvancedConfig struct {
-	BatchSize  int
-	MaxRetries int
+	BatchSize            int
+	MaxRetries           int
+	ZoneStateConcurrency int
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
 type AdvancedOptions struct {
-	BatchSize    int
-	MaxRetries   int
-	BlockedZones []string
+	BatchSize            int
+	MaxRetries           int
+	ZoneStateConcurrency int
+	BlockedZones         []string
+	ResourceTags         []string
 }
 
 var AdvancedOptionsDefaults = AdvancedOptions{
-	BatchSize:    50,
-	MaxRetries:   7,
-	BlockedZones: []string{},
+	BatchSize:            50,
+	MaxRetries:           7,
+	ZoneStateConcurrency: 4,
+	BlockedZones:         []string{},
+	ResourceTags:         []string{},
 }
 
 func (this *AdvancedOptions) AddOptionsToSet(set config.OptionSet) {
 	set.AddIntOption(&this.BatchSize, OPT_ADVANCED_BATCH_SIZE, "", 50, "batch size for change requests (currently only used for aws-route53)")
 	set.AddIntOption(&this.MaxRetries, OPT_ADVANCED_MAX_RETRIES, "", 7, "maximum number of retries to avoid paging stops on throttling (currently only used for aws-route53)")
+	set.AddIntOption(&this.ZoneStateConcurrency, OPT_ADVANCED_ZONE_STATE_CONCURRENCY, "", this.ZoneStateConcurrency, "maximum number of hosted zones of an account whose states are fetched in parallel on startup (limited by the rate limiter burst, 1 disables parallel fetching)")
 	set.AddStringArrayOption(&this.BlockedZones, OPT_ADVANCED_BLOCKED_ZONE, "", []string{}, "Blocks a zone given in the format `zone-id` from a provider as if the zone is not existing.")
+	set.AddStringArrayOption(&this.ResourceTags, OPT_ADVANCED_RESOURCE_TAG, "", []string{}, "Adds a tag given in the format `key=value` to the objects created by a provider (currently only used for azure-dns and azure-private-dns).")
 }
 
 func (c *AdvancedOptions) GetAdvancedConfig() AdvancedConfig {
-	return AdvancedConfig{BatchSize: c.BatchSize, MaxRetries: c.MaxRetries}
+	return AdvancedConfig{BatchSize: c.BatchSize, MaxRetries: c.MaxRetries, ZoneStateConcurrency: c.ZoneStateConcurrency}
 }
 
 func (c *AdvancedOptions) GetBlockedZones() utils.StringSet {
 	return utils.NewStringSet(c.BlockedZones...)
 }
 
+// GetResourceTags returns the configured tags to attach to the objects created by a provider
+func (c *AdvancedOptions) GetResourceTags() map[string]string {
+	return ParseResourceTags(c.ResourceTags...)
+}
+
+// ParseResourceTags parses tags given in the format `key=value`.
+// Each value may contain a comma separated list of tags.
+func ParseResourceTags(values ...string) map[string]string {
+	tags := map[string]string{}
+	for _, v := range values {
+		for _, tag := range strings.Split(v, ",") {
+			kv := strings.SplitN(tag, "=", 2)
+			key := strings.TrimSpace(kv[0])
+			if key == "" {
+				continue
+			}
+			if len(kv) == 2 {
+				tags[key] = strings.TrimSpace(kv[1])
+			} else {
+				tags[key] = ""
+			}
+		}
+	}
+	return tags
+}
+
 // configuration helpers
 
 func (c AdvancedOptions) SetBatchSize(batchSize int) AdvancedOptions {
@@ -68,8 +102,13 @@ func (c AdvancedOptions) SetMaxRetries(maxRetries int) AdvancedOptions {
 	return c
 }
 
+func (c AdvancedOptions) SetZoneStateConcurrency(concurrency int) AdvancedOptions {
+	c.ZoneStateConcurrency = concurrency
+	return c
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c AdvancedConfig) String() string {
-	return fmt.Sprintf("BatchSize: %d, MaxRetries: %d", c.BatchSize, c.MaxRetries)
+	return fmt.Sprintf("BatchSize: %d, MaxRetries: %d, ZoneStateConcurrency: %d", c.BatchSize, c.MaxRetries, c.ZoneStateConcurrency)
 }
@@ -0,0 +1,199 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// anomalyGuard pauses the writes for a zone if the number of changes within a time window
+// exceeds a threshold, which usually indicates a flapping source or a logic bug.
+// Like for the write freeze, the entry status is updated with the planned changes during the pause.
+type anomalyGuard struct {
+	lock       sync.Mutex
+	maxChanges int
+	window     time.Duration
+	pause      time.Duration
+	changes    map[dns.ZoneID][]changeCount
+	paused     map[dns.ZoneID]time.Time
+	listeners  []func(zoneid dns.ZoneID)
+}
+
+type changeCount struct {
+	time  time.Time
+	count int
+}
+
+var theAnomalyGuard = newAnomalyGuard()
+
+func init() {
+	server.RegisterHandler("/anomaly-guard", http.HandlerFunc(serveAnomalyGuard))
+}
+
+func newAnomalyGuard() *anomalyGuard {
+	return &anomalyGuard{
+		changes: map[dns.ZoneID][]changeCount{},
+		paused:  map[dns.ZoneID]time.Time{},
+	}
+}
+
+// configure sets the threshold of the guard. It is disabled if maxChanges is not positive.
+// A positive pause duration releases paused zones automatically.
+func (this *anomalyGuard) configure(maxChanges int, window, pause time.Duration) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.maxChanges = maxChanges
+	this.window = window
+	this.pause = pause
+}
+
+func (this *anomalyGuard) enabled() bool {
+	return this.maxChanges > 0 && this.window > 0
+}
+
+func (this *anomalyGuard) addListener(listener func(zoneid dns.ZoneID)) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.listeners = append(this.listeners, listener)
+}
+
+// Admit checks whether the given number of changes may be applied to the zone and records them.
+// If the changes would exceed the threshold within the window, the writes for the zone are paused
+// until the pause is released and false is returned.
+func (this *anomalyGuard) Admit(logger logger.LogContext, zoneid dns.ZoneID, count int) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if !this.enabled() {
+		return true
+	}
+	if _, ok := this.paused[zoneid]; ok {
+		return false
+	}
+	now := time.Now()
+	sum := count
+	var kept []changeCount
+	for _, c := range this.changes[zoneid] {
+		if now.Sub(c.time) < this.window {
+			kept = append(kept, c)
+			sum += c.count
+		}
+	}
+	if sum > this.maxChanges {
+		this.changes[zoneid] = kept
+		this.paused[zoneid] = now
+		logger.Warnf("anomaly guard: %d changes for zone %s within %v exceed threshold %d: writes for zone paused",
+			sum, zoneid, this.window, this.maxChanges)
+		metrics.ReportAnomalyGuardPause(zoneid, true)
+		if this.pause > 0 {
+			time.AfterFunc(this.pause, func() { this.Release(logger, zoneid) })
+		}
+		return false
+	}
+	this.changes[zoneid] = append(kept, changeCount{time: now, count: count})
+	return true
+}
+
+// IsPaused returns true if the writes for the zone are paused.
+func (this *anomalyGuard) IsPaused(zoneid dns.ZoneID) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, ok := this.paused[zoneid]
+	return ok
+}
+
+// Release releases the write pause of a zone and resets its change history.
+// It returns false if the zone has not been paused.
+func (this *anomalyGuard) Release(logger logger.LogContext, zoneid dns.ZoneID) bool {
+	this.lock.Lock()
+	_, ok := this.paused[zoneid]
+	delete(this.paused, zoneid)
+	delete(this.changes, zoneid)
+	listeners := append([]func(dns.ZoneID){}, this.listeners...)
+	this.lock.Unlock()
+
+	if !ok {
+		return false
+	}
+	logger.Infof("anomaly guard: write pause for zone %s released", zoneid)
+	metrics.ReportAnomalyGuardPause(zoneid, false)
+	for _, l := range listeners {
+		l(zoneid)
+	}
+	return true
+}
+
+// pausedZones returns the paused zones with the start time of the pause.
+func (this *anomalyGuard) pausedZones() map[dns.ZoneID]time.Time {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	result := map[dns.ZoneID]time.Time{}
+	for zoneid, t := range this.paused {
+		result[zoneid] = t
+	}
+	return result
+}
+
+// serveAnomalyGuard lists the paused zones on GET requests and releases the pause of a zone
+// on POST requests with query parameter `zone=<provider type>/<zone id>`.
+func serveAnomalyGuard(w http.ResponseWriter, r *http.Request) {
+	theAnomalyGuard.lock.Lock()
+	enabled := theAnomalyGuard.enabled()
+	theAnomalyGuard.lock.Unlock()
+	if !enabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		parts := strings.SplitN(r.URL.Query().Get("zone"), "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			http.Error(w, "query parameter 'zone' must be <provider type>/<zone id>", http.StatusBadRequest)
+			return
+		}
+		if !theAnomalyGuard.Release(logger.New(), dns.NewZoneID(parts[0], parts[1])) {
+			http.Error(w, "zone is not paused", http.StatusNotFound)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	paused := theAnomalyGuard.pausedZones()
+	zones := make([]dns.ZoneID, 0, len(paused))
+	for zoneid := range paused {
+		zones = append(zones, zoneid)
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].String() < zones[j].String() })
+	w.Header().Set("Content-Type", "text/plain")
+	for _, zoneid := range zones {
+		fmt.Fprintf(w, "%s paused since %s\n", zoneid, paused[zoneid].UTC().Format(time.RFC3339))
+	}
+}
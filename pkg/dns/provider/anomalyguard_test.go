@@ -0,0 +1,73 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Anomaly guard", func() {
+	zone1 := dns.NewZoneID("mock-inmemory", "z1")
+	zone2 := dns.NewZoneID("mock-inmemory", "z2")
+
+	ginkgov2.It("admits all changes if disabled", func() {
+		guard := newAnomalyGuard()
+		Expect(guard.Admit(logger.New(), zone1, 1000)).To(BeTrue())
+	})
+
+	ginkgov2.It("pauses a zone exceeding the threshold until released", func() {
+		guard := newAnomalyGuard()
+		guard.configure(10, 5*time.Minute, 0)
+		var released []dns.ZoneID
+		guard.addListener(func(zoneid dns.ZoneID) { released = append(released, zoneid) })
+
+		Expect(guard.Admit(logger.New(), zone1, 6)).To(BeTrue())
+		Expect(guard.Admit(logger.New(), zone2, 6)).To(BeTrue())
+		Expect(guard.Admit(logger.New(), zone1, 5)).To(BeFalse())
+		Expect(guard.IsPaused(zone1)).To(BeTrue())
+		Expect(guard.Admit(logger.New(), zone1, 1)).To(BeFalse())
+		Expect(guard.Admit(logger.New(), zone2, 4)).To(BeTrue())
+
+		Expect(guard.Release(logger.New(), zone2)).To(BeFalse())
+		Expect(guard.Release(logger.New(), zone1)).To(BeTrue())
+		Expect(released).To(Equal([]dns.ZoneID{zone1}))
+		Expect(guard.Admit(logger.New(), zone1, 10)).To(BeTrue())
+	})
+
+	ginkgov2.It("forgets changes outside of the window", func() {
+		guard := newAnomalyGuard()
+		guard.configure(10, 50*time.Millisecond, 0)
+
+		Expect(guard.Admit(logger.New(), zone1, 8)).To(BeTrue())
+		time.Sleep(60 * time.Millisecond)
+		Expect(guard.Admit(logger.New(), zone1, 8)).To(BeTrue())
+	})
+
+	ginkgov2.It("releases a paused zone after the pause duration", func() {
+		guard := newAnomalyGuard()
+		guard.configure(1, 5*time.Minute, 20*time.Millisecond)
+
+		Expect(guard.Admit(logger.New(), zone1, 2)).To(BeFalse())
+		Eventually(func() bool { return guard.IsPaused(zone1) }).Should(BeFalse())
+	})
+})
@@ -0,0 +1,361 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// AuditRecord describes a change request applied by a provider.
+type AuditRecord struct {
+	Time          time.Time `json:"time"`
+	ProviderType  string    `json:"providerType"`
+	Provider      string    `json:"provider"`
+	Zone          string    `json:"zone"`
+	Action        string    `json:"action"`
+	Type          string    `json:"type"`
+	Name          string    `json:"name"`
+	SetIdentifier string    `json:"setIdentifier,omitempty"`
+	OldTTL        int64     `json:"oldTTL,omitempty"`
+	OldValues     []string  `json:"oldValues,omitempty"`
+	NewTTL        int64     `json:"newTTL,omitempty"`
+	NewValues     []string  `json:"newValues,omitempty"`
+	Owner         string    `json:"owner,omitempty"`
+	// History are the previous values of the record set kept in the zone cache before the change (oldest first).
+	History []RecordSetRevision `json:"history,omitempty"`
+	// Object is the triggering object (kind/namespace/name), if the change belongs to an entry.
+	Object string `json:"object,omitempty"`
+	// PreviousDigest is the SHA-256 digest of the previous signed record chaining the signed records.
+	PreviousDigest string `json:"previousDigest,omitempty"`
+	// Signature is the base64 encoded signature of the record without signature, if signing is configured.
+	Signature string `json:"signature,omitempty"`
+
+	object resources.Object
+}
+
+// AuditSink is a destination of the change audit stream.
+type AuditSink interface {
+	// Name returns the name of the sink used in log messages.
+	Name() string
+	// Record writes the audit record to the sink.
+	Record(record *AuditRecord) error
+}
+
+// AuditLog records all applied change requests to a set of audit sinks.
+type AuditLog struct {
+	lock   sync.Mutex
+	logger logger.LogContext
+	signer *AuditSigner
+	sinks  []AuditSink
+}
+
+// NewAuditLog creates an audit log for the given sinks or returns nil if no sink is given.
+// If a signer is given, all records are signed.
+func NewAuditLog(logger logger.LogContext, signer *AuditSigner, sinks ...AuditSink) *AuditLog {
+	if len(sinks) == 0 {
+		return nil
+	}
+	return &AuditLog{logger: logger, signer: signer, sinks: sinks}
+}
+
+// Record signs the audit record, if a signer is configured, and writes it to all sinks.
+func (this *AuditLog) Record(record *AuditRecord) {
+	if this.signer != nil {
+		// signing and writing must be serialized to keep the chain of signed records in order
+		this.lock.Lock()
+		defer this.lock.Unlock()
+		if err := this.signer.Sign(record); err != nil {
+			this.logger.Warnf("cannot sign audit record for %s %s %s: %s", record.Action, record.Type, record.Name, err)
+		}
+	}
+	for _, s := range this.sinks {
+		if err := s.Record(record); err != nil {
+			this.logger.Warnf("cannot write audit record for %s %s %s to %s: %s", record.Action, record.Type, record.Name, s.Name(), err)
+		}
+	}
+}
+
+func newAuditRecord(zone DNSHostedZone, p DNSProvider, r *ChangeRequest, done DoneHandler) *AuditRecord {
+	record := &AuditRecord{
+		ProviderType: zone.Id().ProviderType,
+		Provider:     p.ObjectName().String(),
+		Zone:         zone.Id().ID,
+		Action:       r.Action,
+		Type:         r.Type,
+	}
+	for _, set := range []*dns.DNSSet{r.Deletion, r.Addition} {
+		if set == nil {
+			continue
+		}
+		record.Name = set.Name
+		record.SetIdentifier = set.SetIdentifier
+		if owner := set.GetOwner(); owner != "" {
+			record.Owner = owner
+		}
+	}
+	if r.Deletion != nil {
+		record.OldTTL, record.OldValues = auditValues(r.Deletion.Sets[r.Type])
+	}
+	if r.Addition != nil {
+		record.NewTTL, record.NewValues = auditValues(r.Addition.Sets[r.Type])
+	}
+	if obj := auditObject(done); obj != nil {
+		record.object = obj
+		record.Object = fmt.Sprintf("%s/%s", obj.GroupKind().Kind, obj.ObjectName())
+	}
+	return record
+}
+
+func auditValues(rs *dns.RecordSet) (int64, []string) {
+	if rs == nil {
+		return 0, nil
+	}
+	values := make([]string, len(rs.Records))
+	for i, r := range rs.Records {
+		values[i] = r.Value
+	}
+	return rs.TTL, values
+}
+
+// auditObject determines the triggering object of a change request from its done handler.
+func auditObject(done DoneHandler) resources.Object {
+	if h := statusUpdateOf(done); h != nil && h.Entry != nil && h.Entry.object != nil {
+		return h.Entry.object
+	}
+	return nil
+}
+
+// statusUpdateOf determines the status update of the entry of a change request from its done handler.
+func statusUpdateOf(done DoneHandler) *StatusUpdate {
+	switch h := done.(type) {
+	case *changeModelDoneHandler:
+		return statusUpdateOf(h.inner)
+	case *StatusUpdate:
+		return h
+	}
+	return nil
+}
+
+// auditDoneHandler records the change request in the audit log if it has been applied successfully.
+type auditDoneHandler struct {
+	inner  DoneHandler
+	audit  *AuditLog
+	record *AuditRecord
+}
+
+var _ DoneHandler = &auditDoneHandler{}
+
+func (this *auditDoneHandler) SetInvalid(err error) {
+	if this.inner != nil {
+		this.inner.SetInvalid(err)
+	}
+}
+
+func (this *auditDoneHandler) Failed(err error) {
+	if this.inner != nil {
+		this.inner.Failed(err)
+	}
+}
+
+func (this *auditDoneHandler) Throttled(retryAfter time.Duration) {
+	if this.inner != nil {
+		this.inner.Throttled(retryAfter)
+	}
+}
+
+func (this *auditDoneHandler) Blocked(reason string, planned []string) {
+	if this.inner != nil {
+		this.inner.Blocked(reason, planned)
+	}
+}
+
+func (this *auditDoneHandler) Succeeded() {
+	this.record.Time = time.Now().UTC()
+	this.audit.Record(this.record)
+	if this.inner != nil {
+		this.inner.Succeeded()
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// sinks
+
+// NewAuditSignerForConfig creates the audit record signer configured by the controller options or returns nil if signing is disabled.
+func NewAuditSignerForConfig(config *Config) (*AuditSigner, error) {
+	if config.AuditLogSigningKey == "" {
+		return nil, nil
+	}
+	return NewAuditSigner(config.AuditLogSigningKey)
+}
+
+// NewAuditSinks creates the audit sinks configured by the controller options.
+func NewAuditSinks(ctx context.Context, logger logger.LogContext, config *Config) ([]AuditSink, error) {
+	var sinks []AuditSink
+	if config.AuditLogFile != "" {
+		s, err := NewFileAuditSink(config.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	if config.AuditLogEvents {
+		sinks = append(sinks, NewEventAuditSink())
+	}
+	if config.AuditLogWebhook != "" {
+		sinks = append(sinks, NewWebhookAuditSink(ctx, logger, config.AuditLogWebhook))
+	}
+	return sinks, nil
+}
+
+type fileAuditSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileAuditSink creates an audit sink appending the records as JSON lines to a file.
+func NewFileAuditSink(path string) (AuditSink, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open audit log file: %w", err)
+	}
+	return &fileAuditSink{file: file}, nil
+}
+
+func (this *fileAuditSink) Name() string {
+	return "file " + this.file.Name()
+}
+
+func (this *fileAuditSink) Record(record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	_, err = this.file.Write(append(data, '\n'))
+	return err
+}
+
+type eventAuditSink struct{}
+
+// NewEventAuditSink creates an audit sink emitting Kubernetes events for the triggering objects.
+// Changes without triggering object (e.g. cleanup of orphaned records) are not recorded.
+func NewEventAuditSink() AuditSink {
+	return eventAuditSink{}
+}
+
+func (eventAuditSink) Name() string {
+	return "events"
+}
+
+func (eventAuditSink) Record(record *AuditRecord) error {
+	if record.object == nil {
+		return nil
+	}
+	msg := fmt.Sprintf("%s %s record set %s in zone %s", record.Action, record.Type, record.Name, record.Zone)
+	if record.OldValues != nil {
+		msg = fmt.Sprintf("%s, old: [%s]", msg, strings.Join(record.OldValues, ", "))
+	}
+	if record.NewValues != nil {
+		msg = fmt.Sprintf("%s, new: [%s]", msg, strings.Join(record.NewValues, ", "))
+	}
+	record.object.Event(corev1.EventTypeNormal, "audit", msg)
+	return nil
+}
+
+// webhookAuditQueueSize is the maximum number of audit records waiting for delivery to a webhook
+const webhookAuditQueueSize = 1000
+
+type webhookAuditSink struct {
+	url    string
+	client *http.Client
+	queue  chan *AuditRecord
+	logger logger.LogContext
+}
+
+// NewWebhookAuditSink creates an audit sink posting the records as JSON to a webhook.
+// The records are delivered asynchronously, if the delivery queue is full, records are dropped.
+func NewWebhookAuditSink(ctx context.Context, logger logger.LogContext, url string) AuditSink {
+	s := &webhookAuditSink{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan *AuditRecord, webhookAuditQueueSize),
+		logger: logger,
+	}
+	go s.run(ctx)
+	return s
+}
+
+func (this *webhookAuditSink) Name() string {
+	return "webhook " + this.url
+}
+
+func (this *webhookAuditSink) Record(record *AuditRecord) error {
+	select {
+	case this.queue <- record:
+		return nil
+	default:
+		return fmt.Errorf("delivery queue full, record dropped")
+	}
+}
+
+func (this *webhookAuditSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case record := <-this.queue:
+			if err := this.post(ctx, record); err != nil {
+				this.logger.Warnf("cannot post audit record for %s %s %s to %s: %s", record.Action, record.Type, record.Name, this.url, err)
+			}
+		}
+	}
+}
+
+func (this *webhookAuditSink) post(ctx context.Context, record *AuditRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, this.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := this.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
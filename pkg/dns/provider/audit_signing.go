@@ -0,0 +1,160 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// AuditSigner signs audit records and chains them by the digest of the previous record
+// to make the change history tamper-evident.
+type AuditSigner struct {
+	key        crypto.Signer
+	lastDigest string
+}
+
+// NewAuditSigner creates an audit signer for a PEM encoded private key file (PKCS#8, SEC 1 or PKCS#1)
+// with an ECDSA, Ed25519 or RSA key.
+func NewAuditSigner(path string) (*AuditSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read audit log signing key: %w", err)
+	}
+	key, err := parseSigningKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit log signing key %s: %w", path, err)
+	}
+	return &AuditSigner{key: key}, nil
+}
+
+func parseSigningKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY", "ENCRYPTED PRIVATE KEY":
+		return nil, fmt.Errorf("encrypted keys are not supported, use an unencrypted PKCS#8 key")
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	case *rsa.PrivateKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+}
+
+// Sign sets the digest of the previously signed record and the signature of the record.
+// The signature is calculated over the JSON encoding of the record without signature.
+func (this *AuditSigner) Sign(record *AuditRecord) error {
+	record.PreviousDigest = this.lastDigest
+	payload, err := unsignedPayload(record)
+	if err != nil {
+		return err
+	}
+	signature, err := signPayload(this.key, payload)
+	if err != nil {
+		return err
+	}
+	record.Signature = base64.StdEncoding.EncodeToString(signature)
+	digest := sha256.Sum256(payload)
+	this.lastDigest = hex.EncodeToString(digest[:])
+	return nil
+}
+
+func signPayload(key crypto.Signer, payload []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, payload, crypto.Hash(0))
+	}
+	digest := sha256.Sum256(payload)
+	return key.Sign(rand.Reader, digest[:], crypto.SHA256)
+}
+
+// VerifyAuditRecord verifies the signature of an audit record with the public key of the signing key.
+func VerifyAuditRecord(key crypto.PublicKey, record *AuditRecord) error {
+	signature, err := base64.StdEncoding.DecodeString(record.Signature)
+	if err != nil || len(signature) == 0 {
+		return fmt.Errorf("invalid signature")
+	}
+	payload, err := unsignedPayload(record)
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256(payload)
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, digest[:], signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, payload, signature) {
+			return fmt.Errorf("signature verification failed")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature); err != nil {
+			return fmt.Errorf("signature verification failed: %w", err)
+		}
+	default:
+		return fmt.Errorf("unsupported key type %T", key)
+	}
+	return nil
+}
+
+// AuditRecordDigest returns the digest of a signed record referenced by the next signed record.
+func AuditRecordDigest(record *AuditRecord) (string, error) {
+	payload, err := unsignedPayload(record)
+	if err != nil {
+		return "", err
+	}
+	digest := sha256.Sum256(payload)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// unsignedPayload returns the JSON encoding of the record without signature.
+func unsignedPayload(record *AuditRecord) ([]byte, error) {
+	unsigned := *record
+	unsigned.Signature = ""
+	return json.Marshal(&unsigned)
+}
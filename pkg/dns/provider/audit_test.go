@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Audit log", func() {
+	ginkgov2.It("appends records as JSON lines to a file", func() {
+		path := filepath.Join(ginkgov2.GinkgoT().TempDir(), "audit.log")
+		sink, err := NewFileAuditSink(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		set := dns.NewDNSSet("www.example.com")
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4", "1.2.3.5")
+		ttl, values := auditValues(set.Sets[dns.RS_A])
+		audit := NewAuditLog(nil, nil, sink)
+		audit.Record(&AuditRecord{Zone: "z1", Action: R_CREATE, Type: dns.RS_A, Name: set.Name, NewTTL: ttl, NewValues: values})
+		audit.Record(&AuditRecord{Zone: "z1", Action: R_DELETE, Type: dns.RS_A, Name: set.Name, OldTTL: ttl, OldValues: values})
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		Expect(lines).To(HaveLen(2))
+		record := &AuditRecord{}
+		Expect(json.Unmarshal([]byte(lines[1]), record)).To(Succeed())
+		Expect(record.Action).To(Equal(R_DELETE))
+		Expect(record.OldTTL).To(Equal(int64(300)))
+		Expect(record.OldValues).To(Equal([]string{"1.2.3.4", "1.2.3.5"}))
+		Expect(record.NewValues).To(BeNil())
+	})
+
+	ginkgov2.It("is disabled without sinks", func() {
+		Expect(NewAuditLog(nil, nil)).To(BeNil())
+	})
+})
+
+var _ = ginkgov2.Describe("Audit signing", func() {
+	ginkgov2.It("signs and chains records", func() {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		Expect(err).NotTo(HaveOccurred())
+		path := filepath.Join(ginkgov2.GinkgoT().TempDir(), "audit.key")
+		Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)).To(Succeed())
+
+		signer, err := NewAuditSigner(path)
+		Expect(err).NotTo(HaveOccurred())
+		first := &AuditRecord{Zone: "z1", Action: R_CREATE, Type: dns.RS_A, Name: "www.example.com", NewValues: []string{"1.2.3.4"}}
+		second := &AuditRecord{Zone: "z1", Action: R_DELETE, Type: dns.RS_A, Name: "www.example.com", OldValues: []string{"1.2.3.4"}}
+		Expect(signer.Sign(first)).To(Succeed())
+		Expect(signer.Sign(second)).To(Succeed())
+
+		Expect(first.PreviousDigest).To(BeEmpty())
+		Expect(VerifyAuditRecord(&key.PublicKey, first)).To(Succeed())
+		Expect(VerifyAuditRecord(&key.PublicKey, second)).To(Succeed())
+		digest, err := AuditRecordDigest(first)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second.PreviousDigest).To(Equal(digest))
+
+		second.OldValues = []string{"1.2.3.5"}
+		Expect(VerifyAuditRecord(&key.PublicKey, second)).NotTo(Succeed())
+	})
+})
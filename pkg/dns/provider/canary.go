@@ -0,0 +1,190 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// CANARY_TTL is the TTL of canary records, it limits the delay of the resolution of a renewed timestamp
+const CANARY_TTL = 60
+
+// canaryLookupTXT resolves the canary records (can be overwritten for test purposes)
+var canaryLookupTXT dnsutils.LookupTXTFunc = net.LookupTXT
+
+// canarySpec is the target spec of the canary record of a zone.
+type canarySpec struct {
+	ownerId string
+	targets []Target
+}
+
+var _ TargetSpec = &canarySpec{}
+
+func (this *canarySpec) Kind() string                      { return api.DNSEntryKind }
+func (this *canarySpec) OwnerId() string                   { return this.ownerId }
+func (this *canarySpec) OwnerGroup() string                { return "" }
+func (this *canarySpec) Targets() []Target                 { return this.targets }
+func (this *canarySpec) RoutingPolicy() *dns.RoutingPolicy { return nil }
+func (this *canarySpec) ProviderHints() *dns.ProviderHints { return nil }
+func (this *canarySpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bool {
+	return !set.IsForeign(ownership)
+}
+
+// CanaryDNSName returns the DNS name of the canary record of a zone.
+func CanaryDNSName(label, domain string) string {
+	return label + "." + domain
+}
+
+func canaryValue(ts time.Time) string {
+	return dns.ATTR_TIMESTAMP + "=" + strconv.FormatInt(ts.Unix(), 10)
+}
+
+// parseCanaryTimestamp returns the timestamp of canary record values.
+func parseCanaryTimestamp(values []string) (time.Time, bool) {
+	for _, v := range values {
+		v = strings.Trim(v, "\"")
+		if !strings.HasPrefix(v, dns.ATTR_TIMESTAMP+"=") {
+			continue
+		}
+		ts, err := strconv.ParseInt(v[len(dns.ATTR_TIMESTAMP)+1:], 10, 64)
+		if err == nil {
+			return time.Unix(ts, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ApplyCanary maintains the canary record of the zone. The timestamp is renewed if it is older
+// than the given period, so that the canary does not cause changes with every reconciliation.
+func (this *ChangeModel) ApplyCanary(label string, period time.Duration, now time.Time) {
+	if this.context.zone.IsPrivate() {
+		return
+	}
+	name := CanaryDNSName(label, this.Domain())
+	p := this.context.providers.LookupFor(name)
+	if p == nil {
+		return
+	}
+	ts := now
+	if oldset := this.getProviderView(p).dnssets[name]; oldset != nil && this.Owns(oldset) {
+		if rs := oldset.Sets[dns.RS_TXT]; rs != nil {
+			var values []string
+			for _, r := range rs.Records {
+				values = append(values, r.Value)
+			}
+			if old, ok := parseCanaryTimestamp(values); ok && now.Sub(old) < period {
+				ts = old
+			}
+		}
+	}
+	spec := &canarySpec{
+		ownerId: this.config.Ident,
+		targets: []Target{dnsutils.NewText(canaryValue(ts), CANARY_TTL)},
+	}
+	result := this.Apply(name, "", time.Time{}, nil, spec)
+	if result.Error != nil {
+		this.Warnf("cannot maintain canary record %s: %s", name, result.Error)
+	} else if result.Modified {
+		this.Infof("renewing canary record %s", name)
+	}
+}
+
+// canaryObservations keeps the last timestamp of a canary record observed by DNS resolution per zone.
+type canaryObservations struct {
+	lock     sync.Mutex
+	observed map[dns.ZoneID]time.Time
+}
+
+func newCanaryObservations() *canaryObservations {
+	return &canaryObservations{observed: map[dns.ZoneID]time.Time{}}
+}
+
+// observe records the resolved timestamp and returns the last observed one.
+// The first check of a zone without resolvable canary starts the age with the current time.
+func (this *canaryObservations) observe(zoneid dns.ZoneID, ts time.Time, ok bool, now time.Time) time.Time {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	last, found := this.observed[zoneid]
+	if ok && (!found || ts.After(last)) {
+		last = ts
+	} else if !found {
+		last = now
+	}
+	this.observed[zoneid] = last
+	return last
+}
+
+func (this *canaryObservations) retain(zoneids map[dns.ZoneID]DNSHostedZone) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for zoneid := range this.observed {
+		if _, ok := zoneids[zoneid]; !ok {
+			delete(this.observed, zoneid)
+			metrics.DeleteZoneCanary(zoneid)
+		}
+	}
+}
+
+func (this *state) getCanaryZones() map[dns.ZoneID]DNSHostedZone {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	result := map[dns.ZoneID]DNSHostedZone{}
+	for zoneid, zone := range this.zones {
+		if !zone.IsPrivate() && len(this.zoneproviders[zoneid]) > 0 {
+			result[zoneid] = zone.getZone()
+		}
+	}
+	return result
+}
+
+// UpdateCanaries resolves the canary records of all public zones and reports the age of their timestamps.
+// Zones with a timestamp older than the canary period are triggered to renew it.
+func (this *state) UpdateCanaries(ctx context.Context, logger logger.LogContext) {
+	zones := this.getCanaryZones()
+	this.canaries.retain(zones)
+	for zoneid, zone := range zones {
+		if ctx.Err() != nil {
+			return
+		}
+		name := CanaryDNSName(this.config.CanaryLabel, zone.Domain())
+		records, err := canaryLookupTXT(name)
+		ts, ok := parseCanaryTimestamp(records)
+		if err != nil {
+			logger.Infof("cannot resolve canary record %s: %s", name, err)
+		}
+		now := time.Now()
+		age := now.Sub(this.canaries.observe(zoneid, ts, ok, now))
+		metrics.ReportZoneCanaryAge(zoneid, age)
+		if age >= this.config.CanaryPeriod {
+			logger.Infof("canary record %s outdated by %s", name, age.Round(time.Second))
+			this.triggerHostedZoneIfKnown(zoneid)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Canary records", func() {
+	ginkgov2.It("parses the timestamp of canary records", func() {
+		ts := time.Unix(1700000000, 0)
+		Expect(dns.TextValue(canaryValue(ts))).To(Equal(`"ts=1700000000"`))
+
+		parsed, ok := parseCanaryTimestamp([]string{`"owner=test"`, dns.TextValue(canaryValue(ts))})
+		Expect(ok).To(BeTrue())
+		Expect(parsed).To(Equal(ts))
+
+		_, ok = parseCanaryTimestamp([]string{"ts=invalid"})
+		Expect(ok).To(BeFalse())
+		_, ok = parseCanaryTimestamp(nil)
+		Expect(ok).To(BeFalse())
+	})
+
+	ginkgov2.It("keeps the last observed timestamp", func() {
+		zoneid := dns.NewZoneID("test", "Z1")
+		now := time.Now()
+		obs := newCanaryObservations()
+
+		Expect(obs.observe(zoneid, time.Time{}, false, now)).To(Equal(now))
+		Expect(obs.observe(zoneid, time.Time{}, false, now.Add(time.Minute))).To(Equal(now))
+
+		ts := now.Add(2 * time.Minute)
+		Expect(obs.observe(zoneid, ts, true, ts)).To(Equal(ts))
+		Expect(obs.observe(zoneid, now, true, ts.Add(time.Minute))).To(Equal(ts))
+		Expect(obs.observe(zoneid, time.Time{}, false, ts.Add(time.Hour))).To(Equal(ts))
+
+		obs.retain(map[dns.ZoneID]DNSHostedZone{})
+		Expect(obs.observed).To(BeEmpty())
+	})
+})
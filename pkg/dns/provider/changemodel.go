@@ -19,6 +19,7 @@ package provider
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/utils"
@@ -71,13 +73,13 @@ func newChangeGroup(name string, provider DNSProvider, model *ChangeModel) *Chan
 func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) bool {
 	mod := false
 	for _, s := range this.dnssets {
-		_, ok := model.applied[s.Name]
+		_, ok := model.applied[s.Key()]
 		if !ok {
 			if s.IsOwnedBy(model.ownership) {
 				if model.ExistsInEquivalentZone(s.Name) {
 					continue
 				}
-				if e := model.IsStale(ZonedDNSName{ZoneID: model.ZoneId(), DNSName: s.Name}); e != nil {
+				if e := model.IsStale(ZonedDNSName{ZoneID: model.ZoneId(), DNSName: s.Name, SetIdentifier: s.SetIdentifier}); e != nil {
 					if e.IsDeleting() {
 						model.failedDNSNames.Add(s.Name) // preventing deletion of stale entry
 					}
@@ -99,7 +101,7 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 					model.Infof("found unapplied managed set '%s'", s.Name)
 					var done DoneHandler
 					for _, e := range model.context.entries {
-						if e.dnsname == s.Name {
+						if e.dnsname == s.Name && e.SetIdentifier() == s.SetIdentifier {
 							done = NewStatusUpdate(logger, e, model.context.fhandler)
 							break
 						}
@@ -120,9 +122,29 @@ func (this *ChangeGroup) update(logger logger.LogContext, model *ChangeModel) bo
 	model.Infof("reconcile entries for %s (with %d requests)", this.name, len(this.requests))
 
 	reqs := this.requests
+	if len(reqs) > 0 && IsWriteFrozen() {
+		this.reportPlannedRequests(logger, MSG_WRITE_FREEZE)
+		return true
+	}
+	if len(reqs) > 0 && this.provider.IsReadOnly() {
+		this.reportPlannedRequests(logger, MSG_READONLY)
+		return true
+	}
+	if len(reqs) > 0 && (model.config.Dryrun || this.provider.IsDryRun()) {
+		this.reportPlannedRequests(logger, MSG_DRYRUN)
+		return true
+	}
+	if len(reqs) > 0 && !theAnomalyGuard.Admit(logger, this.model.context.zone.Id(), len(reqs)) {
+		this.reportPlannedRequests(logger, MSG_ANOMALY_GUARD)
+		return true
+	}
+	if len(reqs) > 0 && model.context.audit != nil {
+		reqs = this.auditedRequests(model.context.audit)
+	}
 	if len(reqs) > 0 {
+		reqs = this.countedRequests(reqs)
 		this.model.context.dnsTicker.TickWhile(logger, func() {
-			err := this.provider.ExecuteRequests(logger, model.context.zone.getZone(), this.model.zonestate, reqs)
+			err := this.provider.ExecuteRequests(model.context.ctx, logger, model.context.zone.getZone(), this.model.zonestate, reqs)
 			if err != nil {
 				model.Errorf("entry reconciliation failed for %s: %s", this.name, err)
 				ok = false
@@ -132,6 +154,81 @@ func (this *ChangeGroup) update(logger logger.LogContext, model *ChangeModel) bo
 	return ok
 }
 
+// auditedRequests returns copies of the requests recording them in the audit log if they are applied successfully.
+func (this *ChangeGroup) auditedRequests(audit *AuditLog) []*ChangeRequest {
+	zone := this.model.context.zone.getZone()
+	reqs := make([]*ChangeRequest, len(this.requests))
+	for i, r := range this.requests {
+		record := newAuditRecord(zone, this.provider, r, r.Done)
+		record.History = this.model.context.history.get(zone.Id(), record.Name, record.SetIdentifier, r.Type)
+		done := &auditDoneHandler{inner: r.Done, audit: audit, record: record}
+		reqs[i] = NewChangeRequest(r.Action, r.Type, r.Deletion, r.Addition, done)
+	}
+	return reqs
+}
+
+// countedRequests returns copies of the requests counting the applied record set changes per record type.
+func (this *ChangeGroup) countedRequests(reqs []*ChangeRequest) []*ChangeRequest {
+	zoneid := this.model.context.zone.Id()
+	counted := make([]*ChangeRequest, len(reqs))
+	for i, r := range reqs {
+		done := &countingDoneHandler{inner: r.Done, zoneid: zoneid, action: r.Action, rtype: changeMetricType(r.Type)}
+		counted[i] = NewChangeRequest(r.Action, r.Type, r.Deletion, r.Addition, done)
+	}
+	return counted
+}
+
+// changeMetricType returns the record type used as metric label.
+// Meta data records are stored as TXT records at the provider.
+func changeMetricType(rtype string) string {
+	if rtype == dns.RS_META {
+		return dns.RS_TXT
+	}
+	return rtype
+}
+
+// reportPlannedRequests reports the requests as planned changes without applying them.
+func (this *ChangeGroup) reportPlannedRequests(logger logger.LogContext, reason string) {
+	planned := map[DoneHandler][]string{}
+	var handlers []DoneHandler
+	for _, r := range this.requests {
+		var set *dns.DNSSet
+		if r.Addition != nil {
+			set = r.Addition
+		} else {
+			set = r.Deletion
+		}
+		change := plannedChange(r, set)
+		logger.Infof("%s: skipping planned change %s for %s", reason, change, this.name)
+		metrics.AddPlannedChanges(this.model.context.zone.Id(), r.Action, 1)
+		if r.Done == nil {
+			continue
+		}
+		if _, ok := planned[r.Done]; !ok {
+			handlers = append(handlers, r.Done)
+		}
+		planned[r.Done] = append(planned[r.Done], change)
+	}
+	for _, done := range handlers {
+		done.Blocked(reason, planned[done])
+	}
+}
+
+// plannedChange describes a planned change request, e.g. "update A www.example.com: [1.2.3.4]".
+func plannedChange(r *ChangeRequest, set *dns.DNSSet) string {
+	name := set.Name
+	if set.SetIdentifier != "" {
+		name = fmt.Sprintf("%s#%s", name, set.SetIdentifier)
+	}
+	change := fmt.Sprintf("%s %s %s", r.Action, r.Type, name)
+	if r.Addition != nil {
+		if rs := r.Addition.Sets[r.Type]; rs != nil {
+			change = fmt.Sprintf("%s: %s", change, rs.RecordString())
+		}
+	}
+	return change
+}
+
 func (this *ChangeGroup) addCreateRequest(dnsset *dns.DNSSet, rtype string, done DoneHandler) {
 	this.addChangeRequest(R_CREATE, nil, dnsset, rtype, done)
 }
@@ -144,6 +241,9 @@ func (this *ChangeGroup) addDeleteRequest(dnsset *dns.DNSSet, rtype string, done
 func (this *ChangeGroup) addChangeRequest(action string, old, new *dns.DNSSet, rtype string, done DoneHandler) {
 	r := NewChangeRequest(action, rtype, old, new, done)
 	this.requests = append(this.requests, r)
+	if s := statusUpdateOf(done); s != nil && rtype != dns.RS_META {
+		s.changes = append(s.changes, formatRecordSetChange(rtype, old, new))
+	}
 }
 
 type TargetSpec = dnsutils.TargetSpec
@@ -162,12 +262,16 @@ type ChangeModel struct {
 	providergroups map[string]*ChangeGroup
 	zonestate      DNSZoneState
 	failedDNSNames utils.StringSet
+	// stale is set if the zone state is an expired cached state
+	stale bool
 }
 
 type ChangeResult struct {
 	Modified bool
 	Retry    bool
 	Error    error
+	// Conflict describes a conflict with the record set of a foreign owner
+	Conflict *OwnerConflict
 }
 
 func NewChangeModel(logger logger.LogContext, ownership dns.Ownership, req *zoneReconciliation, config Config) *ChangeModel {
@@ -232,11 +336,15 @@ func (this *ChangeModel) Setup() error {
 		return fmt.Errorf("no provider found for zone %q", this.ZoneId())
 	}
 	this.context.dnsTicker.TickWhile(this, func() {
-		this.zonestate, err = provider.GetZoneState(this.context.zone.getZone())
+		this.zonestate, err = provider.GetZoneState(this.context.ctx, this.context.zone.getZone())
 	})
 	if err != nil {
 		return err
 	}
+	if IsStaleZoneState(this.zonestate) {
+		this.Warnf("using stale zone state for zone %s", this.ZoneId())
+		this.stale = true
+	}
 	sets := this.zonestate.GetDNSSets()
 	this.context.zone.SetOwners(sets.GetOwners())
 	this.dangling = newChangeGroup("dangling entries", provider, this)
@@ -295,20 +403,68 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 		return ChangeResult{Error: err}
 	}
 
+	if rp := spec.RoutingPolicy(); rp != nil && !delete {
+		if err := p.ValidateRoutingPolicy(rp); err != nil {
+			if done != nil {
+				if apply {
+					done.SetInvalid(err)
+				}
+			} else {
+				this.Warnf("no done handler and %s", err)
+			}
+			return ChangeResult{Error: err}
+		}
+	}
+
+	if !delete {
+		for _, t := range spec.Targets() {
+			if rtype := t.GetRecordType(); rtype != dns.RS_RESOURCE && !p.SupportsRecordType(rtype) {
+				err := fmt.Errorf("record type %s not supported by provider type %s", rtype, p.TypeCode())
+				if done != nil {
+					if apply {
+						done.SetInvalid(err)
+					}
+				} else {
+					this.Warnf("no done handler and %s", err)
+				}
+				return ChangeResult{Error: err}
+			}
+		}
+	}
+
 	view := this.getProviderView(p)
-	oldset := view.dnssets[name]
+	setIdentifier := spec.RoutingPolicy().GetSetIdentifier()
+	key := dns.DNSSetKey(name, setIdentifier)
+	oldset := view.dnssets[key]
 	newset := dns.NewDNSSet(name)
+	newset.SetIdentifier = setIdentifier
 	newset.UpdateGroup = updateGroup
 	newset.SetKind(spec.Kind())
+	var conflict *OwnerConflict
+	if oldset != nil && this.IsForeign(oldset) {
+		conflict = newOwnerConflict(this.config.OwnerConflictPolicy, oldset, createdAt, spec, delete)
+	} else if oldset != nil && this.isOtherNamespaceOwner(oldset, spec) {
+		// record sets of other namespaces are never taken over
+		conflict = &OwnerConflict{Owner: oldset.GetOwner(), OwnerGroup: oldset.GetOwnerGroup()}
+	}
+	base := oldset
+	if conflict.IsResolved() {
+		// the record set is taken over like a record set of an own owner id
+		base = nil
+	}
 	if !delete {
-		this.ApplySpec(newset, oldset, p, spec)
+		this.ApplySpec(newset, base, p, spec)
+		if this.config.OwnerConflictPolicy != OWNER_CONFLICT_RESOLUTION_NONE && spec.OwnerGroup() != "" && this.Owns(newset) {
+			newset.SetMetaAttr(dns.ATTR_CREATED, strconv.FormatInt(createdAt.Unix(), 10))
+		}
 	}
 	mod := false
 	if oldset != nil {
 		this.Debugf("found old for %s %q", oldset.GetKind(), oldset.Name)
-		if this.IsForeign(oldset) {
+		if conflict != nil && !conflict.Resolved {
 			err := &perrs.AlreadyBusyForOwner{DNSName: name, EntryCreatedAt: createdAt, Owner: oldset.GetOwner()}
 			retry := p.ReportZoneStateConflict(this.context.zone.getZone(), err)
+			conflict.Retry = retry
 			if done != nil {
 				if apply && !retry {
 					done.SetInvalid(err)
@@ -316,9 +472,13 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 			} else {
 				this.Warnf("no done handler and %s", err)
 			}
-			return ChangeResult{Error: err, Retry: retry}
+			return ChangeResult{Error: err, Retry: retry, Conflict: conflict}
 		} else {
-			if !spec.Responsible(oldset, this.ownership) {
+			if conflict != nil {
+				if apply {
+					this.Infof("taking over %q from owner %q of owner group %q created before the entry", name, conflict.Owner, conflict.OwnerGroup)
+				}
+			} else if !spec.Responsible(oldset, this.ownership) {
 				return ChangeResult{}
 			}
 			if oldset.GetOwner() == "" && !this.Owns(oldset) {
@@ -379,15 +539,20 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 		}
 	}
 	if apply {
-		this.applied[name] = newset
+		this.applied[key] = newset
 		if !mod && done != nil {
 			done.Succeeded()
 		}
 	}
-	return ChangeResult{Modified: mod}
+	return ChangeResult{Modified: mod, Conflict: conflict}
 }
 
 func (this *ChangeModel) Cleanup(logger logger.LogContext) bool {
+	if this.stale {
+		// deletions of obsolete records are postponed until the zone state is revalidated
+		logger.Infof("skipping cleanup of zone %s with stale zone state", this.ZoneId())
+		return false
+	}
 	mod := false
 	for _, view := range this.providergroups {
 		mod = view.cleanup(logger, this) || mod
@@ -451,9 +616,57 @@ func (this *changeModelDoneHandler) Succeeded() {
 	}
 }
 
-func (this *changeModelDoneHandler) Throttled() {
+func (this *changeModelDoneHandler) Blocked(reason string, planned []string) {
+	if this.inner != nil {
+		this.inner.Blocked(reason, planned)
+	}
+}
+
+func (this *changeModelDoneHandler) Throttled(retryAfter time.Duration) {
 	if this.inner != nil {
-		this.inner.Throttled()
+		this.inner.Throttled(retryAfter)
+	}
+}
+
+/////////////////////////////////////////////////////////////////////////////////
+// countingDoneHandler
+
+// countingDoneHandler counts the change request in the record set change metrics if it has been applied successfully.
+type countingDoneHandler struct {
+	inner  DoneHandler
+	zoneid dns.ZoneID
+	action string
+	rtype  string
+}
+
+func (this *countingDoneHandler) SetInvalid(err error) {
+	if this.inner != nil {
+		this.inner.SetInvalid(err)
+	}
+}
+
+func (this *countingDoneHandler) Failed(err error) {
+	if this.inner != nil {
+		this.inner.Failed(err)
+	}
+}
+
+func (this *countingDoneHandler) Succeeded() {
+	metrics.AddRecordSetChange(this.zoneid, this.action, this.rtype)
+	if this.inner != nil {
+		this.inner.Succeeded()
+	}
+}
+
+func (this *countingDoneHandler) Blocked(reason string, planned []string) {
+	if this.inner != nil {
+		this.inner.Blocked(reason, planned)
+	}
+}
+
+func (this *countingDoneHandler) Throttled(retryAfter time.Duration) {
+	if this.inner != nil {
+		this.inner.Throttled(retryAfter)
 	}
 }
 
@@ -468,6 +681,13 @@ func (this *ChangeModel) IsForeign(set *dns.DNSSet) bool {
 	return set.IsForeign(this.ownership)
 }
 
+// isOtherNamespaceOwner checks whether a record set is owned by an owner id
+// derived from the namespace of another entry.
+func (this *ChangeModel) isOtherNamespaceOwner(set *dns.DNSSet, spec TargetSpec) bool {
+	owner := set.GetOwner()
+	return owner != "" && owner != spec.OwnerId() && this.config.NamespaceOwnerIds.Matches(owner)
+}
+
 func (this *ChangeModel) setOwner(set *dns.DNSSet, id string) bool {
 	if id == "" {
 		id = this.config.Ident
@@ -485,6 +705,7 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 		if this.setOwner(set, spec.OwnerId()) {
 			set.SetMetaAttr(dns.ATTR_PREFIX, dns.TxtPrefix)
 		}
+		set.SetOwnerGroup(spec.OwnerGroup())
 	}
 
 	targetsets := set.Sets
@@ -509,9 +730,26 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 				t.GetHostName(), strings.Join(ipv4addrs, ","), strings.Join(ipv6addrs, ","))
 		} else {
 			t = provider.MapTarget(t)
+			if t.GetRecordType() == dns.RS_RESOURCE {
+				this.Errorf("target resource %q not supported by provider type %s", t.GetHostName(), provider.TypeCode())
+				continue
+			}
 			AddRecord(targetsets, t.GetRecordType(), t.GetHostName(), ttl)
 		}
 	}
+	if rp := spec.RoutingPolicy(); rp != nil {
+		for ty, rs := range targetsets {
+			// the meta data of sets with a set identifier must be kept separately for each set
+			if ty != dns.RS_META || rp.SetIdentifier != "" {
+				rs.RoutingPolicy = rp.Clone()
+			}
+		}
+	}
+	for ty, rs := range targetsets {
+		if ty != dns.RS_META {
+			rs.ProviderHints = provider.ProviderHintsFor(rs, spec.ProviderHints())
+		}
+	}
 	set.Sets = targetsets
 	if len(cnames) > 0 && this.Owns(set) {
 		sort.Strings(cnames)
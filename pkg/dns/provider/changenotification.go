@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ZoneChangeNotification is a change of a hosted zone reported by a provider specific notification channel.
+type ZoneChangeNotification struct {
+	// ZoneID is the provider specific id of the changed hosted zone
+	ZoneID string
+	// Principal is the identity having done the change, if known
+	Principal string
+}
+
+// ZoneChangeNotificationReceiver is implemented by consumers of provider change notifications
+// (e.g. an AWS SQS queue fed by EventBridge or a GCP Pub/Sub subscription fed by audit logs).
+type ZoneChangeNotificationReceiver interface {
+	// Receive waits for the next batch of notifications. The returned function acknowledges
+	// the batch after processing.
+	Receive(ctx context.Context) ([]ZoneChangeNotification, func(), error)
+}
+
+// StartZoneChangeNotificationConsumer consumes the notifications of the receiver until the context is done.
+// For each notified zone the cached zone state is invalidated and a reconciliation of the zone is triggered.
+// Changes done by the given own principal are ignored, as they are already reflected in the zone cache.
+func StartZoneChangeNotificationConsumer(ctx context.Context, logger logger.LogContext, providerType string, cache ZoneCache,
+	receiver ZoneChangeNotificationReceiver, ownPrincipal string) {
+	go consumeZoneChangeNotifications(ctx, logger, providerType, cache, receiver, ownPrincipal)
+}
+
+func consumeZoneChangeNotifications(ctx context.Context, logger logger.LogContext, providerType string, cache ZoneCache,
+	receiver ZoneChangeNotificationReceiver, ownPrincipal string) {
+	logger.Infof("starting consumer for zone change notifications")
+	defer logger.Infof("stopped consumer for zone change notifications")
+
+	backoff := time.Duration(0)
+	for ctx.Err() == nil {
+		notifications, ack, err := receiver.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			backoff = backoff*2 + 5*time.Second
+			if backoff > 5*time.Minute {
+				backoff = 5 * time.Minute
+			}
+			logger.Warnf("receiving zone change notifications failed (retry in %s): %s", backoff, err)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			continue
+		}
+		backoff = 0
+		processZoneChangeNotifications(logger, providerType, cache, notifications, ownPrincipal)
+		if ack != nil {
+			ack()
+		}
+	}
+}
+
+func processZoneChangeNotifications(logger logger.LogContext, providerType string, cache ZoneCache,
+	notifications []ZoneChangeNotification, ownPrincipal string) {
+	invalidated := map[string]struct{}{}
+	for _, n := range notifications {
+		if n.ZoneID == "" || (ownPrincipal != "" && n.Principal == ownPrincipal) {
+			continue
+		}
+		if _, ok := invalidated[n.ZoneID]; ok {
+			continue
+		}
+		invalidated[n.ZoneID] = struct{}{}
+		logger.Infof("zone %s changed by %q: invalidating zone state", n.ZoneID, n.Principal)
+		cache.InvalidateZoneState(dns.NewZoneID(providerType, n.ZoneID))
+	}
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// coldStart prioritizes the zones with pending entries after a restart of the controller.
+// Their reconciliations are triggered first, the reconciliations of the other zones are
+// delayed by the warmup delay, as these zones have nothing to change.
+type coldStart struct {
+	warmupDelay time.Duration
+	// priority are the hosted zone commands of the zones with pending entries
+	priority utils.StringSet
+}
+
+// order returns the commands to trigger immediately, the commands of the zones with pending
+// entries first.
+func (this *coldStart) order(cmds utils.StringSet) []string {
+	if this == nil {
+		return cmds.AsArray()
+	}
+	var first, others []string
+	for c := range cmds {
+		if this.priority.Contains(c) {
+			first = append(first, c)
+		} else if !this.isDeferred(c) {
+			others = append(others, c)
+		}
+	}
+	return append(first, others...)
+}
+
+// deferCommands triggers the reconciliations of the zones without pending entries after the warmup delay.
+func (this *coldStart) deferCommands(context Context, cmds utils.StringSet) {
+	if this == nil {
+		return
+	}
+	count := 0
+	for c := range cmds {
+		if this.isDeferred(c) {
+			context.GetPool(DNS_POOL).EnqueueCommandAfter(c, this.warmupDelay)
+			count++
+		}
+	}
+	if count > 0 {
+		context.Infof("deferred reconciliation of %d zones without pending entries by %s", count, this.warmupDelay)
+	}
+}
+
+func (this *coldStart) isDeferred(cmd string) bool {
+	return strings.HasPrefix(cmd, CMD_HOSTEDZONE_PREFIX) && !this.priority.Contains(cmd)
+}
+
+// zonePrefetch is a prefetching of zone states requested by a provider during the setup.
+// It is started after the setup of the entries, when the zones with pending entries are known.
+type zonePrefetch struct {
+	account *DNSAccount
+	logger  logger.LogContext
+	zones   DNSHostedZones
+}
+
+func (this *setup) AddPrefetch(p *zonePrefetch) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.prefetches = append(this.prefetches, p)
+}
+
+func (this *setup) prioritize(coldStart *coldStart) []*zonePrefetch {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.coldStart = coldStart
+	prefetches := this.prefetches
+	this.prefetches = nil
+	return prefetches
+}
+
+// prioritizeZones determines the zones with pending entries after the setup of the entries and
+// starts the deferred prefetching of zone states, the states of these zones are fetched first.
+func (this *state) prioritizeZones() {
+	var pending map[dns.ZoneID]bool
+	var cs *coldStart
+	if this.config.ZoneWarmupDelay > 0 {
+		pending = this.pendingZones()
+		if len(pending) > 0 {
+			cs = &coldStart{warmupDelay: this.config.ZoneWarmupDelay, priority: utils.StringSet{}}
+			for zoneid := range pending {
+				cs.priority.Add(hostedZoneCommand(zoneid))
+			}
+			this.context.Infof("prioritizing %d zones with pending entries", len(pending))
+		}
+	}
+	for _, p := range this.setup.prioritize(cs) {
+		p.account.prefetchZoneStates(this.context.GetContext(), p.logger, p.zones, pending)
+	}
+}
+
+// pendingZones returns the zones of entries waiting for changes in their zones.
+func (this *state) pendingZones() map[dns.ZoneID]bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	zones := map[dns.ZoneID]bool{}
+	for _, e := range this.entries {
+		if !isPendingEntry(e) {
+			continue
+		}
+		for _, zoneid := range []dns.ZoneID{e.ZoneId(), e.activezone} {
+			if !zoneid.IsEmpty() && this.zones[zoneid] != nil {
+				zones[zoneid] = true
+			}
+		}
+	}
+	return zones
+}
+
+// isPendingEntry checks whether an entry is new, modified, not ready or deleting.
+// Invalid entries are not pending, as they cannot be applied before they are fixed.
+func isPendingEntry(e *Entry) bool {
+	if e.IsDeleting() {
+		return true
+	}
+	switch e.State() {
+	case api.STATE_READY:
+	case api.STATE_INVALID:
+		return false
+	default:
+		return true
+	}
+	status := e.Object().BaseStatus()
+	return status == nil || status.ObservedGeneration < e.Object().GetGeneration()
+}
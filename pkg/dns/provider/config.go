@@ -66,16 +66,19 @@ func (this *FactoryOptions) Evaluate() error {
 type GenericFactoryOptions struct {
 	RateLimiterOptions
 	AdvancedOptions
+	TimeoutOptions
 }
 
 var GenericFactoryOptionDefaults = GenericFactoryOptions{
 	RateLimiterOptions: RateLimiterOptionDefaults,
 	AdvancedOptions:    AdvancedOptionsDefaults,
+	TimeoutOptions:     TimeoutOptionsDefaults,
 }
 
 func (this *GenericFactoryOptions) AddOptionsToSet(set config.OptionSet) {
 	this.RateLimiterOptions.AddOptionsToSet(set)
 	this.AdvancedOptions.AddOptionsToSet(set)
+	this.TimeoutOptions.AddOptionsToSet(set)
 }
 
 func (this GenericFactoryOptions) SetRateLimiterOptions(o RateLimiterOptions) GenericFactoryOptions {
@@ -88,6 +91,11 @@ func (this GenericFactoryOptions) SetAdvancedOptions(o AdvancedOptions) GenericF
 	return this
 }
 
+func (this GenericFactoryOptions) SetTimeoutOptions(o TimeoutOptions) GenericFactoryOptions {
+	this.TimeoutOptions = o
+	return this
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c *DNSHandlerConfig) GetRequiredProperty(key string, altKeys ...string) (string, error) {
@@ -271,6 +279,25 @@ func (c *DNSHandlerConfig) Complete() error {
 			return fmt.Errorf("invalid rate limiter: %w", err)
 		}
 		c.Logger.Infof("rate limiter: %v", rateLimiterConfig)
+		if c.Timeouts != nil {
+			*c.Timeouts = c.Options.GetTimeoutConfig()
+			c.Logger.Infof("timeouts: %v", *c.Timeouts)
+		}
+		if c.ZoneStateConcurrency != nil {
+			concurrency := c.Options.ZoneStateConcurrency
+			if rateLimiterConfig != nil && rateLimiterConfig.Burst > 0 && rateLimiterConfig.Burst < concurrency {
+				concurrency = rateLimiterConfig.Burst
+			}
+			*c.ZoneStateConcurrency = concurrency
+		}
+		tags := c.Options.GetResourceTags()
+		for k, v := range c.ResourceTags {
+			tags[k] = v
+		}
+		c.ResourceTags = tags
+		if len(tags) > 0 {
+			c.Logger.Infof("resource tags: %v", tags)
+		}
 	}
 	c.RateLimiter = rateLimiter
 	return nil
@@ -26,37 +26,103 @@ import (
 */
 
 const (
-	OPT_IDENTIFIER                 = "identifier"
-	OPT_CLASS                      = source.OPT_CLASS
-	OPT_DRYRUN                     = "dry-run"
-	OPT_TTL                        = "ttl"
-	OPT_CACHE_TTL                  = "cache-ttl"
-	OPT_SETUP                      = dns.OPT_SETUP
-	OPT_DNSDELAY                   = "dns-delay"
-	OPT_RESCHEDULEDELAY            = "reschedule-delay"
-	OPT_LOCKSTATUSCHECKPERIOD      = "lock-status-check-period"
-	OPT_DISABLE_ZONE_STATE_CACHING = "disable-zone-state-caching"
+	OPT_IDENTIFIER                  = "identifier"
+	OPT_CLASS                       = source.OPT_CLASS
+	OPT_DRYRUN                      = "dry-run"
+	OPT_TTL                         = "ttl"
+	OPT_CACHE_TTL                   = "cache-ttl"
+	OPT_SETUP                       = dns.OPT_SETUP
+	OPT_DNSDELAY                    = "dns-delay"
+	OPT_RESCHEDULEDELAY             = "reschedule-delay"
+	OPT_LOCKSTATUSCHECKPERIOD       = "lock-status-check-period"
+	OPT_DISABLE_ZONE_STATE_CACHING  = "disable-zone-state-caching"
+	OPT_ZONE_STATE_CACHE_DIR        = "zone-state-cache-dir"
+	OPT_ZONE_STATE_CACHE_MAX_AGE    = "zone-state-cache-max-age"
+	OPT_ZONE_STATE_FULL_SYNC        = "zone-state-full-sync-period"
+	OPT_ZONE_STATE_MAX_STALE        = "zone-state-max-stale"
+	OPT_ZONE_STATE_TTL_JITTER       = "zone-state-ttl-jitter"
+	OPT_ZONE_WARMUP_DELAY           = "zone-warmup-delay"
+	OPT_ZONE_VERIFICATION_PERIOD    = "zone-verification-period"
+	OPT_ZONE_VERIFICATION_DELAY     = "zone-verification-delay"
+	OPT_QUERY_METRICS_PERIOD        = "query-metrics-period"
+	OPT_DNSSEC_CHECK_PERIOD         = "dnssec-check-period"
+	OPT_WRITE_PERMISSION_PERIOD     = "write-permission-check-period"
+	OPT_ZONE_OWNERSHIP_PERIOD       = "zone-ownership-marker-period"
+	OPT_CANARY_PERIOD               = "canary-period"
+	OPT_CANARY_LABEL                = "canary-label"
+	OPT_AUDIT_LOG_FILE              = "audit-log-file"
+	OPT_AUDIT_LOG_EVENTS            = "audit-log-events"
+	OPT_AUDIT_LOG_WEBHOOK           = "audit-log-webhook"
+	OPT_AUDIT_LOG_SIGNING_KEY       = "audit-log-signing-key"
+	OPT_EXTERNAL_DATA_ENDPOINT      = "external-data-endpoint"
+	OPT_ZONE_STATE_ENDPOINT         = "zone-state-endpoint"
+	OPT_DOMAIN_MATCH_ENDPOINT       = "domain-match-endpoint"
+	OPT_INCLUDE_SUGGESTION_ENDPOINT = "include-suggestion-endpoint"
+	OPT_METRICS_ZONE_ALLOWLIST      = "metrics-zone-allowlist"
+	OPT_WATCHDOG_THRESHOLD          = "watchdog-threshold"
+	OPT_COST_ATTRIBUTION_LABEL      = "cost-attribution-label"
+	OPT_COST_REPORT                 = "cost-report"
+	OPT_WRITE_FREEZE                = "write-freeze"
+	OPT_WRITE_FREEZE_ENDPOINT       = "write-freeze-endpoint"
+	OPT_WRITE_FREEZE_CONFIGMAP      = "write-freeze-configmap"
+	OPT_ERROR_HISTORY_SIZE          = "error-history-size"
+	OPT_RECORD_HISTORY_SIZE         = "record-history-size"
+	OPT_ANOMALY_GUARD_MAX_CHANGES   = "anomaly-guard-max-changes"
+	OPT_ANOMALY_GUARD_WINDOW        = "anomaly-guard-window"
+	OPT_ANOMALY_GUARD_PAUSE         = "anomaly-guard-pause"
+	OPT_FLAP_DETECTION_WINDOW       = "flap-detection-window"
+	OPT_FLAP_DETECTION_THRESHOLD    = "flap-detection-threshold"
+	OPT_FLAP_DAMPENING_HOLD_DOWN    = "flap-dampening-hold-down"
+	OPT_OWNER_CONFLICT_RESOLUTION   = "owner-conflict-resolution"
+	OPT_NAMESPACE_OWNER_ID          = "namespace-owner-id"
+	OPT_CRD_MANAGEMENT              = "crd-management"
+
+	OPT_CONVERSION_WEBHOOK_PORT     = "conversion-webhook-port"
+	OPT_CONVERSION_WEBHOOK_CERT_DIR = "conversion-webhook-cert-dir"
+	OPT_CONVERSION_WEBHOOK_SERVICE  = "conversion-webhook-service"
 
 	OPT_REMOTE_ACCESS_PORT               = "remote-access-port"
 	OPT_REMOTE_ACCESS_CACERT             = "remote-access-cacert"
 	OPT_REMOTE_ACCESS_SERVER_SECRET_NAME = "remote-access-server-secret-name"
 	OPT_REMOTE_ACCESS_CLIENT_ID          = "remote-access-client-id"
 
+	OPT_REMOTE_ACCESS_CLIENT_QPS                 = "remote-access-client-qps"
+	OPT_REMOTE_ACCESS_CLIENT_BURST               = "remote-access-client-burst"
+	OPT_REMOTE_ACCESS_CLIENT_MAX_PENDING_CHANGES = "remote-access-client-max-pending-changes"
+
 	OPT_PROVIDERTYPES = "provider-types"
 
-	OPT_RATELIMITER_ENABLED = "ratelimiter.enabled"
-	OPT_RATELIMITER_QPS     = "ratelimiter.qps"
-	OPT_RATELIMITER_BURST   = "ratelimiter.burst"
+	OPT_RATELIMITER_ENABLED  = "ratelimiter.enabled"
+	OPT_RATELIMITER_QPS      = "ratelimiter.qps"
+	OPT_RATELIMITER_BURST    = "ratelimiter.burst"
+	OPT_RATELIMITER_ADAPTIVE = "ratelimiter.adaptive"
+
+	OPT_ADVANCED_BATCH_SIZE             = "advanced.batch-size"
+	OPT_ADVANCED_MAX_RETRIES            = "advanced.max-retries"
+	OPT_ADVANCED_ZONE_STATE_CONCURRENCY = "advanced.zone-state-concurrency"
+	OPT_ADVANCED_BLOCKED_ZONE           = "blocked-zone"
+	OPT_ADVANCED_RESOURCE_TAG           = "resource-tag"
 
-	OPT_ADVANCED_BATCH_SIZE   = "advanced.batch-size"
-	OPT_ADVANCED_MAX_RETRIES  = "advanced.max-retries"
-	OPT_ADVANCED_BLOCKED_ZONE = "blocked-zone"
+	OPT_TIMEOUT_GET_ZONES        = "timeout.get-zones"
+	OPT_TIMEOUT_GET_ZONE_STATE   = "timeout.get-zone-state"
+	OPT_TIMEOUT_EXECUTE_REQUESTS = "timeout.execute-requests"
 
 	CMD_HOSTEDZONE_PREFIX = "hostedzone:"
+	CMD_VERIFYZONE_PREFIX = "verifyzone:"
 	CMD_STATISTIC         = "statistic"
 	CMD_DNSLOOKUP         = "dnslookup"
+	CMD_QUERY_METRICS     = "querymetrics"
+	CMD_DNSSEC            = "dnssec"
+	CMD_WRITE_PERMISSION  = "writepermission"
+	CMD_ZONE_OWNERSHIP    = "zoneownership"
+	CMD_CANARY            = "canary"
 
-	MSG_THROTTLING = "provider throttled"
+	MSG_THROTTLING    = "provider throttled"
+	MSG_READONLY      = "changes planned, but not applied by read-only provider"
+	MSG_WRITE_FREEZE  = "changes planned, but not applied because of global write freeze"
+	MSG_DRYRUN        = "changes planned, but not applied in dry-run mode"
+	MSG_ANOMALY_GUARD = "changes planned, but not applied because writes for zone are paused by anomaly guard"
+	MSG_FLAPPING      = "targets flapping, changes held down until stable"
 )
 
 const (
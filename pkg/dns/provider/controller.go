@@ -17,6 +17,7 @@
 package provider
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -27,6 +28,10 @@ import (
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/source"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/manifests"
+	"github.com/gardener/external-dns-management/pkg/server/health"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -51,6 +56,7 @@ const SYNC_ENTRIES = "entries"
 const FACTORY_OPTIONS = "factory"
 
 const DNS_POOL = "dns"
+const VERIFICATION_POOL = "verification"
 
 var ownerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSOwnerKind)
 var secretGroupKind = resources.NewGroupKind("", "Secret")
@@ -122,16 +128,62 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		DefaultedStringOption(OPT_IDENTIFIER, "dnscontroller", "Identifier used to mark DNS entries in DNS system").
 		DefaultedBoolOption(OPT_DRYRUN, false, "just check, don't modify").
 		DefaultedBoolOption(OPT_DISABLE_ZONE_STATE_CACHING, false, "disable use of cached dns zone state on changes").
+		DefaultedStringOption(OPT_ZONE_STATE_CACHE_DIR, "", "directory to persist cached dns zone states to survive restarts (disabled if empty)").
+		DefaultedDurationOption(OPT_ZONE_STATE_CACHE_MAX_AGE, 1*time.Hour, "maximum age of persisted dns zone states to be reused on startup").
+		DefaultedDurationOption(OPT_ZONE_STATE_FULL_SYNC, 30*time.Minute, "period of full synchronizations of dns zone states for providers supporting incremental synchronization").
+		DefaultedDurationOption(OPT_ZONE_STATE_MAX_STALE, 0, "maximum duration an expired dns zone state is served if its revalidation fails (0 to disable)").
+		DefaultedIntOption(OPT_ZONE_STATE_TTL_JITTER, 10, "maximum jitter in percent of the ttl of cached dns zone states to spread their refreshes (0 to disable)").
+		DefaultedDurationOption(OPT_ZONE_WARMUP_DELAY, 30*time.Second, "delay of the first reconciliation of dns zones without pending entries on startup to prioritize zones with pending entries (0 to disable)").
+		DefaultedDurationOption(OPT_ZONE_VERIFICATION_PERIOD, 0, "period of provider drift checks for dns zones decoupled from the reconciliation of changes (0 to revalidate zone states by their ttl)").
+		DefaultedDurationOption(OPT_ZONE_VERIFICATION_DELAY, 30*time.Second, "minimum delay between two zone verifications").
+		DefaultedDurationOption(OPT_QUERY_METRICS_PERIOD, 0, "period for ingesting DNS query metrics of the providers into the entry status (0 to disable)").
+		DefaultedDurationOption(OPT_DNSSEC_CHECK_PERIOD, 10*time.Minute, "period for enabling DNSSEC signing and checking the chain of trust of zones of providers with DNSSEC enabled (0 to disable)").
+		DefaultedDurationOption(OPT_WRITE_PERMISSION_PERIOD, 30*time.Minute, "period for verifying the write permission for the zones of providers with write permission check enabled (0 to disable)").
+		DefaultedDurationOption(OPT_ZONE_OWNERSHIP_PERIOD, 0, "period for writing ownership markers into the zone-level metadata of the provider zones (0 to disable)").
+		DefaultedDurationOption(OPT_CANARY_PERIOD, 0, "period for renewing and resolving the timestamp of the canary records of the public zones (0 to disable)").
+		DefaultedStringOption(OPT_CANARY_LABEL, "_dnsman-canary", "label of the canary record of the zones").
+		DefaultedStringOption(OPT_AUDIT_LOG_FILE, "", "file to append the audit records of all applied changes to as JSON lines (disabled if empty)").
+		DefaultedBoolOption(OPT_AUDIT_LOG_EVENTS, false, "emit events with the audit records of all applied changes for the triggering objects").
+		DefaultedStringOption(OPT_AUDIT_LOG_WEBHOOK, "", "URL of a webhook to post the audit records of all applied changes to as JSON (disabled if empty)").
+		DefaultedStringOption(OPT_AUDIT_LOG_SIGNING_KEY, "", "file with PEM encoded private key (ECDSA, Ed25519 or RSA) for signing the audit records (disabled if empty)").
+		DefaultedBoolOption(OPT_EXTERNAL_DATA_ENDPOINT, false, "serve managed DNS names as OPA Gatekeeper external data provider on /external-data/dnsnames").
+		DefaultedBoolOption(OPT_ZONE_STATE_ENDPOINT, false, "serve cached zone states and their consistency checks as JSON on /debug/zonestates").
+		DefaultedBoolOption(OPT_DOMAIN_MATCH_ENDPOINT, false, "serve explanations of the provider and zone selection for dns names on /debug/domainmatch").
+		DefaultedBoolOption(OPT_INCLUDE_SUGGESTION_ENDPOINT, false, "serve suggestions for the minimal domain include lists of the providers derived from their entries on /debug/includesuggestion").
+		DefaultedIntOption(OPT_ERROR_HISTORY_SIZE, 5, "number of last errors kept in the status of dns entries (0 to disable)").
+		DefaultedIntOption(OPT_RECORD_HISTORY_SIZE, 5, "number of previous values kept in the zone cache for every changed record set (0 to disable)").
 		DefaultedIntOption(OPT_TTL, 300, "Default time-to-live for DNS entries. Defines how long the record is kept in cache by DNS servers or resolvers.").
 		DefaultedIntOption(OPT_CACHE_TTL, 120, "Time-to-live for provider hosted zone cache").
 		DefaultedIntOption(OPT_SETUP, 10, "number of processors for controller setup").
 		DefaultedDurationOption(OPT_DNSDELAY, 10*time.Second, "delay between two dns reconciliations").
 		DefaultedDurationOption(OPT_RESCHEDULEDELAY, 120*time.Second, "reschedule delay after losing provider").
 		DefaultedDurationOption(OPT_LOCKSTATUSCHECKPERIOD, 120*time.Second, "interval for dns lock status checks").
+		DefaultedStringOption(OPT_METRICS_ZONE_ALLOWLIST, metrics.ZoneLabelsAll, "comma separated list of zone ids with detailed metrics ('*' for all, 'none' to aggregate all zones)").
+		DefaultedDurationOption(OPT_WATCHDOG_THRESHOLD, 15*time.Minute, "maximum duration for processing a single key before the processing is cancelled (0 to disable)").
+		DefaultedStringOption(OPT_COST_ATTRIBUTION_LABEL, "", "label of dns entries used as tenant for the cost attribution (in addition to the namespace)").
+		DefaultedBoolOption(OPT_COST_REPORT, false, "serve cost attribution report per tenant as CSV on /cost-report").
+		DefaultedBoolOption(OPT_WRITE_FREEZE, false, "start with global write freeze suspending all provider writes").
+		DefaultedBoolOption(OPT_WRITE_FREEZE_ENDPOINT, false, "serve switch for global write freeze on /write-freeze (GET for state, POST with query parameter frozen=true|false)").
+		DefaultedStringOption(OPT_WRITE_FREEZE_CONFIGMAP, "", "config map (<namespace>/<name>) persisting the global write freeze to survive restarts (disabled if empty)").
+		DefaultedIntOption(OPT_ANOMALY_GUARD_MAX_CHANGES, 0, "maximum number of changes per zone within the anomaly guard window before the writes for the zone are paused (0 to disable)").
+		DefaultedDurationOption(OPT_ANOMALY_GUARD_WINDOW, 5*time.Minute, "time window for counting the changes per zone of the anomaly guard").
+		DefaultedDurationOption(OPT_ANOMALY_GUARD_PAUSE, 0, "duration of the write pause of a zone after exceeding the anomaly guard threshold (0: until released on /anomaly-guard)").
+		DefaultedDurationOption(OPT_FLAP_DETECTION_WINDOW, 0, "time window for counting the target changes of an entry to detect flapping targets (0 to disable)").
+		DefaultedIntOption(OPT_FLAP_DETECTION_THRESHOLD, 3, "number of target changes within the flap detection window marking an entry as flapping").
+		DefaultedDurationOption(OPT_FLAP_DAMPENING_HOLD_DOWN, 2*time.Minute, "duration the targets of a flapping entry must be stable before its changes are applied").
+		DefaultedStringOption(OPT_OWNER_CONFLICT_RESOLUTION, OWNER_CONFLICT_RESOLUTION_NONE, "resolution of conflicts with dns records of foreign owners ('none' to report them only, 'newer-entry' to take over records of the same owner group created before the entry)").
+		DefaultedStringOption(OPT_NAMESPACE_OWNER_ID, "", "template for the owner id of entries without explicit owner id derived from their namespace (e.g. 'ownerid-{namespace}')").
+		DefaultedStringOption(OPT_CRD_MANAGEMENT, manifests.CRDManagementOff, "management of the DNS CRDs on startup ('off', 'create' to create missing CRDs, 'update' to upgrade existing CRDs, too)").
+		DefaultedIntOption(OPT_CONVERSION_WEBHOOK_PORT, 0, "port of the conversion webhook serving the dns api version v1beta1 (0: disabled)").
+		DefaultedStringOption(OPT_CONVERSION_WEBHOOK_CERT_DIR, "", "directory with the server certificate (tls.crt, tls.key) and its CA (ca.crt) of the conversion webhook").
+		DefaultedStringOption(OPT_CONVERSION_WEBHOOK_SERVICE, "", "service of the conversion webhook registered in the CRDs by the crd management (<namespace>/<name>[:<port>])").
 		DefaultedIntOption(OPT_REMOTE_ACCESS_PORT, 0, "port of remote access server for remote-enabled providers").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CACERT, "", "CA who signed client certs file").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_SERVER_SECRET_NAME, "", "name of secret containing remote access server's certificate").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CLIENT_ID, "", "identifier used for remote access").
+		DefaultedIntOption(OPT_REMOTE_ACCESS_CLIENT_QPS, 0, "maximum requests per second of a remote access client (0: unlimited)").
+		DefaultedIntOption(OPT_REMOTE_ACCESS_CLIENT_BURST, 20, "number of burst requests of a remote access client").
+		DefaultedIntOption(OPT_REMOTE_ACCESS_CLIENT_MAX_PENDING_CHANGES, 0, "maximum number of pending change requests of a remote access client (0: unlimited)").
 		FinalizerDomain("dns.gardener.cloud").
 		Reconciler(DNSReconcilerType(factory)).
 		Cluster(TARGET_CLUSTER).
@@ -160,7 +212,8 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		).
 		WorkerPool(DNS_POOL, 1, 15*time.Minute).CommandMatchers(utils.NewStringGlobMatcher(CMD_HOSTEDZONE_PREFIX+"*")).
 		Commands(CMD_DNSLOOKUP).
-		WorkerPool("statistic", 2, 0).Commands(CMD_STATISTIC).
+		WorkerPool(VERIFICATION_POOL, 1, 0).CommandMatchers(utils.NewStringGlobMatcher(CMD_VERIFYZONE_PREFIX+"*")).
+		WorkerPool("statistic", 2, 0).Commands(CMD_STATISTIC, CMD_QUERY_METRICS, CMD_DNSSEC, CMD_WRITE_PERMISSION, CMD_ZONE_OWNERSHIP, CMD_CANARY).
 		OptionSource(FACTORY_OPTIONS, FactoryOptionSourceCreator(factory))
 	return cfg
 }
@@ -169,6 +222,7 @@ type reconciler struct {
 	reconcile.DefaultReconciler
 	controller controller.Interface
 	state      *state
+	watchdog   *dnsutils.Watchdog
 }
 
 var _ reconcile.Interface = &reconciler{}
@@ -217,28 +271,148 @@ func Create(c controller.Interface, factory DNSHandlerFactory) (reconcile.Interf
 	}, nil
 }
 
+const (
+	HEALTH_ENTRIES   = "entries"
+	HEALTH_PROVIDERS = "providers"
+	HEALTH_ZONES     = "zones"
+
+	HEALTH_REMOTE_ACCESS_SERVER = "remote-access-server"
+)
+
+func (this *reconciler) healthName(kind string) string {
+	return this.controller.GetName() + "/" + kind
+}
+
+func (this *reconciler) healthTimeout(pool string) time.Duration {
+	if period := this.state.GetContext().GetPoolPeriod(pool); period != nil && *period > 0 {
+		return 3 * *period
+	}
+	return 0
+}
+
+// tickIdleHealthChecks keeps the health checks of the providers and zones alive
+// as long as there is nothing to reconcile for them.
+func (this *reconciler) tickIdleHealthChecks() {
+	if !this.state.HasProviders() {
+		health.Tick(this.healthName(HEALTH_PROVIDERS))
+	}
+	if !this.state.HasZones() {
+		health.Tick(this.healthName(HEALTH_ZONES))
+	}
+}
+
 func (this *reconciler) Setup() error {
 	this.controller.Infof("*** state Setup ")
-	return this.state.Setup()
+	this.controller.Infof("watchdog threshold: %s", this.state.config.WatchdogThreshold)
+	this.watchdog = dnsutils.NewWatchdog(this.controller.GetContext(), this.controller, this.state.config.WatchdogThreshold,
+		func(kind, key string, duration time.Duration) {
+			metrics.AddStuckReconciliation(this.controller.GetName(), kind)
+		})
+	health.Register(this.healthName(HEALTH_ENTRIES), 0)
+	health.Register(this.healthName(HEALTH_PROVIDERS), this.healthTimeout("providers"))
+	health.Register(this.healthName(HEALTH_ZONES), this.healthTimeout(DNS_POOL))
+	if cfg := this.state.config.ConversionWebhook; cfg != nil {
+		if err := webhook.StartServer(this.controller.GetContext(), this.controller, cfg.Port, cfg.CertDir); err != nil {
+			return fmt.Errorf("cannot start conversion webhook: %w", err)
+		}
+	}
+	if err := this.manageCRDs(); err != nil {
+		return err
+	}
+	err := this.state.Setup()
+	if err != nil {
+		for _, kind := range []string{HEALTH_ENTRIES, HEALTH_PROVIDERS, HEALTH_ZONES} {
+			health.SetReady(this.healthName(kind), false, err.Error())
+		}
+	}
+	return err
+}
+
+// manageCRDs creates or upgrades the CRDs in the target and provider clusters according to the CRD management mode.
+func (this *reconciler) manageCRDs() error {
+	mode := this.state.config.CRDManagement
+	if mode == manifests.CRDManagementOff {
+		return nil
+	}
+	done := map[string]bool{}
+	for _, name := range []string{TARGET_CLUSTER, PROVIDER_CLUSTER} {
+		cluster := this.controller.GetCluster(name)
+		if cluster == nil || done[cluster.GetId()] {
+			continue
+		}
+		done[cluster.GetId()] = true
+		this.controller.Infof("managing crds in cluster %s (mode %s)", cluster.GetName(), mode)
+		config := cluster.Config()
+		var service *manifests.ConversionWebhook
+		if cfg := this.state.config.ConversionWebhook; cfg != nil {
+			service = cfg.Service
+		}
+		err := manifests.ManageCustomResourceDefinitions(this.controller.GetContext(), this.controller, &config, mode, this.state.config.Dryrun, service)
+		if err != nil {
+			return fmt.Errorf("cannot manage crds in cluster %s: %w", cluster.GetName(), err)
+		}
+	}
+	return nil
 }
 
 func (this *reconciler) Start() {
 	this.state.setup.pending.Add(CMD_DNSLOOKUP)
+	if this.state.config.QueryMetricsPeriod > 0 {
+		this.state.setup.pending.Add(CMD_QUERY_METRICS)
+	}
+	if this.state.config.DNSSECCheckPeriod > 0 {
+		this.state.setup.pending.Add(CMD_DNSSEC)
+	}
+	if this.state.config.WritePermissionPeriod > 0 {
+		this.state.setup.pending.Add(CMD_WRITE_PERMISSION)
+	}
+	if this.state.config.ZoneOwnershipPeriod > 0 {
+		this.state.setup.pending.Add(CMD_ZONE_OWNERSHIP)
+	}
+	if this.state.config.CanaryPeriod > 0 {
+		this.state.setup.pending.Add(CMD_CANARY)
+	}
 	this.state.Start()
+	for _, kind := range []string{HEALTH_ENTRIES, HEALTH_PROVIDERS, HEALTH_ZONES} {
+		health.SetReady(this.healthName(kind), true, "")
+	}
 }
 
 func (this *reconciler) Command(logger logger.LogContext, cmd string) reconcile.Status {
+	ctx, done := this.watchdog.Watch(this.controller.GetContext(), "command", cmd)
+	defer done()
+
 	switch cmd {
 	case CMD_DNSLOOKUP:
 		this.state.ownerCache.TriggerDNSActivation(logger, this.controller)
 		this.state.UpdateLockStates(logger)
+		this.tickIdleHealthChecks()
 		return reconcile.RescheduleAfter(logger, this.state.config.StatusCheckPeriod)
 	case CMD_STATISTIC:
 		this.state.UpdateOwnerCounts(logger)
+	case CMD_QUERY_METRICS:
+		this.state.UpdateQueryMetrics(ctx, logger)
+		return reconcile.RescheduleAfter(logger, this.state.config.QueryMetricsPeriod)
+	case CMD_DNSSEC:
+		this.state.UpdateDNSSEC(ctx, logger)
+		return reconcile.RescheduleAfter(logger, this.state.config.DNSSECCheckPeriod)
+	case CMD_WRITE_PERMISSION:
+		this.state.UpdateWritePermissions(ctx, logger)
+		return reconcile.RescheduleAfter(logger, this.state.config.WritePermissionPeriod)
+	case CMD_ZONE_OWNERSHIP:
+		this.state.UpdateZoneOwnershipMarkers(ctx, logger)
+		return reconcile.RescheduleAfter(logger, this.state.config.ZoneOwnershipPeriod)
+	case CMD_CANARY:
+		this.state.UpdateCanaries(ctx, logger)
+		return reconcile.RescheduleAfter(logger, this.state.config.CanaryPeriod)
 	default:
+		if zoneid := this.state.DecodeZoneVerificationCommand(cmd); zoneid != nil {
+			return this.state.VerifyZone(ctx, logger, *zoneid)
+		}
 		zoneid := this.state.DecodeZoneCommand(cmd)
 		if zoneid != nil {
-			return this.state.ReconcileZone(logger, *zoneid)
+			health.Tick(this.healthName(HEALTH_ZONES))
+			return this.state.ReconcileZone(ctx, logger, *zoneid)
 		}
 		logger.Infof("got unhandled command %q", cmd)
 	}
@@ -246,6 +420,9 @@ func (this *reconciler) Command(logger logger.LogContext, cmd string) reconcile.
 }
 
 func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	ctx, done := this.watchdog.Watch(this.controller.GetContext(), obj.GroupKind().Kind, obj.ObjectName().String())
+	defer done()
+
 	switch {
 	case obj.IsA(&api.DNSOwner{}):
 		if this.state.IsResponsibleFor(logger, obj) {
@@ -254,14 +431,16 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 			return this.state.OwnerDeleted(logger, obj.ClusterKey())
 		}
 	case obj.IsA(&api.DNSProvider{}):
+		health.Tick(this.healthName(HEALTH_PROVIDERS))
 		if this.state.IsResponsibleFor(logger, obj) {
-			return this.state.UpdateProvider(logger, dnsutils.DNSProvider(obj))
+			return this.state.UpdateProvider(ctx, logger, dnsutils.DNSProvider(obj))
 		} else {
 			return this.state.RemoveProvider(logger, dnsutils.DNSProvider(obj))
 		}
 	case obj.IsA(&api.DNSEntry{}):
+		health.Tick(this.healthName(HEALTH_ENTRIES))
 		if this.state.IsResponsibleFor(logger, obj) {
-			return this.state.UpdateEntry(logger, dnsutils.DNSEntry(obj))
+			return this.state.UpdateEntry(ctx, logger, dnsutils.DNSEntry(obj))
 		} else {
 			return this.state.EntryDeleted(logger, obj.ClusterKey())
 		}
@@ -273,7 +452,7 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		}
 	case obj.IsA(&api.DNSLock{}):
 		if this.state.IsResponsibleFor(logger, obj) {
-			return this.state.UpdateEntry(logger, dnsutils.DNSLock(obj))
+			return this.state.UpdateEntry(ctx, logger, dnsutils.DNSLock(obj))
 		} else {
 			return this.state.EntryDeleted(logger, obj.ClusterKey())
 		}
@@ -284,6 +463,9 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 }
 
 func (this *reconciler) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	ctx, done := this.watchdog.Watch(this.controller.GetContext(), obj.GroupKind().Kind, obj.ObjectName().String())
+	defer done()
+
 	if this.state.IsResponsibleFor(logger, obj) {
 		logger.Debugf("should delete %s", obj.Description())
 		switch {
@@ -291,10 +473,10 @@ func (this *reconciler) Delete(logger logger.LogContext, obj resources.Object) r
 			return this.state.RemoveProvider(logger, dnsutils.DNSProvider(obj))
 		case obj.IsA(&api.DNSEntry{}):
 			obj.UpdateFromCache()
-			return this.state.DeleteEntry(logger, dnsutils.DNSEntry(obj))
+			return this.state.DeleteEntry(ctx, logger, dnsutils.DNSEntry(obj))
 		case obj.IsA(&api.DNSLock{}):
 			obj.UpdateFromCache()
-			return this.state.DeleteEntry(logger, dnsutils.DNSLock(obj))
+			return this.state.DeleteEntry(ctx, logger, dnsutils.DNSLock(obj))
 		case obj.IsA(&corev1.Secret{}):
 			return this.state.UpdateSecret(logger, obj)
 		}
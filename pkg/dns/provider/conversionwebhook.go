@@ -0,0 +1,91 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+
+	"github.com/gardener/external-dns-management/pkg/manifests"
+	"github.com/gardener/external-dns-management/pkg/server/webhook"
+)
+
+// ConversionWebhookConfig is the configuration of the conversion webhook serving the API version v1beta1.
+type ConversionWebhookConfig struct {
+	Port    int
+	CertDir string
+	// Service is the webhook service registered in the CRDs by the CRD management (optional)
+	Service *manifests.ConversionWebhook
+}
+
+func (this *ConversionWebhookConfig) String() string {
+	if this == nil {
+		return "disabled"
+	}
+	if this.Service == nil {
+		return fmt.Sprintf("port %d", this.Port)
+	}
+	return fmt.Sprintf("port %d, service %s/%s:%d", this.Port, this.Service.Namespace, this.Service.Name, this.Service.Port)
+}
+
+func createConversionWebhookConfig(c controller.Interface) (*ConversionWebhookConfig, error) {
+	port, err := c.GetIntOption(OPT_CONVERSION_WEBHOOK_PORT)
+	if err != nil || port == 0 {
+		return nil, nil
+	}
+	certDir, _ := c.GetStringOption(OPT_CONVERSION_WEBHOOK_CERT_DIR)
+	if certDir == "" {
+		return nil, fmt.Errorf("missing %s for activated conversion webhook", OPT_CONVERSION_WEBHOOK_CERT_DIR)
+	}
+	cfg := &ConversionWebhookConfig{Port: port, CertDir: certDir}
+	service, _ := c.GetStringOption(OPT_CONVERSION_WEBHOOK_SERVICE)
+	if service != "" {
+		cfg.Service, err = parseConversionWebhookService(service)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", OPT_CONVERSION_WEBHOOK_SERVICE, err)
+		}
+		cfg.Service.CABundle, err = ioutil.ReadFile(filepath.Join(certDir, webhook.CAFile))
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA of conversion webhook: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// parseConversionWebhookService parses a service given as <namespace>/<name>[:<port>] (default port 443).
+func parseConversionWebhookService(service string) (*manifests.ConversionWebhook, error) {
+	result := &manifests.ConversionWebhook{Port: 443, Path: webhook.ConversionPath}
+	if i := strings.LastIndex(service, ":"); i >= 0 {
+		port, err := strconv.ParseInt(service[i+1:], 10, 32)
+		if err != nil || port <= 0 || port > 65535 {
+			return nil, fmt.Errorf("invalid port %q", service[i+1:])
+		}
+		result.Port = int32(port)
+		service = service[:i]
+	}
+	parts := strings.Split(service, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("expected '<namespace>/<name>[:<port>]'")
+	}
+	result.Namespace, result.Name = parts[0], parts[1]
+	return result, nil
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var _ = ginkgov2.Describe("Credentials failover", func() {
+	var (
+		refs     []corev1.SecretReference
+		accounts map[string]*DNSAccount
+		failures map[string]*credentialsFailure
+		bases    map[string]*DNSAccount
+		released []*DNSAccount
+		zones    DNSHostedZones
+	)
+
+	connect := func(ref corev1.SecretReference, last *DNSAccount) (resources.ObjectName, *DNSAccount, DNSHostedZones, *credentialsFailure) {
+		bases[ref.Name] = last
+		if f := failures[ref.Name]; f != nil {
+			return resources.NewObjectName(ref.Namespace, ref.Name), accounts[ref.Name], nil, f
+		}
+		return resources.NewObjectName(ref.Namespace, ref.Name), accounts[ref.Name], zones, nil
+	}
+	release := func(account *DNSAccount) {
+		released = append(released, account)
+	}
+	authFailure := func(name string) *credentialsFailure {
+		return &credentialsFailure{err: fmt.Errorf("%s: invalid credentials", name), temp: true, auth: true}
+	}
+
+	ginkgov2.BeforeEach(func() {
+		refs = []corev1.SecretReference{{Namespace: "default", Name: "primary"}, {Namespace: "default", Name: "secondary"}}
+		accounts = map[string]*DNSAccount{
+			"primary":   NewDNSAccount(nil, nil, "primary"),
+			"secondary": NewDNSAccount(nil, nil, "secondary"),
+		}
+		failures = map[string]*credentialsFailure{}
+		bases = map[string]*DNSAccount{}
+		released = nil
+		zones = DNSHostedZones{NewDNSHostedZone("test", "z1", "example.com", "", nil, false)}
+	})
+
+	ginkgov2.It("keeps working primary credentials", func() {
+		selected, initial := selectCredentials(logger.New(), refs, 0, accounts["primary"], connect, release)
+		Expect(selected).To(Equal(initial))
+		Expect(selected.index).To(Equal(0))
+		Expect(selected.account).To(BeIdenticalTo(accounts["primary"]))
+		Expect(selected.zones).To(Equal(zones))
+		Expect(bases).To(HaveLen(1))
+		Expect(released).To(BeEmpty())
+	})
+
+	ginkgov2.It("switches to the secondary credentials if the primary ones fail", func() {
+		failures["primary"] = authFailure("primary")
+		last := NewDNSAccount(nil, nil, "last")
+
+		selected, initial := selectCredentials(logger.New(), refs, 0, last, connect, release)
+		Expect(initial.index).To(Equal(0))
+		Expect(initial.failure).To(BeIdenticalTo(failures["primary"]))
+		Expect(selected.index).To(Equal(1))
+		Expect(selected.secret).To(Equal(resources.NewObjectName("default", "secondary")))
+		Expect(selected.account).To(BeIdenticalTo(accounts["secondary"]))
+		Expect(selected.failure).To(BeNil())
+		Expect(selected.zones).To(Equal(zones))
+		Expect(bases).To(HaveKeyWithValue("primary", BeIdenticalTo(last)))
+		Expect(bases).To(HaveKeyWithValue("secondary", BeNil()))
+		Expect(released).To(ConsistOf(BeIdenticalTo(accounts["primary"])))
+	})
+
+	ginkgov2.It("stays with the active secondary credentials", func() {
+		failures["primary"] = authFailure("primary")
+
+		selected, initial := selectCredentials(logger.New(), refs, 1, accounts["secondary"], connect, release)
+		Expect(selected).To(Equal(initial))
+		Expect(selected.index).To(Equal(1))
+		Expect(bases).To(HaveLen(1))
+		Expect(bases).To(HaveKeyWithValue("secondary", BeIdenticalTo(accounts["secondary"])))
+		Expect(released).To(BeEmpty())
+	})
+
+	ginkgov2.It("falls back to the primary credentials if the active secondary ones fail", func() {
+		failures["secondary"] = authFailure("secondary")
+
+		selected, initial := selectCredentials(logger.New(), refs, 1, accounts["secondary"], connect, release)
+		Expect(initial.index).To(Equal(1))
+		Expect(selected.index).To(Equal(0))
+		Expect(selected.account).To(BeIdenticalTo(accounts["primary"]))
+		Expect(bases).To(HaveKeyWithValue("primary", BeNil()))
+		// the last account is released by the provider itself
+		Expect(released).To(BeEmpty())
+	})
+
+	ginkgov2.It("keeps the initial credentials if all of them fail", func() {
+		failures["primary"] = authFailure("primary")
+		failures["secondary"] = authFailure("secondary")
+
+		selected, initial := selectCredentials(logger.New(), refs, 0, accounts["primary"], connect, release)
+		Expect(selected).To(Equal(initial))
+		Expect(selected.index).To(Equal(0))
+		Expect(selected.failure).To(BeIdenticalTo(failures["primary"]))
+		Expect(released).To(ConsistOf(BeIdenticalTo(accounts["secondary"])))
+	})
+
+	ginkgov2.It("does not fail over on other errors", func() {
+		failures["primary"] = &credentialsFailure{err: fmt.Errorf("timeout"), temp: true}
+
+		selected, _ := selectCredentials(logger.New(), refs, 0, accounts["primary"], connect, release)
+		Expect(selected.index).To(Equal(0))
+		Expect(selected.failure).To(BeIdenticalTo(failures["primary"]))
+		Expect(bases).To(HaveLen(1))
+	})
+
+	ginkgov2.It("does not fail over without alternative credentials", func() {
+		failures["primary"] = authFailure("primary")
+
+		selected, _ := selectCredentials(logger.New(), refs[:1], 0, accounts["primary"], connect, release)
+		Expect(selected.index).To(Equal(0))
+		Expect(selected.failure).To(BeIdenticalTo(failures["primary"]))
+		Expect(bases).To(HaveLen(1))
+	})
+
+	ginkgov2.It("switches on an authentication error recorded by the primary account", func() {
+		failures["primary"] = &credentialsFailure{err: fmt.Errorf("recorded"), temp: true, auth: true, recorded: true}
+
+		selected, initial := selectCredentials(logger.New(), refs, 0, accounts["primary"], connect, release)
+		Expect(initial.failure.recorded).To(BeTrue())
+		Expect(selected.index).To(Equal(1))
+		Expect(released).To(BeEmpty())
+	})
+})
+
+var _ = ginkgov2.Describe("Secret references", func() {
+	ginkgov2.It("orders the references and defaults their namespace", func() {
+		p := newTestProvider("test", `{}`)
+		p.Spec().SecretRef = &corev1.SecretReference{Name: "primary"}
+		p.Spec().SecretRefs = []corev1.SecretReference{{Name: "secondary"}, {Namespace: "other", Name: "tertiary"}}
+
+		Expect(secretReferences(p)).To(Equal([]corev1.SecretReference{
+			{Namespace: "default", Name: "primary"},
+			{Namespace: "default", Name: "secondary"},
+			{Namespace: "other", Name: "tertiary"},
+		}))
+	})
+
+	ginkgov2.It("returns no references without secret", func() {
+		Expect(secretReferences(newTestProvider("test", ""))).To(BeNil())
+	})
+})
@@ -0,0 +1,168 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// CREDENTIAL_SOURCE is the secret property selecting the credential source used to obtain
+// the credentials of a provider. Without it, the secret properties are used as credentials.
+const CREDENTIAL_SOURCE = "CREDENTIAL_SOURCE"
+
+// minCredentialsRefresh is the minimum delay of the refresh of short-lived credentials.
+const minCredentialsRefresh = 10 * time.Second
+
+// CredentialSource obtains the credentials of a provider from an external secret store.
+type CredentialSource interface {
+	// Name returns the name used in the secret property CREDENTIAL_SOURCE.
+	Name() string
+	// Resolve returns the credentials for the configuration given by the secret properties.
+	Resolve(ctx context.Context, props utils.Properties) (*ResolvedCredentials, error)
+}
+
+// ResolvedCredentials are the credentials obtained from a credential source.
+type ResolvedCredentials struct {
+	// Properties are the credential properties, they overwrite the properties of the secret.
+	Properties utils.Properties
+	// Expiry is the end of the validity of short-lived credentials, zero for static ones.
+	Expiry time.Time
+}
+
+var credentialSources = struct {
+	lock    sync.Mutex
+	sources map[string]CredentialSource
+}{sources: map[string]CredentialSource{}}
+
+// RegisterCredentialSource registers a credential source under its name.
+func RegisterCredentialSource(source CredentialSource) {
+	credentialSources.lock.Lock()
+	defer credentialSources.lock.Unlock()
+	credentialSources.sources[source.Name()] = source
+}
+
+// GetCredentialSource returns the registered credential source with the given name or nil.
+func GetCredentialSource(name string) CredentialSource {
+	credentialSources.lock.Lock()
+	defer credentialSources.lock.Unlock()
+	return credentialSources.sources[name]
+}
+
+type resolvedCredentialsEntry struct {
+	secretHash string
+	props      utils.Properties
+	refresh    time.Time
+	expiry     time.Time
+}
+
+// credentialsCache keeps the credentials obtained for a secret until they have to be refreshed,
+// so that reconciliations of a provider do not request new credentials every time.
+type credentialsCache struct {
+	lock    sync.Mutex
+	entries map[resources.ObjectName]*resolvedCredentialsEntry
+	now     func() time.Time
+}
+
+func newCredentialsCache() *credentialsCache {
+	return &credentialsCache{entries: map[resources.ObjectName]*resolvedCredentialsEntry{}, now: time.Now}
+}
+
+// Resolve returns the credentials for the properties of a secret. If the secret selects a credential source,
+// the credentials are obtained from it and cached until two thirds of their validity have passed.
+// If a refresh fails, the cached credentials are used until they expire.
+func (this *credentialsCache) Resolve(ctx context.Context, secret resources.ObjectName, props utils.Properties) (utils.Properties, error) {
+	name := props[CREDENTIAL_SOURCE]
+	if name == "" {
+		this.Forget(secret)
+		return props, nil
+	}
+	source := GetCredentialSource(name)
+	if source == nil {
+		return nil, fmt.Errorf("unknown credential source %q", name)
+	}
+
+	h := sha256.New224()
+	writeSortedMap(h, props)
+	hash := hex.EncodeToString(h.Sum(nil))
+	now := this.now()
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	last := this.entries[secret]
+	if last != nil && last.secretHash != hash {
+		last = nil
+	}
+	if last != nil && (last.refresh.IsZero() || now.Before(last.refresh)) {
+		return last.props, nil
+	}
+	creds, err := source.Resolve(ctx, props)
+	if err != nil {
+		if last != nil && now.Before(last.expiry) {
+			// keep the still valid credentials and retry soon
+			last.refresh = now.Add(minCredentialsRefresh)
+			return last.props, nil
+		}
+		return nil, fmt.Errorf("credential source %s: %w", name, err)
+	}
+	result := utils.Properties{}
+	for k, v := range props {
+		if k != CREDENTIAL_SOURCE {
+			result[k] = v
+		}
+	}
+	for k, v := range creds.Properties {
+		result[k] = v
+	}
+	e := &resolvedCredentialsEntry{secretHash: hash, props: result, expiry: creds.Expiry}
+	if !creds.Expiry.IsZero() {
+		e.refresh = now.Add(creds.Expiry.Sub(now) * 2 / 3)
+	}
+	this.entries[secret] = e
+	return result, nil
+}
+
+// RefreshIn returns the duration until the credentials of the secret have to be refreshed, or zero
+// if the credentials are not short-lived.
+func (this *credentialsCache) RefreshIn(secret resources.ObjectName) time.Duration {
+	if secret == nil {
+		return 0
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	e := this.entries[secret]
+	if e == nil || e.refresh.IsZero() {
+		return 0
+	}
+	if d := e.refresh.Sub(this.now()); d > minCredentialsRefresh {
+		return d
+	}
+	return minCredentialsRefresh
+}
+
+// Forget removes the cached credentials of a secret.
+func (this *credentialsCache) Forget(secret resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.entries, secret)
+}
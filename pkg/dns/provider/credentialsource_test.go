@@ -0,0 +1,113 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type testCredentialSource struct {
+	calls int
+	ttl   time.Duration
+	err   error
+}
+
+func (s *testCredentialSource) Name() string {
+	return "test"
+}
+
+func (s *testCredentialSource) Resolve(_ context.Context, props utils.Properties) (*ResolvedCredentials, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.calls++
+	return &ResolvedCredentials{
+		Properties: utils.Properties{"KEY": fmt.Sprintf("%s-%d", props["ROLE"], s.calls)},
+		Expiry:     testNow.Add(s.ttl),
+	}, nil
+}
+
+var testNow = time.Date(2022, 7, 1, 12, 0, 0, 0, time.UTC)
+
+var _ = ginkgov2.Describe("Credential sources", func() {
+	var (
+		source *testCredentialSource
+		cache  *credentialsCache
+		now    time.Time
+		secret = resources.NewObjectName("default", "vault")
+		props  = utils.Properties{CREDENTIAL_SOURCE: "test", "ROLE": "dns", "REGION": "eu"}
+	)
+
+	ginkgov2.BeforeEach(func() {
+		source = &testCredentialSource{ttl: 30 * time.Minute}
+		RegisterCredentialSource(source)
+		now = testNow
+		cache = newCredentialsCache()
+		cache.now = func() time.Time { return now }
+	})
+
+	ginkgov2.It("uses the secret properties without credential source", func() {
+		plain := utils.Properties{"KEY": "static"}
+		result, err := cache.Resolve(context.Background(), secret, plain)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(plain))
+		Expect(cache.RefreshIn(secret)).To(BeZero())
+	})
+
+	ginkgov2.It("caches the credentials until two thirds of their validity have passed", func() {
+		result, err := cache.Resolve(context.Background(), secret, props)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(utils.Properties{"KEY": "dns-1", "ROLE": "dns", "REGION": "eu"}))
+		Expect(cache.RefreshIn(secret)).To(Equal(20 * time.Minute))
+
+		now = now.Add(10 * time.Minute)
+		result, _ = cache.Resolve(context.Background(), secret, props)
+		Expect(result["KEY"]).To(Equal("dns-1"))
+
+		now = now.Add(10 * time.Minute)
+		result, _ = cache.Resolve(context.Background(), secret, props)
+		Expect(result["KEY"]).To(Equal("dns-2"))
+	})
+
+	ginkgov2.It("keeps valid credentials if the refresh fails", func() {
+		_, err := cache.Resolve(context.Background(), secret, props)
+		Expect(err).NotTo(HaveOccurred())
+
+		source.err = fmt.Errorf("sealed")
+		now = now.Add(25 * time.Minute)
+		result, err := cache.Resolve(context.Background(), secret, props)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result["KEY"]).To(Equal("dns-1"))
+		Expect(cache.RefreshIn(secret)).To(Equal(minCredentialsRefresh))
+
+		now = now.Add(10 * time.Minute)
+		_, err = cache.Resolve(context.Background(), secret, props)
+		Expect(err).To(HaveOccurred())
+	})
+
+	ginkgov2.It("rejects unknown credential sources", func() {
+		_, err := cache.Resolve(context.Background(), secret, utils.Properties{CREDENTIAL_SOURCE: "unknown"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -26,9 +27,9 @@ import (
 )
 
 type DedicatedDNSAccess interface {
-	GetRecordSet(zone DNSHostedZone, dnsName, recordType string) (DedicatedRecordSet, error)
-	CreateOrUpdateRecordSet(logger logger.LogContext, zone DNSHostedZone, old, new DedicatedRecordSet) error
-	DeleteRecordSet(logger logger.LogContext, zone DNSHostedZone, rs DedicatedRecordSet) error
+	GetRecordSet(ctx context.Context, zone DNSHostedZone, dnsName, recordType string) (DedicatedRecordSet, error)
+	CreateOrUpdateRecordSet(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, old, new DedicatedRecordSet) error
+	DeleteRecordSet(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, rs DedicatedRecordSet) error
 }
 
 type DedicatedRecord interface {
@@ -26,15 +26,27 @@ import (
 ////////////////////////////////////////////////////////////////////////////////
 
 type DefaultDNSZoneState struct {
-	sets dns.DNSSets
+	sets  dns.DNSSets
+	stale bool
 }
 
 func (this *DefaultDNSZoneState) GetDNSSets() dns.DNSSets {
 	return this.sets
 }
 
+// IsStale returns true if the zone state is an expired cached state served after a failed revalidation.
+func (this *DefaultDNSZoneState) IsStale() bool {
+	return this.stale
+}
+
 func NewDNSZoneState(sets dns.DNSSets) DNSZoneState {
-	return &DefaultDNSZoneState{sets}
+	return &DefaultDNSZoneState{sets: sets}
+}
+
+// IsStaleZoneState checks whether a zone state is an expired cached state.
+func IsStaleZoneState(state DNSZoneState) bool {
+	s, ok := state.(interface{ IsStale() bool })
+	return ok && s.IsStale()
 }
 
 func (this *DefaultDNSZoneState) Clone() DNSZoneState {
@@ -0,0 +1,256 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+// DNSSECAccess is an optional interface of a DNSHandler
+// managing the DNSSEC signing of hosted zones.
+type DNSSECAccess interface {
+	// GetDNSSECState returns the DNSSEC signing state of the hosted zone.
+	GetDNSSECState(ctx context.Context, zone DNSHostedZone) (*DNSSECState, error)
+	// EnableDNSSEC enables the DNSSEC signing of the hosted zone.
+	EnableDNSSEC(ctx context.Context, logger logger.LogContext, zone DNSHostedZone) error
+}
+
+// DNSSECState is the DNSSEC signing state of a hosted zone reported by a DNSSECAccess.
+type DNSSECState struct {
+	// State is one of the DNSSEC_STATE_* constants of the api package
+	State string
+	// DSRecords are the DS records for the parent zone (key tag, algorithm, digest type and digest)
+	DSRecords []string
+	// Message optionally describes the state
+	Message string
+}
+
+// lookupDSRecords looks up the DS records published for a domain in its parent zone.
+var lookupDSRecords = func(ctx context.Context, domain string) ([]string, error) {
+	servers, err := nameServers("/etc/resolv.conf")
+	if err != nil {
+		return nil, err
+	}
+	return queryDSRecords(ctx, net.JoinHostPort(servers[0], "53"), domain)
+}
+
+// normalizeDSRecord normalizes white space and digest case of a DS record for comparison.
+func normalizeDSRecord(record string) string {
+	fields := strings.Fields(record)
+	if len(fields) == 4 {
+		fields[3] = strings.ToUpper(fields[3])
+	}
+	return strings.Join(fields, " ")
+}
+
+// isDSRecordPublished checks whether one of the DS records of the provider is published.
+func isDSRecordPublished(records, published []string) bool {
+	for _, r := range records {
+		for _, p := range published {
+			if normalizeDSRecord(r) == normalizeDSRecord(p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type providerDNSSEC struct {
+	provider *dnsProviderVersion
+	access   DNSSECAccess
+	zones    DNSHostedZones
+	enabled  bool
+}
+
+func (this *state) getProviderDNSSEC() []*providerDNSSEC {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	var result []*providerDNSSEC
+	for _, p := range this.providers {
+		spec := p.object.Spec().DNSSEC
+		enabled := spec != nil && spec.Enabled
+		if !enabled && len(p.object.Status().DNSSEC) == 0 && meta.FindStatusCondition(p.object.Status().Conditions, api.CONDITION_DNSSEC_CHAIN_OF_TRUST) == nil {
+			continue
+		}
+		r := &providerDNSSEC{provider: p, access: p.GetDNSSECAccess(), enabled: enabled}
+		for _, z := range p.zones {
+			if p.IncludesZone(z.Id()) {
+				r.zones = append(r.zones, z)
+			}
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// UpdateDNSSEC enables the DNSSEC signing for the zones of all providers with enabled DNSSEC
+// and reports the DNSSEC state and chain of trust in the provider status.
+func (this *state) UpdateDNSSEC(ctx context.Context, logger logger.LogContext) {
+	for _, p := range this.getProviderDNSSEC() {
+		var zones []api.DNSSECZoneStatus
+		var condition *metav1.Condition
+		if p.enabled {
+			for _, zone := range p.zones {
+				zones = append(zones, this.checkDNSSEC(ctx, logger, p, zone))
+			}
+			sort.Slice(zones, func(i, j int) bool { return zones[i].ZoneID < zones[j].ZoneID })
+			condition = chainOfTrustCondition(p.provider.object.DNSProvider().Generation, zones)
+		}
+		if err := p.provider.updateDNSSECStatus(zones, condition); err != nil {
+			logger.Warnf("cannot update DNSSEC status of provider %s: %s", p.provider.ObjectName(), err)
+		}
+	}
+}
+
+func (this *state) checkDNSSEC(ctx context.Context, logger logger.LogContext, p *providerDNSSEC, zone DNSHostedZone) api.DNSSECZoneStatus {
+	status := api.DNSSECZoneStatus{ZoneID: zone.Id().ID, Domain: zone.Domain()}
+	if p.access == nil {
+		status.State = api.DNSSEC_STATE_ERROR
+		status.Message = fmt.Sprintf("DNSSEC not supported by provider type %s", p.provider.TypeCode())
+		return status
+	}
+	state, err := p.access.GetDNSSECState(ctx, zone)
+	if err != nil {
+		status.State = api.DNSSEC_STATE_ERROR
+		status.Message = fmt.Sprintf("cannot get DNSSEC state: %s", err)
+		return status
+	}
+	if state.State == api.DNSSEC_STATE_NOT_SIGNING {
+		switch {
+		case p.provider.IsReadOnly():
+			state.Message = "signing not enabled by read-only provider"
+		case IsWriteFrozen():
+			state.Message = "signing not enabled because of global write freeze"
+		default:
+			logger.Infof("enabling DNSSEC signing for zone %s (%s)", zone.Id(), zone.Domain())
+			if err := p.access.EnableDNSSEC(ctx, logger, zone); err != nil {
+				status.State = api.DNSSEC_STATE_ERROR
+				status.Message = fmt.Sprintf("cannot enable DNSSEC signing: %s", err)
+				return status
+			}
+			p.provider.object.Eventf(corev1.EventTypeNormal, "dnssec", "DNSSEC signing enabled for zone %s (%s)", zone.Id().ID, zone.Domain())
+			state, err = p.access.GetDNSSECState(ctx, zone)
+			if err != nil {
+				status.State = api.DNSSEC_STATE_PENDING
+				status.Message = "signing enabled"
+				return status
+			}
+		}
+	}
+	status.State = state.State
+	status.Message = state.Message
+	status.DSRecords = state.DSRecords
+	if state.State == api.DNSSEC_STATE_SIGNING && len(state.DSRecords) > 0 {
+		published, err := lookupDSRecords(ctx, zone.Domain())
+		if err != nil {
+			status.Message = fmt.Sprintf("cannot look up DS records in parent zone: %s", err)
+		} else {
+			status.ChainOfTrust = isDSRecordPublished(state.DSRecords, published)
+		}
+	}
+	return status
+}
+
+// chainOfTrustCondition calculates the chain of trust condition for the DNSSEC state of the zones.
+func chainOfTrustCondition(generation int64, zones []api.DNSSECZoneStatus) *metav1.Condition {
+	condition := &metav1.Condition{
+		Type:               api.CONDITION_DNSSEC_CHAIN_OF_TRUST,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: generation,
+		Reason:             "DSRecordsPublished",
+		Message:            "DS records of all signed zones are published in the parent zones",
+	}
+	var failed, pending, missing []string
+	for _, z := range zones {
+		switch {
+		case z.State == api.DNSSEC_STATE_ERROR || z.State == api.DNSSEC_STATE_NOT_SIGNING:
+			failed = append(failed, z.Domain)
+		case z.State == api.DNSSEC_STATE_PENDING || len(z.DSRecords) == 0:
+			pending = append(pending, z.Domain)
+		case !z.ChainOfTrust:
+			missing = append(missing, z.Domain)
+		}
+	}
+	switch {
+	case len(failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "SigningFailed"
+		condition.Message = fmt.Sprintf("DNSSEC signing not active for zones %s", strings.Join(failed, ", "))
+	case len(missing) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "DSRecordMissing"
+		condition.Message = fmt.Sprintf("DS records not published in parent zones of %s", strings.Join(missing, ", "))
+	case len(pending) > 0:
+		condition.Status = metav1.ConditionUnknown
+		condition.Reason = "SigningPending"
+		condition.Message = fmt.Sprintf("DNSSEC signing pending for zones %s", strings.Join(pending, ", "))
+	}
+	return condition
+}
+
+// updateDNSSECStatus updates the DNSSEC zone states and the chain of trust condition.
+// A nil condition removes the condition.
+func (this *dnsProviderVersion) updateDNSSECStatus(zones []api.DNSSECZoneStatus, condition *metav1.Condition) error {
+	broken := false
+	_, err := this.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSProvider).Status
+		mod := false
+		if !reflect.DeepEqual(status.DNSSEC, zones) {
+			status.DNSSEC = zones
+			mod = true
+		}
+		old := meta.FindStatusCondition(status.Conditions, api.CONDITION_DNSSEC_CHAIN_OF_TRUST)
+		switch {
+		case condition == nil:
+			if old != nil {
+				meta.RemoveStatusCondition(&status.Conditions, api.CONDITION_DNSSEC_CHAIN_OF_TRUST)
+				mod = true
+			}
+		case old == nil || old.Status != condition.Status || old.Reason != condition.Reason ||
+			old.Message != condition.Message || old.ObservedGeneration != condition.ObservedGeneration:
+			broken = condition.Status == metav1.ConditionFalse && (old == nil || old.Status != metav1.ConditionFalse)
+			meta.SetStatusCondition(&status.Conditions, *condition)
+			mod = true
+		}
+		return mod, nil
+	})
+	if err == nil && broken {
+		this.object.Eventf(corev1.EventTypeWarning, "dnssec", "DNSSEC chain of trust broken: %s", condition.Message)
+	}
+	return err
+}
+
+func (this *dnsProviderVersion) GetDNSSECAccess() DNSSECAccess {
+	if this.account == nil {
+		return nil
+	}
+	h, _ := this.account.handler.(DNSSECAccess)
+	return h
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"net"
+
+	miekgdns "github.com/miekg/dns"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("DNSSEC", func() {
+	signed := api.DNSSECZoneStatus{ZoneID: "z1", Domain: "a.example.com", State: api.DNSSEC_STATE_SIGNING,
+		DSRecords: []string{"12345 13 2 abcdef"}, ChainOfTrust: true}
+
+	ginkgov2.It("compares DS records case insensitive", func() {
+		Expect(isDSRecordPublished([]string{"12345 13 2 abcdef"}, []string{"1 8 2 AA", "12345  13 2 ABCDEF"})).To(BeTrue())
+		Expect(isDSRecordPublished([]string{"12345 13 2 abcdef"}, []string{"12345 13 2 ABCDEE"})).To(BeFalse())
+		Expect(isDSRecordPublished([]string{"12345 13 2 abcdef"}, nil)).To(BeFalse())
+	})
+
+	ginkgov2.Context("DS lookup", func() {
+		var (
+			server *miekgdns.Server
+			addr   string
+		)
+
+		ginkgov2.BeforeEach(func() {
+			conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			addr = conn.LocalAddr().String()
+			started := make(chan struct{})
+			server = &miekgdns.Server{PacketConn: conn, NotifyStartedFunc: func() { close(started) },
+				Handler: miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+					resp := new(miekgdns.Msg)
+					resp.SetReply(req)
+					switch req.Question[0].Name {
+					case "a.example.com.":
+						rr, _ := miekgdns.NewRR("a.example.com. 300 IN DS 12345 13 2 abcdef")
+						resp.Answer = append(resp.Answer, rr)
+						rr, _ = miekgdns.NewRR("a.example.com. 300 IN RRSIG DS 13 3 300 20300101000000 20200101000000 1 example.com. AAAA")
+						resp.Answer = append(resp.Answer, rr)
+					case "unsigned.example.com.":
+					case "failed.example.com.":
+						resp.Rcode = miekgdns.RcodeServerFailure
+					default:
+						resp.Rcode = miekgdns.RcodeNameError
+					}
+					_ = w.WriteMsg(resp)
+				})}
+			go server.ActivateAndServe()
+			<-started
+		})
+
+		ginkgov2.AfterEach(func() {
+			server.Shutdown()
+		})
+
+		ginkgov2.It("parses DS records of a DNS response", func() {
+			records, err := queryDSRecords(context.TODO(), addr, "a.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(Equal([]string{"12345 13 2 ABCDEF"}))
+		})
+
+		ginkgov2.It("returns no records for domains without DS records", func() {
+			records, err := queryDSRecords(context.TODO(), addr, "unsigned.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+
+			records, err = queryDSRecords(context.TODO(), addr, "unknown.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		ginkgov2.It("reports failed lookups", func() {
+			_, err := queryDSRecords(context.TODO(), addr, "failed.example.com")
+			Expect(err).To(MatchError(ContainSubstring("SERVFAIL")))
+
+			_, err = queryDSRecords(context.TODO(), addr, "a..example.com")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	ginkgov2.It("reports an established chain of trust", func() {
+		c := chainOfTrustCondition(3, []api.DNSSECZoneStatus{signed})
+		Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		Expect(c.ObservedGeneration).To(Equal(int64(3)))
+	})
+
+	ginkgov2.It("reports missing DS records and failed signing", func() {
+		missing := signed
+		missing.Domain = "b.example.com"
+		missing.ChainOfTrust = false
+		c := chainOfTrustCondition(1, []api.DNSSECZoneStatus{signed, missing})
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal("DSRecordMissing"))
+		Expect(c.Message).To(ContainSubstring("b.example.com"))
+
+		failed := api.DNSSECZoneStatus{ZoneID: "z3", Domain: "c.example.com", State: api.DNSSEC_STATE_ERROR}
+		c = chainOfTrustCondition(1, []api.DNSSECZoneStatus{missing, failed})
+		Expect(c.Reason).To(Equal("SigningFailed"))
+	})
+
+	ginkgov2.It("reports pending signing as unknown", func() {
+		pending := api.DNSSECZoneStatus{ZoneID: "z2", Domain: "b.example.com", State: api.DNSSEC_STATE_PENDING}
+		c := chainOfTrustCondition(1, []api.DNSSECZoneStatus{signed, pending})
+		Expect(c.Status).To(Equal(metav1.ConditionUnknown))
+	})
+})
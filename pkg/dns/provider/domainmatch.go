@@ -0,0 +1,388 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+	"github.com/gardener/controller-manager-library/pkg/server"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// Decisions of the domain matching of a provider
+const (
+	// MATCH_INCLUDED is used for providers including the DNS name
+	MATCH_INCLUDED = "Included"
+	// MATCH_EXCLUDED_BY_FILTER is used for providers excluding the DNS name by their domain selection
+	MATCH_EXCLUDED_BY_FILTER = "ExcludedByFilter"
+	// MATCH_FORWARDED is used for providers excluding the DNS name as part of a forwarded subdomain of a zone
+	MATCH_FORWARDED = "ForwardedDomain"
+	// MATCH_ZONE_ONLY is used for providers with a zone matching the DNS name, which is not included by their domains
+	MATCH_ZONE_ONLY = "ZoneOnly"
+	// MATCH_NOT_INCLUDED is used for providers not responsible for the DNS name
+	MATCH_NOT_INCLUDED = "NotIncluded"
+)
+
+// Decisions of the zone matching
+const (
+	// ZONE_MATCH_SELECTED is used for the zone selected for the DNS name
+	ZONE_MATCH_SELECTED = "Selected"
+	// ZONE_MATCH_FORWARDED is used for zones delegating a subdomain containing the DNS name
+	ZONE_MATCH_FORWARDED = "Forwarded"
+	// ZONE_MATCH_OTHER_PROVIDER is used for longest matching zones not included by the selected provider
+	ZONE_MATCH_OTHER_PROVIDER = "OtherProvider"
+	// ZONE_MATCH_SHORTER is used for zones with a shorter matching domain
+	ZONE_MATCH_SHORTER = "ShorterMatch"
+)
+
+// DomainMatchExplanation explains the selection of the provider and the zone for a DNS name.
+type DomainMatchExplanation struct {
+	DNSName   string `json:"dnsName"`
+	Namespace string `json:"namespace,omitempty"`
+	Entry     string `json:"entry,omitempty"`
+	// Providers are the candidate providers ordered by their match length
+	Providers []*ProviderMatchExplanation `json:"providers"`
+	// Zones are the zones with a domain matching the DNS name
+	Zones            []*ZoneMatchExplanation `json:"zones"`
+	SelectedProvider string                  `json:"selectedProvider,omitempty"`
+	SelectedZone     string                  `json:"selectedZone,omitempty"`
+	Result           string                  `json:"result"`
+}
+
+// ProviderMatchExplanation explains the domain matching of a single provider.
+type ProviderMatchExplanation struct {
+	Provider string `json:"provider"`
+	Type     string `json:"type"`
+	Valid    bool   `json:"valid"`
+	Decision string `json:"decision"`
+	// Domain is the included or excluded domain deciding the match
+	Domain string `json:"domain,omitempty"`
+	// MatchLength is the length of the included domain used to rank the providers
+	MatchLength         int    `json:"matchLength,omitempty"`
+	DefaultForNamespace bool   `json:"defaultForNamespace,omitempty"`
+	AccessDenied        string `json:"accessDenied,omitempty"`
+}
+
+// ZoneMatchExplanation explains the matching of a hosted zone.
+type ZoneMatchExplanation struct {
+	Zone      string   `json:"zone"`
+	Domain    string   `json:"domain"`
+	Providers []string `json:"providers,omitempty"`
+	// ForwardedDomain is the forwarded domain of the zone containing the DNS name
+	ForwardedDomain string `json:"forwardedDomain,omitempty"`
+	Decision        string `json:"decision"`
+}
+
+// explainProviderMatch explains the domain selection of a provider for a DNS name.
+func explainProviderMatch(p *dnsProviderVersion, dnsname string) *ProviderMatchExplanation {
+	result := &ProviderMatchExplanation{
+		Provider: p.ObjectName().String(),
+		Type:     p.TypeCode(),
+		Valid:    p.IsValid(),
+	}
+	result.Decision, result.Domain = p.matchDecision(dnsname)
+	if result.Decision == MATCH_INCLUDED {
+		result.MatchLength = len(result.Domain)
+	}
+	return result
+}
+
+// matchDecision determines the decision of the domain selection of the provider for a DNS name
+// together with the deciding included or excluded domain.
+func (this *dnsProviderVersion) matchDecision(dnsname string) (string, string) {
+	included := longestMatch(dnsname, this.included)
+	excluded := longestMatch(dnsname, this.excluded)
+	switch {
+	case included != "" && len(included) > len(excluded):
+		return MATCH_INCLUDED, included
+	case included != "":
+		if !this.def_exclude.Contains(excluded) && isForwardedDomain(this.zones, excluded) {
+			return MATCH_FORWARDED, excluded
+		}
+		return MATCH_EXCLUDED_BY_FILTER, excluded
+	case this.MatchZone(dnsname) > 0:
+		return MATCH_ZONE_ONLY, ""
+	}
+	return MATCH_NOT_INCLUDED, ""
+}
+
+func longestMatch(dnsname string, domains utils.StringSet) string {
+	found := ""
+	for d := range domains {
+		if len(d) > len(found) && dnsutils.Match(dnsname, d) {
+			found = d
+		}
+	}
+	return found
+}
+
+func isForwardedDomain(zones DNSHostedZones, domain string) bool {
+	for _, z := range zones {
+		for _, f := range z.ForwardedDomains() {
+			if f == domain {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ExplainDomainMatch explains the selection of the provider and the zone for a DNS name in a namespace.
+// If the name of an entry is given instead, its DNS name and namespace are used and the access of the
+// entry to the providers is checked, too.
+func (this *state) ExplainDomainMatch(dnsname, namespace string, entry resources.ObjectName) (*DomainMatchExplanation, error) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	checkAccess := func(p *dnsProviderVersion) error { return nil }
+	current := ""
+	result := &DomainMatchExplanation{}
+	if entry != nil {
+		e := this.entries[entry]
+		if e == nil {
+			return nil, fmt.Errorf("entry %s not found", entry)
+		}
+		obj := e.Object()
+		dnsname, namespace = obj.GetDNSName(), obj.GetNamespace()
+		current = utils.StringValue(obj.BaseStatus().Provider)
+		checkAccess = func(p *dnsProviderVersion) error {
+			return access.CheckAccessWithRealms(obj, "use", p.Object(), this.realms)
+		}
+		result.Entry = entry.String()
+	}
+	dnsname = strings.TrimSuffix(strings.ToLower(dnsname), ".")
+	if dnsname == "" {
+		return nil, fmt.Errorf("dns name required")
+	}
+	result.DNSName, result.Namespace = dnsname, namespace
+
+	nslabels := this.namespaceLabelsForDefaultProviders(namespace)
+	for _, p := range this.providers {
+		if decision, _ := p.matchDecision(dnsname); decision == MATCH_NOT_INCLUDED {
+			continue
+		}
+		m := explainProviderMatch(p, dnsname)
+		m.DefaultForNamespace = p.isDefaultForNamespace(nslabels)
+		if err := checkAccess(p); err != nil {
+			m.AccessDenied = err.Error()
+		}
+		result.Providers = append(result.Providers, m)
+	}
+	sort.Slice(result.Providers, func(i, j int) bool {
+		if result.Providers[i].MatchLength != result.Providers[j].MatchLength {
+			return result.Providers[i].MatchLength > result.Providers[j].MatchLength
+		}
+		return result.Providers[i].Provider < result.Providers[j].Provider
+	})
+
+	provider, fallback, err := this.lookupProviderForName(dnsname, namespace, current, checkAccess)
+	if provider != nil {
+		result.SelectedProvider = provider.ObjectName().String()
+	}
+	selected := this.getProviderZoneForName(dnsname, provider)
+	if selected == nil && provider == nil {
+		selected = this.getProviderZoneForName(dnsname, fallback)
+	}
+	if selected != nil {
+		result.SelectedZone = selected.Id().String()
+	}
+	longest := this.getZonesForName(dnsname)
+	for _, z := range this.zones {
+		if !dnsutils.Match(dnsname, z.Domain()) {
+			continue
+		}
+		zm := &ZoneMatchExplanation{Zone: z.Id().String(), Domain: z.Domain(), Decision: ZONE_MATCH_SHORTER}
+		for n := range this.zoneproviders[z.Id()] {
+			zm.Providers = append(zm.Providers, n.String())
+		}
+		sort.Strings(zm.Providers)
+		for _, f := range z.ForwardedDomains() {
+			if dnsutils.Match(dnsname, f) {
+				zm.ForwardedDomain = f
+			}
+		}
+		switch {
+		case selected != nil && z.Id() == selected.Id():
+			zm.Decision = ZONE_MATCH_SELECTED
+		case zm.ForwardedDomain != "":
+			zm.Decision = ZONE_MATCH_FORWARDED
+		case containsZone(longest, z):
+			zm.Decision = ZONE_MATCH_OTHER_PROVIDER
+		}
+		result.Zones = append(result.Zones, zm)
+	}
+	sort.Slice(result.Zones, func(i, j int) bool {
+		if len(result.Zones[i].Domain) != len(result.Zones[j].Domain) {
+			return len(result.Zones[i].Domain) > len(result.Zones[j].Domain)
+		}
+		return result.Zones[i].Zone < result.Zones[j].Zone
+	})
+
+	switch {
+	case provider == nil && fallback != nil && selected != nil:
+		result.Result = fmt.Sprintf("no provider includes the domain, zone %s of provider %s matches", selected.Id(), fallback.ObjectName())
+	case provider == nil && err != nil:
+		result.Result = fmt.Sprintf("no matching provider: %s", err)
+	case provider == nil:
+		result.Result = "no matching provider"
+	case !provider.IsValid():
+		result.Result = fmt.Sprintf("provider %s matches, but is not valid", provider.ObjectName())
+	case selected == nil:
+		result.Result = fmt.Sprintf("provider %s matches, but no zone of the provider matches", provider.ObjectName())
+	default:
+		result.Result = fmt.Sprintf("provider %s, zone %s", provider.ObjectName(), selected.Id())
+	}
+	return result, nil
+}
+
+func containsZone(zones []*dnsHostedZone, zone *dnsHostedZone) bool {
+	for _, z := range zones {
+		if z == zone {
+			return true
+		}
+	}
+	return false
+}
+
+// domainMatchEndpoint serves the explanations of the domain matching of the states of the compound controllers.
+type domainMatchEndpoint struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+var theDomainMatchEndpoint = &domainMatchEndpoint{}
+
+func init() {
+	server.RegisterHandler("/debug/domainmatch", http.HandlerFunc(serveDomainMatch))
+}
+
+func enableDomainMatchEndpoint(state *state) {
+	theDomainMatchEndpoint.lock.Lock()
+	defer theDomainMatchEndpoint.lock.Unlock()
+	theDomainMatchEndpoint.states = append(theDomainMatchEndpoint.states, state)
+}
+
+// serveDomainMatch explains the domain matching for the query parameter `name` (optionally with `namespace`)
+// or for the entry given by the query parameter `entry` (`<namespace>/<name>`). With `format=text` the
+// explanation is returned as plain text.
+func serveDomainMatch(w http.ResponseWriter, r *http.Request) {
+	theDomainMatchEndpoint.lock.Lock()
+	states := append([]*state{}, theDomainMatchEndpoint.states...)
+	theDomainMatchEndpoint.lock.Unlock()
+	if len(states) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	var entry resources.ObjectName
+	if e := strings.TrimSpace(query.Get("entry")); e != "" {
+		parts := strings.Split(e, "/")
+		if len(parts) != 2 {
+			http.Error(w, "entry must be given as <namespace>/<name>", http.StatusBadRequest)
+			return
+		}
+		entry = resources.NewObjectName(parts[0], parts[1])
+	}
+	results := []*DomainMatchExplanation{}
+	for _, s := range states {
+		result, err := s.ExplainDomainMatch(query.Get("name"), query.Get("namespace"), entry)
+		if err != nil {
+			if entry != nil && len(states) > 1 {
+				continue
+			}
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		http.Error(w, fmt.Sprintf("entry %s not found", entry), http.StatusNotFound)
+		return
+	}
+
+	if query.Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, result := range results {
+			writeDomainMatchText(w, result)
+		}
+		return
+	}
+	var data []byte
+	var err error
+	if len(results) == 1 {
+		data, err = json.MarshalIndent(results[0], "", "  ")
+	} else {
+		data, err = json.MarshalIndent(results, "", "  ")
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeDomainMatchText(w http.ResponseWriter, result *DomainMatchExplanation) {
+	fmt.Fprintf(w, "dns name:  %s\n", result.DNSName)
+	if result.Entry != "" {
+		fmt.Fprintf(w, "entry:     %s\n", result.Entry)
+	}
+	if result.Namespace != "" {
+		fmt.Fprintf(w, "namespace: %s\n", result.Namespace)
+	}
+	fmt.Fprintf(w, "providers:\n")
+	for _, p := range result.Providers {
+		line := fmt.Sprintf("  %s (%s): %s", p.Provider, p.Type, p.Decision)
+		if p.Domain != "" {
+			line += " " + p.Domain
+		}
+		if !p.Valid {
+			line += ", not valid"
+		}
+		if p.DefaultForNamespace {
+			line += ", default for namespace"
+		}
+		if p.AccessDenied != "" {
+			line += ", access denied: " + p.AccessDenied
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "zones:\n")
+	for _, z := range result.Zones {
+		line := fmt.Sprintf("  %s (%s): %s", z.Zone, z.Domain, z.Decision)
+		if z.ForwardedDomain != "" {
+			line += " " + z.ForwardedDomain
+		}
+		fmt.Fprintln(w, line)
+	}
+	fmt.Fprintf(w, "result:    %s\n\n", result.Result)
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Domain match explanation", func() {
+	p := &dnsProviderVersion{
+		zones: DNSHostedZones{
+			NewDNSHostedZone("test", "z1", "example.com", "", []string{"sub.example.com"}, false),
+			NewDNSHostedZone("test", "z2", "other.com", "", nil, false),
+		},
+		def_exclude: utils.NewStringSet("private.example.com"),
+		included:    utils.NewStringSet("example.com"),
+		excluded:    utils.NewStringSet("private.example.com", "sub.example.com"),
+	}
+
+	ginkgov2.It("explains the decisions of the domain selection", func() {
+		for name, expected := range map[string][2]string{
+			"www.example.com":         {MATCH_INCLUDED, "example.com"},
+			"a.private.example.com":   {MATCH_EXCLUDED_BY_FILTER, "private.example.com"},
+			"a.sub.example.com":       {MATCH_FORWARDED, "sub.example.com"},
+			"www.other.com":           {MATCH_ZONE_ONLY, ""},
+			"www.example.org":         {MATCH_NOT_INCLUDED, ""},
+			"www.notexample.com":      {MATCH_NOT_INCLUDED, ""},
+			"sub.example.com.example": {MATCH_NOT_INCLUDED, ""},
+		} {
+			decision, domain := p.matchDecision(name)
+			Expect([2]string{decision, domain}).To(Equal(expected), name)
+		}
+	})
+})
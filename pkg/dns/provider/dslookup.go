@@ -0,0 +1,89 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	miekgdns "github.com/miekg/dns"
+)
+
+// nameServers reads the name servers configured in a resolv.conf file.
+func nameServers(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var servers []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, fields[1])
+		}
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no name servers configured in %s", path)
+	}
+	return servers, scanner.Err()
+}
+
+// queryDSRecords queries the DS records of a domain from a recursive name server.
+// The records are returned in presentation format without owner name (key tag, algorithm, digest type and digest).
+func queryDSRecords(ctx context.Context, server, domain string) ([]string, error) {
+	if _, ok := miekgdns.IsDomainName(domain); !ok {
+		return nil, fmt.Errorf("invalid domain name %q", domain)
+	}
+	msg := new(miekgdns.Msg)
+	msg.SetQuestion(miekgdns.Fqdn(domain), miekgdns.TypeDS)
+	msg.RecursionDesired = true
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	client := &miekgdns.Client{}
+	resp, _, err := client.ExchangeContext(ctx, msg, server)
+	if err == nil && resp.Truncated {
+		client.Net = "tcp"
+		resp, _, err = client.ExchangeContext(ctx, msg, server)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseDSResponse(resp, domain)
+}
+
+func parseDSResponse(resp *miekgdns.Msg, domain string) ([]string, error) {
+	switch resp.Rcode {
+	case miekgdns.RcodeSuccess:
+	case miekgdns.RcodeNameError:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("DS lookup for %s failed with rcode %s", domain, miekgdns.RcodeToString[resp.Rcode])
+	}
+	var records []string
+	for _, rr := range resp.Answer {
+		if ds, ok := rr.(*miekgdns.DS); ok {
+			records = append(records, fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, strings.ToUpper(ds.Digest)))
+		}
+	}
+	return records, nil
+}
@@ -19,6 +19,7 @@ package provider
 import (
 	"fmt"
 	"net"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -33,11 +34,13 @@ import (
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const MSG_PRESERVED = "errorneous entry preserved in provider"
@@ -51,6 +54,7 @@ type EntryPremise struct {
 
 	// non-identifying fields
 	zonedomain string
+	noProvider *noProviderCause // cause for a missing provider (only set if zoneid is empty)
 }
 
 func (this *EntryPremise) Match(p *EntryPremise) bool {
@@ -87,11 +91,19 @@ type EntryVersion struct {
 
 	status api.DNSBaseStatus
 
+	errorHistorySize int
+	flapCount        int
+	// lastChanges are the record set changes last applied for the entry (old -> new values)
+	lastChanges []string
+
 	interval    int64
 	responsible bool
 	valid       bool
 	duplicate   bool
 	obsolete    bool
+
+	providerCondition *metav1.Condition
+	noProviderReason  string
 }
 
 func NewEntryVersion(object dnsutils.DNSSpecification, old *Entry) *EntryVersion {
@@ -129,6 +141,9 @@ func (this *EntryVersion) RequiresUpdateFor(e *EntryVersion) (reasons []string,
 	if this.OwnerId() != e.OwnerId() {
 		reasons = append(reasons, "ownerid changed")
 	}
+	if this.OwnerGroup() != e.OwnerGroup() {
+		reasons = append(reasons, "owner group changed")
+	}
 	if this.targets.DifferFrom(e.targets) {
 		reasons = append(reasons, "targets changed")
 	}
@@ -192,8 +207,16 @@ func (this *EntryVersion) DNSName() string {
 	return this.dnsname
 }
 
+// SetIdentifier returns the set identifier of the routing policy, if the entry shares its DNS name with other entries.
+func (this *EntryVersion) SetIdentifier() string {
+	if rp := this.object.GetRoutingPolicy(); rp != nil {
+		return rp.SetIdentifier
+	}
+	return ""
+}
+
 func (this *EntryVersion) ZonedDNSName() ZonedDNSName {
-	return ZonedDNSName{ZoneID: this.ZoneId(), DNSName: this.dnsname}
+	return ZonedDNSName{ZoneID: this.ZoneId(), DNSName: this.dnsname, SetIdentifier: this.SetIdentifier()}
 }
 
 func (this *EntryVersion) Targets() Targets {
@@ -231,6 +254,13 @@ func (this *EntryVersion) OwnerId() string {
 	return ""
 }
 
+func (this *EntryVersion) OwnerGroup() string {
+	if this.object.GetOwnerGroup() != nil {
+		return *this.object.GetOwnerGroup()
+	}
+	return ""
+}
+
 type dnsSpecModification struct {
 	dnsutils.DNSSpecification
 	targets []string
@@ -238,6 +268,7 @@ type dnsSpecModification struct {
 	ttl     *int64
 	ownerid *string
 	lookup  *int64
+	records []dnsutils.StructuredRecord
 }
 
 func (this *dnsSpecModification) GetTargets() []string {
@@ -254,6 +285,13 @@ func (this *dnsSpecModification) GetText() []string {
 	return this.DNSSpecification.GetText()
 }
 
+func (this *dnsSpecModification) GetStructuredRecords() ([]dnsutils.StructuredRecord, error) {
+	if this.records != nil {
+		return this.records, nil
+	}
+	return this.DNSSpecification.GetStructuredRecords()
+}
+
 func (this *dnsSpecModification) GetOwnerId() *string {
 	if this.ownerid != nil {
 		return this.ownerid
@@ -276,7 +314,7 @@ func (this *dnsSpecModification) GetTTL() *int64 {
 }
 
 func (this *dnsSpecModification) IsModified() bool {
-	return this.targets != nil || this.text != nil || this.ownerid != nil || this.lookup != nil || this.ttl != nil
+	return this.targets != nil || this.text != nil || this.records != nil || this.ownerid != nil || this.lookup != nil || this.ttl != nil
 }
 
 func complete(logger logger.LogContext, state *state, spec dnsutils.DNSSpecification, object resources.Object, prefix string) (dnsutils.DNSSpecification, error) {
@@ -317,8 +355,15 @@ func complete(logger logger.LogContext, state *state, spec dnsutils.DNSSpecifica
 			err = fmt.Errorf("%stext specified together with entry reference", prefix)
 			return nil, err
 		}
+		if records, err := spec.GetStructuredRecords(); err != nil || len(records) > 0 {
+			return nil, fmt.Errorf("%sstructured records specified together with entry reference", prefix)
+		}
 		mod.targets = rspec.GetTargets()
 		mod.text = rspec.GetText()
+		mod.records, err = rspec.GetStructuredRecords()
+		if err != nil {
+			return nil, err
+		}
 
 		if spec.GetTTL() == nil {
 			mod.ttl = rspec.GetTTL()
@@ -371,6 +416,20 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		return
 	}
 
+	if policy := effspec.GetRoutingPolicy(); policy != nil && policy.Type == "" {
+		err = fmt.Errorf("routing policy type must not be empty")
+		return
+	}
+
+	if ref := strings.TrimSpace(entry.object.GetAnnotations()[dns.TARGET_RESOURCE_ANNOTATION]); ref != "" {
+		if len(effspec.GetTargets()) > 0 || len(effspec.GetText()) > 0 {
+			err = fmt.Errorf("annotation %s cannot be combined with Targets or Text", dns.TARGET_RESOURCE_ANNOTATION)
+			return
+		}
+		targets = append(targets, dnsutils.NewTarget(dns.RS_RESOURCE, ref, entry.TTL()))
+		return
+	}
+
 	for i, t := range effspec.GetTargets() {
 		if strings.TrimSpace(t) == "" {
 			err = fmt.Errorf("target %d must not be empty", i+1)
@@ -379,6 +438,7 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		var new Target
 		new, err = NewHostTargetFromEntryVersion(t, entry)
 		if err != nil {
+			err = fmt.Errorf("target %d: %s", i+1, err)
 			return
 		}
 		if targets.Has(new) {
@@ -388,11 +448,15 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		}
 	}
 	tcnt := 0
-	for _, t := range effspec.GetText() {
+	for i, t := range effspec.GetText() {
 		if t == "" {
 			warnings = append(warnings, fmt.Sprintf("dns entry %q has empty text", entry.ObjectName()))
 			continue
 		}
+		if err = dns.ValidateText(t); err != nil {
+			err = fmt.Errorf("text %d: %s", i+1, err)
+			return
+		}
 		new := dnsutils.NewText(t, entry.TTL())
 		if targets.Has(new) {
 			warnings = append(warnings, fmt.Sprintf("dns entry %q has duplicate text %q", entry.ObjectName(), new))
@@ -405,9 +469,26 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 		err = fmt.Errorf("dns entry has only empty text")
 		return
 	}
+	var records []dnsutils.StructuredRecord
+	records, err = effspec.GetStructuredRecords()
+	if err != nil {
+		return
+	}
+	for _, r := range records {
+		if r.Type == dns.RS_TXT && len(effspec.GetTargets()) > 0 {
+			err = fmt.Errorf("only Text or Targets possible")
+			return
+		}
+		new := dnsutils.NewTarget(r.Type, r.Value, entry.TTL())
+		if targets.Has(new) {
+			warnings = append(warnings, fmt.Sprintf("dns entry %q has duplicate %s record %q", entry.ObjectName(), r.Type, r.Value))
+		} else {
+			targets = append(targets, new)
+		}
+	}
 
 	if len(targets) == 0 {
-		err = fmt.Errorf("no target or text specified")
+		err = fmt.Errorf("no target, text or structured record specified")
 	}
 	return
 }
@@ -428,8 +509,21 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 
 	this.valid = false
 	this.responsible = false
+	this.providerCondition = nil
+	this.noProviderReason = ""
 	spec := this.object
 
+	if p.zoneid == "" && p.noProvider != nil && p.noProvider.awaitsReadiness {
+		// neither reject nor release the entry while a newly created provider is probed
+		hello.Infof(logger, "waiting for readiness of provider %s", p.noProvider.provider)
+		this.setNoProviderCondition(p)
+		err := this.updateStatus(logger, api.STATE_PENDING, "%s", this.providerCondition.Message)
+		if err != nil {
+			return reconcile.Delay(logger, err)
+		}
+		return reconcile.Succeeded(logger)
+	}
+
 	///////////// handle type responsibility
 
 	if !utils.IsEmptyString(this.object.BaseStatus().ProviderType) && p.ptype == "" {
@@ -454,7 +548,10 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 			this.status.ProviderType = nil
 			this.status.Zone = nil
 			msg := "No responsible provider found"
-			if err != nil {
+			if p.noProvider != nil {
+				this.setNoProviderCondition(p)
+				msg = fmt.Sprintf("%s: %s", msg, this.providerCondition.Message)
+			} else if err != nil {
 				msg = fmt.Sprintf("%s: %s", msg, err)
 			}
 			err := this.updateStatus(logger, api.STATE_ERROR, msg)
@@ -477,6 +574,9 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.status.Provider = nil
 		this.status.ProviderType = nil
 		this.status.Zone = nil
+		if p.noProvider != nil {
+			this.setNoProviderCondition(p)
+		}
 		err := this.updateStatus(logger, "", "not valid for known provider anymore -> releasing provider type %s", oldType)
 		if err != nil {
 			return reconcile.Delay(logger, err)
@@ -495,6 +595,8 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.providername = p.provider.ObjectName()
 		provider = p.provider.ObjectName().String()
 		this.status.Provider = &provider
+		this.setProviderCondition(metav1.ConditionTrue, api.PROVIDER_REASON_ASSIGNED,
+			fmt.Sprintf("provider %s responsible for zone %s", provider, p.zoneid))
 		defaultTTL := p.provider.DefaultTTL()
 		this.status.TTL = &defaultTTL
 		if spec.GetTTL() != nil {
@@ -504,6 +606,8 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.providername = nil
 		this.status.Provider = nil
 		this.status.TTL = nil
+		this.setProviderCondition(metav1.ConditionTrue, api.PROVIDER_REASON_ASSIGNED,
+			fmt.Sprintf("provider type %s responsible for zone %s", p.ptype, p.zoneid))
 	}
 
 	///////////// validate
@@ -562,6 +666,12 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 				this.UpdateStatus(logger, state, verr.Error())
 				return reconcile.Recheck(logger, verr, time.Duration(this.interval)*time.Second)
 			}
+		} else if targets.HasResourceReference() {
+			// periodically remap the resource to track address changes
+			this.interval = int64(600)
+			if iv := spec.GetCNameLookupInterval(); iv != nil && *iv > 0 {
+				this.interval = *iv
+			}
 		} else {
 			this.interval = 0
 		}
@@ -615,6 +725,7 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 				AssureStringPtrPtr(&status.Message, this.status.Message).
 				AssureStringPtrPtr(&status.Zone, this.status.Zone).
 				AssureStringPtrPtr(&status.Provider, this.status.Provider)
+			mod.Modify(assureProviderCondition(status, this.providerCondition))
 			if mod.IsModified() {
 				dnsutils.SetLastUpdateTime(&status.LastUptimeTime)
 				logmsg.Infof(logger)
@@ -653,6 +764,7 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 		if utils.StringValue(this.status.Provider) == "" {
 			mod.Modify(o.AcknowledgeTargets(nil))
 		}
+		mod.Modify(assureProviderCondition(status, this.providerCondition))
 		if mod.IsModified() {
 			logmsg.Infof(logger)
 		}
@@ -664,6 +776,19 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 }
 
 func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, msg string) (bool, error) {
+	return this.updateStatusWithReason(logger, state, msg, "")
+}
+
+// UpdateErrorStatus updates the state with the message of the error and records its reason in the error history.
+func (this *EntryVersion) UpdateErrorStatus(logger logger.LogContext, state string, err error) (bool, error) {
+	reason := ""
+	if r := perrs.Reason(err); r != perrs.ReasonUnknown {
+		reason = string(r)
+	}
+	return this.updateStatusWithReason(logger, state, err.Error(), reason)
+}
+
+func (this *EntryVersion) updateStatusWithReason(logger logger.LogContext, state, msg, reason string) (bool, error) {
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
 		if err != nil {
@@ -686,10 +811,19 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 			if this.status.Provider != nil {
 				mod.AssureStringPtrPtr(&b.Provider, this.status.Provider)
 			}
+			if this.lastChanges != nil && !reflect.DeepEqual(b.LastChanges, this.lastChanges) {
+				b.LastChanges = this.lastChanges
+				mod.Modify(true)
+			}
 		} else if state != api.STATE_STALE {
 			mod.Modify(o.AcknowledgeTargets(nil))
 		}
 		mod.AssureInt64Value(&b.ObservedGeneration, o.GetGeneration())
+		mod.Modify(recordError(b, this.errorHistorySize, state, msg, reason))
+		mod.Modify(assureNextAttemptTime(b, time.Time{}))
+		mod.Modify(assurePlannedChanges(b, nil))
+		mod.Modify(assureFlapCount(b, this.flapCount))
+		mod.Modify(assureProviderCondition(b, this.providerCondition))
 		if !(this.status.State == api.STATE_STALE && this.status.State == state) {
 			mod.AssureStringPtrValue(&b.Message, msg)
 			this.status.Message = &msg
@@ -706,6 +840,12 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 }
 
 func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg string) (bool, error) {
+	return this.UpdateDelayedState(logger, state, msg, time.Time{})
+}
+
+// UpdateDelayedState updates state and message and reports the time of the next attempt
+// to apply the entry (a zero time clears it).
+func (this *EntryVersion) UpdateDelayedState(logger logger.LogContext, state, msg string, next time.Time) (bool, error) {
 	f := func(data resources.ObjectData) (bool, error) {
 		obj, err := this.object.GetResource().Wrap(data)
 		if err != nil {
@@ -719,6 +859,8 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 		this.status.Message = &msg
 		mod.AssureStringValue(&b.State, state)
 		this.status.State = state
+		mod.Modify(assureNextAttemptTime(b, next))
+		mod.Modify(assureFlapCount(b, this.flapCount))
 		if mod.IsModified() {
 			dnsutils.SetLastUpdateTime(&b.LastUptimeTime)
 			logger.Infof("update state of '%s/%s' to %s (%s)", o.GetNamespace(), o.GetName(), state, msg)
@@ -728,6 +870,100 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 	return this.object.ModifyStatus(f)
 }
 
+// UpdatePlannedState sets the entry to pending and reports the planned, but not applied changes.
+func (this *EntryVersion) UpdatePlannedState(logger logger.LogContext, reason string, planned []string) (bool, error) {
+	f := func(data resources.ObjectData) (bool, error) {
+		obj, err := this.object.GetResource().Wrap(data)
+		if err != nil {
+			return false, err
+		}
+		o := dnsutils.DNSObject(obj)
+		b := o.BaseStatus()
+		mod := &utils.ModificationState{}
+
+		mod.AssureStringPtrValue(&b.Message, reason)
+		this.status.Message = &reason
+		mod.AssureStringValue(&b.State, api.STATE_PENDING)
+		this.status.State = api.STATE_PENDING
+		mod.Modify(assureNextAttemptTime(b, time.Time{}))
+		mod.Modify(assurePlannedChanges(b, planned))
+		if mod.IsModified() {
+			dnsutils.SetLastUpdateTime(&b.LastUptimeTime)
+			logger.Infof("update state of '%s/%s' to %s (%s: %s)", o.GetNamespace(), o.GetName(), api.STATE_PENDING, reason, strings.Join(planned, ", "))
+		}
+		return mod.IsModified(), nil
+	}
+	return this.object.ModifyStatus(f)
+}
+
+// UpdateNextAttempt reports the time of the next attempt to apply the entry
+// without changing its state.
+func (this *EntryVersion) UpdateNextAttempt(logger logger.LogContext, next time.Time) (bool, error) {
+	f := func(data resources.ObjectData) (bool, error) {
+		obj, err := this.object.GetResource().Wrap(data)
+		if err != nil {
+			return false, err
+		}
+		b := dnsutils.DNSObject(obj).BaseStatus()
+		return assureNextAttemptTime(b, next), nil
+	}
+	return this.object.ModifyStatus(f)
+}
+
+// assureNextAttemptTime sets the time of the next attempt (with a precision of seconds) or clears it for a zero time.
+func assureNextAttemptTime(b *api.DNSBaseStatus, next time.Time) bool {
+	if next.IsZero() {
+		if b.NextAttemptTime == nil {
+			return false
+		}
+		b.NextAttemptTime = nil
+		return true
+	}
+	t := metav1.NewTime(next.Truncate(time.Second))
+	if b.NextAttemptTime != nil && b.NextAttemptTime.Equal(&t) {
+		return false
+	}
+	b.NextAttemptTime = &t
+	return true
+}
+
+// assurePlannedChanges sets the planned changes or clears them for an empty list.
+func assurePlannedChanges(b *api.DNSBaseStatus, planned []string) bool {
+	if len(planned) == 0 {
+		planned = nil
+	}
+	if reflect.DeepEqual(b.PlannedChanges, planned) {
+		return false
+	}
+	b.PlannedChanges = planned
+	return true
+}
+
+// recordError appends a new error state to the bounded error history of the status.
+// Repeated status updates for the same error are not recorded.
+func recordError(b *api.DNSBaseStatus, size int, state, msg, reason string) bool {
+	switch state {
+	case api.STATE_ERROR, api.STATE_INVALID, api.STATE_STALE:
+	default:
+		return false
+	}
+	if size <= 0 {
+		if b.ErrorHistory == nil {
+			return false
+		}
+		b.ErrorHistory = nil
+		return true
+	}
+	if b.State == state && (state == api.STATE_STALE || utils.StringValue(b.Message) == msg) {
+		return false
+	}
+	b.ErrorHistory = append(b.ErrorHistory, api.ErrorRecord{Time: metav1.Now(), State: state, Message: msg, Reason: reason})
+	if len(b.ErrorHistory) > size {
+		b.ErrorHistory = b.ErrorHistory[len(b.ErrorHistory)-size:]
+	}
+	return true
+}
+
 func targetList(targets Targets) ([]string, string) {
 	list := []string{}
 	msg := "update effective targets: ["
@@ -814,6 +1050,7 @@ type Entry struct {
 	updateRequired bool
 	activezone     dns.ZoneID
 	state          *state
+	flaps          flapDetection
 
 	*EntryVersion
 }
@@ -841,6 +1078,15 @@ func (this *Entry) Trigger(logger logger.LogContext) {
 	this.state.TriggerEntry(logger, this)
 }
 
+// OwnerId returns the effective owner id of the entry, which is derived from
+// its namespace if no explicit owner id is given and a template is configured.
+func (this *Entry) OwnerId() string {
+	if id := this.EntryVersion.OwnerId(); id != "" {
+		return id
+	}
+	return this.state.config.NamespaceOwnerIds.OwnerId(this.ObjectName().Namespace())
+}
+
 func (this *Entry) IsActive() bool {
 	id := this.OwnerId()
 	if id == "" {
@@ -878,6 +1124,9 @@ func (this *Entry) Update(logger logger.LogContext, new *EntryVersion) *Entry {
 			}
 			_, msg := targetList(new.targets)
 			logger.Infof("%s", msg)
+			if new.valid && len(this.targets) > 0 {
+				this.recordTargetChange(logger, new)
+			}
 		}
 		this.modified = true
 	}
@@ -886,6 +1135,10 @@ func (this *Entry) Update(logger logger.LogContext, new *EntryVersion) *Entry {
 	if new.valid && this.status.State == api.STATE_STALE {
 		this.modified = true
 	}
+	if new.valid && new.targets.HasResourceReference() {
+		// addresses of referenced resources may have changed
+		this.modified = true
+	}
 
 	return this
 }
@@ -908,6 +1161,16 @@ func (this *Entry) updateStatistic(statistic *statistic.EntryStatistic) {
 	defer this.lock.Unlock()
 	statistic.Owners.Inc(this.OwnerId(), this.ProviderType(), this.ProviderName())
 	statistic.Providers.Inc(this.ProviderType(), this.ProviderName())
+	if group := this.OwnerGroup(); group != "" {
+		statistic.OwnerGroups.Inc(group, this.OwnerId(), this.ProviderType())
+	}
+	if this.IsValid() && this.ProviderType() != "" {
+		tenant := ""
+		if label := this.state.config.CostLabel; label != "" {
+			tenant = this.object.GetLabels()[label]
+		}
+		statistic.Costs.Add(this.object.GetNamespace(), tenant, this.ProviderType(), len(this.targets), this.TTL())
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,92 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Error history", func() {
+	update := func(b *api.DNSBaseStatus, state, msg string) bool {
+		mod := recordError(b, 2, state, msg, "")
+		b.State = state
+		b.Message = &msg
+		return mod
+	}
+
+	ginkgov2.It("records new errors only", func() {
+		b := &api.DNSBaseStatus{}
+		Expect(update(b, api.STATE_ERROR, "e1")).To(BeTrue())
+		Expect(update(b, api.STATE_ERROR, "e1")).To(BeFalse())
+		Expect(update(b, api.STATE_READY, "ok")).To(BeFalse())
+		Expect(update(b, api.STATE_ERROR, "e1")).To(BeTrue())
+		Expect(b.ErrorHistory).To(HaveLen(2))
+	})
+
+	ginkgov2.It("keeps the last errors", func() {
+		b := &api.DNSBaseStatus{}
+		update(b, api.STATE_ERROR, "e1")
+		update(b, api.STATE_INVALID, "e2")
+		update(b, api.STATE_ERROR, "e3")
+		Expect(b.ErrorHistory).To(HaveLen(2))
+		Expect(b.ErrorHistory[0].Message).To(Equal("e2"))
+		Expect(b.ErrorHistory[1].Message).To(Equal("e3"))
+	})
+
+	ginkgov2.It("drops the history if disabled", func() {
+		b := &api.DNSBaseStatus{}
+		update(b, api.STATE_ERROR, "e1")
+		Expect(recordError(b, 0, api.STATE_ERROR, "e2", "")).To(BeTrue())
+		Expect(b.ErrorHistory).To(BeNil())
+	})
+})
+
+var _ = ginkgov2.Describe("Next attempt time", func() {
+	ginkgov2.It("sets and clears the next attempt", func() {
+		b := &api.DNSBaseStatus{}
+		next := time.Now().Add(30 * time.Second)
+		Expect(assureNextAttemptTime(b, next)).To(BeTrue())
+		Expect(b.NextAttemptTime.Time).To(Equal(next.Truncate(time.Second)))
+		Expect(assureNextAttemptTime(b, next)).To(BeFalse())
+		Expect(assureNextAttemptTime(b, time.Time{})).To(BeTrue())
+		Expect(b.NextAttemptTime).To(BeNil())
+		Expect(assureNextAttemptTime(b, time.Time{})).To(BeFalse())
+	})
+})
+
+var _ = ginkgov2.Describe("Planned changes", func() {
+	ginkgov2.It("describes planned change requests", func() {
+		set := dns.NewDNSSet("www.example.com")
+		set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+		Expect(plannedChange(NewChangeRequest(R_CREATE, dns.RS_A, nil, set, nil), set)).To(Equal("create A www.example.com: [1.2.3.4]"))
+		Expect(plannedChange(NewChangeRequest(R_DELETE, dns.RS_A, set, nil, nil), set)).To(Equal("delete A www.example.com"))
+	})
+
+	ginkgov2.It("sets and clears the planned changes", func() {
+		b := &api.DNSBaseStatus{}
+		Expect(assurePlannedChanges(b, []string{"create A www.example.com"})).To(BeTrue())
+		Expect(assurePlannedChanges(b, []string{"create A www.example.com"})).To(BeFalse())
+		Expect(assurePlannedChanges(b, nil)).To(BeTrue())
+		Expect(b.PlannedChanges).To(BeNil())
+		Expect(assurePlannedChanges(b, []string{})).To(BeFalse())
+	})
+})
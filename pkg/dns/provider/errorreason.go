@@ -0,0 +1,130 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// permanentErrorBackoff is the minimum delay for retries after errors, which are not resolved
+// without changes of the credentials or the account (see perrs.IsPermanentReason).
+const permanentErrorBackoff = 5 * time.Minute
+
+// ClassifyError maps an error to an error reason. Errors not already classified
+// by the handler are mapped by the classification of the handler.
+func (this *DNSAccount) ClassifyError(err error) perrs.ErrorReason {
+	reason := perrs.Reason(err)
+	if reason == perrs.ReasonUnknown {
+		reason = this.handler.ClassifyError(err)
+	}
+	return reason
+}
+
+// classify wraps an error of the handler with its error reason.
+func (this *DNSAccount) classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	return perrs.Classify(err, this.ClassifyError(err))
+}
+
+// reportError classifies an error of the handler and counts it in the provider error metrics.
+func (this *DNSAccount) reportError(err error) error {
+	err = this.classify(err)
+	if err != nil {
+		reason := perrs.Reason(err)
+		metrics.AddProviderError(this.ProviderType(), string(reason))
+		if reason == perrs.ReasonAuthFailed {
+			this.setAuthFailure(err)
+		}
+	}
+	return err
+}
+
+// setAuthFailure records the last authentication error of the account.
+// It is reset by the next successful retrieval of a zone state.
+func (this *DNSAccount) setAuthFailure(err error) {
+	this.authLock.Lock()
+	defer this.authLock.Unlock()
+	this.authFailure = err
+}
+
+// AuthFailure returns the last authentication error of the account, if not yet resolved.
+func (this *DNSAccount) AuthFailure() error {
+	this.authLock.Lock()
+	defer this.authLock.Unlock()
+	return this.authFailure
+}
+
+// classifiedRequests returns copies of the requests, whose errors reported to the done handlers are classified.
+func (this *DNSAccount) classifiedRequests(reqs []*ChangeRequest) []*ChangeRequest {
+	result := make([]*ChangeRequest, len(reqs))
+	for i, r := range reqs {
+		done := r.Done
+		if done != nil {
+			done = &classifyingDoneHandler{inner: done, account: this}
+		}
+		result[i] = NewChangeRequest(r.Action, r.Type, r.Deletion, r.Addition, done)
+	}
+	return result
+}
+
+// classifyingDoneHandler classifies the errors of failed change requests.
+type classifyingDoneHandler struct {
+	inner   DoneHandler
+	account *DNSAccount
+}
+
+var _ DoneHandler = &classifyingDoneHandler{}
+
+func (this *classifyingDoneHandler) SetInvalid(err error) {
+	this.inner.SetInvalid(err)
+}
+
+func (this *classifyingDoneHandler) Failed(err error) {
+	this.inner.Failed(this.account.reportError(err))
+}
+
+func (this *classifyingDoneHandler) Throttled(retryAfter time.Duration) {
+	metrics.AddProviderError(this.account.ProviderType(), string(perrs.ReasonThrottled))
+	this.inner.Throttled(retryAfter)
+}
+
+func (this *classifyingDoneHandler) Blocked(reason string, planned []string) {
+	this.inner.Blocked(reason, planned)
+}
+
+func (this *classifyingDoneHandler) Succeeded() {
+	this.inner.Succeeded()
+}
+
+// errorBackoff returns the delay of the next attempt after an error for the given default backoff.
+// Throttled requests respect the delay requested by the API, errors requiring user interaction
+// are retried at most every permanentErrorBackoff.
+func errorBackoff(err error, backoff time.Duration) time.Duration {
+	reason := perrs.Reason(err)
+	switch {
+	case reason == perrs.ReasonThrottled:
+		return maxDuration(backoff, perrs.RetryAfter(err))
+	case perrs.IsPermanentReason(reason):
+		return maxDuration(backoff, permanentErrorBackoff)
+	}
+	return backoff
+}
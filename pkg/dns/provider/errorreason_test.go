@@ -0,0 +1,49 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgov2.Describe("Error reasons", func() {
+	ginkgov2.It("classifies wrapped errors only once", func() {
+		err := perrs.Classify(fmt.Errorf("denied"), perrs.ReasonAuthFailed)
+		Expect(perrs.Reason(fmt.Errorf("listing zones: %w", err))).To(Equal(perrs.ReasonAuthFailed))
+		Expect(perrs.Reason(perrs.Classify(err, perrs.ReasonConflict))).To(Equal(perrs.ReasonAuthFailed))
+		Expect(err.Error()).To(Equal("denied"))
+
+		Expect(perrs.Reason(perrs.NewThrottlingError(fmt.Errorf("rate exceeded")))).To(Equal(perrs.ReasonThrottled))
+		Expect(perrs.Reason(&perrs.NoSuchHostedZone{ZoneId: "z1"})).To(Equal(perrs.ReasonNotFound))
+		Expect(perrs.Reason(fmt.Errorf("other"))).To(Equal(perrs.ReasonUnknown))
+	})
+
+	ginkgov2.It("calculates the backoff by reason", func() {
+		throttled := &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: time.Minute, Err: fmt.Errorf("slow down")}
+		Expect(errorBackoff(throttled, 3*time.Second)).To(Equal(time.Minute))
+		Expect(errorBackoff(throttled, 2*time.Minute)).To(Equal(2 * time.Minute))
+		Expect(errorBackoff(perrs.NewProviderError(perrs.ReasonAuthFailed, fmt.Errorf("denied")), 3*time.Second)).To(Equal(permanentErrorBackoff))
+		Expect(errorBackoff(perrs.NewProviderError(perrs.ReasonInvalidRecord, fmt.Errorf("invalid")), 3*time.Second)).To(Equal(3 * time.Second))
+		Expect(errorBackoff(fmt.Errorf("other"), 3*time.Second)).To(Equal(3 * time.Second))
+	})
+})
@@ -17,6 +17,7 @@
 package errors
 
 import (
+	goerrors "errors"
 	"fmt"
 	"time"
 
@@ -63,7 +64,35 @@ func (e *ThrottlingError) Error() string {
 	return fmt.Sprintf("Throttling: %s", e.err)
 }
 
+func (e *ThrottlingError) Unwrap() error {
+	return e.err
+}
+
+// IsThrottlingError checks for errors classified as throttling.
 func IsThrottlingError(err error) bool {
-	_, ok := err.(*ThrottlingError)
-	return ok
+	return Reason(err) == ReasonThrottled
+}
+
+func NewWritePermissionUnverifiable(err error) *WritePermissionUnverifiable {
+	return &WritePermissionUnverifiable{err: err}
+}
+
+// WritePermissionUnverifiable is returned by a write permission probe if the permission cannot be verified,
+// e.g. because the credentials are not allowed to run a policy simulation.
+type WritePermissionUnverifiable struct {
+	err error
+}
+
+func (e *WritePermissionUnverifiable) Error() string {
+	return fmt.Sprintf("write permission cannot be verified: %s", e.err)
+}
+
+func (e *WritePermissionUnverifiable) Unwrap() error {
+	return e.err
+}
+
+// IsWritePermissionUnverifiable checks for errors of write permission probes not able to verify the permission.
+func IsWritePermissionUnverifiable(err error) bool {
+	var e *WritePermissionUnverifiable
+	return goerrors.As(err, &e)
 }
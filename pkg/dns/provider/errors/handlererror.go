@@ -45,3 +45,7 @@ func (e *handlerError) Error() string {
 func (e *handlerError) Cause() error {
 	return e.err
 }
+
+func (e *handlerError) Unwrap() error {
+	return e.err
+}
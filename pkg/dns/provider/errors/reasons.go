@@ -0,0 +1,126 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrorReason is the category of a provider error.
+// Handlers map the errors of their DNS API to these categories to get
+// a consistent backoff, status reasons and metrics for all provider types.
+type ErrorReason string
+
+const (
+	// ReasonThrottled is used if requests are rejected by rate limits of the API.
+	ReasonThrottled ErrorReason = "Throttled"
+	// ReasonAuthFailed is used for invalid credentials or missing permissions.
+	ReasonAuthFailed ErrorReason = "AuthFailed"
+	// ReasonNotFound is used for missing zones or records.
+	ReasonNotFound ErrorReason = "NotFound"
+	// ReasonQuotaExceeded is used if a quota of the account (e.g. the number of records) is exhausted.
+	ReasonQuotaExceeded ErrorReason = "QuotaExceeded"
+	// ReasonInvalidRecord is used if a record is rejected by the API.
+	ReasonInvalidRecord ErrorReason = "InvalidRecord"
+	// ReasonConflict is used for concurrent or conflicting modifications.
+	ReasonConflict ErrorReason = "Conflict"
+	// ReasonUnknown is used for errors not classified by the handler.
+	ReasonUnknown ErrorReason = "Unknown"
+)
+
+// ProviderError is a provider error classified by its reason.
+// The error message is the one of the original error.
+type ProviderError struct {
+	Reason ErrorReason
+	// RetryAfter is the delay requested by the API before the next attempt (0 if unknown)
+	RetryAfter time.Duration
+	Err        error
+}
+
+// NewProviderError classifies an error with the given reason.
+func NewProviderError(reason ErrorReason, err error) *ProviderError {
+	return &ProviderError{Reason: reason, Err: err}
+}
+
+func (e *ProviderError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ProviderError) Unwrap() error {
+	return e.Err
+}
+
+// Reason returns the reason of an error, which is ReasonUnknown if it has not been classified.
+func Reason(err error) ErrorReason {
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.Reason
+	}
+	var terr *ThrottlingError
+	if errors.As(err, &terr) {
+		return ReasonThrottled
+	}
+	var zerr *NoSuchHostedZone
+	if errors.As(err, &zerr) {
+		return ReasonNotFound
+	}
+	return ReasonUnknown
+}
+
+// RetryAfter returns the delay requested for a classified error (0 if unknown).
+func RetryAfter(err error) time.Duration {
+	var perr *ProviderError
+	if errors.As(err, &perr) {
+		return perr.RetryAfter
+	}
+	return 0
+}
+
+// Classify classifies an error with the given reason, if it is not already classified.
+func Classify(err error, reason ErrorReason) error {
+	if err == nil || reason == ReasonUnknown || Reason(err) != ReasonUnknown {
+		return err
+	}
+	return NewProviderError(reason, err)
+}
+
+// ReasonForHTTPStatus maps an HTTP status code of a DNS API response to an error reason.
+func ReasonForHTTPStatus(code int) ErrorReason {
+	switch code {
+	case http.StatusTooManyRequests:
+		return ReasonThrottled
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ReasonAuthFailed
+	case http.StatusNotFound:
+		return ReasonNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return ReasonConflict
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return ReasonInvalidRecord
+	case http.StatusPaymentRequired, http.StatusInsufficientStorage:
+		return ReasonQuotaExceeded
+	}
+	return ReasonUnknown
+}
+
+// IsPermanentReason checks for errors that are not resolved without changes of the credentials
+// or the account, so that retries should be delayed.
+func IsPermanentReason(reason ErrorReason) bool {
+	return reason == ReasonAuthFailed || reason == ReasonQuotaExceeded
+}
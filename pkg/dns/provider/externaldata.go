@@ -0,0 +1,172 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	externalDataAPIVersion   = "externaldata.gatekeeper.sh/v1beta1"
+	externalDataResponseKind = "ProviderResponse"
+)
+
+// ExternalDataRequest is the request of an OPA Gatekeeper external data provider.
+type ExternalDataRequest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Request    struct {
+		Keys []string `json:"keys"`
+	} `json:"request"`
+}
+
+// ExternalDataResponse is the response of an OPA Gatekeeper external data provider.
+type ExternalDataResponse struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Response   struct {
+		Idempotent  bool               `json:"idempotent"`
+		Items       []ExternalDataItem `json:"items"`
+		SystemError string             `json:"systemError,omitempty"`
+	} `json:"response"`
+}
+
+// ExternalDataItem is the result for a single key of an external data request.
+type ExternalDataItem struct {
+	Key   string       `json:"key"`
+	Value *DNSNameInfo `json:"value,omitempty"`
+	Error string       `json:"error,omitempty"`
+}
+
+// DNSNameInfo describes whether a DNS name is managed by the controller and by which owners and entries.
+type DNSNameInfo struct {
+	Managed bool     `json:"managed"`
+	Owners  []string `json:"owners,omitempty"`
+	Entries []string `json:"entries,omitempty"`
+	Zones   []string `json:"zones,omitempty"`
+}
+
+// externalData serves the DNS names managed by the states of the compound controllers
+// as OPA Gatekeeper external data provider.
+type externalData struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+var theExternalData = &externalData{}
+
+func init() {
+	server.RegisterHandler("/external-data/dnsnames", http.HandlerFunc(serveExternalData))
+}
+
+func enableExternalDataEndpoint(state *state) {
+	theExternalData.lock.Lock()
+	defer theExternalData.lock.Unlock()
+	theExternalData.states = append(theExternalData.states, state)
+}
+
+// LookupDNSName reports the entries managing a DNS name.
+func (this *state) LookupDNSName(name string) *DNSNameInfo {
+	name = dns.NormalizeHostname(name)
+	owners := map[string]struct{}{}
+	zones := map[string]struct{}{}
+	info := &DNSNameInfo{}
+
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for zoned, e := range this.dnsnames {
+		if zoned.DNSName != name || e.Kind() == api.DNSLockKind {
+			continue
+		}
+		info.Managed = true
+		owner := e.OwnerId()
+		if owner == "" {
+			owner = this.config.Ident
+		}
+		owners[owner] = struct{}{}
+		zones[zoned.ZoneID.String()] = struct{}{}
+		info.Entries = append(info.Entries, e.ObjectName().String())
+	}
+	for owner := range owners {
+		info.Owners = append(info.Owners, owner)
+	}
+	for zone := range zones {
+		info.Zones = append(info.Zones, zone)
+	}
+	sort.Strings(info.Owners)
+	sort.Strings(info.Zones)
+	sort.Strings(info.Entries)
+	return info
+}
+
+func (this *externalData) lookup(name string) *DNSNameInfo {
+	this.lock.Lock()
+	states := append([]*state{}, this.states...)
+	this.lock.Unlock()
+
+	result := &DNSNameInfo{}
+	for _, s := range states {
+		info := s.LookupDNSName(name)
+		result.Managed = result.Managed || info.Managed
+		result.Owners = append(result.Owners, info.Owners...)
+		result.Entries = append(result.Entries, info.Entries...)
+		result.Zones = append(result.Zones, info.Zones...)
+	}
+	return result
+}
+
+// serveExternalData answers OPA Gatekeeper external data requests with the keys as DNS names.
+func serveExternalData(w http.ResponseWriter, r *http.Request) {
+	theExternalData.lock.Lock()
+	enabled := len(theExternalData.states) > 0
+	theExternalData.lock.Unlock()
+	if !enabled {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := &ExternalDataResponse{APIVersion: externalDataAPIVersion, Kind: externalDataResponseKind}
+	resp.Response.Idempotent = true
+	req := &ExternalDataRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(req); err != nil {
+		resp.Response.SystemError = "invalid request: " + err.Error()
+	} else {
+		for _, key := range req.Request.Keys {
+			item := ExternalDataItem{Key: key}
+			if key == "" {
+				item.Error = "empty DNS name"
+			} else {
+				item.Value = theExternalData.lookup(key)
+			}
+			resp.Response.Items = append(resp.Response.Items, item)
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
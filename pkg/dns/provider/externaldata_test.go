@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("External data provider", func() {
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serveExternalData(w, httptest.NewRequest(http.MethodPost, "/external-data/dnsnames", strings.NewReader(body)))
+		return w
+	}
+
+	ginkgov2.AfterEach(func() {
+		theExternalData.states = nil
+	})
+
+	ginkgov2.It("is not served if disabled", func() {
+		Expect(post(`{}`).Code).To(Equal(http.StatusNotFound))
+	})
+
+	ginkgov2.It("answers the keys of requests", func() {
+		enableExternalDataEndpoint(&state{dnsnames: DNSNames{}})
+		w := post(`{"apiVersion":"externaldata.gatekeeper.sh/v1beta1","kind":"ProviderRequest","request":{"keys":["www.example.com",""]}}`)
+		Expect(w.Code).To(Equal(http.StatusOK))
+		resp := &ExternalDataResponse{}
+		Expect(json.Unmarshal(w.Body.Bytes(), resp)).To(Succeed())
+		Expect(resp.Kind).To(Equal(externalDataResponseKind))
+		Expect(resp.Response.Items).To(HaveLen(2))
+		Expect(resp.Response.Items[0].Value).To(Equal(&DNSNameInfo{}))
+		Expect(resp.Response.Items[1].Error).NotTo(BeEmpty())
+	})
+
+	ginkgov2.It("reports invalid requests as system error", func() {
+		enableExternalDataEndpoint(&state{dnsnames: DNSNames{}})
+		resp := &ExternalDataResponse{}
+		Expect(json.Unmarshal(post(`{`).Body.Bytes(), resp)).To(Succeed())
+		Expect(resp.Response.SystemError).NotTo(BeEmpty())
+	})
+})
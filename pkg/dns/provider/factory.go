@@ -24,6 +24,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/extension"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 )
 
 type DNSHandlerCreatorFunction func(config *DNSHandlerConfig) (DNSHandler, error)
@@ -34,9 +35,11 @@ type Factory struct {
 	optionCreator         extension.OptionSourceCreator
 	genericDefaults       *GenericFactoryOptions
 	supportZoneStateCache bool
+	providerConfigSchema  *apiextensionsv1.JSONSchemaProps
 }
 
 var _ DNSHandlerFactory = &Factory{}
+var _ ProviderConfigSchemaSupport = &Factory{}
 
 func NewDNSHandlerFactory(typecode string, create DNSHandlerCreatorFunction, disableZoneStateCache ...bool) *Factory {
 	disable := false
@@ -70,6 +73,17 @@ func (this *Factory) SetOptionSourceByExample(proto config.OptionSource, default
 	return this.SetGenericFactoryOptionDefaults(defaults...)
 }
 
+// SetProviderConfigSchema sets the schema used to validate the provider config of the provider type.
+func (this *Factory) SetProviderConfigSchema(schema *apiextensionsv1.JSONSchemaProps) *Factory {
+	this.providerConfigSchema = schema
+	return this
+}
+
+// SetProviderConfigSchemaByExample derives the schema of the provider config from a prototype of the config struct.
+func (this *Factory) SetProviderConfigSchemaByExample(proto interface{}) *Factory {
+	return this.SetProviderConfigSchema(ProviderConfigSchemaFor(proto))
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (this *Factory) IsResponsibleFor(object *dnsutils.DNSProviderObject) bool {
@@ -109,6 +123,13 @@ func (this *Factory) SupportZoneStateCache(typecode string) (bool, error) {
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
 
+func (this *Factory) ProviderConfigSchema(typecode string) *apiextensionsv1.JSONSchemaProps {
+	if typecode == this.typecode {
+		return this.providerConfigSchema
+	}
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type CompoundFactory struct {
@@ -119,6 +140,7 @@ type CompoundFactory struct {
 }
 
 var _ DNSHandlerFactory = &CompoundFactory{}
+var _ ProviderConfigSchemaSupport = &CompoundFactory{}
 
 func NewDNSHandlerCompoundFactory(name string) *CompoundFactory {
 	return &CompoundFactory{name,
@@ -207,3 +229,10 @@ func (this *CompoundFactory) SupportZoneStateCache(typecode string) (bool, error
 	}
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
+
+func (this *CompoundFactory) ProviderConfigSchema(typecode string) *apiextensionsv1.JSONSchemaProps {
+	if f, ok := this.factories[typecode].(ProviderConfigSchemaSupport); ok {
+		return f.ProviderConfigSchema(typecode)
+	}
+	return nil
+}
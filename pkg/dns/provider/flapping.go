@@ -0,0 +1,99 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// flapDetection records the target changes of an entry to detect rapidly alternating targets,
+// e.g. caused by a churn of load balancer addresses.
+type flapDetection struct {
+	changes []time.Time
+}
+
+// record records a target change and returns the number of changes within the window.
+func (this *flapDetection) record(now time.Time, window time.Duration) int {
+	this.changes = append(this.changes, now)
+	return this.count(now, window)
+}
+
+// count drops the changes outside of the window and returns the number of remaining changes.
+func (this *flapDetection) count(now time.Time, window time.Duration) int {
+	i := 0
+	for i < len(this.changes) && now.Sub(this.changes[i]) >= window {
+		i++
+	}
+	this.changes = this.changes[i:]
+	return len(this.changes)
+}
+
+// holdDown returns the remaining time the targets must be stable before the changes
+// of a flapping entry are applied. It is 0 if the entry is not flapping.
+func (this *flapDetection) holdDown(now time.Time, config *Config) time.Duration {
+	if this.count(now, config.FlapWindow) < config.FlapThreshold {
+		return 0
+	}
+	remaining := this.changes[len(this.changes)-1].Add(config.FlapHoldDown).Sub(now)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+func flapDetectionEnabled(config *Config) bool {
+	return config.FlapWindow > 0 && config.FlapThreshold > 0
+}
+
+// recordTargetChange records a change of the targets for the flap detection.
+func (this *Entry) recordTargetChange(logger logger.LogContext, new *EntryVersion) {
+	if !flapDetectionEnabled(&this.state.config) {
+		return
+	}
+	count := this.flaps.record(time.Now(), this.state.config.FlapWindow)
+	new.flapCount = count
+	if count >= this.state.config.FlapThreshold {
+		logger.Infof("targets flapping: %d changes within %v", count, this.state.config.FlapWindow)
+		metrics.AddEntryFlap(new.ZoneId())
+	}
+}
+
+// HoldDown returns the remaining hold-down time of an entry with flapping targets.
+// Its changes must not be applied before. It is 0 if the entry is not flapping.
+func (this *Entry) HoldDown() time.Duration {
+	if !flapDetectionEnabled(&this.state.config) {
+		return 0
+	}
+	now := time.Now()
+	hold := this.flaps.holdDown(now, &this.state.config)
+	this.flapCount = len(this.flaps.changes)
+	return hold
+}
+
+// assureFlapCount sets the number of target changes within the flap detection window.
+func assureFlapCount(b *api.DNSBaseStatus, count int) bool {
+	if b.FlapCount == count {
+		return false
+	}
+	b.FlapCount = count
+	return true
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Flap detection", func() {
+	config := &Config{FlapWindow: 5 * time.Minute, FlapThreshold: 3, FlapHoldDown: 2 * time.Minute}
+	start := time.Now()
+
+	ginkgov2.It("holds down the changes of flapping targets until stable", func() {
+		flaps := &flapDetection{}
+		Expect(flaps.record(start, config.FlapWindow)).To(Equal(1))
+		Expect(flaps.holdDown(start, config)).To(BeZero())
+		Expect(flaps.record(start.Add(10*time.Second), config.FlapWindow)).To(Equal(2))
+		Expect(flaps.holdDown(start.Add(10*time.Second), config)).To(BeZero())
+
+		last := start.Add(20 * time.Second)
+		Expect(flaps.record(last, config.FlapWindow)).To(Equal(3))
+		Expect(flaps.holdDown(last, config)).To(Equal(2 * time.Minute))
+		Expect(flaps.holdDown(last.Add(90*time.Second), config)).To(Equal(30 * time.Second))
+		Expect(flaps.holdDown(last.Add(2*time.Minute), config)).To(BeZero())
+	})
+
+	ginkgov2.It("forgets changes outside of the window", func() {
+		flaps := &flapDetection{}
+		flaps.record(start, config.FlapWindow)
+		flaps.record(start.Add(time.Minute), config.FlapWindow)
+		Expect(flaps.record(start.Add(6*time.Minute), config.FlapWindow)).To(Equal(1))
+		Expect(flaps.holdDown(start.Add(6*time.Minute), config)).To(BeZero())
+	})
+})
@@ -26,6 +26,7 @@ import (
 
 type foreignProvider struct {
 	name     resources.ObjectName
+	ptype    string
 	included utils.StringSet
 	excluded utils.StringSet
 }
@@ -44,6 +45,7 @@ func (this *foreignProvider) Update(logger logger.LogContext, provider *dnsutils
 	var included utils.StringSet
 	var excluded utils.StringSet
 
+	this.ptype = provider.TypeCode()
 	status := provider.DNSProvider().Status
 	if status.Domains.Included != nil {
 		included = utils.NewStringSet(status.Domains.Included...)
@@ -52,12 +54,12 @@ func (this *foreignProvider) Update(logger logger.LogContext, provider *dnsutils
 		excluded = utils.NewStringSet(status.Domains.Excluded...)
 	}
 
-	if this.included.Equals(included) {
+	if !this.included.Equals(included) {
 		logger.Infof("included domain changed for foreign provider %q: %s", provider.ObjectName(), included)
 		this.included = included
 	}
 
-	if this.excluded.Equals(excluded) {
+	if !this.excluded.Equals(excluded) {
 		logger.Infof("excluded domain changed for foreign provider %q: %s", provider.ObjectName(), excluded)
 		this.excluded = excluded
 	}
@@ -0,0 +1,237 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// IncludeSuggestion is the suggested domain include list of a provider calculated from the DNS names
+// of the entries assigned to it.
+type IncludeSuggestion struct {
+	Provider string `json:"provider"`
+	// Entries is the number of the entries assigned to the provider
+	Entries int `json:"entries"`
+	// Included are the currently included domains
+	Included []string `json:"included"`
+	// Suggested is the minimal include list covering the DNS names of all assigned entries
+	Suggested []string `json:"suggested"`
+	// Unused are the currently included domains without any assigned entry
+	Unused []string `json:"unused,omitempty"`
+}
+
+// SuggestIncludeDomains calculates the include suggestions for all providers or for a single provider.
+// With a depth >= 0 the suggested domains are shortened to at most depth labels below their zone
+// domain, otherwise the DNS names of the entries are suggested.
+func (this *state) SuggestIncludeDomains(provider resources.ObjectName, depth int) []*IncludeSuggestion {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	names := map[resources.ObjectName][]string{}
+	for _, e := range this.entries {
+		if e.ProviderName() != nil && e.DNSName() != "" {
+			names[e.ProviderName()] = append(names[e.ProviderName()], e.DNSName())
+		}
+	}
+
+	var result []*IncludeSuggestion
+	for n, p := range this.providers {
+		if provider != nil && n != provider {
+			continue
+		}
+		var zoneDomains []string
+		for _, z := range p.zones {
+			if p.IncludesZone(z.Id()) {
+				zoneDomains = append(zoneDomains, z.Domain())
+			}
+		}
+		suggested := suggestIncludeDomains(names[n], zoneDomains, depth)
+		s := &IncludeSuggestion{
+			Provider:  n.String(),
+			Entries:   len(names[n]),
+			Included:  p.included.AsArray(),
+			Suggested: suggested,
+		}
+		sort.Strings(s.Included)
+		for _, d := range s.Included {
+			if !matchesAny(names[n], d) {
+				s.Unused = append(s.Unused, d)
+			}
+		}
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Provider < result[j].Provider })
+	return result
+}
+
+// suggestIncludeDomains calculates the minimal list of domains covering all DNS names.
+func suggestIncludeDomains(names, zoneDomains []string, depth int) []string {
+	candidates := map[string]struct{}{}
+	for _, name := range names {
+		name = strings.TrimPrefix(name, "*.")
+		candidates[shortenDomain(name, longestDomain(name, zoneDomains), depth)] = struct{}{}
+	}
+	sorted := make([]string, 0, len(candidates))
+	for c := range candidates {
+		sorted = append(sorted, c)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) < len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	result := []string{}
+	for _, c := range sorted {
+		if longestDomain(c, result) == "" {
+			result = append(result, c)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// matchesAny checks whether one of the DNS names matches the domain.
+func matchesAny(names []string, domain string) bool {
+	for _, name := range names {
+		if dnsutils.Match(strings.TrimPrefix(name, "*."), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func longestDomain(name string, domains []string) string {
+	found := ""
+	for _, d := range domains {
+		if len(d) > len(found) && dnsutils.Match(name, d) {
+			found = d
+		}
+	}
+	return found
+}
+
+// shortenDomain shortens a DNS name to at most depth labels below the zone domain.
+func shortenDomain(name, zoneDomain string, depth int) string {
+	if depth < 0 || zoneDomain == "" || name == zoneDomain {
+		return name
+	}
+	labels := strings.Split(strings.TrimSuffix(name, "."+zoneDomain), ".")
+	if depth == 0 {
+		return zoneDomain
+	}
+	if len(labels) > depth {
+		labels = labels[len(labels)-depth:]
+	}
+	return strings.Join(labels, ".") + "." + zoneDomain
+}
+
+// includeSuggestionEndpoint serves the include suggestions of the states of the compound controllers.
+type includeSuggestionEndpoint struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+var theIncludeSuggestionEndpoint = &includeSuggestionEndpoint{}
+
+func init() {
+	server.RegisterHandler("/debug/includesuggestion", http.HandlerFunc(serveIncludeSuggestion))
+}
+
+func enableIncludeSuggestionEndpoint(state *state) {
+	theIncludeSuggestionEndpoint.lock.Lock()
+	defer theIncludeSuggestionEndpoint.lock.Unlock()
+	theIncludeSuggestionEndpoint.states = append(theIncludeSuggestionEndpoint.states, state)
+}
+
+// serveIncludeSuggestion serves the include suggestions for all providers or for the provider given by the
+// query parameter `provider` (`<namespace>/<name>`). The optional query parameter `depth` limits the number
+// of labels of the suggested domains below their zone domain. With `format=text` the suggestions are
+// returned as plain text.
+func serveIncludeSuggestion(w http.ResponseWriter, r *http.Request) {
+	theIncludeSuggestionEndpoint.lock.Lock()
+	states := append([]*state{}, theIncludeSuggestionEndpoint.states...)
+	theIncludeSuggestionEndpoint.lock.Unlock()
+	if len(states) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	var provider resources.ObjectName
+	if p := strings.TrimSpace(query.Get("provider")); p != "" {
+		parts := strings.Split(p, "/")
+		if len(parts) != 2 {
+			http.Error(w, "provider must be given as <namespace>/<name>", http.StatusBadRequest)
+			return
+		}
+		provider = resources.NewObjectName(parts[0], parts[1])
+	}
+	depth := -1
+	if d := strings.TrimSpace(query.Get("depth")); d != "" {
+		var err error
+		if depth, err = strconv.Atoi(d); err != nil || depth < 0 {
+			http.Error(w, "depth must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+	}
+	results := []*IncludeSuggestion{}
+	for _, s := range states {
+		results = append(results, s.SuggestIncludeDomains(provider, depth)...)
+	}
+	if provider != nil && len(results) == 0 {
+		http.Error(w, fmt.Sprintf("provider %s not found", provider), http.StatusNotFound)
+		return
+	}
+
+	if query.Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain")
+		for _, result := range results {
+			fmt.Fprintf(w, "provider:  %s (%d entries)\n", result.Provider, result.Entries)
+			fmt.Fprintf(w, "included:  %s\n", strings.Join(result.Included, ", "))
+			fmt.Fprintf(w, "suggested: %s\n", strings.Join(result.Suggested, ", "))
+			if len(result.Unused) > 0 {
+				fmt.Fprintf(w, "unused:    %s\n", strings.Join(result.Unused, ", "))
+			}
+			fmt.Fprintln(w)
+		}
+		return
+	}
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
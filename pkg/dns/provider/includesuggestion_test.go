@@ -0,0 +1,61 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Include suggestion", func() {
+	zones := []string{"example.com", "sub.example.com", "example.org"}
+	names := []string{
+		"www.example.com",
+		"api.www.example.com",
+		"*.apps.dev.example.com",
+		"a.b.sub.example.com",
+		"example.org",
+	}
+
+	ginkgov2.It("suggests the DNS names of the entries without depth", func() {
+		Expect(suggestIncludeDomains(names, zones, -1)).To(Equal([]string{
+			"a.b.sub.example.com",
+			"apps.dev.example.com",
+			"example.org",
+			"www.example.com",
+		}))
+	})
+
+	ginkgov2.It("shortens the suggested domains to the depth below the zone domain", func() {
+		Expect(suggestIncludeDomains(names, zones, 1)).To(Equal([]string{
+			"b.sub.example.com",
+			"dev.example.com",
+			"example.org",
+			"www.example.com",
+		}))
+		Expect(suggestIncludeDomains(names, zones, 0)).To(Equal([]string{
+			"example.com",
+			"example.org",
+		}))
+	})
+
+	ginkgov2.It("finds included domains without entries", func() {
+		Expect(matchesAny(names, "dev.example.com")).To(BeTrue())
+		Expect(matchesAny(names, "test.example.com")).To(BeFalse())
+		Expect(matchesAny(names, "example.net")).To(BeFalse())
+	})
+})
@@ -18,6 +18,8 @@ package provider
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
@@ -28,13 +30,22 @@ type zonedata struct {
 	dnssets dns.DNSSets
 }
 
+// recordKey identifies the record sets of a name and type in a zone.
+type recordKey struct {
+	zoneID dns.ZoneID
+	name   string
+	rtype  string
+}
+
 type InMemory struct {
-	lock  sync.Mutex
-	zones map[dns.ZoneID]zonedata
+	lock        sync.Mutex
+	zones       map[dns.ZoneID]zonedata
+	failures    map[recordKey]error
+	persistence InMemoryPersistence
 }
 
 func NewInMemory() *InMemory {
-	return &InMemory{zones: map[dns.ZoneID]zonedata{}}
+	return &InMemory{zones: map[dns.ZoneID]zonedata{}, failures: map[recordKey]error{}}
 }
 
 func (m *InMemory) GetZones() DNSHostedZones {
@@ -78,12 +89,14 @@ func (m *InMemory) SetZone(zone DNSHostedZone, zoneState DNSZoneState) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	m.zones[zone.Id()] = zonedata{zone: zone, dnssets: clone}
+	_ = m.persist()
 }
 
 func (m *InMemory) DeleteZone(zoneID dns.ZoneID) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 	delete(m.zones, zoneID)
+	_ = m.persist()
 }
 
 func (m *InMemory) AddZone(zone DNSHostedZone) bool {
@@ -96,6 +109,7 @@ func (m *InMemory) AddZone(zone DNSHostedZone) bool {
 	}
 
 	m.zones[zone.Id()] = zonedata{zone: zone, dnssets: dns.DNSSets{}}
+	_ = m.persist()
 	return true
 }
 
@@ -107,18 +121,129 @@ func (m *InMemory) Apply(zoneID dns.ZoneID, request *ChangeRequest, metrics Metr
 	if !ok {
 		return fmt.Errorf("DNSZone %s not hosted", zoneID)
 	}
+	if err := m.injectedFailure(zoneID, request); err != nil {
+		return err
+	}
+	apply(data.dnssets, request)
+	countRequest(zoneID, request, metrics)
+	return m.persist()
+}
+
+// TransactionError is returned by ApplyTransaction if a change request of a batch failed.
+type TransactionError struct {
+	// Request is the failed change request
+	Request *ChangeRequest
+	Err     error
+}
+
+func (e *TransactionError) Error() string {
+	name, rset := buildRecordSet(e.Request)
+	return fmt.Sprintf("%s %s %s failed: %s", e.Request.Action, rset.Type, name, e.Err)
+}
+
+func (e *TransactionError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyTransaction applies all change requests of a batch or none of them.
+// If a change request fails, a TransactionError is returned and the zone is left unchanged.
+func (m *InMemory) ApplyTransaction(zoneID dns.ZoneID, requests []*ChangeRequest, metrics Metrics) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.zones[zoneID]
+	if !ok {
+		return fmt.Errorf("DNSZone %s not hosted", zoneID)
+	}
+	dnssets := data.dnssets.Clone()
+	for _, r := range requests {
+		if err := m.injectedFailure(zoneID, r); err != nil {
+			return &TransactionError{Request: r, Err: err}
+		}
+		apply(dnssets, r)
+	}
+	m.zones[zoneID] = zonedata{zone: data.zone, dnssets: dnssets}
+	for _, r := range requests {
+		countRequest(zoneID, r, metrics)
+	}
+	return m.persist()
+}
+
+// InjectFailure lets all changes of the record sets of the given name and type in a zone fail with the given error.
+// An empty record type matches all record types. A nil error removes the injected failure.
+func (m *InMemory) InjectFailure(zoneID dns.ZoneID, name, rtype string, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	key := recordKey{zoneID: zoneID, name: name, rtype: rtype}
+	if err == nil {
+		delete(m.failures, key)
+	} else {
+		m.failures[key] = err
+	}
+}
+
+// ClearFailures removes all injected failures.
+func (m *InMemory) ClearFailures() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.failures = map[recordKey]error{}
+}
+
+func (m *InMemory) injectedFailure(zoneID dns.ZoneID, request *ChangeRequest) error {
+	name, rset := buildRecordSet(request)
+	if err := m.failures[recordKey{zoneID: zoneID, name: name, rtype: rset.Type}]; err != nil {
+		return err
+	}
+	return m.failures[recordKey{zoneID: zoneID, name: name}]
+}
+
+// InMemorySnapshot is a copy of the zones and record sets of an InMemory.
+type InMemorySnapshot struct {
+	zones map[dns.ZoneID]zonedata
+}
+
+// Snapshot returns a copy of all zones and record sets, which can be restored later on.
+func (m *InMemory) Snapshot() *InMemorySnapshot {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return &InMemorySnapshot{zones: cloneZoneData(m.zones)}
+}
+
+// Restore resets all zones and record sets to the state of the snapshot.
+// Injected failures are not affected.
+func (m *InMemory) Restore(snapshot *InMemorySnapshot) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.zones = cloneZoneData(snapshot.zones)
+	_ = m.persist()
+}
+
+func cloneZoneData(zones map[dns.ZoneID]zonedata) map[dns.ZoneID]zonedata {
+	result := make(map[dns.ZoneID]zonedata, len(zones))
+	for id, data := range zones {
+		result[id] = zonedata{zone: data.zone, dnssets: data.dnssets.Clone()}
+	}
+	return result
+}
 
+func apply(dnssets dns.DNSSets, request *ChangeRequest) {
 	name, rset := buildRecordSet(request)
+	switch request.Action {
+	case R_CREATE, R_UPDATE:
+		dnssets.AddRecordSet(name, rset)
+	case R_DELETE:
+		dnssets.RemoveRecordSet(dns.DNSSetKey(name, rset.RoutingPolicy.GetSetIdentifier()), rset.Type)
+	}
+}
 
+func countRequest(zoneID dns.ZoneID, request *ChangeRequest, metrics Metrics) {
 	switch request.Action {
 	case R_CREATE, R_UPDATE:
-		data.dnssets.AddRecordSet(name, rset)
 		metrics.AddZoneRequests(zoneID.ID, M_UPDATERECORDS, 1)
 	case R_DELETE:
-		data.dnssets.RemoveRecordSet(name, rset.Type)
 		metrics.AddZoneRequests(zoneID.ID, M_DELETERECORDS, 1)
 	}
-	return nil
 }
 
 func buildRecordSet(req *ChangeRequest) (string, *dns.RecordSet) {
@@ -133,12 +258,95 @@ func buildRecordSet(req *ChangeRequest) (string, *dns.RecordSet) {
 	return dnsset.Name, dnsset.Sets[req.Type]
 }
 
+// InMemoryPersistence stores the zones and record sets of an InMemory, e.g. for a standalone mode of the mock provider.
+type InMemoryPersistence interface {
+	// Load returns the stored zones, or nil if nothing has been stored yet.
+	Load() ([]*ZoneDump, error)
+	// Store stores the zones after every change.
+	Store(zones []*ZoneDump) error
+}
+
+// SetPersistence restores the record sets of the already added zones from the persistence and stores all further changes.
+// Stored zones not added anymore are dropped. Changes of methods without error result are stored with the next
+// change, if storing fails.
+func (m *InMemory) SetPersistence(persistence InMemoryPersistence) error {
+	zones, err := persistence.Load()
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, zone := range zones {
+		id := dns.NewZoneID(zone.HostedZone.ProviderType, zone.HostedZone.Id)
+		if data, ok := m.zones[id]; ok && zone.DNSSets != nil {
+			m.zones[id] = zonedata{zone: data.zone, dnssets: zone.DNSSets}
+		}
+	}
+	m.persistence = persistence
+	return m.persist()
+}
+
+func (m *InMemory) persist() error {
+	if m.persistence == nil {
+		return nil
+	}
+	zones := make([]*ZoneDump, 0, len(m.zones))
+	for id := range m.zones {
+		zones = append(zones, m.buildZoneDump(id))
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].HostedZone.ProviderType != zones[j].HostedZone.ProviderType {
+			return zones[i].HostedZone.ProviderType < zones[j].HostedZone.ProviderType
+		}
+		return zones[i].HostedZone.Id < zones[j].HostedZone.Id
+	})
+	if err := m.persistence.Store(zones); err != nil {
+		return fmt.Errorf("cannot store zones: %w", err)
+	}
+	return nil
+}
+
+// Lookup returns the zone with the longest domain matching the DNS name and the record sets of the name in this zone.
+// Record sets with a set identifier are ignored. If there are no record sets for the name, a wildcard name
+// of the same level is looked up.
+func (m *InMemory) Lookup(dnsName string) (DNSHostedZone, dns.RecordSets) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	var found *zonedata
+	for _, data := range m.zones {
+		domain := data.zone.Domain()
+		if dnsName == domain || strings.HasSuffix(dnsName, "."+domain) {
+			if found == nil || len(domain) > len(found.zone.Domain()) {
+				d := data
+				found = &d
+			}
+		}
+	}
+	if found == nil {
+		return nil, nil
+	}
+	if set := found.dnssets[dns.DNSSetKey(dnsName, "")]; set != nil {
+		return found.zone, set.Sets.Clone()
+	}
+	if dnsName != found.zone.Domain() {
+		if i := strings.Index(dnsName, "."); i > 0 {
+			if set := found.dnssets[dns.DNSSetKey("*"+dnsName[i:], "")]; set != nil {
+				return found.zone, set.Sets.Clone()
+			}
+		}
+	}
+	return found.zone, nil
+}
+
 type DumpDNSHostedZone struct {
 	ProviderType     string
 	Key              string
 	Id               string
 	Domain           string
 	ForwardedDomains []string
+	Private          bool
 }
 
 type ZoneDump struct {
@@ -167,22 +375,7 @@ func (m *InMemory) buildZoneDump(zoneId dns.ZoneID) *ZoneDump {
 		return nil
 	}
 	hostedZone := DumpDNSHostedZone{ProviderType: data.zone.Id().ProviderType, Id: data.zone.Id().ID, Domain: data.zone.Domain(),
-		Key: data.zone.Key(), ForwardedDomains: data.zone.ForwardedDomains()}
-
-	return &ZoneDump{HostedZone: hostedZone, DNSSets: data.dnssets}
-}
-
-/*
-func (m *InMemory) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	fullDump := m.BuildFullDump()
-
-	js, err := json.Marshal(*fullDump)
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+		Key: data.zone.Key(), ForwardedDomains: data.zone.ForwardedDomains(), Private: data.zone.IsPrivate()}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.Write(js)
+	return &ZoneDump{HostedZone: hostedZone, DNSSets: data.dnssets.Clone()}
 }
-*/
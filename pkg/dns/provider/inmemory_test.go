@@ -0,0 +1,143 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"errors"
+	"path/filepath"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("InMemory", func() {
+	var (
+		mock   *InMemory
+		zone   DNSHostedZone
+		failed error
+	)
+
+	request := func(action, name, value string) *ChangeRequest {
+		set := dns.NewDNSSet(name)
+		set.SetRecordSet(dns.RS_A, 300, value)
+		if action == R_DELETE {
+			return NewChangeRequest(action, dns.RS_A, set, nil, nil)
+		}
+		return NewChangeRequest(action, dns.RS_A, nil, set, nil)
+	}
+
+	names := func() []string {
+		state, err := mock.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		result := []string{}
+		for _, set := range state.GetDNSSets() {
+			result = append(result, set.Name)
+		}
+		return result
+	}
+
+	ginkgov2.BeforeEach(func() {
+		mock = NewInMemory()
+		zone = NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false)
+		mock.AddZone(zone)
+		failed = errors.New("injected")
+	})
+
+	ginkgov2.It("applies all requests of a transaction or none", func() {
+		Expect(mock.ApplyTransaction(zone.Id(), []*ChangeRequest{
+			request(R_CREATE, "a.example.com", "1.1.1.1"),
+			request(R_CREATE, "b.example.com", "2.2.2.2"),
+		}, &NullMetrics{})).To(Succeed())
+		Expect(names()).To(ConsistOf("a.example.com", "b.example.com"))
+
+		mock.InjectFailure(zone.Id(), "d.example.com", "", failed)
+		failing := request(R_CREATE, "d.example.com", "4.4.4.4")
+		err := mock.ApplyTransaction(zone.Id(), []*ChangeRequest{
+			request(R_DELETE, "a.example.com", "1.1.1.1"),
+			request(R_CREATE, "c.example.com", "3.3.3.3"),
+			failing,
+		}, &NullMetrics{})
+		var terr *TransactionError
+		Expect(errors.As(err, &terr)).To(BeTrue())
+		Expect(terr.Request).To(BeIdenticalTo(failing))
+		Expect(errors.Is(err, failed)).To(BeTrue())
+		Expect(names()).To(ConsistOf("a.example.com", "b.example.com"))
+	})
+
+	ginkgov2.It("injects failures per record type", func() {
+		mock.InjectFailure(zone.Id(), "a.example.com", dns.RS_AAAA, failed)
+		Expect(mock.Apply(zone.Id(), request(R_CREATE, "a.example.com", "1.1.1.1"), &NullMetrics{})).To(Succeed())
+
+		mock.InjectFailure(zone.Id(), "a.example.com", dns.RS_A, failed)
+		Expect(mock.Apply(zone.Id(), request(R_UPDATE, "a.example.com", "2.2.2.2"), &NullMetrics{})).To(MatchError(failed))
+
+		mock.ClearFailures()
+		Expect(mock.Apply(zone.Id(), request(R_UPDATE, "a.example.com", "2.2.2.2"), &NullMetrics{})).To(Succeed())
+	})
+
+	ginkgov2.It("restores snapshots", func() {
+		Expect(mock.Apply(zone.Id(), request(R_CREATE, "a.example.com", "1.1.1.1"), &NullMetrics{})).To(Succeed())
+		snapshot := mock.Snapshot()
+
+		Expect(mock.Apply(zone.Id(), request(R_DELETE, "a.example.com", "1.1.1.1"), &NullMetrics{})).To(Succeed())
+		Expect(mock.Apply(zone.Id(), request(R_CREATE, "b.example.com", "2.2.2.2"), &NullMetrics{})).To(Succeed())
+		Expect(names()).To(ConsistOf("b.example.com"))
+
+		mock.Restore(snapshot)
+		Expect(names()).To(ConsistOf("a.example.com"))
+		mock.Restore(snapshot)
+		Expect(names()).To(ConsistOf("a.example.com"))
+	})
+
+	ginkgov2.It("persists zones to a file", func() {
+		path := filepath.Join(ginkgov2.GinkgoT().TempDir(), "zones.json")
+		Expect(mock.SetPersistence(NewFileInMemoryPersistence(path))).To(Succeed())
+		Expect(mock.Apply(zone.Id(), request(R_CREATE, "a.example.com", "1.1.1.1"), &NullMetrics{})).To(Succeed())
+
+		restored := NewInMemory()
+		restored.AddZone(zone)
+		restored.AddZone(NewDNSHostedZone("mock", "Z2", "example.org", "", nil, false))
+		Expect(restored.SetPersistence(NewFileInMemoryPersistence(path))).To(Succeed())
+		mock = restored
+		Expect(names()).To(ConsistOf("a.example.com"))
+		Expect(restored.GetZones()).To(HaveLen(2))
+	})
+
+	ginkgov2.It("looks up record sets of the most specific zone", func() {
+		sub := NewDNSHostedZone("mock", "Z2", "sub.example.com", "", nil, false)
+		mock.AddZone(sub)
+		Expect(mock.Apply(zone.Id(), request(R_CREATE, "a.example.com", "1.1.1.1"), &NullMetrics{})).To(Succeed())
+		Expect(mock.Apply(sub.Id(), request(R_CREATE, "*.sub.example.com", "2.2.2.2"), &NullMetrics{})).To(Succeed())
+
+		found, sets := mock.Lookup("a.example.com")
+		Expect(found.Id()).To(Equal(zone.Id()))
+		Expect(sets[dns.RS_A].Records[0].Value).To(Equal("1.1.1.1"))
+
+		found, sets = mock.Lookup("x.sub.example.com")
+		Expect(found.Id()).To(Equal(sub.Id()))
+		Expect(sets[dns.RS_A].Records[0].Value).To(Equal("2.2.2.2"))
+
+		found, sets = mock.Lookup("b.example.com")
+		Expect(found.Id()).To(Equal(zone.Id()))
+		Expect(sets).To(BeEmpty())
+
+		found, _ = mock.Lookup("example.org")
+		Expect(found).To(BeNil())
+	})
+})
@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// fileInMemoryPersistence stores the zones of an InMemory as JSON file.
+type fileInMemoryPersistence struct {
+	path string
+}
+
+var _ InMemoryPersistence = &fileInMemoryPersistence{}
+
+// NewFileInMemoryPersistence returns a persistence storing the zones of an InMemory in the given JSON file.
+// The file is replaced atomically on every change.
+func NewFileInMemoryPersistence(path string) InMemoryPersistence {
+	return &fileInMemoryPersistence{path: path}
+}
+
+func (this *fileInMemoryPersistence) Load() ([]*ZoneDump, error) {
+	data, err := os.ReadFile(this.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var zones []*ZoneDump
+	if err := json.Unmarshal(data, &zones); err != nil {
+		return nil, fmt.Errorf("invalid zone file %s: %w", this.path, err)
+	}
+	return zones, nil
+}
+
+func (this *fileInMemoryPersistence) Store(zones []*ZoneDump) error {
+	data, err := json.MarshalIndent(zones, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(this.path), 0755); err != nil {
+		return err
+	}
+	tmp := this.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, this.path)
+}
@@ -19,6 +19,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
@@ -27,7 +28,10 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/manifests"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -35,18 +39,59 @@ import (
 )
 
 type Config struct {
-	TTL                int64
-	CacheTTL           time.Duration
-	RescheduleDelay    time.Duration
-	StatusCheckPeriod  time.Duration
-	Ident              string
-	Dryrun             bool
-	ZoneStateCaching   bool
-	Delay              time.Duration
-	Enabled            utils.StringSet
-	Options            *FactoryOptions
-	Factory            DNSHandlerFactory
-	RemoteAccessConfig *embed.RemoteAccessServerConfig
+	TTL                       int64
+	CacheTTL                  time.Duration
+	RescheduleDelay           time.Duration
+	StatusCheckPeriod         time.Duration
+	Ident                     string
+	Dryrun                    bool
+	ZoneStateCaching          bool
+	ZoneStateCacheDir         string
+	ZoneStateCacheAge         time.Duration
+	ZoneStateFullSync         time.Duration
+	ZoneStateMaxStale         time.Duration
+	ZoneStateTTLJitter        int
+	ZoneWarmupDelay           time.Duration
+	VerificationPeriod        time.Duration
+	VerificationDelay         time.Duration
+	QueryMetricsPeriod        time.Duration
+	DNSSECCheckPeriod         time.Duration
+	WritePermissionPeriod     time.Duration
+	ZoneOwnershipPeriod       time.Duration
+	CanaryPeriod              time.Duration
+	CanaryLabel               string
+	MetricsZones              string
+	WatchdogThreshold         time.Duration
+	CostLabel                 string
+	CostReport                bool
+	WriteFreeze               bool
+	WriteFreezeSwitch         bool
+	WriteFreezeConfigMap      resources.ObjectName
+	ErrorHistorySize          int
+	RecordHistorySize         int
+	AnomalyGuardMax           int
+	AnomalyGuardWindow        time.Duration
+	AnomalyGuardPause         time.Duration
+	FlapWindow                time.Duration
+	FlapThreshold             int
+	FlapHoldDown              time.Duration
+	OwnerConflictPolicy       string
+	NamespaceOwnerIds         *NamespaceOwnerIdTemplate
+	CRDManagement             string
+	AuditLogFile              string
+	AuditLogEvents            bool
+	AuditLogWebhook           string
+	AuditLogSigningKey        string
+	ExternalDataEndpoint      bool
+	ZoneStateEndpoint         bool
+	DomainMatchEndpoint       bool
+	IncludeSuggestionEndpoint bool
+	Delay                     time.Duration
+	Enabled                   utils.StringSet
+	Options                   *FactoryOptions
+	Factory                   DNSHandlerFactory
+	RemoteAccessConfig        *embed.RemoteAccessServerConfig
+	ConversionWebhook         *ConversionWebhookConfig
 }
 
 func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (*Config, error) {
@@ -88,7 +133,129 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		return nil, err
 	}
 
+	conversionWebhook, err := createConversionWebhookConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
 	disableZoneStateCaching, _ := c.GetBoolOption(OPT_DISABLE_ZONE_STATE_CACHING)
+	zoneStateCacheDir, _ := c.GetStringOption(OPT_ZONE_STATE_CACHE_DIR)
+	zoneStateCacheAge, err := c.GetDurationOption(OPT_ZONE_STATE_CACHE_MAX_AGE)
+	if err != nil {
+		zoneStateCacheAge = 1 * time.Hour
+	}
+	zoneStateFullSync, err := c.GetDurationOption(OPT_ZONE_STATE_FULL_SYNC)
+	if err != nil {
+		zoneStateFullSync = 30 * time.Minute
+	}
+
+	zoneStateMaxStale, _ := c.GetDurationOption(OPT_ZONE_STATE_MAX_STALE)
+	zoneStateTTLJitter, _ := c.GetIntOption(OPT_ZONE_STATE_TTL_JITTER)
+	if zoneStateTTLJitter < 0 || zoneStateTTLJitter > 100 {
+		return nil, fmt.Errorf("invalid %s: %d (expected 0-100)", OPT_ZONE_STATE_TTL_JITTER, zoneStateTTLJitter)
+	}
+	zoneWarmupDelay, _ := c.GetDurationOption(OPT_ZONE_WARMUP_DELAY)
+
+	verificationPeriod, _ := c.GetDurationOption(OPT_ZONE_VERIFICATION_PERIOD)
+	verificationDelay, err := c.GetDurationOption(OPT_ZONE_VERIFICATION_DELAY)
+	if err != nil {
+		verificationDelay = 30 * time.Second
+	}
+
+	queryMetricsPeriod, _ := c.GetDurationOption(OPT_QUERY_METRICS_PERIOD)
+	dnssecCheckPeriod, _ := c.GetDurationOption(OPT_DNSSEC_CHECK_PERIOD)
+	writePermissionPeriod, _ := c.GetDurationOption(OPT_WRITE_PERMISSION_PERIOD)
+	zoneOwnershipPeriod, _ := c.GetDurationOption(OPT_ZONE_OWNERSHIP_PERIOD)
+	canaryPeriod, _ := c.GetDurationOption(OPT_CANARY_PERIOD)
+	canaryLabel, _ := c.GetStringOption(OPT_CANARY_LABEL)
+	if canaryPeriod > 0 && (canaryLabel == "" || strings.Contains(canaryLabel, ".")) {
+		return nil, fmt.Errorf("invalid canary label %q: must be a single DNS label", canaryLabel)
+	}
+
+	auditLogFile, _ := c.GetStringOption(OPT_AUDIT_LOG_FILE)
+	auditLogEvents, _ := c.GetBoolOption(OPT_AUDIT_LOG_EVENTS)
+	auditLogWebhook, _ := c.GetStringOption(OPT_AUDIT_LOG_WEBHOOK)
+	auditLogSigningKey, _ := c.GetStringOption(OPT_AUDIT_LOG_SIGNING_KEY)
+	externalDataEndpoint, _ := c.GetBoolOption(OPT_EXTERNAL_DATA_ENDPOINT)
+	zoneStateEndpoint, _ := c.GetBoolOption(OPT_ZONE_STATE_ENDPOINT)
+	domainMatchEndpoint, _ := c.GetBoolOption(OPT_DOMAIN_MATCH_ENDPOINT)
+	includeSuggestionEndpoint, _ := c.GetBoolOption(OPT_INCLUDE_SUGGESTION_ENDPOINT)
+
+	watchdogThreshold, err := c.GetDurationOption(OPT_WATCHDOG_THRESHOLD)
+	if err != nil {
+		watchdogThreshold = 15 * time.Minute
+	}
+
+	costLabel, _ := c.GetStringOption(OPT_COST_ATTRIBUTION_LABEL)
+	costReport, _ := c.GetBoolOption(OPT_COST_REPORT)
+	writeFreeze, _ := c.GetBoolOption(OPT_WRITE_FREEZE)
+	writeFreezeSwitch, _ := c.GetBoolOption(OPT_WRITE_FREEZE_ENDPOINT)
+	var writeFreezeConfigMap resources.ObjectName
+	if name, _ := c.GetStringOption(OPT_WRITE_FREEZE_CONFIGMAP); name != "" {
+		parts := strings.Split(name, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid format for %s: expected '<namespace>/<name>'", OPT_WRITE_FREEZE_CONFIGMAP)
+		}
+		writeFreezeConfigMap = resources.NewObjectName(parts[0], parts[1])
+	}
+	errorHistorySize, err := c.GetIntOption(OPT_ERROR_HISTORY_SIZE)
+	if err != nil || errorHistorySize < 0 {
+		errorHistorySize = 0
+	}
+	recordHistorySize, err := c.GetIntOption(OPT_RECORD_HISTORY_SIZE)
+	if err != nil || recordHistorySize < 0 {
+		recordHistorySize = 0
+	}
+
+	anomalyGuardMax, err := c.GetIntOption(OPT_ANOMALY_GUARD_MAX_CHANGES)
+	if err != nil || anomalyGuardMax < 0 {
+		anomalyGuardMax = 0
+	}
+	anomalyGuardWindow, err := c.GetDurationOption(OPT_ANOMALY_GUARD_WINDOW)
+	if err != nil {
+		anomalyGuardWindow = 5 * time.Minute
+	}
+	anomalyGuardPause, _ := c.GetDurationOption(OPT_ANOMALY_GUARD_PAUSE)
+
+	flapWindow, _ := c.GetDurationOption(OPT_FLAP_DETECTION_WINDOW)
+	flapThreshold, err := c.GetIntOption(OPT_FLAP_DETECTION_THRESHOLD)
+	if err != nil {
+		flapThreshold = 3
+	}
+	flapHoldDown, err := c.GetDurationOption(OPT_FLAP_DAMPENING_HOLD_DOWN)
+	if err != nil {
+		flapHoldDown = 2 * time.Minute
+	}
+
+	ownerConflictPolicy, err := c.GetStringOption(OPT_OWNER_CONFLICT_RESOLUTION)
+	if err != nil || ownerConflictPolicy == "" {
+		ownerConflictPolicy = OWNER_CONFLICT_RESOLUTION_NONE
+	}
+	switch ownerConflictPolicy {
+	case OWNER_CONFLICT_RESOLUTION_NONE, OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY:
+	default:
+		return nil, fmt.Errorf("invalid owner conflict resolution %q (allowed: %s, %s)", ownerConflictPolicy,
+			OWNER_CONFLICT_RESOLUTION_NONE, OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY)
+	}
+
+	namespaceOwnerId, _ := c.GetStringOption(OPT_NAMESPACE_OWNER_ID)
+	namespaceOwnerIds, err := ParseNamespaceOwnerIdTemplate(namespaceOwnerId)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", OPT_NAMESPACE_OWNER_ID, err)
+	}
+
+	crdManagement, err := c.GetStringOption(OPT_CRD_MANAGEMENT)
+	if err != nil || crdManagement == "" {
+		crdManagement = manifests.CRDManagementOff
+	}
+	if err := manifests.ValidateCRDManagementMode(crdManagement); err != nil {
+		return nil, err
+	}
+
+	metricsZones, err := c.GetStringOption(OPT_METRICS_ZONE_ALLOWLIST)
+	if err != nil {
+		metricsZones = metrics.ZoneLabelsAll
+	}
 
 	enabled := utils.StringSet{}
 	types, err := c.GetStringOption(OPT_PROVIDERTYPES)
@@ -112,18 +279,59 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 	fopts := GetFactoryOptions(osrc)
 
 	return &Config{
-		Ident:              ident,
-		TTL:                int64(ttl),
-		CacheTTL:           time.Duration(cttl) * time.Second,
-		RescheduleDelay:    rescheduleDelay,
-		StatusCheckPeriod:  statuscheckperiod,
-		Dryrun:             dryrun,
-		ZoneStateCaching:   !disableZoneStateCaching,
-		Delay:              delay,
-		Enabled:            enabled,
-		Options:            fopts,
-		Factory:            factory,
-		RemoteAccessConfig: remoteAccessConfig,
+		Ident:                     ident,
+		TTL:                       int64(ttl),
+		CacheTTL:                  time.Duration(cttl) * time.Second,
+		RescheduleDelay:           rescheduleDelay,
+		StatusCheckPeriod:         statuscheckperiod,
+		Dryrun:                    dryrun,
+		ZoneStateCaching:          !disableZoneStateCaching,
+		ZoneStateCacheDir:         zoneStateCacheDir,
+		ZoneStateCacheAge:         zoneStateCacheAge,
+		ZoneStateFullSync:         zoneStateFullSync,
+		ZoneStateMaxStale:         zoneStateMaxStale,
+		ZoneStateTTLJitter:        zoneStateTTLJitter,
+		ZoneWarmupDelay:           zoneWarmupDelay,
+		VerificationPeriod:        verificationPeriod,
+		VerificationDelay:         verificationDelay,
+		QueryMetricsPeriod:        queryMetricsPeriod,
+		DNSSECCheckPeriod:         dnssecCheckPeriod,
+		WritePermissionPeriod:     writePermissionPeriod,
+		ZoneOwnershipPeriod:       zoneOwnershipPeriod,
+		CanaryPeriod:              canaryPeriod,
+		CanaryLabel:               canaryLabel,
+		MetricsZones:              metricsZones,
+		WatchdogThreshold:         watchdogThreshold,
+		CostLabel:                 costLabel,
+		CostReport:                costReport,
+		WriteFreeze:               writeFreeze,
+		WriteFreezeSwitch:         writeFreezeSwitch,
+		WriteFreezeConfigMap:      writeFreezeConfigMap,
+		ErrorHistorySize:          errorHistorySize,
+		RecordHistorySize:         recordHistorySize,
+		AnomalyGuardMax:           anomalyGuardMax,
+		AnomalyGuardWindow:        anomalyGuardWindow,
+		AnomalyGuardPause:         anomalyGuardPause,
+		FlapWindow:                flapWindow,
+		FlapThreshold:             flapThreshold,
+		FlapHoldDown:              flapHoldDown,
+		OwnerConflictPolicy:       ownerConflictPolicy,
+		NamespaceOwnerIds:         namespaceOwnerIds,
+		CRDManagement:             crdManagement,
+		AuditLogFile:              auditLogFile,
+		AuditLogEvents:            auditLogEvents,
+		AuditLogWebhook:           auditLogWebhook,
+		AuditLogSigningKey:        auditLogSigningKey,
+		ExternalDataEndpoint:      externalDataEndpoint,
+		ZoneStateEndpoint:         zoneStateEndpoint,
+		DomainMatchEndpoint:       domainMatchEndpoint,
+		IncludeSuggestionEndpoint: includeSuggestionEndpoint,
+		Delay:                     delay,
+		Enabled:                   enabled,
+		Options:                   fopts,
+		Factory:                   factory,
+		RemoteAccessConfig:        remoteAccessConfig,
+		ConversionWebhook:         conversionWebhook,
 	}, nil
 }
 
@@ -164,6 +372,14 @@ type DNSHandlerConfig struct {
 	Options          *FactoryOptions
 	Metrics          Metrics
 	RateLimiter      flowcontrol.RateLimiter
+	// Timeouts is filled with the (provider type specific) timeouts for the handler calls on completion
+	Timeouts *TimeoutConfig
+	// ZoneStateConcurrency is filled with the (provider type specific) maximum number of zone states
+	// fetched in parallel on completion. It is limited by the burst of the rate limiter.
+	ZoneStateConcurrency *int
+	// ResourceTags are the tags to attach to created objects, if supported by the provider type.
+	// On completion, tags of the DNS provider annotation are merged with the configured ones.
+	ResourceTags map[string]string
 }
 
 type DNSZoneState interface {
@@ -177,6 +393,9 @@ const (
 	M_LISTRECORDS  = "list_records"
 	M_PLISTRECORDS = "list_records_pages"
 
+	M_LISTCHANGES  = "list_changes"
+	M_PLISTCHANGES = "list_changes_pages"
+
 	M_UPDATERECORDS = "update_records"
 	M_PUPDATEREORDS = "update_records_pages"
 
@@ -185,6 +404,18 @@ const (
 
 	M_CACHED_GETZONES     = "cached_getzones"
 	M_CACHED_GETZONESTATE = "cached_getzonestate"
+
+	M_INCREMENTAL_GETZONESTATE = "incremental_getzonestate"
+
+	M_QUERYMETRICS = "query_metrics"
+
+	M_HEALTHCHECKS = "health_checks"
+
+	M_DNSSEC = "dnssec"
+
+	M_ZONEMETADATA = "zone_metadata"
+
+	M_WRITEPERMISSION = "write_permission"
 )
 
 type Metrics interface {
@@ -198,11 +429,17 @@ type Finalizers interface {
 
 type DNSHandler interface {
 	ProviderType() string
-	GetZones() (DNSHostedZones, error)
-	GetZoneState(DNSHostedZone) (DNSZoneState, error)
+	GetZones(ctx context.Context) (DNSHostedZones, error)
+	GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error)
 	ReportZoneStateConflict(zone DNSHostedZone, err error) bool
-	ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error
+	ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error
 	MapTarget(t Target) Target
+	// ValidateRoutingPolicy checks whether a routing policy is supported by the handler
+	ValidateRoutingPolicy(policy *dns.RoutingPolicy) error
+	// SupportsRecordType checks whether record sets of the given type can be managed by the handler
+	SupportsRecordType(rtype string) bool
+	// ClassifyError maps an error of the DNS API to an error reason, ReasonUnknown if not applicable
+	ClassifyError(err error) perrs.ErrorReason
 	Release()
 }
 
@@ -222,6 +459,18 @@ func (this *DefaultDNSHandler) MapTarget(t Target) Target {
 	return t
 }
 
+func (this *DefaultDNSHandler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return fmt.Errorf("routing policy %s not supported by provider type %s", policy.Type, this.providerType)
+}
+
+func (this *DefaultDNSHandler) SupportsRecordType(rtype string) bool {
+	return dns.SupportedRecordType(rtype)
+}
+
+func (this *DefaultDNSHandler) ClassifyError(err error) perrs.ErrorReason {
+	return perrs.ReasonUnknown
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type DNSHandlerOptionSource interface {
@@ -244,15 +493,22 @@ type DNSProvider interface {
 	TypeCode() string
 
 	DefaultTTL() int64
+	// IsReadOnly returns true if changes must not be applied for the provider
+	IsReadOnly() bool
+	// IsDryRun returns true if changes are only planned and reported, but not applied for the provider
+	IsDryRun() bool
 
 	GetZones() DNSHostedZones
 	IncludesZone(zoneID dns.ZoneID) bool
 	HasEquivalentZone(zoneID dns.ZoneID) bool
 
-	GetZoneState(zone DNSHostedZone) (DNSZoneState, error)
-	ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, requests []*ChangeRequest) error
+	GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error)
+	ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, requests []*ChangeRequest) error
 
 	GetDedicatedDNSAccess() DedicatedDNSAccess
+	GetQueryMetricsAccess() QueryMetricsAccess
+	GetDNSSECAccess() DNSSECAccess
+	GetZoneOwnershipAccess() ZoneOwnershipAccess
 
 	Match(dns string) int
 	MatchZone(dns string) int
@@ -260,6 +516,10 @@ type DNSProvider interface {
 
 	AccountHash() string
 	MapTarget(t Target) Target
+	ValidateRoutingPolicy(policy *dns.RoutingPolicy) error
+	SupportsRecordType(rtype string) bool
+	// ProviderHintsFor returns the provider hints applicable for a record set (nil if none)
+	ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints
 
 	// ReportZoneStateConflict is used to report a conflict because of stale data.
 	// It returns true if zone data will be updated and a retry may resolve the conflict
@@ -269,7 +529,11 @@ type DNSProvider interface {
 type DoneHandler interface {
 	SetInvalid(err error)
 	Failed(err error)
-	Throttled()
+	// Throttled reports changes delayed by throttling, retryAfter is the delay until the next attempt (0 if unknown)
+	Throttled(retryAfter time.Duration)
+	// Blocked reports planned changes, which are not applied because of a read-only provider,
+	// the dry-run mode or a write freeze
+	Blocked(reason string, planned []string)
 	Succeeded()
 }
 
@@ -280,7 +544,55 @@ type ProviderEventListener interface {
 
 type LightDNSHandler interface {
 	ProviderType() string
-	GetZones() (DNSHostedZones, error)
-	GetZoneState(DNSHostedZone) (DNSZoneState, error)
-	ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error
+	GetZones(ctx context.Context) (DNSHostedZones, error)
+	GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error)
+	ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error
+	// ValidateRoutingPolicy checks whether a routing policy is supported by the handler
+	ValidateRoutingPolicy(policy *dns.RoutingPolicy) error
+	// SupportsRecordType checks whether record sets of the given type can be managed by the handler
+	SupportsRecordType(rtype string) bool
+	// SupportedRoutingPolicyTypes returns the routing policy types supported by the handler
+	SupportedRoutingPolicyTypes() []string
+}
+
+// RoutingPolicySupport is optionally implemented by DNS handlers supporting routing policies.
+type RoutingPolicySupport interface {
+	// SupportedRoutingPolicyTypes returns the routing policy types supported by the handler
+	SupportedRoutingPolicyTypes() []string
+}
+
+// ConfigUpdateSupport is optionally implemented by DNS handlers able to adopt a changed provider config
+// or changed resource tags without being recreated, so that their zone cache survives the change.
+type ConfigUpdateSupport interface {
+	// UpdateConfig takes over the provider config and the resource tags of the completed handler config.
+	// The handler keeps its rate limiter, timeouts and zone cache.
+	// If an error is returned, the handler must be left unchanged and is replaced by a new one.
+	UpdateConfig(config *DNSHandlerConfig) error
+}
+
+// CredentialsUpdateSupport is optionally implemented by DNS handlers able to adopt rotated credentials
+// by swapping their API clients without being recreated, so that their zone cache survives the rotation.
+// It is implemented by the aws-route53 and google-clouddns handlers only, for all other handlers
+// rotated credentials recreate the account.
+type CredentialsUpdateSupport interface {
+	// UpdateCredentials takes over the credentials of the properties of the completed handler config.
+	// The handler keeps its rate limiter, timeouts and zone cache.
+	// If an error is returned, the handler is replaced by a new one.
+	UpdateCredentials(config *DNSHandlerConfig) error
+}
+
+// ProviderHintsSupport is optionally implemented by DNS handlers supporting provider hints of entries.
+type ProviderHintsSupport interface {
+	// ProviderHintsFor returns the effective provider hints for a record set to be created or updated.
+	// The handler must report the same hints for the record set in the zone state.
+	ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints
+}
+
+// WritePermissionProbeSupport is optionally implemented by DNS handlers able to verify the write permission
+// for a hosted zone without modifying it, e.g. by a dry-run change or a policy simulation.
+// Entries are only assigned to a newly created provider after the probe has succeeded for all its zones.
+type WritePermissionProbeSupport interface {
+	// ProbeWritePermission returns an error if the credentials do not allow to change the record sets of the zone.
+	// If the permission cannot be verified at all, an error of type errors.WritePermissionUnverifiable is returned.
+	ProbeWritePermission(ctx context.Context, zone DNSHostedZone) error
 }
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ZoneChangeExecutor executes the change requests for a zone, it is implemented
+// by DNSProvider and DNSHandler.
+type ZoneChangeExecutor interface {
+	ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error
+}
+
+// ZoneChangeSet are the change requests of a multi-zone change for a single zone.
+type ZoneChangeSet struct {
+	Executor ZoneChangeExecutor
+	Zone     DNSHostedZone
+	State    DNSZoneState
+	Requests []*ChangeRequest
+}
+
+// ZoneChangeStatus is the outcome of a multi-zone change for a single zone.
+type ZoneChangeStatus string
+
+const (
+	// ZoneChangeApplied means all changes of the zone are applied.
+	ZoneChangeApplied ZoneChangeStatus = "Applied"
+	// ZoneChangeFailed means the changes of the zone failed, changes already applied to the zone are rolled back.
+	ZoneChangeFailed ZoneChangeStatus = "Failed"
+	// ZoneChangeRolledBack means the changes of the zone were applied, but rolled back because of another zone.
+	ZoneChangeRolledBack ZoneChangeStatus = "RolledBack"
+	// ZoneChangeRollbackFailed means the changes of the zone could not be rolled back, the zone must be
+	// reconciled again to reach a consistent state.
+	ZoneChangeRollbackFailed ZoneChangeStatus = "RollbackFailed"
+	// ZoneChangeSkipped means the changes of the zone were not executed, because a previous zone failed.
+	ZoneChangeSkipped ZoneChangeStatus = "Skipped"
+)
+
+// MultiZoneChange applies the changes of entries spanning several zones all-or-nothing.
+// The zones are changed one after the other. If the changes of a zone fail, the changes of
+// all zones already written (including the successful requests of the failed zone) are rolled
+// back by the inverse requests and the remaining zones are skipped.
+// The done handlers of the requests are only called with the overall result.
+type MultiZoneChange struct {
+	sets []*ZoneChangeSet
+}
+
+// NewMultiZoneChange creates a multi-zone change for the given change sets, which are applied in the given order.
+func NewMultiZoneChange(sets ...*ZoneChangeSet) *MultiZoneChange {
+	return &MultiZoneChange{sets: sets}
+}
+
+// MultiZoneResult is the result of a multi-zone change.
+type MultiZoneResult struct {
+	// Zones is the status per zone
+	Zones map[dns.ZoneID]ZoneChangeStatus
+	// Err is the error of the failed zone, nil if all zones are applied
+	Err error
+}
+
+// Error returns a description of the failed change including the status of all zones, it can
+// be used as status message of the entries.
+func (r *MultiZoneResult) Error() string {
+	var zones []string
+	for id, status := range r.Zones {
+		zones = append(zones, fmt.Sprintf("%s: %s", id, status))
+	}
+	sort.Strings(zones)
+	return fmt.Sprintf("multi-zone change failed: %s (zones %s)", r.Err, strings.Join(zones, ", "))
+}
+
+func (r *MultiZoneResult) Unwrap() error {
+	return r.Err
+}
+
+// Apply executes the change sets and calls the done handlers of all requests with the overall result.
+func (this *MultiZoneChange) Apply(ctx context.Context, logger logger.LogContext) *MultiZoneResult {
+	result := &MultiZoneResult{Zones: map[dns.ZoneID]ZoneChangeStatus{}}
+	var written []*zoneChangeExecution
+	for _, set := range this.sets {
+		if result.Err != nil {
+			result.Zones[set.Zone.Id()] = ZoneChangeSkipped
+			continue
+		}
+		exec := newZoneChangeExecution(set)
+		err := set.Executor.ExecuteRequests(ctx, logger, set.Zone, set.State, exec.requests)
+		if err == nil {
+			err = exec.failure()
+		}
+		written = append(written, exec)
+		if err != nil {
+			logger.Warnf("multi-zone change failed for zone %s: %s", set.Zone.Id(), err)
+			result.Err = err
+			result.Zones[set.Zone.Id()] = ZoneChangeFailed
+			continue
+		}
+		result.Zones[set.Zone.Id()] = ZoneChangeApplied
+	}
+
+	if result.Err != nil {
+		// roll back in reverse order
+		for i := len(written) - 1; i >= 0; i-- {
+			exec := written[i]
+			id := exec.set.Zone.Id()
+			if err := exec.rollback(ctx, logger); err != nil {
+				logger.Errorf("rollback of multi-zone change failed for zone %s: %s", id, err)
+				result.Zones[id] = ZoneChangeRollbackFailed
+			} else if result.Zones[id] == ZoneChangeApplied {
+				result.Zones[id] = ZoneChangeRolledBack
+			}
+		}
+	}
+
+	for _, set := range this.sets {
+		for _, r := range set.Requests {
+			if r.Done == nil {
+				continue
+			}
+			if result.Err != nil {
+				r.Done.Failed(result)
+			} else {
+				r.Done.Succeeded()
+			}
+		}
+	}
+	return result
+}
+
+// zoneChangeExecution executes the requests of a zone with done handlers recording the outcome of every request.
+type zoneChangeExecution struct {
+	set      *ZoneChangeSet
+	requests []*ChangeRequest
+	outcomes []*zoneChangeOutcome
+}
+
+func newZoneChangeExecution(set *ZoneChangeSet) *zoneChangeExecution {
+	exec := &zoneChangeExecution{set: set}
+	for _, r := range set.Requests {
+		outcome := &zoneChangeOutcome{}
+		exec.outcomes = append(exec.outcomes, outcome)
+		exec.requests = append(exec.requests, NewChangeRequest(r.Action, r.Type, r.Deletion, r.Addition, outcome))
+	}
+	return exec
+}
+
+// failure returns an error if a request was not applied, even if the executor didn't report an error.
+func (this *zoneChangeExecution) failure() error {
+	for i, o := range this.outcomes {
+		if !o.succeeded() {
+			return fmt.Errorf("%s %s %s not applied: %s", this.requests[i].Action, this.requests[i].Type, requestName(this.requests[i]), o.reason())
+		}
+	}
+	return nil
+}
+
+// rollback executes the inverse requests of all successfully applied requests.
+func (this *zoneChangeExecution) rollback(ctx context.Context, logger logger.LogContext) error {
+	var inverse []*ChangeRequest
+	var outcomes []*zoneChangeOutcome
+	for i := len(this.requests) - 1; i >= 0; i-- {
+		if !this.outcomes[i].succeeded() {
+			continue
+		}
+		outcome := &zoneChangeOutcome{}
+		outcomes = append(outcomes, outcome)
+		inverse = append(inverse, inverseRequest(this.requests[i], outcome))
+	}
+	if len(inverse) == 0 {
+		return nil
+	}
+	if err := this.set.Executor.ExecuteRequests(ctx, logger, this.set.Zone, this.set.State, inverse); err != nil {
+		return err
+	}
+	for i, o := range outcomes {
+		if !o.succeeded() {
+			return fmt.Errorf("%s %s %s not applied: %s", inverse[i].Action, inverse[i].Type, requestName(inverse[i]), o.reason())
+		}
+	}
+	return nil
+}
+
+// inverseRequest returns the request reverting a change request.
+func inverseRequest(r *ChangeRequest, done DoneHandler) *ChangeRequest {
+	switch r.Action {
+	case R_CREATE:
+		return NewChangeRequest(R_DELETE, r.Type, r.Addition, nil, done)
+	case R_DELETE:
+		return NewChangeRequest(R_CREATE, r.Type, nil, r.Deletion, done)
+	default:
+		return NewChangeRequest(R_UPDATE, r.Type, r.Addition, r.Deletion, done)
+	}
+}
+
+func requestName(r *ChangeRequest) string {
+	if r.Addition != nil {
+		return r.Addition.Name
+	}
+	if r.Deletion != nil {
+		return r.Deletion.Name
+	}
+	return ""
+}
+
+// zoneChangeOutcome is a DoneHandler recording the outcome of a single request.
+type zoneChangeOutcome struct {
+	lock sync.Mutex
+	done bool
+	err  error
+	msg  string
+}
+
+var _ DoneHandler = &zoneChangeOutcome{}
+
+func (this *zoneChangeOutcome) set(done bool, err error, msg string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.done, this.err, this.msg = done, err, msg
+}
+
+func (this *zoneChangeOutcome) SetInvalid(err error) { this.set(false, err, "") }
+func (this *zoneChangeOutcome) Failed(err error)     { this.set(false, err, "") }
+func (this *zoneChangeOutcome) Succeeded()           { this.set(true, nil, "") }
+
+func (this *zoneChangeOutcome) Throttled(retryAfter time.Duration) {
+	this.set(false, nil, "throttled")
+}
+
+func (this *zoneChangeOutcome) Blocked(reason string, _ []string) {
+	this.set(false, nil, reason)
+}
+
+func (this *zoneChangeOutcome) succeeded() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.done
+}
+
+func (this *zoneChangeOutcome) reason() string {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	switch {
+	case this.err != nil:
+		return this.err.Error()
+	case this.msg != "":
+		return this.msg
+	default:
+		return "no result reported"
+	}
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// inMemoryExecutor executes change requests one by one on an InMemory.
+type inMemoryExecutor struct {
+	mock  *InMemory
+	calls int
+	// failFromCall lets all requests fail starting with the given call
+	failFromCall int
+}
+
+func (e *inMemoryExecutor) ExecuteRequests(_ context.Context, _ logger.LogContext, zone DNSHostedZone, _ DNSZoneState, reqs []*ChangeRequest) error {
+	e.calls++
+	var failed error
+	for _, r := range reqs {
+		var err error
+		if e.failFromCall > 0 && e.calls >= e.failFromCall {
+			err = errors.New("provider unavailable")
+		} else {
+			err = e.mock.Apply(zone.Id(), r, &NullMetrics{})
+		}
+		if err != nil {
+			failed = err
+			r.Done.Failed(err)
+		} else {
+			r.Done.Succeeded()
+		}
+	}
+	return failed
+}
+
+type recordingDoneHandler struct {
+	succeeded int
+	failed    []error
+}
+
+func (h *recordingDoneHandler) SetInvalid(err error)         { h.failed = append(h.failed, err) }
+func (h *recordingDoneHandler) Failed(err error)             { h.failed = append(h.failed, err) }
+func (h *recordingDoneHandler) Throttled(_ time.Duration)    {}
+func (h *recordingDoneHandler) Blocked(_ string, _ []string) {}
+func (h *recordingDoneHandler) Succeeded()                   { h.succeeded++ }
+
+var _ = ginkgov2.Describe("MultiZoneChange", func() {
+	var (
+		mock     *InMemory
+		exec     *inMemoryExecutor
+		zones    []DNSHostedZone
+		done     *recordingDoneHandler
+		injected error
+	)
+
+	set := func(name, value string) *dns.DNSSet {
+		s := dns.NewDNSSet(name)
+		s.SetRecordSet(dns.RS_A, 300, value)
+		return s
+	}
+	create := func(name, value string) *ChangeRequest {
+		return NewChangeRequest(R_CREATE, dns.RS_A, nil, set(name, value), done)
+	}
+	update := func(name, old, new string) *ChangeRequest {
+		return NewChangeRequest(R_UPDATE, dns.RS_A, set(name, old), set(name, new), done)
+	}
+	remove := func(name, value string) *ChangeRequest {
+		return NewChangeRequest(R_DELETE, dns.RS_A, set(name, value), nil, done)
+	}
+	changeSet := func(i int, reqs ...*ChangeRequest) *ZoneChangeSet {
+		return &ZoneChangeSet{Executor: exec, Zone: zones[i], Requests: reqs}
+	}
+	records := func(i int) map[string]string {
+		state, err := mock.CloneZoneState(zones[i])
+		Expect(err).NotTo(HaveOccurred())
+		result := map[string]string{}
+		for _, s := range state.GetDNSSets() {
+			result[s.Name] = s.Sets[dns.RS_A].Records[0].Value
+		}
+		return result
+	}
+
+	ginkgov2.BeforeEach(func() {
+		mock = NewInMemory()
+		exec = &inMemoryExecutor{mock: mock}
+		done = &recordingDoneHandler{}
+		injected = errors.New("injected")
+		zones = []DNSHostedZone{
+			NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+			NewDNSHostedZone("mock", "Z2", "example.org", "", nil, false),
+			NewDNSHostedZone("mock", "Z3", "example.net", "", nil, false),
+		}
+		for i, z := range zones {
+			mock.AddZone(z)
+			Expect(mock.Apply(z.Id(), create("old."+z.Domain(), "1.1.1.1"), &NullMetrics{})).To(Succeed())
+			Expect(mock.Apply(z.Id(), create("keep."+z.Domain(), "2.2.2.2"), &NullMetrics{})).To(Succeed())
+			Expect(records(i)).To(HaveLen(2))
+		}
+	})
+
+	ginkgov2.It("applies the changes of all zones", func() {
+		result := NewMultiZoneChange(
+			changeSet(0, create("new.example.com", "3.3.3.3"), update("keep.example.com", "2.2.2.2", "4.4.4.4")),
+			changeSet(1, remove("old.example.org", "1.1.1.1")),
+		).Apply(context.Background(), logger.New())
+
+		Expect(result.Err).NotTo(HaveOccurred())
+		Expect(result.Zones).To(Equal(map[dns.ZoneID]ZoneChangeStatus{
+			zones[0].Id(): ZoneChangeApplied,
+			zones[1].Id(): ZoneChangeApplied,
+		}))
+		Expect(records(0)).To(Equal(map[string]string{"old.example.com": "1.1.1.1", "keep.example.com": "4.4.4.4", "new.example.com": "3.3.3.3"}))
+		Expect(records(1)).To(Equal(map[string]string{"keep.example.org": "2.2.2.2"}))
+		Expect(done.succeeded).To(Equal(3))
+		Expect(done.failed).To(BeEmpty())
+	})
+
+	ginkgov2.It("rolls back the zones already written if a later zone fails", func() {
+		mock.InjectFailure(zones[1].Id(), "fail.example.org", "", injected)
+
+		result := NewMultiZoneChange(
+			changeSet(0, create("new.example.com", "3.3.3.3"), update("keep.example.com", "2.2.2.2", "4.4.4.4"),
+				remove("old.example.com", "1.1.1.1")),
+			changeSet(1, create("new.example.org", "3.3.3.3"), create("fail.example.org", "5.5.5.5")),
+			changeSet(2, create("new.example.net", "3.3.3.3")),
+		).Apply(context.Background(), logger.New())
+
+		Expect(errors.Is(result.Err, injected)).To(BeTrue())
+		Expect(result.Zones).To(Equal(map[dns.ZoneID]ZoneChangeStatus{
+			zones[0].Id(): ZoneChangeRolledBack,
+			zones[1].Id(): ZoneChangeFailed,
+			zones[2].Id(): ZoneChangeSkipped,
+		}))
+		for i, z := range zones {
+			Expect(records(i)).To(Equal(map[string]string{"old." + z.Domain(): "1.1.1.1", "keep." + z.Domain(): "2.2.2.2"}))
+		}
+		Expect(exec.calls).To(Equal(4))
+
+		Expect(done.succeeded).To(BeZero())
+		Expect(done.failed).To(HaveLen(6))
+		Expect(done.failed[0]).To(MatchError(ContainSubstring("injected")))
+		Expect(done.failed[0]).To(MatchError(ContainSubstring("mock/Z1: RolledBack, mock/Z2: Failed, mock/Z3: Skipped")))
+	})
+
+	ginkgov2.It("reports zones which cannot be rolled back", func() {
+		// the second zone and the rollback of the first one fail
+		exec.failFromCall = 2
+
+		result := NewMultiZoneChange(
+			changeSet(0, create("new.example.com", "3.3.3.3")),
+			changeSet(1, create("new.example.org", "3.3.3.3")),
+		).Apply(context.Background(), logger.New())
+
+		Expect(result.Err).To(MatchError("provider unavailable"))
+		Expect(result.Zones).To(Equal(map[dns.ZoneID]ZoneChangeStatus{
+			zones[0].Id(): ZoneChangeRollbackFailed,
+			zones[1].Id(): ZoneChangeFailed,
+		}))
+		Expect(records(0)).To(HaveKey("new.example.com"))
+		Expect(done.failed).To(HaveLen(2))
+	})
+
+	ginkgov2.It("treats requests without result as failed", func() {
+		blocking := &blockingExecutor{}
+		result := NewMultiZoneChange(
+			changeSet(0, create("new.example.com", "3.3.3.3")),
+			&ZoneChangeSet{Executor: blocking, Zone: zones[1], Requests: []*ChangeRequest{create("new.example.org", "3.3.3.3")}},
+		).Apply(context.Background(), logger.New())
+
+		Expect(result.Err).To(MatchError("create A new.example.org not applied: write freeze"))
+		Expect(result.Zones[zones[0].Id()]).To(Equal(ZoneChangeRolledBack))
+		Expect(result.Zones[zones[1].Id()]).To(Equal(ZoneChangeFailed))
+		Expect(records(0)).NotTo(HaveKey("new.example.com"))
+	})
+})
+
+// blockingExecutor blocks all requests without reporting an error.
+type blockingExecutor struct{}
+
+func (e *blockingExecutor) ExecuteRequests(_ context.Context, _ logger.LogContext, _ DNSHostedZone, _ DNSZoneState, reqs []*ChangeRequest) error {
+	for _, r := range reqs {
+		r.Done.Blocked("write freeze", nil)
+	}
+	return nil
+}
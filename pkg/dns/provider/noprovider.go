@@ -0,0 +1,249 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// noProviderPrecedence orders the reasons for a missing provider from the most to the least specific one.
+var noProviderPrecedence = []string{
+	api.PROVIDER_REASON_NOT_READY,
+	api.PROVIDER_REASON_ACCESS_DENIED,
+	api.PROVIDER_REASON_NO_ZONE,
+	api.PROVIDER_REASON_ZONE_FORWARDED,
+	api.PROVIDER_REASON_DOMAIN_EXCLUDED,
+	api.PROVIDER_REASON_OTHER_CLASS,
+	api.PROVIDER_REASON_TYPE_NOT_HANDLED,
+	api.PROVIDER_REASON_NO_PROVIDER,
+}
+
+// noProviderCause describes why a provider is not responsible for a DNS name.
+type noProviderCause struct {
+	reason   string
+	provider string
+	domain   string
+	detail   string
+	// awaitsReadiness is set if the provider is newly created and its readiness probe is pending
+	awaitsReadiness bool
+}
+
+func (this noProviderCause) Message(dnsname string) string {
+	switch this.reason {
+	case api.PROVIDER_REASON_NOT_READY:
+		if this.awaitsReadiness {
+			return fmt.Sprintf("waiting for readiness probe of provider %s including domain %s", this.provider, this.domain)
+		}
+		return fmt.Sprintf("provider %s including domain %s is not ready", this.provider, this.domain)
+	case api.PROVIDER_REASON_ACCESS_DENIED:
+		return fmt.Sprintf("access to provider %s including domain %s denied: %s", this.provider, this.domain, this.detail)
+	case api.PROVIDER_REASON_NO_ZONE:
+		return fmt.Sprintf("provider %s includes domain %s, but has no zone for %q", this.provider, this.domain, dnsname)
+	case api.PROVIDER_REASON_ZONE_FORWARDED:
+		return fmt.Sprintf("domain %s is forwarded to another zone by provider %s", this.domain, this.provider)
+	case api.PROVIDER_REASON_DOMAIN_EXCLUDED:
+		return fmt.Sprintf("domain %s is excluded by provider %s", this.domain, this.provider)
+	case api.PROVIDER_REASON_OTHER_CLASS:
+		return fmt.Sprintf("provider %s including %q belongs to dns class %q", this.provider, dnsname, this.detail)
+	case api.PROVIDER_REASON_TYPE_NOT_HANDLED:
+		return fmt.Sprintf("provider %s including %q has type %q not handled by this controller", this.provider, dnsname, this.detail)
+	}
+	return fmt.Sprintf("no provider includes %q", dnsname)
+}
+
+// causeForProvider determines why a local provider is not responsible for a DNS name.
+// It returns false if the provider does not match the DNS name at all.
+// The name of the provider is left to the caller.
+func causeForProvider(p *dnsProviderVersion, dnsname string, checkAccess func(p *dnsProviderVersion) error) (noProviderCause, bool) {
+	decision, domain := p.matchDecision(dnsname)
+	cause := noProviderCause{domain: domain}
+	switch decision {
+	case MATCH_INCLUDED:
+		if !p.IsValid() {
+			cause.reason = api.PROVIDER_REASON_NOT_READY
+			cause.awaitsReadiness = p.probing
+		} else if err := checkAccess(p); err != nil {
+			cause.reason = api.PROVIDER_REASON_ACCESS_DENIED
+			cause.detail = err.Error()
+		} else {
+			cause.reason = api.PROVIDER_REASON_NO_ZONE
+		}
+	case MATCH_FORWARDED:
+		cause.reason = api.PROVIDER_REASON_ZONE_FORWARDED
+	case MATCH_EXCLUDED_BY_FILTER:
+		cause.reason = api.PROVIDER_REASON_DOMAIN_EXCLUDED
+	default:
+		return cause, false
+	}
+	return cause, true
+}
+
+// selectNoProviderCause selects the most specific cause.
+// For causes with the same reason the provider with the lowest name is used to get a stable message.
+func selectNoProviderCause(causes []noProviderCause) noProviderCause {
+	for _, reason := range noProviderPrecedence {
+		var found *noProviderCause
+		for i := range causes {
+			c := &causes[i]
+			if c.reason == reason && (found == nil || c.provider < found.provider) {
+				found = c
+			}
+		}
+		if found != nil {
+			return *found
+		}
+	}
+	return noProviderCause{reason: api.PROVIDER_REASON_NO_PROVIDER}
+}
+
+// lookupNoProviderCause determines why no provider is responsible for the DNS name of an entry.
+// The state lock must be held by the caller.
+func (this *state) lookupNoProviderCause(e dnsutils.DNSSpecification) noProviderCause {
+	dnsname := e.GetDNSName()
+	checkAccess := func(p *dnsProviderVersion) error {
+		return access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
+	}
+	var causes []noProviderCause
+	for name, p := range this.providers {
+		if c, ok := causeForProvider(p, dnsname, checkAccess); ok {
+			c.provider = name.String()
+			causes = append(causes, c)
+		}
+	}
+	for name, p := range this.foreign {
+		if p.Match(dnsname) > 0 {
+			causes = append(causes, noProviderCause{reason: api.PROVIDER_REASON_TYPE_NOT_HANDLED, provider: name.String(), detail: p.ptype})
+		}
+	}
+	causes = append(causes, this.otherClassProviders(dnsname)...)
+	return selectNoProviderCause(causes)
+}
+
+// otherClassProviders returns the causes for cached providers of other dns classes including the DNS name.
+func (this *state) otherClassProviders(dnsname string) []noProviderCause {
+	var causes []noProviderCause
+	res, err := this.context.GetCluster(PROVIDER_CLUSTER).Resources().GetByExample(&api.DNSProvider{})
+	if err != nil {
+		return nil
+	}
+	list, _ := res.ListCached(labels.Everything())
+	for _, o := range list {
+		if this.IsResponsibleFor(this.context, o) {
+			continue
+		}
+		status := o.Data().(*api.DNSProvider).Status
+		if dnsutils.MatchSet(dnsname, utils.NewStringSet(status.Domains.Included...)) <= dnsutils.MatchSet(dnsname, utils.NewStringSet(status.Domains.Excluded...)) {
+			continue
+		}
+		class, ok := resources.GetAnnotation(o.Data(), dns.CLASS_ANNOTATION)
+		if !ok || class == "" {
+			class = dns.DEFAULT_CLASS
+		}
+		causes = append(causes, noProviderCause{reason: api.PROVIDER_REASON_OTHER_CLASS, provider: o.ObjectName().String(), detail: class})
+	}
+	return causes
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// entriesWithoutProvider tracks the reasons of entries without responsible provider
+// to report the number of these entries per reason.
+type entriesWithoutProvider struct {
+	lock    sync.Mutex
+	reasons map[resources.ObjectName]string
+}
+
+func newEntriesWithoutProvider() *entriesWithoutProvider {
+	return &entriesWithoutProvider{reasons: map[resources.ObjectName]string{}}
+}
+
+// Set sets the reason for an entry. An empty reason removes the entry.
+func (this *entriesWithoutProvider) Set(name resources.ObjectName, reason string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old := this.reasons[name]
+	if old == reason {
+		return
+	}
+	if reason == "" {
+		delete(this.reasons, name)
+	} else {
+		this.reasons[name] = reason
+	}
+	if old != "" {
+		metrics.ReportEntriesWithoutProvider(old, this.count(old))
+	}
+	if reason != "" {
+		metrics.ReportEntriesWithoutProvider(reason, this.count(reason))
+	}
+}
+
+func (this *entriesWithoutProvider) count(reason string) int {
+	count := 0
+	for _, r := range this.reasons {
+		if r == reason {
+			count++
+		}
+	}
+	return count
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *EntryVersion) setProviderCondition(status metav1.ConditionStatus, reason, msg string) {
+	this.providerCondition = &metav1.Condition{
+		Type:               api.CONDITION_PROVIDER_ASSIGNED,
+		Status:             status,
+		ObservedGeneration: this.object.GetGeneration(),
+		Reason:             reason,
+		Message:            msg,
+	}
+}
+
+func (this *EntryVersion) setNoProviderCondition(p *EntryPremise) {
+	this.noProviderReason = p.noProvider.reason
+	this.setProviderCondition(metav1.ConditionFalse, p.noProvider.reason, p.noProvider.Message(this.dnsname))
+}
+
+// assureProviderCondition sets the ProviderAssigned condition in the status of an entry.
+// A nil condition keeps the actual one.
+func assureProviderCondition(b *api.DNSBaseStatus, condition *metav1.Condition) bool {
+	if condition == nil {
+		return false
+	}
+	old := meta.FindStatusCondition(b.Conditions, condition.Type)
+	if old != nil && old.Status == condition.Status && old.Reason == condition.Reason &&
+		old.Message == condition.Message && old.ObservedGeneration == condition.ObservedGeneration {
+		return false
+	}
+	meta.SetStatusCondition(&b.Conditions, *condition)
+	return true
+}
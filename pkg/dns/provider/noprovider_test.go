@@ -0,0 +1,74 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Reasons for missing providers", func() {
+	newProvider := func(valid bool) *dnsProviderVersion {
+		return &dnsProviderVersion{
+			zones: DNSHostedZones{
+				NewDNSHostedZone("test", "z1", "example.com", "", []string{"sub.example.com"}, false),
+			},
+			included: utils.NewStringSet("example.com"),
+			excluded: utils.NewStringSet("private.example.com", "sub.example.com"),
+			valid:    valid,
+		}
+	}
+	allowed := func(p *dnsProviderVersion) error { return nil }
+	denied := func(p *dnsProviderVersion) error { return fmt.Errorf("denied") }
+
+	ginkgov2.It("classifies the domain matching of a provider", func() {
+		for _, c := range []struct {
+			valid       bool
+			checkAccess func(p *dnsProviderVersion) error
+			dnsname     string
+			reason      string
+		}{
+			{false, allowed, "www.example.com", api.PROVIDER_REASON_NOT_READY},
+			{true, denied, "www.example.com", api.PROVIDER_REASON_ACCESS_DENIED},
+			{true, allowed, "www.example.com", api.PROVIDER_REASON_NO_ZONE},
+			{true, allowed, "a.sub.example.com", api.PROVIDER_REASON_ZONE_FORWARDED},
+			{true, allowed, "a.private.example.com", api.PROVIDER_REASON_DOMAIN_EXCLUDED},
+		} {
+			cause, ok := causeForProvider(newProvider(c.valid), c.dnsname, c.checkAccess)
+			Expect(ok).To(BeTrue(), c.dnsname)
+			Expect(cause.reason).To(Equal(c.reason), c.dnsname)
+		}
+		_, ok := causeForProvider(newProvider(true), "www.example.org", allowed)
+		Expect(ok).To(BeFalse())
+	})
+
+	ginkgov2.It("selects the most specific reason", func() {
+		causes := []noProviderCause{
+			{reason: api.PROVIDER_REASON_OTHER_CLASS, provider: "ns/a"},
+			{reason: api.PROVIDER_REASON_DOMAIN_EXCLUDED, provider: "ns/c"},
+			{reason: api.PROVIDER_REASON_DOMAIN_EXCLUDED, provider: "ns/b"},
+		}
+		Expect(selectNoProviderCause(causes)).To(Equal(causes[2]))
+		Expect(selectNoProviderCause(causes[:1])).To(Equal(causes[0]))
+		Expect(selectNoProviderCause(nil).reason).To(Equal(api.PROVIDER_REASON_NO_PROVIDER))
+	})
+})
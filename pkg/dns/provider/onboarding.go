@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
+)
+
+// needsOnboardingReport checks whether the onboarding report has still to be generated.
+// Providers which have already been ready before do not get a report.
+func (this *dnsProviderVersion) needsOnboardingReport(last *dnsProviderVersion) bool {
+	if last != nil {
+		return !last.onboarded
+	}
+	status := this.object.Status()
+	return status.Onboarding == nil && status.State != api.STATE_READY
+}
+
+// onboard sets the onboarding report in the status and emits it as events.
+// The status is updated together with the status of the succeeded reconciliation.
+func (this *dnsProviderVersion) onboard(logger logger.LogContext, zones DNSHostedZones, results selection.SelectionResult) {
+	report := newOnboardingReport(this.object.Spec(), this.account, zones, results)
+	report.RateLimit = this.rateLimit
+	this.object.Status().Onboarding = report
+	this.onboarded = true
+
+	included := 0
+	for _, z := range report.Zones {
+		if z.Included {
+			included++
+		}
+	}
+	logger.Infof("onboarded with %d of %d hosted zones", included, len(report.Zones))
+	this.object.Eventf(corev1.EventTypeNormal, "onboarding", "provider onboarded with %d of %d hosted zones: included domains %s, excluded domains %s",
+		included, len(report.Zones), this.included, this.excluded)
+	for _, w := range report.Warnings {
+		this.object.Eventf(corev1.EventTypeWarning, "onboarding", "%s", w)
+	}
+}
+
+// newOnboardingReport describes the zones found in the account, the domain selection with the
+// reasons and the features of the spec not supported by the provider type.
+func newOnboardingReport(spec *api.DNSProviderSpec, account *DNSAccount, zones DNSHostedZones, results selection.SelectionResult) *api.ProviderOnboardingReport {
+	report := &api.ProviderOnboardingReport{
+		Time:          metav1.Now(),
+		ZoneRateLimit: spec.ZoneRateLimit,
+		Warnings:      append([]string(nil), results.Warnings...),
+	}
+
+	forwarded := utils.StringSet{}
+	for _, z := range zones {
+		if z.Id().ProviderType != spec.Type {
+			continue
+		}
+		oz := api.OnboardingZone{ZoneID: z.Id().ID, Domain: z.Domain()}
+		switch {
+		case results.ZoneSel.Include.Contains(z.Id().ID):
+			oz.Included = true
+			oz.Reason = "all zones served"
+			if len(results.SpecZoneSel.Include) > 0 {
+				oz.Reason = "in zone include list"
+			}
+			forwarded.AddAll(z.ForwardedDomains())
+		case len(results.SpecZoneSel.Include) > 0 && !results.SpecZoneSel.Include.Contains(z.Id().ID):
+			oz.Reason = "not in zone include list"
+		case results.SpecZoneSel.Exclude.Contains(z.Id().ID):
+			oz.Reason = "in zone exclude list"
+		default:
+			oz.Reason = "no included domain in zone"
+		}
+		report.Zones = append(report.Zones, oz)
+	}
+	sort.Slice(report.Zones, func(i, j int) bool { return report.Zones[i].ZoneID < report.Zones[j].ZoneID })
+
+	specExcluded := utils.StringSet{}
+	for d := range results.SpecDomainSel.Exclude {
+		specExcluded.Add(strings.TrimSuffix(strings.ToLower(d), "."))
+	}
+	for _, d := range results.DomainSel.Include.AsArray() {
+		reason := "domain of included zone"
+		if len(results.SpecDomainSel.Include) > 0 {
+			reason = "in domain include list"
+		}
+		report.Domains = append(report.Domains, api.OnboardingDomain{Domain: d, Included: true, Reason: reason})
+	}
+	for _, d := range results.DomainSel.Exclude.AsArray() {
+		reason := "domain of excluded zone"
+		switch {
+		case specExcluded.Contains(d):
+			reason = "in domain exclude list"
+		case forwarded.Contains(d):
+			reason = "forwarded to another zone"
+		}
+		report.Domains = append(report.Domains, api.OnboardingDomain{Domain: d, Reason: reason})
+	}
+	sort.SliceStable(report.Domains, func(i, j int) bool { return report.Domains[i].Domain < report.Domains[j].Domain })
+
+	report.Warnings = append(report.Warnings, capabilityWarnings(spec, account)...)
+	return report
+}
+
+// capabilityWarnings lists the features used by the spec or commonly used by entries, which are
+// not supported by the provider type.
+func capabilityWarnings(spec *api.DNSProviderSpec, account *DNSAccount) []string {
+	var warnings []string
+	if len(account.SupportedRoutingPolicyTypes()) == 0 {
+		warnings = append(warnings, fmt.Sprintf("routing policies not supported by provider type %s", spec.Type))
+	}
+	if spec.DNSSEC != nil && spec.DNSSEC.Enabled {
+		if _, ok := account.handler.(DNSSECAccess); !ok {
+			warnings = append(warnings, fmt.Sprintf("DNSSEC signing not supported by provider type %s", spec.Type))
+		}
+	}
+	if spec.WritePermissionCheck != nil && spec.WritePermissionCheck.Enabled && !account.SupportsWritePermissionProbe() {
+		warnings = append(warnings, fmt.Sprintf("write permission check not supported by provider type %s", spec.Type))
+	}
+	return warnings
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
+)
+
+var _ = ginkgov2.Describe("Provider onboarding report", func() {
+	var (
+		account *DNSAccount
+		zones   DNSHostedZones
+	)
+
+	ginkgov2.BeforeEach(func() {
+		account = NewDNSAccount(nil, &prefetchTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}, "hash")
+		zones = DNSHostedZones{
+			NewDNSHostedZone("test", "Z1", "example.com", "", []string{"sub.example.com"}, false),
+			NewDNSHostedZone("test", "Z2", "example.org", "", nil, false),
+			NewDNSHostedZone("test", "Z3", "example.net", "", nil, false),
+			NewDNSHostedZone("other", "O1", "example.io", "", nil, false),
+		}
+	})
+
+	report := func(spec *api.DNSProviderSpec) *api.ProviderOnboardingReport {
+		results := selection.CalcZoneAndDomainSelection(*spec, toLightZones(zones))
+		return newOnboardingReport(spec, account, zones, results)
+	}
+
+	ginkgov2.It("reports the reasons of the zone and domain selection", func() {
+		spec := &api.DNSProviderSpec{
+			Type:    "test",
+			Zones:   &api.DNSSelection{Exclude: []string{"Z3"}},
+			Domains: &api.DNSSelection{Include: []string{"example.com"}, Exclude: []string{"a.example.com."}},
+		}
+		r := report(spec)
+		Expect(r.Zones).To(Equal([]api.OnboardingZone{
+			{ZoneID: "Z1", Domain: "example.com", Included: true, Reason: "all zones served"},
+			{ZoneID: "Z2", Domain: "example.org", Reason: "no included domain in zone"},
+			{ZoneID: "Z3", Domain: "example.net", Reason: "in zone exclude list"},
+		}))
+		Expect(r.Domains).To(ContainElements(
+			api.OnboardingDomain{Domain: "example.com", Included: true, Reason: "in domain include list"},
+			api.OnboardingDomain{Domain: "a.example.com", Reason: "in domain exclude list"},
+			api.OnboardingDomain{Domain: "sub.example.com", Reason: "forwarded to another zone"},
+			api.OnboardingDomain{Domain: "example.org", Reason: "domain of excluded zone"},
+		))
+	})
+
+	ginkgov2.It("reports the zone rate limit and unsupported features", func() {
+		zoneRateLimit := &api.ZoneRateLimit{Requests: 5}
+		spec := &api.DNSProviderSpec{
+			Type:                 "test",
+			Zones:                &api.DNSSelection{Include: []string{"Z2"}},
+			ZoneRateLimit:        zoneRateLimit,
+			DNSSEC:               &api.DNSSECConfig{Enabled: true},
+			WritePermissionCheck: &api.WritePermissionCheckConfig{Enabled: true},
+		}
+		r := report(spec)
+		Expect(r.Zones[0]).To(Equal(api.OnboardingZone{ZoneID: "Z1", Domain: "example.com", Reason: "not in zone include list"}))
+		Expect(r.Zones[1]).To(Equal(api.OnboardingZone{ZoneID: "Z2", Domain: "example.org", Included: true, Reason: "in zone include list"}))
+		Expect(r.ZoneRateLimit).To(Equal(zoneRateLimit))
+		Expect(r.Warnings).To(Equal([]string{
+			"routing policies not supported by provider type test",
+			"DNSSEC signing not supported by provider type test",
+			"write permission check not supported by provider type test",
+		}))
+	})
+})
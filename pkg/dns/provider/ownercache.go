@@ -87,6 +87,9 @@ type OwnerCache struct {
 	ownerids   OwnerIDInfos
 	pendingids utils.StringSet
 
+	// namespaceOwnerIds are the owner ids derived from the namespaces of entries
+	namespaceOwnerIds *NamespaceOwnerIdTemplate
+
 	schedule *dnsutils.Schedule
 }
 
@@ -99,6 +102,8 @@ func NewOwnerCache(ctx ProviderCacheContext, config *Config) *OwnerCache {
 		ownerids:       OwnerIDInfos{config.Ident: {refcount: 1, entrycounts: ProviderTypeCounts{}}},
 		dnsactivations: OwnerDNSActivations{},
 		pendingids:     utils.StringSet{},
+
+		namespaceOwnerIds: config.NamespaceOwnerIds,
 	}
 	this.schedule = dnsutils.NewSchedule(ctx.GetContext(), dnsutils.ScheduleExecutorFunction(this.expire))
 	return this
@@ -132,7 +137,7 @@ func (this *OwnerCache) expire(key dnsutils.ScheduleKey) {
 func (this *OwnerCache) IsResponsibleFor(id string) bool {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
-	return this.ownerids.Contains(id)
+	return this.ownerids.Contains(id) || this.namespaceOwnerIds.Matches(id)
 }
 
 func (this *OwnerCache) IsResponsiblePendingFor(id string) bool {
@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+const (
+	// OWNER_CONFLICT_RESOLUTION_NONE only reports conflicts with record sets of foreign owners.
+	OWNER_CONFLICT_RESOLUTION_NONE = "none"
+	// OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY takes over record sets of foreign owners of the same owner group,
+	// if they have been created before the entry (e.g. left over by the controller of a migrated cluster).
+	OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY = "newer-entry"
+)
+
+// OwnerConflict describes a conflict of an entry with the record set of a foreign owner.
+type OwnerConflict struct {
+	Owner      string
+	OwnerGroup string
+	// RecordCreatedAt is the creation timestamp of the entry of the foreign owner (zero if unknown)
+	RecordCreatedAt time.Time
+	// Newer is set if the entry has been created after the entry of the foreign owner
+	Newer bool
+	// Resolved is set if the record set is taken over by the entry
+	Resolved bool
+	// Retry is set if the conflict is checked again with a refreshed zone state
+	Retry bool
+}
+
+func newOwnerConflict(resolution string, set *dns.DNSSet, createdAt time.Time, spec TargetSpec, delete bool) *OwnerConflict {
+	conflict := &OwnerConflict{Owner: set.GetOwner(), OwnerGroup: set.GetOwnerGroup()}
+	if ts, err := strconv.ParseInt(set.GetMetaAttr(dns.ATTR_CREATED), 10, 64); err == nil {
+		conflict.RecordCreatedAt = time.Unix(ts, 0)
+		conflict.Newer = createdAt.Unix() > ts
+	}
+	conflict.Resolved = !delete && resolution == OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY && conflict.Newer &&
+		set.GetKind() != api.DNSLockKind && spec.OwnerGroup() != "" && conflict.OwnerGroup == spec.OwnerGroup()
+	return conflict
+}
+
+func (this *OwnerConflict) IsResolved() bool {
+	return this != nil && this.Resolved
+}
+
+// resolution returns the label of the conflict handling for metrics.
+func (this *OwnerConflict) resolution() string {
+	switch {
+	case this.Resolved:
+		return "takenover"
+	case this.Retry:
+		return "retried"
+	}
+	return "reported"
+}
+
+// Suggestion returns a hint for resolving the conflict manually.
+func (this *OwnerConflict) Suggestion(ownerGroup string) string {
+	if this.Newer && ownerGroup != "" && this.OwnerGroup == ownerGroup {
+		return fmt.Sprintf("the record set of owner %q of the same owner group has been created before the entry (e.g. left over by a migration): "+
+			"add the owner id to an active DNSOwner, delete the records or use the owner conflict resolution %q", this.Owner, OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY)
+	}
+	return fmt.Sprintf("if owner %q is not active anymore, add the owner id to an active DNSOwner or delete the records, otherwise use another DNS name", this.Owner)
+}
+
+// reportOwnerConflict records metrics and events for a conflict of an entry with a foreign owner.
+func reportOwnerConflict(logger logger.LogContext, e *Entry, zoneid dns.ZoneID, conflict *OwnerConflict) {
+	metrics.AddOwnerConflict(zoneid.ProviderType, conflict.Owner, conflict.resolution())
+	switch {
+	case conflict.Resolved:
+		e.object.Eventf(corev1.EventTypeNormal, "owner conflict", "record set taken over from owner %q of owner group %q", conflict.Owner, conflict.OwnerGroup)
+	case conflict.Retry:
+		logger.Infof("owner conflict of %s with owner %q retried with refreshed zone state", e.ObjectName(), conflict.Owner)
+	default:
+		suggestion := conflict.Suggestion(e.OwnerGroup())
+		logger.Infof("owner conflict of %s with owner %q: %s", e.ObjectName(), conflict.Owner, suggestion)
+		e.object.Eventf(corev1.EventTypeWarning, "owner conflict", "DNS name %q already busy for owner %q: %s", e.DNSName(), conflict.Owner, suggestion)
+	}
+}
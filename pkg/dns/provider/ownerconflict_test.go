@@ -0,0 +1,70 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"strconv"
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type ownerGroupSpec struct {
+	TargetSpec
+	ownerGroup string
+}
+
+func (s *ownerGroupSpec) OwnerGroup() string {
+	return s.ownerGroup
+}
+
+var _ = ginkgov2.Describe("Owner conflicts", func() {
+	created := time.Now()
+	foreign := func(group string, recordCreatedAt time.Time) *dns.DNSSet {
+		set := dns.NewDNSSet("a.example.com").SetOwner("other").SetOwnerGroup(group)
+		set.SetMetaAttr(dns.ATTR_CREATED, strconv.FormatInt(recordCreatedAt.Unix(), 10))
+		return set
+	}
+	spec := &ownerGroupSpec{ownerGroup: "team-a"}
+
+	ginkgov2.It("takes over older record sets of the same owner group", func() {
+		conflict := newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY, foreign("team-a", created.Add(-time.Hour)), created, spec, false)
+		Expect(conflict.IsResolved()).To(BeTrue())
+		Expect(conflict.resolution()).To(Equal("takenover"))
+	})
+
+	ginkgov2.It("only reports conflicts without matching policy", func() {
+		Expect(newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NONE, foreign("team-a", created.Add(-time.Hour)), created, spec, false).IsResolved()).To(BeFalse())
+		Expect(newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY, foreign("team-b", created.Add(-time.Hour)), created, spec, false).IsResolved()).To(BeFalse())
+		Expect(newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY, foreign("team-a", created.Add(time.Hour)), created, spec, false).IsResolved()).To(BeFalse())
+		Expect(newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY, foreign("team-a", created.Add(-time.Hour)), created, spec, true).IsResolved()).To(BeFalse())
+		Expect(newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY, dns.NewDNSSet("a.example.com").SetOwner("other").SetOwnerGroup("team-a"), created, spec, false).IsResolved()).To(BeFalse())
+
+		var none *OwnerConflict
+		Expect(none.IsResolved()).To(BeFalse())
+	})
+
+	ginkgov2.It("suggests resolutions", func() {
+		conflict := newOwnerConflict(OWNER_CONFLICT_RESOLUTION_NONE, foreign("team-a", created.Add(-time.Hour)), created, spec, false)
+		Expect(conflict.resolution()).To(Equal("reported"))
+		Expect(conflict.Suggestion("team-a")).To(ContainSubstring(OWNER_CONFLICT_RESOLUTION_NEWER_ENTRY))
+		Expect(conflict.Suggestion("team-b")).To(HavePrefix(`if owner "other" is not active anymore`))
+	})
+})
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// NamespacePlaceholder is replaced by the namespace of an entry in a namespace owner id template.
+const NamespacePlaceholder = "{namespace}"
+
+// NamespaceOwnerIdTemplate derives the owner id of entries without explicit owner id
+// from their namespace, so that tenants sharing a zone get distinct ownership domains.
+type NamespaceOwnerIdTemplate struct {
+	prefix string
+	suffix string
+}
+
+// ParseNamespaceOwnerIdTemplate parses a template like `ownerid-{namespace}`.
+// For an empty template nil is returned.
+func ParseNamespaceOwnerIdTemplate(template string) (*NamespaceOwnerIdTemplate, error) {
+	if template == "" {
+		return nil, nil
+	}
+	if strings.Count(template, NamespacePlaceholder) != 1 {
+		return nil, fmt.Errorf("template %q must contain %s exactly once", template, NamespacePlaceholder)
+	}
+	parts := strings.SplitN(template, NamespacePlaceholder, 2)
+	if parts[0] == "" && parts[1] == "" {
+		return nil, fmt.Errorf("template %q must not consist of %s only", template, NamespacePlaceholder)
+	}
+	return &NamespaceOwnerIdTemplate{prefix: parts[0], suffix: parts[1]}, nil
+}
+
+// OwnerId returns the owner id for the given namespace, or an empty string if no template is configured.
+func (this *NamespaceOwnerIdTemplate) OwnerId(namespace string) string {
+	if this == nil {
+		return ""
+	}
+	return this.prefix + namespace + this.suffix
+}
+
+// Namespace returns the namespace an owner id has been derived from.
+func (this *NamespaceOwnerIdTemplate) Namespace(id string) (string, bool) {
+	if this == nil || len(id) <= len(this.prefix)+len(this.suffix) ||
+		!strings.HasPrefix(id, this.prefix) || !strings.HasSuffix(id, this.suffix) {
+		return "", false
+	}
+	namespace := id[len(this.prefix) : len(id)-len(this.suffix)]
+	if len(validation.IsDNS1123Label(namespace)) > 0 {
+		return "", false
+	}
+	return namespace, true
+}
+
+// Matches checks whether an owner id has been derived from the template.
+func (this *NamespaceOwnerIdTemplate) Matches(id string) bool {
+	_, ok := this.Namespace(id)
+	return ok
+}
+
+func (this *NamespaceOwnerIdTemplate) String() string {
+	if this == nil {
+		return "<none>"
+	}
+	return this.prefix + NamespacePlaceholder + this.suffix
+}
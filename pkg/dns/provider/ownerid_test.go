@@ -0,0 +1,53 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Namespace owner ids", func() {
+	ginkgov2.It("derives owner ids from namespaces", func() {
+		template, err := ParseNamespaceOwnerIdTemplate("ownerid-{namespace}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(template.OwnerId("team-a")).To(Equal("ownerid-team-a"))
+		Expect(template.String()).To(Equal("ownerid-{namespace}"))
+
+		ns, ok := template.Namespace("ownerid-team-a")
+		Expect(ok).To(BeTrue())
+		Expect(ns).To(Equal("team-a"))
+		Expect(template.Matches("ownerid-")).To(BeFalse())
+		Expect(template.Matches("ownerid-Team_A")).To(BeFalse())
+		Expect(template.Matches("dnscontroller")).To(BeFalse())
+	})
+
+	ginkgov2.It("is disabled for an empty template", func() {
+		template, err := ParseNamespaceOwnerIdTemplate("")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(template).To(BeNil())
+		Expect(template.OwnerId("team-a")).To(BeEmpty())
+		Expect(template.Matches("team-a")).To(BeFalse())
+	})
+
+	ginkgov2.It("rejects invalid templates", func() {
+		for _, t := range []string{"ownerid", "{namespace}", "{namespace}-{namespace}"} {
+			_, err := ParseNamespaceOwnerIdTemplate(t)
+			Expect(err).To(HaveOccurred(), t)
+		}
+	})
+})
@@ -17,9 +17,11 @@
 package provider
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"hash"
 	"reflect"
 	"sort"
 	"strings"
@@ -28,10 +30,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/util/flowcontrol"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -66,8 +72,20 @@ type DNSAccount struct {
 	handler DNSHandler
 	config  utils.Properties
 
-	hash    string
-	clients resources.ObjectNameSet
+	hash string
+	// key is the current cache key, it differs from the hash once the handler config has been updated in place
+	key             string
+	credentialsHash string
+	clients         resources.ObjectNameSet
+	timeouts        TimeoutConfig
+	rateLimiter     flowcontrol.RateLimiter
+
+	zoneStateConcurrency int
+	prefetchLock         sync.Mutex
+	prefetched           map[dns.ZoneID]bool
+
+	authLock    sync.Mutex
+	authFailure error
 }
 
 var _ DNSHandler = &DNSAccount{}
@@ -79,7 +97,9 @@ func NewDNSAccount(config utils.Properties, handler DNSHandler, hash string) *DN
 		config:      config,
 		handler:     handler,
 		hash:        hash,
+		key:         hash,
 		clients:     resources.ObjectNameSet{},
+		prefetched:  map[dns.ZoneID]bool{},
 	}
 }
 
@@ -91,6 +111,14 @@ func (this *DNSAccount) AddZoneRequests(zoneID, requestType string, n int) {
 	metrics.AddRequests(this.handler.ProviderType(), this.hash, requestType, n, &zoneID)
 }
 
+func (this *DNSAccount) AddZoneListCacheLookup(hit bool) {
+	metrics.AddZoneListCacheLookup(this.handler.ProviderType(), this.hash, hit)
+}
+
+func (this *DNSAccount) ReportZoneListBackoff(backoff time.Duration) {
+	metrics.ReportZoneListBackoff(this.handler.ProviderType(), this.hash, backoff)
+}
+
 func (this *DNSAccount) ProviderType() string {
 	return this.handler.ProviderType()
 }
@@ -99,8 +127,14 @@ func (this *DNSAccount) Hash() string {
 	return this.hash
 }
 
-func (this *DNSAccount) GetZones() (DNSHostedZones, error) {
-	zones, err := this.handler.GetZones()
+func (this *DNSAccount) GetZones(ctx context.Context) (DNSHostedZones, error) {
+	ctx, cancel := withTimeout(ctx, this.timeouts.GetZones)
+	defer cancel()
+	start := time.Now()
+	zones, err := this.handler.GetZones(ctx)
+	metrics.ReportProviderAPISeconds(this.ProviderType(), metrics.OperationGetZones, err, time.Since(start))
+	err = this.reportError(err)
+	this.reportThrottlingFeedback(err)
 	if err == nil {
 		zones = addObviousForwardedDomains(zones)
 		this.Succeeded()
@@ -141,9 +175,16 @@ func addObviousForwardedDomains(zones DNSHostedZones) DNSHostedZones {
 	return result
 }
 
-func (this *DNSAccount) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
-	state, err := this.handler.GetZoneState(zone)
+func (this *DNSAccount) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	ctx, cancel := withTimeout(ctx, this.timeouts.GetZoneState)
+	defer cancel()
+	start := time.Now()
+	state, err := this.handler.GetZoneState(ctx, zone)
+	metrics.ReportProviderAPISeconds(this.ProviderType(), metrics.OperationGetZoneState, err, time.Since(start))
+	err = this.reportError(err)
+	this.reportThrottlingFeedback(err)
 	if err == nil {
+		this.setAuthFailure(nil)
 		this.Succeeded()
 	} else {
 		this.Failed()
@@ -155,14 +196,84 @@ func (this *DNSAccount) ReportZoneStateConflict(zone DNSHostedZone, err error) b
 	return this.handler.ReportZoneStateConflict(zone, err)
 }
 
-func (this *DNSAccount) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
-	return this.handler.ExecuteRequests(logger, zone, state, reqs)
+func (this *DNSAccount) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	ctx, cancel := withTimeout(ctx, this.timeouts.ExecuteRequests)
+	defer cancel()
+	metrics.ReportChangeBatchSize(this.ProviderType(), len(reqs))
+	start := time.Now()
+	err := this.handler.ExecuteRequests(ctx, logger, zone, state, this.classifiedRequests(reqs))
+	metrics.ReportProviderAPISeconds(this.ProviderType(), metrics.OperationExecuteRequests, err, time.Since(start))
+	err = this.classify(err)
+	this.reportThrottlingFeedback(err)
+	return err
+}
+
+// reportThrottlingFeedback adapts the request rate of an adaptive rate limiter
+// to the outcome of a provider API call.
+func (this *DNSAccount) reportThrottlingFeedback(err error) {
+	feedback, ok := this.rateLimiter.(ThrottlingFeedback)
+	if !ok {
+		return
+	}
+	old := this.rateLimiter.QPS()
+	if perrs.IsThrottlingError(err) {
+		feedback.ReportThrottling()
+	} else if err == nil {
+		feedback.ReportSuccess()
+	}
+	if qps := this.rateLimiter.QPS(); qps != old {
+		metrics.ReportAccountRateLimit(this.ProviderType(), this.Hash(), qps)
+	}
 }
 
 func (this *DNSAccount) MapTarget(t Target) Target {
 	return this.handler.MapTarget(t)
 }
 
+func (this *DNSAccount) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return this.handler.ValidateRoutingPolicy(policy)
+}
+
+func (this *DNSAccount) SupportsRecordType(rtype string) bool {
+	return this.handler.SupportsRecordType(rtype)
+}
+
+func (this *DNSAccount) SupportedRoutingPolicyTypes() []string {
+	if support, ok := this.handler.(RoutingPolicySupport); ok {
+		return support.SupportedRoutingPolicyTypes()
+	}
+	return nil
+}
+
+// ProviderHintsFor returns the provider hints applicable for the record set, if supported by the handler.
+func (this *DNSAccount) ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints {
+	if support, ok := this.handler.(ProviderHintsSupport); ok {
+		return support.ProviderHintsFor(rs, hints)
+	}
+	return nil
+}
+
+// SupportsWritePermissionProbe returns true if the handler is able to probe the write permission for zones.
+func (this *DNSAccount) SupportsWritePermissionProbe() bool {
+	_, ok := this.handler.(WritePermissionProbeSupport)
+	return ok
+}
+
+// ProbeWritePermission checks the write permission for a zone, if supported by the handler.
+// A denied permission is not recorded as authentication failure of the account, as the
+// credentials may still be used for other zones.
+func (this *DNSAccount) ProbeWritePermission(ctx context.Context, zone DNSHostedZone) error {
+	support, ok := this.handler.(WritePermissionProbeSupport)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := withTimeout(ctx, this.timeouts.ExecuteRequests)
+	defer cancel()
+	err := support.ProbeWritePermission(ctx, zone)
+	this.reportThrottlingFeedback(this.classify(err))
+	return err
+}
+
 func (this *DNSAccount) Release() {
 	this.handler.Release()
 }
@@ -183,14 +294,24 @@ func NewAccountCache(ttl time.Duration, opts *FactoryOptions) *AccountCache {
 	}
 }
 
-func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties, state *state) (*DNSAccount, error) {
+// Get returns the account for the given provider and credentials.
+// If the provider config, the resource tags or the credentials of a provider have changed and the previous
+// account is only used by this provider, its handler is updated in place if it supports ConfigUpdateSupport
+// or CredentialsUpdateSupport.
+func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties, state *state, last *DNSAccount) (*DNSAccount, error) {
 	name := provider.ObjectName()
-	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig)
+	tags := ParseResourceTags(provider.GetAnnotations()[dns.RESOURCE_TAGS_ANNOTATION])
+	hash := this.Hash(props, provider.Spec().Type, provider.Spec().ProviderConfig, tags)
+	credentialsHash := this.CredentialsHash(props, provider.Spec().Type)
 	this.lock.Lock()
 	defer this.lock.Unlock()
 	a := this.cache[hash]
+	if a == nil && this.updateAccount(logger, last, provider, props, tags, hash, credentialsHash) {
+		a = last
+	}
 	if a == nil {
 		a = NewDNSAccount(props, nil, hash)
+		a.credentialsHash = credentialsHash
 		syncPeriod := state.GetContext().GetPoolPeriod("dns")
 		if syncPeriod == nil {
 			return nil, fmt.Errorf("Pool dns not found")
@@ -201,23 +322,32 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			zonesTTL:              this.ttl,
 			zoneStates:            state.zoneStates,
 			disableZoneStateCache: !state.config.ZoneStateCaching,
+			credentialsHash:       credentialsHash,
+			zoneTrigger:           state.triggerHostedZoneIfKnown,
 		}
 
 		cfg := DNSHandlerConfig{
-			Context:          state.GetContext().GetContext(),
-			Logger:           logger,
-			Properties:       props,
-			Config:           provider.Spec().ProviderConfig,
-			DryRun:           state.GetConfig().Dryrun,
-			ZoneCacheFactory: cacheFactory,
-			Options:          this.options,
-			Metrics:          a,
+			Context:              state.GetContext().GetContext(),
+			Logger:               logger,
+			Properties:           props,
+			Config:               provider.Spec().ProviderConfig,
+			DryRun:               state.GetConfig().Dryrun,
+			ZoneCacheFactory:     cacheFactory,
+			Options:              this.options,
+			Metrics:              a,
+			Timeouts:             &a.timeouts,
+			ZoneStateConcurrency: &a.zoneStateConcurrency,
+			ResourceTags:         tags,
 		}
 		var err error
 		a.handler, err = state.GetHandlerFactory().Create(provider.TypeCode(), &cfg)
 		if err != nil {
 			return nil, err
 		}
+		a.rateLimiter = cfg.RateLimiter
+		if cfg.RateLimiter != nil {
+			metrics.ReportAccountRateLimit(a.ProviderType(), a.Hash(), cfg.RateLimiter.QPS())
+		}
 		logger.Infof("creating account for %s (%s)", name, a.Hash())
 		this.cache[hash] = a
 	}
@@ -230,6 +360,65 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 	return a, nil
 }
 
+// updateAccount tries to update the handler of the last account of a provider in place.
+// This is only possible if the account is not shared with other providers and the handler
+// supports ConfigUpdateSupport for a changed provider config or resource tags and
+// CredentialsUpdateSupport for changed credentials.
+// On success the account is moved to the new cache key, but keeps its hash used for the metrics.
+func (this *AccountCache) updateAccount(logger logger.LogContext, last *DNSAccount, provider *dnsutils.DNSProviderObject,
+	props utils.Properties, tags map[string]string, hash, credentialsHash string) bool {
+	if last == nil || this.cache[last.key] != last {
+		return false
+	}
+	if len(last.clients) != 1 || !last.clients.Contains(provider.ObjectName()) {
+		return false
+	}
+	configChanged := this.Hash(last.config, provider.Spec().Type, provider.Spec().ProviderConfig, tags) != last.key
+	credentialsChanged := last.credentialsHash != credentialsHash
+	configSupport, ok := last.handler.(ConfigUpdateSupport)
+	if configChanged && !ok {
+		return false
+	}
+	credentialsSupport, ok := last.handler.(CredentialsUpdateSupport)
+	if credentialsChanged && !ok {
+		return false
+	}
+	cfg := DNSHandlerConfig{
+		Logger:       logger,
+		Properties:   props,
+		Config:       provider.Spec().ProviderConfig,
+		Options:      this.options,
+		Metrics:      last,
+		ResourceTags: tags,
+	}
+	if err := cfg.Complete(); err != nil {
+		logger.Warnf("cannot update account %s: %s", last.Hash(), err)
+		return false
+	}
+	if configChanged {
+		if err := configSupport.UpdateConfig(&cfg); err != nil {
+			logger.Infof("cannot update account %s in place, recreating it: %s", last.Hash(), err)
+			return false
+		}
+		logger.Infof("updated account for %s (%s) in place", provider.ObjectName(), last.Hash())
+	}
+	if credentialsChanged {
+		if err := credentialsSupport.UpdateCredentials(&cfg); err != nil {
+			logger.Infof("cannot update credentials of account %s in place, recreating it: %s", last.Hash(), err)
+			return false
+		}
+		logger.Infof("updated credentials of account for %s (%s) in place", provider.ObjectName(), last.Hash())
+		// the zone states stay shared under the original credentials hash
+		last.config = props
+		last.credentialsHash = credentialsHash
+		last.setAuthFailure(nil)
+	}
+	delete(this.cache, last.key)
+	last.key = hash
+	this.cache[hash] = last
+	return true
+}
+
 var null = []byte{0}
 
 func (this *AccountCache) Release(logger logger.LogContext, a *DNSAccount, name resources.ObjectName) {
@@ -240,7 +429,7 @@ func (this *AccountCache) Release(logger logger.LogContext, a *DNSAccount, name
 		a.clients.Remove(name)
 		if len(a.clients) == 0 {
 			logger.Infof("releasing account for %s (%s)", name, a.Hash())
-			delete(this.cache, a.hash)
+			delete(this.cache, a.key)
 			metrics.DeleteAccount(a.ProviderType(), a.Hash())
 			a.handler.Release()
 		} else {
@@ -249,29 +438,48 @@ func (this *AccountCache) Release(logger logger.LogContext, a *DNSAccount, name
 	}
 }
 
-func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension) string {
-	keys := make([]string, len(props))
-	i := 0
+func (this *AccountCache) Hash(props utils.Properties, ptype string, extension *runtime.RawExtension, tags map[string]string) string {
+	h := sha256.New224()
+	writeSortedMap(h, props)
+
+	if len(tags) > 0 {
+		h.Write(null)
+		writeSortedMap(h, tags)
+	}
+
+	if extension != nil {
+		h.Write(extension.Raw)
+	}
+	h.Write(null)
+	h.Write([]byte(ptype))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CredentialsHash calculates a hash only covering the credentials and the type of a provider.
+// In contrast to the account hash it is independent of the provider config and the resource tags.
+func (this *AccountCache) CredentialsHash(props utils.Properties, ptype string) string {
 	h := sha256.New224()
-	for k := range props {
+	writeSortedMap(h, props)
+	h.Write(null)
+	h.Write([]byte(ptype))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func writeSortedMap(h hash.Hash, m map[string]string) {
+	keys := make([]string, len(m))
+	i := 0
+	for k := range m {
 		keys[i] = k
 		i++
 	}
 	sort.Strings(keys)
 	for _, k := range keys {
-		v := props[k]
+		v := m[k]
 		h.Write([]byte(k))
 		h.Write(null)
 		h.Write(([]byte(v)))
 		h.Write(null)
 	}
-
-	if extension != nil {
-		h.Write(extension.Raw)
-	}
-	h.Write(null)
-	h.Write([]byte(ptype))
-	return hex.EncodeToString(h.Sum(nil))
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -289,6 +497,9 @@ type dnsProviderVersion struct {
 	def_include utils.StringSet
 	def_exclude utils.StringSet
 
+	// activeSecret is the index of the used secret in the secret references of the provider
+	activeSecret int
+
 	zones          DNSHostedZones
 	included_zones utils.StringSet
 	excluded_zones utils.StringSet
@@ -296,6 +507,16 @@ type dnsProviderVersion struct {
 	included  utils.StringSet
 	excluded  utils.StringSet
 	rateLimit *api.RateLimit
+	readOnly  bool
+	dryRun    bool
+	// unconfirmed is set as long as the readiness probe of a newly created provider has not succeeded
+	unconfirmed bool
+	// probing is set while the readiness probe is running
+	probing bool
+	// onboarded is set once the onboarding report has been generated
+	onboarded bool
+
+	defaultForNamespaces labels.Selector
 }
 
 var _ DNSProvider = &dnsProviderVersion{}
@@ -312,6 +533,14 @@ func (this *dnsProviderVersion) DefaultTTL() int64 {
 	return this.defaultTTL
 }
 
+func (this *dnsProviderVersion) IsReadOnly() bool {
+	return this.readOnly
+}
+
+func (this *dnsProviderVersion) IsDryRun() bool {
+	return this.dryRun
+}
+
 func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if this.account != v.account {
 		return false
@@ -328,6 +557,12 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if !reflect.DeepEqual(this.defaultTTL, v.defaultTTL) {
 		return false
 	}
+	if selectorString(this.defaultForNamespaces) != selectorString(v.defaultForNamespaces) {
+		return false
+	}
+	if this.readOnly != v.readOnly || this.dryRun != v.dryRun {
+		return false
+	}
 	if this.secret != nil && v.secret != nil && this.secret != v.secret {
 		return false
 	} else {
@@ -338,7 +573,7 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	return true
 }
 
-func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutils.DNSProviderObject, last *dnsProviderVersion) (*dnsProviderVersion, reconcile.Status) {
+func updateDNSProvider(ctx context.Context, logger logger.LogContext, state *state, provider *dnsutils.DNSProviderObject, last *dnsProviderVersion) (*dnsProviderVersion, reconcile.Status) {
 	domsel := selection.PrepareSelection(provider.DNSProvider().Spec.Domains)
 	this := &dnsProviderVersion{
 		state:  state,
@@ -362,50 +597,63 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		this.included_zones = utils.NewStringSet(provider.Status().Zones.Included...)
 		this.excluded_zones = utils.NewStringSet(provider.Status().Zones.Excluded...)
 	}
+	this.unconfirmed = this.needsReadinessProbe(last)
+	this.onboarded = !this.needsOnboardingReport(last)
 
 	if provider.Spec().DefaultTTL != nil {
 		this.defaultTTL = *provider.Spec().DefaultTTL
 	} else {
 		this.defaultTTL = state.config.TTL
 	}
+	this.readOnly = provider.Spec().Mode == api.PROVIDER_MODE_READ_ONLY
+	this.dryRun = provider.Spec().Mode == api.PROVIDER_MODE_DRY_RUN
 
-	if last != nil && last.ObjectName() != this.ObjectName() {
-		panic(fmt.Errorf("provider name mismatch %q<=>%q", last.ObjectName(), this.ObjectName()))
+	if sel := provider.Spec().DefaultForNamespaces; sel != nil {
+		selector, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return this, this.failed(logger, false, fmt.Errorf("invalid namespace selector: %s", err), false)
+		}
+		this.defaultForNamespaces = selector
 	}
 
-	var props utils.Properties
-	var err error
+	if err := validateProviderConfig(logger, state.GetHandlerFactory(), provider); err != nil {
+		return this, this.failed(logger, false, err, false)
+	}
 
-	ref := this.object.DNSProvider().Spec.SecretRef
-	if ref != nil {
-		localref := *ref
-		ref = &localref
+	if last != nil && last.ObjectName() != this.ObjectName() {
+		panic(fmt.Errorf("provider name mismatch %q<=>%q", last.ObjectName(), this.ObjectName()))
+	}
 
-		if ref.Namespace == "" {
-			ref.Namespace = provider.GetNamespace()
-		}
-		this.secret = resources.NewObjectName(ref.Namespace, ref.Name)
-		props, _, err = state.GetContext().GetSecretPropertiesByRef(provider, ref)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				return this, this.failed(logger, false, fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
-					ref.Namespace, ref.Name, provider.Description(), err), false)
-			}
-			return this, this.failed(logger, false, fmt.Errorf("error reading secret for provider %q", provider.Description()), true)
-		}
-	} else {
+	refs := secretReferences(provider)
+	if len(refs) == 0 {
 		return this, this.failed(logger, false, fmt.Errorf("no secret specified"), false)
 	}
 
-	this.account, err = state.GetDNSAccount(logger, provider, props)
-	if err != nil {
-		return this, this.failed(logger, false, err, true)
+	var lastAccount *DNSAccount
+	start := 0
+	if last != nil {
+		lastAccount = last.account
+		start = last.activeSecret % len(refs)
 	}
 
-	zones, err := this.account.GetZones()
-	if err != nil {
-		this.zones = nil
-		return this, this.failed(logger, false, fmt.Errorf("cannot get hosted zones: %w", err), true)
+	selected, initial := selectCredentials(logger, refs, start, lastAccount,
+		func(ref corev1.SecretReference, base *DNSAccount) (resources.ObjectName, *DNSAccount, DNSHostedZones, *credentialsFailure) {
+			return this.connect(ctx, logger, ref, base, len(refs) > 1)
+		},
+		func(account *DNSAccount) {
+			state.accountCache.Release(logger, account, provider.ObjectName())
+		})
+	if selected.index != initial.index {
+		logger.Warnf("switching credentials from secret %s to %s: %s", initial.secret, selected.secret, initial.failure.err)
+		this.object.Eventf(corev1.EventTypeWarning, "credentials", "switched credentials from secret %s to %s: %s", initial.secret, selected.secret, initial.failure.err)
+	}
+	this.secret, this.account, this.activeSecret = selected.secret, selected.account, selected.index
+	zones, failure := selected.zones, selected.failure
+	if failure != nil {
+		if !failure.recorded {
+			return this, this.failed(logger, false, failure.err, failure.temp)
+		}
+		logger.Warnf("no alternative credentials available, keeping secret %s: %s", this.secret, failure.err)
 	}
 	if len(zones) == 0 {
 		empty := utils.StringSet{}
@@ -469,10 +717,150 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		}
 	}
 
+	if this.unconfirmed {
+		ready, err := this.checkReadiness(logger)
+		if err != nil {
+			this.zones = nil
+			return this, this.failed(logger, mod, err, true)
+		}
+		if !ready {
+			// the zones are only registered once the readiness is confirmed
+			this.zones = nil
+			this.probing = true
+			return this, this.pending(logger, mod)
+		}
+		this.unconfirmed = false
+	}
+
+	this.prefetchZoneStates(logger)
+
 	this.valid = true
 	this.rateLimit = state.updateProviderRateLimiter(logger, provider)
+	state.updateZoneRateLimiters(logger, provider)
+	if !this.onboarded {
+		this.onboard(logger, zones, results)
+		mod = true
+	}
+
+	status := this.succeeded(logger, mod)
+	if refresh := state.credentials.RefreshIn(this.secret); refresh > 0 && status.IsSucceeded() {
+		// reconcile again to refresh short-lived credentials before they expire
+		status = status.RescheduleAfter(refresh)
+	}
+	return this, status
+}
 
-	return this, this.succeeded(logger, mod)
+// credentialsFailure describes why the credentials of a secret cannot be used for a provider.
+type credentialsFailure struct {
+	err  error
+	temp bool
+	// auth is set for authentication errors, which may be resolved by the credentials of another secret
+	auth bool
+	// recorded is set if the hosted zones are available, but a previous request of the account failed with an authentication error
+	recorded bool
+}
+
+// credentialsSelection is the result of connecting with the credentials of a secret reference of a provider.
+type credentialsSelection struct {
+	index   int
+	secret  resources.ObjectName
+	account *DNSAccount
+	zones   DNSHostedZones
+	failure *credentialsFailure
+}
+
+// selectCredentials connects with the credentials of the secret references, starting with the one at index start.
+// On an authentication error it fails over to the next references and selects the first one without failure.
+// Only the initial connect may update the last account in place, as it uses the credentials of the last active secret.
+// The accounts of rejected credentials are released. It returns the selected and the initially tried credentials,
+// which are the same if there is no working alternative.
+func selectCredentials(logger logger.LogContext, refs []corev1.SecretReference, start int, last *DNSAccount,
+	connect func(ref corev1.SecretReference, last *DNSAccount) (resources.ObjectName, *DNSAccount, DNSHostedZones, *credentialsFailure),
+	release func(account *DNSAccount)) (selected, initial credentialsSelection) {
+	for i := 0; i < len(refs); i++ {
+		c := credentialsSelection{index: (start + i) % len(refs)}
+		if i == 0 {
+			c.secret, c.account, c.zones, c.failure = connect(refs[c.index], last)
+			initial = c
+			if c.failure == nil || !c.failure.auth || len(refs) == 1 {
+				return c, c
+			}
+			continue
+		}
+		c.secret, c.account, c.zones, c.failure = connect(refs[c.index], nil)
+		if c.failure != nil {
+			if c.account != nil && c.account != last && c.account != initial.account {
+				release(c.account)
+			}
+			logger.Infof("credentials of secret %s are failing, too: %s", c.secret, c.failure.err)
+			continue
+		}
+		if initial.account != nil && initial.account != last && initial.account != c.account {
+			release(initial.account)
+		}
+		return c, initial
+	}
+	return initial, initial
+}
+
+// secretReferences returns the secret references of a provider in the order of their usage.
+// References without namespace refer to the namespace of the provider.
+func secretReferences(provider *dnsutils.DNSProviderObject) []corev1.SecretReference {
+	spec := provider.DNSProvider().Spec
+	if spec.SecretRef == nil {
+		return nil
+	}
+	refs := append([]corev1.SecretReference{*spec.SecretRef}, spec.SecretRefs...)
+	for i := range refs {
+		if refs[i].Namespace == "" {
+			refs[i].Namespace = provider.GetNamespace()
+		}
+	}
+	return refs
+}
+
+// connect gets the account for the credentials of the given secret and retrieves its hosted zones.
+// If alternatives are given, authentication errors recorded by the last account also trigger a failover.
+func (this *dnsProviderVersion) connect(ctx context.Context, logger logger.LogContext, ref corev1.SecretReference, last *DNSAccount, alternatives bool) (resources.ObjectName, *DNSAccount, DNSHostedZones, *credentialsFailure) {
+	state := this.state
+	provider := this.object
+	secret := resources.NewObjectName(ref.Namespace, ref.Name)
+	props, _, err := state.GetContext().GetSecretPropertiesByRef(provider, &ref)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return secret, nil, nil, &credentialsFailure{err: fmt.Errorf("cannot get secret %s/%s for provider %s: %s",
+				ref.Namespace, ref.Name, provider.Description(), err)}
+		}
+		return secret, nil, nil, &credentialsFailure{err: fmt.Errorf("error reading secret for provider %q", provider.Description()), temp: true}
+	}
+	props, err = state.credentials.Resolve(ctx, secret, props)
+	if err != nil {
+		return secret, nil, nil, &credentialsFailure{err: fmt.Errorf("cannot obtain credentials of secret %s for provider %s: %w",
+			secret, provider.Description(), err), temp: true}
+	}
+
+	account, err := state.GetDNSAccount(logger, provider, props, last)
+	if err != nil {
+		return secret, nil, nil, &credentialsFailure{err: err, temp: true}
+	}
+
+	zones, err := account.GetZones(ctx)
+	if err != nil {
+		return secret, account, nil, &credentialsFailure{err: fmt.Errorf("cannot get hosted zones: %w", err), temp: true,
+			auth: perrs.Reason(err) == perrs.ReasonAuthFailed}
+	}
+	if alternatives {
+		if err := account.AuthFailure(); err != nil {
+			return secret, account, zones, &credentialsFailure{err: err, temp: true, auth: true, recorded: true}
+		}
+	}
+	return secret, account, zones, nil
+}
+
+// hasFailingCredentials checks whether the account of the provider has recorded an
+// authentication error and alternative credentials are configured.
+func (this *dnsProviderVersion) hasFailingCredentials() bool {
+	return this.account != nil && len(this.object.Spec().SecretRefs) > 0 && this.account.AuthFailure() != nil
 }
 
 func toLightZones(zones DNSHostedZones) []selection.LightDNSHostedZone {
@@ -528,6 +916,22 @@ func (this *dnsProviderVersion) Match(dns string) int {
 	return 0
 }
 
+// isDefaultForNamespace checks whether the provider should be preferred
+// for entries in a namespace with the given labels.
+func (this *dnsProviderVersion) isDefaultForNamespace(nslabels labels.Set) bool {
+	if this.defaultForNamespaces == nil || nslabels == nil {
+		return false
+	}
+	return this.defaultForNamespaces.Matches(nslabels)
+}
+
+func selectorString(sel labels.Selector) string {
+	if sel == nil {
+		return ""
+	}
+	return sel.String()
+}
+
 func (this *dnsProviderVersion) MatchZone(dns string) int {
 	for _, zone := range this.zones {
 		ilen := zone.Match(dns)
@@ -542,6 +946,18 @@ func (this *dnsProviderVersion) MapTarget(t Target) Target {
 	return this.account.MapTarget(t)
 }
 
+func (this *dnsProviderVersion) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return this.account.ValidateRoutingPolicy(policy)
+}
+
+func (this *dnsProviderVersion) SupportsRecordType(rtype string) bool {
+	return this.account.SupportsRecordType(rtype)
+}
+
+func (this *dnsProviderVersion) ProviderHintsFor(rs *dns.RecordSet, hints *dns.ProviderHints) *dns.ProviderHints {
+	return this.account.ProviderHintsFor(rs, hints)
+}
+
 func (this *dnsProviderVersion) setError(modified bool, err error) error {
 	modified = this.object.SetStateWithError(api.STATE_ERROR, err) || modified
 	if modified {
@@ -571,7 +987,7 @@ func (this *dnsProviderVersion) failed(logger logger.LogContext, modified bool,
 		return reconcile.Failed(logger, err)
 	}
 	if this.account != nil {
-		return reconcile.Recheck(logger, err, this.account.RateLimit())
+		return reconcile.Recheck(logger, err, errorBackoff(err, this.account.RateLimit()))
 	}
 	return reconcile.Delay(logger, err)
 }
@@ -598,26 +1014,56 @@ func (this *dnsProviderVersion) succeeded(logger logger.LogContext, modified boo
 	status := &this.object.DNSProvider().Status
 	mod := resources.NewModificationState(this.object, modified)
 	mod.AssureStringValue(&status.State, api.STATE_READY)
-	mod.AssureStringPtrValue(&status.Message, "provider operational")
+	msg := "provider operational"
+	if this.readOnly {
+		msg = "provider operational (read-only)"
+	} else if this.dryRun {
+		msg = "provider operational (dry-run)"
+	}
+	mod.AssureStringPtrValue(&status.Message, msg)
 	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
 	mod.AssureInt64PtrValue(&status.DefaultTTL, this.defaultTTL)
 	assureRateLimit(mod, &status.RateLimit, this.rateLimit)
+	assureActiveSecretRef(mod, &status.ActiveSecretRef, this.activeSecretRef())
 	if mod.IsModified() {
 		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
 	}
 	return reconcile.UpdateStatus(logger, mod)
 }
 
-func (this *dnsProviderVersion) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
-	return this.account.GetZoneState(zone)
+// activeSecretRef returns the reference of the used secret, if alternative secrets are configured.
+func (this *dnsProviderVersion) activeSecretRef() *corev1.SecretReference {
+	if len(this.object.Spec().SecretRefs) == 0 || this.secret == nil {
+		return nil
+	}
+	return &corev1.SecretReference{Namespace: this.secret.Namespace(), Name: this.secret.Name()}
+}
+
+func (this *dnsProviderVersion) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	if err := this.state.waitForZoneRateLimit(ctx, this.ObjectName(), zone.Id()); err != nil {
+		return nil, err
+	}
+	return this.account.GetZoneState(ctx, zone)
 }
 
 func (this *dnsProviderVersion) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
 	return this.account.ReportZoneStateConflict(zone, err)
 }
 
-func (this *dnsProviderVersion) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
-	return this.account.ExecuteRequests(logger, zone, state, reqs)
+func (this *dnsProviderVersion) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	if this.readOnly {
+		return fmt.Errorf("provider %s is read-only", this.ObjectName())
+	}
+	if this.dryRun {
+		return fmt.Errorf("provider %s is in dry-run mode", this.ObjectName())
+	}
+	if IsWriteFrozen() {
+		return fmt.Errorf("provider writes suspended by global write freeze")
+	}
+	if err := this.state.waitForZoneRateLimit(ctx, this.ObjectName(), zone.Id()); err != nil {
+		return err
+	}
+	return this.account.ExecuteRequests(ctx, logger, zone, state, reqs)
 }
 
 func (this *dnsProviderVersion) IncludesZone(zoneID dns.ZoneID) bool {
@@ -636,3 +1082,8 @@ func (this *dnsProviderVersion) GetDedicatedDNSAccess() DedicatedDNSAccess {
 	h, _ := this.account.handler.(DedicatedDNSAccess)
 	return h
 }
+
+func (this *dnsProviderVersion) GetQueryMetricsAccess() QueryMetricsAccess {
+	h, _ := this.account.handler.(QueryMetricsAccess)
+	return h
+}
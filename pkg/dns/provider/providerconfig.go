@@ -0,0 +1,228 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// ProviderConfigSchemaSupport is an optional interface of a DNSHandlerFactory providing the
+// OpenAPI schema of the provider specific configuration (spec.providerConfig) of a provider type.
+// A nil schema means the provider type has no provider specific configuration.
+type ProviderConfigSchemaSupport interface {
+	ProviderConfigSchema(typecode string) *apiextensionsv1.JSONSchemaProps
+}
+
+// ProviderConfigError is the error for a provider config not matching the schema of the provider type.
+type ProviderConfigError struct {
+	ProviderType string
+	Errors       field.ErrorList
+}
+
+func (e *ProviderConfigError) Error() string {
+	return fmt.Sprintf("invalid providerConfig for provider type %s: %s", e.ProviderType, e.Errors.ToAggregate())
+}
+
+var providerConfigPath = field.NewPath("spec", "providerConfig")
+
+var (
+	rawExtensionType    = reflect.TypeOf(runtime.RawExtension{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+)
+
+// ProviderConfigSchemaFor derives the structural schema of a provider config from
+// the json serialization of the given prototype (typically a pointer to the config struct
+// of the handler). Fields with a custom json unmarshaler keep unknown fields.
+func ProviderConfigSchemaFor(proto interface{}) *apiextensionsv1.JSONSchemaProps {
+	return schemaForType(reflect.TypeOf(proto))
+}
+
+func schemaForType(t reflect.Type) *apiextensionsv1.JSONSchemaProps {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawExtensionType || reflect.PtrTo(t).Implements(jsonUnmarshalerType) {
+		return &apiextensionsv1.JSONSchemaProps{XPreserveUnknownFields: boolPtr(true)}
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &apiextensionsv1.JSONSchemaProps{Type: "string"}
+	case reflect.Bool:
+		return &apiextensionsv1.JSONSchemaProps{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &apiextensionsv1.JSONSchemaProps{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &apiextensionsv1.JSONSchemaProps{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &apiextensionsv1.JSONSchemaProps{Type: "array",
+			Items: &apiextensionsv1.JSONSchemaPropsOrArray{Schema: schemaForType(t.Elem())}}
+	case reflect.Map:
+		return &apiextensionsv1.JSONSchemaProps{Type: "object",
+			AdditionalProperties: &apiextensionsv1.JSONSchemaPropsOrBool{Allows: true, Schema: schemaForType(t.Elem())}}
+	case reflect.Struct:
+		schema := &apiextensionsv1.JSONSchemaProps{Type: "object", Properties: map[string]apiextensionsv1.JSONSchemaProps{}}
+		addStructProperties(schema, t)
+		return schema
+	}
+	return &apiextensionsv1.JSONSchemaProps{XPreserveUnknownFields: boolPtr(true)}
+}
+
+func addStructProperties(schema *apiextensionsv1.JSONSchemaProps, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" || (f.PkgPath != "" && !f.Anonymous) {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructProperties(schema, ft)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		schema.Properties[name] = *schemaForType(f.Type)
+	}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// validateProviderConfig validates the provider config of a provider if the schema of its type is known.
+func validateProviderConfig(logger logger.LogContext, factory DNSHandlerFactory, provider *dnsutils.DNSProviderObject) error {
+	config := provider.Spec().ProviderConfig
+	if config == nil || len(config.Raw) == 0 {
+		return nil
+	}
+	support, ok := factory.(ProviderConfigSchemaSupport)
+	if !ok {
+		return nil
+	}
+	schema := support.ProviderConfigSchema(provider.TypeCode())
+	if schema == nil {
+		logger.Infof("provider type %s has no provider specific configuration, ignoring providerConfig", provider.TypeCode())
+		return nil
+	}
+	if errs := ValidateProviderConfig(schema, config); len(errs) > 0 {
+		return &ProviderConfigError{ProviderType: provider.TypeCode(), Errors: errs}
+	}
+	return nil
+}
+
+// ValidateProviderConfig validates a provider config against the schema of the provider type.
+// Unknown fields are reported as unsupported values instead of being silently ignored.
+func ValidateProviderConfig(schema *apiextensionsv1.JSONSchemaProps, config *runtime.RawExtension) field.ErrorList {
+	if schema == nil || config == nil || len(config.Raw) == 0 {
+		return nil
+	}
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(config.Raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return field.ErrorList{field.Invalid(providerConfigPath, string(config.Raw), fmt.Sprintf("invalid json: %s", err))}
+	}
+	return validateValue(schema, providerConfigPath, value)
+}
+
+func validateValue(schema *apiextensionsv1.JSONSchemaProps, path *field.Path, value interface{}) field.ErrorList {
+	if value == nil || schema.Type == "" {
+		return nil
+	}
+	var allErrs field.ErrorList
+	switch schema.Type {
+	case "object":
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return field.ErrorList{field.TypeInvalid(path, value, "must be of type object")}
+		}
+		var known []string
+		for k := range schema.Properties {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if prop, ok := schema.Properties[k]; ok {
+				allErrs = append(allErrs, validateValue(&prop, path.Child(k), m[k])...)
+				continue
+			}
+			if schema.AdditionalProperties != nil && schema.AdditionalProperties.Schema != nil {
+				allErrs = append(allErrs, validateValue(schema.AdditionalProperties.Schema, path.Key(k), m[k])...)
+				continue
+			}
+			if schema.XPreserveUnknownFields != nil && *schema.XPreserveUnknownFields {
+				continue
+			}
+			allErrs = append(allErrs, field.NotSupported(path, k, known))
+		}
+	case "array":
+		a, ok := value.([]interface{})
+		if !ok {
+			return field.ErrorList{field.TypeInvalid(path, value, "must be of type array")}
+		}
+		if schema.Items != nil && schema.Items.Schema != nil {
+			for i, v := range a {
+				allErrs = append(allErrs, validateValue(schema.Items.Schema, path.Index(i), v)...)
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			allErrs = append(allErrs, field.TypeInvalid(path, value, "must be of type string"))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			allErrs = append(allErrs, field.TypeInvalid(path, value, "must be of type boolean"))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if ok {
+			_, err := n.Int64()
+			ok = err == nil
+		}
+		if !ok {
+			allErrs = append(allErrs, field.TypeInvalid(path, value, "must be of type integer"))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			allErrs = append(allErrs, field.TypeInvalid(path, value, "must be of type number"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+type testSubConfig struct {
+	Region  string                `json:"region,omitempty"`
+	Weights map[string]int        `json:"weights,omitempty"`
+	Extra   *runtime.RawExtension `json:"extra,omitempty"`
+}
+
+type testProviderConfig struct {
+	BatchSize int            `json:"batchSize"`
+	Enabled   *bool          `json:"enabled,omitempty"`
+	Domains   []string       `json:"domains,omitempty"`
+	Sub       *testSubConfig `json:"sub,omitempty"`
+	internal  string
+}
+
+var _ = ginkgov2.Describe("Provider config schema", func() {
+	schema := ProviderConfigSchemaFor(&testProviderConfig{})
+
+	validate := func(raw string) field.ErrorList {
+		return ValidateProviderConfig(schema, &runtime.RawExtension{Raw: []byte(raw)})
+	}
+
+	ginkgov2.It("derives the schema from the config struct", func() {
+		Expect(schema.Type).To(Equal("object"))
+		Expect(schema.Properties).To(HaveLen(4))
+		Expect(schema.Properties["batchSize"].Type).To(Equal("integer"))
+		Expect(schema.Properties["enabled"].Type).To(Equal("boolean"))
+		Expect(schema.Properties["domains"].Items.Schema.Type).To(Equal("string"))
+		Expect(schema.Properties["sub"].Properties["weights"].AdditionalProperties.Schema.Type).To(Equal("integer"))
+		Expect(*schema.Properties["sub"].Properties["extra"].XPreserveUnknownFields).To(BeTrue())
+	})
+
+	ginkgov2.It("accepts valid configs", func() {
+		Expect(validate(`{"batchSize": 10, "enabled": true, "domains": ["a.b"], "sub": {"region": "r1", "weights": {"x": 1}, "extra": {"any": [1]}}}`)).To(BeEmpty())
+		Expect(validate(`{"sub": null}`)).To(BeEmpty())
+		Expect(ValidateProviderConfig(schema, nil)).To(BeEmpty())
+	})
+
+	ginkgov2.It("reports unknown fields and wrong types", func() {
+		errs := validate(`{"batchsize": 10, "enabled": "yes", "domains": ["a", 1], "sub": {"regoin": "r1", "weights": {"x": 1.5}}}`)
+		Expect(errs).To(HaveLen(5))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeNotSupported))
+		Expect(errs[0].Field).To(Equal("spec.providerConfig"))
+		Expect(errs[0].BadValue).To(Equal("batchsize"))
+		Expect(errs[1].Field).To(Equal("spec.providerConfig.domains[1]"))
+		Expect(errs[1].Type).To(Equal(field.ErrorTypeTypeInvalid))
+		Expect(errs[2].Field).To(Equal("spec.providerConfig.enabled"))
+		Expect(errs[3].Field).To(Equal("spec.providerConfig.sub"))
+		Expect(errs[3].BadValue).To(Equal("regoin"))
+		Expect(errs[4].Field).To(Equal("spec.providerConfig.sub.weights[x]"))
+
+		err := &ProviderConfigError{ProviderType: "test", Errors: errs[:1]}
+		Expect(err.Error()).To(Equal(`invalid providerConfig for provider type test: spec.providerConfig: Unsupported value: "batchsize": supported values: "batchSize", "domains", "enabled", "sub"`))
+	})
+
+	ginkgov2.It("rejects invalid json", func() {
+		errs := validate(`{"batchSize": `)
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Type).To(Equal(field.ErrorTypeInvalid))
+	})
+})
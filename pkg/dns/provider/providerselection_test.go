@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var _ = ginkgov2.Describe("Provider selection", func() {
+	teamA := labels.Set{"team": "a"}
+	newProvider := func(domain string, defaultFor labels.Set) *dnsProviderVersion {
+		p := &dnsProviderVersion{
+			valid:    true,
+			included: utils.NewStringSet(domain),
+			excluded: utils.NewStringSet(),
+		}
+		if defaultFor != nil {
+			p.defaultForNamespaces = labels.SelectorFromSet(defaultFor)
+		}
+		return p
+	}
+	allowAll := func(p *dnsProviderVersion) error { return nil }
+	selected := func(providers map[resources.ObjectName]*dnsProviderVersion, dnsname string, nslabels labels.Set, current string) resources.ObjectName {
+		found, _, err := selectProvider(providers, dnsname, nslabels, current, allowAll)
+		Expect(err).NotTo(HaveOccurred())
+		for name, p := range providers {
+			if DNSProvider(p) == found {
+				return name
+			}
+		}
+		return nil
+	}
+
+	general := resources.NewObjectName("default", "general")
+	team := resources.NewObjectName("default", "team-a")
+	sub := resources.NewObjectName("default", "sub")
+
+	ginkgov2.It("prefers the namespace default provider on equal matches", func() {
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			general: newProvider("example.com", nil),
+			team:    newProvider("example.com", teamA),
+		}
+		for i := 0; i < 10; i++ {
+			Expect(selected(providers, "www.example.com", teamA, "")).To(Equal(team))
+		}
+	})
+
+	ginkgov2.It("keeps the current provider on equal matches without namespace default", func() {
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			general: newProvider("example.com", nil),
+			team:    newProvider("example.com", teamA),
+		}
+		for i := 0; i < 10; i++ {
+			Expect(selected(providers, "www.example.com", labels.Set{"team": "b"}, general.String())).To(Equal(general))
+			Expect(selected(providers, "www.example.com", nil, team.String())).To(Equal(team))
+		}
+	})
+
+	ginkgov2.It("prefers a longer domain match over the namespace default provider", func() {
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			team: newProvider("example.com", teamA),
+			sub:  newProvider("sub.example.com", nil),
+		}
+		Expect(selected(providers, "www.sub.example.com", teamA, "")).To(Equal(sub))
+		Expect(selected(providers, "www.example.com", teamA, "")).To(Equal(team))
+	})
+
+	ginkgov2.It("prefers the namespace default provider with longer match", func() {
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			general: newProvider("example.com", nil),
+			team:    newProvider("team.example.com", teamA),
+		}
+		Expect(selected(providers, "www.team.example.com", teamA, "")).To(Equal(team))
+	})
+
+	ginkgov2.It("skips namespace default providers without access", func() {
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			general: newProvider("example.com", nil),
+			team:    newProvider("example.com", teamA),
+		}
+		denyTeam := func(p *dnsProviderVersion) error {
+			if p == providers[team] {
+				return fmt.Errorf("access denied")
+			}
+			return nil
+		}
+		found, _, err := selectProvider(providers, "www.example.com", teamA, "", denyTeam)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeIdenticalTo(DNSProvider(providers[general])))
+	})
+
+	ginkgov2.It("ignores unconfirmed and invalid providers", func() {
+		unconfirmed := newProvider("example.com", teamA)
+		unconfirmed.unconfirmed = true
+		invalid := newProvider("sub.example.com", nil)
+		invalid.valid = false
+		providers := map[resources.ObjectName]*dnsProviderVersion{
+			general: newProvider("example.com", nil),
+			team:    unconfirmed,
+			sub:     invalid,
+		}
+		Expect(selected(providers, "www.example.com", teamA, "")).To(Equal(general))
+		Expect(selected(providers, "www.sub.example.com", teamA, "")).To(Equal(general))
+	})
+})
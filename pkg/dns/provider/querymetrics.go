@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// QueryMetricsAccess is an optional interface of a DNSHandler
+// providing the DNS query counts observed by the provider.
+type QueryMetricsAccess interface {
+	// GetQueryCounts returns the number of queries per DNS name (lower case without trailing dot)
+	// received for the hosted zone in the given time range.
+	// It returns nil if query metrics are not configured for the zone.
+	GetQueryCounts(ctx context.Context, zone DNSHostedZone, start, end time.Time) (map[string]int64, error)
+}
+
+type zoneQueryMetrics struct {
+	zone    DNSHostedZone
+	access  QueryMetricsAccess
+	entries []*Entry
+}
+
+func (this *state) getZoneQueryMetrics() map[dns.ZoneID]*zoneQueryMetrics {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	result := map[dns.ZoneID]*zoneQueryMetrics{}
+	for zoneid, zone := range this.zones {
+		for _, p := range this.getProvidersForZone(zoneid) {
+			if access := p.GetQueryMetricsAccess(); access != nil {
+				result[zoneid] = &zoneQueryMetrics{zone: zone.zone, access: access}
+				break
+			}
+		}
+	}
+	for _, e := range this.entries {
+		if e.Kind() != api.DNSEntryKind {
+			continue
+		}
+		if m := result[e.ZoneId()]; m != nil {
+			m.entries = append(m.entries, e)
+		}
+	}
+	return result
+}
+
+// UpdateQueryMetrics ingests the query counts of all zones supporting query metrics
+// and updates the query rates in the status of the entries.
+func (this *state) UpdateQueryMetrics(ctx context.Context, logger logger.LogContext) {
+	period := this.config.QueryMetricsPeriod
+	end := time.Now()
+	start := end.Add(-period)
+	for zoneid, m := range this.getZoneQueryMetrics() {
+		counts, err := m.access.GetQueryCounts(ctx, m.zone, start, end)
+		if err != nil {
+			logger.Warnf("cannot get query metrics for zone %s: %s", zoneid, err)
+			continue
+		}
+		if counts == nil {
+			continue
+		}
+		total, unqueried := this.updateQueryRates(logger, m.entries, counts, period)
+		metrics.ReportZoneQueries(zoneid, total, unqueried)
+	}
+}
+
+func (this *state) updateQueryRates(logger logger.LogContext, entries []*Entry, counts map[string]int64, period time.Duration) (int64, int) {
+	var total int64
+	for _, c := range counts {
+		total += c
+	}
+	total = queriesPerHour(total, period)
+
+	names := utils.StringSet{}
+	for _, e := range entries {
+		names.Add(normalizeQueryName(e.DNSName()))
+	}
+	unqueried := 0
+	for _, e := range entries {
+		rate := queriesPerHour(entryQueryCount(normalizeQueryName(e.DNSName()), counts, names), period)
+		if rate == 0 {
+			unqueried++
+		}
+		e.lock.Lock()
+		_, err := e.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+			status := &data.(*api.DNSEntry).Status
+			if status.QueriesPerHour != nil && *status.QueriesPerHour == rate {
+				return false, nil
+			}
+			status.QueriesPerHour = &rate
+			return true, nil
+		})
+		e.lock.Unlock()
+		if err != nil {
+			logger.Warnf("cannot update query rate of entry %s: %s", e.ObjectName(), err)
+		}
+	}
+	return total, unqueried
+}
+
+// entryQueryCount sums up the queries for a DNS name. For wildcard names
+// all queried names are included which are covered by the wildcard and
+// are not managed by another entry.
+func entryQueryCount(name string, counts map[string]int64, names utils.StringSet) int64 {
+	if !strings.HasPrefix(name, "*.") {
+		return counts[name]
+	}
+	var sum int64
+	suffix := name[1:]
+	for n, c := range counts {
+		if strings.HasSuffix(n, suffix) && !names.Contains(n) {
+			sum += c
+		}
+	}
+	return sum
+}
+
+func normalizeQueryName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func queriesPerHour(count int64, period time.Duration) int64 {
+	if period <= 0 {
+		return 0
+	}
+	return int64(float64(count) * float64(time.Hour) / float64(period))
+}
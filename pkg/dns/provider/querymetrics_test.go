@@ -0,0 +1,51 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgov2.Describe("Query metrics", func() {
+	counts := map[string]int64{
+		"a.example.com":   10,
+		"b.example.com":   4,
+		"x.b.example.com": 2,
+		"c.example.com":   1,
+	}
+	names := utils.NewStringSet("a.example.com", "*.example.com", "*.b.example.com")
+
+	ginkgov2.It("counts queries of plain names", func() {
+		Expect(entryQueryCount("a.example.com", counts, names)).To(Equal(int64(10)))
+		Expect(entryQueryCount("d.example.com", counts, names)).To(Equal(int64(0)))
+	})
+
+	ginkgov2.It("counts queries of wildcard names not managed by other entries", func() {
+		Expect(entryQueryCount("*.example.com", counts, names)).To(Equal(int64(7)))
+		Expect(entryQueryCount("*.b.example.com", counts, names)).To(Equal(int64(2)))
+	})
+
+	ginkgov2.It("scales counts to queries per hour", func() {
+		Expect(queriesPerHour(30, 30*time.Minute)).To(Equal(int64(60)))
+		Expect(queriesPerHour(30, 2*time.Hour)).To(Equal(int64(15)))
+		Expect(queriesPerHour(30, 0)).To(Equal(int64(0)))
+		Expect(normalizeQueryName("A.Example.com.")).To(Equal("a.example.com"))
+	})
+})
@@ -24,35 +24,39 @@ import (
 )
 
 type RateLimiterConfig struct {
-	QPS   float32
-	Burst int
+	QPS      float32
+	Burst    int
+	Adaptive bool
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
 type RateLimiterOptions struct {
-	Enabled bool
-	QPS     int
-	Burst   int
+	Enabled  bool
+	QPS      int
+	Burst    int
+	Adaptive bool
 }
 
 var RateLimiterOptionDefaults = RateLimiterOptions{
-	Enabled: true,
-	QPS:     10,
-	Burst:   20,
+	Enabled:  true,
+	QPS:      10,
+	Burst:    20,
+	Adaptive: true,
 }
 
 func (this *RateLimiterOptions) AddOptionsToSet(set config.OptionSet) {
 	set.AddBoolOption(&this.Enabled, OPT_RATELIMITER_ENABLED, "", this.Enabled, "enables rate limiter for DNS provider requests")
 	set.AddIntOption(&this.QPS, OPT_RATELIMITER_QPS, "", this.QPS, "maximum requests/queries per second")
 	set.AddIntOption(&this.Burst, OPT_RATELIMITER_BURST, "", this.Burst, "number of burst requests for rate limiter")
+	set.AddBoolOption(&this.Adaptive, OPT_RATELIMITER_ADAPTIVE, "", this.Adaptive, "adapts rate limit to throttling responses of the DNS provider API (additive increase, multiplicative decrease)")
 }
 
 func (c *RateLimiterOptions) GetRateLimiterConfig() *RateLimiterConfig {
 	if !c.Enabled {
 		return nil
 	}
-	return &RateLimiterConfig{QPS: float32(c.QPS), Burst: c.Burst}
+	return &RateLimiterConfig{QPS: float32(c.QPS), Burst: c.Burst, Adaptive: c.Adaptive}
 }
 
 // configuration helpers
@@ -72,10 +76,15 @@ func (c RateLimiterOptions) SetEnabled(enabled bool) RateLimiterOptions {
 	return c
 }
 
+func (c RateLimiterOptions) SetAdaptive(adaptive bool) RateLimiterOptions {
+	c.Adaptive = adaptive
+	return c
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (c *RateLimiterConfig) String() string {
-	return fmt.Sprintf("QPS: %f, Burst: %d", c.QPS, c.Burst)
+	return fmt.Sprintf("QPS: %f, Burst: %d, Adaptive: %t", c.QPS, c.Burst, c.Adaptive)
 }
 
 func (c *RateLimiterConfig) NewRateLimiter() (flowcontrol.RateLimiter, error) {
@@ -90,6 +99,9 @@ func (c *RateLimiterConfig) NewRateLimiter() (flowcontrol.RateLimiter, error) {
 		return nil, fmt.Errorf("invalid burst value %d", c.Burst)
 	}
 
+	if c.Adaptive {
+		return NewAdaptiveRateLimiter(c.QPS, c.Burst), nil
+	}
 	return flowcontrol.NewTokenBucketRateLimiter(c.QPS, c.Burst), nil
 }
 
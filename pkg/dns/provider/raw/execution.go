@@ -17,6 +17,7 @@
 package raw
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
@@ -34,6 +35,27 @@ type Executor interface {
 	GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (RecordSet, error)
 }
 
+// BatchExecutor is optionally implemented by executors able to submit
+// several record changes of a zone with a single request.
+type BatchExecutor interface {
+	Executor
+	// BatchSize is the maximum number of record changes per batch, 0 means unlimited.
+	BatchSize() int
+	ExecuteBatch(batch *Batch, zone provider.DNSHostedZone) error
+}
+
+// Batch is a set of record changes to be submitted with a single request.
+type Batch struct {
+	Additions RecordSet
+	Updates   RecordSet
+	Deletions RecordSet
+}
+
+// Size returns the number of record changes of the batch.
+func (this *Batch) Size() int {
+	return len(this.Additions) + len(this.Updates) + len(this.Deletions)
+}
+
 type result struct {
 	done []provider.DoneHandler
 	err  error
@@ -41,6 +63,7 @@ type result struct {
 
 type Execution struct {
 	logger.LogContext
+	ctx      context.Context
 	executor Executor
 	zone     provider.DNSHostedZone
 	state    *ZoneState
@@ -53,9 +76,10 @@ type Execution struct {
 	results map[string]*result
 }
 
-func NewExecution(logger logger.LogContext, e Executor, state *ZoneState, zone provider.DNSHostedZone) *Execution {
+func NewExecution(ctx context.Context, logger logger.LogContext, e Executor, state *ZoneState, zone provider.DNSHostedZone) *Execution {
 	return &Execution{
 		LogContext: logger,
+		ctx:        ctx,
 		executor:   e,
 		zone:       zone,
 		state:      state,
@@ -113,14 +137,16 @@ func (this *Execution) add(dnsname string, rset *dns.RecordSet, modonly bool, fo
 	for _, r := range rset.Records {
 		old := this.state.GetRecord(dnsname, rtype, r.Value)
 		if old != nil {
-			if (!modonly) || (old.GetTTL() != int(rset.TTL)) {
+			if (!modonly) || (old.GetTTL() != int(rset.TTL)) || !GetProviderHints(old).Match(rset.ProviderHints) {
 				or := old.Copy()
 				or.SetTTL(int(rset.TTL))
+				SetProviderHints(or, rset.ProviderHints)
 				*found = append(*found, or)
 			}
 		} else {
 			if notfound != nil {
 				record := this.executor.NewRecord(dnsname, rset.Type, r.Value, this.zone, rset.TTL)
+				SetProviderHints(record, rset.ProviderHints)
 				*notfound = append(*notfound, record)
 			}
 		}
@@ -134,17 +160,21 @@ func (this *Execution) SubmitChanges() error {
 	}
 
 	this.Infof("processing changes for zone %s", this.zone.Id())
-	for _, r := range this.additions {
-		this.Infof("desired change: Addition %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
-		this.submit(this.executor.CreateRecord, r)
-	}
-	for _, r := range this.updates {
-		this.Infof("desired change: Update %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
-		this.submit(this.executor.UpdateRecord, r)
-	}
-	for _, r := range this.deletions {
-		this.Infof("desired change: Deletion %s %s: %s", r.GetDNSName(), r.GetType(), r.GetValue())
-		this.submit(this.executor.DeleteRecord, r)
+	if e, ok := this.executor.(BatchExecutor); ok {
+		this.submitBatches(e)
+	} else {
+		for _, r := range this.additions {
+			this.Infof("desired change: Addition %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
+			this.submit(this.executor.CreateRecord, r)
+		}
+		for _, r := range this.updates {
+			this.Infof("desired change: Update %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
+			this.submit(this.executor.UpdateRecord, r)
+		}
+		for _, r := range this.deletions {
+			this.Infof("desired change: Deletion %s %s: %s", r.GetDNSName(), r.GetType(), r.GetValue())
+			this.submit(this.executor.DeleteRecord, r)
+		}
 	}
 
 	err_cnt := 0
@@ -174,18 +204,71 @@ func (this *Execution) SubmitChanges() error {
 }
 
 func (this *Execution) submit(f func(record Record, zone provider.DNSHostedZone) error, r Record) {
-	err := f(r, this.zone)
+	// the executor calls are not context aware, so stop submitting
+	// further changes once the deadline of the execution is exceeded
+	err := this.ctx.Err()
+	if err == nil {
+		err = f(r, this.zone)
+	}
 	if err != nil {
-		res := this.results[r.GetDNSName()]
-		if res != nil {
-			res.err = err
-			this.Infof("operation failed for %s %s: %s", r.GetType(), r.GetDNSName(), err)
+		this.failed(r, err)
+	}
+}
+
+// submitBatches submits the record changes in batches limited by the batch size of the executor.
+// If a batch fails, all record changes of the batch are considered as failed.
+func (this *Execution) submitBatches(e BatchExecutor) {
+	size := e.BatchSize()
+	batch := &Batch{}
+	flush := func() {
+		if batch.Size() == 0 {
+			return
+		}
+		err := this.ctx.Err()
+		if err == nil {
+			err = e.ExecuteBatch(batch, this.zone)
+		}
+		if err != nil {
+			for _, set := range []RecordSet{batch.Additions, batch.Updates, batch.Deletions} {
+				for _, r := range set {
+					this.failed(r, err)
+				}
+			}
+		}
+		batch = &Batch{}
+	}
+	add := func(set *RecordSet, r Record) {
+		*set = append(*set, r)
+		if size > 0 && batch.Size() >= size {
+			flush()
 		}
 	}
+
+	for _, r := range this.additions {
+		this.Infof("desired change: Addition %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
+		add(&batch.Additions, r)
+	}
+	for _, r := range this.updates {
+		this.Infof("desired change: Update %s %s: %s (%d)", r.GetDNSName(), r.GetType(), r.GetValue(), r.GetTTL())
+		add(&batch.Updates, r)
+	}
+	for _, r := range this.deletions {
+		this.Infof("desired change: Deletion %s %s: %s", r.GetDNSName(), r.GetType(), r.GetValue())
+		add(&batch.Deletions, r)
+	}
+	flush()
+}
+
+func (this *Execution) failed(r Record, err error) {
+	res := this.results[r.GetDNSName()]
+	if res != nil {
+		res.err = err
+		this.Infof("operation failed for %s %s: %s", r.GetType(), r.GetDNSName(), err)
+	}
 }
 
-func ExecuteRequests(logger logger.LogContext, config *provider.DNSHandlerConfig, e Executor, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
-	exec := NewExecution(logger, e, state.(*ZoneState), zone)
+func ExecuteRequests(ctx context.Context, logger logger.LogContext, config *provider.DNSHandlerConfig, e Executor, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	exec := NewExecution(ctx, logger, e, state.(*ZoneState), zone)
 	for _, r := range reqs {
 		exec.AddChange(r)
 	}
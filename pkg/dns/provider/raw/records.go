@@ -34,6 +34,27 @@ type Record interface {
 	Copy() Record
 }
 
+// ProviderHintsRecord is optionally implemented by records supporting provider hints.
+type ProviderHintsRecord interface {
+	GetProviderHints() *dns.ProviderHints
+	SetProviderHints(hints *dns.ProviderHints)
+}
+
+// GetProviderHints returns the provider hints of a record, if supported.
+func GetProviderHints(r Record) *dns.ProviderHints {
+	if h, ok := r.(ProviderHintsRecord); ok {
+		return h.GetProviderHints()
+	}
+	return nil
+}
+
+// SetProviderHints sets the provider hints of a record, if supported.
+func SetProviderHints(r Record, hints *dns.ProviderHints) {
+	if h, ok := r.(ProviderHintsRecord); ok {
+		h.SetProviderHints(hints)
+	}
+}
+
 type RecordSet []Record
 
 func (this RecordSet) Clone() RecordSet {
@@ -80,7 +101,7 @@ func (this *ZoneState) Clone() provider.DNSZoneState {
 }
 
 func (this *ZoneState) AddRecord(r Record) {
-	if dns.SupportedRecordType(r.GetType()) {
+	if dns.SupportedRecordType(r.GetType()) || dns.StructuredRecordType(r.GetType()) {
 		name := r.GetDNSName()
 		t := r.GetType()
 		e := this.records[name]
@@ -111,6 +132,7 @@ func (this *ZoneState) CalculateDNSSets() {
 			rs := dns.NewRecordSet(rtype, 0, nil)
 			for _, r := range rset {
 				rs.TTL = int64(r.GetTTL())
+				rs.ProviderHints = GetProviderHints(r)
 				rs.Add(&dns.Record{Value: r.GetValue()})
 			}
 			this.dnssets.AddRecordSetFromProvider(dnsname, rs)
@@ -119,7 +141,7 @@ func (this *ZoneState) CalculateDNSSets() {
 }
 
 func EnsureQuotedText(v string) string {
-	if _, err := strconv.Unquote(v); err != nil {
+	if _, err := dns.SplitTextValue(v); err != nil {
 		v = strconv.Quote(v)
 	}
 	return v
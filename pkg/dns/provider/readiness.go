@@ -0,0 +1,158 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// readinessProbeTimeout limits the duration of a readiness probe of a provider.
+const readinessProbeTimeout = 2 * time.Minute
+
+// readinessProbe is the asynchronous check of the write permission of a newly created provider.
+type readinessProbe struct {
+	account *DNSAccount
+	done    bool
+	err     error
+}
+
+// readinessProbes keeps track of the readiness probes of the providers.
+// Entries are only assigned to a newly created provider after its probe has succeeded,
+// so that they do not run through failures while the provider is still bootstrapping.
+type readinessProbes struct {
+	lock   sync.Mutex
+	probes map[resources.ObjectName]*readinessProbe
+}
+
+func newReadinessProbes() *readinessProbes {
+	return &readinessProbes{probes: map[resources.ObjectName]*readinessProbe{}}
+}
+
+// Check returns true if the readiness probe of the provider has completed, together with its result.
+// Otherwise the probe is started in the background if it is not running yet, and trigger is called
+// once it has completed. A completed probe is forgotten, so that the next check after a failed probe
+// probes again.
+func (this *readinessProbes) Check(ctx context.Context, logger logger.LogContext, name resources.ObjectName, account *DNSAccount, zones DNSHostedZones, trigger func()) (bool, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	probe := this.probes[name]
+	if probe != nil && probe.account == account {
+		if !probe.done {
+			return false, nil
+		}
+		delete(this.probes, name)
+		return true, probe.err
+	}
+	probe = &readinessProbe{account: account}
+	this.probes[name] = probe
+	logger.Infof("starting readiness probe for %d zones", len(zones))
+	go func() {
+		err := probeWritePermission(ctx, account, zones)
+		this.lock.Lock()
+		if this.probes[name] != probe {
+			this.lock.Unlock()
+			return
+		}
+		probe.done = true
+		probe.err = err
+		this.lock.Unlock()
+		if err != nil {
+			logger.Warnf("readiness probe failed: %s", err)
+		} else {
+			logger.Infof("readiness probe succeeded")
+		}
+		trigger()
+	}()
+	return false, nil
+}
+
+// Remove forgets the readiness probe of a provider. The result of a still running probe is discarded.
+func (this *readinessProbes) Remove(name resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.probes, name)
+}
+
+// probeWritePermission checks the write permission for all zones, one after another.
+func probeWritePermission(ctx context.Context, account *DNSAccount, zones DNSHostedZones) error {
+	ctx, cancel := withTimeout(ctx, readinessProbeTimeout)
+	defer cancel()
+	for _, z := range zones {
+		err := account.ProbeWritePermission(ctx, z)
+		if perrs.IsWritePermissionUnverifiable(err) {
+			// the first zone is sufficient to find out that the probe is not possible at all
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("no write permission for zone %s (%s): %s", z.Id(), z.Domain(), err)
+		}
+	}
+	return nil
+}
+
+// needsReadinessProbe returns true if the readiness of the provider has not been confirmed yet.
+// Providers which have been ready before are not probed, this avoids delaying the entries
+// of all providers on a restart of the controller.
+func (this *dnsProviderVersion) needsReadinessProbe(last *dnsProviderVersion) bool {
+	if last != nil {
+		return last.unconfirmed
+	}
+	return this.object.Status().State != api.STATE_READY
+}
+
+// checkReadiness checks the readiness of a provider not confirmed yet.
+// It returns false as long as the readiness probe is pending.
+func (this *dnsProviderVersion) checkReadiness(logger logger.LogContext) (bool, error) {
+	if !this.account.SupportsWritePermissionProbe() {
+		return true, nil
+	}
+	var zones DNSHostedZones
+	for _, z := range this.zones {
+		if z.Id().ProviderType == this.TypeCode() && this.included_zones.Contains(z.Id().ID) {
+			zones = append(zones, z)
+		}
+	}
+	key := this.object.ClusterKey()
+	return this.state.readiness.Check(this.state.GetContext().GetContext(), logger, this.ObjectName(), this.account, zones, func() {
+		this.state.triggerKey(key)
+	})
+}
+
+// pending sets the state of a provider waiting for its readiness probe.
+func (this *dnsProviderVersion) pending(logger logger.LogContext, modified bool) reconcile.Status {
+	status := &this.object.DNSProvider().Status
+	mod := resources.NewModificationState(this.object, modified)
+	mod.AssureStringValue(&status.State, api.STATE_PENDING)
+	mod.AssureStringPtrValue(&status.Message, "waiting for readiness probe")
+	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
+	if mod.IsModified() {
+		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
+	}
+	return reconcile.UpdateStatus(logger, mod)
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type probeTestHandler struct {
+	prefetchTestHandler
+	probes       int32
+	deny         string
+	unverifiable bool
+}
+
+func (h *probeTestHandler) ProbeWritePermission(ctx context.Context, zone DNSHostedZone) error {
+	atomic.AddInt32(&h.probes, 1)
+	if h.unverifiable {
+		return perrs.NewWritePermissionUnverifiable(fmt.Errorf("simulation not permitted"))
+	}
+	if zone.Id().ID == h.deny {
+		return fmt.Errorf("access denied")
+	}
+	return nil
+}
+
+var _ = ginkgov2.Describe("Provider readiness probes", func() {
+	var (
+		handler   *probeTestHandler
+		account   *DNSAccount
+		probes    *readinessProbes
+		zones     DNSHostedZones
+		name      = resources.NewObjectName("default", "p1")
+		triggered int32
+		trigger   = func() { atomic.AddInt32(&triggered, 1) }
+	)
+
+	ginkgov2.BeforeEach(func() {
+		handler = &probeTestHandler{prefetchTestHandler: prefetchTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+		account = NewDNSAccount(nil, handler, "hash")
+		probes = newReadinessProbes()
+		zones = DNSHostedZones{
+			NewDNSHostedZone("test", "Z1", "z1.example.com", "", nil, false),
+			NewDNSHostedZone("test", "Z2", "z2.example.com", "", nil, false),
+		}
+		atomic.StoreInt32(&triggered, 0)
+	})
+
+	check := func() (bool, error) {
+		return probes.Check(context.TODO(), logger.New(), name, account, zones, trigger)
+	}
+
+	ginkgov2.It("reports the readiness once the probe has succeeded", func() {
+		Expect(account.SupportsWritePermissionProbe()).To(BeTrue())
+		ready, err := check()
+		Expect(ready).To(BeFalse())
+		Expect(err).To(BeNil())
+		Eventually(func() int32 { return atomic.LoadInt32(&triggered) }, time.Second).Should(Equal(int32(1)))
+		ready, err = check()
+		Expect(ready).To(BeTrue())
+		Expect(err).To(BeNil())
+		Expect(atomic.LoadInt32(&handler.probes)).To(Equal(int32(2)))
+	})
+
+	ginkgov2.It("probes again after a failed probe", func() {
+		handler.deny = "Z1"
+		check()
+		Eventually(func() int32 { return atomic.LoadInt32(&triggered) }, time.Second).Should(Equal(int32(1)))
+		ready, err := check()
+		Expect(ready).To(BeTrue())
+		Expect(err).To(MatchError(ContainSubstring("no write permission for zone test/Z1")))
+
+		handler.deny = ""
+		ready, _ = check()
+		Expect(ready).To(BeFalse())
+		Eventually(func() int32 { return atomic.LoadInt32(&triggered) }, time.Second).Should(Equal(int32(2)))
+		ready, err = check()
+		Expect(ready).To(BeTrue())
+		Expect(err).To(BeNil())
+	})
+
+	ginkgov2.It("reports the readiness if the permission cannot be verified", func() {
+		handler.unverifiable = true
+		check()
+		Eventually(func() int32 { return atomic.LoadInt32(&triggered) }, time.Second).Should(Equal(int32(1)))
+		ready, err := check()
+		Expect(ready).To(BeTrue())
+		Expect(err).To(BeNil())
+		Expect(atomic.LoadInt32(&handler.probes)).To(Equal(int32(1)))
+	})
+
+	ginkgov2.It("discards the result of a removed probe", func() {
+		check()
+		probes.Remove(name)
+		Consistently(func() int32 { return atomic.LoadInt32(&triggered) }, 100*time.Millisecond).Should(Equal(int32(0)))
+	})
+})
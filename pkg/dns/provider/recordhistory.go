@@ -0,0 +1,179 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// RecordSetRevision is a previous value of a managed record set kept in the zone cache.
+type RecordSetRevision struct {
+	// Time is the time the value has been replaced or deleted.
+	Time   time.Time `json:"time"`
+	TTL    int64     `json:"ttl"`
+	Values []string  `json:"values"`
+}
+
+// RecordSetHistory are the previous values of a record set (oldest first).
+type RecordSetHistory struct {
+	Name          string              `json:"name"`
+	SetIdentifier string              `json:"setIdentifier,omitempty"`
+	Type          string              `json:"type"`
+	Revisions     []RecordSetRevision `json:"revisions"`
+}
+
+type recordHistoryKey struct {
+	name          string
+	setIdentifier string
+	rtype         string
+}
+
+// recordHistory keeps a bounded number of previous values of the record sets changed by the controller.
+// It is kept independent of the cached zone states to survive their discarding.
+type recordHistory struct {
+	lock  sync.Mutex
+	size  int
+	zones map[dns.ZoneID]map[recordHistoryKey][]RecordSetRevision
+}
+
+func newRecordHistory() *recordHistory {
+	return &recordHistory{zones: map[dns.ZoneID]map[recordHistoryKey][]RecordSetRevision{}}
+}
+
+// setSize sets the maximum number of revisions per record set, a size of 0 disables the history.
+func (this *recordHistory) setSize(size int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.size = size
+	if size <= 0 {
+		this.zones = map[dns.ZoneID]map[recordHistoryKey][]RecordSetRevision{}
+		return
+	}
+	for _, sets := range this.zones {
+		for key, revisions := range sets {
+			if len(revisions) > size {
+				sets[key] = revisions[len(revisions)-size:]
+			}
+		}
+	}
+}
+
+// record adds the previous value of the record set replaced or deleted by the change request.
+func (this *recordHistory) record(zoneID dns.ZoneID, req *ChangeRequest, now time.Time) {
+	if req.Deletion == nil || req.Action == R_CREATE {
+		return
+	}
+	rs := req.Deletion.Sets[req.Type]
+	if rs == nil {
+		return
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.size <= 0 {
+		return
+	}
+	sets := this.zones[zoneID]
+	if sets == nil {
+		sets = map[recordHistoryKey][]RecordSetRevision{}
+		this.zones[zoneID] = sets
+	}
+	ttl, values := auditValues(rs)
+	key := recordHistoryKey{name: req.Deletion.Name, setIdentifier: req.Deletion.SetIdentifier, rtype: req.Type}
+	revisions := append(sets[key], RecordSetRevision{Time: now, TTL: ttl, Values: values})
+	if len(revisions) > this.size {
+		revisions = revisions[len(revisions)-this.size:]
+	}
+	sets[key] = revisions
+}
+
+// get returns a copy of the previous values of a record set (oldest first).
+func (this *recordHistory) get(zoneID dns.ZoneID, name, setIdentifier, rtype string) []RecordSetRevision {
+	if this == nil {
+		return nil
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	revisions := this.zones[zoneID][recordHistoryKey{name: name, setIdentifier: setIdentifier, rtype: rtype}]
+	if len(revisions) == 0 {
+		return nil
+	}
+	return append([]RecordSetRevision{}, revisions...)
+}
+
+// dump returns the history of all record sets of a zone sorted by name and type.
+func (this *recordHistory) dump(zoneID dns.ZoneID) []*RecordSetHistory {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var result []*RecordSetHistory
+	for key, revisions := range this.zones[zoneID] {
+		result = append(result, &RecordSetHistory{
+			Name:          key.name,
+			SetIdentifier: key.setIdentifier,
+			Type:          key.rtype,
+			Revisions:     append([]RecordSetRevision{}, revisions...),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		if result[i].SetIdentifier != result[j].SetIdentifier {
+			return result[i].SetIdentifier < result[j].SetIdentifier
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result
+}
+
+func (this *recordHistory) deleteZone(zoneID dns.ZoneID) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.zones, zoneID)
+}
+
+// recordHistory returns the record set history of the zone cache, if there is one.
+func (this *state) recordHistory() *recordHistory {
+	if this.zoneStates == nil {
+		return nil
+	}
+	return this.zoneStates.history
+}
+
+// formatRecordSetChange describes the change of a record set as `<type>: [<old values>] -> [<new values>]`.
+// A changed TTL is appended as `(ttl <old> -> <new>)`.
+func formatRecordSetChange(rtype string, old, new *dns.DNSSet) string {
+	var oldRS, newRS *dns.RecordSet
+	if old != nil {
+		oldRS = old.Sets[rtype]
+	}
+	if new != nil {
+		newRS = new.Sets[rtype]
+	}
+	oldTTL, oldValues := auditValues(oldRS)
+	newTTL, newValues := auditValues(newRS)
+	msg := fmt.Sprintf("%s: [%s] -> [%s]", rtype, strings.Join(oldValues, ", "), strings.Join(newValues, ", "))
+	if oldRS != nil && newRS != nil && oldTTL != newTTL {
+		msg = fmt.Sprintf("%s (ttl %d -> %d)", msg, oldTTL, newTTL)
+	}
+	return msg
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Record set history", func() {
+	zoneID := dns.NewZoneID("test", "Z1")
+	now := time.Now()
+	dnsset := func(ttl int64, values ...string) *dns.DNSSet {
+		set := dns.NewDNSSet("www.example.com")
+		var records []*dns.Record
+		for _, v := range values {
+			records = append(records, &dns.Record{Value: v})
+		}
+		set.Sets[dns.RS_A] = dns.NewRecordSet(dns.RS_A, ttl, records)
+		return set
+	}
+
+	ginkgov2.It("keeps a bounded number of previous values", func() {
+		h := newRecordHistory()
+		h.setSize(2)
+		h.record(zoneID, NewChangeRequest(R_CREATE, dns.RS_A, nil, dnsset(300, "1.1.1.1"), nil), now)
+		Expect(h.get(zoneID, "www.example.com", "", dns.RS_A)).To(BeNil())
+
+		h.record(zoneID, NewChangeRequest(R_UPDATE, dns.RS_A, dnsset(300, "1.1.1.1"), dnsset(300, "2.2.2.2"), nil), now)
+		h.record(zoneID, NewChangeRequest(R_UPDATE, dns.RS_A, dnsset(300, "2.2.2.2"), dnsset(300, "3.3.3.3"), nil), now)
+		h.record(zoneID, NewChangeRequest(R_DELETE, dns.RS_A, dnsset(300, "3.3.3.3"), nil, nil), now)
+		revisions := h.get(zoneID, "www.example.com", "", dns.RS_A)
+		Expect(revisions).To(HaveLen(2))
+		Expect(revisions[0].Values).To(Equal([]string{"2.2.2.2"}))
+		Expect(revisions[1].Values).To(Equal([]string{"3.3.3.3"}))
+
+		dump := h.dump(zoneID)
+		Expect(dump).To(HaveLen(1))
+		Expect(dump[0].Name).To(Equal("www.example.com"))
+		Expect(dump[0].Revisions).To(Equal(revisions))
+
+		h.deleteZone(zoneID)
+		Expect(h.get(zoneID, "www.example.com", "", dns.RS_A)).To(BeNil())
+	})
+
+	ginkgov2.It("records nothing if disabled", func() {
+		h := newRecordHistory()
+		h.record(zoneID, NewChangeRequest(R_UPDATE, dns.RS_A, dnsset(300, "1.1.1.1"), dnsset(300, "2.2.2.2"), nil), now)
+		Expect(h.get(zoneID, "www.example.com", "", dns.RS_A)).To(BeNil())
+		Expect((*recordHistory)(nil).get(zoneID, "www.example.com", "", dns.RS_A)).To(BeNil())
+	})
+
+	ginkgov2.It("formats changes as old -> new", func() {
+		Expect(formatRecordSetChange(dns.RS_A, dnsset(300, "1.1.1.1"), dnsset(120, "2.2.2.2", "3.3.3.3"))).
+			To(Equal("A: [1.1.1.1] -> [2.2.2.2, 3.3.3.3] (ttl 300 -> 120)"))
+		Expect(formatRecordSetChange(dns.RS_A, nil, dnsset(300, "1.1.1.1"))).To(Equal("A: [] -> [1.1.1.1]"))
+		Expect(formatRecordSetChange(dns.RS_A, dnsset(300, "1.1.1.1"), nil)).To(Equal("A: [1.1.1.1] -> []"))
+	})
+})
@@ -17,6 +17,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -24,6 +25,8 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -44,21 +47,33 @@ func (h dnsProviderVersionLightHandler) ProviderType() string {
 	return h.version.TypeCode()
 }
 
-func (h dnsProviderVersionLightHandler) GetZones() (DNSHostedZones, error) {
+func (h dnsProviderVersionLightHandler) GetZones(_ context.Context) (DNSHostedZones, error) {
 	return h.version.GetZones(), nil
 }
 
-func (h dnsProviderVersionLightHandler) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+func (h dnsProviderVersionLightHandler) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
 	for _, z := range h.version.GetZones() {
 		if z.Id() == zone.Id() {
-			return h.version.GetZoneState(zone)
+			return h.version.GetZoneState(ctx, zone)
 		}
 	}
 	return nil, fmt.Errorf("zone %s is not included", zone.Id())
 }
 
-func (h dnsProviderVersionLightHandler) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
-	return h.version.ExecuteRequests(logger, zone, state, reqs)
+func (h dnsProviderVersionLightHandler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	return h.version.ExecuteRequests(ctx, logger, zone, state, reqs)
+}
+
+func (h dnsProviderVersionLightHandler) ValidateRoutingPolicy(policy *dns.RoutingPolicy) error {
+	return h.version.ValidateRoutingPolicy(policy)
+}
+
+func (h dnsProviderVersionLightHandler) SupportsRecordType(rtype string) bool {
+	return h.version.SupportsRecordType(rtype)
+}
+
+func (h dnsProviderVersionLightHandler) SupportedRoutingPolicyTypes() []string {
+	return h.version.account.SupportedRoutingPolicyTypes()
 }
 
 func createRemoteAccessConfig(c controller.Interface) (*embed.RemoteAccessServerConfig, error) {
@@ -83,14 +98,40 @@ func createRemoteAccessConfig(c controller.Interface) (*embed.RemoteAccessServer
 		return nil, fmt.Errorf("invalid format for %s: expected '<namespace>/<name>'", OPT_REMOTE_ACCESS_SERVER_SECRET_NAME)
 	}
 	secretName := resources.NewObjectName(parts[0], parts[1])
+	limits, err := createRemoteAccessClientLimits(c)
+	if err != nil {
+		return nil, err
+	}
 	return &embed.RemoteAccessServerConfig{
 		Port:                 remoteAccessPort,
 		CACertFilename:       values[OPT_REMOTE_ACCESS_CACERT],
 		SecretName:           secretName,
 		ServerSecretProvider: &serverSecretProvider{},
+		ClientLimits:         limits,
 	}, nil
 }
 
+func createRemoteAccessClientLimits(c controller.Interface) (common.ClientLimits, error) {
+	limits := common.ClientLimits{}
+	qps, err := c.GetIntOption(OPT_REMOTE_ACCESS_CLIENT_QPS)
+	if err != nil {
+		return limits, err
+	}
+	limits.Burst, err = c.GetIntOption(OPT_REMOTE_ACCESS_CLIENT_BURST)
+	if err != nil {
+		return limits, err
+	}
+	limits.MaxPendingChanges, err = c.GetIntOption(OPT_REMOTE_ACCESS_CLIENT_MAX_PENDING_CHANGES)
+	if err != nil {
+		return limits, err
+	}
+	if qps < 0 || limits.Burst < 0 || limits.MaxPendingChanges < 0 {
+		return limits, fmt.Errorf("invalid negative remote access client limits")
+	}
+	limits.QPS = float32(qps)
+	return limits, nil
+}
+
 type serverSecretProvider struct {
 	lock     sync.Mutex
 	handlers []embed.ServerSecretUpdateHandler
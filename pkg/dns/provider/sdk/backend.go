@@ -0,0 +1,100 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+// Zone is a hosted zone as reported by a Backend.
+type Zone struct {
+	// ID is the zone id used for blocked zones, metrics and the zone id of the DNSHostedZone.
+	ID string
+	// Domain is the base domain of the zone.
+	Domain string
+	// Key is the key used for backend calls, defaults to the ID.
+	Key string
+	// Private is set for zones only visible in private networks.
+	Private bool
+}
+
+// Lister lists the zones and records of a DNS API.
+type Lister interface {
+	// ListZones lists all hosted zones of the account.
+	ListZones(ctx context.Context) ([]Zone, error)
+	// ListRecords lists all records of the zone with the given key.
+	ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error)
+}
+
+// Backend is the provider type specific access to the DNS API.
+// Implementations don't need to care about rate limiting and request metrics,
+// every call is accounted by the Handler.
+type Backend interface {
+	Lister
+
+	// NewRecord creates a new record for the zone, it is not persisted until CreateRecord is called.
+	NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record
+	CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+	UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+	DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error
+}
+
+// RecordSetGetter is optionally implemented by backends able to query the records
+// of a single record set. Otherwise the complete zone is listed and filtered.
+type RecordSetGetter interface {
+	GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, rtype string) (raw.RecordSet, error)
+}
+
+// BatchBackend is optionally implemented by backends able to submit several
+// record changes of a zone with a single request. The maximum number of
+// changes per batch is given by Options.BatchSize.
+type BatchBackend interface {
+	ApplyBatch(ctx context.Context, batch *raw.Batch, zone provider.DNSHostedZone) error
+}
+
+// RecordSetChange is the change of a complete record set of a zone.
+type RecordSetChange struct {
+	// Action is one of provider.R_CREATE, provider.R_UPDATE or provider.R_DELETE.
+	Action string
+	// Name is the DNS name of the record set.
+	Name string
+	// RecordSet is the desired record set, for deletions the deleted one.
+	RecordSet *dns.RecordSet
+	// Old is the record set before the change, if known.
+	Old *dns.RecordSet
+}
+
+// RecordSetBackend is the provider type specific access to DNS APIs managing
+// complete record sets instead of individual records. The records are only
+// listed individually to calculate the zone state.
+type RecordSetBackend interface {
+	Lister
+
+	// ApplyRecordSetChange creates, replaces or deletes a complete record set.
+	ApplyRecordSetChange(ctx context.Context, change *RecordSetChange, zone provider.DNSHostedZone) error
+}
+
+// IsAccessForbidden checks for errors caused by missing permissions for a zone.
+// It can be used for Options.SkipZone if the API reports the HTTP status code in the error message.
+func IsAccessForbidden(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "403")
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// Package sdk provides the building blocks for DNS provider types managing
+// individual records with a REST like API.
+//
+// A provider type only implements the Backend interface, which maps the
+// records of its API to raw.Record and back. The Handler created with
+// NewHandler implements provider.DNSHandler and provider.DedicatedDNSAccess
+// on top of it and takes care of
+//   - the zone cache wiring and the filtering of blocked zones,
+//   - the detection of forwarded sub domains by NS records,
+//   - the calculation of the zone state from the records of a zone,
+//   - the mapping of change requests to record changes (optionally batched),
+//   - the rate limiting and the standard request metrics of all backend calls.
+//
+// A minimal provider type looks like this:
+//
+//	func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
+//		token, err := c.GetRequiredProperty("API_TOKEN")
+//		if err != nil {
+//			return nil, err
+//		}
+//		return sdk.NewHandler(TYPE_CODE, c, newBackend(token), sdk.Options{SkipZone: sdk.IsAccessForbidden})
+//	}
+//
+// DNS APIs managing complete record sets instead of individual records
+// implement the RecordSetBackend interface and use NewRecordSetHandler.
+// Their change requests are submitted record set by record set.
+//
+// Provider types with additional features embed the *Handler and add the
+// methods of the optional handler interfaces, like provider.DNSSECAccess.
+// Additional API calls should be accounted with Handler.Request to
+// participate in rate limiting and request metrics.
+package sdk
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+// executor adapts the backend of a handler to a raw.Executor for a single execution.
+type executor struct {
+	ctx     context.Context
+	handler *Handler
+}
+
+var _ raw.Executor = &executor{}
+
+func (h *Handler) executor(ctx context.Context) raw.Executor {
+	e := &executor{ctx: ctx, handler: h}
+	if b, ok := h.backend.(BatchBackend); ok {
+		return &batchExecutor{executor: e, backend: b}
+	}
+	return e
+}
+
+func (this *executor) CreateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.handler.createRecord(this.ctx, r, zone)
+}
+
+func (this *executor) UpdateRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.handler.updateRecord(this.ctx, r, zone)
+}
+
+func (this *executor) DeleteRecord(r raw.Record, zone provider.DNSHostedZone) error {
+	return this.handler.deleteRecord(this.ctx, r, zone)
+}
+
+func (this *executor) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	return this.handler.backend.NewRecord(fqdn, rtype, value, zone, ttl)
+}
+
+func (this *executor) GetRecordSet(dnsName, rtype string, zone provider.DNSHostedZone) (raw.RecordSet, error) {
+	return this.handler.getRecordSet(this.ctx, zone, dnsName, rtype)
+}
+
+type batchExecutor struct {
+	*executor
+	backend BatchBackend
+}
+
+var _ raw.BatchExecutor = &batchExecutor{}
+
+func (this *batchExecutor) BatchSize() int {
+	return this.handler.options.BatchSize
+}
+
+func (this *batchExecutor) ExecuteBatch(batch *raw.Batch, zone provider.DNSHostedZone) error {
+	this.handler.Request(zone.Id().ID, provider.M_UPDATERECORDS)
+	return this.backend.ApplyBatch(this.ctx, batch, zone)
+}
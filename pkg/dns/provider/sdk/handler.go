@@ -0,0 +1,296 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+// Options are the provider type specific settings of a Handler.
+type Options struct {
+	// CacheType is the type of the zone cache, defaults to provider.CacheZonesOnly.
+	CacheType provider.ZoneCacheType
+	// RecordTypes are the record types supported in addition to the default ones.
+	RecordTypes []string
+	// SkipZone is called for errors on listing the records of a zone while getting the zones.
+	// If it returns true, the zone is ignored instead of failing.
+	SkipZone func(err error) bool
+	// AdjustRecordSet is called for every record set of a zone state after it has been calculated.
+	AdjustRecordSet func(rs *dns.RecordSet)
+	// BatchSize is the maximum number of record changes per batch for a BatchBackend, 0 means unlimited.
+	BatchSize int
+	// ClassifyError maps errors of the backend to error reasons.
+	ClassifyError func(err error) perrs.ErrorReason
+}
+
+// Handler is a generic DNS handler for record based DNS APIs.
+type Handler struct {
+	provider.DefaultDNSHandler
+	config  provider.DNSHandlerConfig
+	options Options
+	lister  Lister
+	backend Backend
+	rrsets  RecordSetBackend
+	cache   provider.ZoneCache
+}
+
+var _ provider.DNSHandler = &Handler{}
+var _ provider.DedicatedDNSAccess = &Handler{}
+
+// NewHandler creates a handler for the given backend.
+func NewHandler(providerType string, c *provider.DNSHandlerConfig, backend Backend, options Options) (*Handler, error) {
+	h := newHandler(providerType, c, backend, options)
+	h.backend = backend
+	return h.init()
+}
+
+// NewRecordSetHandler creates a handler for the given record set backend.
+// Change requests are executed per record set, batching is not supported.
+func NewRecordSetHandler(providerType string, c *provider.DNSHandlerConfig, backend RecordSetBackend, options Options) (*Handler, error) {
+	h := newHandler(providerType, c, backend, options)
+	h.rrsets = backend
+	return h.init()
+}
+
+func newHandler(providerType string, c *provider.DNSHandlerConfig, lister Lister, options Options) *Handler {
+	return &Handler{
+		DefaultDNSHandler: provider.NewDefaultDNSHandler(providerType),
+		config:            *c,
+		options:           options,
+		lister:            lister,
+	}
+}
+
+func (h *Handler) init() (*Handler, error) {
+	var err error
+	h.cache, err = h.config.ZoneCacheFactory.CreateZoneCache(h.options.CacheType, h.config.Metrics, h.getZones, h.getZoneState)
+	if err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Config returns the handler config.
+func (h *Handler) Config() *provider.DNSHandlerConfig {
+	return &h.config
+}
+
+// Request accounts a backend request in the request metrics and waits for the rate limiter.
+// An empty zone id is accounted as generic request.
+func (h *Handler) Request(zoneID, requestType string) {
+	if zoneID == "" {
+		h.config.Metrics.AddGenericRequests(requestType, 1)
+	} else {
+		h.config.Metrics.AddZoneRequests(zoneID, requestType, 1)
+	}
+	if h.config.RateLimiter != nil {
+		h.config.RateLimiter.Accept()
+	}
+}
+
+func (h *Handler) Release() {
+	h.cache.Release()
+}
+
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	if utils.NewStringSet(h.options.RecordTypes...).Contains(rtype) {
+		return true
+	}
+	return h.DefaultDNSHandler.SupportsRecordType(rtype)
+}
+
+func (h *Handler) ClassifyError(err error) perrs.ErrorReason {
+	if h.options.ClassifyError != nil {
+		return h.options.ClassifyError(err)
+	}
+	return perrs.ReasonUnknown
+}
+
+func (h *Handler) GetZones(ctx context.Context) (provider.DNSHostedZones, error) {
+	return h.cache.GetZones(ctx)
+}
+
+func (h *Handler) getZones(ctx context.Context, cache provider.ZoneCache) (provider.DNSHostedZones, error) {
+	blockedZones := h.config.Options.AdvancedOptions.GetBlockedZones()
+
+	h.Request("", provider.M_LISTZONES)
+	rawZones, err := h.lister.ListZones(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	zones := provider.DNSHostedZones{}
+	for _, z := range rawZones {
+		if blockedZones.Contains(z.ID) {
+			h.config.Logger.Infof("ignoring blocked zone id: %s", z.ID)
+			continue
+		}
+		key := z.Key
+		if key == "" {
+			key = z.ID
+		}
+		records, err := h.listRecords(ctx, z.ID, key)
+		if err != nil {
+			if h.options.SkipZone != nil && h.options.SkipZone(err) {
+				// It is possible to deny access to certain zones in the account
+				// As a result, the zone should not be appended to the hosted zones
+				continue
+			}
+			return nil, err
+		}
+		forwarded := []string{}
+		for _, r := range records {
+			if r.GetType() == dns.RS_NS && r.GetDNSName() != z.Domain {
+				forwarded = append(forwarded, r.GetDNSName())
+			}
+		}
+		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.ID, z.Domain, key, forwarded, z.Private)
+		zones = append(zones, hostedZone)
+	}
+	return zones, nil
+}
+
+func (h *Handler) GetZoneState(ctx context.Context, zone provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	return h.cache.GetZoneState(ctx, zone)
+}
+
+func (h *Handler) getZoneState(ctx context.Context, zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+	records, err := h.listRecords(ctx, zone.Id().ID, zone.Key())
+	if err != nil {
+		return nil, err
+	}
+	state := raw.NewState()
+	for _, r := range records {
+		state.AddRecord(r)
+	}
+	state.CalculateDNSSets()
+	if h.options.AdjustRecordSet != nil {
+		for _, set := range state.GetDNSSets() {
+			for _, rs := range set.Sets {
+				h.options.AdjustRecordSet(rs)
+			}
+		}
+	}
+	return state, nil
+}
+
+func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
+	return h.cache.ReportZoneStateConflict(zone, err)
+}
+
+func (h *Handler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, state provider.DNSZoneState, reqs []*provider.ChangeRequest) error {
+	var err error
+	if h.rrsets != nil {
+		err = h.executeRecordSetChanges(ctx, logger, zone, reqs)
+	} else {
+		err = raw.ExecuteRequests(ctx, logger, &h.config, h.executor(ctx), zone, state, reqs)
+	}
+	h.cache.ApplyRequests(logger, err, zone, reqs)
+	return err
+}
+
+func (h *Handler) GetRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, recordType string) (provider.DedicatedRecordSet, error) {
+	rs, err := h.getRecordSet(ctx, zone, dnsName, recordType)
+	if err != nil {
+		return nil, err
+	}
+	d := provider.DedicatedRecordSet{}
+	for _, r := range rs {
+		d = append(d, r)
+	}
+	return d, nil
+}
+
+func (h *Handler) CreateOrUpdateRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
+	if h.rrsets != nil {
+		return h.replaceDedicatedRecordSet(ctx, logger, zone, old, new)
+	}
+	err := h.DeleteRecordSet(ctx, logger, zone, old)
+	if err != nil {
+		return err
+	}
+	for _, r := range new {
+		r0 := h.backend.NewRecord(r.GetDNSName(), r.GetType(), r.GetValue(), zone, int64(r.GetTTL()))
+		err = h.createRecord(ctx, r0, zone)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) DeleteRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, rs provider.DedicatedRecordSet) error {
+	if h.rrsets != nil {
+		return h.replaceDedicatedRecordSet(ctx, logger, zone, rs, nil)
+	}
+	for _, r := range rs {
+		if a, ok := r.(raw.Record); ok && a.GetId() != "" {
+			err := h.deleteRecord(ctx, a, zone)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *Handler) listRecords(ctx context.Context, zoneID, zoneKey string) (raw.RecordSet, error) {
+	h.Request(zoneID, provider.M_LISTRECORDS)
+	return h.lister.ListRecords(ctx, zoneKey)
+}
+
+func (h *Handler) getRecordSet(ctx context.Context, zone provider.DNSHostedZone, dnsName, rtype string) (raw.RecordSet, error) {
+	if g, ok := h.lister.(RecordSetGetter); ok {
+		h.Request(zone.Id().ID, provider.M_LISTRECORDS)
+		return g.GetRecordSet(ctx, zone, dnsName, rtype)
+	}
+	// no filtering provided by API, we have to list complete zone and filter
+	records, err := h.listRecords(ctx, zone.Id().ID, zone.Key())
+	if err != nil {
+		return nil, err
+	}
+	rs := raw.RecordSet{}
+	for _, r := range records {
+		if r.GetType() == rtype && r.GetDNSName() == dnsName {
+			rs = append(rs, r)
+		}
+	}
+	return rs, nil
+}
+
+func (h *Handler) createRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	h.Request(zone.Id().ID, provider.M_CREATERECORDS)
+	return h.backend.CreateRecord(ctx, r, zone)
+}
+
+func (h *Handler) updateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	h.Request(zone.Id().ID, provider.M_UPDATERECORDS)
+	return h.backend.UpdateRecord(ctx, r, zone)
+}
+
+func (h *Handler) deleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	h.Request(zone.Id().ID, provider.M_DELETERECORDS)
+	return h.backend.DeleteRecord(ctx, r, zone)
+}
@@ -0,0 +1,313 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+type testRecord struct {
+	id     string
+	zoneID string
+	name   string
+	rtype  string
+	value  string
+	ttl    int
+}
+
+func (r *testRecord) GetId() string      { return r.id }
+func (r *testRecord) GetType() string    { return r.rtype }
+func (r *testRecord) GetValue() string   { return NormalizeValue(r.rtype, r.value) }
+func (r *testRecord) GetDNSName() string { return r.name }
+func (r *testRecord) GetTTL() int        { return r.ttl }
+func (r *testRecord) SetTTL(ttl int)     { r.ttl = ttl }
+func (r *testRecord) Copy() raw.Record   { n := *r; return &n }
+
+type testBackend struct {
+	zones   []Zone
+	records map[string]raw.RecordSet
+	calls   []string
+}
+
+func (b *testBackend) ListZones(ctx context.Context) ([]Zone, error) {
+	return b.zones, nil
+}
+
+func (b *testBackend) ListRecords(ctx context.Context, zoneKey string) (raw.RecordSet, error) {
+	if zoneKey == "forbidden" {
+		return nil, fmt.Errorf("403 forbidden")
+	}
+	return b.records[zoneKey], nil
+}
+
+func (b *testBackend) NewRecord(fqdn, rtype, value string, zone provider.DNSHostedZone, ttl int64) raw.Record {
+	return &testRecord{zoneID: zone.Id().ID, name: fqdn, rtype: rtype, value: value, ttl: int(ttl)}
+}
+
+func (b *testBackend) CreateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	b.calls = append(b.calls, "create "+r.GetValue())
+	return nil
+}
+
+func (b *testBackend) UpdateRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	b.calls = append(b.calls, "update "+r.GetValue())
+	return nil
+}
+
+func (b *testBackend) DeleteRecord(ctx context.Context, r raw.Record, zone provider.DNSHostedZone) error {
+	b.calls = append(b.calls, "delete "+r.GetValue())
+	return nil
+}
+
+type testBatchBackend struct {
+	testBackend
+}
+
+func (b *testBatchBackend) ApplyBatch(ctx context.Context, batch *raw.Batch, zone provider.DNSHostedZone) error {
+	b.calls = append(b.calls, fmt.Sprintf("batch %d", batch.Size()))
+	return nil
+}
+
+type testRecordSetBackend struct {
+	testBackend
+	err error
+}
+
+func (b *testRecordSetBackend) ApplyRecordSetChange(ctx context.Context, change *RecordSetChange, zone provider.DNSHostedZone) error {
+	old := ""
+	if change.Old != nil {
+		old = change.Old.RecordString()
+	}
+	b.calls = append(b.calls, fmt.Sprintf("%s %s %s %s (%s)", change.Action, change.RecordSet.Type, change.Name, change.RecordSet.RecordString(), old))
+	return b.err
+}
+
+type testDone struct {
+	result     string
+	retryAfter time.Duration
+}
+
+func (d *testDone) SetInvalid(err error) { d.result = "invalid" }
+func (d *testDone) Failed(err error)     { d.result = "failed" }
+func (d *testDone) Throttled(retryAfter time.Duration) {
+	d.result, d.retryAfter = "throttled", retryAfter
+}
+func (d *testDone) Blocked(reason string, planned []string) { d.result = "blocked" }
+func (d *testDone) Succeeded()                              { d.result = "succeeded" }
+
+type testMetrics struct {
+	requests map[string]int
+}
+
+func (m *testMetrics) AddGenericRequests(requestType string, n int) {
+	m.requests[requestType] += n
+}
+
+func (m *testMetrics) AddZoneRequests(zoneID, requestType string, n int) {
+	m.requests[zoneID+"/"+requestType] += n
+}
+
+var _ = ginkgov2.Describe("Handler", func() {
+	var (
+		metrics *testMetrics
+		config  *provider.DNSHandlerConfig
+		records map[string]raw.RecordSet
+	)
+
+	newHandler := func(backend Backend, options Options) *Handler {
+		h, err := NewHandler("test", config, backend, options)
+		Expect(err).NotTo(HaveOccurred())
+		return h
+	}
+
+	ginkgov2.BeforeEach(func() {
+		metrics = &testMetrics{requests: map[string]int{}}
+		config = &provider.DNSHandlerConfig{
+			Logger:           logger.New(),
+			Metrics:          metrics,
+			ZoneCacheFactory: *provider.NewTestZoneCacheFactory(0, 0),
+			Options: &provider.FactoryOptions{GenericFactoryOptions: provider.GenericFactoryOptions{
+				AdvancedOptions: provider.AdvancedOptions{BlockedZones: []string{"blocked"}},
+			}},
+		}
+		records = map[string]raw.RecordSet{
+			"z1": {
+				&testRecord{id: "1", zoneID: "z1", name: "example.com", rtype: dns.RS_NS, value: "ns1.example.net"},
+				&testRecord{id: "2", zoneID: "z1", name: "sub.example.com", rtype: dns.RS_NS, value: "ns1.example.org"},
+				&testRecord{id: "3", zoneID: "z1", name: "www.example.com", rtype: dns.RS_A, value: "1.2.3.4", ttl: 300},
+				&testRecord{id: "4", zoneID: "z1", name: "www.example.com", rtype: dns.RS_TXT, value: "unquoted", ttl: 300},
+			},
+		}
+	})
+
+	ginkgov2.It("filters zones and detects forwarded domains", func() {
+		backend := &testBackend{
+			zones: []Zone{
+				{ID: "z1", Domain: "example.com"},
+				{ID: "blocked", Domain: "blocked.com"},
+				{ID: "z3", Domain: "forbidden.com", Key: "forbidden"},
+			},
+			records: records,
+		}
+		h := newHandler(backend, Options{SkipZone: IsAccessForbidden})
+
+		zones, err := h.GetZones(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(HaveLen(1))
+		Expect(zones[0].Id().ID).To(Equal("z1"))
+		Expect(zones[0].ForwardedDomains()).To(ConsistOf("sub.example.com"))
+		Expect(metrics.requests).To(Equal(map[string]int{
+			provider.M_LISTZONES:           1,
+			"z1/" + provider.M_LISTRECORDS: 1,
+			"z3/" + provider.M_LISTRECORDS: 1,
+		}))
+
+		_, err = newHandler(backend, Options{}).GetZones(context.Background())
+		Expect(err).To(MatchError("403 forbidden"))
+	})
+
+	ginkgov2.It("calculates zone state and executes changes record by record", func() {
+		backend := &testBackend{records: records}
+		h := newHandler(backend, Options{})
+		zone := provider.NewDNSHostedZone("test", "z1", "example.com", "z1", nil, false)
+
+		state, err := h.GetZoneState(context.Background(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		set := state.GetDNSSets()["www.example.com"]
+		Expect(set).NotTo(BeNil())
+		Expect(set.Sets[dns.RS_TXT].Records[0].Value).To(Equal(`"unquoted"`))
+
+		add := dns.NewDNSSet("www.example.com")
+		add.SetRecordSet(dns.RS_A, 600, "1.2.3.4", "5.6.7.8")
+		reqs := []*provider.ChangeRequest{provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, set, add, nil)}
+		err = h.ExecuteRequests(context.Background(), config.Logger, zone, state, reqs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.calls).To(ConsistOf("create 5.6.7.8", "update 1.2.3.4"))
+		Expect(metrics.requests["z1/"+provider.M_CREATERECORDS]).To(Equal(1))
+		Expect(metrics.requests["z1/"+provider.M_UPDATERECORDS]).To(Equal(1))
+	})
+
+	ginkgov2.It("executes changes in batches", func() {
+		backend := &testBatchBackend{testBackend{records: map[string]raw.RecordSet{}}}
+		h := newHandler(backend, Options{BatchSize: 2})
+		zone := provider.NewDNSHostedZone("test", "z1", "example.com", "z1", nil, false)
+
+		state, err := h.GetZoneState(context.Background(), zone)
+		Expect(err).NotTo(HaveOccurred())
+
+		add := dns.NewDNSSet("www.example.com")
+		add.SetRecordSet(dns.RS_A, 600, "1.1.1.1", "2.2.2.2", "3.3.3.3")
+		reqs := []*provider.ChangeRequest{provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, add, nil)}
+		err = h.ExecuteRequests(context.Background(), config.Logger, zone, state, reqs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.calls).To(Equal([]string{"batch 2", "batch 1"}))
+		Expect(metrics.requests["z1/"+provider.M_UPDATERECORDS]).To(Equal(2))
+	})
+
+	ginkgov2.It("executes changes record set by record set", func() {
+		backend := &testRecordSetBackend{testBackend: testBackend{records: records}}
+		h, err := NewRecordSetHandler("test", config, backend, Options{})
+		Expect(err).NotTo(HaveOccurred())
+		zone := provider.NewDNSHostedZone("test", "z1", "example.com", "z1", nil, false)
+
+		state, err := h.GetZoneState(context.Background(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		set := state.GetDNSSets()["www.example.com"]
+		Expect(set).NotTo(BeNil())
+
+		add := dns.NewDNSSet("www.example.com")
+		add.SetRecordSet(dns.RS_A, 600, "1.2.3.4", "5.6.7.8")
+		create := dns.NewDNSSet("new.example.com")
+		create.SetRecordSet(dns.RS_A, 300, "9.9.9.9")
+		done := []*testDone{{}, {}, {}}
+		reqs := []*provider.ChangeRequest{
+			provider.NewChangeRequest(provider.R_UPDATE, dns.RS_A, set, add, done[0]),
+			provider.NewChangeRequest(provider.R_DELETE, dns.RS_TXT, set, nil, done[1]),
+			provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, create, done[2]),
+		}
+		err = h.ExecuteRequests(context.Background(), config.Logger, zone, state, reqs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(backend.calls).To(Equal([]string{
+			"update A www.example.com [1.2.3.4, 5.6.7.8] ([1.2.3.4])",
+			`delete TXT www.example.com ["unquoted"] (["unquoted"])`,
+			"create A new.example.com [9.9.9.9] ()",
+		}))
+		for _, d := range done {
+			Expect(d.result).To(Equal("succeeded"))
+		}
+		Expect(metrics.requests["z1/"+provider.M_UPDATERECORDS]).To(Equal(1))
+		Expect(metrics.requests["z1/"+provider.M_DELETERECORDS]).To(Equal(1))
+		Expect(metrics.requests["z1/"+provider.M_CREATERECORDS]).To(Equal(1))
+
+		backend.calls = nil
+		backend.err = &perrs.ProviderError{Reason: perrs.ReasonThrottled, RetryAfter: 3 * time.Second, Err: fmt.Errorf("429")}
+		reqs = []*provider.ChangeRequest{provider.NewChangeRequest(provider.R_CREATE, dns.RS_A, nil, create, done[2])}
+		err = h.ExecuteRequests(context.Background(), config.Logger, zone, state, reqs)
+		Expect(perrs.IsThrottlingError(err)).To(BeTrue())
+		Expect(done[2].result).To(Equal("throttled"))
+		Expect(done[2].retryAfter).To(Equal(3 * time.Second))
+	})
+
+	ginkgov2.It("replaces dedicated record sets as a whole", func() {
+		backend := &testRecordSetBackend{testBackend: testBackend{records: records}}
+		h, err := NewRecordSetHandler("test", config, backend, Options{})
+		Expect(err).NotTo(HaveOccurred())
+		zone := provider.NewDNSHostedZone("test", "z1", "example.com", "z1", nil, false)
+
+		old, err := h.GetRecordSet(context.Background(), zone, "www.example.com", dns.RS_TXT)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(old).To(HaveLen(1))
+		new := provider.DedicatedRecordSet{&testRecord{name: "www.example.com", rtype: dns.RS_TXT, value: "other", ttl: 60}}
+		Expect(h.CreateOrUpdateRecordSet(context.Background(), config.Logger, zone, old, new)).To(Succeed())
+		Expect(h.CreateOrUpdateRecordSet(context.Background(), config.Logger, zone, nil, new)).To(Succeed())
+		Expect(h.DeleteRecordSet(context.Background(), config.Logger, zone, new)).To(Succeed())
+		Expect(backend.calls).To(Equal([]string{
+			`update TXT www.example.com ["other"] (["unquoted"])`,
+			`create TXT www.example.com ["other"] ()`,
+			`delete TXT www.example.com ["other"] (["other"])`,
+		}))
+	})
+})
+
+var _ = ginkgov2.Describe("RetryAfter", func() {
+	ginkgov2.It("parses seconds and HTTP dates", func() {
+		header := http.Header{}
+		Expect(RetryAfter(header)).To(Equal(time.Duration(0)))
+		header.Set("Retry-After", "2")
+		Expect(RetryAfter(header)).To(Equal(2 * time.Second))
+		header.Set("Retry-After", "-1")
+		Expect(RetryAfter(header)).To(Equal(time.Duration(0)))
+		header.Set("Retry-After", "soon")
+		Expect(RetryAfter(header)).To(Equal(time.Duration(0)))
+		header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+		Expect(RetryAfter(header)).To(BeNumerically("~", time.Minute, 2*time.Second))
+		header.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+		Expect(RetryAfter(header)).To(Equal(time.Duration(0)))
+	})
+})
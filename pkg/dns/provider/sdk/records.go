@@ -0,0 +1,40 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
+)
+
+// NormalizeValue maps a record value as returned by a DNS API to the presentation format used by the zone state.
+//   - TXT values are quoted
+//   - separators of SVCB and HTTPS values are normalized
+//   - CAA values are normalized
+func NormalizeValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_TXT:
+		return raw.EnsureQuotedText(value)
+	case dns.RS_SVCB, dns.RS_HTTPS:
+		return strings.Join(strings.Fields(value), " ")
+	case dns.RS_CAA:
+		return dns.NormalizeCAAValue(value)
+	}
+	return value
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// executeRecordSetChanges submits the change requests record set by record set to the RecordSetBackend.
+func (h *Handler) executeRecordSetChanges(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, reqs []*provider.ChangeRequest) error {
+	failed, throttled, count := 0, 0, 0
+	for _, r := range reqs {
+		change := mapRecordSetChange(r, zone)
+		if change == nil {
+			continue
+		}
+		count++
+		if h.config.DryRun {
+			logger.Infof("dryrun: %s %s record set %s[%s]", r.Action, change.RecordSet.Type, change.Name, zone.Id())
+			continue
+		}
+		// stop submitting further changes once the deadline of the execution is exceeded
+		err := ctx.Err()
+		if err == nil {
+			err = h.applyRecordSetChange(ctx, logger, change, zone)
+		}
+		if r.Done != nil {
+			switch {
+			case err == nil:
+				r.Done.Succeeded()
+			case perrs.IsThrottlingError(err):
+				r.Done.Throttled(perrs.RetryAfter(err))
+			default:
+				r.Done.Failed(err)
+			}
+		}
+		if err != nil {
+			logger.Errorf("%s of %s record set %s in zone %s failed: %s", r.Action, change.RecordSet.Type, change.Name, zone.Id(), err)
+			failed++
+			if perrs.IsThrottlingError(err) {
+				throttled++
+			}
+		}
+	}
+	if failed > 0 {
+		err := fmt.Errorf("%d of %d record set changes failed", failed, count)
+		if throttled == failed {
+			err = perrs.NewThrottlingError(err)
+		}
+		return err
+	}
+	if count > 0 && !h.config.DryRun {
+		logger.Infof("%d record sets in zone %s were successfully updated", count, zone.Id())
+	}
+	return nil
+}
+
+// mapRecordSetChange maps a change request to a record set change, nil is returned if there is nothing to change.
+func mapRecordSetChange(r *provider.ChangeRequest, zone provider.DNSHostedZone) *RecordSetChange {
+	change := &RecordSetChange{Action: r.Action}
+	if r.Deletion != nil {
+		change.Name, change.Old = dns.MapToProvider(r.Type, r.Deletion, zone.Domain())
+	}
+	switch r.Action {
+	case provider.R_CREATE, provider.R_UPDATE:
+		if r.Addition != nil {
+			change.Name, change.RecordSet = dns.MapToProvider(r.Type, r.Addition, zone.Domain())
+		}
+	case provider.R_DELETE:
+		change.RecordSet = change.Old
+	}
+	if change.Name == "" || change.RecordSet.Length() == 0 {
+		return nil
+	}
+	return change
+}
+
+// replaceDedicatedRecordSet replaces the old dedicated record set by the new one.
+func (h *Handler) replaceDedicatedRecordSet(ctx context.Context, logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
+	if len(old) > 0 && (len(new) == 0 || old[0].GetDNSName() != new[0].GetDNSName() || old[0].GetType() != new[0].GetType()) {
+		rs := dedicatedRecordSet(old)
+		change := &RecordSetChange{Action: provider.R_DELETE, Name: old[0].GetDNSName(), RecordSet: rs, Old: rs}
+		if err := h.applyRecordSetChange(ctx, logger, change, zone); err != nil {
+			return err
+		}
+		old = nil
+	}
+	if len(new) == 0 {
+		return nil
+	}
+	change := &RecordSetChange{Action: provider.R_UPDATE, Name: new[0].GetDNSName(), RecordSet: dedicatedRecordSet(new), Old: dedicatedRecordSet(old)}
+	if change.Old == nil {
+		change.Action = provider.R_CREATE
+	}
+	return h.applyRecordSetChange(ctx, logger, change, zone)
+}
+
+// dedicatedRecordSet returns the record set of the dedicated records of a single name and type.
+func dedicatedRecordSet(records provider.DedicatedRecordSet) *dns.RecordSet {
+	if len(records) == 0 {
+		return nil
+	}
+	rs := dns.NewRecordSet(records[0].GetType(), int64(records[0].GetTTL()), nil)
+	for _, r := range records {
+		rs.Add(&dns.Record{Value: r.GetValue()})
+	}
+	return rs
+}
+
+func (h *Handler) applyRecordSetChange(ctx context.Context, logger logger.LogContext, change *RecordSetChange, zone provider.DNSHostedZone) error {
+	rs := change.RecordSet
+	switch change.Action {
+	case provider.R_CREATE:
+		logger.Infof("creating %s record set %s[%s]: %s(%d)", rs.Type, change.Name, zone.Id(), rs.RecordString(), rs.TTL)
+		h.Request(zone.Id().ID, provider.M_CREATERECORDS)
+	case provider.R_UPDATE:
+		logger.Infof("updating %s record set %s[%s]: %s(%d)", rs.Type, change.Name, zone.Id(), rs.RecordString(), rs.TTL)
+		h.Request(zone.Id().ID, provider.M_UPDATERECORDS)
+	default:
+		logger.Infof("deleting %s record set %s[%s]", rs.Type, change.Name, zone.Id())
+		h.Request(zone.Id().ID, provider.M_DELETERECORDS)
+	}
+	return h.rrsets.ApplyRecordSetChange(ctx, change, zone)
+}
@@ -17,6 +17,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"sync"
@@ -37,15 +38,21 @@ import (
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/health"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 )
 
 type ZonedDNSName struct {
-	ZoneID  dns.ZoneID
-	DNSName string
+	ZoneID        dns.ZoneID
+	DNSName       string
+	SetIdentifier string
 }
 
 func (z ZonedDNSName) String() string {
+	if z.SetIdentifier != "" {
+		return fmt.Sprintf("%s#%s[%s]", z.DNSName, z.SetIdentifier, z.ZoneID)
+	}
 	return fmt.Sprintf("%s[%s]", z.DNSName, z.ZoneID)
 }
 
@@ -54,6 +61,7 @@ type DNSNames map[ZonedDNSName]*Entry
 type DNSNameSet = utils.StringSet
 
 type zoneReconciliation struct {
+	ctx          context.Context
 	zone         *dnsHostedZone
 	providers    DNSProviders
 	entries      Entries
@@ -64,12 +72,16 @@ type zoneReconciliation struct {
 	deleting     bool
 	fhandler     FinalizerHandler
 	dnsTicker    *Ticker
+	audit        *AuditLog
+	history      *recordHistory
 }
 
 type setup struct {
 	lock        sync.Mutex
 	pending     utils.StringSet
 	pendingKeys resources.ClusterObjectKeySet
+	prefetches  []*zonePrefetch
+	coldStart   *coldStart
 }
 
 func newSetup() *setup {
@@ -94,10 +106,11 @@ func (this *setup) AddKey(key ...resources.ClusterObjectKey) {
 func (this *setup) Start(context Context) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
-	for c := range this.pending {
+	for _, c := range this.coldStart.order(this.pending) {
 		context.Infof("trigger %s", c)
 		context.EnqueueCommand(c)
 	}
+	this.coldStart.deferCommands(context, this.pending)
 
 	for key := range this.pendingKeys {
 		context.Infof("trigger key %s/%s", key.Namespace(), key.Name())
@@ -127,6 +140,7 @@ type state struct {
 	realms access.RealmTypes
 
 	accountCache *AccountCache
+	credentials  *credentialsCache
 	ownerCache   *OwnerCache
 	zoneStates   *zoneStates
 
@@ -144,10 +158,17 @@ type state struct {
 	entries         Entries
 	outdated        *synchronizedEntries
 	blockingEntries map[resources.ObjectName]time.Time
+	withoutProvider *entriesWithoutProvider
+	readiness       *readinessProbes
 
 	providerRateLimiter map[resources.ObjectName]*rateLimiterData
+	zoneRateLimiters    map[resources.ObjectName]*zoneRateLimiters
 	prlock              sync.RWMutex
 
+	// nextVerification is the earliest time of the next zone verification (rate limit of the verification loop)
+	nextVerification time.Time
+	vlock            sync.Mutex
+
 	dnsnames   DNSNames
 	references *References
 
@@ -155,7 +176,11 @@ type state struct {
 
 	dnsTicker *Ticker
 
+	canaries *canaryObservations
+
 	providerEventListeners []ProviderEventListener
+
+	audit *AuditLog
 }
 
 type rateLimiterData struct {
@@ -174,13 +199,52 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 	ctx.Infof("reschedule delay:            %v", config.RescheduleDelay)
 	ctx.Infof("zone cache ttl for zones:    %v", config.CacheTTL)
 	ctx.Infof("disable zone state caching:  %t", !config.ZoneStateCaching)
+	ctx.Infof("zone state cache directory:  %s (max age %v)", config.ZoneStateCacheDir, config.ZoneStateCacheAge)
+	ctx.Infof("zone state full sync period: %v", config.ZoneStateFullSync)
+	ctx.Infof("zone state max stale:        %v", config.ZoneStateMaxStale)
+	ctx.Infof("zone state ttl jitter:       %d%%", config.ZoneStateTTLJitter)
+	ctx.Infof("zone warmup delay:           %v", config.ZoneWarmupDelay)
+	ctx.Infof("zone verification period:   %v (delay %v)", config.VerificationPeriod, config.VerificationDelay)
+	ctx.Infof("query metrics period:        %v", config.QueryMetricsPeriod)
+	ctx.Infof("dnssec check period:         %v", config.DNSSECCheckPeriod)
+	ctx.Infof("write permission period:     %v", config.WritePermissionPeriod)
+	ctx.Infof("zone ownership period:       %v", config.ZoneOwnershipPeriod)
+	ctx.Infof("canary period:               %v (label %s)", config.CanaryPeriod, config.CanaryLabel)
+	ctx.Infof("detailed zone metrics:       %s", config.MetricsZones)
+	ctx.Infof("cost attribution label:      %s", config.CostLabel)
+	ctx.Infof("cost report:                 %t", config.CostReport)
+	ctx.Infof("error history size:          %d", config.ErrorHistorySize)
+	ctx.Infof("record history size:         %d", config.RecordHistorySize)
+	ctx.Infof("audit log:                   file %q, events %t, webhook %q, signed %t", config.AuditLogFile, config.AuditLogEvents, config.AuditLogWebhook, config.AuditLogSigningKey != "")
+	ctx.Infof("write freeze:                %t (switch %t)", config.WriteFreeze, config.WriteFreezeSwitch)
+	if config.WriteFreezeConfigMap != nil {
+		ctx.Infof("write freeze config map:     %s", config.WriteFreezeConfigMap)
+	}
+	ctx.Infof("anomaly guard:               max %d changes per %v (pause %v)", config.AnomalyGuardMax, config.AnomalyGuardWindow, config.AnomalyGuardPause)
+	ctx.Infof("flap detection:              window %v, threshold %d (hold down %v)", config.FlapWindow, config.FlapThreshold, config.FlapHoldDown)
+	ctx.Infof("owner conflict resolution:   %s", config.OwnerConflictPolicy)
+	ctx.Infof("namespace owner ids:         %s", config.NamespaceOwnerIds)
+	ctx.Infof("crd management:              %s", config.CRDManagement)
+	ctx.Infof("conversion webhook:          %s", config.ConversionWebhook)
+	ctx.Infof("external data endpoint:      %t", config.ExternalDataEndpoint)
+	ctx.Infof("zone state endpoint:         %t", config.ZoneStateEndpoint)
+	ctx.Infof("domain match endpoint:       %t", config.DomainMatchEndpoint)
+	ctx.Infof("include suggestion endpoint: %t", config.IncludeSuggestionEndpoint)
 	if config.RemoteAccessConfig != nil {
 		ctx.Infof("remote access server port: %d", config.RemoteAccessConfig.Port)
 	}
 
+	metrics.SetZoneLabelAllowlist(config.MetricsZones)
+	metrics.EnableCostReport(config.CostReport)
+	enableWriteFreezeEndpoint(config.WriteFreezeSwitch)
+	if config.WriteFreezeConfigMap == nil {
+		_ = SetWriteFreeze(ctx, config.WriteFreeze)
+	}
+	theAnomalyGuard.configure(config.AnomalyGuardMax, config.AnomalyGuardWindow, config.AnomalyGuardPause)
+
 	realms := access.RealmTypes{"use": access.NewRealmType(dns.REALM_ANNOTATION)}
 
-	return &state{
+	s := &state{
 		setup:               newSetup(),
 		classes:             classes,
 		context:             ctx,
@@ -189,6 +253,7 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 		config:              config,
 		realms:              realms,
 		accountCache:        NewAccountCache(config.CacheTTL, config.Options),
+		credentials:         newCredentialsCache(),
 		ownerCache:          NewOwnerCache(ctx, &config),
 		foreign:             map[resources.ObjectName]*foreignProvider{},
 		providers:           map[resources.ObjectName]*dnsProviderVersion{},
@@ -202,10 +267,27 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 		entries:             Entries{},
 		outdated:            newSynchronizedEntries(),
 		blockingEntries:     map[resources.ObjectName]time.Time{},
+		withoutProvider:     newEntriesWithoutProvider(),
+		readiness:           newReadinessProbes(),
 		dnsnames:            map[ZonedDNSName]*Entry{},
 		references:          NewReferenceCache(),
 		providerRateLimiter: map[resources.ObjectName]*rateLimiterData{},
+		zoneRateLimiters:    map[resources.ObjectName]*zoneRateLimiters{},
+		canaries:            newCanaryObservations(),
+	}
+	if config.ExternalDataEndpoint {
+		enableExternalDataEndpoint(s)
+	}
+	if config.ZoneStateEndpoint {
+		enableZoneStateEndpoint(s)
+	}
+	if config.DomainMatchEndpoint {
+		enableDomainMatchEndpoint(s)
+	}
+	if config.IncludeSuggestionEndpoint {
+		enableIncludeSuggestionEndpoint(s)
 	}
+	return s
 }
 
 func (this *state) IsResponsibleFor(logger logger.LogContext, obj resources.Object) bool {
@@ -217,8 +299,53 @@ func (this *state) Setup() error {
 	if syncPeriod == nil {
 		return fmt.Errorf("Pool %s not found", DNS_POOL)
 	}
-	this.zoneStates = newZoneStates(this.CreateStateTTLGetter(*syncPeriod))
+	stateTTL := *syncPeriod
+	if this.config.VerificationPeriod > 0 {
+		// zone states are revalidated by the verification loop only
+		stateTTL = this.config.VerificationPeriod
+	}
+	this.zoneStates = newZoneStates(this.CreateStateTTLGetter(stateTTL, this.config.ZoneStateTTLJitter))
+	this.zoneStates.SetFullSyncPeriod(this.config.ZoneStateFullSync)
+	this.zoneStates.SetMaxStale(this.config.ZoneStateMaxStale)
+	this.zoneStates.SetVerificationDriven(this.config.VerificationPeriod > 0)
+	this.zoneStates.SetRecordHistorySize(this.config.RecordHistorySize)
+	if this.config.ZoneStateCaching && this.config.ZoneStateCacheDir != "" {
+		persistence, err := NewFileZoneStatePersistence(this.config.ZoneStateCacheDir)
+		if err != nil {
+			return err
+		}
+		this.zoneStates.EnablePersistence(persistence, this.config.ZoneStateCacheAge)
+	}
+	sinks, err := NewAuditSinks(this.context.GetContext(), this.context, &this.config)
+	if err != nil {
+		return err
+	}
+	signer, err := NewAuditSignerForConfig(&this.config)
+	if err != nil {
+		return err
+	}
+	this.audit = NewAuditLog(this.context, signer, sinks...)
+	if this.config.WriteFreezeConfigMap != nil {
+		resc, err := this.context.GetByExample(&corev1.ConfigMap{})
+		if err != nil {
+			return err
+		}
+		persistence := NewConfigMapWriteFreezePersistence(resc, this.config.WriteFreezeConfigMap)
+		if err := restoreWriteFreeze(this.context, persistence, this.config.WriteFreeze); err != nil {
+			return err
+		}
+	}
 	this.dnsTicker = NewTicker(this.context.GetPool(DNS_POOL).Tick)
+	addWriteFreezeListener(func(frozen bool) {
+		if !frozen {
+			// apply the changes planned during the write freeze
+			this.triggerAllHostedZones()
+		}
+	})
+	theAnomalyGuard.addListener(func(zoneid dns.ZoneID) {
+		// apply the changes planned during the write pause of the zone
+		this.triggerHostedZoneIfKnown(zoneid)
+	})
 	this.ownerupd = startOwnerUpdater(this.context, this.ownerresc)
 	processors, err := this.context.GetIntOption(OPT_SETUP)
 	if err != nil || processors <= 0 {
@@ -245,7 +372,7 @@ func (this *state) Setup() error {
 	this.setupFor(&api.DNSProvider{}, "providers", func(e resources.Object) {
 		p := dnsutils.DNSProvider(e)
 		if this.GetHandlerFactory().IsResponsibleFor(p) {
-			this.UpdateProvider(this.context.NewContext("provider", p.ObjectName().String()), p)
+			this.UpdateProvider(this.context.GetContext(), this.context.NewContext("provider", p.ObjectName().String()), p)
 		}
 	}, processors)
 	this.setupFor(&api.DNSOwner{}, "owners", func(e resources.Object) {
@@ -254,12 +381,13 @@ func (this *state) Setup() error {
 	}, processors)
 	this.setupFor(&api.DNSEntry{}, "entries", func(e resources.Object) {
 		p := dnsutils.DNSEntry(e)
-		this.UpdateEntry(this.context.NewContext("entry", p.ObjectName().String()), p)
+		this.UpdateEntry(this.context.GetContext(), this.context.NewContext("entry", p.ObjectName().String()), p)
 	}, processors)
 	this.setupFor(&api.DNSLock{}, "locks", func(e resources.Object) {
 		p := dnsutils.DNSLock(e)
-		this.UpdateEntry(this.context.NewContext("entry", p.ObjectName().String()), p)
+		this.UpdateEntry(this.context.GetContext(), this.context.NewContext("entry", p.ObjectName().String()), p)
 	}, processors)
+	this.prioritizeZones()
 
 	this.triggerStatistic()
 	this.initialized = true
@@ -269,10 +397,13 @@ func (this *state) Setup() error {
 
 func (this *state) startRemoteAccessServer(secret *corev1.Secret) error {
 	this.context.Infof("starting RemoteAccessServer")
+	health.Register(HEALTH_REMOTE_ACCESS_SERVER, 0)
 	server, err := embed.StartDNSHandlerServer(this.context, this.config.RemoteAccessConfig)
 	if err != nil {
+		health.SetReady(HEALTH_REMOTE_ACCESS_SERVER, false, err.Error())
 		return err
 	}
+	health.SetReady(HEALTH_REMOTE_ACCESS_SERVER, true, "")
 	this.config.RemoteAccessConfig.ServerSecretProvider.UpdateSecret(secret)
 
 	listener, ok := server.(ProviderEventListener)
@@ -321,8 +452,8 @@ func (this *state) GetConfig() Config {
 	return this.config
 }
 
-func (this *state) GetDNSAccount(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties) (*DNSAccount, error) {
-	return this.accountCache.Get(logger, provider, props, this)
+func (this *state) GetDNSAccount(logger logger.LogContext, provider *dnsutils.DNSProviderObject, props utils.Properties, last *DNSAccount) (*DNSAccount, error) {
+	return this.accountCache.Get(logger, provider, props, this, last)
 }
 
 func (this *state) GetHandlerFactory() DNSHandlerFactory {
@@ -341,6 +472,20 @@ func (this *state) HasProvidersForZone(zoneid dns.ZoneID) bool {
 	return this.hasProvidersForZone(zoneid)
 }
 
+// HasProviders returns whether there are providers to reconcile.
+func (this *state) HasProviders() bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return len(this.providers) > 0 || len(this.deleting) > 0
+}
+
+// HasZones returns whether there are hosted zones to reconcile.
+func (this *state) HasZones() bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return len(this.zones) > 0
+}
+
 func (this *state) hasProvidersForZone(zoneid dns.ZoneID) bool {
 	return len(this.zoneproviders[zoneid]) > 0
 }
@@ -401,11 +546,28 @@ type providerMatch struct {
 }
 
 func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNSProvider, error) {
-	handleMatch := func(match *providerMatch, p *dnsProviderVersion, n int, err error) error {
+	checkAccess := func(p *dnsProviderVersion) error {
+		return access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
+	}
+	return this.lookupProviderForName(e.GetDNSName(), e.GetNamespace(), utils.StringValue(e.BaseStatus().Provider), checkAccess)
+}
+
+// lookupProviderForName selects the provider for a DNS name of an entry in the given namespace.
+// On equal matches the provider currently used by the entry is kept.
+func (this *state) lookupProviderForName(dnsname, namespace, current string, checkAccess func(p *dnsProviderVersion) error) (DNSProvider, DNSProvider, error) {
+	return selectProvider(this.providers, dnsname, this.namespaceLabelsForDefaultProviders(namespace), current, checkAccess)
+}
+
+// selectProvider selects the provider with the longest domain match for a DNS name.
+// A provider declared as default for the namespace of the entry (given by its labels) is preferred
+// over other providers with the same match, but not over providers with a longer (more specific) match.
+func selectProvider(providers map[resources.ObjectName]*dnsProviderVersion, dnsname string, nslabels labels.Set, current string,
+	checkAccess func(p *dnsProviderVersion) error) (DNSProvider, DNSProvider, error) {
+	handleMatch := func(match *providerMatch, name resources.ObjectName, p *dnsProviderVersion, n int, err error) error {
 		if match.match <= n {
-			err2 := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
+			err2 := checkAccess(p)
 			if err2 == nil {
-				if match.match < n || (e.BaseStatus().Provider != nil && *e.BaseStatus().Provider == p.object.ObjectName().String()) {
+				if match.match < n || (current != "" && current == name.String()) {
 					match.found = p
 					match.match = n
 				}
@@ -418,24 +580,35 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 		return err
 	}
 	var err error
+	defaultMatch := &providerMatch{}
 	validMatch := &providerMatch{}
 	errorMatch := &providerMatch{}
 	validMatchFallback := &providerMatch{}
-	for _, p := range this.providers {
-		n := p.Match(e.GetDNSName())
+	for name, p := range providers {
+		if p.unconfirmed {
+			// entries are not assigned before the readiness is confirmed
+			continue
+		}
+		n := p.Match(dnsname)
 		if n > 0 {
 			if p.IsValid() {
-				err = handleMatch(validMatch, p, n, err)
+				if p.isDefaultForNamespace(nslabels) {
+					handleMatch(defaultMatch, name, p, n, nil)
+				}
+				err = handleMatch(validMatch, name, p, n, err)
 			} else {
-				err = handleMatch(errorMatch, p, n, err)
+				err = handleMatch(errorMatch, name, p, n, err)
 			}
 		} else {
-			n = p.MatchZone(e.GetDNSName())
+			n = p.MatchZone(dnsname)
 			if n > 0 && p.IsValid() {
-				handleMatch(validMatchFallback, p, n, nil)
+				handleMatch(validMatchFallback, name, p, n, nil)
 			}
 		}
 	}
+	if defaultMatch.found != nil && defaultMatch.match >= validMatch.match {
+		return defaultMatch.found, nil, nil
+	}
 	if validMatch.found != nil {
 		return validMatch.found, nil, nil
 	}
@@ -445,6 +618,30 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 	return nil, validMatchFallback.found, err
 }
 
+// namespaceLabelsForDefaultProviders returns the labels of the given namespace
+// if there is at least one provider declaring a namespace selector.
+func (this *state) namespaceLabelsForDefaultProviders(namespace string) labels.Set {
+	found := false
+	for _, p := range this.providers {
+		if p.defaultForNamespaces != nil {
+			found = true
+			break
+		}
+	}
+	if !found || namespace == "" {
+		return nil
+	}
+	res, err := this.context.GetByExample(&corev1.Namespace{})
+	if err != nil {
+		return nil
+	}
+	ns, err := res.GetCached(resources.NewObjectName(namespace))
+	if err != nil {
+		return nil
+	}
+	return labels.Set(ns.GetLabels())
+}
+
 func (this *state) GetProvider(name resources.ObjectName) DNSProvider {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
@@ -594,8 +791,32 @@ func (this *state) triggerStatistic() {
 	}
 }
 
+func hostedZoneCommand(zoneid dns.ZoneID) string {
+	return CMD_HOSTEDZONE_PREFIX + zoneid.ProviderType + ":" + zoneid.ID
+}
+
 func (this *state) triggerHostedZone(zoneid dns.ZoneID) {
-	cmd := CMD_HOSTEDZONE_PREFIX + zoneid.ProviderType + ":" + zoneid.ID
+	cmd := hostedZoneCommand(zoneid)
+	if this.context.IsReady() {
+		this.context.EnqueueCommand(cmd)
+	} else {
+		this.setup.AddCommand(cmd)
+	}
+}
+
+func (this *state) triggerAllHostedZones() {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	for zoneid := range this.zones {
+		this.triggerHostedZone(zoneid)
+	}
+}
+
+func (this *state) triggerZoneVerification(zoneid dns.ZoneID) {
+	if this.config.VerificationPeriod <= 0 {
+		return
+	}
+	cmd := CMD_VERIFYZONE_PREFIX + zoneid.ProviderType + ":" + zoneid.ID
 	if this.context.IsReady() {
 		this.context.EnqueueCommand(cmd)
 	} else {
@@ -622,6 +843,17 @@ func (this *state) DecodeZoneCommand(name string) *dns.ZoneID {
 	return nil
 }
 
+func (this *state) DecodeZoneVerificationCommand(name string) *dns.ZoneID {
+	if strings.HasPrefix(name, CMD_VERIFYZONE_PREFIX) {
+		parts := strings.SplitN(name[len(CMD_VERIFYZONE_PREFIX):], ":", 2)
+		if len(parts) == 2 {
+			zoneid := dns.NewZoneID(parts[0], parts[1])
+			return &zoneid
+		}
+	}
+	return nil
+}
+
 func (this *state) updateZones(logger logger.LogContext, last, new *dnsProviderVersion) bool {
 	var name resources.ObjectName
 	keeping := []string{}
@@ -637,6 +869,7 @@ func (this *state) updateZones(logger logger.LogContext, last, new *dnsProviderV
 				this.zones[z.Id()] = zone
 				logger.Infof("adding hosted zone %q (%s)", z.Id(), z.Domain())
 				this.triggerHostedZone(zone.Id())
+				this.triggerZoneVerification(zone.Id())
 				this.triggerAllZonePolicies()
 			}
 			zone.update(z)
@@ -680,6 +913,7 @@ func (this *state) updateZones(logger logger.LogContext, last, new *dnsProviderV
 		}
 	}
 	this.providerzones[name] = result
+	this.updateStateTTLMap()
 	return modified
 }
 
@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
@@ -96,12 +97,12 @@ func (this *state) addBlockingEntries(logger logger.LogContext, entries Entries)
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func (this *state) UpdateEntry(logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
-	return this.HandleUpdateEntry(logger, "reconcile", object)
+func (this *state) UpdateEntry(ctx context.Context, logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
+	return this.HandleUpdateEntry(ctx, logger, "reconcile", object)
 }
 
-func (this *state) DeleteEntry(logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
-	return this.HandleUpdateEntry(logger, "delete", object)
+func (this *state) DeleteEntry(ctx context.Context, logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
+	return this.HandleUpdateEntry(ctx, logger, "delete", object)
 }
 
 func (this *state) GetEntry(name resources.ObjectName) *Entry {
@@ -270,10 +271,14 @@ func (this *state) EntryPremise(e dnsutils.DNSSpecification) (*EntryPremise, err
 			p.zonedomain = zone.Domain()
 		}
 	}
+	if p.zoneid == "" {
+		cause := this.lookupNoProviderCause(e)
+		p.noProvider = &cause
+	}
 	return p, err
 }
 
-func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object dnsutils.DNSSpecification) reconcile.Status {
+func (this *state) HandleUpdateEntry(ctx context.Context, logger logger.LogContext, op string, object dnsutils.DNSSpecification) reconcile.Status {
 	old := this.GetEntry(object.ObjectName())
 	if old != nil {
 		if !old.lock.TryLockSpinning(200 * time.Millisecond) {
@@ -294,18 +299,20 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 
 	logger = this.RefineLogger(logger, p.ptype)
 	v := NewEntryVersion(object, old)
+	v.errorHistorySize = this.config.ErrorHistorySize
 	if p.fallback != nil {
 		v.obsolete = true
 	}
 	status := v.Setup(logger, this, p, op, err, this.config, old)
+	this.withoutProvider.Set(object.ObjectName(), v.noProviderReason)
 	new, status := this.AddEntryVersion(logger, v, status)
 
 	if new != nil {
 		if new.Kind() == api.DNSLockKind {
 			if object.IsDeleting() {
-				return this.checkAndDeleteLock(logger, new, p)
+				return this.checkAndDeleteLock(ctx, logger, new, p)
 			} else {
-				return this.checkAndUpdateLock(logger, new, p)
+				return this.checkAndUpdateLock(ctx, logger, new, p)
 			}
 		}
 
@@ -342,6 +349,7 @@ func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterO
 	}()
 
 	delete(this.blockingEntries, key.ObjectName())
+	this.withoutProvider.Set(key.ObjectName(), "")
 
 	old := this.entries[key.ObjectName()]
 	if old != nil {
@@ -394,7 +402,7 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 	}
 }
 
-func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, premise *EntryPremise) reconcile.Status {
+func (this *state) checkAndUpdateLock(ctx context.Context, logger logger.LogContext, entry *Entry, premise *EntryPremise) reconcile.Status {
 	if !entry.updateRequired && entry.object.BaseStatus().ObservedGeneration == entry.object.GetGeneration() {
 		return reconcile.Succeeded(logger)
 	}
@@ -413,7 +421,7 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 	}
 	newRS := FromDedicatedRecordSet(entry.DNSName(), dns.NewRecordSet(dns.RS_TXT, newTTL, records))
 
-	rs, err := handler.GetRecordSet(zone, entry.DNSName(), dns.RS_TXT)
+	rs, err := handler.GetRecordSet(ctx, zone, entry.DNSName(), dns.RS_TXT)
 	if err != nil {
 		return reconcile.Delay(logger, err)
 	}
@@ -427,7 +435,7 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 	}
 
 	if owned && hasLockRecordsetChanged(rs, newRS) {
-		err = handler.CreateOrUpdateRecordSet(logger, zone, rs, newRS)
+		err = handler.CreateOrUpdateRecordSet(ctx, logger, zone, rs, newRS)
 		if err != nil {
 			return reconcile.Delay(logger, err)
 		}
@@ -511,11 +519,11 @@ func isLockOwned(obj *dnsutils.DNSLockObject, lockDNS, timestampDNS string) (own
 	return
 }
 
-func (this *state) checkAndDeleteLock(logger logger.LogContext, entry *Entry, premise *EntryPremise) reconcile.Status {
+func (this *state) checkAndDeleteLock(ctx context.Context, logger logger.LogContext, entry *Entry, premise *EntryPremise) reconcile.Status {
 	handler := premise.provider.GetDedicatedDNSAccess()
 	zone := this.zones[entry.ZoneId()]
 
-	rs, err := handler.GetRecordSet(zone, entry.DNSName(), dns.RS_TXT)
+	rs, err := handler.GetRecordSet(ctx, zone, entry.DNSName(), dns.RS_TXT)
 	if err != nil {
 		return reconcile.Delay(logger, err)
 	}
@@ -524,7 +532,7 @@ func (this *state) checkAndDeleteLock(logger logger.LogContext, entry *Entry, pr
 		timestamp := rs.GetAttr(dns.ATTR_TIMESTAMP)
 		owned, _, _ := isLockOwned(entry.object.(*dnsutils.DNSLockObject), lockID, timestamp)
 		if owned {
-			err = handler.DeleteRecordSet(logger, zone, rs)
+			err = handler.DeleteRecordSet(ctx, logger, zone, rs)
 			if err != nil {
 				return reconcile.Delay(logger, err)
 			}
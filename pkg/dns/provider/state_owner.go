@@ -122,6 +122,8 @@ func (this *state) UpdateOwnerCounts(log logger.LogContext) {
 	this.UpdateStatistic(statistic)
 	types := this.GetHandlerFactory().TypeCodes()
 	metrics.UpdateOwnerStatistic(statistic, types)
+	metrics.UpdateCostStatistic(statistic.Costs)
+	metrics.UpdateOwnerGroupStatistic(statistic.OwnerGroups)
 	changes := this.ownerCache.UpdateCountsWith(statistic.Owners, types)
 	if len(changes) > 0 {
 		log.Infof("found %d changes for owner usages", len(changes))
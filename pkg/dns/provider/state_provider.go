@@ -18,6 +18,7 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
@@ -46,16 +47,16 @@ func (this *state) addEntriesForProvider(p *dnsProviderVersion, entries Entries)
 		}
 	}
 }
-func (this *state) UpdateProvider(logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
+func (this *state) UpdateProvider(ctx context.Context, logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
 	logger = this.RefineLogger(logger, obj.TypeCode())
 	logger.Infof("reconcile PROVIDER")
 	if !this.config.Enabled.Contains(obj.TypeCode()) || !this.config.Factory.IsResponsibleFor(obj) {
 		return this._UpdateForeignProvider(logger, obj)
 	}
-	return this._UpdateLocalProvider(logger, obj)
+	return this._UpdateLocalProvider(ctx, logger, obj)
 }
 
-func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
+func (this *state) _UpdateLocalProvider(ctx context.Context, logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
 	err := this.SetFinalizer(obj)
 	if err != nil {
 		return reconcile.Delay(logger, fmt.Errorf("cannot set finalizer: %s", err))
@@ -68,7 +69,7 @@ func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.
 		last = p.(*dnsProviderVersion)
 	}
 
-	new, status := updateDNSProvider(logger, this, obj, last)
+	new, status := updateDNSProvider(ctx, logger, this, obj, last)
 
 	if last != nil && last.account != nil && last.account != new.account {
 		this.accountCache.Release(logger, last.account, obj.ObjectName())
@@ -100,6 +101,10 @@ func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.
 		}
 		return status
 	}
+	if new.probing {
+		logger.Infof("readiness probe pending")
+		return status
+	}
 
 	this.informProviderUpdated(logger, new)
 
@@ -226,6 +231,7 @@ func (this *state) RemoveProvider(logger logger.LogContext, obj *dnsutils.DNSPro
 
 func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.DNSProviderObject) reconcile.Status {
 	pname := obj.ObjectName()
+	this.readiness.Remove(pname)
 	cur := this.providers[pname]
 	if cur != nil {
 		this.deleting[pname] = cur
@@ -245,6 +251,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 					logger.Infof("provider is exclusively handling zone %q -> cleanup", zoneid)
 
 					done, err := this.StartZoneReconcilation(logger, &zoneReconciliation{
+						ctx:       this.GetContext().GetContext(),
 						zone:      z,
 						providers: providers,
 						entries:   Entries{},
@@ -253,6 +260,8 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 						deleting:  false,
 						fhandler:  this.context,
 						ownership: this.ownerCache,
+						audit:     this.audit,
+						history:   this.recordHistory(),
 					})
 					if !done {
 						return reconcile.Delay(logger, fmt.Errorf("zone reconcilation busy -> delay deletion"))
@@ -290,6 +299,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 		this.accountCache.Release(logger, cur.account, cur.ObjectName())
 		delete(this.deleting, obj.ObjectName())
 		delete(this.providerzones, obj.ObjectName())
+		this.deleteZoneRateLimiters(obj.ObjectName())
 		logger.Infof("finally remove finalizer")
 		return reconcile.DelayOnError(logger, this.RemoveFinalizer(cur.Object()))
 	}
@@ -99,6 +99,7 @@ func (this *state) registerSecret(logger logger.LogContext, secret resources.Obj
 					}
 				}
 				delete(this.secrets, old)
+				this.credentials.Forget(old)
 			} else {
 				delete(oldp, pname)
 			}
@@ -18,7 +18,9 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"hash/fnv"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -29,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -44,6 +47,14 @@ func (this *state) TriggerHostedZone(zoneid dns.ZoneID) {
 	this.triggerHostedZone(zoneid)
 }
 
+func (this *state) triggerHostedZoneIfKnown(zoneid dns.ZoneID) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.zones[zoneid] != nil {
+		this.triggerHostedZone(zoneid)
+	}
+}
+
 func (this *state) TriggerHostedZonesByChangedOwners(logger logger.LogContext, changed utils.StringSet) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -58,6 +69,8 @@ func (this *state) TriggerHostedZonesByChangedOwners(logger logger.LogContext, c
 func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid dns.ZoneID) (time.Duration, bool, *zoneReconciliation) {
 	req := &zoneReconciliation{
 		fhandler: this.context,
+		audit:    this.audit,
+		history:  this.recordHistory(),
 	}
 
 	this.lock.RLock()
@@ -98,7 +111,7 @@ func (this *state) reconcileZoneBlockingEntries(logger logger.LogContext) int {
 	return len(this.blockingEntries)
 }
 
-func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) reconcile.Status {
+func (this *state) ReconcileZone(ctx context.Context, logger logger.LogContext, zoneid dns.ZoneID) reconcile.Status {
 	logger.Infof("Initiate reconcilation of zone %s", zoneid)
 	defer logger.Infof("zone %s done", zoneid)
 
@@ -121,6 +134,7 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) re
 		return reconcile.Succeeded(logger).RescheduleAfter(delay)
 	}
 	logger.Infof("precondition fulfilled for zone %s", zoneid)
+	req.ctx = ctx
 	if done, err := this.StartZoneReconcilation(logger, req); done {
 		if err != nil {
 			if _, ok := err.(*perrs.NoSuchHostedZone); ok {
@@ -130,8 +144,14 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid dns.ZoneID) re
 				}
 				return reconcile.Succeeded(logger)
 			}
+			for _, provider := range req.providers {
+				if p, ok := provider.(*dnsProviderVersion); ok && p.hasFailingCredentials() {
+					// trigger provider reconciliation to fail over to alternative credentials
+					_ = this.context.Enqueue(provider.Object())
+				}
+			}
 			logger.Infof("zone reconcilation failed for %s: %s", req.zone.Id(), err)
-			return reconcile.Succeeded(logger).RescheduleAfter(req.zone.RateLimit())
+			return reconcile.Succeeded(logger).RescheduleAfter(errorBackoff(err, req.zone.RateLimit()))
 		}
 		if req.zone.nextTrigger > 0 {
 			return reconcile.Succeeded(logger).RescheduleAfter(req.zone.nextTrigger)
@@ -170,11 +190,30 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 			list.Unlock()
 			this.triggerStatistic()
 		}()
-		return true, this.reconcileZone(logger, req)
+		err := this.reconcileZone(logger, req)
+		if err != nil {
+			if _, ok := err.(*perrs.NoSuchHostedZone); !ok {
+				this.reportZoneBackoff(logger, req, time.Now().Add(errorBackoff(err, req.zone.RateLimit())))
+			}
+		}
+		return true, err
 	}
 	return false, nil
 }
 
+// reportZoneBackoff reports the next attempt of a failed zone reconciliation in the status of the
+// entries not yet applied, so that the delay caused by the zone backoff is visible.
+func (this *state) reportZoneBackoff(logger logger.LogContext, req *zoneReconciliation, next time.Time) {
+	for _, e := range req.entries {
+		switch e.status.State {
+		case api.STATE_PENDING, api.STATE_ERROR, api.STATE_STALE:
+			if _, err := e.UpdateNextAttempt(logger, next); err != nil {
+				logger.Warnf("cannot update next attempt of %s: %s", e.ObjectName(), err)
+			}
+		}
+	}
+}
+
 func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliation) error {
 	zoneid := req.zone.Id()
 	req.zone.SetNext(time.Now().Add(this.config.Delay))
@@ -189,8 +228,13 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 	}
 	req.zone.nextTrigger = 0
 	modified := false
+	flapping := 0
 	var conflictErr error
 	for _, e := range req.entries {
+		if req.ctx != nil && req.ctx.Err() != nil {
+			req.zone.Failed()
+			return fmt.Errorf("reconciliation of zone %s aborted: %w", zoneid, req.ctx.Err())
+		}
 		// TODO: err handling
 		var changeResult ChangeResult
 		spec := e.object.GetTargetSpec(e)
@@ -198,6 +242,21 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 		if e.IsDeleting() {
 			changeResult = changes.Delete(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 		} else {
+			if hold := e.HoldDown(); hold > 0 {
+				changeResult = changes.Check(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
+				if changeResult.Modified {
+					flapping++
+					if req.zone.nextTrigger == 0 || hold < req.zone.nextTrigger {
+						req.zone.nextTrigger = hold
+					}
+					changes.PseudoApply(e.DNSName())
+					logger.Infof("flapping targets of %s, hold down %.1f s", e.ObjectName(), hold.Seconds())
+					if _, err := e.UpdateDelayedState(logger, api.STATE_PENDING, MSG_FLAPPING, time.Now().Add(hold)); err != nil {
+						logger.Errorf("cannot update: %s", err)
+					}
+					continue
+				}
+			}
 			if !e.NotRateLimited() {
 				changeResult = changes.Check(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 				if changeResult.Modified {
@@ -205,7 +264,7 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 						req.zone.nextTrigger = delay
 						changes.PseudoApply(e.DNSName())
 						logger.Infof("rate limited %s, delay %.1f s", e.ObjectName(), delay.Seconds())
-						statusUpdate.Throttled()
+						statusUpdate.Throttled(delay)
 						if delay.Seconds() > 2 {
 							e.object.Eventf(corev1.EventTypeNormal, "rate limit", "delayed for %1.fs", delay.Seconds())
 						}
@@ -217,9 +276,16 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 			if changeResult.Error != nil && changeResult.Retry {
 				conflictErr = changeResult.Error
 			}
+			if changeResult.Conflict != nil {
+				reportOwnerConflict(logger, e, zoneid, changeResult.Conflict)
+			}
 		}
 		modified = modified || changeResult.Modified
 	}
+	metrics.ReportFlappingEntries(zoneid, flapping)
+	if this.config.CanaryPeriod > 0 && !req.deleting && !req.dedicated {
+		changes.ApplyCanary(this.config.CanaryLabel, this.config.CanaryPeriod, time.Now())
+	}
 	modified = changes.Cleanup(logger) || modified
 	if modified {
 		err = changes.Update(logger)
@@ -246,20 +312,78 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 	return err
 }
 
+// VerifyZone handles the slow verification loop of a zone. It is decoupled from the
+// reconciliation of changes: it only marks the cached zone state for revalidation and
+// triggers a zone reconciliation, which then checks the provider for drifts.
+func (this *state) VerifyZone(ctx context.Context, logger logger.LogContext, zoneid dns.ZoneID) reconcile.Status {
+	this.lock.RLock()
+	zone := this.zones[zoneid]
+	this.lock.RUnlock()
+	if zone == nil {
+		return reconcile.Succeeded(logger).Stop()
+	}
+	if this.config.VerificationPeriod <= 0 {
+		return reconcile.Succeeded(logger).Stop()
+	}
+
+	period := this.zoneStates.stateTTLGetter(zoneid)
+	now := time.Now()
+	if due := zone.GetVerified().Add(period); now.Before(due) {
+		return reconcile.Succeeded(logger).RescheduleAfter(due.Sub(now))
+	}
+
+	this.vlock.Lock()
+	if now.Before(this.nextVerification) {
+		delay := this.nextVerification.Sub(now)
+		this.vlock.Unlock()
+		logger.Debugf("verification of zone %s delayed by %s", zoneid, delay)
+		return reconcile.Succeeded(logger).RescheduleAfter(delay)
+	}
+	this.nextVerification = now.Add(this.config.VerificationDelay)
+	this.vlock.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		// cancelled by the watchdog or on shutdown, the verification is retried later
+		return reconcile.Delay(logger, err)
+	}
+	logger.Infof("verify zone %s", zoneid)
+	zone.SetVerified(now)
+	this.zoneStates.MarkForVerification(zoneid)
+	this.TriggerHostedZone(zoneid)
+	return reconcile.Succeeded(logger).RescheduleAfter(period)
+}
+
 func (this *state) deleteZone(zoneid dns.ZoneID) {
 	metrics.DeleteZone(zoneid)
 	delete(this.zones, zoneid)
 	this.triggerAllZonePolicies()
 }
 
-func (this *state) CreateStateTTLGetter(defaultStateTTL time.Duration) StateTTLGetter {
+// CreateStateTTLGetter creates the getter for the ttl of the cached zone states.
+// The ttl is spread by a jitter (in percent) derived from the zone id, so that the zone states
+// loaded at the same time (e.g. on startup) don't expire simultaneously.
+func (this *state) CreateStateTTLGetter(defaultStateTTL time.Duration, jitterPercent int) StateTTLGetter {
 	return func(zoneid dns.ZoneID) time.Duration {
+		ttl := defaultStateTTL
 		if value := this.zoneStateTTL.Load(); value != nil {
 			stateTTLMap := value.(map[dns.ZoneID]time.Duration)
-			if ttl, ok := stateTTLMap[zoneid]; ok {
-				return ttl
+			if zttl, ok := stateTTLMap[zoneid]; ok {
+				ttl = zttl
 			}
 		}
-		return defaultStateTTL
+		return applyStateTTLJitter(zoneid, ttl, jitterPercent)
+	}
+}
+
+// applyStateTTLJitter changes the ttl by up to +/- jitterPercent.
+// The jitter is stable for a zone to keep the expiration checks consistent.
+func applyStateTTLJitter(zoneid dns.ZoneID, ttl time.Duration, jitterPercent int) time.Duration {
+	if jitterPercent <= 0 || ttl <= 0 {
+		return ttl
 	}
+	h := fnv.New32a()
+	h.Write([]byte(zoneid.String()))
+	// factor in [-1, 1]
+	factor := float64(h.Sum32()%2001)/1000 - 1
+	return ttl + time.Duration(factor*float64(jitterPercent)/100*float64(ttl))
 }
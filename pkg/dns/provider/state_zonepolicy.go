@@ -102,12 +102,42 @@ func (this *state) updateStateTTLMap() {
 		if zpol := zone.Policy(); zpol != nil {
 			if zpol.spec.Policy.ZoneStateCacheTTL != nil {
 				new[zone.Id()] = zpol.spec.Policy.ZoneStateCacheTTL.Duration
+				continue
 			}
 		}
+		if ttl, ok := this.providerStateTTL(zone.Id()); ok {
+			new[zone.Id()] = ttl
+		}
 	}
 	this.zoneStateTTL.Store(new)
 }
 
+// providerStateTTL returns the minimum zone state ttl of the providers of a zone
+// given by the annotation dns.gardener.cloud/zone-state-cache-ttl.
+func (this *state) providerStateTTL(zoneid dns.ZoneID) (time.Duration, bool) {
+	var result time.Duration
+	found := false
+	for name := range this.zoneproviders[zoneid] {
+		p := this.providers[name]
+		if p == nil {
+			continue
+		}
+		value, ok := p.object.GetAnnotations()[dns.ZONE_STATE_CACHE_TTL_ANNOTATION]
+		if !ok {
+			continue
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl <= 0 {
+			continue
+		}
+		if !found || ttl < result {
+			result = ttl
+			found = true
+		}
+	}
+	return result, found
+}
+
 func (this *state) RemoveZonePolicy(logger logger.LogContext, policy *dnsutils.DNSHostedZonePolicyObject) reconcile.Status {
 	key := this.createZonePolicyClusterKey(policy.GetName())
 	return this.ZonePolicyDeleted(logger, key)
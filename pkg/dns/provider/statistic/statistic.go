@@ -128,11 +128,62 @@ func (this OwnerStatistic) Walk(state WalkingState, walker OwnerWalker) WalkingS
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// CostKey identifies a tenant for cost attribution
+type CostKey struct {
+	Namespace    string
+	Tenant       string
+	ProviderType string
+}
+
+// CostDrivers are the cost relevant figures of the entries of a tenant
+type CostDrivers struct {
+	Entries int
+	Records int
+	// Refreshes is the number of record refreshes per hour of a caching resolver derived from the TTLs.
+	// It is an indicator for the query based costs.
+	Refreshes float64
+}
+
+type CostStatistic map[CostKey]*CostDrivers
+
+func (this CostStatistic) Add(namespace, tenant, ptype string, records int, ttl int64) {
+	key := CostKey{Namespace: namespace, Tenant: tenant, ProviderType: ptype}
+	cur := this[key]
+	if cur == nil {
+		cur = &CostDrivers{}
+		this[key] = cur
+	}
+	cur.Entries++
+	cur.Records += records
+	if ttl > 0 {
+		cur.Refreshes += float64(records) * 3600 / float64(ttl)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// OwnerGroupKey identifies the entries of an owner group (team) for an owner id and provider type
+type OwnerGroupKey struct {
+	OwnerGroup   string
+	Owner        string
+	ProviderType string
+}
+
+type OwnerGroupStatistic map[OwnerGroupKey]int
+
+func (this OwnerGroupStatistic) Inc(group, owner, ptype string) {
+	this[OwnerGroupKey{OwnerGroup: group, Owner: owner, ProviderType: ptype}]++
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type EntryStatistic struct {
-	Providers ProviderTypeStatistic
-	Owners    OwnerStatistic
+	Providers   ProviderTypeStatistic
+	Owners      OwnerStatistic
+	Costs       CostStatistic
+	OwnerGroups OwnerGroupStatistic
 }
 
 func NewEntryStatistic() *EntryStatistic {
-	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}}
+	return &EntryStatistic{ProviderTypeStatistic{}, OwnerStatistic{}, CostStatistic{}, OwnerGroupStatistic{}}
 }
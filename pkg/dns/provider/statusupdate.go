@@ -17,6 +17,8 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
@@ -33,6 +35,8 @@ type StatusUpdate struct {
 	delete   bool
 	done     bool
 	fhandler FinalizerHandler
+	// changes are the record set changes requested for the entry (old -> new values)
+	changes []string
 }
 
 func NewStatusUpdate(logger logger.LogContext, e *Entry, f FinalizerHandler) DoneHandler {
@@ -61,7 +65,7 @@ func (this *StatusUpdate) Failed(err error) {
 		} else {
 			newState = api.STATE_STALE
 		}
-		_, err := this.UpdateStatus(this.logger, newState, err.Error())
+		_, err := this.UpdateErrorStatus(this.logger, newState, err)
 		if err != nil {
 			this.logger.Errorf("cannot update: %s", err)
 		}
@@ -76,6 +80,9 @@ func (this *StatusUpdate) Succeeded() {
 			this.fhandler.RemoveFinalizer(this.Entry.Object())
 		} else {
 			this.Entry.activezone = this.ZoneId()
+			if len(this.changes) > 0 {
+				this.Entry.lastChanges = this.changes
+			}
 			this.fhandler.SetFinalizer(this.Entry.Object())
 			_, err := this.UpdateStatus(this.logger, api.STATE_READY, "dns entry active")
 			if err != nil {
@@ -84,8 +91,23 @@ func (this *StatusUpdate) Succeeded() {
 		}
 	}
 }
-func (this *StatusUpdate) Throttled() {
-	_, err := this.UpdateState(this.logger, api.STATE_PENDING, MSG_THROTTLING)
+func (this *StatusUpdate) Blocked(reason string, planned []string) {
+	if !this.done {
+		this.done = true
+		this.modified = false
+		_, err := this.UpdatePlannedState(this.logger, reason, planned)
+		if err != nil {
+			this.logger.Errorf("cannot update: %s", err)
+		}
+	}
+}
+
+func (this *StatusUpdate) Throttled(retryAfter time.Duration) {
+	var next time.Time
+	if retryAfter > 0 {
+		next = time.Now().Add(retryAfter)
+	}
+	_, err := this.UpdateDelayedState(this.logger, api.STATE_PENDING, MSG_THROTTLING, next)
 	if err != nil {
 		this.logger.Errorf("cannot update: %s", err)
 	}
@@ -17,10 +17,6 @@
 package provider
 
 import (
-	"fmt"
-	"net"
-
-	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
@@ -28,14 +24,8 @@ type Target = dnsutils.Target
 type Targets = dnsutils.Targets
 
 func NewHostTargetFromEntryVersion(name string, entry *EntryVersion) (Target, error) {
-	ip := net.ParseIP(name)
-	if ip == nil {
-		return dnsutils.NewTarget(dns.RS_CNAME, name, entry.TTL()), nil
-	} else if ip.To4() != nil {
-		return dnsutils.NewTarget(dns.RS_A, name, entry.TTL()), nil
-	} else if ip.To16() != nil {
-		return dnsutils.NewTarget(dns.RS_AAAA, name, entry.TTL()), nil
-	} else {
-		return nil, fmt.Errorf("unexpected IP address (never ipv4 or ipv6): %s (%s)", ip.String(), name)
+	if err := dnsutils.ValidateTarget(name); err != nil {
+		return nil, err
 	}
+	return dnsutils.NewTarget(dnsutils.TargetRecordType(name), name, entry.TTL()), nil
 }
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/config"
+)
+
+type TimeoutConfig struct {
+	GetZones        time.Duration
+	GetZoneState    time.Duration
+	ExecuteRequests time.Duration
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// TimeoutOptions are the timeouts applied to the context passed to the
+// provider handlers. The handlers pass this context to the requests of the
+// provider APIs. The Alibaba Cloud SDK does not support contexts, therefore
+// the remaining time is used as read timeout of each request and the deadline
+// is only checked before each request or page.
+type TimeoutOptions struct {
+	GetZones        time.Duration
+	GetZoneState    time.Duration
+	ExecuteRequests time.Duration
+}
+
+var TimeoutOptionsDefaults = TimeoutOptions{
+	GetZones:        2 * time.Minute,
+	GetZoneState:    5 * time.Minute,
+	ExecuteRequests: 10 * time.Minute,
+}
+
+func (this *TimeoutOptions) AddOptionsToSet(set config.OptionSet) {
+	set.AddDurationOption(&this.GetZones, OPT_TIMEOUT_GET_ZONES, "", this.GetZones, "timeout for listing the hosted zones of a DNS provider account (0 disables the timeout)")
+	set.AddDurationOption(&this.GetZoneState, OPT_TIMEOUT_GET_ZONE_STATE, "", this.GetZoneState, "timeout for reading the records of a hosted zone (0 disables the timeout)")
+	set.AddDurationOption(&this.ExecuteRequests, OPT_TIMEOUT_EXECUTE_REQUESTS, "", this.ExecuteRequests, "timeout for executing a batch of change requests for a hosted zone (0 disables the timeout)")
+}
+
+func (c *TimeoutOptions) GetTimeoutConfig() TimeoutConfig {
+	return TimeoutConfig{GetZones: c.GetZones, GetZoneState: c.GetZoneState, ExecuteRequests: c.ExecuteRequests}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (c TimeoutConfig) String() string {
+	return fmt.Sprintf("GetZones: %s, GetZoneState: %s, ExecuteRequests: %s", c.GetZones, c.GetZoneState, c.ExecuteRequests)
+}
+
+// withTimeout derives a context with the given timeout from the parent context.
+// A nil parent context is replaced by the background context and a timeout <= 0
+// keeps the deadline of the parent context.
+func withTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
@@ -21,6 +21,8 @@ import (
 	"reflect"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 )
@@ -72,3 +74,15 @@ func assureRateLimit(mod *resources.ModificationState, t **api.RateLimit, s *api
 		}
 	}
 }
+
+func assureActiveSecretRef(mod *resources.ModificationState, t **corev1.SecretReference, s *corev1.SecretReference) {
+	if s == nil && *t != nil {
+		*t = nil
+		mod.Modify(true)
+	} else if s != nil {
+		if *t == nil || **t != *s {
+			*t = s
+			mod.Modify(true)
+		}
+	}
+}
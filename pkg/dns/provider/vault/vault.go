@@ -0,0 +1,270 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+// NAME is the value of the secret property CREDENTIAL_SOURCE selecting HashiCorp Vault.
+const NAME = "vault"
+
+// Secret engines supported for the credentials
+const (
+	// ENGINE_KV reads static credentials from a key/value secret (version 1 or 2)
+	ENGINE_KV = "kv"
+	// ENGINE_AWS generates short-lived credentials with the AWS secrets engine
+	ENGINE_AWS = "aws"
+	// ENGINE_GCP generates service account keys with the Google Cloud secrets engine
+	ENGINE_GCP = "gcp"
+)
+
+const defaultJWTFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+func init() {
+	provider.RegisterCredentialSource(&source{client: &http.Client{Timeout: 30 * time.Second}})
+}
+
+type source struct {
+	client *http.Client
+}
+
+var _ provider.CredentialSource = &source{}
+
+func (s *source) Name() string {
+	return NAME
+}
+
+// Resolve logs in to Vault and reads the credentials from the configured path.
+func (s *source) Resolve(ctx context.Context, props utils.Properties) (*provider.ResolvedCredentials, error) {
+	c, err := newClient(s.client, props)
+	if err != nil {
+		return nil, err
+	}
+	path := strings.Trim(props["VAULT_PATH"], "/")
+	if path == "" {
+		return nil, fmt.Errorf("VAULT_PATH not specified")
+	}
+	engine := props["VAULT_ENGINE"]
+	if engine == "" {
+		engine = ENGINE_KV
+	}
+	if err := c.login(ctx, props); err != nil {
+		return nil, err
+	}
+	resp, err := c.read(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	creds := &provider.ResolvedCredentials{}
+	if resp.LeaseDuration > 0 {
+		creds.Expiry = time.Now().Add(time.Duration(resp.LeaseDuration) * time.Second)
+	}
+	switch engine {
+	case ENGINE_KV:
+		creds.Properties, err = kvProperties(resp.Data)
+	case ENGINE_AWS:
+		creds.Properties, err = awsProperties(resp.Data)
+	case ENGINE_GCP:
+		creds.Properties, err = gcpProperties(resp.Data)
+	default:
+		err = fmt.Errorf("unsupported VAULT_ENGINE %q (allowed: %s, %s, %s)", engine, ENGINE_KV, ENGINE_AWS, ENGINE_GCP)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid credentials at %s: %w", path, err)
+	}
+	return creds, nil
+}
+
+// kvProperties returns the values of a key/value secret. The data of
+// version 2 is nested in the field data beside the metadata.
+func kvProperties(data map[string]interface{}) (utils.Properties, error) {
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+	props := utils.Properties{}
+	for k, v := range data {
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("value of key %s is no string", k)
+		}
+		props[k] = str
+	}
+	if len(props) == 0 {
+		return nil, fmt.Errorf("no keys found")
+	}
+	return props, nil
+}
+
+// awsProperties maps the credentials of the aws secrets engine to the properties of the aws-route53 provider.
+func awsProperties(data map[string]interface{}) (utils.Properties, error) {
+	accessKey, _ := data["access_key"].(string)
+	secretKey, _ := data["secret_key"].(string)
+	if accessKey == "" || secretKey == "" {
+		return nil, fmt.Errorf("access_key or secret_key missing")
+	}
+	props := utils.Properties{
+		"AWS_ACCESS_KEY_ID":     accessKey,
+		"AWS_SECRET_ACCESS_KEY": secretKey,
+	}
+	if token, _ := data["security_token"].(string); token != "" {
+		props["AWS_SESSION_TOKEN"] = token
+	}
+	return props, nil
+}
+
+// gcpProperties maps the service account key of the gcp secrets engine to the properties of the google-clouddns provider.
+func gcpProperties(data map[string]interface{}) (utils.Properties, error) {
+	key, _ := data["private_key_data"].(string)
+	if key == "" {
+		return nil, fmt.Errorf("private_key_data missing")
+	}
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private_key_data: %w", err)
+	}
+	return utils.Properties{"serviceaccount.json": string(decoded)}, nil
+}
+
+type client struct {
+	http      *http.Client
+	address   string
+	namespace string
+	token     string
+}
+
+type response struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+func newClient(httpClient *http.Client, props utils.Properties) (*client, error) {
+	address := strings.TrimSuffix(props["VAULT_ADDR"], "/")
+	if address == "" {
+		return nil, fmt.Errorf("VAULT_ADDR not specified")
+	}
+	if ca := props["VAULT_CACERT"]; ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("invalid VAULT_CACERT")
+		}
+		httpClient = &http.Client{
+			Timeout:   httpClient.Timeout,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}},
+		}
+	}
+	return &client{http: httpClient, address: address, namespace: props["VAULT_NAMESPACE"]}, nil
+}
+
+// login uses the token VAULT_TOKEN or the Kubernetes auth method with the role VAULT_ROLE.
+func (c *client) login(ctx context.Context, props utils.Properties) error {
+	if token := props["VAULT_TOKEN"]; token != "" {
+		c.token = token
+		return nil
+	}
+	role := props["VAULT_ROLE"]
+	if role == "" {
+		return fmt.Errorf("either VAULT_TOKEN or VAULT_ROLE must be specified")
+	}
+	mount := strings.Trim(props["VAULT_AUTH_PATH"], "/")
+	if mount == "" {
+		mount = "kubernetes"
+	}
+	file := props["VAULT_JWT_FILE"]
+	if file == "" {
+		file = defaultJWTFile
+	}
+	jwt, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("cannot read service account token: %w", err)
+	}
+	body, _ := json.Marshal(map[string]string{"role": role, "jwt": strings.TrimSpace(string(jwt))})
+	resp, err := c.do(ctx, http.MethodPost, "auth/"+mount+"/login", body)
+	if err != nil {
+		return fmt.Errorf("login with role %s failed: %w", role, err)
+	}
+	if resp.Auth == nil || resp.Auth.ClientToken == "" {
+		return fmt.Errorf("login with role %s failed: no client token", role)
+	}
+	c.token = resp.Auth.ClientToken
+	return nil
+}
+
+func (c *client) read(ctx context.Context, path string) (*response, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", path, err)
+	}
+	if resp.Data == nil {
+		return nil, fmt.Errorf("no data found at %s", path)
+	}
+	return resp, nil
+}
+
+func (c *client) do(ctx context.Context, method, path string, body []byte) (*response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.address+"/v1/"+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	httpResp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	resp := &response{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("invalid response (status %d): %w", httpResp.StatusCode, err)
+	}
+	if httpResp.StatusCode/100 != 2 {
+		if len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("status %d: %s", httpResp.StatusCode, strings.Join(resp.Errors, ", "))
+		}
+		return nil, fmt.Errorf("status %d", httpResp.StatusCode)
+	}
+	return resp, nil
+}
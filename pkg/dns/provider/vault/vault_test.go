@@ -0,0 +1,115 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package vault
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/kubernetes/login" {
+			body := map[string]string{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["role"] != "dns" || body["jwt"] != "sa-token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth":{"client_token":"login-token"}}`))
+			return
+		}
+		if token := r.Header.Get("X-Vault-Token"); token != "root" && token != "login-token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/route53":
+			_, _ = w.Write([]byte(`{"data":{"data":{"AWS_ACCESS_KEY_ID":"id","AWS_SECRET_ACCESS_KEY":"secret"},"metadata":{"version":3}}}`))
+		case "/v1/aws/creds/dns":
+			_, _ = w.Write([]byte(`{"lease_duration":3600,"data":{"access_key":"ASIA","secret_key":"key","security_token":"token"}}`))
+		case "/v1/gcp/roleset/dns/key":
+			key := base64.StdEncoding.EncodeToString([]byte(`{"type":"service_account"}`))
+			_, _ = w.Write([]byte(`{"lease_duration":600,"data":{"private_key_data":"` + key + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+}
+
+func TestResolve(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+	jwtFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(jwtFile, []byte("sa-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	s := &source{client: server.Client()}
+
+	table := []struct {
+		name     string
+		props    utils.Properties
+		expected utils.Properties
+		expiring bool
+	}{
+		{"kv v2", utils.Properties{"VAULT_PATH": "secret/data/route53", "VAULT_TOKEN": "root"},
+			utils.Properties{"AWS_ACCESS_KEY_ID": "id", "AWS_SECRET_ACCESS_KEY": "secret"}, false},
+		{"aws", utils.Properties{"VAULT_PATH": "aws/creds/dns", "VAULT_ENGINE": ENGINE_AWS, "VAULT_ROLE": "dns", "VAULT_JWT_FILE": jwtFile},
+			utils.Properties{"AWS_ACCESS_KEY_ID": "ASIA", "AWS_SECRET_ACCESS_KEY": "key", "AWS_SESSION_TOKEN": "token"}, true},
+		{"gcp", utils.Properties{"VAULT_PATH": "gcp/roleset/dns/key", "VAULT_ENGINE": ENGINE_GCP, "VAULT_TOKEN": "root"},
+			utils.Properties{"serviceaccount.json": `{"type":"service_account"}`}, true},
+	}
+	for _, entry := range table {
+		entry.props["VAULT_ADDR"] = server.URL
+		creds, err := s.Resolve(context.Background(), entry.props)
+		if err != nil {
+			t.Errorf("Failed: %s: %s", entry.name, err)
+			continue
+		}
+		if !creds.Properties.Equals(entry.expected) {
+			t.Errorf("Failed: %s: unexpected properties %v", entry.name, creds.Properties)
+		}
+		if entry.expiring != !creds.Expiry.IsZero() || (entry.expiring && creds.Expiry.Before(time.Now())) {
+			t.Errorf("Failed: %s: unexpected expiry %v", entry.name, creds.Expiry)
+		}
+	}
+
+	for _, props := range []utils.Properties{
+		{"VAULT_PATH": "secret/data/route53"},
+		{"VAULT_PATH": "secret/data/route53", "VAULT_TOKEN": "invalid"},
+		{"VAULT_PATH": "secret/data/unknown", "VAULT_TOKEN": "root"},
+		{"VAULT_PATH": "aws/creds/dns", "VAULT_TOKEN": "root", "VAULT_ENGINE": "ssh"},
+		{"VAULT_PATH": "aws/creds/dns", "VAULT_ROLE": "other", "VAULT_JWT_FILE": jwtFile},
+	} {
+		props["VAULT_ADDR"] = server.URL
+		if _, err := s.Resolve(context.Background(), props); err == nil {
+			t.Errorf("Failed: expected error for %v", props)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/server"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// writeFreeze is the global emergency switch suspending the writes of all providers.
+// Zones and records are still read and the entry status is updated with the planned changes.
+type writeFreeze struct {
+	lock        sync.Mutex
+	frozen      bool
+	endpoint    bool
+	persistence WriteFreezePersistence
+	listeners   []func(frozen bool)
+}
+
+var theWriteFreeze = &writeFreeze{}
+
+func init() {
+	server.RegisterHandler("/write-freeze", http.HandlerFunc(serveWriteFreeze))
+}
+
+// IsWriteFrozen returns true if the global write freeze is active.
+func IsWriteFrozen() bool {
+	theWriteFreeze.lock.Lock()
+	defer theWriteFreeze.lock.Unlock()
+	return theWriteFreeze.frozen
+}
+
+// SetWriteFreeze activates or releases the global write freeze.
+// The freeze takes effect immediately, an error is returned if the new state cannot be persisted.
+func SetWriteFreeze(logger logger.LogContext, frozen bool) error {
+	theWriteFreeze.lock.Lock()
+	changed := theWriteFreeze.frozen != frozen
+	theWriteFreeze.frozen = frozen
+	persistence := theWriteFreeze.persistence
+	listeners := append([]func(bool){}, theWriteFreeze.listeners...)
+	theWriteFreeze.lock.Unlock()
+
+	metrics.ReportWriteFreeze(frozen)
+	var err error
+	if persistence != nil {
+		if err = persistence.Store(frozen); err != nil {
+			err = fmt.Errorf("cannot persist write freeze: %w", err)
+			logger.Warnf("%s", err)
+		}
+	}
+	if !changed {
+		return err
+	}
+	if frozen {
+		logger.Warnf("global write freeze activated: all provider writes are suspended")
+	} else {
+		logger.Infof("global write freeze released")
+	}
+	for _, l := range listeners {
+		l(frozen)
+	}
+	return err
+}
+
+// restoreWriteFreeze enables the persistence of the global write freeze and restores the persisted state.
+// The freeze is active if it has been persisted as active or if frozen is set.
+// If the persisted state cannot be read, an error is returned instead of starting without the freeze.
+func restoreWriteFreeze(logger logger.LogContext, persistence WriteFreezePersistence, frozen bool) error {
+	persisted, found, err := persistence.Load()
+	if err != nil {
+		return fmt.Errorf("cannot load persisted write freeze: %w", err)
+	}
+	if found {
+		logger.Infof("persisted write freeze: %t", persisted)
+	}
+	theWriteFreeze.lock.Lock()
+	theWriteFreeze.persistence = persistence
+	theWriteFreeze.lock.Unlock()
+	// a failing store is logged, the state is persisted again with the next change
+	_ = SetWriteFreeze(logger, frozen || persisted)
+	return nil
+}
+
+func enableWriteFreezeEndpoint(enabled bool) {
+	theWriteFreeze.lock.Lock()
+	defer theWriteFreeze.lock.Unlock()
+	theWriteFreeze.endpoint = enabled
+}
+
+func addWriteFreezeListener(listener func(frozen bool)) {
+	theWriteFreeze.lock.Lock()
+	defer theWriteFreeze.lock.Unlock()
+	theWriteFreeze.listeners = append(theWriteFreeze.listeners, listener)
+}
+
+// serveWriteFreeze reports the write freeze state on GET requests and
+// activates or releases it on POST requests with query parameter `frozen=true|false`.
+func serveWriteFreeze(w http.ResponseWriter, r *http.Request) {
+	theWriteFreeze.lock.Lock()
+	enabled := theWriteFreeze.endpoint
+	theWriteFreeze.lock.Unlock()
+	if !enabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		frozen, err := strconv.ParseBool(r.URL.Query().Get("frozen"))
+		if err != nil {
+			http.Error(w, "query parameter 'frozen' must be true or false", http.StatusBadRequest)
+			return
+		}
+		if err := SetWriteFreeze(logger.New(), frozen); err != nil {
+			http.Error(w, fmt.Sprintf("frozen=%t, but %s", IsWriteFrozen(), err), http.StatusInternalServerError)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "frozen=%t\n", IsWriteFrozen())
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// WriteFreezePersistence stores the state of the global write freeze to survive controller restarts.
+type WriteFreezePersistence interface {
+	// Load returns the persisted state, found is false if there is no persisted state.
+	Load() (frozen bool, found bool, err error)
+	// Store persists the state.
+	Store(frozen bool) error
+}
+
+const writeFreezeConfigMapKey = "frozen"
+
+type configMapWriteFreezePersistence struct {
+	resources resources.Interface
+	name      resources.ObjectName
+}
+
+var _ WriteFreezePersistence = &configMapWriteFreezePersistence{}
+
+// NewConfigMapWriteFreezePersistence creates a persistence storing the write freeze state in the given config map.
+// The config map is created on the first change of the state.
+func NewConfigMapWriteFreezePersistence(resources resources.Interface, name resources.ObjectName) WriteFreezePersistence {
+	return &configMapWriteFreezePersistence{resources: resources, name: name}
+}
+
+func (this *configMapWriteFreezePersistence) Load() (bool, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if _, err := this.resources.GetInto(this.name, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return false, false, nil
+		}
+		return false, false, err
+	}
+	value, ok := cm.Data[writeFreezeConfigMapKey]
+	if !ok {
+		return false, false, nil
+	}
+	frozen, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, false, fmt.Errorf("invalid value %q of key %s in config map %s", value, writeFreezeConfigMapKey, this.name)
+	}
+	return frozen, true, nil
+}
+
+func (this *configMapWriteFreezePersistence) Store(frozen bool) error {
+	cm := &corev1.ConfigMap{}
+	cm.Namespace = this.name.Namespace()
+	cm.Name = this.name.Name()
+	value := strconv.FormatBool(frozen)
+	_, _, err := this.resources.CreateOrModifyByName(cm, func(data resources.ObjectData) (bool, error) {
+		cm := data.(*corev1.ConfigMap)
+		if cm.Data[writeFreezeConfigMapKey] == value {
+			return false, nil
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[writeFreezeConfigMapKey] = value
+		return true, nil
+	})
+	return err
+}
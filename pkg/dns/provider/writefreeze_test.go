@@ -0,0 +1,301 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+type memoryWriteFreezePersistence struct {
+	frozen *bool
+	err    error
+	stored []bool
+}
+
+func (p *memoryWriteFreezePersistence) Load() (bool, bool, error) {
+	if p.err != nil {
+		return false, false, p.err
+	}
+	if p.frozen == nil {
+		return false, false, nil
+	}
+	return *p.frozen, true, nil
+}
+
+func (p *memoryWriteFreezePersistence) Store(frozen bool) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.frozen = &frozen
+	p.stored = append(p.stored, frozen)
+	return nil
+}
+
+// configMapResources provides a single config map without cluster access.
+type configMapResources struct {
+	resources.Interface
+	configMap *corev1.ConfigMap
+}
+
+func (r *configMapResources) GetInto(name resources.ObjectName, data resources.ObjectData) (resources.Object, error) {
+	if r.configMap == nil || r.configMap.Namespace != name.Namespace() || r.configMap.Name != name.Name() {
+		return nil, errors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, name.Name())
+	}
+	r.configMap.DeepCopyInto(data.(*corev1.ConfigMap))
+	return nil, nil
+}
+
+func (r *configMapResources) CreateOrModifyByName(obj resources.ObjectDataName, modifier resources.Modifier) (resources.Object, bool, error) {
+	cm := obj.(*corev1.ConfigMap)
+	if r.configMap != nil {
+		cm = r.configMap.DeepCopy()
+	}
+	mod, err := modifier(cm)
+	if err == nil && mod {
+		r.configMap = cm
+	}
+	return nil, mod, err
+}
+
+type freezeTestProvider struct {
+	DNSProvider
+}
+
+func (p *freezeTestProvider) IsReadOnly() bool {
+	return true
+}
+
+type blockingDoneHandler struct {
+	recordingDoneHandler
+	reasons []string
+	planned []string
+}
+
+func (h *blockingDoneHandler) Blocked(reason string, planned []string) {
+	h.reasons = append(h.reasons, reason)
+	h.planned = append(h.planned, planned...)
+}
+
+var _ = ginkgov2.Describe("Write freeze", func() {
+	var saved writeFreeze
+
+	ginkgov2.BeforeEach(func() {
+		theWriteFreeze.lock.Lock()
+		saved.frozen, saved.endpoint, saved.persistence, saved.listeners =
+			theWriteFreeze.frozen, theWriteFreeze.endpoint, theWriteFreeze.persistence, theWriteFreeze.listeners
+		theWriteFreeze.frozen, theWriteFreeze.endpoint, theWriteFreeze.persistence, theWriteFreeze.listeners = false, false, nil, nil
+		theWriteFreeze.lock.Unlock()
+	})
+
+	ginkgov2.AfterEach(func() {
+		theWriteFreeze.lock.Lock()
+		theWriteFreeze.frozen, theWriteFreeze.endpoint, theWriteFreeze.persistence, theWriteFreeze.listeners =
+			saved.frozen, saved.endpoint, saved.persistence, saved.listeners
+		theWriteFreeze.lock.Unlock()
+	})
+
+	serve := func(method, query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serveWriteFreeze(w, httptest.NewRequest(method, "/write-freeze"+query, nil))
+		return w
+	}
+
+	ginkgov2.Describe("endpoint", func() {
+		ginkgov2.It("is not served if disabled", func() {
+			Expect(serve(http.MethodGet, "").Code).To(Equal(http.StatusNotFound))
+			Expect(serve(http.MethodPost, "?frozen=true").Code).To(Equal(http.StatusNotFound))
+			Expect(IsWriteFrozen()).To(BeFalse())
+		})
+
+		ginkgov2.It("reports the state on GET", func() {
+			enableWriteFreezeEndpoint(true)
+			w := serve(http.MethodGet, "")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("frozen=false\n"))
+
+			Expect(SetWriteFreeze(logger.New(), true)).To(Succeed())
+			Expect(serve(http.MethodGet, "").Body.String()).To(Equal("frozen=true\n"))
+		})
+
+		ginkgov2.It("activates and releases the freeze on POST", func() {
+			enableWriteFreezeEndpoint(true)
+			var notified []bool
+			addWriteFreezeListener(func(frozen bool) { notified = append(notified, frozen) })
+
+			w := serve(http.MethodPost, "?frozen=true")
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(Equal("frozen=true\n"))
+			Expect(IsWriteFrozen()).To(BeTrue())
+
+			Expect(serve(http.MethodPost, "?frozen=true").Code).To(Equal(http.StatusOK))
+			Expect(serve(http.MethodPost, "?frozen=false").Body.String()).To(Equal("frozen=false\n"))
+			Expect(IsWriteFrozen()).To(BeFalse())
+			Expect(notified).To(Equal([]bool{true, false}))
+		})
+
+		ginkgov2.It("rejects invalid requests", func() {
+			enableWriteFreezeEndpoint(true)
+			Expect(serve(http.MethodPost, "").Code).To(Equal(http.StatusBadRequest))
+			Expect(serve(http.MethodPost, "?frozen=maybe").Code).To(Equal(http.StatusBadRequest))
+			w := serve(http.MethodDelete, "")
+			Expect(w.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(w.Header().Get("Allow")).To(Equal("GET, POST"))
+			Expect(IsWriteFrozen()).To(BeFalse())
+		})
+
+		ginkgov2.It("persists the state and reports persistence failures", func() {
+			enableWriteFreezeEndpoint(true)
+			persistence := &memoryWriteFreezePersistence{}
+			Expect(restoreWriteFreeze(logger.New(), persistence, false)).To(Succeed())
+
+			Expect(serve(http.MethodPost, "?frozen=true").Code).To(Equal(http.StatusOK))
+			Expect(persistence.stored).To(Equal([]bool{false, true}))
+
+			persistence.err = fmt.Errorf("forbidden")
+			w := serve(http.MethodPost, "?frozen=false")
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			Expect(w.Body.String()).To(ContainSubstring("frozen=false, but cannot persist write freeze: forbidden"))
+			// the switch takes effect even if it cannot be persisted
+			Expect(IsWriteFrozen()).To(BeFalse())
+		})
+	})
+
+	ginkgov2.Describe("restore", func() {
+		ginkgov2.It("restores a persisted freeze", func() {
+			frozen := true
+			Expect(restoreWriteFreeze(logger.New(), &memoryWriteFreezePersistence{frozen: &frozen}, false)).To(Succeed())
+			Expect(IsWriteFrozen()).To(BeTrue())
+		})
+
+		ginkgov2.It("keeps the freeze of the option", func() {
+			frozen := false
+			persistence := &memoryWriteFreezePersistence{frozen: &frozen}
+			Expect(restoreWriteFreeze(logger.New(), persistence, true)).To(Succeed())
+			Expect(IsWriteFrozen()).To(BeTrue())
+			Expect(*persistence.frozen).To(BeTrue())
+		})
+
+		ginkgov2.It("starts without freeze if nothing is persisted", func() {
+			Expect(restoreWriteFreeze(logger.New(), &memoryWriteFreezePersistence{}, false)).To(Succeed())
+			Expect(IsWriteFrozen()).To(BeFalse())
+		})
+
+		ginkgov2.It("fails if the persisted state cannot be read", func() {
+			err := restoreWriteFreeze(logger.New(), &memoryWriteFreezePersistence{err: fmt.Errorf("forbidden")}, false)
+			Expect(err).To(MatchError(ContainSubstring("cannot load persisted write freeze")))
+			Expect(theWriteFreeze.persistence).To(BeNil())
+		})
+	})
+
+	ginkgov2.Describe("config map persistence", func() {
+		name := resources.NewObjectName("kube-system", "dns-write-freeze")
+
+		ginkgov2.It("creates and updates the config map", func() {
+			resc := &configMapResources{}
+			persistence := NewConfigMapWriteFreezePersistence(resc, name)
+
+			_, found, err := persistence.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			Expect(persistence.Store(true)).To(Succeed())
+			Expect(resc.configMap.Namespace).To(Equal("kube-system"))
+			Expect(resc.configMap.Name).To(Equal("dns-write-freeze"))
+			Expect(resc.configMap.Data).To(Equal(map[string]string{"frozen": "true"}))
+			frozen, found, err := persistence.Load()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(frozen).To(BeTrue())
+
+			Expect(persistence.Store(false)).To(Succeed())
+			frozen, _, _ = persistence.Load()
+			Expect(frozen).To(BeFalse())
+		})
+
+		ginkgov2.It("keeps other keys of the config map", func() {
+			resc := &configMapResources{configMap: &corev1.ConfigMap{Data: map[string]string{"note": "incident-42"}}}
+			resc.configMap.Namespace, resc.configMap.Name = "kube-system", "dns-write-freeze"
+			Expect(NewConfigMapWriteFreezePersistence(resc, name).Store(true)).To(Succeed())
+			Expect(resc.configMap.Data).To(Equal(map[string]string{"note": "incident-42", "frozen": "true"}))
+		})
+
+		ginkgov2.It("rejects an invalid value", func() {
+			resc := &configMapResources{configMap: &corev1.ConfigMap{Data: map[string]string{"frozen": "maybe"}}}
+			resc.configMap.Namespace, resc.configMap.Name = "kube-system", "dns-write-freeze"
+			_, _, err := NewConfigMapWriteFreezePersistence(resc, name).Load()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	ginkgov2.Describe("change group", func() {
+		var (
+			group *ChangeGroup
+			done  *blockingDoneHandler
+		)
+
+		ginkgov2.BeforeEach(func() {
+			zone := newDNSHostedZone(0, NewDNSHostedZone("test", "Z1", "example.com", "", nil, false))
+			model := &ChangeModel{LogContext: logger.New(), context: &zoneReconciliation{zone: zone}}
+			group = newChangeGroup("test", &freezeTestProvider{}, model)
+			done = &blockingDoneHandler{}
+		})
+
+		addRequest := func() {
+			set := dns.NewDNSSet("www.example.com")
+			set.SetRecordSet(dns.RS_A, 300, "1.2.3.4")
+			group.requests = append(group.requests, NewChangeRequest(R_CREATE, dns.RS_A, nil, set, done))
+		}
+
+		ginkgov2.It("reports the requests as planned during a write freeze", func() {
+			Expect(SetWriteFreeze(logger.New(), true)).To(Succeed())
+			addRequest()
+
+			Expect(group.update(logger.New(), group.model)).To(BeTrue())
+			Expect(done.reasons).To(Equal([]string{MSG_WRITE_FREEZE}))
+			Expect(done.planned).To(HaveLen(1))
+			Expect(done.planned[0]).To(HavePrefix("create A www.example.com"))
+			Expect(done.succeeded).To(Equal(0))
+		})
+
+		ginkgov2.It("is not gated without write freeze", func() {
+			addRequest()
+
+			Expect(group.update(logger.New(), group.model)).To(BeTrue())
+			// the read-only test provider blocks the requests, if the write freeze does not
+			Expect(done.reasons).To(Equal([]string{MSG_READONLY}))
+		})
+
+		ginkgov2.It("does nothing without requests", func() {
+			Expect(SetWriteFreeze(logger.New(), true)).To(Succeed())
+
+			Expect(group.update(logger.New(), group.model)).To(BeTrue())
+			Expect(done.reasons).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type providerWritePermission struct {
+	provider *dnsProviderVersion
+	zones    DNSHostedZones
+	enabled  bool
+}
+
+func (this *state) getProviderWritePermissions() []*providerWritePermission {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	var result []*providerWritePermission
+	for _, p := range this.providers {
+		spec := p.object.Spec().WritePermissionCheck
+		enabled := spec != nil && spec.Enabled
+		if !enabled && len(p.object.Status().WritePermissions) == 0 {
+			continue
+		}
+		r := &providerWritePermission{provider: p, enabled: enabled}
+		for _, z := range p.zones {
+			if p.IncludesZone(z.Id()) {
+				r.zones = append(r.zones, z)
+			}
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// UpdateWritePermissions verifies the write permission for the zones of all providers
+// with enabled write permission check and reports the result in the provider status.
+func (this *state) UpdateWritePermissions(ctx context.Context, logger logger.LogContext) {
+	for _, p := range this.getProviderWritePermissions() {
+		var zones []api.ZoneWritePermissionStatus
+		if p.enabled && p.provider.account != nil {
+			for _, zone := range p.zones {
+				zones = append(zones, checkWritePermission(ctx, p.provider.account, p.provider.TypeCode(), zone))
+			}
+			sort.Slice(zones, func(i, j int) bool { return zones[i].ZoneID < zones[j].ZoneID })
+		}
+		if err := p.provider.updateWritePermissionStatus(zones); err != nil {
+			logger.Warnf("cannot update write permission status of provider %s: %s", p.provider.ObjectName(), err)
+		}
+	}
+}
+
+func checkWritePermission(ctx context.Context, account *DNSAccount, typeCode string, zone DNSHostedZone) api.ZoneWritePermissionStatus {
+	status := api.ZoneWritePermissionStatus{ZoneID: zone.Id().ID, Domain: zone.Domain()}
+	if !account.SupportsWritePermissionProbe() {
+		status.State = api.WRITE_PERMISSION_UNKNOWN
+		status.Message = fmt.Sprintf("write permission check not supported by provider type %s", typeCode)
+		return status
+	}
+	err := account.ProbeWritePermission(ctx, zone)
+	switch {
+	case err == nil:
+		status.State = api.WRITE_PERMISSION_GRANTED
+	case perrs.IsWritePermissionUnverifiable(err):
+		status.State = api.WRITE_PERMISSION_UNKNOWN
+		status.Message = err.Error()
+	default:
+		status.State = api.WRITE_PERMISSION_DENIED
+		status.Message = err.Error()
+	}
+	return status
+}
+
+// updateWritePermissionStatus updates the write permission states of the zones.
+// A warning event is emitted for zones with newly denied write permission.
+func (this *dnsProviderVersion) updateWritePermissionStatus(zones []api.ZoneWritePermissionStatus) error {
+	var denied []api.ZoneWritePermissionStatus
+	_, err := this.object.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSProvider).Status
+		if reflect.DeepEqual(status.WritePermissions, zones) {
+			return false, nil
+		}
+		old := map[string]string{}
+		for _, z := range status.WritePermissions {
+			old[z.ZoneID] = z.State
+		}
+		denied = nil
+		for _, z := range zones {
+			if z.State == api.WRITE_PERMISSION_DENIED && old[z.ZoneID] != api.WRITE_PERMISSION_DENIED {
+				denied = append(denied, z)
+			}
+		}
+		status.WritePermissions = zones
+		return true, nil
+	})
+	if err == nil {
+		for _, z := range denied {
+			this.object.Eventf(corev1.EventTypeWarning, "writepermission", "write permission denied for zone %s (%s): %s", z.ZoneID, z.Domain, z.Message)
+		}
+	}
+	return err
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgov2.Describe("Write permission check", func() {
+	zone := NewDNSHostedZone("test", "Z1", "z1.example.com", "", nil, false)
+
+	check := func(handler DNSHandler) api.ZoneWritePermissionStatus {
+		return checkWritePermission(context.TODO(), NewDNSAccount(nil, handler, "hash"), "test", zone)
+	}
+	newHandler := func() *probeTestHandler {
+		return &probeTestHandler{prefetchTestHandler: prefetchTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}}
+	}
+
+	ginkgov2.It("reports a granted write permission", func() {
+		Expect(check(newHandler())).To(Equal(api.ZoneWritePermissionStatus{ZoneID: "Z1", Domain: "z1.example.com", State: api.WRITE_PERMISSION_GRANTED}))
+	})
+
+	ginkgov2.It("reports a denied write permission", func() {
+		handler := newHandler()
+		handler.deny = "Z1"
+		status := check(handler)
+		Expect(status.State).To(Equal(api.WRITE_PERMISSION_DENIED))
+		Expect(status.Message).To(Equal("access denied"))
+	})
+
+	ginkgov2.It("reports an unknown write permission if it cannot be verified", func() {
+		handler := newHandler()
+		handler.unverifiable = true
+		status := check(handler)
+		Expect(status.State).To(Equal(api.WRITE_PERMISSION_UNKNOWN))
+		Expect(status.Message).To(Equal("write permission cannot be verified: simulation not permitted"))
+	})
+
+	ginkgov2.It("reports an unknown write permission for unsupported provider types", func() {
+		status := check(&prefetchTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")})
+		Expect(status.State).To(Equal(api.WRITE_PERMISSION_UNKNOWN))
+		Expect(status.Message).To(Equal("write permission check not supported by provider type test"))
+	})
+})
@@ -38,6 +38,7 @@ type dnsHostedZone struct {
 	nextTrigger time.Duration
 	owners      utils.StringSet
 	policy      *dnsHostedZonePolicy
+	verified    time.Time
 }
 
 func newDNSHostedZone(min time.Duration, zone DNSHostedZone) *dnsHostedZone {
@@ -45,6 +46,7 @@ func newDNSHostedZone(min time.Duration, zone DNSHostedZone) *dnsHostedZone {
 		zone:        zone,
 		RateLimiter: dnsutils.NewRateLimiter(min, 10*time.Minute, min/2),
 		owners:      utils.StringSet{},
+		verified:    time.Now(),
 	}
 }
 
@@ -124,6 +126,18 @@ func (this *dnsHostedZone) SetNext(next time.Time) {
 	this.next = next
 }
 
+func (this *dnsHostedZone) GetVerified() time.Time {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.verified
+}
+
+func (this *dnsHostedZone) SetVerified(verified time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.verified = verified
+}
+
 func (this *dnsHostedZone) Policy() *dnsHostedZonePolicy {
 	this.lock.Lock()
 	defer this.lock.Unlock()
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
@@ -40,25 +41,46 @@ type ZoneCacheFactory struct {
 	zonesTTL              time.Duration
 	zoneStates            *zoneStates
 	disableZoneStateCache bool
+	// credentialsHash is the hash of the credentials of the account, used to share the zones
+	credentialsHash string
+	// zoneTrigger triggers the reconciliation of a zone
+	zoneTrigger func(zoneid dns.ZoneID)
 }
 
 func (c ZoneCacheFactory) CreateZoneCache(cacheType ZoneCacheType, metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater) (ZoneCache, error) {
-	common := abstractZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}
+	common := abstractZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater,
+		zoneTrigger: c.zoneTrigger}
 	switch cacheType {
 	case CacheZonesOnly:
 		cache := &onlyZonesCache{abstractZonesCache: common}
 		return cache, nil
-	case CacheZoneState:
+	case CacheZoneState, CacheSharedZoneState:
 		if c.disableZoneStateCache {
 			cache := &onlyZonesCache{abstractZonesCache: common}
 			return cache, nil
 		}
-		return newDefaultZoneCache(c.zoneStates, common, metrics)
+		cache, err := newDefaultZoneCache(c.zoneStates, common, metrics)
+		if err == nil && cacheType == CacheSharedZoneState && c.credentialsHash != "" {
+			cache.shared = c.zoneStates.acquireSharedZones(c.credentialsHash)
+		}
+		return cache, err
 	default:
 		return nil, fmt.Errorf("unknown zone cache type: %v", cacheType)
 	}
 }
 
+// CreateIncrementalZoneCache creates a zone state cache, which uses the incremental updater to synchronize
+// expired zone states between the periodic full synchronizations.
+func (c ZoneCacheFactory) CreateIncrementalZoneCache(metrics Metrics, zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater,
+	incrementalUpdater IncrementalZoneStateUpdater) (ZoneCache, error) {
+	common := abstractZonesCache{zonesTTL: c.zonesTTL, logger: c.logger, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater,
+		incrementalUpdater: incrementalUpdater, zoneTrigger: c.zoneTrigger}
+	if c.disableZoneStateCache {
+		return &onlyZonesCache{abstractZonesCache: common}, nil
+	}
+	return newDefaultZoneCache(c.zoneStates, common, metrics)
+}
+
 // ZoneCacheType is the zone cache type.
 type ZoneCacheType int
 
@@ -67,6 +89,10 @@ const (
 	CacheZonesOnly ZoneCacheType = iota
 	// CacheZoneState caches both zones of the account and the zone states as needed.
 	CacheZoneState
+	// CacheSharedZoneState caches zones and zone states like CacheZoneState, but shares the zones
+	// between all accounts using the same credentials. It must only be used if the zones of an account
+	// only depend on its credentials and not on the provider config.
+	CacheSharedZoneState
 )
 
 func NewTestZoneCacheFactory(zonesTTL, stateTTL time.Duration) *ZoneCacheFactory {
@@ -76,17 +102,30 @@ func NewTestZoneCacheFactory(zonesTTL, stateTTL time.Duration) *ZoneCacheFactory
 	}
 }
 
-type ZoneCacheZoneUpdater func(cache ZoneCache) (DNSHostedZones, error)
+type ZoneCacheZoneUpdater func(ctx context.Context, cache ZoneCache) (DNSHostedZones, error)
 
-type ZoneCacheStateUpdater func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error)
+type ZoneCacheStateUpdater func(ctx context.Context, zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error)
+
+// IncrementalZoneStateUpdater is implemented by handlers of providers exposing a change log for hosted zones.
+type IncrementalZoneStateUpdater interface {
+	// GetSyncMarker returns a marker for the current revision of the zone. It is called before a full
+	// synchronization of the zone state.
+	GetSyncMarker(ctx context.Context, zone DNSHostedZone) (string, error)
+	// UpdateZoneState applies all changes since the given marker to the zone state and returns the new marker.
+	// If an error is returned, the zone state is synchronized completely.
+	UpdateZoneState(ctx context.Context, zone DNSHostedZone, state DNSZoneState, marker string) (string, error)
+}
 
 type ZoneCache interface {
-	GetZones() (DNSHostedZones, error)
-	GetZoneState(zone DNSHostedZone) (DNSZoneState, error)
+	GetZones(ctx context.Context) (DNSHostedZones, error)
+	GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error)
 	ApplyRequests(logctx logger.LogContext, err error, zone DNSHostedZone, reqs []*ChangeRequest)
 	ForwardedDomainsCache() ForwardedDomainsCache
 	Release()
 	ReportZoneStateConflict(zone DNSHostedZone, err error) bool
+	// InvalidateZoneState enforces the revalidation of the cached zone state and triggers
+	// the reconciliation of the zone, e.g. on a change notification of the provider.
+	InvalidateZoneState(zoneID dns.ZoneID)
 }
 
 type ForwardedDomainsCache interface {
@@ -135,6 +174,15 @@ type abstractZonesCache struct {
 	zonesNext    time.Time
 	zonesUpdater ZoneCacheZoneUpdater
 	stateUpdater ZoneCacheStateUpdater
+
+	incrementalUpdater IncrementalZoneStateUpdater
+	zoneTrigger        func(zoneid dns.ZoneID)
+}
+
+func (c *abstractZonesCache) triggerZone(zoneID dns.ZoneID) {
+	if c.zoneTrigger != nil {
+		c.zoneTrigger(zoneID)
+	}
 }
 
 type onlyZonesCache struct {
@@ -145,13 +193,13 @@ type onlyZonesCache struct {
 
 var _ ZoneCache = &onlyZonesCache{}
 
-func (c *onlyZonesCache) GetZones() (DNSHostedZones, error) {
-	zones, err := c.zonesUpdater(c)
+func (c *onlyZonesCache) GetZones(ctx context.Context) (DNSHostedZones, error) {
+	zones, err := c.zonesUpdater(ctx, c)
 	return zones, err
 }
 
-func (c *onlyZonesCache) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
-	state, err := c.stateUpdater(zone, c)
+func (c *onlyZonesCache) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	state, err := c.stateUpdater(ctx, zone, c)
 	return state, err
 }
 
@@ -171,6 +219,11 @@ func (c *onlyZonesCache) ReportZoneStateConflict(zone DNSHostedZone, err error)
 	return false
 }
 
+func (c *onlyZonesCache) InvalidateZoneState(zoneID dns.ZoneID) {
+	metrics.AddZoneCacheInvalidation(zoneID)
+	c.triggerZone(zoneID)
+}
+
 func (c *onlyZonesCache) Release() {
 }
 
@@ -180,58 +233,101 @@ type defaultZoneCache struct {
 	logger     logger.LogContext
 	metrics    Metrics
 	zoneStates *zoneStates
+	// shared are the zones shared with the caches of other accounts using the same credentials
+	shared *sharedZones
 
 	backoffOnError time.Duration
 }
 
 var _ ZoneCache = &defaultZoneCache{}
 
+// zoneListMetrics is implemented by the metrics of an account to report the lookups
+// of the hosted zones in the cache and the backoff after errors.
+type zoneListMetrics interface {
+	AddZoneListCacheLookup(hit bool)
+	ReportZoneListBackoff(backoff time.Duration)
+}
+
 func newDefaultZoneCache(zoneStates *zoneStates, common abstractZonesCache, metrics Metrics) (*defaultZoneCache, error) {
 	cache := &defaultZoneCache{abstractZonesCache: common, logger: common.logger, metrics: metrics, zoneStates: zoneStates}
 	return cache, nil
 }
 
-func (c *defaultZoneCache) GetZones() (DNSHostedZones, error) {
+func (c *defaultZoneCache) GetZones(ctx context.Context) (DNSHostedZones, error) {
+	if c.shared != nil {
+		return c.getSharedZones(ctx)
+	}
 	c.lock.Lock()
 	defer c.lock.Unlock()
 	if time.Now().After(c.zonesNext) {
-		c.zones, c.zonesErr = c.zonesUpdater(c)
+		c.zones, c.zonesErr = c.zonesUpdater(ctx, c)
 		updateTime := time.Now()
 		if c.zonesErr != nil {
 			// if getzones fails, don't wait zonesTTL, but use an exponential backoff
 			// to recover fast from temporary failures like throttling, network problems...
-			backoff := c.nextBackoff()
-			c.zonesNext = updateTime.Add(backoff)
+			c.backoffOnError = nextZonesBackoff(c.backoffOnError, c.zonesTTL)
+			c.zonesNext = updateTime.Add(c.backoffOnError)
 		} else {
-			c.clearBackoff()
+			c.backoffOnError = 0
 			c.zonesNext = updateTime.Add(c.zonesTTL)
 		}
 		c.zoneStates.UpdateUsedZones(c, toSortedZoneIDs(c.zones))
+		c.reportZoneListLookup(false, c.backoffOnError)
 	} else {
 		c.metrics.AddGenericRequests(M_CACHED_GETZONES, 1)
+		c.reportZoneListLookup(true, c.backoffOnError)
 	}
 	return c.zones, c.zonesErr
 }
 
-func (c *defaultZoneCache) nextBackoff() time.Duration {
-	next := c.backoffOnError*5/4 + 2*time.Second
-	maxBackoff := c.zonesTTL / 4
+// getSharedZones returns the zones shared by all accounts with the same credentials.
+// They are only fetched by the first cache requesting them after expiration.
+func (c *defaultZoneCache) getSharedZones(ctx context.Context) (DNSHostedZones, error) {
+	s := c.shared
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if time.Now().After(s.zonesNext) {
+		s.zones, s.zonesErr = c.zonesUpdater(ctx, c)
+		updateTime := time.Now()
+		if s.zonesErr != nil {
+			s.backoffOnError = nextZonesBackoff(s.backoffOnError, c.zonesTTL)
+			s.zonesNext = updateTime.Add(s.backoffOnError)
+		} else {
+			s.backoffOnError = 0
+			s.zonesNext = updateTime.Add(c.zonesTTL)
+		}
+		c.reportZoneListLookup(false, s.backoffOnError)
+	} else {
+		c.metrics.AddGenericRequests(M_CACHED_GETZONES, 1)
+		// the backoff is reported for every account, as it is only updated by the account fetching the zones
+		c.reportZoneListLookup(true, s.backoffOnError)
+	}
+	c.zoneStates.UpdateUsedZones(c, toSortedZoneIDs(s.zones))
+	return s.zones, s.zonesErr
+}
+
+func (c *defaultZoneCache) reportZoneListLookup(hit bool, backoff time.Duration) {
+	if m, ok := c.metrics.(zoneListMetrics); ok {
+		m.AddZoneListCacheLookup(hit)
+		m.ReportZoneListBackoff(backoff)
+	}
+}
+
+func nextZonesBackoff(current, zonesTTL time.Duration) time.Duration {
+	next := current*5/4 + 2*time.Second
+	maxBackoff := zonesTTL / 4
 	if next > maxBackoff {
 		next = maxBackoff
 	}
-	c.backoffOnError = next
 	return next
 }
 
-func (c *defaultZoneCache) clearBackoff() {
-	c.backoffOnError = 0
-}
-
-func (c *defaultZoneCache) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
-	state, cached, err := c.zoneStates.GetZoneState(zone, c)
+func (c *defaultZoneCache) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	state, cached, err := c.zoneStates.GetZoneState(ctx, zone, c)
 	if cached {
 		c.metrics.AddZoneRequests(zone.Id().ID, M_CACHED_GETZONESTATE, 1)
 	}
+	metrics.AddZoneCacheLookup(zone.Id(), cached)
 	return state, err
 }
 
@@ -239,6 +335,12 @@ func (c *defaultZoneCache) ReportZoneStateConflict(zone DNSHostedZone, err error
 	return c.zoneStates.ReportZoneStateConflict(zone.Id(), err)
 }
 
+func (c *defaultZoneCache) InvalidateZoneState(zoneID dns.ZoneID) {
+	c.zoneStates.MarkForVerification(zoneID)
+	metrics.AddZoneCacheInvalidation(zoneID)
+	c.triggerZone(zoneID)
+}
+
 func (c *defaultZoneCache) cleanZoneState(zoneID dns.ZoneID) {
 	c.zoneStates.CleanZoneState(zoneID)
 }
@@ -263,12 +365,35 @@ func (c *defaultZoneCache) ForwardedDomainsCache() ForwardedDomainsCache {
 
 func (c *defaultZoneCache) Release() {
 	c.zoneStates.UpdateUsedZones(c, nil)
+	if c.shared != nil {
+		c.zoneStates.releaseSharedZones(c.shared)
+	}
+}
+
+// sharedZones are the zones shared by the caches of all accounts using the same credentials.
+type sharedZones struct {
+	lock           sync.Mutex
+	key            string
+	clients        int
+	zones          DNSHostedZones
+	zonesErr       error
+	zonesNext      time.Time
+	backoffOnError time.Duration
 }
 
 type zoneStateProxy struct {
 	lock            sync.Mutex
 	lastUpdateStart time.Time
 	lastUpdateEnd   time.Time
+	// lastFullSync is the start time of the last full synchronization of the zone state
+	lastFullSync time.Time
+	// syncMarker is the marker of the zone revision used for incremental synchronization
+	syncMarker string
+	// verify is set if the next access must revalidate the zone state with the provider
+	verify bool
+	// conflictRetries are the DNS names granted a retry after the zone state has been invalidated
+	// because of an owner conflict, until the zone state is fetched again
+	conflictRetries utils.StringSet
 }
 
 type zoneStates struct {
@@ -277,7 +402,22 @@ type zoneStates struct {
 	inMemory              *InMemory
 	proxies               map[dns.ZoneID]*zoneStateProxy
 	usedZones             map[ZoneCache][]dns.ZoneID
+	sharedZones           map[string]*sharedZones
 	forwardedDomainsCache *forwardedDomainsCacheImpl
+	// history are the previous values of the changed record sets
+	history *recordHistory
+
+	persistence ZoneStatePersistence
+	// persistenceMaxAge is the maximum age of a persisted zone state to be reused
+	persistenceMaxAge time.Duration
+	// fullSyncPeriod is the period of full synchronizations for zone caches using incremental synchronization
+	fullSyncPeriod time.Duration
+	// verificationDriven disables the expiration of zone states by their ttl,
+	// they are only revalidated if marked by the verification loop
+	verificationDriven bool
+	// maxStale is the maximum duration after the expiration an expired zone state
+	// is served if the revalidation fails
+	maxStale time.Duration
 }
 
 func newZoneStates(stateTTLGetter StateTTLGetter) *zoneStates {
@@ -286,7 +426,88 @@ func newZoneStates(stateTTLGetter StateTTLGetter) *zoneStates {
 		stateTTLGetter:        stateTTLGetter,
 		proxies:               map[dns.ZoneID]*zoneStateProxy{},
 		usedZones:             map[ZoneCache][]dns.ZoneID{},
+		sharedZones:           map[string]*sharedZones{},
 		forwardedDomainsCache: newForwardedDomainsCacheImpl(),
+		history:               newRecordHistory(),
+		fullSyncPeriod:        30 * time.Minute,
+	}
+}
+
+// SetRecordHistorySize sets the number of previous values kept for every changed record set (0 to disable).
+func (s *zoneStates) SetRecordHistorySize(size int) {
+	s.history.setSize(size)
+}
+
+// SetFullSyncPeriod sets the period of full synchronizations for zones with incremental synchronization.
+func (s *zoneStates) SetFullSyncPeriod(period time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.fullSyncPeriod = period
+}
+
+// SetVerificationDriven switches the revalidation of zone states from ttl based expiration
+// to explicit marking with MarkForVerification.
+func (s *zoneStates) SetVerificationDriven(enabled bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.verificationDriven = enabled
+}
+
+// SetMaxStale sets the maximum duration an expired zone state is served after failed revalidations.
+func (s *zoneStates) SetMaxStale(maxStale time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.maxStale = maxStale
+}
+
+// MarkForVerification enforces the revalidation of the zone state with the provider on next access.
+func (s *zoneStates) MarkForVerification(zoneID dns.ZoneID) {
+	proxy := s.getProxy(zoneID)
+	proxy.lock.Lock()
+	defer proxy.lock.Unlock()
+	proxy.verify = true
+}
+
+func (s *zoneStates) isExpired(proxy *zoneStateProxy, start time.Time, ttl time.Duration) bool {
+	s.lock.Lock()
+	verificationDriven := s.verificationDriven
+	s.lock.Unlock()
+	if proxy.verify || proxy.lastUpdateEnd.IsZero() {
+		return true
+	}
+	return !verificationDriven && start.After(proxy.lastUpdateEnd.Add(ttl))
+}
+
+// EnablePersistence enables storing zone states with the given persistence layer.
+// On a cold start persisted zone states not older than maxAge are reused until the
+// next regular revalidation.
+func (s *zoneStates) EnablePersistence(persistence ZoneStatePersistence, maxAge time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.persistence = persistence
+	s.persistenceMaxAge = maxAge
+}
+
+// acquireSharedZones returns the shared zones for the given credentials hash.
+func (s *zoneStates) acquireSharedZones(credentialsHash string) *sharedZones {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	shared := s.sharedZones[credentialsHash]
+	if shared == nil {
+		shared = &sharedZones{key: credentialsHash}
+		s.sharedZones[credentialsHash] = shared
+	}
+	shared.clients++
+	return shared
+}
+
+// releaseSharedZones releases the shared zones, they are discarded with the last cache using them.
+func (s *zoneStates) releaseSharedZones(shared *sharedZones) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	shared.clients--
+	if shared.clients <= 0 {
+		delete(s.sharedZones, shared.key)
 	}
 }
 
@@ -301,20 +522,49 @@ func (s *zoneStates) getProxy(zoneID dns.ZoneID) *zoneStateProxy {
 	return proxy
 }
 
-func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (DNSZoneState, bool, error) {
+func (s *zoneStates) GetZoneState(ctx context.Context, zone DNSHostedZone, cache *defaultZoneCache) (DNSZoneState, bool, error) {
 	proxy := s.getProxy(zone.Id())
 	proxy.lock.Lock()
 	defer proxy.lock.Unlock()
+	defer reportZoneStateAge(zone.Id(), proxy)
 
 	start := time.Now()
 	ttl := s.stateTTLGetter(zone.Id())
-	if start.After(proxy.lastUpdateEnd.Add(ttl)) {
-		state, err := cache.stateUpdater(zone, cache)
+	if proxy.lastUpdateEnd.IsZero() {
+		s.loadPersistedZoneState(cache.logger, zone, proxy, start, ttl)
+	}
+	if s.isExpired(proxy, start, ttl) {
+		proxy.verify = false
+		if state := s.incrementalUpdate(ctx, zone, proxy, cache, start); state != nil {
+			return state, false, nil
+		}
+		marker := ""
+		if cache.incrementalUpdater != nil {
+			var err error
+			marker, err = cache.incrementalUpdater.GetSyncMarker(ctx, zone)
+			if err != nil {
+				cache.logger.Warnf("cannot get sync marker for zone %s: %s", zone.Id(), err)
+			}
+		}
+		state, err := cache.stateUpdater(ctx, zone, cache)
 		if err == nil {
 			proxy.lastUpdateStart = start
 			proxy.lastUpdateEnd = time.Now()
+			proxy.lastFullSync = start
+			proxy.syncMarker = marker
+			proxy.conflictRetries = nil
 			s.inMemory.SetZone(zone, state)
+			s.storeZoneState(zone.Id(), state, start)
 		} else {
+			if stale := s.staleZoneState(zone, proxy, start, ttl); stale != nil {
+				cache.logger.Warnf("revalidation of zone state %s failed, serving stale state of %s: %s",
+					zone.Id(), proxy.lastUpdateEnd.Format(time.RFC3339), err)
+				metrics.AddZoneCacheStaleServing(zone.Id())
+				return stale, true, nil
+			}
+			if !proxy.lastUpdateEnd.IsZero() {
+				metrics.AddZoneCacheDiscarding(zone.Id())
+			}
 			s.cleanZoneState(zone.Id(), proxy)
 		}
 		return state, false, err
@@ -327,22 +577,116 @@ func (s *zoneStates) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (
 	return state, true, nil
 }
 
+// reportZoneStateAge reports the age of the cached zone state, if there is one.
+func reportZoneStateAge(zoneID dns.ZoneID, proxy *zoneStateProxy) {
+	if proxy.lastUpdateEnd.IsZero() {
+		metrics.DeleteZoneStateAge(zoneID)
+		return
+	}
+	metrics.ReportZoneStateAge(zoneID, time.Since(proxy.lastUpdateEnd))
+}
+
+// staleZoneState returns the expired cached zone state flagged as stale if it has not exceeded the
+// max-stale bound. The zone state stays expired, so the next access retries the revalidation.
+func (s *zoneStates) staleZoneState(zone DNSHostedZone, proxy *zoneStateProxy, now time.Time, ttl time.Duration) DNSZoneState {
+	s.lock.Lock()
+	maxStale := s.maxStale
+	s.lock.Unlock()
+	if maxStale <= 0 || proxy.lastUpdateEnd.IsZero() || now.After(proxy.lastUpdateEnd.Add(ttl+maxStale)) {
+		return nil
+	}
+	state, err := s.inMemory.CloneZoneState(zone)
+	if err != nil {
+		return nil
+	}
+	proxy.verify = true
+	return &DefaultDNSZoneState{sets: state.GetDNSSets(), stale: true}
+}
+
+// incrementalUpdate synchronizes an expired zone state with the changes since the last synchronization.
+// It returns nil if an incremental synchronization is not possible or a full synchronization is due.
+func (s *zoneStates) incrementalUpdate(ctx context.Context, zone DNSHostedZone, proxy *zoneStateProxy, cache *defaultZoneCache, start time.Time) DNSZoneState {
+	if cache.incrementalUpdater == nil || proxy.syncMarker == "" || start.Sub(proxy.lastFullSync) >= s.fullSyncPeriod {
+		return nil
+	}
+	state, err := s.inMemory.CloneZoneState(zone)
+	if err != nil {
+		return nil
+	}
+	marker, err := cache.incrementalUpdater.UpdateZoneState(ctx, zone, state, proxy.syncMarker)
+	if err != nil {
+		cache.logger.Infof("incremental synchronization of zone %s failed, falling back to full synchronization: %s", zone.Id(), err)
+		return nil
+	}
+	cache.metrics.AddZoneRequests(zone.Id().ID, M_INCREMENTAL_GETZONESTATE, 1)
+	proxy.lastUpdateStart = start
+	proxy.lastUpdateEnd = time.Now()
+	proxy.syncMarker = marker
+	s.inMemory.SetZone(zone, state)
+	s.storeZoneState(zone.Id(), state, start)
+	return state
+}
+
+// loadPersistedZoneState restores a persisted zone state on a cold start.
+// The restored state is revalidated lazily once the zone state ttl has expired since its persisted update.
+func (s *zoneStates) loadPersistedZoneState(logger logger.LogContext, zone DNSHostedZone, proxy *zoneStateProxy, now time.Time, ttl time.Duration) {
+	if s.persistence == nil {
+		return
+	}
+	state, updated, err := s.persistence.Load(zone.Id())
+	if err != nil {
+		logger.Warnf("cannot load persisted zone state for %s: %s", zone.Id(), err)
+		return
+	}
+	if state == nil {
+		return
+	}
+	if now.Sub(updated) > s.persistenceMaxAge {
+		logger.Infof("persisted zone state for %s too old (%s)", zone.Id(), updated.Format(time.RFC3339))
+		return
+	}
+	logger.Infof("restored persisted zone state for %s (%s)", zone.Id(), updated.Format(time.RFC3339))
+	metrics.AddZoneCacheRestored(zone.Id())
+	s.inMemory.SetZone(zone, state)
+	proxy.lastUpdateStart = updated
+	proxy.lastUpdateEnd = updated
+}
+
+func (s *zoneStates) storeZoneState(zoneID dns.ZoneID, state DNSZoneState, updated time.Time) {
+	if s.persistence == nil {
+		return
+	}
+	if err := s.persistence.Store(zoneID, state, updated); err != nil {
+		logger.Warnf("cannot persist zone state for %s: %s", zoneID, err)
+	}
+}
+
 func (s *zoneStates) ReportZoneStateConflict(zoneID dns.ZoneID, err error) bool {
 	proxy := s.getProxy(zoneID)
 	proxy.lock.Lock()
 	defer proxy.lock.Unlock()
 
-	if !proxy.lastUpdateStart.IsZero() {
-		ownerConflict, ok := err.(*errors.AlreadyBusyForOwner)
-		if ok {
-			if ownerConflict.EntryCreatedAt.After(proxy.lastUpdateStart) {
-				// If a DNSEntry ownership is moved to another DNS controller manager (e.g. shoot recreation on another seed)
-				// the zone cache may have stale owner information. In this case the cache is invalidated
-				// if the entry is newer than the last cache refresh.
-				s.cleanZoneState(zoneID, proxy)
-				return true
-			}
+	ownerConflict, ok := err.(*errors.AlreadyBusyForOwner)
+	if !ok {
+		return false
+	}
+	if proxy.lastUpdateStart.IsZero() {
+		// The zone state has already been invalidated by the conflict of another entry after it has been
+		// provided for the current reconciliation. The entry gets a retry with the next fetch of the zone state,
+		// too, instead of failing with the same stale owner information.
+		if len(proxy.conflictRetries) > 0 {
+			proxy.conflictRetries.Add(ownerConflict.DNSName)
+			return true
 		}
+		return false
+	}
+	if ownerConflict.EntryCreatedAt.After(proxy.lastUpdateStart) {
+		// If a DNSEntry ownership is moved to another DNS controller manager (e.g. shoot recreation on another seed)
+		// the zone cache may have stale owner information. In this case the cache is invalidated
+		// if the entry is newer than the last cache refresh.
+		s.cleanZoneState(zoneID, proxy)
+		proxy.conflictRetries = utils.NewStringSet(ownerConflict.DNSName)
+		return true
 	}
 	return false
 }
@@ -352,6 +696,11 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 	proxy.lock.Lock()
 	defer proxy.lock.Unlock()
 
+	now := time.Now()
+	for _, req := range reqs {
+		s.history.record(zoneID, req, now)
+	}
+
 	var err error
 	nullMetrics := &NullMetrics{}
 	for _, req := range reqs {
@@ -363,6 +712,15 @@ func (s *zoneStates) ExecuteRequests(zoneID dns.ZoneID, reqs []*ChangeRequest) {
 
 	if err != nil {
 		s.cleanZoneState(zoneID, proxy)
+		metrics.AddZoneCacheDiscarding(zoneID)
+		return
+	}
+	if s.persistence != nil {
+		if zone := s.inMemory.FindHostedZone(zoneID); zone != nil {
+			if state, err := s.inMemory.CloneZoneState(zone); err == nil {
+				s.storeZoneState(zoneID, state, proxy.lastUpdateStart)
+			}
+		}
 	}
 }
 
@@ -380,6 +738,11 @@ func (s *zoneStates) CleanZoneState(zoneID dns.ZoneID) {
 
 func (s *zoneStates) cleanZoneState(zoneID dns.ZoneID, proxy *zoneStateProxy) {
 	s.inMemory.DeleteZone(zoneID)
+	if s.persistence != nil {
+		if err := s.persistence.Delete(zoneID); err != nil {
+			logger.Warnf("cannot delete persisted zone state for %s: %s", zoneID, err)
+		}
+	}
 	if s.forwardedDomainsCache != nil {
 		s.forwardedDomainsCache.DeleteZone(zoneID)
 	}
@@ -387,6 +750,9 @@ func (s *zoneStates) cleanZoneState(zoneID dns.ZoneID, proxy *zoneStateProxy) {
 		var zero time.Time
 		proxy.lastUpdateStart = zero
 		proxy.lastUpdateEnd = zero
+		proxy.lastFullSync = zero
+		proxy.syncMarker = ""
+		proxy.verify = false
 	}
 }
 
@@ -422,6 +788,8 @@ func (s *zoneStates) UpdateUsedZones(cache ZoneCache, zoneids []dns.ZoneID) {
 	for id := range s.proxies {
 		if _, ok := allUsed[id]; !ok {
 			delete(s.proxies, id)
+			s.history.deleteZone(id)
+			metrics.DeleteZoneStateAge(id)
 		}
 	}
 }
@@ -0,0 +1,322 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type testIncrementalUpdater struct {
+	updates int
+	fail    bool
+}
+
+func (u *testIncrementalUpdater) GetSyncMarker(ctx context.Context, zone DNSHostedZone) (string, error) {
+	return "0", nil
+}
+
+func (u *testIncrementalUpdater) UpdateZoneState(ctx context.Context, zone DNSHostedZone, state DNSZoneState, marker string) (string, error) {
+	if u.fail {
+		return "", fmt.Errorf("failed")
+	}
+	u.updates++
+	state.GetDNSSets().AddRecordSetFromProvider(fmt.Sprintf("r%d.example.com", u.updates), dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+	return fmt.Sprintf("%d", u.updates), nil
+}
+
+var _ = ginkgov2.Describe("Incremental zone cache", func() {
+	var (
+		factory     *ZoneCacheFactory
+		cache       ZoneCache
+		incremental *testIncrementalUpdater
+		fullSyncs   int
+		fullSyncErr error
+	)
+	zone := NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)
+
+	ginkgov2.BeforeEach(func() {
+		fullSyncs = 0
+		fullSyncErr = nil
+		incremental = &testIncrementalUpdater{}
+		factory = NewTestZoneCacheFactory(time.Minute, 0)
+		factory.logger = logger.New()
+		stateUpdater := func(ctx context.Context, zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+			fullSyncs++
+			if fullSyncErr != nil {
+				return nil, fullSyncErr
+			}
+			return NewDNSZoneState(dns.DNSSets{}), nil
+		}
+		var err error
+		cache, err = factory.CreateIncrementalZoneCache(&NullMetrics{}, nil, stateUpdater, incremental)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.It("synchronizes incrementally after a full synchronization", func() {
+		state, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullSyncs).To(Equal(1))
+		Expect(state.GetDNSSets()).To(HaveLen(0))
+
+		state, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullSyncs).To(Equal(1))
+		Expect(incremental.updates).To(Equal(1))
+		Expect(state.GetDNSSets()).To(HaveKey("r1.example.com"))
+
+		state, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()).To(HaveLen(2))
+	})
+
+	ginkgov2.It("falls back to full synchronization", func() {
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		incremental.fail = true
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullSyncs).To(Equal(2))
+	})
+
+	ginkgov2.It("synchronizes completely after the full sync period", func() {
+		factory.zoneStates.SetFullSyncPeriod(0)
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullSyncs).To(Equal(2))
+		Expect(incremental.updates).To(Equal(0))
+	})
+
+	ginkgov2.It("serves stale zone states if revalidation fails", func() {
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		incremental.fail = true
+		fullSyncErr = fmt.Errorf("throttled")
+
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).To(HaveOccurred())
+		fullSyncErr = nil
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+
+		factory.zoneStates.SetMaxStale(time.Minute)
+		fullSyncErr = fmt.Errorf("throttled")
+		state, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsStaleZoneState(state)).To(BeTrue())
+
+		fullSyncErr = nil
+		state, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsStaleZoneState(state)).To(BeFalse())
+		Expect(fullSyncs).To(Equal(5))
+	})
+
+	ginkgov2.It("revalidates only marked zone states if verification driven", func() {
+		factory.zoneStates.SetVerificationDriven(true)
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fullSyncs).To(Equal(1))
+		Expect(incremental.updates).To(Equal(0))
+
+		factory.zoneStates.MarkForVerification(zone.Id())
+		state, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incremental.updates).To(Equal(1))
+		Expect(state.GetDNSSets()).To(HaveKey("r1.example.com"))
+
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incremental.updates).To(Equal(1))
+	})
+
+	ginkgov2.It("invalidates zone states on change notifications", func() {
+		triggered := []dns.ZoneID{}
+		factory.zoneStates.SetVerificationDriven(true)
+		cache.(*defaultZoneCache).zoneTrigger = func(zoneid dns.ZoneID) { triggered = append(triggered, zoneid) }
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+
+		processZoneChangeNotifications(logger.New(), "test", cache, []ZoneChangeNotification{
+			{ZoneID: "Z1", Principal: "self"},
+			{ZoneID: "Z2", Principal: "other"},
+			{ZoneID: "Z1", Principal: "other"},
+			{ZoneID: "Z1", Principal: "other"},
+		}, "self")
+		Expect(triggered).To(Equal([]dns.ZoneID{dns.NewZoneID("test", "Z2"), zone.Id()}))
+
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(incremental.updates).To(Equal(1))
+	})
+})
+
+var _ = ginkgov2.Describe("Zone state TTL jitter", func() {
+	ginkgov2.It("spreads the TTL of zones stable within the jitter range", func() {
+		ttl := 10 * time.Minute
+		seen := map[time.Duration]bool{}
+		for i := 0; i < 20; i++ {
+			zoneid := dns.NewZoneID("test", fmt.Sprintf("zone%d", i))
+			jittered := applyStateTTLJitter(zoneid, ttl, 10)
+			Expect(jittered).To(BeNumerically(">=", 9*time.Minute))
+			Expect(jittered).To(BeNumerically("<=", 11*time.Minute))
+			Expect(applyStateTTLJitter(zoneid, ttl, 10)).To(Equal(jittered))
+			seen[jittered] = true
+		}
+		Expect(len(seen)).To(BeNumerically(">", 1))
+	})
+
+	ginkgov2.It("keeps the TTL without jitter", func() {
+		Expect(applyStateTTLJitter(dns.NewZoneID("test", "zone"), time.Minute, 0)).To(Equal(time.Minute))
+	})
+})
+
+var _ = ginkgov2.Describe("Shared zone cache", func() {
+	ginkgov2.It("fetches the zones once for accounts with the same credentials", func() {
+		factory := NewTestZoneCacheFactory(time.Minute, time.Minute)
+		factory.logger = logger.New()
+		factory.credentialsHash = "creds"
+		calls := 0
+		zonesUpdater := func(ctx context.Context, cache ZoneCache) (DNSHostedZones, error) {
+			calls++
+			return DNSHostedZones{NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)}, nil
+		}
+		cache1, err := factory.CreateZoneCache(CacheSharedZoneState, &NullMetrics{}, zonesUpdater, nil)
+		Expect(err).NotTo(HaveOccurred())
+		cache2, err := factory.CreateZoneCache(CacheSharedZoneState, &NullMetrics{}, zonesUpdater, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		zones, err := cache1.GetZones(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(HaveLen(1))
+		zones, err = cache2.GetZones(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(HaveLen(1))
+		Expect(calls).To(Equal(1))
+
+		cache1.Release()
+		cache2.Release()
+		Expect(factory.zoneStates.sharedZones).To(BeEmpty())
+	})
+})
+
+type testZoneListMetrics struct {
+	NullMetrics
+	hits    int
+	misses  int
+	backoff time.Duration
+}
+
+func (m *testZoneListMetrics) AddZoneListCacheLookup(hit bool) {
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func (m *testZoneListMetrics) ReportZoneListBackoff(backoff time.Duration) {
+	m.backoff = backoff
+}
+
+var _ = ginkgov2.Describe("Zone list cache metrics", func() {
+	ginkgov2.It("reports lookups and the backoff after errors", func() {
+		factory := NewTestZoneCacheFactory(time.Hour, time.Minute)
+		factory.logger = logger.New()
+		fail := true
+		zonesUpdater := func(ctx context.Context, cache ZoneCache) (DNSHostedZones, error) {
+			if fail {
+				return nil, fmt.Errorf("failed")
+			}
+			return DNSHostedZones{NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)}, nil
+		}
+		m := &testZoneListMetrics{}
+		cache, err := factory.CreateZoneCache(CacheZoneState, m, zonesUpdater, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = cache.GetZones(context.TODO())
+		Expect(err).To(HaveOccurred())
+		Expect(m.misses).To(Equal(1))
+		Expect(m.backoff).To(Equal(2 * time.Second))
+
+		_, err = cache.GetZones(context.TODO())
+		Expect(err).To(HaveOccurred())
+		Expect(m.hits).To(Equal(1))
+		Expect(m.backoff).To(Equal(2 * time.Second))
+
+		fail = false
+		cache.(*defaultZoneCache).zonesNext = time.Time{}
+		_, err = cache.GetZones(context.TODO())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.misses).To(Equal(2))
+		Expect(m.backoff).To(BeZero())
+		cache.Release()
+	})
+})
+
+var _ = ginkgov2.Describe("Owner conflict retries", func() {
+	zone := NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)
+
+	ginkgov2.It("grants a retry with a refreshed zone state to all conflicting entries", func() {
+		states := newZoneStates(func(id dns.ZoneID) time.Duration { return time.Minute })
+		fetches := 0
+		cache := &defaultZoneCache{
+			abstractZonesCache: abstractZonesCache{
+				logger: logger.New(),
+				stateUpdater: func(ctx context.Context, zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+					fetches++
+					return NewDNSZoneState(dns.DNSSets{}), nil
+				},
+			},
+			logger:     logger.New(),
+			metrics:    &NullMetrics{},
+			zoneStates: states,
+		}
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+
+		created := time.Now().Add(time.Second)
+		conflict := func(name string, createdAt time.Time) error {
+			return &perrs.AlreadyBusyForOwner{DNSName: name, EntryCreatedAt: createdAt, Owner: "other"}
+		}
+		Expect(cache.ReportZoneStateConflict(zone, conflict("a.example.com", created))).To(BeTrue())
+		Expect(cache.ReportZoneStateConflict(zone, conflict("b.example.com", created.Add(-time.Hour)))).To(BeTrue())
+
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(2))
+		Expect(cache.ReportZoneStateConflict(zone, conflict("a.example.com", created.Add(-time.Hour)))).To(BeFalse())
+		Expect(cache.ReportZoneStateConflict(zone, conflict("b.example.com", created.Add(-time.Hour)))).To(BeFalse())
+	})
+
+	ginkgov2.It("does not grant retries without a fetched zone state", func() {
+		states := newZoneStates(func(id dns.ZoneID) time.Duration { return time.Minute })
+		Expect(states.ReportZoneStateConflict(zone.Id(), &perrs.AlreadyBusyForOwner{DNSName: "a.example.com", EntryCreatedAt: time.Now()})).To(BeFalse())
+	})
+})
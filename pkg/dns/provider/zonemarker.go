@@ -0,0 +1,127 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ZONE_MARKER_MANAGED_BY identifies the dns controller manager in zone ownership markers
+const ZONE_MARKER_MANAGED_BY = "dns-controller-manager"
+
+// ZoneOwnershipMarker identifies the controller and owner managing a hosted zone.
+type ZoneOwnershipMarker struct {
+	// ManagedBy identifies the managing controller
+	ManagedBy string
+	// Owner is the owner identifier of the managing controller
+	Owner string
+}
+
+// ForeignZoneOwnershipError is returned by MarkZoneOwnership if the hosted zone
+// is already marked for another owner. The existing marker is kept.
+type ForeignZoneOwnershipError struct {
+	// Marker is the existing marker of the hosted zone
+	Marker ZoneOwnershipMarker
+}
+
+func (e *ForeignZoneOwnershipError) Error() string {
+	return fmt.Sprintf("zone already marked as managed by %s for owner %s", e.Marker.ManagedBy, e.Marker.Owner)
+}
+
+// ZoneOwnershipAccess is an optional interface of a DNSHandler
+// writing ownership markers into the zone-level metadata of hosted zones.
+type ZoneOwnershipAccess interface {
+	// MarkZoneOwnership writes the ownership marker into the metadata of the hosted zone
+	// if it is missing or outdated and returns true if the metadata has been modified.
+	// If the hosted zone is marked for another owner, a ForeignZoneOwnershipError is returned.
+	MarkZoneOwnership(ctx context.Context, zone DNSHostedZone, marker ZoneOwnershipMarker) (bool, error)
+}
+
+type providerZoneOwnership struct {
+	provider *dnsProviderVersion
+	access   ZoneOwnershipAccess
+	zones    DNSHostedZones
+}
+
+func (this *state) getProviderZoneOwnership() []*providerZoneOwnership {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	var result []*providerZoneOwnership
+	for _, p := range this.providers {
+		if p.IsReadOnly() || p.IsDryRun() {
+			continue
+		}
+		access := p.GetZoneOwnershipAccess()
+		if access == nil {
+			continue
+		}
+		r := &providerZoneOwnership{provider: p, access: access}
+		for _, z := range p.zones {
+			if p.IncludesZone(z.Id()) {
+				r.zones = append(r.zones, z)
+			}
+		}
+		result = append(result, r)
+	}
+	return result
+}
+
+// UpdateZoneOwnershipMarkers writes the ownership marker into the zone-level metadata
+// of all included zones of the providers supporting it.
+func (this *state) UpdateZoneOwnershipMarkers(ctx context.Context, logger logger.LogContext) {
+	if this.config.Dryrun || IsWriteFrozen() {
+		return
+	}
+	marker := ZoneOwnershipMarker{ManagedBy: ZONE_MARKER_MANAGED_BY, Owner: this.config.Ident}
+	done := map[dns.ZoneID]struct{}{}
+	for _, p := range this.getProviderZoneOwnership() {
+		for _, zone := range p.zones {
+			if _, ok := done[zone.Id()]; ok {
+				continue
+			}
+			done[zone.Id()] = struct{}{}
+			mod, err := p.access.MarkZoneOwnership(ctx, zone, marker)
+			var foreign *ForeignZoneOwnershipError
+			if errors.As(err, &foreign) {
+				logger.Warnf("zone %s (%s) of provider %s is already marked for owner %s, keeping the marker",
+					zone.Id(), zone.Domain(), p.provider.ObjectName(), foreign.Marker.Owner)
+				continue
+			}
+			if err != nil {
+				logger.Warnf("cannot mark ownership of zone %s (%s) by provider %s: %s", zone.Id(), zone.Domain(), p.provider.ObjectName(), err)
+				continue
+			}
+			if mod {
+				logger.Infof("marked ownership of zone %s (%s) for owner %s", zone.Id(), zone.Domain(), marker.Owner)
+			}
+		}
+	}
+}
+
+func (this *dnsProviderVersion) GetZoneOwnershipAccess() ZoneOwnershipAccess {
+	if this.account == nil {
+		return nil
+	}
+	h, _ := this.account.handler.(ZoneOwnershipAccess)
+	return h
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type zoneMarkerTestHandler struct {
+	updateTestHandler
+	owners map[string]string
+	err    error
+	marked []string
+}
+
+func (h *zoneMarkerTestHandler) MarkZoneOwnership(_ context.Context, zone DNSHostedZone, marker ZoneOwnershipMarker) (bool, error) {
+	h.marked = append(h.marked, zone.Id().ID)
+	if h.err != nil {
+		return false, h.err
+	}
+	if owner := h.owners[zone.Id().ID]; owner != "" && owner != marker.Owner {
+		return false, &ForeignZoneOwnershipError{Marker: ZoneOwnershipMarker{ManagedBy: ZONE_MARKER_MANAGED_BY, Owner: owner}}
+	}
+	if h.owners[zone.Id().ID] == marker.Owner {
+		return false, nil
+	}
+	h.owners[zone.Id().ID] = marker.Owner
+	return true, nil
+}
+
+var _ = ginkgov2.Describe("Zone ownership markers", func() {
+	var (
+		st      *state
+		handler *zoneMarkerTestHandler
+	)
+
+	addProvider := func(name string, zones ...string) *dnsProviderVersion {
+		p := &dnsProviderVersion{
+			object:         newTestProvider(name, ""),
+			account:        &DNSAccount{handler: handler},
+			included_zones: utils.StringSet{},
+		}
+		for _, id := range zones {
+			p.zones = append(p.zones, NewDNSHostedZone("test", id, id+".example.com", "", nil, false))
+			p.included_zones.Add(id)
+		}
+		st.providers[p.ObjectName()] = p
+		return p
+	}
+
+	ginkgov2.BeforeEach(func() {
+		st = &state{
+			config:    Config{Ident: "me"},
+			providers: map[resources.ObjectName]*dnsProviderVersion{},
+		}
+		handler = &zoneMarkerTestHandler{owners: map[string]string{}}
+	})
+
+	ginkgov2.It("marks each included zone once", func() {
+		addProvider("p1", "z1", "z2")
+		addProvider("p2", "z2", "z3")
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(ConsistOf("z1", "z2", "z3"))
+		Expect(handler.owners).To(Equal(map[string]string{"z1": "me", "z2": "me", "z3": "me"}))
+	})
+
+	ginkgov2.It("skips excluded zones", func() {
+		p := addProvider("p1", "z1", "z2")
+		p.included_zones = utils.NewStringSet("z1")
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(ConsistOf("z1"))
+	})
+
+	ginkgov2.It("skips read-only and dry-run providers", func() {
+		addProvider("p1", "z1").readOnly = true
+		addProvider("p2", "z2").dryRun = true
+		addProvider("p3", "z3")
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(ConsistOf("z3"))
+	})
+
+	ginkgov2.It("skips providers without zone ownership support", func() {
+		p := addProvider("p1", "z1")
+		p.account = &DNSAccount{handler: &updateTestHandler{}}
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(BeEmpty())
+	})
+
+	ginkgov2.It("writes no markers in dry-run mode", func() {
+		addProvider("p1", "z1")
+		st.config.Dryrun = true
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(BeEmpty())
+	})
+
+	ginkgov2.It("writes no markers during a write freeze", func() {
+		addProvider("p1", "z1")
+		theWriteFreeze.lock.Lock()
+		saved := theWriteFreeze.frozen
+		theWriteFreeze.frozen = true
+		theWriteFreeze.lock.Unlock()
+		defer func() {
+			theWriteFreeze.lock.Lock()
+			theWriteFreeze.frozen = saved
+			theWriteFreeze.lock.Unlock()
+		}()
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(BeEmpty())
+	})
+
+	ginkgov2.It("keeps the marker of zones marked for another owner", func() {
+		addProvider("p1", "z1", "z2")
+		handler.owners["z1"] = "other"
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(ConsistOf("z1", "z2"))
+		Expect(handler.owners).To(Equal(map[string]string{"z1": "other", "z2": "me"}))
+	})
+
+	ginkgov2.It("continues with the next zone on errors", func() {
+		addProvider("p1", "z1", "z2")
+		handler.err = fmt.Errorf("access denied")
+
+		st.UpdateZoneOwnershipMarkers(context.TODO(), logger.New())
+		Expect(handler.marked).To(ConsistOf("z1", "z2"))
+		Expect(handler.owners).To(BeEmpty())
+	})
+})
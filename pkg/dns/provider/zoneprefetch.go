@@ -0,0 +1,151 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+// prefetchZoneStates fetches the states of the included zones of the provider in parallel
+// if the states are cached. This shortens the cold start for accounts with many zones,
+// as the zones are otherwise reconciled one after another. During the setup the prefetching
+// is deferred until the zones with pending entries are known (see prioritizeZones).
+func (this *dnsProviderVersion) prefetchZoneStates(logger logger.LogContext) {
+	if !this.state.config.ZoneStateCaching {
+		return
+	}
+	if ok, _ := this.state.GetHandlerFactory().SupportZoneStateCache(this.TypeCode()); !ok {
+		return
+	}
+	var zones DNSHostedZones
+	for _, z := range this.zones {
+		if z.Id().ProviderType == this.TypeCode() && this.included_zones.Contains(z.Id().ID) {
+			zones = append(zones, z)
+		}
+	}
+	if !this.state.initialized && this.state.setup != nil {
+		this.state.setup.AddPrefetch(&zonePrefetch{account: this.account, logger: logger, zones: zones})
+		return
+	}
+	this.account.prefetchZoneStates(this.state.GetContext().GetContext(), logger, zones, nil)
+}
+
+// prefetchZoneStates fetches the states of zones not yet prefetched for the account in the background.
+// At most zoneStateConcurrency states are fetched at once, the request rate is still governed by
+// the rate limiter of the account. The prefetching stops on the first throttled request, the
+// remaining zones are fetched by their reconciliations.
+// The states of the pending zones are fetched first, the states of the other zones are warmed up
+// one after another afterwards, to leave the request budget to the reconciliations of the pending zones.
+func (this *DNSAccount) prefetchZoneStates(ctx context.Context, logger logger.LogContext, zones DNSHostedZones, pending map[dns.ZoneID]bool) {
+	concurrency := this.zoneStateConcurrency
+	if concurrency <= 1 {
+		return
+	}
+	this.prefetchLock.Lock()
+	var todo DNSHostedZones
+	for _, z := range zones {
+		if !this.prefetched[z.Id()] {
+			todo = append(todo, z)
+		}
+	}
+	if len(todo) < 2 {
+		this.prefetchLock.Unlock()
+		return
+	}
+	for _, z := range todo {
+		this.prefetched[z.Id()] = true
+	}
+	this.prefetchLock.Unlock()
+
+	var priority, warmup DNSHostedZones
+	for _, z := range todo {
+		if pending[z.Id()] {
+			priority = append(priority, z)
+		} else {
+			warmup = append(warmup, z)
+		}
+	}
+	go func() {
+		if len(priority) > 0 {
+			if !this.fetchZoneStates(ctx, logger, priority, concurrency) {
+				return
+			}
+			concurrency = 1
+		}
+		this.fetchZoneStates(ctx, logger, warmup, concurrency)
+	}()
+}
+
+// fetchZoneStates fetches the states of the zones with the given concurrency.
+// It returns false if the fetching has been stopped on throttling or cancellation.
+func (this *DNSAccount) fetchZoneStates(ctx context.Context, logger logger.LogContext, zones DNSHostedZones, concurrency int) bool {
+	if len(zones) == 0 {
+		return true
+	}
+	if concurrency > len(zones) {
+		concurrency = len(zones)
+	}
+	start := time.Now()
+	var fetched, throttled int32
+	work := make(chan DNSHostedZone)
+	wg := sync.WaitGroup{}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for zone := range work {
+				if _, err := this.GetZoneState(ctx, zone); err != nil {
+					if perrs.IsThrottlingError(err) {
+						atomic.StoreInt32(&throttled, 1)
+					}
+					logger.Infof("prefetching state of zone %s failed: %s", zone.Id(), err)
+					continue
+				}
+				atomic.AddInt32(&fetched, 1)
+			}
+		}()
+	}
+
+	completed := true
+loop:
+	for _, zone := range zones {
+		if atomic.LoadInt32(&throttled) != 0 {
+			logger.Infof("stopped prefetching zone states of account %s on throttling", this.Hash())
+			completed = false
+			break
+		}
+		select {
+		case work <- zone:
+		case <-ctx.Done():
+			completed = false
+			break loop
+		}
+	}
+	close(work)
+	wg.Wait()
+	logger.Infof("prefetched %d/%d zone states of account %s with concurrency %d in %s",
+		fetched, len(zones), this.Hash(), concurrency, time.Since(start).Round(time.Millisecond))
+	return completed && atomic.LoadInt32(&throttled) == 0
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+type prefetchTestHandler struct {
+	DefaultDNSHandler
+	active    int32
+	maxActive int32
+	calls     int32
+	throttle  bool
+
+	lock    sync.Mutex
+	fetched []string
+}
+
+func (h *prefetchTestHandler) GetZones(ctx context.Context) (DNSHostedZones, error) {
+	return nil, nil
+}
+
+func (h *prefetchTestHandler) GetZoneState(ctx context.Context, zone DNSHostedZone) (DNSZoneState, error) {
+	atomic.AddInt32(&h.calls, 1)
+	h.lock.Lock()
+	h.fetched = append(h.fetched, zone.Id().ID)
+	h.lock.Unlock()
+	active := atomic.AddInt32(&h.active, 1)
+	defer atomic.AddInt32(&h.active, -1)
+	for {
+		max := atomic.LoadInt32(&h.maxActive)
+		if active <= max || atomic.CompareAndSwapInt32(&h.maxActive, max, active) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	if h.throttle {
+		return nil, perrs.NewThrottlingError(fmt.Errorf("rate exceeded"))
+	}
+	return NewDNSZoneState(dns.DNSSets{}), nil
+}
+
+func (h *prefetchTestHandler) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
+	return false
+}
+
+func (h *prefetchTestHandler) ExecuteRequests(ctx context.Context, logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	return nil
+}
+
+func (h *prefetchTestHandler) Release() {
+}
+
+var _ = ginkgov2.Describe("Zone state prefetching", func() {
+	var (
+		handler *prefetchTestHandler
+		account *DNSAccount
+		zones   DNSHostedZones
+	)
+
+	ginkgov2.BeforeEach(func() {
+		handler = &prefetchTestHandler{DefaultDNSHandler: NewDefaultDNSHandler("test")}
+		account = NewDNSAccount(nil, handler, "hash")
+		zones = nil
+		for i := 0; i < 10; i++ {
+			zones = append(zones, NewDNSHostedZone("test", fmt.Sprintf("Z%d", i), fmt.Sprintf("z%d.example.com", i), "", nil, false))
+		}
+	})
+
+	ginkgov2.It("fetches the zone states with bounded concurrency", func() {
+		account.fetchZoneStates(context.TODO(), logger.New(), zones, 3)
+		Expect(handler.calls).To(Equal(int32(10)))
+		Expect(handler.maxActive).To(Equal(int32(3)))
+	})
+
+	ginkgov2.It("stops on throttling", func() {
+		handler.throttle = true
+		account.fetchZoneStates(context.TODO(), logger.New(), zones, 2)
+		Expect(handler.calls).To(BeNumerically("<", 10))
+	})
+
+	ginkgov2.It("prefetches every zone only once", func() {
+		account.zoneStateConcurrency = 4
+		account.prefetchZoneStates(context.TODO(), logger.New(), zones, nil)
+		Eventually(func() int32 { return atomic.LoadInt32(&handler.calls) }).Should(Equal(int32(10)))
+		account.prefetchZoneStates(context.TODO(), logger.New(), zones, nil)
+		Consistently(func() int32 { return atomic.LoadInt32(&handler.calls) }, "100ms").Should(Equal(int32(10)))
+	})
+
+	ginkgov2.It("does not prefetch without concurrency", func() {
+		account.zoneStateConcurrency = 1
+		account.prefetchZoneStates(context.TODO(), logger.New(), zones, nil)
+		Consistently(func() int32 { return atomic.LoadInt32(&handler.calls) }, "100ms").Should(Equal(int32(0)))
+	})
+
+	ginkgov2.It("prefetches the pending zones first and warms up the others one after another", func() {
+		account.zoneStateConcurrency = 4
+		pending := map[dns.ZoneID]bool{zones[7].Id(): true, zones[8].Id(): true}
+		account.prefetchZoneStates(context.TODO(), logger.New(), zones, pending)
+		Eventually(func() int32 { return atomic.LoadInt32(&handler.calls) }).Should(Equal(int32(10)))
+		handler.lock.Lock()
+		defer handler.lock.Unlock()
+		Expect(handler.fetched[:2]).To(ConsistOf("Z7", "Z8"))
+		Expect(handler.fetched[2:]).To(Equal([]string{"Z0", "Z1", "Z2", "Z3", "Z4", "Z5", "Z6", "Z9"}))
+	})
+})
+
+var _ = ginkgov2.Describe("Cold start", func() {
+	ginkgov2.It("triggers the zones with pending entries first", func() {
+		pending := hostedZoneCommand(dns.NewZoneID("test", "Z1"))
+		other := hostedZoneCommand(dns.NewZoneID("test", "Z2"))
+		cs := &coldStart{warmupDelay: time.Minute, priority: utils.NewStringSet(pending)}
+		cmds := utils.NewStringSet(CMD_STATISTIC, pending, other)
+
+		order := cs.order(cmds)
+		Expect(order).To(Equal([]string{pending, CMD_STATISTIC}))
+		Expect(cs.isDeferred(other)).To(BeTrue())
+		Expect(cs.isDeferred(CMD_STATISTIC)).To(BeFalse())
+
+		var none *coldStart
+		Expect(none.order(cmds)).To(ConsistOf(CMD_STATISTIC, pending, other))
+	})
+})
@@ -0,0 +1,109 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"k8s.io/client-go/util/flowcontrol"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// zoneRateLimiters are the rate limiters of the provider API requests per hosted zone of a provider.
+type zoneRateLimiters struct {
+	config   api.ZoneRateLimit
+	qps      float32
+	burst    int
+	limiters map[dns.ZoneID]flowcontrol.RateLimiter
+}
+
+func newZoneRateLimiters(config api.ZoneRateLimit) *zoneRateLimiters {
+	interval := time.Second
+	if config.Interval != nil && config.Interval.Duration > 0 {
+		interval = config.Interval.Duration
+	}
+	burst := config.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+	return &zoneRateLimiters{
+		config:   config,
+		qps:      float32(float64(config.Requests) / interval.Seconds()),
+		burst:    burst,
+		limiters: map[dns.ZoneID]flowcontrol.RateLimiter{},
+	}
+}
+
+func (this *zoneRateLimiters) get(zoneid dns.ZoneID) flowcontrol.RateLimiter {
+	limiter := this.limiters[zoneid]
+	if limiter == nil {
+		limiter = flowcontrol.NewTokenBucketRateLimiter(this.qps, this.burst)
+		this.limiters[zoneid] = limiter
+	}
+	return limiter
+}
+
+func (this *state) updateZoneRateLimiters(logger logger.LogContext, obj *dnsutils.DNSProviderObject) {
+	this.prlock.Lock()
+	defer this.prlock.Unlock()
+
+	config := obj.Spec().ZoneRateLimit
+	if config == nil || config.Requests <= 0 {
+		if _, ok := this.zoneRateLimiters[obj.ObjectName()]; ok {
+			delete(this.zoneRateLimiters, obj.ObjectName())
+			logger.Infof("zone rate limiter deleted")
+		}
+		return
+	}
+	if cur := this.zoneRateLimiters[obj.ObjectName()]; cur == nil || !reflect.DeepEqual(cur.config, *config) {
+		limiters := newZoneRateLimiters(*config)
+		this.zoneRateLimiters[obj.ObjectName()] = limiters
+		logger.Infof("zone rate limiter updated: qps=%.3f, burst=%d", limiters.qps, limiters.burst)
+	}
+}
+
+func (this *state) deleteZoneRateLimiters(pname resources.ObjectName) {
+	this.prlock.Lock()
+	defer this.prlock.Unlock()
+	delete(this.zoneRateLimiters, pname)
+}
+
+// waitForZoneRateLimit blocks until a provider API request for the zone is allowed by the zone rate limit of the provider.
+func (this *state) waitForZoneRateLimit(ctx context.Context, pname resources.ObjectName, zoneid dns.ZoneID) error {
+	this.prlock.Lock()
+	limiters := this.zoneRateLimiters[pname]
+	var limiter flowcontrol.RateLimiter
+	if limiters != nil {
+		limiter = limiters.get(zoneid)
+	}
+	this.prlock.Unlock()
+
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("zone rate limit of provider %s for zone %s: %w", pname, zoneid, err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	DISCREPANCY_MISSING   = "missing"
+	DISCREPANCY_EXTRA     = "extra"
+	DISCREPANCY_DIFFERING = "differing"
+
+	defaultDiscrepancySamples = 10
+)
+
+var errZoneStateCheckBusy = fmt.Errorf("zone reconciliation in progress, retry later")
+
+// ZoneStateDiscrepancy is a record set of a zone differing between the cached zone state and the provider.
+type ZoneStateDiscrepancy struct {
+	// Kind is `missing` for record sets only known by the provider, `extra` for record sets only
+	// found in the cached zone state, and `differing` for record sets with different records.
+	Kind          string         `json:"kind"`
+	DNSName       string         `json:"dnsName"`
+	SetIdentifier string         `json:"setIdentifier,omitempty"`
+	Type          string         `json:"type"`
+	Cached        *dns.RecordSet `json:"cached,omitempty"`
+	Provider      *dns.RecordSet `json:"provider,omitempty"`
+}
+
+// ZoneStateCheck is the result of the comparison of a cached zone state with a fresh provider fetch.
+type ZoneStateCheck struct {
+	ProviderType string `json:"providerType"`
+	ID           string `json:"id"`
+	Domain       string `json:"domain"`
+	// CachedSince is the start time of the synchronization of the cached zone state
+	CachedSince time.Time `json:"cachedSince"`
+	RecordSets  int       `json:"recordSets"`
+	Consistent  bool      `json:"consistent"`
+	Missing     int       `json:"missing"`
+	Extra       int       `json:"extra"`
+	Differing   int       `json:"differing"`
+	// Samples are the first discrepancies sorted by DNS name
+	Samples []*ZoneStateDiscrepancy `json:"samples,omitempty"`
+}
+
+func init() {
+	server.RegisterHandler("/debug/zonestates/check", http.HandlerFunc(serveZoneStateCheck))
+}
+
+// CheckZoneState compares the cached state of the zone with a fresh fetch from the provider.
+// The check is rejected while the zone is reconciled, as the cache is updated only after the changes
+// have been executed by the provider. The cached zone state is not modified.
+func (this *state) CheckZoneState(ctx context.Context, zoneid dns.ZoneID, samples int) (*ZoneStateCheck, error) {
+	if this.zoneStates == nil {
+		return nil, nil
+	}
+	this.lock.RLock()
+	zone := this.zones[zoneid]
+	this.lock.RUnlock()
+	if zone == nil {
+		return nil, nil
+	}
+	if !zone.TestAndSetBusy() {
+		return nil, errZoneStateCheckBusy
+	}
+	defer zone.Release()
+	return this.zoneStates.check(ctx, zoneid, samples)
+}
+
+func (s *zoneStates) check(ctx context.Context, zoneid dns.ZoneID, samples int) (*ZoneStateCheck, error) {
+	zone := s.inMemory.FindHostedZone(zoneid)
+	cache := s.findZoneCache(zoneid)
+	if zone == nil || cache == nil {
+		return nil, nil
+	}
+
+	proxy := s.getProxy(zoneid)
+	proxy.lock.Lock()
+	since := proxy.lastUpdateStart
+	cached, err := s.inMemory.CloneZoneState(zone)
+	proxy.lock.Unlock()
+	if err != nil {
+		return nil, nil
+	}
+
+	fresh, err := cache.stateUpdater(ctx, zone, cache)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch zone state %s: %w", zoneid, err)
+	}
+	result := compareZoneStates(cached.GetDNSSets(), fresh.GetDNSSets(), samples)
+	result.ProviderType = zoneid.ProviderType
+	result.ID = zoneid.ID
+	result.Domain = zone.Domain()
+	result.CachedSince = since
+	return result, nil
+}
+
+// findZoneCache returns a zone cache using the zone, which is used to fetch the zone state from the provider.
+func (s *zoneStates) findZoneCache(zoneid dns.ZoneID) *defaultZoneCache {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for cache, zoneids := range s.usedZones {
+		if c, ok := cache.(*defaultZoneCache); ok {
+			for _, id := range zoneids {
+				if id == zoneid {
+					return c
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// compareZoneStates counts the record sets missing in, extra in, or differing from the provider sets
+// and keeps the given number of samples.
+func compareZoneStates(cached, provider dns.DNSSets, samples int) *ZoneStateCheck {
+	result := &ZoneStateCheck{}
+	var discrepancies []*ZoneStateDiscrepancy
+	add := func(kind string, set *dns.DNSSet, rtype string, c, p *dns.RecordSet) {
+		switch kind {
+		case DISCREPANCY_MISSING:
+			result.Missing++
+		case DISCREPANCY_EXTRA:
+			result.Extra++
+		default:
+			result.Differing++
+		}
+		discrepancies = append(discrepancies, &ZoneStateDiscrepancy{
+			Kind:          kind,
+			DNSName:       set.Name,
+			SetIdentifier: set.SetIdentifier,
+			Type:          rtype,
+			Cached:        c,
+			Provider:      p,
+		})
+	}
+
+	for key, pset := range provider {
+		cset := cached[key]
+		for rtype, prs := range pset.Sets {
+			result.RecordSets++
+			var crs *dns.RecordSet
+			if cset != nil {
+				crs = cset.Sets[rtype]
+			}
+			switch {
+			case crs == nil:
+				add(DISCREPANCY_MISSING, pset, rtype, nil, prs)
+			case !crs.Match(prs):
+				add(DISCREPANCY_DIFFERING, pset, rtype, crs, prs)
+			}
+		}
+	}
+	for key, cset := range cached {
+		pset := provider[key]
+		for rtype, crs := range cset.Sets {
+			if pset == nil || pset.Sets[rtype] == nil {
+				add(DISCREPANCY_EXTRA, cset, rtype, crs, nil)
+			}
+		}
+	}
+
+	result.Consistent = len(discrepancies) == 0
+	sort.Slice(discrepancies, func(i, j int) bool {
+		a, b := discrepancies[i], discrepancies[j]
+		if a.DNSName != b.DNSName {
+			return a.DNSName < b.DNSName
+		}
+		if a.SetIdentifier != b.SetIdentifier {
+			return a.SetIdentifier < b.SetIdentifier
+		}
+		return a.Type < b.Type
+	})
+	if len(discrepancies) > samples {
+		discrepancies = discrepancies[:samples]
+	}
+	result.Samples = discrepancies
+	return result
+}
+
+// serveZoneStateCheck compares the cached state of the zone given by the query parameter
+// `zone=<provider type>/<zone id>` with a fresh fetch from the provider and reports the discrepancies as JSON.
+// The optional query parameter `samples` limits the number of reported discrepancies (default 10).
+func serveZoneStateCheck(w http.ResponseWriter, r *http.Request) {
+	theZoneStateDump.lock.Lock()
+	states := append([]*state{}, theZoneStateDump.states...)
+	theZoneStateDump.lock.Unlock()
+	if len(states) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.SplitN(r.URL.Query().Get("zone"), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		http.Error(w, "query parameter 'zone' must be <provider type>/<zone id>", http.StatusBadRequest)
+		return
+	}
+	zoneid := dns.NewZoneID(parts[0], parts[1])
+	samples := defaultDiscrepancySamples
+	if value := r.URL.Query().Get("samples"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			http.Error(w, "query parameter 'samples' must be a non-negative number", http.StatusBadRequest)
+			return
+		}
+		samples = n
+	}
+
+	var result *ZoneStateCheck
+	for _, s := range states {
+		var err error
+		result, err = s.CheckZoneState(r.Context(), zoneid, samples)
+		if err == errZoneStateCheckBusy {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		if result != nil {
+			break
+		}
+	}
+	if result == nil {
+		http.Error(w, "zone state not cached", http.StatusNotFound)
+		return
+	}
+	if !result.Consistent {
+		logger.Warnf("zone state check: cached state of zone %s differs from provider (%d missing, %d extra, %d differing record sets)",
+			zoneid, result.Missing, result.Extra, result.Differing)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
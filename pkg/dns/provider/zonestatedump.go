@@ -0,0 +1,131 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ZoneStateDump is the dump of the cached state of a hosted zone.
+type ZoneStateDump struct {
+	ProviderType string `json:"providerType"`
+	ID           string `json:"id"`
+	Domain       string `json:"domain"`
+	Private      bool   `json:"private,omitempty"`
+	// ForwardedDomains are the forwarded domains of the hosted zone
+	ForwardedDomains []string `json:"forwardedDomains,omitempty"`
+	// CachedForwardedDomains are the forwarded domains determined from the NS records of the zone state
+	CachedForwardedDomains []string    `json:"cachedForwardedDomains,omitempty"`
+	DNSSets                dns.DNSSets `json:"dnsSets"`
+	// History are the previous values of the record sets changed by the controller
+	History []*RecordSetHistory `json:"history,omitempty"`
+}
+
+// zoneStateDump serves the cached zone states of the states of the compound controllers.
+type zoneStateDump struct {
+	lock   sync.Mutex
+	states []*state
+}
+
+var theZoneStateDump = &zoneStateDump{}
+
+func init() {
+	server.RegisterHandler("/debug/zonestates", http.HandlerFunc(serveZoneStateDump))
+}
+
+func enableZoneStateEndpoint(state *state) {
+	theZoneStateDump.lock.Lock()
+	defer theZoneStateDump.lock.Unlock()
+	theZoneStateDump.states = append(theZoneStateDump.states, state)
+}
+
+// DumpZoneStates returns the cached zone states, optionally restricted to the zone with the given id.
+func (this *state) DumpZoneStates(zoneID string) []*ZoneStateDump {
+	if this.zoneStates == nil {
+		return nil
+	}
+	return this.zoneStates.dump(zoneID)
+}
+
+func (s *zoneStates) dump(zoneID string) []*ZoneStateDump {
+	var result []*ZoneStateDump
+	for id, zone := range s.inMemory.BuildFullDump().InMemory {
+		if zoneID != "" && zoneID != id.ID && zoneID != id.String() {
+			continue
+		}
+		result = append(result, &ZoneStateDump{
+			ProviderType:           id.ProviderType,
+			ID:                     id.ID,
+			Domain:                 zone.HostedZone.Domain,
+			Private:                zone.HostedZone.Private,
+			ForwardedDomains:       zone.HostedZone.ForwardedDomains,
+			CachedForwardedDomains: s.forwardedDomainsCache.Get(id),
+			DNSSets:                zone.DNSSets,
+			History:                s.history.dump(id),
+		})
+	}
+	return result
+}
+
+// serveZoneStateDump dumps the cached zone states as JSON. The optional query parameter `zone`
+// restricts the dump to a single zone, given by its id with or without provider type prefix (`<type>/<id>`).
+func serveZoneStateDump(w http.ResponseWriter, r *http.Request) {
+	theZoneStateDump.lock.Lock()
+	states := append([]*state{}, theZoneStateDump.states...)
+	theZoneStateDump.lock.Unlock()
+	if len(states) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	zones := []*ZoneStateDump{}
+	zoneID := strings.TrimSpace(r.URL.Query().Get("zone"))
+	for _, s := range states {
+		zones = append(zones, s.DumpZoneStates(zoneID)...)
+	}
+	sort.Slice(zones, func(i, j int) bool {
+		if zones[i].ProviderType != zones[j].ProviderType {
+			return zones[i].ProviderType < zones[j].ProviderType
+		}
+		return zones[i].ID < zones[j].ID
+	})
+	if zoneID != "" && len(zones) == 0 {
+		http.Error(w, "zone state not cached", http.StatusNotFound)
+		return
+	}
+
+	data, err := json.MarshalIndent(zones, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
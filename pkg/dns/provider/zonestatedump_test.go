@@ -0,0 +1,163 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("Zone state endpoint", func() {
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serveZoneStateDump(w, httptest.NewRequest(http.MethodGet, "/debug/zonestates"+query, nil))
+		return w
+	}
+
+	ginkgov2.AfterEach(func() {
+		theZoneStateDump.states = nil
+	})
+
+	ginkgov2.It("is not served if disabled", func() {
+		Expect(get("").Code).To(Equal(http.StatusNotFound))
+	})
+
+	ginkgov2.It("dumps the cached zone states", func() {
+		zoneStates := newZoneStates(func(id dns.ZoneID) time.Duration { return time.Minute })
+		zone := NewDNSHostedZone("test", "Z1", "example.com", "", []string{"sub.example.com"}, false)
+		dnssets := dns.DNSSets{}
+		dnssets.AddRecordSetFromProvider("www.example.com", dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+		zoneStates.inMemory.SetZone(zone, NewDNSZoneState(dnssets))
+		enableZoneStateEndpoint(&state{zoneStates: zoneStates})
+
+		w := get("")
+		Expect(w.Code).To(Equal(http.StatusOK))
+		dump := []*ZoneStateDump{}
+		Expect(json.Unmarshal(w.Body.Bytes(), &dump)).To(Succeed())
+		Expect(dump).To(HaveLen(1))
+		Expect(dump[0].ID).To(Equal("Z1"))
+		Expect(dump[0].ForwardedDomains).To(ConsistOf("sub.example.com"))
+		Expect(dump[0].DNSSets).To(HaveKey("www.example.com"))
+
+		Expect(get("?zone=test/Z1").Code).To(Equal(http.StatusOK))
+		Expect(get("?zone=Z2").Code).To(Equal(http.StatusNotFound))
+	})
+})
+
+var _ = ginkgov2.Describe("Zone state check", func() {
+	var (
+		zoneStates *zoneStates
+		fresh      dns.DNSSets
+		cached     dns.DNSSets
+	)
+	zone := NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)
+	rs := func(ttl int64, values ...string) *dns.RecordSet {
+		var records []*dns.Record
+		for _, v := range values {
+			records = append(records, &dns.Record{Value: v})
+		}
+		return dns.NewRecordSet(dns.RS_A, ttl, records)
+	}
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		serveZoneStateCheck(w, httptest.NewRequest(http.MethodGet, "/debug/zonestates/check"+query, nil))
+		return w
+	}
+
+	ginkgov2.BeforeEach(func() {
+		factory := NewTestZoneCacheFactory(time.Minute, time.Hour)
+		factory.logger = logger.New()
+		zoneStates = factory.zoneStates
+		stateUpdater := func(ctx context.Context, zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+			return NewDNSZoneState(fresh.Clone()), nil
+		}
+		cache, err := factory.CreateZoneCache(CacheZoneState, &NullMetrics{}, nil, stateUpdater)
+		Expect(err).NotTo(HaveOccurred())
+		zoneStates.UpdateUsedZones(cache, []dns.ZoneID{zone.Id()})
+
+		fresh = dns.DNSSets{}
+		fresh.AddRecordSetFromProvider("a.example.com", rs(300, "1.1.1.1"))
+		fresh.AddRecordSetFromProvider("b.example.com", rs(300, "2.2.2.2"))
+		fresh.AddRecordSetFromProvider("c.example.com", rs(300, "3.3.3.3"))
+		_, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		state, err := zoneStates.inMemory.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		cached = state.GetDNSSets()
+	})
+
+	ginkgov2.AfterEach(func() {
+		theZoneStateDump.states = nil
+	})
+
+	ginkgov2.It("reports a consistent zone state", func() {
+		result, err := zoneStates.check(context.TODO(), zone.Id(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Consistent).To(BeTrue())
+		Expect(result.RecordSets).To(Equal(3))
+		Expect(result.Samples).To(BeEmpty())
+	})
+
+	ginkgov2.It("reports missing, extra and differing record sets", func() {
+		fresh = dns.DNSSets{}
+		fresh.AddRecordSetFromProvider("a.example.com", rs(300, "1.1.1.1"))
+		fresh.AddRecordSetFromProvider("b.example.com", rs(300, "2.2.2.3"))
+		fresh.AddRecordSetFromProvider("d.example.com", rs(300, "4.4.4.4"))
+
+		result, err := zoneStates.check(context.TODO(), zone.Id(), 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Consistent).To(BeFalse())
+		Expect(result.Missing).To(Equal(1))
+		Expect(result.Extra).To(Equal(1))
+		Expect(result.Differing).To(Equal(1))
+		Expect(result.Samples).To(HaveLen(3))
+		Expect(result.Samples[0].DNSName).To(Equal("b.example.com"))
+		Expect(result.Samples[0].Kind).To(Equal(DISCREPANCY_DIFFERING))
+		Expect(result.Samples[1].Kind).To(Equal(DISCREPANCY_EXTRA))
+		Expect(result.Samples[2].Kind).To(Equal(DISCREPANCY_MISSING))
+
+		result, err = zoneStates.check(context.TODO(), zone.Id(), 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Samples).To(HaveLen(1))
+
+		state, err := zoneStates.inMemory.CloneZoneState(zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state.GetDNSSets()).To(Equal(cached))
+	})
+
+	ginkgov2.It("serves checks and rejects them while the zone is reconciled", func() {
+		s := &state{zoneStates: zoneStates, zones: dnsHostedZones{}}
+		s.zones[zone.Id()] = &dnsHostedZone{zone: zone}
+		enableZoneStateEndpoint(s)
+
+		Expect(get("?zone=Z1").Code).To(Equal(http.StatusBadRequest))
+		Expect(get("?zone=test/Z2").Code).To(Equal(http.StatusNotFound))
+		Expect(get("?zone=test/Z1").Code).To(Equal(http.StatusOK))
+
+		Expect(s.zones[zone.Id()].TestAndSetBusy()).To(BeTrue())
+		Expect(get("?zone=test/Z1").Code).To(Equal(http.StatusConflict))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ZoneStatePersistence is a persistence layer for zone states, which allows to survive
+// controller restarts without reading all zone states from the provider APIs again.
+type ZoneStatePersistence interface {
+	// Load returns the persisted zone state and the time it has been read from the provider
+	// or nil if there is no persisted state.
+	Load(zoneID dns.ZoneID) (DNSZoneState, time.Time, error)
+	// Store persists the zone state
+	Store(zoneID dns.ZoneID, state DNSZoneState, updated time.Time) error
+	// Delete removes a persisted zone state
+	Delete(zoneID dns.ZoneID) error
+}
+
+type persistedZoneState struct {
+	ZoneID  dns.ZoneID  `json:"zoneID"`
+	Updated time.Time   `json:"updated"`
+	DNSSets dns.DNSSets `json:"dnsSets"`
+}
+
+type fileZoneStatePersistence struct {
+	dir string
+}
+
+var _ ZoneStatePersistence = &fileZoneStatePersistence{}
+
+// NewFileZoneStatePersistence creates a zone state persistence storing a JSON file per zone in the given directory.
+func NewFileZoneStatePersistence(dir string) (ZoneStatePersistence, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create zone state cache directory %s: %w", dir, err)
+	}
+	return &fileZoneStatePersistence{dir: dir}, nil
+}
+
+func (p *fileZoneStatePersistence) filename(zoneID dns.ZoneID) string {
+	h := sha256.Sum256([]byte(zoneID.String()))
+	return filepath.Join(p.dir, zoneID.ProviderType+"-"+hex.EncodeToString(h[:16])+".json")
+}
+
+func (p *fileZoneStatePersistence) Load(zoneID dns.ZoneID) (DNSZoneState, time.Time, error) {
+	data, err := ioutil.ReadFile(p.filename(zoneID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, time.Time{}, nil
+		}
+		return nil, time.Time{}, err
+	}
+	persisted := &persistedZoneState{}
+	if err := json.Unmarshal(data, persisted); err != nil {
+		return nil, time.Time{}, fmt.Errorf("invalid persisted zone state for %s: %w", zoneID, err)
+	}
+	if persisted.ZoneID != zoneID {
+		return nil, time.Time{}, fmt.Errorf("persisted zone state belongs to zone %s instead of %s", persisted.ZoneID, zoneID)
+	}
+	if persisted.DNSSets == nil {
+		persisted.DNSSets = dns.DNSSets{}
+	}
+	return NewDNSZoneState(persisted.DNSSets), persisted.Updated, nil
+}
+
+func (p *fileZoneStatePersistence) Store(zoneID dns.ZoneID, state DNSZoneState, updated time.Time) error {
+	data, err := json.Marshal(&persistedZoneState{ZoneID: zoneID, Updated: updated, DNSSets: state.GetDNSSets()})
+	if err != nil {
+		return err
+	}
+	filename := p.filename(zoneID)
+	tmp, err := ioutil.TempFile(p.dir, filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	// rename is atomic, so a crash never leaves a partially written zone state
+	return os.Rename(tmp.Name(), filename)
+}
+
+func (p *fileZoneStatePersistence) Delete(zoneID dns.ZoneID) error {
+	err := os.Remove(p.filename(zoneID))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	ginkgov2 "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = ginkgov2.Describe("File zone state persistence", func() {
+	var (
+		dir string
+		p   ZoneStatePersistence
+	)
+	zoneID := dns.NewZoneID("test", "Z1234")
+
+	ginkgov2.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "zonestates")
+		Expect(err).NotTo(HaveOccurred())
+		p, err = NewFileZoneStatePersistence(dir)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	ginkgov2.AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	ginkgov2.It("returns nil for unknown zones", func() {
+		state, _, err := p.Load(zoneID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+	})
+
+	ginkgov2.It("stores, loads and deletes a zone state", func() {
+		sets := dns.DNSSets{}
+		sets.AddRecordSetFromProvider("a.example.com", dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+		updated := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+		Expect(p.Store(zoneID, NewDNSZoneState(sets), updated)).To(Succeed())
+
+		state, ts, err := p.Load(zoneID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ts.Equal(updated)).To(BeTrue())
+		Expect(state.GetDNSSets()).To(HaveLen(1))
+		rs := state.GetDNSSets()["a.example.com"].Sets[dns.RS_A]
+		Expect(rs.TTL).To(Equal(int64(300)))
+		Expect(rs.Records[0].Value).To(Equal("1.2.3.4"))
+
+		Expect(p.Delete(zoneID)).To(Succeed())
+		state, _, err = p.Load(zoneID)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(BeNil())
+	})
+})
+
+var _ = ginkgov2.Describe("Restoring persisted zone states", func() {
+	var (
+		dir     string
+		p       ZoneStatePersistence
+		cache   *defaultZoneCache
+		fetches int
+	)
+	zone := NewDNSHostedZone("test", "Z1", "example.com", "", nil, false)
+	ttl := time.Minute
+
+	ginkgov2.BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "zonestates")
+		Expect(err).NotTo(HaveOccurred())
+		p, err = NewFileZoneStatePersistence(dir)
+		Expect(err).NotTo(HaveOccurred())
+		fetches = 0
+		states := newZoneStates(func(id dns.ZoneID) time.Duration { return ttl })
+		states.EnablePersistence(p, time.Hour)
+		cache = &defaultZoneCache{
+			abstractZonesCache: abstractZonesCache{
+				logger: logger.New(),
+				stateUpdater: func(ctx context.Context, zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+					fetches++
+					return NewDNSZoneState(dns.DNSSets{}), nil
+				},
+			},
+			logger:     logger.New(),
+			metrics:    &NullMetrics{},
+			zoneStates: states,
+		}
+	})
+
+	ginkgov2.AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	store := func(updated time.Time) {
+		sets := dns.DNSSets{}
+		sets.AddRecordSetFromProvider("a.example.com", dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.2.3.4"}}))
+		Expect(p.Store(zone.Id(), NewDNSZoneState(sets), updated)).To(Succeed())
+	}
+
+	ginkgov2.It("expires a restored zone state with the ttl of its persisted update", func() {
+		store(time.Now().Add(-ttl + 200*time.Millisecond))
+
+		state, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(0))
+		Expect(state.GetDNSSets()).To(HaveKey("a.example.com"))
+
+		time.Sleep(300 * time.Millisecond)
+		state, err = cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(1))
+		Expect(state.GetDNSSets()).To(HaveLen(0))
+	})
+
+	ginkgov2.It("revalidates a restored zone state older than the ttl immediately", func() {
+		store(time.Now().Add(-2 * ttl))
+
+		state, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(1))
+		Expect(state.GetDNSSets()).To(HaveLen(0))
+	})
+
+	ginkgov2.It("ignores persisted zone states older than the maximum age", func() {
+		store(time.Now().Add(-2 * time.Hour))
+
+		_, err := cache.GetZoneState(context.TODO(), zone)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fetches).To(Equal(1))
+	})
+})
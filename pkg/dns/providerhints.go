@@ -0,0 +1,47 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+// ProviderHints are provider type specific settings of the records of a record set.
+// They are only set for record sets of providers supporting them.
+type ProviderHints struct {
+	// CloudflareProxied enables the Cloudflare proxy for the records
+	CloudflareProxied bool
+	// UltraDNSPoolOrder manages the records as UltraDNS resource distribution pool with the given order
+	UltraDNSPoolOrder string
+}
+
+func (this *ProviderHints) Clone() *ProviderHints {
+	if this == nil {
+		return nil
+	}
+	clone := *this
+	return &clone
+}
+
+// IsEmpty returns true if no hint is set.
+func (this *ProviderHints) IsEmpty() bool {
+	return this == nil || (!this.CloudflareProxied && this.UltraDNSPoolOrder == "")
+}
+
+// Match compares the hints, missing hints are equivalent to empty hints.
+func (this *ProviderHints) Match(hints *ProviderHints) bool {
+	if this.IsEmpty() || hints.IsEmpty() {
+		return this.IsEmpty() == hints.IsEmpty()
+	}
+	return *this == *hints
+}
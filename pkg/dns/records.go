@@ -22,7 +22,8 @@ import (
 )
 
 const RS_META = "META"
-const RS_ALIAS = "ALIAS" // provider specific alias for CNAME record (e.g. AWS alias target)
+const RS_ALIAS = "ALIAS"       // provider specific alias for CNAME record (e.g. AWS alias target)
+const RS_RESOURCE = "RESOURCE" // provider specific cloud resource reference (e.g. GCP forwarding rule), mapped to its addresses
 
 const RS_TXT = "TXT"
 const RS_CNAME = "CNAME"
@@ -31,6 +32,13 @@ const RS_AAAA = "AAAA"
 
 const RS_NS = "NS"
 
+const RS_SRV = "SRV"
+const RS_SSHFP = "SSHFP"
+const RS_NAPTR = "NAPTR"
+const RS_SVCB = "SVCB"
+const RS_HTTPS = "HTTPS"
+const RS_CAA = "CAA"
+
 ////////////////////////////////////////////////////////////////////////////////
 // Record Sets
 ////////////////////////////////////////////////////////////////////////////////
@@ -55,10 +63,12 @@ func (this *Record) Clone() *Record {
 }
 
 type RecordSet struct {
-	Type      string
-	TTL       int64
-	IgnoreTTL bool
-	Records   Records
+	Type          string
+	TTL           int64
+	IgnoreTTL     bool
+	Records       Records
+	RoutingPolicy *RoutingPolicy
+	ProviderHints *ProviderHints
 }
 
 func NewRecordSet(rtype string, ttl int64, records []*Record) *RecordSet {
@@ -69,7 +79,7 @@ func NewRecordSet(rtype string, ttl int64, records []*Record) *RecordSet {
 }
 
 func (this *RecordSet) Clone() *RecordSet {
-	set := &RecordSet{this.Type, this.TTL, this.IgnoreTTL, nil}
+	set := &RecordSet{this.Type, this.TTL, this.IgnoreTTL, nil, this.RoutingPolicy.Clone(), this.ProviderHints.Clone()}
 	for _, r := range this.Records {
 		set.Records = append(set.Records, r.Clone())
 	}
@@ -112,6 +122,14 @@ func (this *RecordSet) Match(set *RecordSet) bool {
 		return false
 	}
 
+	if !this.RoutingPolicy.Match(set.RoutingPolicy) {
+		return false
+	}
+
+	if !this.ProviderHints.Match(set.ProviderHints) {
+		return false
+	}
+
 	for _, r := range this.Records {
 		found := false
 		for _, t := range set.Records {
@@ -200,5 +218,5 @@ func newAttrRecord(name, value string) *Record {
 
 func newAttrRecordSet(ty string, name, value string) *RecordSet {
 	records := []*Record{newAttrRecord(name, value)}
-	return &RecordSet{ty, 600, false, records}
+	return &RecordSet{ty, 600, false, records, nil, nil}
 }
@@ -26,6 +26,24 @@ func TestMatch(t *testing.T) {
 		{RecordSet{Type: RS_META, TTL: 600, Records: []*Record{{"\"owner=test\""}}}, RecordSet{Type: RS_TXT, TTL: 800, Records: []*Record{{"\"owner=test\""}}}, false},
 		// different amount of records = not equal
 		{RecordSet{Type: RS_META, TTL: 600, Records: []*Record{{"\"owner=test\""}}}, RecordSet{Type: RS_TXT, TTL: 600, Records: []*Record{{"\"owner=test\""}, {"\"owner=test\""}}}, false},
+		// equal routing policies = equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: NewRoutingPolicy(RP_MULTIVALUE, map[string]string{"healthCheckID.1.2.3.4": "hc"})},
+			RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: NewRoutingPolicy(RP_MULTIVALUE, map[string]string{"healthCheckID.1.2.3.4": "hc"})}, true},
+		// routing policy only for one set = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: NewRoutingPolicy(RP_MULTIVALUE, nil)}, RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}}, false},
+		// different routing policy parameters = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: NewRoutingPolicy(RP_MULTIVALUE, map[string]string{"healthCheckID.1.2.3.4": "hc"})},
+			RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: NewRoutingPolicy(RP_MULTIVALUE, nil)}, false},
+		// different set identifiers = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: &RoutingPolicy{Type: RP_WEIGHTED, SetIdentifier: "blue", Parameters: map[string]string{"weight": "10"}}},
+			RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, RoutingPolicy: &RoutingPolicy{Type: RP_WEIGHTED, SetIdentifier: "green", Parameters: map[string]string{"weight": "10"}}}, false},
+		// empty provider hints = equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, ProviderHints: &ProviderHints{}}, RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}}, true},
+		// proxied only for one set = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, ProviderHints: &ProviderHints{CloudflareProxied: true}}, RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}}, false},
+		// different pool orders = not equal
+		{RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, ProviderHints: &ProviderHints{UltraDNSPoolOrder: "FIXED"}},
+			RecordSet{Type: RS_A, TTL: 600, Records: []*Record{{"1.2.3.4"}}, ProviderHints: &ProviderHints{UltraDNSPoolOrder: "RANDOM"}}, false},
 	}
 
 	for _, entry := range table {
@@ -0,0 +1,106 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RP_MULTIVALUE is the routing policy type for multi value answers with optional health checks per record
+const RP_MULTIVALUE = "multivalue"
+
+// RP_WEIGHTED is the routing policy type for weighted record sets sharing the same DNS name
+const RP_WEIGHTED = "weighted"
+
+// RP_GEOLOCATION is the routing policy type for record sets answering queries based on the location of the client
+const RP_GEOLOCATION = "geolocation"
+
+// RP_FAILOVER is the routing policy type for primary and secondary record sets switched by health checks
+const RP_FAILOVER = "failover"
+
+// RoutingPolicyTypes are all known routing policy types
+var RoutingPolicyTypes = []string{RP_MULTIVALUE, RP_WEIGHTED, RP_GEOLOCATION, RP_FAILOVER}
+
+// RoutingPolicy is an optional provider specific routing policy of a record set
+type RoutingPolicy struct {
+	Type string
+	// SetIdentifier distinguishes multiple record sets for the same DNS name (e.g. for weighted routing)
+	SetIdentifier string
+	Parameters    map[string]string
+}
+
+func NewRoutingPolicy(typ string, parameters map[string]string) *RoutingPolicy {
+	if parameters == nil {
+		parameters = map[string]string{}
+	}
+	return &RoutingPolicy{Type: typ, Parameters: parameters}
+}
+
+func (this *RoutingPolicy) Clone() *RoutingPolicy {
+	if this == nil {
+		return nil
+	}
+	parameters := map[string]string{}
+	for k, v := range this.Parameters {
+		parameters[k] = v
+	}
+	return &RoutingPolicy{Type: this.Type, SetIdentifier: this.SetIdentifier, Parameters: parameters}
+}
+
+// GetSetIdentifier returns the set identifier of the policy or an empty string if no policy is given.
+func (this *RoutingPolicy) GetSetIdentifier() string {
+	if this == nil {
+		return ""
+	}
+	return this.SetIdentifier
+}
+
+func (this *RoutingPolicy) Match(policy *RoutingPolicy) bool {
+	if this == nil || policy == nil {
+		return this == policy
+	}
+	if this.Type != policy.Type || this.SetIdentifier != policy.SetIdentifier || len(this.Parameters) != len(policy.Parameters) {
+		return false
+	}
+	for k, v := range this.Parameters {
+		if w, ok := policy.Parameters[k]; !ok || v != w {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *RoutingPolicy) String() string {
+	if this == nil {
+		return "none"
+	}
+	keys := make([]string, 0, len(this.Parameters))
+	for k := range this.Parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	params := make([]string, len(keys))
+	for i, k := range keys {
+		params[i] = fmt.Sprintf("%s=%s", k, this.Parameters[k])
+	}
+	if this.SetIdentifier != "" {
+		return fmt.Sprintf("%s[%s](%s)", this.Type, this.SetIdentifier, strings.Join(params, ","))
+	}
+	return fmt.Sprintf("%s(%s)", this.Type, strings.Join(params, ","))
+}
@@ -43,6 +43,7 @@ const DNS_ANNOTATION = dns.ANNOTATION_GROUP + "/dnsnames"
 const TTL_ANNOTATION = dns.ANNOTATION_GROUP + "/ttl"
 const PERIOD_ANNOTATION = dns.ANNOTATION_GROUP + "/cname-lookup-interval"
 const CLASS_ANNOTATION = dns.CLASS_ANNOTATION
+const RETAIN_ANNOTATION = dns.ANNOTATION_GROUP + "/retain-entries"
 
 const OPT_CLASS = "dns-class"
 const OPT_TARGET_CLASS = "dns-target-class"
@@ -56,6 +57,16 @@ const OPT_TARGET_OWNER_ID = "target-owner-id"
 const OPT_TARGET_OWNER_OBJECT = "target-owner-object"
 const OPT_TARGET_SET_IGNORE_OWNERS = "target-set-ignore-owners"
 const OPT_TARGET_REALMS = "target-realms"
+const OPT_TARGET_PROPAGATE_LABELS = "target-propagate-labels"
+const OPT_TARGET_PROPAGATE_ANNOTATIONS = "target-propagate-annotations"
+const OPT_TARGET_CLEANUP_POLICY = "target-cleanup-policy"
+
+const (
+	// CLEANUP_POLICY_DELETE deletes the generated entries of source objects not qualifying anymore
+	CLEANUP_POLICY_DELETE = "delete"
+	// CLEANUP_POLICY_ORPHAN keeps the generated entries of source objects not qualifying anymore as unowned entries
+	CLEANUP_POLICY_ORPHAN = "orphan"
+)
 
 var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
 var ownerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSOwnerKind)
@@ -85,6 +96,9 @@ func DNSSourceController(source DNSSourceType, reconcilerType controller.Reconci
 		StringOption(OPT_TARGET_OWNER_OBJECT, "owner object to use for generated DNS entries").
 		BoolOption(OPT_TARGET_SET_IGNORE_OWNERS, "mark generated DNS entries to omit owner based access control").
 		StringOption(OPT_TARGET_REALMS, "realm(s) to use for generated DNS entries").
+		StringArrayOption(OPT_TARGET_PROPAGATE_LABELS, "label keys of source objects to propagate to generated DNS entries (a trailing '*' selects a key prefix)").
+		StringArrayOption(OPT_TARGET_PROPAGATE_ANNOTATIONS, "annotation keys of source objects to propagate to generated DNS entries (a trailing '*' selects a key prefix)").
+		DefaultedStringOption(OPT_TARGET_CLEANUP_POLICY, CLEANUP_POLICY_DELETE, "policy for generated DNS entries of source objects not qualifying anymore (delete or orphan)").
 		FinalizerDomain(api.GroupName).
 		Reconciler(SourceReconciler(source, reconcilerType)).
 		Cluster(cluster.DEFAULT). // first one used as MAIN cluster
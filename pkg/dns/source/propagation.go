@@ -0,0 +1,111 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// propagation selects the labels or annotations of a source object to be copied
+// to the generated DNS entries. A key ending with '*' selects all keys with this prefix.
+// Keys of the DNS annotation group are never propagated, as they are maintained by the
+// source controller itself.
+type propagation struct {
+	keys     []string
+	reserved []string
+}
+
+func newPropagation(keys []string, reserved ...string) *propagation {
+	p := &propagation{reserved: reserved}
+	for _, k := range keys {
+		for _, s := range strings.Split(k, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				p.keys = append(p.keys, s)
+			}
+		}
+	}
+	return p
+}
+
+func (this *propagation) IsEmpty() bool {
+	return len(this.keys) == 0
+}
+
+func (this *propagation) String() string {
+	return strings.Join(this.keys, ",")
+}
+
+func (this *propagation) Matches(key string) bool {
+	if strings.HasPrefix(key, dns.ANNOTATION_GROUP+"/") {
+		return false
+	}
+	for _, r := range this.reserved {
+		if key == r {
+			return false
+		}
+	}
+	for _, k := range this.keys {
+		if strings.HasSuffix(k, "*") {
+			if strings.HasPrefix(key, k[:len(k)-1]) {
+				return true
+			}
+		} else if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// propagate copies the selected values of the source map and removes selected keys
+// not found in the source anymore. It reports whether the target has been modified.
+func (this *propagation) propagate(source, target map[string]string, set func(key, value string) bool, remove func(key string) bool) bool {
+	if this.IsEmpty() {
+		return false
+	}
+	modified := false
+	for k, v := range source {
+		if this.Matches(k) && set(k, v) {
+			modified = true
+		}
+	}
+	for k := range target {
+		if _, ok := source[k]; !ok && this.Matches(k) && remove(k) {
+			modified = true
+		}
+	}
+	return modified
+}
+
+// PropagateLabels propagates the selected labels of the source object to the entry object.
+func (this *propagation) PropagateLabels(src resources.ObjectData, entry resources.ObjectData) bool {
+	return this.propagate(src.GetLabels(), entry.GetLabels(),
+		func(key, value string) bool { return resources.SetLabel(entry, key, value) },
+		func(key string) bool { return resources.RemoveLabel(entry, key) },
+	)
+}
+
+// PropagateAnnotations propagates the selected annotations of the source object to the entry object.
+func (this *propagation) PropagateAnnotations(src resources.ObjectData, entry resources.ObjectData) bool {
+	return this.propagate(src.GetAnnotations(), entry.GetAnnotations(),
+		func(key, value string) bool { return resources.SetAnnotation(entry, key, value) },
+		func(key string) bool { return resources.RemoveAnnotation(entry, key) },
+	)
+}
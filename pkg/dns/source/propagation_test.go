@@ -0,0 +1,97 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Propagation", func() {
+	const creatorLabel = "creator"
+
+	var (
+		src   *corev1.Service
+		entry *api.DNSEntry
+	)
+
+	BeforeEach(func() {
+		src = &corev1.Service{}
+		entry = &api.DNSEntry{}
+	})
+
+	It("splits and trims the selected keys", func() {
+		p := newPropagation([]string{"team, app.kubernetes.io/*", "", " cost-center "})
+		Expect(p.String()).To(Equal("team,app.kubernetes.io/*,cost-center"))
+		Expect(p.IsEmpty()).To(BeFalse())
+		Expect(newPropagation(nil).IsEmpty()).To(BeTrue())
+		Expect(newPropagation([]string{" , "}).IsEmpty()).To(BeTrue())
+	})
+
+	It("matches keys and key prefixes", func() {
+		p := newPropagation([]string{"team", "app.kubernetes.io/*"})
+		Expect(p.Matches("team")).To(BeTrue())
+		Expect(p.Matches("team2")).To(BeFalse())
+		Expect(p.Matches("app.kubernetes.io/name")).To(BeTrue())
+		Expect(p.Matches("app.kubernetes.io")).To(BeFalse())
+	})
+
+	It("never matches keys of the dns group or reserved keys", func() {
+		p := newPropagation([]string{"*"}, creatorLabel)
+		Expect(p.Matches("team")).To(BeTrue())
+		Expect(p.Matches(dns.SOURCE_UID_LABEL)).To(BeFalse())
+		Expect(p.Matches(dns.CLASS_ANNOTATION)).To(BeFalse())
+		Expect(p.Matches(creatorLabel)).To(BeFalse())
+	})
+
+	It("copies the selected labels to a new entry", func() {
+		src.Labels = map[string]string{"team": "dns", "app.kubernetes.io/name": "web", "other": "x", creatorLabel: "me"}
+		p := newPropagation([]string{"team", "app.kubernetes.io/*", creatorLabel}, creatorLabel)
+		Expect(p.PropagateLabels(src, entry)).To(BeTrue())
+		Expect(entry.Labels).To(Equal(map[string]string{"team": "dns", "app.kubernetes.io/name": "web"}))
+		Expect(p.PropagateLabels(src, entry)).To(BeFalse())
+	})
+
+	It("updates changed values and removes keys missing at the source", func() {
+		src.Labels = map[string]string{"team": "dns-ops"}
+		entry.Labels = map[string]string{"team": "dns", "app.kubernetes.io/name": "web", "other": "x", dns.SOURCE_UID_LABEL: "uid"}
+		p := newPropagation([]string{"team", "app.kubernetes.io/*"})
+		Expect(p.PropagateLabels(src, entry)).To(BeTrue())
+		Expect(entry.Labels).To(Equal(map[string]string{"team": "dns-ops", "other": "x", dns.SOURCE_UID_LABEL: "uid"}))
+	})
+
+	It("propagates the selected annotations", func() {
+		src.Annotations = map[string]string{"note": "hello", access.ANNOTATION_IGNORE_OWNERS: "true", TTL_ANNOTATION: "60"}
+		entry.Annotations = map[string]string{dns.SOURCE_ANNOTATION: "/Service/default/svc", "obsolete": "x"}
+		p := newPropagation([]string{"*"}, access.ANNOTATION_IGNORE_OWNERS)
+		Expect(p.PropagateAnnotations(src, entry)).To(BeTrue())
+		Expect(entry.Annotations).To(Equal(map[string]string{"note": "hello", dns.SOURCE_ANNOTATION: "/Service/default/svc"}))
+	})
+
+	It("does not modify entries without selected keys", func() {
+		src.Labels = map[string]string{"team": "dns"}
+		entry.Labels = map[string]string{"other": "x"}
+		p := newPropagation(nil)
+		Expect(p.PropagateLabels(src, entry)).To(BeFalse())
+		Expect(entry.Labels).To(Equal(map[string]string{"other": "x"}))
+	})
+})
@@ -33,9 +33,11 @@ import (
 	"github.com/gardener/external-dns-management/pkg/controller/annotation/annotations"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/health"
 
 	core "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func NewSlaveAccessSpec(c controller.Interface, sourceType DNSSourceType) reconcilers.SlaveAccessSpec {
@@ -86,6 +88,23 @@ func SourceReconciler(sourceType DNSSourceType, rtype controller.ReconcilerType)
 		reconciler.creatorLabelName, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_NAME)
 		reconciler.creatorLabelValue, _ = c.GetStringOption(OPT_TARGET_CREATOR_LABEL_VALUE)
 		reconciler.setIgnoreOwners, _ = c.GetBoolOption(OPT_TARGET_SET_IGNORE_OWNERS)
+		labels, _ := c.GetStringArrayOption(OPT_TARGET_PROPAGATE_LABELS)
+		reconciler.propagatedLabels = newPropagation(labels, reconciler.creatorLabelName)
+		annos, _ := c.GetStringArrayOption(OPT_TARGET_PROPAGATE_ANNOTATIONS)
+		reconciler.propagatedAnnotations = newPropagation(annos, access.ANNOTATION_IGNORE_OWNERS)
+		if !reconciler.propagatedLabels.IsEmpty() || !reconciler.propagatedAnnotations.IsEmpty() {
+			c.Infof("propagated labels: %s, annotations: %s", reconciler.propagatedLabels, reconciler.propagatedAnnotations)
+		}
+		reconciler.healthName = c.GetName()
+		reconciler.cleanupPolicy, _ = c.GetStringOption(OPT_TARGET_CLEANUP_POLICY)
+		switch reconciler.cleanupPolicy {
+		case CLEANUP_POLICY_DELETE, CLEANUP_POLICY_ORPHAN:
+		case "":
+			reconciler.cleanupPolicy = CLEANUP_POLICY_DELETE
+		default:
+			return nil, fmt.Errorf("invalid %s %q (expected %s or %s)", OPT_TARGET_CLEANUP_POLICY, reconciler.cleanupPolicy, CLEANUP_POLICY_DELETE, CLEANUP_POLICY_ORPHAN)
+		}
+		c.Infof("cleanup policy: %s", reconciler.cleanupPolicy)
 
 		excluded, _ := c.GetStringArrayOption(OPT_EXCLUDE)
 		reconciler.excluded = utils.NewStringSetByArray(excluded)
@@ -117,6 +136,11 @@ type sourceReconciler struct {
 	creatorLabelName  string
 	creatorLabelValue string
 	setIgnoreOwners   bool
+	healthName        string
+	cleanupPolicy     string
+
+	propagatedLabels      *propagation
+	propagatedAnnotations *propagation
 
 	state       *state
 	annotations *annotations.State
@@ -134,10 +158,17 @@ func (this *sourceReconciler) Setup() error {
 	}
 	this.SlaveAccess.Setup()
 	this.state.source.Setup()
+	health.Register(this.healthName, 0)
 	return this.NestedReconciler.Setup()
 }
 
+func (this *sourceReconciler) Start() {
+	this.NestedReconciler.Start()
+	health.SetReady(this.healthName, true, "")
+}
+
 func (this *sourceReconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
+	health.Tick(this.healthName)
 	slaves := this.LookupSlaves(obj.ClusterKey())
 	names := utils.StringSet{}
 	for _, s := range slaves {
@@ -158,10 +189,15 @@ func (this *sourceReconciler) Reconcile(logger logger.LogContext, obj resources.
 	}
 
 	this.state.SetDep(obj.ClusterKey(), this.usedRef(obj, info))
-	if !responsible {
-		if len(slaves) > 0 {
+	disqualified := len(slaves) > 0 && isDisqualified(info, responsible, err)
+	if disqualified {
+		switch {
+		case !responsible:
 			logger.Infof("not responsible anymore, but still found slaves (cleanup required): %v", resources.ObjectArrayToString(slaves...))
 			info = &DNSInfo{}
+		case info == nil:
+			logger.Infof("no dns info found anymore, but still found slaves (cleanup required): %v", resources.ObjectArrayToString(slaves...))
+			info = &DNSInfo{}
 		}
 	}
 
@@ -181,7 +217,7 @@ func (this *sourceReconciler) Reconcile(logger logger.LogContext, obj resources.
 		return reconcile.Succeeded(logger).Stop()
 	} else {
 		// if not responsible now  it was responsible, therefore cleanup the active state
-		err = this.annotations.SetActive(obj.ClusterKey(), responsible)
+		err = this.annotations.SetActive(obj.ClusterKey(), responsible && !disqualified)
 		if err != nil {
 			return reconcile.Delay(logger, err)
 		}
@@ -242,8 +278,16 @@ outer:
 	}
 	if len(obsolete_dns) > 0 {
 		logger.Infof("found obsolete dns entries: %s", obsolete_dns)
+		retain := disqualified && this.retainEntries(obj)
 		for _, o := range obsolete {
 			dnsname := dnsutils.DNSEntry(o).DNSEntry().Spec.DNSName
+			if retain {
+				err := this.orphanEntry(logger, obj, o, dnsname, feedback)
+				if err != nil {
+					notifiedErrors = append(notifiedErrors, fmt.Sprintf("cannot orphan dns entry object %q(%s): %s", o.ClusterKey(), dnsname, err))
+				}
+				continue
+			}
 			err := this.deleteEntry(logger, obj, o, dnsname, feedback)
 			if err != nil {
 				notifiedErrors = append(notifiedErrors, fmt.Sprintf("cannot remove dns entry object %q(%s): %s", o.ClusterKey(), dnsname, err))
@@ -425,6 +469,10 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 	if this.creatorLabelName != "" && this.creatorLabelValue != "" {
 		resources.SetLabel(entry, this.creatorLabelName, this.creatorLabelValue)
 	}
+	this.propagatedLabels.PropagateLabels(obj.Data(), entry)
+	this.propagatedAnnotations.PropagateAnnotations(obj.Data(), entry)
+	resources.SetAnnotation(entry, dns.SOURCE_ANNOTATION, dnsutils.SourceDescription(obj))
+	resources.SetLabel(entry, dns.SOURCE_UID_LABEL, string(obj.GetUID()))
 	if this.state.ownerState.ownerId != "" {
 		entry.Spec.OwnerId = &this.state.ownerState.ownerId
 	}
@@ -450,6 +498,7 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 		entry.Namespace = this.namespace
 	}
 	entry.Spec.TTL = info.TTL
+	resources.SetAnnotation(entry, dns.SOURCE_HASH_ANNOTATION, dnsutils.SourceSpecHash(&entry.Spec))
 
 	e, _ := this.SlaveResoures()[0].Wrap(entry)
 
@@ -472,11 +521,27 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 }
 
 func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resources.Object, info *DNSInfo, slave resources.Object) (bool, error) {
+	reverted := false
 	f := func(o resources.ObjectData) (bool, error) {
 		spec := &o.(*api.DNSEntry).Spec
 		mod := &utils.ModificationState{}
 		var changed bool
 
+		// compare the fields managed by the source with the hash of their last generation before overwriting them,
+		// foreign modifications are reverted, unless explicitly allowed
+		if hash := o.GetAnnotations()[dns.SOURCE_HASH_ANNOTATION]; hash != "" && hash != dnsutils.SourceSpecHash(spec) &&
+			o.GetAnnotations()[dns.SOURCE_OVERRIDE_ANNOTATION] != "true" {
+			reverted = true
+		}
+
+		source := dnsutils.SourceDescription(obj)
+		if cur := o.GetAnnotations()[dns.SOURCE_ANNOTATION]; cur != "" && cur != source &&
+			o.GetAnnotations()[dns.SOURCE_OVERRIDE_ANNOTATION] != "true" {
+			return false, fmt.Errorf("entry %s has been generated for source %s", slave.ObjectName(), cur)
+		}
+		mod.Modify(resources.SetAnnotation(o, dns.SOURCE_ANNOTATION, source))
+		mod.Modify(resources.SetLabel(o, dns.SOURCE_UID_LABEL, string(obj.GetUID())))
+
 		if !this.targetclasses.IsDefault() {
 			changed = resources.SetAnnotation(o, CLASS_ANNOTATION, this.targetclasses.Main())
 		} else {
@@ -505,6 +570,8 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 			}
 			mod.Modify(changed)
 		}
+		mod.Modify(this.propagatedLabels.PropagateLabels(obj.Data(), o))
+		mod.Modify(this.propagatedAnnotations.PropagateAnnotations(obj.Data(), o))
 		var p *string
 		if this.state.ownerState.ownerId != "" {
 			p = &this.state.ownerState.ownerId
@@ -532,12 +599,19 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 		}
 		mod.AssureStringSet(&spec.Targets, targets)
 		mod.AssureStringSet(&spec.Text, text)
+		mod.Modify(resources.SetAnnotation(o, dns.SOURCE_HASH_ANNOTATION, dnsutils.SourceSpecHash(spec)))
 		if mod.IsModified() {
 			logger.Infof("update entry %s", slave.ObjectName())
 		}
 		return mod.IsModified(), nil
 	}
-	return slave.Modify(f)
+	mod, err := slave.Modify(f)
+	if err == nil && reverted {
+		msg := fmt.Sprintf("reverted foreign modification of the spec managed by source %s", dnsutils.SourceDescription(obj))
+		logger.Warnf("entry %s: %s", slave.ObjectName(), msg)
+		slave.Event(core.EventTypeWarning, "sourcemodified", msg)
+	}
+	return mod, err
 }
 
 func (this *sourceReconciler) deleteEntry(logger logger.LogContext, obj resources.Object, e resources.Object, dnsname string, feedback DNSFeedback) error {
@@ -558,3 +632,67 @@ func (this *sourceReconciler) deleteEntry(logger logger.LogContext, obj resource
 	}
 	return err
 }
+
+// isDisqualified checks whether a source object does not qualify for DNS entries anymore,
+// i.e. it is not handled by this controller or does not request any DNS names.
+// Errors determining the DNS info do not disqualify a source object.
+func isDisqualified(info *DNSInfo, responsible bool, err error) bool {
+	switch {
+	case !responsible:
+		return true
+	case info == nil:
+		return err == nil
+	default:
+		return len(info.Names) == 0
+	}
+}
+
+// retainEntries checks whether the generated entries of a source object not qualifying
+// anymore should be kept. The annotation of the source object overrides the cleanup policy.
+func (this *sourceReconciler) retainEntries(obj resources.Object) bool {
+	switch obj.GetAnnotations()[RETAIN_ANNOTATION] {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return this.cleanupPolicy == CLEANUP_POLICY_ORPHAN
+}
+
+// orphanEntry releases a generated entry from its source object. The entry is kept
+// as unowned entry, which is not updated or deleted by the source controller anymore.
+func (this *sourceReconciler) orphanEntry(logger logger.LogContext, obj resources.Object, e resources.Object, dnsname string, feedback DNSFeedback) error {
+	source := dnsutils.SourceDescription(obj)
+	_, err := e.Modify(func(o resources.ObjectData) (bool, error) {
+		mod := releaseEntry(o, obj.GetOwnerReference(), source)
+		if w, err := this.SlaveResoures()[0].Wrap(o); err == nil && w.RemoveOwner(obj) {
+			mod = true
+		}
+		return mod, nil
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		logger.Errorf("cannot orphan dns entry object %s: %s", e.ObjectName(), err)
+		return err
+	}
+	e.Event(core.EventTypeNormal, "orphaned", fmt.Sprintf("orphaned from source %s", source))
+	msg := fmt.Sprintf("orphaned dns entry object %s (retained)", e.ObjectName())
+	if feedback != nil {
+		feedback.Deleted(logger, dnsname, msg)
+	} else {
+		logger.Info(msg)
+	}
+	return nil
+}
+
+// releaseEntry removes the owner reference and the source markers from a generated entry
+// and records the former source object.
+func releaseEntry(o resources.ObjectData, ownerRef *metav1.OwnerReference, source string) bool {
+	mod := resources.RemoveOwnerReference(o, ownerRef)
+	mod = resources.RemoveLabel(o, dns.SOURCE_UID_LABEL) || mod
+	mod = resources.RemoveAnnotation(o, dns.SOURCE_HASH_ANNOTATION) || mod
+	mod = resources.SetAnnotation(o, dns.ORPHANED_SOURCE_ANNOTATION, source) || mod
+	return mod
+}
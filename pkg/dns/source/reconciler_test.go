@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package source
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// testSourceObject provides the annotations of a source object without cluster access.
+type testSourceObject struct {
+	resources.Object
+	annotations map[string]string
+}
+
+func (o *testSourceObject) GetAnnotations() map[string]string {
+	return o.annotations
+}
+
+var _ = Describe("Disqualified source objects", func() {
+	Describe("isDisqualified", func() {
+		It("disqualifies source objects not handled anymore", func() {
+			Expect(isDisqualified(&DNSInfo{Names: utils.NewStringSet("a.example.com")}, false, nil)).To(BeTrue())
+			Expect(isDisqualified(nil, false, nil)).To(BeTrue())
+		})
+
+		It("disqualifies source objects without dns info", func() {
+			Expect(isDisqualified(nil, true, nil)).To(BeTrue())
+		})
+
+		It("disqualifies source objects without dns names", func() {
+			Expect(isDisqualified(&DNSInfo{}, true, nil)).To(BeTrue())
+			Expect(isDisqualified(&DNSInfo{Names: utils.StringSet{}}, true, nil)).To(BeTrue())
+		})
+
+		It("keeps source objects with dns names", func() {
+			Expect(isDisqualified(&DNSInfo{Names: utils.NewStringSet("a.example.com")}, true, nil)).To(BeFalse())
+		})
+
+		It("keeps source objects with invalid dns info", func() {
+			Expect(isDisqualified(nil, true, fmt.Errorf("invalid annotation"))).To(BeFalse())
+		})
+	})
+
+	Describe("retainEntries", func() {
+		source := func(annotations map[string]string) resources.Object {
+			return &testSourceObject{annotations: annotations}
+		}
+
+		It("follows the cleanup policy", func() {
+			Expect((&sourceReconciler{cleanupPolicy: CLEANUP_POLICY_DELETE}).retainEntries(source(nil))).To(BeFalse())
+			Expect((&sourceReconciler{cleanupPolicy: CLEANUP_POLICY_ORPHAN}).retainEntries(source(nil))).To(BeTrue())
+		})
+
+		It("lets the annotation override the cleanup policy", func() {
+			Expect((&sourceReconciler{cleanupPolicy: CLEANUP_POLICY_DELETE}).retainEntries(source(map[string]string{RETAIN_ANNOTATION: "true"}))).To(BeTrue())
+			Expect((&sourceReconciler{cleanupPolicy: CLEANUP_POLICY_ORPHAN}).retainEntries(source(map[string]string{RETAIN_ANNOTATION: "false"}))).To(BeFalse())
+		})
+
+		It("ignores invalid annotation values", func() {
+			Expect((&sourceReconciler{cleanupPolicy: CLEANUP_POLICY_ORPHAN}).retainEntries(source(map[string]string{RETAIN_ANNOTATION: "yes"}))).To(BeTrue())
+		})
+	})
+
+	Describe("releaseEntry", func() {
+		var (
+			entry    *api.DNSEntry
+			ownerRef *metav1.OwnerReference
+		)
+
+		BeforeEach(func() {
+			ownerRef = &metav1.OwnerReference{APIVersion: "v1", Kind: "Service", Name: "svc", UID: "source-uid"}
+			entry = &api.DNSEntry{}
+			entry.OwnerReferences = []metav1.OwnerReference{
+				{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"},
+				*ownerRef,
+			}
+			entry.Labels = map[string]string{dns.SOURCE_UID_LABEL: "source-uid", "app": "test"}
+			entry.Annotations = map[string]string{dns.SOURCE_HASH_ANNOTATION: "hash", "note": "kept"}
+		})
+
+		It("releases the entry from its source object", func() {
+			Expect(releaseEntry(entry, ownerRef, "/Service/default/svc")).To(BeTrue())
+			Expect(entry.OwnerReferences).To(Equal([]metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "other", UID: "other-uid"}}))
+			Expect(entry.Labels).To(Equal(map[string]string{"app": "test"}))
+			Expect(entry.Annotations).To(Equal(map[string]string{dns.ORPHANED_SOURCE_ANNOTATION: "/Service/default/svc", "note": "kept"}))
+		})
+
+		It("reports no modification for released entries", func() {
+			Expect(releaseEntry(entry, ownerRef, "/Service/default/svc")).To(BeTrue())
+			Expect(releaseEntry(entry, ownerRef, "/Service/default/svc")).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,21 @@
+/*
+ * SPDX-FileCopyrightText: 2020 SAP SE or an SAP affiliate company and Gardener contributors
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ *
+ *
+ */
+
+package source
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSourceSuite(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Source Suite")
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TXT_CHUNK_SIZE is the maximum length of a single character string of a TXT record.
+const TXT_CHUNK_SIZE = 255
+
+// TXT_MAX_LENGTH is the maximum length of the text of a TXT record supported by all providers.
+const TXT_MAX_LENGTH = 4000
+
+// TextValue returns the value of a TXT record for the given text.
+// Texts longer than TXT_CHUNK_SIZE are split into multiple quoted character strings.
+func TextValue(text string) string {
+	var chunks []string
+	for len(text) > TXT_CHUNK_SIZE {
+		n := TXT_CHUNK_SIZE
+		// don't split multi-byte characters
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		chunks = append(chunks, strconv.Quote(text[:n]))
+		text = text[n:]
+	}
+	return strings.Join(append(chunks, strconv.Quote(text)), " ")
+}
+
+// SplitTextValue returns the unquoted character strings of the value of a TXT record.
+func SplitTextValue(value string) ([]string, error) {
+	var chunks []string
+	rest := strings.TrimSpace(value)
+	for rest != "" {
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil || quoted[0] != '"' {
+			return nil, fmt.Errorf("TXT record %q must consist of quoted strings", value)
+		}
+		chunk, _ := strconv.Unquote(quoted)
+		chunks = append(chunks, chunk)
+		rest = strings.TrimLeft(rest[len(quoted):], " \t")
+	}
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("TXT record must not be empty")
+	}
+	return chunks, nil
+}
+
+// ValidateText validates the text of a TXT record.
+func ValidateText(text string) error {
+	if text == "" {
+		return fmt.Errorf("text must not be empty")
+	}
+	if len(text) > TXT_MAX_LENGTH {
+		return fmt.Errorf("text has %d characters, but at most %d are supported", len(text), TXT_MAX_LENGTH)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTextValue(t *testing.T) {
+	dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBIjANBgkqhkiG9w0BAQEFAAOCAQ8AMIIBCgKCAQEA", 9)
+	table := []struct {
+		text   string
+		chunks []string
+	}{
+		{"any text", []string{"any text"}},
+		{"with \"quotes\"", []string{"with \"quotes\""}},
+		{strings.Repeat("a", 255), []string{strings.Repeat("a", 255)}},
+		{strings.Repeat("a", 256), []string{strings.Repeat("a", 255), "a"}},
+		{strings.Repeat("a", 254) + "äb", []string{strings.Repeat("a", 254), "äb"}},
+		{dkim, []string{dkim[:255], dkim[255:]}},
+	}
+	for _, entry := range table {
+		value := TextValue(entry.text)
+		chunks, err := SplitTextValue(value)
+		if err != nil {
+			t.Errorf("%q: unexpected error %s", entry.text, err)
+			continue
+		}
+		if !reflect.DeepEqual(chunks, entry.chunks) {
+			t.Errorf("%q: expected chunks %q, but got %q", entry.text, entry.chunks, chunks)
+		}
+		if strings.Join(chunks, "") != entry.text {
+			t.Errorf("%q: chunks %q do not match text", entry.text, chunks)
+		}
+	}
+	if value := TextValue("any text"); value != `"any text"` {
+		t.Errorf("unexpected value %s", value)
+	}
+}
+
+func TestSplitTextValue(t *testing.T) {
+	table := []struct {
+		value string
+		ok    bool
+	}{
+		{`"a"`, true},
+		{`"a" "b"`, true},
+		{`"a"  "b c"`, true},
+		{`a`, false},
+		{`"a" b`, false},
+		{`"a`, false},
+		{``, false},
+	}
+	for _, entry := range table {
+		_, err := SplitTextValue(entry.value)
+		if entry.ok && err != nil {
+			t.Errorf("%s should be ok, but got error %s", entry.value, err)
+		} else if !entry.ok && err == nil {
+			t.Errorf("%s should not be ok, but got no error", entry.value)
+		}
+	}
+}
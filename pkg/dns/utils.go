@@ -29,6 +29,19 @@ func SupportedRecordType(t string) bool {
 	return false
 }
 
+// ManagedRecordTypes are all record types which can be managed with DNS entries.
+var ManagedRecordTypes = []string{RS_A, RS_AAAA, RS_CNAME, RS_TXT, RS_SRV, RS_SSHFP, RS_NAPTR, RS_SVCB, RS_HTTPS, RS_CAA}
+
+// StructuredRecordType returns true for record types which are specified with a structured
+// specification in the DNSEntry spec and are only supported by some providers.
+func StructuredRecordType(t string) bool {
+	switch t {
+	case RS_SRV, RS_SSHFP, RS_NAPTR, RS_SVCB, RS_HTTPS, RS_CAA:
+		return true
+	}
+	return false
+}
+
 func DNSNameMatcher(dnsname string) resources.ObjectMatcher {
 	return func(o resources.Object) bool {
 		return o.Data().(*api.DNSEntry).Spec.DNSName == dnsname
@@ -0,0 +1,240 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// StructuredRecord is a record of a record type with structured specification in the
+// DNSEntry spec, given as value in zone file presentation format.
+type StructuredRecord struct {
+	Type  string
+	Value string
+}
+
+// StructuredRecordsFromEntrySpec validates the records with structured specification
+// and returns them in presentation format.
+func StructuredRecordsFromEntrySpec(spec *api.DNSEntrySpec) ([]StructuredRecord, error) {
+	var records []StructuredRecord
+	for i, r := range spec.TXT {
+		if err := dns.ValidateText(r.Value); err != nil {
+			return nil, fmt.Errorf("invalid txt record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_TXT, Value: dns.TextValue(r.Value)})
+	}
+	for i, r := range spec.SRV {
+		value := fmt.Sprintf("%d %d %d %s", r.Priority, r.Weight, r.Port, alignTarget(r.Target))
+		if err := dns.ValidateRecordValue(dns.RS_SRV, value); err != nil {
+			return nil, fmt.Errorf("invalid srv record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_SRV, Value: value})
+	}
+	for i, r := range spec.SSHFP {
+		value, err := sshfpValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sshfp record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_SSHFP, Value: value})
+	}
+	for i, r := range spec.NAPTR {
+		value, err := naptrValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid naptr record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_NAPTR, Value: value})
+	}
+	for i, r := range spec.SVCB {
+		value, err := svcbValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid svcb record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_SVCB, Value: value})
+	}
+	for i, r := range spec.HTTPS {
+		value, err := svcbValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid https record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_HTTPS, Value: value})
+	}
+	for i, r := range spec.CAA {
+		value := dns.CAAValue(r.Flags, r.Tag, r.Value)
+		if err := dns.ValidateRecordValue(dns.RS_CAA, value); err != nil {
+			return nil, fmt.Errorf("invalid caa record %d: %w", i+1, err)
+		}
+		records = append(records, StructuredRecord{Type: dns.RS_CAA, Value: value})
+	}
+	return records, nil
+}
+
+func alignTarget(target string) string {
+	if target == "" || target == "." {
+		return "."
+	}
+	return dns.AlignHostname(target)
+}
+
+func sshfpValue(r api.SSHFPRecord) (string, error) {
+	switch r.Algorithm {
+	case 1, 2, 3, 4, 6:
+	default:
+		return "", fmt.Errorf("unsupported algorithm %d", r.Algorithm)
+	}
+	length := 0
+	switch r.FingerprintType {
+	case 1:
+		length = 20
+	case 2:
+		length = 32
+	default:
+		return "", fmt.Errorf("unsupported fingerprint type %d", r.FingerprintType)
+	}
+	fingerprint := strings.ToLower(strings.TrimSpace(r.Fingerprint))
+	data, err := hex.DecodeString(fingerprint)
+	if err != nil {
+		return "", fmt.Errorf("fingerprint is no hexadecimal string")
+	}
+	if len(data) != length {
+		return "", fmt.Errorf("fingerprint has %d bytes, but fingerprint type %d requires %d", len(data), r.FingerprintType, length)
+	}
+	return fmt.Sprintf("%d %d %s", r.Algorithm, r.FingerprintType, fingerprint), nil
+}
+
+func naptrValue(r api.NAPTRRecord) (string, error) {
+	if r.Order < 0 || r.Order > 65535 {
+		return "", fmt.Errorf("order %d out of range", r.Order)
+	}
+	if r.Preference < 0 || r.Preference > 65535 {
+		return "", fmt.Errorf("preference %d out of range", r.Preference)
+	}
+	for _, c := range r.Flags {
+		if !(c >= 'A' && c <= 'Z' || c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return "", fmt.Errorf("flags must be alphanumeric")
+		}
+	}
+	for name, v := range map[string]string{"service": r.Service, "regexp": r.Regexp} {
+		if strings.ContainsAny(v, "\"\\") {
+			return "", fmt.Errorf("%s must not contain quotes or backslashes", name)
+		}
+	}
+	replacement := "."
+	if r.Replacement != "" && r.Replacement != "." {
+		if r.Regexp != "" {
+			return "", fmt.Errorf("either regexp or replacement can be specified")
+		}
+		replacement = dns.AlignHostname(r.Replacement)
+	}
+	return fmt.Sprintf("%d %d %q %q %q %s", r.Order, r.Preference, strings.ToUpper(r.Flags), r.Service, r.Regexp, replacement), nil
+}
+
+var svcbParamKeys = []string{"mandatory", "alpn", "no-default-alpn", "port", "ipv4hint", "ech", "ipv6hint"}
+
+func svcbValue(r api.SVCBRecord) (string, error) {
+	if r.Priority < 0 || r.Priority > 65535 {
+		return "", fmt.Errorf("priority %d out of range", r.Priority)
+	}
+	target := "."
+	if r.Target != "" && r.Target != "." {
+		target = dns.AlignHostname(r.Target)
+	}
+	params, err := svcbParams(r.Params)
+	if err != nil {
+		return "", err
+	}
+	if r.Priority == 0 && len(params) > 0 {
+		return "", fmt.Errorf("service parameters not allowed in alias mode (priority 0)")
+	}
+	return strings.Join(append([]string{fmt.Sprintf("%d", r.Priority), target}, params...), " "), nil
+}
+
+// svcbParams returns the service parameters in presentation format ordered by their key numbers.
+func svcbParams(p *api.SVCBParams) ([]string, error) {
+	if p == nil {
+		return nil, nil
+	}
+	values := map[string]string{}
+	if len(p.ALPN) > 0 {
+		for _, id := range p.ALPN {
+			if id == "" || strings.ContainsAny(id, ",\"\\ ") {
+				return nil, fmt.Errorf("invalid alpn id %q", id)
+			}
+		}
+		values["alpn"] = fmt.Sprintf("%q", strings.Join(p.ALPN, ","))
+	}
+	if p.NoDefaultALPN {
+		if len(p.ALPN) == 0 {
+			return nil, fmt.Errorf("no-default-alpn requires alpn")
+		}
+		values["no-default-alpn"] = ""
+	}
+	if p.Port != nil {
+		if *p.Port < 0 || *p.Port > 65535 {
+			return nil, fmt.Errorf("port %d out of range", *p.Port)
+		}
+		values["port"] = fmt.Sprintf("%d", *p.Port)
+	}
+	if len(p.IPv4Hint) > 0 {
+		for _, a := range p.IPv4Hint {
+			if ip := net.ParseIP(a); ip == nil || ip.To4() == nil {
+				return nil, fmt.Errorf("invalid ipv4hint %q", a)
+			}
+		}
+		values["ipv4hint"] = strings.Join(p.IPv4Hint, ",")
+	}
+	if p.ECH != "" {
+		if _, err := base64.StdEncoding.DecodeString(p.ECH); err != nil {
+			return nil, fmt.Errorf("ech is no base64 encoded string")
+		}
+		values["ech"] = p.ECH
+	}
+	if len(p.IPv6Hint) > 0 {
+		for _, a := range p.IPv6Hint {
+			if ip := net.ParseIP(a); ip == nil || ip.To4() != nil {
+				return nil, fmt.Errorf("invalid ipv6hint %q", a)
+			}
+		}
+		values["ipv6hint"] = strings.Join(p.IPv6Hint, ",")
+	}
+	if len(p.Mandatory) > 0 {
+		for _, k := range p.Mandatory {
+			if _, ok := values[k]; !ok {
+				return nil, fmt.Errorf("mandatory key %q not specified as parameter", k)
+			}
+		}
+		values["mandatory"] = strings.Join(p.Mandatory, ",")
+	}
+
+	var params []string
+	for _, k := range svcbParamKeys {
+		if v, ok := values[k]; ok {
+			if v == "" {
+				params = append(params, k)
+			} else {
+				params = append(params, k+"="+v)
+			}
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Structured records", func() {
+	It("builds TXT records with split character strings", func() {
+		dkim := "v=DKIM1; k=rsa; p=" + strings.Repeat("MIIBCgKCAQEA", 30)
+		spec := &api.DNSEntrySpec{TXT: []api.TXTRecord{{Value: "short text"}, {Value: dkim}}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_TXT, Value: `"short text"`},
+			{Type: dns.RS_TXT, Value: `"` + dkim[:255] + `" "` + dkim[255:] + `"`},
+		}))
+	})
+
+	It("rejects empty or too long TXT records", func() {
+		for _, r := range []api.TXTRecord{{Value: ""}, {Value: strings.Repeat("a", 4001)}} {
+			_, err := StructuredRecordsFromEntrySpec(&api.DNSEntrySpec{TXT: []api.TXTRecord{r}})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("builds SRV records in presentation format", func() {
+		spec := &api.DNSEntrySpec{SRV: []api.SRVRecord{
+			{Priority: 10, Weight: 60, Port: 5060, Target: "sip1.example.com"},
+			{Priority: 0, Weight: 0, Port: 0, Target: "."},
+		}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_SRV, Value: "10 60 5060 sip1.example.com."},
+			{Type: dns.RS_SRV, Value: "0 0 0 ."},
+		}))
+	})
+
+	It("rejects SRV records with invalid port or target", func() {
+		for _, r := range []api.SRVRecord{
+			{Priority: 10, Weight: 60, Port: 65536, Target: "sip1.example.com"},
+			{Priority: 10, Weight: 60, Port: 5060, Target: "sip1.example.com:5060"},
+		} {
+			_, err := StructuredRecordsFromEntrySpec(&api.DNSEntrySpec{SRV: []api.SRVRecord{r}})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("builds SSHFP records in presentation format", func() {
+		spec := &api.DNSEntrySpec{SSHFP: []api.SSHFPRecord{
+			{Algorithm: 4, FingerprintType: 2, Fingerprint: "ECF6A7E8D6F2C3B1A09F8E7D6C5B4A39281706F5E4D3C2B1A0998877665544AB"},
+		}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_SSHFP, Value: "4 2 ecf6a7e8d6f2c3b1a09f8e7d6c5b4a39281706f5e4d3c2b1a0998877665544ab"},
+		}))
+	})
+
+	It("rejects SSHFP fingerprints with wrong length", func() {
+		spec := &api.DNSEntrySpec{SSHFP: []api.SSHFPRecord{{Algorithm: 1, FingerprintType: 1, Fingerprint: "abcd"}}}
+		_, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("builds NAPTR records in presentation format", func() {
+		spec := &api.DNSEntrySpec{NAPTR: []api.NAPTRRecord{
+			{Order: 100, Preference: 10, Flags: "u", Service: "E2U+sip", Regexp: "!^.*$!sip:info@example.com!"},
+			{Order: 100, Preference: 20, Flags: "S", Service: "SIP+D2U", Replacement: "_sip._udp.example.com"},
+		}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_NAPTR, Value: `100 10 "U" "E2U+sip" "!^.*$!sip:info@example.com!" .`},
+			{Type: dns.RS_NAPTR, Value: `100 20 "S" "SIP+D2U" "" _sip._udp.example.com.`},
+		}))
+	})
+
+	It("rejects NAPTR records with both regexp and replacement", func() {
+		spec := &api.DNSEntrySpec{NAPTR: []api.NAPTRRecord{{Regexp: "!^.*$!x!", Replacement: "example.com"}}}
+		_, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("builds HTTPS records with ordered service parameters", func() {
+		port := 8443
+		spec := &api.DNSEntrySpec{HTTPS: []api.SVCBRecord{
+			{Priority: 1, Params: &api.SVCBParams{
+				IPv6Hint:  []string{"2001:db8::1"},
+				Port:      &port,
+				ALPN:      []string{"h3", "h2"},
+				IPv4Hint:  []string{"1.2.3.4", "5.6.7.8"},
+				Mandatory: []string{"alpn"},
+			}},
+			{Priority: 0, Target: "svc.example.com"},
+		}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_HTTPS, Value: `1 . mandatory=alpn alpn="h3,h2" port=8443 ipv4hint=1.2.3.4,5.6.7.8 ipv6hint=2001:db8::1`},
+			{Type: dns.RS_HTTPS, Value: `0 svc.example.com.`},
+		}))
+	})
+
+	It("rejects invalid SVCB records", func() {
+		for _, r := range []api.SVCBRecord{
+			{Priority: 0, Params: &api.SVCBParams{ALPN: []string{"h2"}}},
+			{Priority: 1, Params: &api.SVCBParams{IPv4Hint: []string{"2001:db8::1"}}},
+			{Priority: 1, Params: &api.SVCBParams{ECH: "not base64!"}},
+			{Priority: 1, Params: &api.SVCBParams{Mandatory: []string{"port"}}},
+			{Priority: 1, Params: &api.SVCBParams{NoDefaultALPN: true}},
+		} {
+			_, err := StructuredRecordsFromEntrySpec(&api.DNSEntrySpec{SVCB: []api.SVCBRecord{r}})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("builds CAA records in presentation format", func() {
+		spec := &api.DNSEntrySpec{CAA: []api.CAARecord{
+			{Tag: "issue", Value: "letsencrypt.org"},
+			{Tag: "issueWild", Value: ";"},
+			{Flags: 128, Tag: "iodef", Value: "mailto:security@example.com"},
+		}}
+		records, err := StructuredRecordsFromEntrySpec(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(records).To(Equal([]StructuredRecord{
+			{Type: dns.RS_CAA, Value: `0 issue "letsencrypt.org"`},
+			{Type: dns.RS_CAA, Value: `0 issuewild ";"`},
+			{Type: dns.RS_CAA, Value: `128 iodef "mailto:security@example.com"`},
+		}))
+	})
+
+	It("rejects invalid CAA records", func() {
+		for _, r := range []api.CAARecord{
+			{Flags: 256, Tag: "issue", Value: "letsencrypt.org"},
+			{Tag: "", Value: "letsencrypt.org"},
+			{Tag: "issue", Value: `letsencrypt.org"`},
+		} {
+			_, err := StructuredRecordsFromEntrySpec(&api.DNSEntrySpec{CAA: []api.CAARecord{r}})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+})
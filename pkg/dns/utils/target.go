@@ -20,6 +20,7 @@ package utils
 import (
 	"fmt"
 
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 )
 
@@ -51,6 +52,15 @@ func (this Targets) DifferFrom(targets Targets) bool {
 	return false
 }
 
+func (this Targets) HasResourceReference() bool {
+	for _, t := range this {
+		if t.GetRecordType() == dns.RS_RESOURCE {
+			return true
+		}
+	}
+	return false
+}
+
 type Target interface {
 	GetHostName() string
 	GetRecordType() string
@@ -65,7 +75,7 @@ type target struct {
 }
 
 func NewText(t string, ttl int64) Target {
-	return NewTarget(dns.RS_TXT, fmt.Sprintf("%q", t), ttl)
+	return NewTarget(dns.RS_TXT, dns.TextValue(t), ttl)
 }
 
 func NewTarget(ty string, ta string, ttl int64) Target {
@@ -91,14 +101,20 @@ func (t *target) String() string {
 type TargetSpec interface {
 	Kind() string
 	OwnerId() string
+	OwnerGroup() string
 	Targets() []Target
+	RoutingPolicy() *dns.RoutingPolicy
+	ProviderHints() *dns.ProviderHints
 	Responsible(set *dns.DNSSet, ownership dns.Ownership) bool
 }
 
 type targetSpec struct {
-	kind    string
-	ownerId string
-	targets []Target
+	kind          string
+	ownerId       string
+	ownerGroup    string
+	targets       []Target
+	routingPolicy *dns.RoutingPolicy
+	providerHints *dns.ProviderHints
 }
 
 func BaseTargetSpec(entry DNSSpecification, p TargetProvider) TargetSpec {
@@ -107,9 +123,35 @@ func BaseTargetSpec(entry DNSSpecification, p TargetProvider) TargetSpec {
 		ownerId: p.OwnerId(),
 		targets: p.Targets(),
 	}
+	if group := entry.GetOwnerGroup(); group != nil {
+		spec.ownerGroup = *group
+	}
+	if rp := entry.GetRoutingPolicy(); rp != nil {
+		spec.routingPolicy = dns.NewRoutingPolicy(rp.Type, rp.Parameters).Clone()
+		spec.routingPolicy.SetIdentifier = rp.SetIdentifier
+	}
+	spec.providerHints = ProviderHints(entry.GetProviderHints())
 	return spec
 }
 
+// ProviderHints converts the provider hints of a specification, nil is returned if no hint is set.
+func ProviderHints(hints *api.ProviderHints) *dns.ProviderHints {
+	if hints == nil {
+		return nil
+	}
+	result := &dns.ProviderHints{}
+	if hints.Cloudflare != nil && hints.Cloudflare.Proxied != nil {
+		result.CloudflareProxied = *hints.Cloudflare.Proxied
+	}
+	if hints.UltraDNS != nil && hints.UltraDNS.PoolOrder != nil {
+		result.UltraDNSPoolOrder = *hints.UltraDNS.PoolOrder
+	}
+	if result.IsEmpty() {
+		return nil
+	}
+	return result
+}
+
 func (this *targetSpec) Kind() string {
 	return this.kind
 }
@@ -118,10 +160,22 @@ func (this *targetSpec) OwnerId() string {
 	return this.ownerId
 }
 
+func (this *targetSpec) OwnerGroup() string {
+	return this.ownerGroup
+}
+
 func (this *targetSpec) Targets() []Target {
 	return this.targets
 }
 
+func (this *targetSpec) RoutingPolicy() *dns.RoutingPolicy {
+	return this.routingPolicy
+}
+
+func (this *targetSpec) ProviderHints() *dns.ProviderHints {
+	return this.providerHints
+}
+
 func (this *targetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bool {
 	return !set.IsForeign(ownership)
 }
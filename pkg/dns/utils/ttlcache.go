@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+type ttlCacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+// TTLCache is a thread-safe key/value cache with a fixed time-to-live for all entries.
+// It is intended for auxiliary provider lookups which are repeated for many entries.
+type TTLCache struct {
+	lock      sync.Mutex
+	ttl       time.Duration
+	entries   map[string]ttlCacheEntry
+	lastPurge time.Time
+	now       func() time.Time
+}
+
+func NewTTLCache(ttl time.Duration) *TTLCache {
+	return &TTLCache{
+		ttl:     ttl,
+		entries: map[string]ttlCacheEntry{},
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached value for the given key if it is not expired.
+func (this *TTLCache) Get(key string) (interface{}, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.get(key)
+}
+
+func (this *TTLCache) get(key string) (interface{}, bool) {
+	e, ok := this.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if this.now().After(e.expires) {
+		delete(this.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores a value for the given key.
+func (this *TTLCache) Set(key string, value interface{}) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.set(key, value)
+}
+
+func (this *TTLCache) set(key string, value interface{}) {
+	now := this.now()
+	if now.After(this.lastPurge.Add(this.ttl)) {
+		for k, e := range this.entries {
+			if now.After(e.expires) {
+				delete(this.entries, k)
+			}
+		}
+		this.lastPurge = now
+	}
+	this.entries[key] = ttlCacheEntry{value: value, expires: now.Add(this.ttl)}
+}
+
+// GetOrCompute returns the cached value for the given key or calls the lookup
+// function to fill the cache. Errors of the lookup are not cached.
+func (this *TTLCache) GetOrCompute(key string, lookup func() (interface{}, error)) (interface{}, error) {
+	this.lock.Lock()
+	if v, ok := this.get(key); ok {
+		this.lock.Unlock()
+		return v, nil
+	}
+	this.lock.Unlock()
+
+	v, err := lookup()
+	if err != nil {
+		return nil, err
+	}
+	this.Set(key, v)
+	return v, nil
+}
+
+// Invalidate removes the entry for the given key.
+func (this *TTLCache) Invalidate(key string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.entries, key)
+}
+
+// Len returns the number of cached entries including expired ones not purged yet.
+func (this *TTLCache) Len() int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return len(this.entries)
+}
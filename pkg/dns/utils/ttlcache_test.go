@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TTLCache", func() {
+	var (
+		now   time.Time
+		cache *TTLCache
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		cache = NewTTLCache(time.Minute)
+		cache.now = func() time.Time { return now }
+	})
+
+	It("expires entries after the ttl", func() {
+		cache.Set("a", "x")
+		v, ok := cache.Get("a")
+		Expect(ok).To(BeTrue())
+		Expect(v).To(Equal("x"))
+
+		now = now.Add(2 * time.Minute)
+		_, ok = cache.Get("a")
+		Expect(ok).To(BeFalse())
+		Expect(cache.Len()).To(Equal(0))
+	})
+
+	It("computes missing values once and does not cache errors", func() {
+		calls := 0
+		lookup := func() (interface{}, error) {
+			calls++
+			return calls, nil
+		}
+		v, err := cache.GetOrCompute("a", lookup)
+		Expect(err).To(BeNil())
+		Expect(v).To(Equal(1))
+		v, err = cache.GetOrCompute("a", lookup)
+		Expect(err).To(BeNil())
+		Expect(v).To(Equal(1))
+
+		_, err = cache.GetOrCompute("b", func() (interface{}, error) { return nil, fmt.Errorf("failed") })
+		Expect(err).NotTo(BeNil())
+		_, ok := cache.Get("b")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("purges expired entries on set", func() {
+		cache.Set("a", 1)
+		now = now.Add(2 * time.Minute)
+		cache.Set("b", 2)
+		Expect(cache.Len()).To(Equal(1))
+	})
+})
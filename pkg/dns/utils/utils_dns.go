@@ -35,10 +35,14 @@ type DNSSpecification interface {
 	GetDNSName() string
 	GetTTL() *int64
 	GetOwnerId() *string
+	GetOwnerGroup() *string
 	GetTargets() []string
 	GetText() []string
 	GetCNameLookupInterval() *int64
 	GetReference() *api.EntryReference
+	GetRoutingPolicy() *api.RoutingPolicy
+	GetProviderHints() *api.ProviderHints
+	GetStructuredRecords() ([]StructuredRecord, error)
 	BaseStatus() *api.DNSBaseStatus
 
 	GetTargetSpec(TargetProvider) TargetSpec
@@ -17,12 +17,18 @@
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
 )
 
 var _ DNSSpecification = (*DNSEntryObject)(nil)
@@ -72,6 +78,9 @@ func (this *DNSEntryObject) GetText() []string {
 func (this *DNSEntryObject) GetOwnerId() *string {
 	return this.DNSEntry().Spec.OwnerId
 }
+func (this *DNSEntryObject) GetOwnerGroup() *string {
+	return this.DNSEntry().Spec.OwnerGroup
+}
 func (this *DNSEntryObject) GetTTL() *int64 {
 	return this.DNSEntry().Spec.TTL
 }
@@ -81,15 +90,125 @@ func (this *DNSEntryObject) GetCNameLookupInterval() *int64 {
 func (this *DNSEntryObject) GetReference() *api.EntryReference {
 	return this.DNSEntry().Spec.Reference
 }
+func (this *DNSEntryObject) GetRoutingPolicy() *api.RoutingPolicy {
+	return this.DNSEntry().Spec.RoutingPolicy
+}
+func (this *DNSEntryObject) GetProviderHints() *api.ProviderHints {
+	return this.DNSEntry().Spec.ProviderHints
+}
+func (this *DNSEntryObject) GetStructuredRecords() ([]StructuredRecord, error) {
+	return StructuredRecordsFromEntrySpec(&this.DNSEntry().Spec)
+}
 
 func (this *DNSEntryObject) RefreshTime() time.Time {
 	return time.Time{}
 }
 
 func (this *DNSEntryObject) ValidateSpecial() error {
+	return this.ValidateSource()
+}
+
+// GetSource returns the source object description and uid recorded for entries generated by a source controller.
+func (this *DNSEntryObject) GetSource() (string, string) {
+	return this.GetAnnotations()[dns.SOURCE_ANNOTATION], this.GetLabels()[dns.SOURCE_UID_LABEL]
+}
+
+// ValidateSource checks that the spec of an entry generated by a source controller
+// has not been modified by someone else. Modifications can explicitly be allowed
+// by the override annotation.
+func (this *DNSEntryObject) ValidateSource() error {
+	hash := this.GetAnnotations()[dns.SOURCE_HASH_ANNOTATION]
+	if hash == "" || this.GetAnnotations()[dns.SOURCE_OVERRIDE_ANNOTATION] == "true" {
+		return nil
+	}
+	source, _ := this.GetSource()
+	if hash != SourceSpecHash(this.Spec()) {
+		return fmt.Errorf("spec has been modified outside of source %q (set annotation %s=true to allow)", source, dns.SOURCE_OVERRIDE_ANNOTATION)
+	}
+	if fields := SourceUnmanagedFields(this.Spec()); len(fields) > 0 {
+		return fmt.Errorf("spec fields %s have been set outside of source %q (set annotation %s=true to allow)",
+			strings.Join(fields, ", "), source, dns.SOURCE_OVERRIDE_ANNOTATION)
+	}
 	return nil
 }
 
+// sourceManagedSpec are the fields of an entry spec set by the source controllers.
+// All other fields of the entry spec are considered to be set by someone else,
+// so a field added to the entry spec must also be added here if it is set by the source controllers.
+type sourceManagedSpec struct {
+	DNSName             string              `json:"dnsName"`
+	Reference           *api.EntryReference `json:"reference,omitempty"`
+	OwnerId             *string             `json:"ownerId,omitempty"`
+	TTL                 *int64              `json:"ttl,omitempty"`
+	CNameLookupInterval *int64              `json:"cnameLookupInterval,omitempty"`
+	Text                []string            `json:"text,omitempty"`
+	Targets             []string            `json:"targets,omitempty"`
+}
+
+// sourceUnmanagedFields are the indices of the fields of the entry spec missing in sourceManagedSpec.
+var sourceUnmanagedFields = func() []int {
+	spec := reflect.TypeOf(api.DNSEntrySpec{})
+	managed := reflect.TypeOf(sourceManagedSpec{})
+	for i := 0; i < managed.NumField(); i++ {
+		f := managed.Field(i)
+		if sf, ok := spec.FieldByName(f.Name); !ok || sf.Type != f.Type || sf.Tag != f.Tag {
+			panic(fmt.Sprintf("source managed field %s does not match the DNSEntrySpec", f.Name))
+		}
+	}
+	var fields []int
+	for i := 0; i < spec.NumField(); i++ {
+		if _, ok := managed.FieldByName(spec.Field(i).Name); !ok {
+			fields = append(fields, i)
+		}
+	}
+	return fields
+}()
+
+// SourceSpecHash calculates the hash of the fields of an entry spec set by the source controllers.
+// It is used to detect foreign modifications of entries generated by source controllers.
+func SourceSpecHash(spec *api.DNSEntrySpec) string {
+	managed := sourceManagedSpec{}
+	mv := reflect.ValueOf(&managed).Elem()
+	sv := reflect.ValueOf(spec).Elem()
+	for i := 0; i < mv.NumField(); i++ {
+		mv.Field(i).Set(sv.FieldByName(mv.Type().Field(i).Name))
+	}
+	data, err := json.Marshal(&managed)
+	if err != nil {
+		return ""
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
+
+// SourceUnmanagedFields returns the json names of the fields of an entry spec set although they are never set
+// by the source controllers. For generated entries, they can only be set by someone else.
+func SourceUnmanagedFields(spec *api.DNSEntrySpec) []string {
+	var fields []string
+	v := reflect.ValueOf(spec).Elem()
+	for _, i := range sourceUnmanagedFields {
+		if isSet(v.Field(i)) {
+			fields = append(fields, strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0])
+		}
+	}
+	return fields
+}
+
+// isSet checks whether a field is set, empty slices and maps are considered unset.
+func isSet(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() > 0
+	default:
+		return !v.IsZero()
+	}
+}
+
+// SourceDescription returns the description of a source object as used for the source annotation.
+func SourceDescription(obj resources.Object) string {
+	return fmt.Sprintf("%s/%s/%s", obj.GroupKind(), obj.GetNamespace(), obj.GetName())
+}
+
 func (this *DNSEntryObject) AcknowledgeTargets(targets []string) bool {
 	s := this.Status()
 	if !reflect.DeepEqual(s.Targets, targets) {
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// testEntryObject provides the entry data of a DNSEntryObject without cluster access.
+type testEntryObject struct {
+	resources.Object
+	entry *api.DNSEntry
+}
+
+func (o *testEntryObject) Data() resources.ObjectData {
+	return o.entry
+}
+
+func (o *testEntryObject) GetAnnotations() map[string]string {
+	return o.entry.Annotations
+}
+
+func (o *testEntryObject) GetLabels() map[string]string {
+	return o.entry.Labels
+}
+
+func entryObject(e *api.DNSEntry) *DNSEntryObject {
+	return &DNSEntryObject{&testEntryObject{entry: e}}
+}
+
+var _ = Describe("Source provenance", func() {
+	ttl := int64(300)
+	generated := func() *api.DNSEntry {
+		e := &api.DNSEntry{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "e1",
+				Namespace:   "default",
+				Annotations: map[string]string{dns.SOURCE_ANNOTATION: "Service/default/svc"},
+			},
+			Spec: api.DNSEntrySpec{DNSName: "www.example.com", TTL: &ttl, Targets: []string{"1.2.3.4"}},
+		}
+		e.Annotations[dns.SOURCE_HASH_ANNOTATION] = SourceSpecHash(&e.Spec)
+		return e
+	}
+
+	Context("SourceSpecHash", func() {
+		It("covers the fields set by the source", func() {
+			e := generated()
+			hash := SourceSpecHash(&e.Spec)
+			Expect(hash).To(HaveLen(64))
+
+			changed := e.Spec.DeepCopy()
+			changed.Targets = []string{"5.6.7.8"}
+			Expect(SourceSpecHash(changed)).NotTo(Equal(hash))
+
+			changed = e.Spec.DeepCopy()
+			changed.Reference = &api.EntryReference{Name: "other"}
+			Expect(SourceSpecHash(changed)).NotTo(Equal(hash))
+		})
+
+		It("ignores the fields not set by the source", func() {
+			e := generated()
+			hash := SourceSpecHash(&e.Spec)
+			group := "team-a"
+			changed := e.Spec.DeepCopy()
+			changed.OwnerGroup = &group
+			changed.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "a"}
+			changed.ProviderHints = &api.ProviderHints{}
+			Expect(SourceSpecHash(changed)).To(Equal(hash))
+			Expect(SourceUnmanagedFields(changed)).To(Equal([]string{"ownerGroup", "routingPolicy", "providerHints"}))
+			Expect(SourceUnmanagedFields(&e.Spec)).To(BeEmpty())
+		})
+
+		It("reports all fields of the entry spec not managed by the source", func() {
+			group := "team-a"
+			spec := &api.DNSEntrySpec{
+				OwnerGroup:    &group,
+				TXT:           []api.TXTRecord{{}},
+				RoutingPolicy: &api.RoutingPolicy{},
+				ProviderHints: &api.ProviderHints{},
+				SRV:           []api.SRVRecord{{}},
+				SSHFP:         []api.SSHFPRecord{{}},
+				NAPTR:         []api.NAPTRRecord{{}},
+				SVCB:          []api.SVCBRecord{{}},
+				HTTPS:         []api.SVCBRecord{{}},
+				CAA:           []api.CAARecord{{}},
+			}
+			Expect(SourceUnmanagedFields(spec)).To(Equal([]string{"ownerGroup", "txt", "routingPolicy", "providerHints",
+				"srv", "sshfp", "naptr", "svcb", "https", "caa"}))
+			Expect(SourceSpecHash(spec)).To(Equal(SourceSpecHash(&api.DNSEntrySpec{})))
+
+			spec = &api.DNSEntrySpec{TXT: []api.TXTRecord{}, CAA: []api.CAARecord{}}
+			Expect(SourceUnmanagedFields(spec)).To(BeEmpty())
+		})
+	})
+
+	Context("ValidateSource", func() {
+		It("accepts unmodified generated entries", func() {
+			Expect(entryObject(generated()).ValidateSource()).To(Succeed())
+		})
+
+		It("accepts entries without source hash", func() {
+			e := generated()
+			delete(e.Annotations, dns.SOURCE_HASH_ANNOTATION)
+			e.Spec.Targets = []string{"5.6.7.8"}
+			Expect(entryObject(e).ValidateSource()).To(Succeed())
+		})
+
+		It("rejects modified source fields", func() {
+			e := generated()
+			e.Spec.Targets = []string{"5.6.7.8"}
+			Expect(entryObject(e).ValidateSource()).To(MatchError(ContainSubstring("modified outside of source \"Service/default/svc\"")))
+		})
+
+		It("rejects fields set outside of the source", func() {
+			e := generated()
+			e.Spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "a"}
+			Expect(entryObject(e).ValidateSource()).To(MatchError(ContainSubstring("spec fields routingPolicy have been set outside")))
+		})
+
+		It("accepts modifications with override annotation", func() {
+			e := generated()
+			e.Spec.Targets = []string{"5.6.7.8"}
+			e.Spec.RoutingPolicy = &api.RoutingPolicy{Type: "weighted", SetIdentifier: "a"}
+			e.Annotations[dns.SOURCE_OVERRIDE_ANNOTATION] = "true"
+			Expect(entryObject(e).ValidateSource()).To(Succeed())
+		})
+	})
+})
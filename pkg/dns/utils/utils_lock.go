@@ -97,6 +97,10 @@ func (this *DNSLockObject) GetOwnerId() *string {
 	return this.DNSLock().Spec.LockId
 }
 
+func (this *DNSLockObject) GetOwnerGroup() *string {
+	return nil
+}
+
 func (this *DNSLockObject) GetTTL() *int64 {
 	return &this.DNSLock().Spec.TTL
 }
@@ -109,6 +113,18 @@ func (this *DNSLockObject) GetReference() *api.EntryReference {
 	return nil
 }
 
+func (this *DNSLockObject) GetRoutingPolicy() *api.RoutingPolicy {
+	return nil
+}
+
+func (this *DNSLockObject) GetProviderHints() *api.ProviderHints {
+	return nil
+}
+
+func (this *DNSLockObject) GetStructuredRecords() ([]StructuredRecord, error) {
+	return nil, nil
+}
+
 func (this *DNSLockObject) RefreshTime() time.Time {
 	return this.Spec().Timestamp.Time
 }
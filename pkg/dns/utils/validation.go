@@ -0,0 +1,78 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// TargetRecordType returns the record type for a target of a DNSEntry (A or AAAA for IP addresses, CNAME otherwise).
+func TargetRecordType(target string) string {
+	ip := net.ParseIP(target)
+	switch {
+	case ip == nil:
+		return dns.RS_CNAME
+	case ip.To4() != nil:
+		return dns.RS_A
+	default:
+		return dns.RS_AAAA
+	}
+}
+
+// ValidateTarget validates a target of a DNSEntry according to its record type.
+func ValidateTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	return dns.ValidateRecordValue(TargetRecordType(target), target)
+}
+
+// ValidateEntrySpec validates the record specification of a DNSEntry spec without any controller state.
+// It is used by the controller and can be used by admission webhooks to reject invalid entries early.
+func ValidateEntrySpec(spec *api.DNSEntrySpec) error {
+	if err := dns.ValidateDomainName(spec.DNSName); err != nil {
+		return err
+	}
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		return fmt.Errorf("TTL must be greater than zero")
+	}
+	if len(spec.Targets) > 0 && (len(spec.Text) > 0 || len(spec.TXT) > 0) {
+		return fmt.Errorf("only Text or Targets possible")
+	}
+	for i, t := range spec.Text {
+		if t != "" {
+			if err := dns.ValidateText(t); err != nil {
+				return fmt.Errorf("text %d: %s", i+1, err)
+			}
+		}
+	}
+	for i, t := range spec.Targets {
+		if err := ValidateTarget(t); err != nil {
+			return fmt.Errorf("target %d: %s", i+1, err)
+		}
+	}
+	if spec.RoutingPolicy != nil && spec.RoutingPolicy.Type == "" {
+		return fmt.Errorf("routing policy type must not be empty")
+	}
+	_, err := StructuredRecordsFromEntrySpec(spec)
+	return err
+}
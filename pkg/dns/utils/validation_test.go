@@ -0,0 +1,50 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+var _ = Describe("Entry spec validation", func() {
+	It("determines the record type of targets", func() {
+		Expect(TargetRecordType("1.2.3.4")).To(Equal(dns.RS_A))
+		Expect(TargetRecordType("2001:db8::1")).To(Equal(dns.RS_AAAA))
+		Expect(TargetRecordType("a.example.com")).To(Equal(dns.RS_CNAME))
+	})
+
+	It("accepts valid specs", func() {
+		spec := &api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4", "b.example.com"}}
+		Expect(ValidateEntrySpec(spec)).To(Succeed())
+	})
+
+	It("rejects invalid targets with the target index", func() {
+		spec := &api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4", "https://b.example.com"}}
+		err := ValidateEntrySpec(spec)
+		Expect(err).To(MatchError(ContainSubstring("target 2:")))
+		Expect(err).To(MatchError(ContainSubstring(`use "b.example.com"`)))
+	})
+
+	It("rejects text together with targets", func() {
+		spec := &api.DNSEntrySpec{DNSName: "a.example.com", Targets: []string{"1.2.3.4"}, Text: []string{"foo"}}
+		Expect(ValidateEntrySpec(spec)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,185 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// StuckHandler is called once for every call exceeding the watchdog threshold.
+type StuckHandler func(kind, key string, duration time.Duration)
+
+// Watchdog detects calls processing a single key beyond a threshold.
+// Such calls are reported with the stack of the processing goroutine and
+// their context is cancelled.
+type Watchdog struct {
+	lock      sync.Mutex
+	logger    logger.LogContext
+	threshold time.Duration
+	handler   StuckHandler
+	nextId    int64
+	active    map[int64]*watchedCall
+}
+
+type watchedCall struct {
+	kind      string
+	key       string
+	start     time.Time
+	goroutine string
+	cancel    context.CancelFunc
+	reported  bool
+}
+
+// NewWatchdog creates a watchdog checking active calls until the given context is done.
+// A threshold less or equal zero disables the watchdog.
+func NewWatchdog(ctx context.Context, logger logger.LogContext, threshold time.Duration, handler StuckHandler) *Watchdog {
+	this := &Watchdog{
+		logger:    logger,
+		threshold: threshold,
+		handler:   handler,
+		active:    map[int64]*watchedCall{},
+	}
+	if threshold > 0 {
+		go this.run(ctx)
+	}
+	return this
+}
+
+// Threshold returns the maximum duration for processing a single key.
+func (this *Watchdog) Threshold() time.Duration {
+	if this == nil {
+		return 0
+	}
+	return this.threshold
+}
+
+// Watch registers a call processing a key. It returns the context to be used
+// for the call and a function to be called when the call is completed.
+func (this *Watchdog) Watch(parent context.Context, kind, key string) (context.Context, func()) {
+	if this == nil || this.threshold <= 0 {
+		return parent, func() {}
+	}
+	ctx, cancel := context.WithCancel(parent)
+	call := &watchedCall{
+		kind:      kind,
+		key:       key,
+		start:     time.Now(),
+		goroutine: currentGoroutine(),
+		cancel:    cancel,
+	}
+
+	this.lock.Lock()
+	this.nextId++
+	id := this.nextId
+	this.active[id] = call
+	this.lock.Unlock()
+
+	return ctx, func() {
+		this.lock.Lock()
+		delete(this.active, id)
+		this.lock.Unlock()
+		cancel()
+	}
+}
+
+func (this *Watchdog) run(ctx context.Context) {
+	period := this.threshold / 4
+	if period < time.Second {
+		period = time.Second
+	}
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			this.check()
+		}
+	}
+}
+
+func (this *Watchdog) check() {
+	now := time.Now()
+	stuck := []*watchedCall{}
+	this.lock.Lock()
+	for _, call := range this.active {
+		if !call.reported && now.Sub(call.start) > this.threshold {
+			call.reported = true
+			stuck = append(stuck, call)
+		}
+	}
+	this.lock.Unlock()
+
+	if len(stuck) == 0 {
+		return
+	}
+	stacks := allStacks()
+	for _, call := range stuck {
+		duration := now.Sub(call.start)
+		this.logger.Warnf("watchdog: processing %s %s stuck for %s -> cancelling context", call.kind, call.key, duration)
+		if stack := stacks[call.goroutine]; stack != "" {
+			this.logger.Warnf("watchdog: stack of %s:\n%s", call.key, stack)
+		}
+		call.cancel()
+		if this.handler != nil {
+			this.handler(call.kind, call.key, duration)
+		}
+	}
+}
+
+// currentGoroutine returns the id of the current goroutine as found in stack traces.
+func currentGoroutine() string {
+	buf := make([]byte, 64)
+	n := runtime.Stack(buf, false)
+	fields := strings.Fields(string(buf[:n]))
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+func allStacks() map[string]string {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= 16<<20 {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	stacks := map[string]string{}
+	for _, stack := range bytes.Split(buf, []byte("\n\n")) {
+		header := stack
+		if len(header) > 64 {
+			header = header[:64]
+		}
+		fields := strings.Fields(string(header))
+		if len(fields) >= 2 && fields[0] == "goroutine" {
+			stacks[fields[1]] = string(stack)
+		}
+	}
+	return stacks
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package utils
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watchdog", func() {
+	It("cancels the context of stuck calls only", func() {
+		var stuck int32
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		wd := NewWatchdog(ctx, logger.New(), 100*time.Millisecond, func(kind, key string, duration time.Duration) {
+			Expect(kind).To(Equal("test"))
+			Expect(key).To(Equal("slow"))
+			atomic.AddInt32(&stuck, 1)
+		})
+
+		fastCtx, fastDone := wd.Watch(ctx, "test", "fast")
+		fastDone()
+
+		slowCtx, slowDone := wd.Watch(ctx, "test", "slow")
+		defer slowDone()
+		wd.check()
+		Expect(slowCtx.Err()).To(BeNil())
+
+		time.Sleep(150 * time.Millisecond)
+		wd.check()
+		Expect(slowCtx.Err()).To(Equal(context.Canceled))
+		Expect(atomic.LoadInt32(&stuck)).To(Equal(int32(1)))
+		Expect(fastCtx.Err()).To(Equal(context.Canceled))
+
+		wd.check()
+		Expect(atomic.LoadInt32(&stuck)).To(Equal(int32(1)))
+	})
+
+	It("is disabled without threshold", func() {
+		wd := NewWatchdog(context.Background(), logger.New(), 0, nil)
+		ctx, done := wd.Watch(context.Background(), "test", "key")
+		defer done()
+		Expect(ctx).To(Equal(context.Background()))
+	})
+})
@@ -18,6 +18,8 @@ package dns
 
 import (
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/validation"
@@ -25,10 +27,14 @@ import (
 
 func ValidateDomainName(name string) error {
 	check := NormalizeHostname(name)
-	if strings.HasPrefix(check, "_") {
-		// allow "_" prefix, as it is used for DNS challenges of Let's encrypt
-		check = "x" + check[1:]
+	// allow "_" prefix of labels, as it is used for DNS challenges of Let's encrypt and service records
+	labels := strings.Split(check, ".")
+	for i, label := range labels {
+		if strings.HasPrefix(label, "_") {
+			labels[i] = "x" + label[1:]
+		}
 	}
+	check = strings.Join(labels, ".")
 
 	var errs []string
 	if strings.HasPrefix(check, "*.") {
@@ -51,7 +57,7 @@ func ValidateDomainName(name string) error {
 		return fmt.Errorf("metadata record %q of %q is no valid dns name (%v)", metaCheck, name, errs)
 	}
 
-	labels := strings.Split(strings.TrimPrefix(check, "*."), ".")
+	labels = strings.Split(strings.TrimPrefix(check, "*."), ".")
 	for i, label := range labels {
 		if errs = validation.IsDNS1123Label(label); len(errs) > 0 {
 			return fmt.Errorf("%d. label %q of %q is not valid (%v)", i+1, label, name, errs)
@@ -64,3 +70,143 @@ func ValidateDomainName(name string) error {
 
 	return nil
 }
+
+// ValidateRecordValue validates the value of a record of the given type in presentation format.
+// Values of record types without specific validation are accepted.
+func ValidateRecordValue(rtype, value string) error {
+	switch rtype {
+	case RS_A:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() == nil {
+			return fmt.Errorf("%q is no valid IPv4 address", value)
+		}
+	case RS_AAAA:
+		if ip := net.ParseIP(value); ip == nil || ip.To4() != nil {
+			return fmt.Errorf("%q is no valid IPv6 address", value)
+		}
+	case RS_CNAME:
+		return ValidateHostname(value)
+	case RS_SRV:
+		_, _, _, _, err := ParseSRVValue(value)
+		return err
+	case RS_CAA:
+		_, _, _, err := ParseCAAValue(value)
+		return err
+	}
+	return nil
+}
+
+// ParseSRVValue parses and validates the value of a SRV record (<priority> <weight> <port> <target>).
+func ParseSRVValue(value string) (priority, weight, port int, target string, err error) {
+	fields := strings.Fields(value)
+	if len(fields) != 4 {
+		err = fmt.Errorf("SRV record %q must have the form <priority> <weight> <port> <target>", value)
+		return
+	}
+	for i, name := range []string{"priority", "weight", "port"} {
+		v, perr := strconv.Atoi(fields[i])
+		if perr != nil || v < 0 || v > 65535 {
+			err = fmt.Errorf("%s of SRV record %q must be a number between 0 and 65535", name, value)
+			return
+		}
+		switch i {
+		case 0:
+			priority = v
+		case 1:
+			weight = v
+		case 2:
+			port = v
+		}
+	}
+	target = fields[3]
+	if target != "." {
+		err = ValidateHostname(target)
+	}
+	return
+}
+
+// ParseCAAValue parses and validates the value of a CAA record (<flags> <tag> "<value>").
+// The value may also be given unquoted, as it is used by some providers.
+func ParseCAAValue(value string) (flags int, tag, caaValue string, err error) {
+	fields := strings.SplitN(strings.TrimSpace(value), " ", 3)
+	if len(fields) != 3 {
+		err = fmt.Errorf("CAA record %q must have the form <flags> <tag> \"<value>\"", value)
+		return
+	}
+	flags, err = strconv.Atoi(fields[0])
+	if err != nil || flags < 0 || flags > 255 {
+		err = fmt.Errorf("flags of CAA record %q must be a number between 0 and 255", value)
+		return
+	}
+	tag = fields[1]
+	if len(tag) == 0 || len(tag) > 15 || strings.IndexFunc(tag, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) >= 0 {
+		err = fmt.Errorf("tag of CAA record %q must be alphanumeric with at most 15 characters", value)
+		return
+	}
+	caaValue = strings.TrimSpace(fields[2])
+	if len(caaValue) >= 2 && strings.HasPrefix(caaValue, "\"") && strings.HasSuffix(caaValue, "\"") {
+		caaValue = caaValue[1 : len(caaValue)-1]
+	}
+	if strings.ContainsAny(caaValue, "\"\\") {
+		err = fmt.Errorf("value of CAA record %q must not contain quotes or backslashes", value)
+	}
+	return
+}
+
+// CAAValue returns the value of a CAA record in presentation format.
+func CAAValue(flags int, tag, value string) string {
+	return fmt.Sprintf("%d %s \"%s\"", flags, strings.ToLower(tag), value)
+}
+
+// NormalizeCAAValue returns the value of a CAA record as given by a provider in presentation format.
+// Invalid values are returned unchanged.
+func NormalizeCAAValue(value string) string {
+	flags, tag, caaValue, err := ParseCAAValue(value)
+	if err != nil {
+		return value
+	}
+	return CAAValue(flags, tag, caaValue)
+}
+
+// ValidateHostname validates a hostname used as target of a record (e.g. a CNAME record).
+func ValidateHostname(host string) error {
+	if host == "" {
+		return fmt.Errorf("hostname must not be empty")
+	}
+	if i := strings.Index(host, "://"); i >= 0 {
+		return fmt.Errorf("hostname %q must not contain a URL scheme (use %q)", host, strings.SplitN(host[i+3:], "/", 2)[0])
+	}
+	if strings.ContainsAny(host, " \t,;") {
+		return fmt.Errorf("hostname %q must not contain whitespaces or separators (use a separate target for each hostname)", host)
+	}
+	if strings.Contains(host, "/") {
+		return fmt.Errorf("hostname %q must not contain a path", host)
+	}
+	if strings.Contains(host, ":") {
+		return fmt.Errorf("hostname %q must not contain a port or be an IPv6 address with port or zone", host)
+	}
+	check := strings.ToLower(strings.TrimSuffix(host, "."))
+	if strings.HasPrefix(check, "*.") {
+		return fmt.Errorf("hostname %q must not be a wildcard domain", host)
+	}
+	numeric := true
+	labels := strings.Split(check, ".")
+	for i, label := range labels {
+		if strings.Trim(label, "0123456789") != "" {
+			numeric = false
+		}
+		// allow "_" as used for service names
+		label = strings.ReplaceAll(label, "_", "x")
+		if errs := validation.IsDNS1123Label(label); len(errs) > 0 {
+			return fmt.Errorf("%d. label %q of hostname %q is not valid (%s)", i+1, labels[i], host, strings.Join(errs, ", "))
+		}
+	}
+	if numeric {
+		return fmt.Errorf("%q is neither a valid IP address nor a hostname", host)
+	}
+	if len(check) > 253 {
+		return fmt.Errorf("hostname %q is longer than 253 characters", host)
+	}
+	return nil
+}
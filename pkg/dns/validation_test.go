@@ -40,6 +40,8 @@ func TestValidation(t *testing.T) {
 		{"\\052.a.b", true},
 		{"a-a.a9.a8.a7.a6.a5.a4.a3.a2.a1.a.b.c.d.e.f.g.h.i.j.k.l.m.n.o.p.q.r.s.t.u.v.w.x.y.z", true},
 		{"_a.b", true},
+		{"_sip._tcp.a.b", true},
+		{"a._b_.c", false},
 		{"1.2-3.b", true},
 		{"a123456789012345678901234567890123456789012345678901234567890abc.b", false},   // label too long
 		{"a.a123456789012345678901234567890123456789012345678901234567890abc.b", false}, // label too long
@@ -61,3 +63,48 @@ func TestValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestRecordValueValidation(t *testing.T) {
+	table := []struct {
+		rtype string
+		input string
+		ok    bool
+	}{
+		{RS_A, "1.2.3.4", true},
+		{RS_A, "1.2.3", false},
+		{RS_A, "2001:db8::1", false},
+		{RS_AAAA, "2001:db8::1", true},
+		{RS_AAAA, "1.2.3.4", false},
+		{RS_CNAME, "a.example.com", true},
+		{RS_CNAME, "a.example.com.", true},
+		{RS_CNAME, "A.Example.com", true},
+		{RS_CNAME, "_sip._udp.example.com", true},
+		{RS_CNAME, "1.2.3", false},
+		{RS_CNAME, "https://a.example.com/path", false},
+		{RS_CNAME, "a.example.com:443", false},
+		{RS_CNAME, "a.example.com,b.example.com", false},
+		{RS_CNAME, "a..example.com", false},
+		{RS_CNAME, "*.example.com", false},
+		{RS_SRV, "10 5 5060 sip.example.com.", true},
+		{RS_SRV, "0 0 0 .", true},
+		{RS_SRV, "10 5 70000 sip.example.com.", false},
+		{RS_SRV, "10 5 sip.example.com.", false},
+		{RS_CAA, "0 issue \"letsencrypt.org\"", true},
+		{RS_CAA, "128 iodef \"mailto:security@example.com\"", true},
+		{RS_CAA, "0 issue ca.example.net", true},
+		{RS_CAA, "0 issue \";\"", true},
+		{RS_CAA, "256 issue \"letsencrypt.org\"", false},
+		{RS_CAA, "0 is-sue \"letsencrypt.org\"", false},
+		{RS_CAA, "0 issue \"a\"b\"", false},
+		{RS_CAA, "0 issue", false},
+		{RS_TXT, "any text", true},
+	}
+	for _, entry := range table {
+		err := ValidateRecordValue(entry.rtype, entry.input)
+		if entry.ok && err != nil {
+			t.Errorf("%s %s should be ok, but got error %s", entry.rtype, entry.input, err)
+		} else if !entry.ok && err == nil {
+			t.Errorf("%s %s should not be ok, but got no error", entry.rtype, entry.input)
+		}
+	}
+}
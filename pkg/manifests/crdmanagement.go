@@ -0,0 +1,275 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package manifests
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// Modes of the CRD management
+const (
+	// CRDManagementOff leaves the CRDs untouched
+	CRDManagementOff = "off"
+	// CRDManagementCreate creates missing CRDs, but never updates existing ones
+	CRDManagementCreate = "create"
+	// CRDManagementUpdate creates missing CRDs and upgrades existing ones
+	CRDManagementUpdate = "update"
+)
+
+var crdResource = apiext.SchemeGroupVersion.WithResource("customresourcedefinitions")
+
+// ValidateCRDManagementMode checks the mode of the CRD management.
+func ValidateCRDManagementMode(mode string) error {
+	switch mode {
+	case CRDManagementOff, CRDManagementCreate, CRDManagementUpdate:
+		return nil
+	}
+	return fmt.Errorf("invalid crd management mode %q (allowed: %s, %s, %s)", mode, CRDManagementOff, CRDManagementCreate, CRDManagementUpdate)
+}
+
+// ConversionWebhook describes the conversion webhook service registered in CRDs with several versions.
+type ConversionWebhook struct {
+	// Namespace is the namespace of the webhook service
+	Namespace string
+	// Name is the name of the webhook service
+	Name string
+	// Port is the port of the webhook service
+	Port int32
+	// Path is the URL path of the conversion webhook
+	Path string
+	// CABundle is the PEM encoded CA bundle to validate the server certificate of the webhook
+	CABundle []byte
+}
+
+// EnableConversionWebhook registers the conversion webhook in a CRD with several versions
+// and serves all of its versions. CRDs with a single version are left unchanged.
+func EnableConversionWebhook(crd *apiext.CustomResourceDefinition, webhook *ConversionWebhook) {
+	if webhook == nil || len(crd.Spec.Versions) < 2 {
+		return
+	}
+	port := webhook.Port
+	path := webhook.Path
+	crd.Spec.Conversion = &apiext.CustomResourceConversion{
+		Strategy: apiext.WebhookConverter,
+		Webhook: &apiext.WebhookConversion{
+			ClientConfig: &apiext.WebhookClientConfig{
+				Service: &apiext.ServiceReference{
+					Namespace: webhook.Namespace,
+					Name:      webhook.Name,
+					Path:      &path,
+					Port:      &port,
+				},
+				CABundle: webhook.CABundle,
+			},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	for i := range crd.Spec.Versions {
+		crd.Spec.Versions[i].Served = true
+	}
+}
+
+// ManageCustomResourceDefinitions creates or upgrades the CRDs of the DNS API group in the cluster
+// of the given config according to the mode. Updates are validated with a server-side dry-run
+// before they are applied. With dryRun, planned changes are only logged. If a conversion webhook
+// is given, it is registered in all CRDs with several versions.
+func ManageCustomResourceDefinitions(ctx context.Context, log logger.LogContext, config *rest.Config, mode string, dryRun bool, webhook *ConversionWebhook) error {
+	if mode == CRDManagementOff {
+		return nil
+	}
+	if err := ValidateCRDManagementMode(mode); err != nil {
+		return err
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+	crds, err := CustomResourceDefinitions()
+	if err != nil {
+		return err
+	}
+	for _, crd := range crds {
+		EnableConversionWebhook(crd, webhook)
+		if err := manageCustomResourceDefinition(ctx, log, client.Resource(crdResource), crd, mode, dryRun); err != nil {
+			return fmt.Errorf("management of crd %s failed: %w", crd.Name, err)
+		}
+	}
+	return nil
+}
+
+func manageCustomResourceDefinition(ctx context.Context, log logger.LogContext, client dynamic.ResourceInterface,
+	desired *apiext.CustomResourceDefinition, mode string, dryRun bool) error {
+	obj, err := client.Get(ctx, desired.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		if dryRun {
+			log.Infof("dry-run: crd %s would be created", desired.Name)
+			return nil
+		}
+		log.Infof("creating crd %s", desired.Name)
+		u, err := toUnstructured(desired)
+		if err != nil {
+			return err
+		}
+		_, err = client.Create(ctx, u, metav1.CreateOptions{})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if mode != CRDManagementUpdate {
+		return nil
+	}
+
+	existing := &apiext.CustomResourceDefinition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, existing); err != nil {
+		return err
+	}
+	updated, err := MergeCustomResourceDefinition(existing, desired)
+	if err != nil {
+		return err
+	}
+	if updated == nil {
+		log.Infof("crd %s is up to date", desired.Name)
+		return nil
+	}
+	u, err := toUnstructured(updated)
+	if err != nil {
+		return err
+	}
+	known, err := toUnstructured(existing)
+	if err != nil {
+		return err
+	}
+	// fields of the existing CRD unknown to the API types of this client (e.g. of a newer API server) survive the update
+	u.Object = preserveUnknownFields(obj.Object, known.Object, u.Object)
+	if _, err := client.Update(ctx, u, metav1.UpdateOptions{DryRun: []string{metav1.DryRunAll}}); err != nil {
+		return fmt.Errorf("dry-run of update rejected: %w", err)
+	}
+	if dryRun {
+		log.Infof("dry-run: crd %s would be updated", desired.Name)
+		return nil
+	}
+	log.Infof("updating crd %s", desired.Name)
+	_, err = client.Update(ctx, u, metav1.UpdateOptions{})
+	return err
+}
+
+// MergeCustomResourceDefinition returns the update of an existing CRD to the desired one,
+// or nil if the CRD is up to date. Versions still used for stored objects are kept (not
+// served as storage version), as are foreign labels and annotations and the CA bundle of
+// a conversion webhook, which is typically injected. A CRD with a storage version unknown
+// to the desired CRD is never downgraded.
+func MergeCustomResourceDefinition(existing, desired *apiext.CustomResourceDefinition) (*apiext.CustomResourceDefinition, error) {
+	known := map[string]bool{}
+	for _, v := range desired.Spec.Versions {
+		known[v.Name] = true
+	}
+	for _, v := range existing.Spec.Versions {
+		if v.Storage && !known[v.Name] {
+			return nil, fmt.Errorf("storage version %s unknown, refusing downgrade", v.Name)
+		}
+	}
+
+	updated := existing.DeepCopy()
+	updated.Spec = *desired.Spec.DeepCopy()
+	for _, stored := range existing.Status.StoredVersions {
+		if known[stored] {
+			continue
+		}
+		for _, v := range existing.Spec.Versions {
+			if v.Name == stored {
+				v = *v.DeepCopy()
+				v.Storage = false
+				updated.Spec.Versions = append(updated.Spec.Versions, v)
+			}
+		}
+	}
+	// keep the defaulted or explicitly configured conversion of the existing CRD
+	if c := updated.Spec.Conversion; c == nil || (c.Strategy == apiext.NoneConverter && len(updated.Spec.Versions) > 1 &&
+		existing.Spec.Conversion != nil && existing.Spec.Conversion.Strategy == apiext.WebhookConverter) {
+		updated.Spec.Conversion = existing.Spec.Conversion.DeepCopy()
+	}
+	if c := updated.Spec.Conversion; c != nil && c.Webhook != nil && c.Webhook.ClientConfig != nil && len(c.Webhook.ClientConfig.CABundle) == 0 {
+		if e := existing.Spec.Conversion; e != nil && e.Webhook != nil && e.Webhook.ClientConfig != nil {
+			c.Webhook.ClientConfig.CABundle = e.Webhook.ClientConfig.CABundle
+		}
+	}
+	updated.Labels = mergeStrings(existing.Labels, desired.Labels)
+	updated.Annotations = mergeStrings(existing.Annotations, desired.Annotations)
+
+	if reflect.DeepEqual(updated.Spec, existing.Spec) && reflect.DeepEqual(updated.Labels, existing.Labels) &&
+		reflect.DeepEqual(updated.Annotations, existing.Annotations) {
+		return nil, nil
+	}
+	return updated, nil
+}
+
+func mergeStrings(existing, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		return existing
+	}
+	result := map[string]string{}
+	for k, v := range existing {
+		result[k] = v
+	}
+	for k, v := range desired {
+		result[k] = v
+	}
+	return result
+}
+
+// preserveUnknownFields adds the fields of the raw object, which are lost by the conversion
+// to the API types (known), to the updated object.
+func preserveUnknownFields(raw, known, updated map[string]interface{}) map[string]interface{} {
+	for k, v := range raw {
+		kv, ok := known[k]
+		if !ok {
+			if _, exists := updated[k]; !exists {
+				updated[k] = v
+			}
+			continue
+		}
+		rm, ok1 := v.(map[string]interface{})
+		km, ok2 := kv.(map[string]interface{})
+		um, ok3 := updated[k].(map[string]interface{})
+		if ok1 && ok2 && ok3 {
+			updated[k] = preserveUnknownFields(rm, km, um)
+		}
+	}
+	return updated
+}
+
+func toUnstructured(crd *apiext.CustomResourceDefinition) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	u.SetGroupVersionKind(apiext.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	return u, nil
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package manifests
+
+import (
+	"testing"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+func testCRD(storage string, versions ...string) *apiext.CustomResourceDefinition {
+	crd := &apiext.CustomResourceDefinition{}
+	crd.Name = "dnsentries.dns.gardener.cloud"
+	for _, v := range versions {
+		crd.Spec.Versions = append(crd.Spec.Versions, apiext.CustomResourceDefinitionVersion{Name: v, Served: true, Storage: v == storage})
+	}
+	return crd
+}
+
+func TestMergeCustomResourceDefinition(t *testing.T) {
+	existing := testCRD("v1alpha1", "v1alpha1")
+	existing.Labels = map[string]string{"foreign": "true"}
+	if updated, err := MergeCustomResourceDefinition(existing, testCRD("v1alpha1", "v1alpha1")); err != nil || updated != nil {
+		t.Errorf("Failed: expected up to date CRD, got %v (%v)", updated, err)
+	}
+
+	existing = testCRD("v1alpha1", "v1alpha0", "v1alpha1")
+	existing.Labels = map[string]string{"foreign": "true"}
+	existing.Status.StoredVersions = []string{"v1alpha0", "v1alpha1"}
+	updated, err := MergeCustomResourceDefinition(existing, testCRD("v1beta1", "v1alpha1", "v1beta1"))
+	if err != nil || updated == nil {
+		t.Fatalf("Failed: expected update, got %v", err)
+	}
+	if len(updated.Spec.Versions) != 3 || updated.Spec.Versions[2].Name != "v1alpha0" || updated.Spec.Versions[2].Storage {
+		t.Errorf("Failed: stored version not kept as non-storage version: %v", updated.Spec.Versions)
+	}
+	if updated.Labels["foreign"] != "true" {
+		t.Errorf("Failed: foreign labels not kept: %v", updated.Labels)
+	}
+
+	if _, err := MergeCustomResourceDefinition(testCRD("v1beta1", "v1alpha1", "v1beta1"), testCRD("v1alpha1", "v1alpha1")); err == nil {
+		t.Errorf("Failed: expected downgrade to be refused")
+	}
+
+	existing = testCRD("v1beta1", "v1alpha1", "v1beta1")
+	existing.Spec.Conversion = &apiext.CustomResourceConversion{
+		Strategy: apiext.WebhookConverter,
+		Webhook: &apiext.WebhookConversion{
+			ClientConfig:             &apiext.WebhookClientConfig{CABundle: []byte("ca")},
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+	desired := testCRD("v1beta1", "v1alpha1", "v1beta1")
+	desired.Spec.Conversion = existing.Spec.Conversion.DeepCopy()
+	desired.Spec.Conversion.Webhook.ClientConfig.CABundle = nil
+	if updated, err := MergeCustomResourceDefinition(existing, desired); err != nil || updated != nil {
+		t.Errorf("Failed: expected injected CA bundle to be kept, got %v (%v)", updated, err)
+	}
+}
+
+func TestPreserveUnknownFields(t *testing.T) {
+	raw := map[string]interface{}{"spec": map[string]interface{}{"group": "old", "future": "keep"}, "extra": 1}
+	known := map[string]interface{}{"spec": map[string]interface{}{"group": "old"}}
+	updated := map[string]interface{}{"spec": map[string]interface{}{"group": "new"}}
+	result := preserveUnknownFields(raw, known, updated)
+	spec := result["spec"].(map[string]interface{})
+	if spec["group"] != "new" || spec["future"] != "keep" || result["extra"] != 1 {
+		t.Errorf("Failed: unknown fields not preserved: %v", result)
+	}
+}
+
+func TestEnableConversionWebhook(t *testing.T) {
+	webhook := &ConversionWebhook{Namespace: "kube-system", Name: "dns", Port: 443, Path: "/convert", CABundle: []byte("ca")}
+
+	single := testCRD("v1alpha1", "v1alpha1")
+	EnableConversionWebhook(single, webhook)
+	if single.Spec.Conversion != nil {
+		t.Errorf("Failed: conversion set for CRD with single version")
+	}
+
+	crd := testCRD("v1alpha1", "v1alpha1", "v1beta1")
+	crd.Spec.Versions[1].Served = false
+	EnableConversionWebhook(crd, webhook)
+	c := crd.Spec.Conversion
+	if c == nil || c.Strategy != apiext.WebhookConverter || c.Webhook.ClientConfig.Service.Name != "dns" || string(c.Webhook.ClientConfig.CABundle) != "ca" {
+		t.Errorf("Failed: unexpected conversion %v", c)
+	}
+	if !crd.Spec.Versions[1].Served {
+		t.Errorf("Failed: version v1beta1 not served")
+	}
+}
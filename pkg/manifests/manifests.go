@@ -0,0 +1,102 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+// Package manifests provides the installation manifests of the DNS controller manager
+// generated from the Go API types. They are embedded into the binary, so that
+// controller managers embedding the DNS controllers can install matching CRDs and
+// roles programmatically.
+package manifests
+
+//go:generate go run ../../hack/manifestgen
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"path"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Names of the embedded manifests
+const (
+	// CRDs contains the custom resource definitions of the DNS API group
+	CRDs = "crds.yaml"
+	// RBAC contains the cluster role and the role required by the controllers
+	RBAC = "rbac.yaml"
+	// Examples contains the example objects of the examples directory
+	Examples = "examples.yaml"
+	// Kustomization is a kustomization for the CRDs and the roles
+	Kustomization = "kustomization.yaml"
+)
+
+const dir = "generated"
+
+//go:embed generated/*.yaml
+var files embed.FS
+
+// FS returns the file system with all embedded manifests.
+func FS() fs.FS {
+	sub, err := fs.Sub(files, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
+
+// Get returns the embedded multi-document manifest with the given name.
+func Get(name string) ([]byte, error) {
+	return files.ReadFile(path.Join(dir, name))
+}
+
+// Documents splits a multi-document manifest into its non-empty documents.
+func Documents(data []byte) [][]byte {
+	var docs [][]byte
+	for _, doc := range bytes.Split(data, []byte("\n---\n")) {
+		if !isEmptyDocument(doc) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+func isEmptyDocument(doc []byte) bool {
+	for _, line := range bytes.Split(doc, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 && line[0] != '#' && !bytes.Equal(line, []byte("---")) {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomResourceDefinitions returns the decoded custom resource definitions of the DNS API group.
+func CustomResourceDefinitions() ([]*apiext.CustomResourceDefinition, error) {
+	data, err := Get(CRDs)
+	if err != nil {
+		return nil, err
+	}
+	var result []*apiext.CustomResourceDefinition
+	for _, doc := range Documents(data) {
+		crd := &apiext.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(doc, crd); err != nil {
+			return nil, err
+		}
+		result = append(result, crd)
+	}
+	return result, nil
+}
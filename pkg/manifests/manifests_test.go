@@ -0,0 +1,66 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package manifests
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// TestCRDsUpToDate checks that the embedded CRDs match the CRDs generated for the API types.
+func TestCRDsUpToDate(t *testing.T) {
+	files, err := filepath.Glob("../apis/dns/crds/*.yaml")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("cannot find generated CRDs: %v", err)
+	}
+	crds, err := CustomResourceDefinitions()
+	if err != nil {
+		t.Fatalf("cannot decode embedded CRDs: %s", err)
+	}
+	embedded := map[string]*apiext.CustomResourceDefinition{}
+	for _, crd := range crds {
+		embedded[crd.Name] = crd
+	}
+	if len(embedded) != len(files) {
+		t.Errorf("found %d embedded CRDs, but %d generated ones (run go generate ./pkg/manifests)", len(embedded), len(files))
+	}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		generated := &apiext.CustomResourceDefinition{}
+		if err := yaml.Unmarshal(data, generated); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(embedded[generated.Name], generated) {
+			t.Errorf("embedded CRD %s is outdated (run go generate ./pkg/manifests)", generated.Name)
+		}
+	}
+}
+
+func TestDocuments(t *testing.T) {
+	docs := Documents([]byte("# header\n---\na: 1\n---\n# comment\n---\nb: 2\n"))
+	if len(docs) != 2 || string(docs[0]) != "a: 1" || string(docs[1]) != "b: 2\n" {
+		t.Errorf("unexpected documents: %q", docs)
+	}
+}
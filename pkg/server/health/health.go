@@ -0,0 +1,177 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+	"github.com/gardener/controller-manager-library/pkg/server/healthz"
+)
+
+func init() {
+	server.Register("/healthz/detail", Healthz)
+	server.Register("/readyz", Readyz)
+}
+
+// ComponentState describes the state of a sub controller or server.
+type ComponentState struct {
+	Name         string     `json:"name"`
+	Healthy      bool       `json:"healthy"`
+	Ready        bool       `json:"ready"`
+	LastActivity *time.Time `json:"lastActivity,omitempty"`
+	Timeout      string     `json:"timeout,omitempty"`
+	Message      string     `json:"message,omitempty"`
+}
+
+// Report is the machine-readable response of the health endpoints.
+type Report struct {
+	Healthy    bool             `json:"healthy"`
+	Ready      bool             `json:"ready"`
+	Components []ComponentState `json:"components"`
+}
+
+type component struct {
+	ready   bool
+	message string
+	last    time.Time
+	timeout time.Duration
+}
+
+var (
+	components = map[string]*component{}
+	lock       sync.Mutex
+)
+
+// Register adds a component. If timeout is greater than zero, the component
+// is reported as unhealthy if there is no activity (see Tick) within this duration.
+// Such components are also checked by the /healthz endpoint of the controller manager.
+func Register(name string, timeout time.Duration) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	c := components[name]
+	if c == nil {
+		c = &component{}
+		components[name] = c
+	}
+	c.last = time.Now()
+	c.timeout = timeout
+	if timeout > 0 {
+		// healthz uses three times the given period as timeout
+		healthz.Start(name, timeout/3)
+	} else {
+		healthz.End(name)
+	}
+}
+
+// Unregister removes a component.
+func Unregister(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	delete(components, name)
+	healthz.End(name)
+}
+
+// Tick records an activity of a component.
+func Tick(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if c := components[name]; c != nil {
+		c.last = time.Now()
+		if c.timeout > 0 {
+			healthz.Tick(name)
+		}
+	}
+}
+
+// SetReady sets the readiness of a component together with an optional message.
+func SetReady(name string, ready bool, message string) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if c := components[name]; c != nil {
+		c.ready = ready
+		c.message = message
+	}
+}
+
+// GetReport returns the actual state of all registered components.
+func GetReport() *Report {
+	lock.Lock()
+	defer lock.Unlock()
+
+	now := time.Now()
+	report := &Report{Healthy: true, Ready: true, Components: []ComponentState{}}
+	for name, c := range components {
+		last := c.last
+		state := ComponentState{
+			Name:         name,
+			Healthy:      c.timeout <= 0 || !last.Add(c.timeout).Before(now),
+			Ready:        c.ready,
+			LastActivity: &last,
+			Message:      c.message,
+		}
+		if c.timeout > 0 {
+			state.Timeout = c.timeout.String()
+		}
+		report.Healthy = report.Healthy && state.Healthy
+		report.Ready = report.Ready && state.Ready
+		report.Components = append(report.Components, state)
+	}
+	sort.Slice(report.Components, func(i, j int) bool {
+		return report.Components[i].Name < report.Components[j].Name
+	})
+	return report
+}
+
+// Healthz is a HTTP handler reporting the health of all components as JSON.
+func Healthz(w http.ResponseWriter, r *http.Request) {
+	report := GetReport()
+	status := http.StatusOK
+	if !report.Healthy {
+		status = http.StatusInternalServerError
+	}
+	writeReport(w, status, report)
+}
+
+// Readyz is a HTTP handler reporting the readiness of all components as JSON.
+func Readyz(w http.ResponseWriter, r *http.Request) {
+	report := GetReport()
+	status := http.StatusOK
+	if !report.Ready {
+		status = http.StatusServiceUnavailable
+	}
+	writeReport(w, status, report)
+}
+
+func writeReport(w http.ResponseWriter, status int, report *Report) {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
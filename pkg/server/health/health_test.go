@@ -0,0 +1,128 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/server/healthz"
+)
+
+func reset() {
+	lock.Lock()
+	names := []string{}
+	for name := range components {
+		names = append(names, name)
+	}
+	lock.Unlock()
+	for _, name := range names {
+		Unregister(name)
+	}
+}
+
+func request(t *testing.T, handler http.HandlerFunc) (int, *Report) {
+	w := httptest.NewRecorder()
+	handler(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	report := &Report{}
+	if err := json.Unmarshal(w.Body.Bytes(), report); err != nil {
+		t.Fatalf("Failed: invalid report %q: %s", w.Body.String(), err)
+	}
+	return w.Code, report
+}
+
+func expire(name string) {
+	lock.Lock()
+	defer lock.Unlock()
+	components[name].last = time.Now().Add(-time.Hour)
+}
+
+func TestHealth(t *testing.T) {
+	reset()
+	defer reset()
+
+	Register("test/entries", 0)
+	Register("test/zones", 30*time.Millisecond)
+
+	report := GetReport()
+	if !report.Healthy || len(report.Components) != 2 {
+		t.Errorf("Failed: expected two healthy components, got %+v", report)
+	}
+	if report.Components[1].Name != "test/zones" || report.Components[1].Timeout != "30ms" {
+		t.Errorf("Failed: unexpected component state %+v", report.Components[1])
+	}
+	if !healthz.IsHealthy() {
+		t.Errorf("Failed: expected healthz to be healthy")
+	}
+
+	expire("test/entries")
+	time.Sleep(50 * time.Millisecond)
+	report = GetReport()
+	if report.Healthy {
+		t.Errorf("Failed: expected component with expired timeout to be unhealthy")
+	}
+	if !report.Components[0].Healthy {
+		t.Errorf("Failed: component without timeout must stay healthy")
+	}
+	if code, detail := request(t, Healthz); code != http.StatusInternalServerError || detail.Healthy {
+		t.Errorf("Failed: expected status %d, got %d", http.StatusInternalServerError, code)
+	}
+	if healthz.IsHealthy() {
+		t.Errorf("Failed: expected healthz to be unhealthy")
+	}
+
+	Tick("test/zones")
+	if code, _ := request(t, Healthz); code != http.StatusOK {
+		t.Errorf("Failed: expected status %d after tick, got %d", http.StatusOK, code)
+	}
+	if !healthz.IsHealthy() {
+		t.Errorf("Failed: expected healthz to be healthy after tick")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	Unregister("test/zones")
+	if !GetReport().Healthy || !healthz.IsHealthy() {
+		t.Errorf("Failed: expected unregistered component to be ignored")
+	}
+}
+
+func TestReadiness(t *testing.T) {
+	reset()
+	defer reset()
+
+	Register("test/entries", 0)
+	Register("test/providers", 0)
+	SetReady("test/entries", true, "")
+	SetReady("test/providers", false, "setup failed")
+	SetReady("test/unknown", true, "")
+
+	code, report := request(t, Readyz)
+	if code != http.StatusServiceUnavailable || report.Ready {
+		t.Errorf("Failed: expected status %d, got %d", http.StatusServiceUnavailable, code)
+	}
+	if len(report.Components) != 2 || report.Components[1].Message != "setup failed" {
+		t.Errorf("Failed: unexpected components %+v", report.Components)
+	}
+
+	SetReady("test/providers", true, "")
+	if code, report := request(t, Readyz); code != http.StatusOK || !report.Ready {
+		t.Errorf("Failed: expected status %d, got %d", http.StatusOK, code)
+	}
+}
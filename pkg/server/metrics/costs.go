@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package metrics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/gardener/controller-manager-library/pkg/server"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/statistic"
+)
+
+func init() {
+	prometheus.MustRegister(CostEntries)
+	prometheus.MustRegister(CostRecords)
+	prometheus.MustRegister(CostRefreshes)
+
+	server.RegisterHandler("/cost-report", http.HandlerFunc(serveCostReport))
+}
+
+var (
+	CostEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_cost_entries",
+			Help: "Total number of valid dns entries per namespace, tenant, and provider type",
+		},
+		[]string{"namespace", "tenant", "providertype"},
+	)
+
+	CostRecords = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_cost_records",
+			Help: "Total number of managed records per namespace, tenant, and provider type",
+		},
+		[]string{"namespace", "tenant", "providertype"},
+	)
+
+	CostRefreshes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_cost_record_refreshes_per_hour",
+			Help: "Record refreshes per hour of a caching resolver (derived from the TTLs) per namespace, tenant, and provider type",
+		},
+		[]string{"namespace", "tenant", "providertype"},
+	)
+)
+
+type costReport struct {
+	lock      sync.Mutex
+	enabled   bool
+	timestamp time.Time
+	costs     statistic.CostStatistic
+}
+
+var theCostReport = &costReport{costs: statistic.CostStatistic{}}
+
+// EnableCostReport enables the CSV cost attribution report served on /cost-report.
+func EnableCostReport(enabled bool) {
+	theCostReport.lock.Lock()
+	defer theCostReport.lock.Unlock()
+	theCostReport.enabled = enabled
+}
+
+// UpdateCostStatistic updates the cost attribution metrics and report.
+func UpdateCostStatistic(costs statistic.CostStatistic) {
+	theCostReport.lock.Lock()
+	defer theCostReport.lock.Unlock()
+
+	for key := range theCostReport.costs {
+		if costs[key] == nil {
+			CostEntries.DeleteLabelValues(key.Namespace, key.Tenant, key.ProviderType)
+			CostRecords.DeleteLabelValues(key.Namespace, key.Tenant, key.ProviderType)
+			CostRefreshes.DeleteLabelValues(key.Namespace, key.Tenant, key.ProviderType)
+		}
+	}
+	for key, c := range costs {
+		CostEntries.WithLabelValues(key.Namespace, key.Tenant, key.ProviderType).Set(float64(c.Entries))
+		CostRecords.WithLabelValues(key.Namespace, key.Tenant, key.ProviderType).Set(float64(c.Records))
+		CostRefreshes.WithLabelValues(key.Namespace, key.Tenant, key.ProviderType).Set(c.Refreshes)
+	}
+	theCostReport.costs = costs
+	theCostReport.timestamp = time.Now()
+}
+
+func serveCostReport(w http.ResponseWriter, r *http.Request) {
+	theCostReport.lock.Lock()
+	defer theCostReport.lock.Unlock()
+
+	if !theCostReport.enabled {
+		http.NotFound(w, r)
+		return
+	}
+
+	keys := make([]statistic.CostKey, 0, len(theCostReport.costs))
+	for key := range theCostReport.costs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Namespace != keys[j].Namespace {
+			return keys[i].Namespace < keys[j].Namespace
+		}
+		if keys[i].Tenant != keys[j].Tenant {
+			return keys[i].Tenant < keys[j].Tenant
+		}
+		return keys[i].ProviderType < keys[j].ProviderType
+	})
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"dns-cost-report.csv\"")
+	timestamp := theCostReport.timestamp.UTC().Format(time.RFC3339)
+	out := csv.NewWriter(w)
+	out.Write([]string{"timestamp", "namespace", "tenant", "providertype", "entries", "records", "refreshesPerHour"})
+	for _, key := range keys {
+		c := theCostReport.costs[key]
+		out.Write([]string{timestamp, key.Namespace, key.Tenant, key.ProviderType,
+			fmt.Sprintf("%d", c.Entries), fmt.Sprintf("%d", c.Records), fmt.Sprintf("%.2f", c.Refreshes)})
+	}
+	out.Flush()
+}
@@ -35,14 +35,39 @@ func init() {
 	prometheus.MustRegister(Requests)
 	prometheus.MustRegister(ZoneRequests)
 	prometheus.MustRegister(ZoneCacheDiscardings)
+	prometheus.MustRegister(ZoneCacheRestorings)
+	prometheus.MustRegister(ZoneCacheInvalidations)
+	prometheus.MustRegister(ZoneCacheStaleServings)
+	prometheus.MustRegister(ZoneCacheLookups)
+	prometheus.MustRegister(ZoneStateAge)
+	prometheus.MustRegister(ZoneListCacheLookups)
+	prometheus.MustRegister(ZoneListBackoff)
+	prometheus.MustRegister(ZoneQueries)
+	prometheus.MustRegister(UnqueriedEntries)
 	prometheus.MustRegister(Accounts)
+	prometheus.MustRegister(AccountRateLimits)
 	prometheus.MustRegister(Entries)
 	prometheus.MustRegister(StaleEntries)
 	prometheus.MustRegister(Owners)
+	prometheus.MustRegister(OwnerGroups)
+	prometheus.MustRegister(OwnerConflicts)
 	prometheus.MustRegister(RemoteAccessLogins)
 	prometheus.MustRegister(RemoteAccessRequests)
+	prometheus.MustRegister(RemoteAccessThrottledRequests)
 	prometheus.MustRegister(RemoteAccessSeconds)
 	prometheus.MustRegister(RemoteAccessCertificates)
+	prometheus.MustRegister(StuckReconciliations)
+	prometheus.MustRegister(WriteFreeze)
+	prometheus.MustRegister(PlannedChanges)
+	prometheus.MustRegister(ProviderErrors)
+	prometheus.MustRegister(RecordSetChanges)
+	prometheus.MustRegister(AnomalyGuardPausedZones)
+	prometheus.MustRegister(EntryFlaps)
+	prometheus.MustRegister(FlappingEntries)
+	prometheus.MustRegister(ZoneCanaryAge)
+	prometheus.MustRegister(EntriesWithoutProvider)
+	prometheus.MustRegister(ProviderAPISeconds)
+	prometheus.MustRegister(ChangeBatchSize)
 
 	server.RegisterHandler("/metrics", promhttp.Handler())
 }
@@ -72,6 +97,78 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	ZoneCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_cache_lookups",
+			Help: "Lookups of zone states in the zone cache per provider type, zone and result (hit or miss)",
+		},
+		[]string{"providertype", "zone", "result"},
+	)
+
+	ZoneStateAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_state_age_seconds",
+			Help: "Age of the cached zone state at its last lookup per provider type and zone (maximum for aggregated zones)",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneListCacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_list_cache_lookups",
+			Help: "Lookups of the hosted zones in the zone cache per provider type, credential set and result (hit or miss)",
+		},
+		[]string{"providertype", "accounthash", "result"},
+	)
+
+	ZoneListBackoff = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_list_backoff_seconds",
+			Help: "Current backoff of the hosted zones query after errors per provider type and credential set",
+		},
+		[]string{"providertype", "accounthash"},
+	)
+
+	ZoneCacheRestorings = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_cache_restorings",
+			Help: "Zone states restored from persisted zone cache per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneCacheInvalidations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_cache_invalidations",
+			Help: "Invalidations of zone cache by provider change notifications per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneCacheStaleServings = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_zone_cache_stale_servings",
+			Help: "Stale zone states served after failed revalidations per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneQueries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_queries_per_hour",
+			Help: "DNS queries per hour reported by the provider per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	UnqueriedEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_unqueried_entries",
+			Help: "Number of entries without DNS queries reported by the provider per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
 	Accounts = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_account_providers",
@@ -80,6 +177,14 @@ var (
 		[]string{"providertype", "accounthash"},
 	)
 
+	AccountRateLimits = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_account_rate_limit_qps",
+			Help: "Current request rate limit (queries per second) per account adapted to provider throttling",
+		},
+		[]string{"providertype", "accounthash"},
+	)
+
 	Entries = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_dns_entries",
@@ -104,6 +209,22 @@ var (
 		[]string{"owner", "providertype", "provider"},
 	)
 
+	OwnerGroups = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_dns_owner_groups",
+			Help: "Total number of dns entries per owner group, owner and provider type",
+		},
+		[]string{"ownergroup", "owner", "providertype"},
+	)
+
+	OwnerConflicts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_dns_owner_conflicts",
+			Help: "Conflicts of dns entries with records of foreign owners per provider type, foreign owner and resolution",
+		},
+		[]string{"providertype", "owner", "resolution"},
+	)
+
 	RemoteAccessLogins = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "external_dns_management_remoteaccess_logins",
@@ -120,6 +241,14 @@ var (
 		[]string{"handler", "client", "type", "zoneid"},
 	)
 
+	RemoteAccessThrottledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_remoteaccess_throttled_requests",
+			Help: "Total number of remote access requests rejected by the client limits",
+		},
+		[]string{"handler", "client", "type", "reason"},
+	)
+
 	RemoteAccessSeconds = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "external_dns_management_remoteaccess_seconds",
@@ -129,6 +258,103 @@ var (
 		[]string{"handler", "client", "type", "zoneid", "error"},
 	)
 
+	ProviderAPISeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_provider_api_seconds",
+			Help:    "Duration in seconds of provider API calls per provider type, operation and result (success or error)",
+			Buckets: []float64{.05, .1, .25, .5, 1, 2.5, 5, 10, 25, 60, 120},
+		},
+		[]string{"providertype", "operation", "result"},
+	)
+
+	ChangeBatchSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_change_batch_size",
+			Help:    "Number of change requests executed by a provider in a single batch per provider type",
+			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
+		},
+		[]string{"providertype"},
+	)
+
+	StuckReconciliations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_stuck_reconciliations",
+			Help: "Total number of reconciliations exceeding the watchdog threshold",
+		},
+		[]string{"controller", "kind"},
+	)
+
+	WriteFreeze = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_write_freeze",
+			Help: "Global write freeze suspending all provider writes (1 if active)",
+		},
+	)
+
+	PlannedChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_planned_changes",
+			Help: "Planned, but not applied changes per provider type, zone and action (dry-run, read-only or write freeze)",
+		},
+		[]string{"providertype", "zone", "action"},
+	)
+
+	ProviderErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_provider_errors",
+			Help: "Errors of provider API calls per provider type and error reason",
+		},
+		[]string{"providertype", "reason"},
+	)
+
+	RecordSetChanges = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_record_set_changes",
+			Help: "Applied record set changes per provider type, zone, action and record type",
+		},
+		[]string{"providertype", "zone", "action", "recordtype"},
+	)
+
+	AnomalyGuardPausedZones = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_anomaly_guard_paused_zones",
+			Help: "Zones with writes paused by the anomaly guard per provider type and zone (1 if paused)",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntryFlaps = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_entry_flaps",
+			Help: "Target changes of flapping entries per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	FlappingEntries = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_flapping_entries",
+			Help: "Entries with changes held down because of flapping targets per provider type and zone",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	ZoneCanaryAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_canary_age_seconds",
+			Help: "Age of the timestamp of the canary record resolved by DNS per provider type and zone (maximum for aggregated zones)",
+		},
+		[]string{"providertype", "zone"},
+	)
+
+	EntriesWithoutProvider = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_entries_without_provider",
+			Help: "Entries without responsible provider per reason",
+		},
+		[]string{"reason"},
+	)
+
 	RemoteAccessCertificates = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_remoteaccess_transport_credentials",
@@ -174,27 +400,88 @@ func (this *requestLabels) Delete(ptype, account string) utils.StringSet {
 
 func DeleteAccount(ptype, account string) {
 	Accounts.DeleteLabelValues(ptype, account)
+	AccountRateLimits.DeleteLabelValues(ptype, account)
 	requestTypes := theRequestLabels.Delete(ptype, account)
 	for rtype := range requestTypes {
 		Requests.DeleteLabelValues(ptype, account, rtype)
 	}
 	Entries.DeleteLabelValues(ptype, account)
+	ZoneListCacheLookups.DeleteLabelValues(ptype, account, CacheHit)
+	ZoneListCacheLookups.DeleteLabelValues(ptype, account, CacheMiss)
+	ZoneListBackoff.DeleteLabelValues(ptype, account)
 }
 
 func ReportAccountProviders(ptype, account string, amount int) {
 	Accounts.WithLabelValues(ptype, account).Set(float64(amount))
 }
 
+func ReportAccountRateLimit(ptype, account string, qps float32) {
+	AccountRateLimits.WithLabelValues(ptype, account).Set(float64(qps))
+}
+
 func AddRequests(ptype, account, requestType string, no int, zone *string) {
 	theRequestLabels.AddRequestLabel(ptype, account, requestType)
 	Requests.WithLabelValues(ptype, account, requestType).Add(float64(no))
 	if zone != nil {
-		ZoneRequests.WithLabelValues(ptype, account, requestType, *zone).Add(float64(no))
+		ZoneRequests.WithLabelValues(ptype, account, requestType, ZoneLabel(*zone)).Add(float64(no))
 	}
 }
 
 func AddZoneCacheDiscarding(id dns.ZoneID) {
-	ZoneCacheDiscardings.WithLabelValues(id.ProviderType, id.ID).Add(float64(1))
+	ZoneCacheDiscardings.WithLabelValues(id.ProviderType, ZoneLabel(id.ID)).Add(float64(1))
+}
+
+// CacheHit and CacheMiss are the result label values of zone cache lookups.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+func cacheResult(hit bool) string {
+	if hit {
+		return CacheHit
+	}
+	return CacheMiss
+}
+
+func AddZoneCacheLookup(id dns.ZoneID, hit bool) {
+	ZoneCacheLookups.WithLabelValues(id.ProviderType, ZoneLabel(id.ID), cacheResult(hit)).Inc()
+}
+
+func ReportZoneStateAge(id dns.ZoneID, age time.Duration) {
+	theZoneLabelScope.reportStateAge(id, &age)
+}
+
+func DeleteZoneStateAge(id dns.ZoneID) {
+	theZoneLabelScope.reportStateAge(id, nil)
+}
+
+func AddZoneListCacheLookup(ptype, account string, hit bool) {
+	ZoneListCacheLookups.WithLabelValues(ptype, account, cacheResult(hit)).Inc()
+}
+
+func ReportZoneListBackoff(ptype, account string, backoff time.Duration) {
+	ZoneListBackoff.WithLabelValues(ptype, account).Set(backoff.Seconds())
+}
+
+func AddZoneCacheRestored(id dns.ZoneID) {
+	ZoneCacheRestorings.WithLabelValues(id.ProviderType, ZoneLabel(id.ID)).Add(float64(1))
+}
+
+func AddZoneCacheInvalidation(id dns.ZoneID) {
+	ZoneCacheInvalidations.WithLabelValues(id.ProviderType, ZoneLabel(id.ID)).Add(float64(1))
+}
+
+func AddOwnerConflict(providerType, owner, resolution string) {
+	OwnerConflicts.WithLabelValues(providerType, owner, resolution).Inc()
+}
+
+func AddZoneCacheStaleServing(id dns.ZoneID) {
+	ZoneCacheStaleServings.WithLabelValues(id.ProviderType, ZoneLabel(id.ID)).Add(float64(1))
+}
+
+func ReportZoneQueries(id dns.ZoneID, queriesPerHour int64, unqueried int) {
+	theZoneLabelScope.reportQueries(id, queriesPerHour, unqueried)
 }
 
 type ZoneProviderTypes struct {
@@ -217,8 +504,7 @@ func (this *ZoneProviderTypes) Remove(zone dns.ZoneID) {
 var zoneProviders = &ZoneProviderTypes{providers: map[dns.ZoneID]struct{}{}}
 
 func ReportZoneEntries(zoneid dns.ZoneID, amount int, stale int) {
-	Entries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
-	StaleEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(stale))
+	theZoneLabelScope.reportEntries(zoneid, amount, stale)
 	zoneProviders.Add(zoneid)
 }
 
@@ -227,11 +513,86 @@ func ReportRemoteAccessLogins(namespace, client string, success bool) {
 }
 
 func ReportRemoteAccessRequests(namespace, client, requestType, zoneid string) {
-	RemoteAccessRequests.WithLabelValues(namespace, client, requestType, zoneid).Add(float64(1))
+	RemoteAccessRequests.WithLabelValues(namespace, client, requestType, ZoneLabel(zoneid)).Add(float64(1))
+}
+
+func AddRemoteAccessThrottledRequests(namespace, client, requestType, reason string) {
+	RemoteAccessThrottledRequests.WithLabelValues(namespace, client, requestType, reason).Inc()
 }
 
 func ReportRemoteAccessSeconds(namespace, client, requestType, zoneid, error string, duration time.Duration) {
-	RemoteAccessSeconds.WithLabelValues(namespace, client, requestType, zoneid, error).Observe(duration.Seconds())
+	RemoteAccessSeconds.WithLabelValues(namespace, client, requestType, ZoneLabel(zoneid), error).Observe(duration.Seconds())
+}
+
+// Operation label values of the provider API call durations.
+const (
+	OperationGetZones        = "getzones"
+	OperationGetZoneState    = "getzonestate"
+	OperationExecuteRequests = "executerequests"
+)
+
+func ReportProviderAPISeconds(ptype, operation string, err error, duration time.Duration) {
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	ProviderAPISeconds.WithLabelValues(ptype, operation, result).Observe(duration.Seconds())
+}
+
+func ReportChangeBatchSize(ptype string, size int) {
+	ChangeBatchSize.WithLabelValues(ptype).Observe(float64(size))
+}
+
+func AddStuckReconciliation(controller, kind string) {
+	StuckReconciliations.WithLabelValues(controller, kind).Inc()
+}
+
+func ReportWriteFreeze(frozen bool) {
+	if frozen {
+		WriteFreeze.Set(1)
+	} else {
+		WriteFreeze.Set(0)
+	}
+}
+
+func AddPlannedChanges(zoneid dns.ZoneID, action string, no int) {
+	PlannedChanges.WithLabelValues(zoneid.ProviderType, ZoneLabel(zoneid.ID), action).Add(float64(no))
+}
+
+func AddRecordSetChange(zoneid dns.ZoneID, action, rtype string) {
+	RecordSetChanges.WithLabelValues(zoneid.ProviderType, ZoneLabel(zoneid.ID), action, rtype).Inc()
+}
+
+func ReportAnomalyGuardPause(zoneid dns.ZoneID, paused bool) {
+	if paused {
+		theZoneLabelScope.reportPaused(zoneid, 1)
+	} else {
+		theZoneLabelScope.reportPaused(zoneid, 0)
+	}
+}
+
+func AddEntryFlap(zoneid dns.ZoneID) {
+	EntryFlaps.WithLabelValues(zoneid.ProviderType, ZoneLabel(zoneid.ID)).Inc()
+}
+
+func ReportFlappingEntries(zoneid dns.ZoneID, count int) {
+	theZoneLabelScope.reportFlapping(zoneid, count)
+}
+
+func ReportZoneCanaryAge(zoneid dns.ZoneID, age time.Duration) {
+	theZoneLabelScope.reportCanaryAge(zoneid, &age)
+}
+
+func DeleteZoneCanary(zoneid dns.ZoneID) {
+	theZoneLabelScope.reportCanaryAge(zoneid, nil)
+}
+
+func AddProviderError(ptype, reason string) {
+	ProviderErrors.WithLabelValues(ptype, reason).Inc()
+}
+
+func ReportEntriesWithoutProvider(reason string, count int) {
+	EntriesWithoutProvider.WithLabelValues(reason).Set(float64(count))
 }
 
 func ReportRemoteAccessCertificates(count int) {
@@ -240,7 +601,7 @@ func ReportRemoteAccessCertificates(count int) {
 
 func DeleteZone(zoneid dns.ZoneID) {
 	zoneProviders.Remove(zoneid)
-	Entries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	theZoneLabelScope.deleteEntries(zoneid)
 }
 
 var currentStatistic = statistic.NewEntryStatistic()
@@ -254,6 +615,23 @@ func deleteOwnerStatistic(state statistic.WalkingState, owner, ptype string, pna
 	return state
 }
 
+var currentOwnerGroups = statistic.OwnerGroupStatistic{}
+
+func UpdateOwnerGroupStatistic(groups statistic.OwnerGroupStatistic) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	for key := range currentOwnerGroups {
+		if _, ok := groups[key]; !ok {
+			OwnerGroups.DeleteLabelValues(key.OwnerGroup, key.Owner, key.ProviderType)
+		}
+	}
+	for key, count := range groups {
+		OwnerGroups.WithLabelValues(key.OwnerGroup, key.Owner, key.ProviderType).Set(float64(count))
+	}
+	currentOwnerGroups = groups
+}
+
 func UpdateOwnerStatistic(statistic *statistic.EntryStatistic, types utils.StringSet) {
 	lock.Lock()
 	defer lock.Unlock()
@@ -0,0 +1,277 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package metrics
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// OtherZones is the zone label value used for all zones without detailed metrics.
+const OtherZones = "other"
+
+// ZoneLabelsAll enables detailed metrics for all zones
+const ZoneLabelsAll = "*"
+
+// ZoneLabelsNone aggregates the metrics of all zones
+const ZoneLabelsNone = "none"
+
+type zoneCounts struct {
+	entries   int
+	stale     int
+	queries   *int64
+	unqueried int
+	flapping  int
+	paused    int
+	canaryAge *time.Duration
+	stateAge  *time.Duration
+}
+
+// zoneLabelScope restricts the zone label values to an allowlist.
+// The metrics of all other zones are bucketed in the zone OtherZones.
+type zoneLabelScope struct {
+	lock     sync.Mutex
+	all      bool
+	allowed  utils.StringSet
+	bucketed map[dns.ZoneID]zoneCounts
+}
+
+var theZoneLabelScope = &zoneLabelScope{all: true, allowed: utils.StringSet{}, bucketed: map[dns.ZoneID]zoneCounts{}}
+
+// SetZoneLabelAllowlist configures the zones reported with detailed metrics.
+// It is a comma separated list of zone ids, `*` for all zones or `none` (or empty)
+// to aggregate the metrics of all zones.
+func SetZoneLabelAllowlist(list string) {
+	theZoneLabelScope.lock.Lock()
+	defer theZoneLabelScope.lock.Unlock()
+
+	theZoneLabelScope.allowed = utils.StringSet{}
+	theZoneLabelScope.all = false
+	for _, z := range strings.Split(list, ",") {
+		z = strings.TrimSpace(z)
+		switch z {
+		case "", ZoneLabelsNone:
+		case ZoneLabelsAll:
+			theZoneLabelScope.all = true
+		default:
+			theZoneLabelScope.allowed.Add(z)
+		}
+	}
+}
+
+func (this *zoneLabelScope) isDetailed(zone string) bool {
+	return this.all || this.allowed.Contains(zone)
+}
+
+// ZoneLabel maps a zone id to the label value used for metrics.
+func ZoneLabel(zone string) string {
+	theZoneLabelScope.lock.Lock()
+	defer theZoneLabelScope.lock.Unlock()
+	if theZoneLabelScope.isDetailed(zone) {
+		return zone
+	}
+	return OtherZones
+}
+
+// reportEntries sets the entry gauges of a zone, summing up all bucketed zones of the provider type.
+func (this *zoneLabelScope) reportEntries(zoneid dns.ZoneID, amount, stale int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		Entries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(amount))
+		StaleEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(stale))
+		return
+	}
+	counts := this.bucketed[zoneid]
+	counts.entries = amount
+	counts.stale = stale
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+// reportQueries sets the query gauges of a zone, summing up all bucketed zones of the provider type.
+func (this *zoneLabelScope) reportQueries(zoneid dns.ZoneID, queriesPerHour int64, unqueried int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		ZoneQueries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(queriesPerHour))
+		UnqueriedEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(unqueried))
+		return
+	}
+	counts := this.bucketed[zoneid]
+	counts.queries = &queriesPerHour
+	counts.unqueried = unqueried
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+// reportFlapping sets the gauge of flapping entries of a zone, summing up all bucketed zones of the provider type.
+func (this *zoneLabelScope) reportFlapping(zoneid dns.ZoneID, flapping int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		FlappingEntries.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(flapping))
+		return
+	}
+	counts := this.bucketed[zoneid]
+	counts.flapping = flapping
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+// reportPaused sets the gauge of zones paused by the anomaly guard, summing up all bucketed zones of the provider type.
+func (this *zoneLabelScope) reportPaused(zoneid dns.ZoneID, paused int) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		AnomalyGuardPausedZones.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(float64(paused))
+		return
+	}
+	counts := this.bucketed[zoneid]
+	counts.paused = paused
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+// reportCanaryAge sets the canary age gauge of a zone, reporting the maximum age of all bucketed zones of the provider type.
+// A nil age removes the gauge of the zone.
+func (this *zoneLabelScope) reportCanaryAge(zoneid dns.ZoneID, age *time.Duration) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		if age == nil {
+			ZoneCanaryAge.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+		} else {
+			ZoneCanaryAge.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(age.Seconds())
+		}
+		return
+	}
+	counts, ok := this.bucketed[zoneid]
+	if !ok && age == nil {
+		return
+	}
+	counts.canaryAge = age
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+// reportStateAge sets the zone state age gauge of a zone, reporting the maximum age of all bucketed zones of the provider type.
+// A nil age removes the gauge of the zone.
+func (this *zoneLabelScope) reportStateAge(zoneid dns.ZoneID, age *time.Duration) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.isDetailed(zoneid.ID) {
+		if age == nil {
+			ZoneStateAge.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+		} else {
+			ZoneStateAge.WithLabelValues(zoneid.ProviderType, zoneid.ID).Set(age.Seconds())
+		}
+		return
+	}
+	counts, ok := this.bucketed[zoneid]
+	if !ok && age == nil {
+		return
+	}
+	counts.stateAge = age
+	this.bucketed[zoneid] = counts
+	this.updateBucket(zoneid.ProviderType)
+}
+
+func (this *zoneLabelScope) deleteEntries(zoneid dns.ZoneID) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if _, ok := this.bucketed[zoneid]; ok {
+		delete(this.bucketed, zoneid)
+		this.updateBucket(zoneid.ProviderType)
+		return
+	}
+	Entries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	StaleEntries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	ZoneQueries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	UnqueriedEntries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	FlappingEntries.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	AnomalyGuardPausedZones.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	ZoneCanaryAge.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+	ZoneStateAge.DeleteLabelValues(zoneid.ProviderType, zoneid.ID)
+}
+
+func (this *zoneLabelScope) updateBucket(ptype string) {
+	found := false
+	queried := false
+	sum := zoneCounts{}
+	var queries int64
+	var canaryAge, stateAge *time.Duration
+	for id, c := range this.bucketed {
+		if id.ProviderType == ptype {
+			if c.canaryAge != nil && (canaryAge == nil || *c.canaryAge > *canaryAge) {
+				canaryAge = c.canaryAge
+			}
+			if c.stateAge != nil && (stateAge == nil || *c.stateAge > *stateAge) {
+				stateAge = c.stateAge
+			}
+			found = true
+			sum.entries += c.entries
+			sum.stale += c.stale
+			sum.flapping += c.flapping
+			sum.paused += c.paused
+			if c.queries != nil {
+				queried = true
+				queries += *c.queries
+				sum.unqueried += c.unqueried
+			}
+		}
+	}
+	if !queried {
+		ZoneQueries.DeleteLabelValues(ptype, OtherZones)
+		UnqueriedEntries.DeleteLabelValues(ptype, OtherZones)
+	} else {
+		ZoneQueries.WithLabelValues(ptype, OtherZones).Set(float64(queries))
+		UnqueriedEntries.WithLabelValues(ptype, OtherZones).Set(float64(sum.unqueried))
+	}
+	if canaryAge == nil {
+		ZoneCanaryAge.DeleteLabelValues(ptype, OtherZones)
+	} else {
+		ZoneCanaryAge.WithLabelValues(ptype, OtherZones).Set(canaryAge.Seconds())
+	}
+	if stateAge == nil {
+		ZoneStateAge.DeleteLabelValues(ptype, OtherZones)
+	} else {
+		ZoneStateAge.WithLabelValues(ptype, OtherZones).Set(stateAge.Seconds())
+	}
+	if !found {
+		Entries.DeleteLabelValues(ptype, OtherZones)
+		StaleEntries.DeleteLabelValues(ptype, OtherZones)
+		FlappingEntries.DeleteLabelValues(ptype, OtherZones)
+		AnomalyGuardPausedZones.DeleteLabelValues(ptype, OtherZones)
+		return
+	}
+	Entries.WithLabelValues(ptype, OtherZones).Set(float64(sum.entries))
+	StaleEntries.WithLabelValues(ptype, OtherZones).Set(float64(sum.stale))
+	FlappingEntries.WithLabelValues(ptype, OtherZones).Set(float64(sum.flapping))
+	AnomalyGuardPausedZones.WithLabelValues(ptype, OtherZones).Set(float64(sum.paused))
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const scopeTestType = "scopetest"
+
+// resetZoneLabelScope configures the allowlist and drops all bucketed zones and reported zone gauges.
+func resetZoneLabelScope(allowlist string) {
+	SetZoneLabelAllowlist(allowlist)
+	theZoneLabelScope.lock.Lock()
+	theZoneLabelScope.bucketed = map[dns.ZoneID]zoneCounts{}
+	theZoneLabelScope.lock.Unlock()
+	for _, g := range []*prometheus.GaugeVec{Entries, StaleEntries, ZoneQueries, UnqueriedEntries, FlappingEntries,
+		AnomalyGuardPausedZones, ZoneCanaryAge, ZoneStateAge} {
+		g.Reset()
+	}
+}
+
+// gathered returns the value of the gauge with the given name and labels from the registry, or nil if it does not exist.
+func gathered(t *testing.T, name, zone string) *float64 {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatalf("Failed: cannot gather metrics: %s", err)
+	}
+	for _, f := range families {
+		if f.GetName() != name {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			if hasLabels(m, scopeTestType, zone) {
+				v := m.GetGauge().GetValue()
+				return &v
+			}
+		}
+	}
+	return nil
+}
+
+func hasLabels(m *dto.Metric, ptype, zone string) bool {
+	found := 0
+	for _, l := range m.GetLabel() {
+		switch {
+		case l.GetName() == "providertype" && l.GetValue() == ptype:
+			found++
+		case l.GetName() == "zone" && l.GetValue() == zone:
+			found++
+		}
+	}
+	return found == 2
+}
+
+func zone(id string) dns.ZoneID {
+	return dns.NewZoneID(scopeTestType, id)
+}
+
+func TestZoneLabel(t *testing.T) {
+	table := []struct {
+		allowlist string
+		zone      string
+		label     string
+	}{
+		{"*", "z1", "z1"},
+		{"z1, z2", "z1", "z1"},
+		{"z1,z2", "z3", OtherZones},
+		{"none", "z1", OtherZones},
+		{"", "z1", OtherZones},
+		{"z1,*", "z3", "z3"},
+	}
+	for _, entry := range table {
+		resetZoneLabelScope(entry.allowlist)
+		if label := ZoneLabel(entry.zone); label != entry.label {
+			t.Errorf("Failed: allowlist %q, zone %s: expected label %s, got %s", entry.allowlist, entry.zone, entry.label, label)
+		}
+	}
+	resetZoneLabelScope(ZoneLabelsAll)
+}
+
+func TestZoneLabelScopeReports(t *testing.T) {
+	table := []struct {
+		name     string
+		report   func()
+		metric   string
+		zone     string
+		expected float64
+	}{
+		{
+			name:     "detailed entries",
+			report:   func() { ReportZoneEntries(zone("z1"), 3, 1) },
+			metric:   "external_dns_management_dns_entries",
+			zone:     "z1",
+			expected: 3,
+		},
+		{
+			name: "summed up entries of bucketed zones",
+			report: func() {
+				ReportZoneEntries(zone("z1"), 3, 1)
+				ReportZoneEntries(zone("z2"), 4, 2)
+				ReportZoneEntries(zone("z3"), 5, 0)
+				ReportZoneEntries(zone("z2"), 1, 0)
+			},
+			metric:   "external_dns_management_dns_entries",
+			zone:     OtherZones,
+			expected: 6,
+		},
+		{
+			name: "summed up stale entries of bucketed zones",
+			report: func() {
+				ReportZoneEntries(zone("z2"), 4, 2)
+				ReportZoneEntries(zone("z3"), 5, 3)
+			},
+			metric:   "external_dns_management_dns_entries_stale",
+			zone:     OtherZones,
+			expected: 5,
+		},
+		{
+			name: "summed up queries of bucketed zones",
+			report: func() {
+				ReportZoneQueries(zone("z2"), 100, 1)
+				ReportZoneQueries(zone("z3"), 50, 2)
+			},
+			metric:   "external_dns_management_zone_queries_per_hour",
+			zone:     OtherZones,
+			expected: 150,
+		},
+		{
+			name: "summed up flapping entries of bucketed zones",
+			report: func() {
+				ReportFlappingEntries(zone("z2"), 1)
+				ReportFlappingEntries(zone("z3"), 2)
+			},
+			metric:   "external_dns_management_flapping_entries",
+			zone:     OtherZones,
+			expected: 3,
+		},
+		{
+			name: "summed up paused zones of bucketed zones",
+			report: func() {
+				ReportAnomalyGuardPause(zone("z2"), true)
+				ReportAnomalyGuardPause(zone("z3"), true)
+				ReportAnomalyGuardPause(zone("z4"), false)
+			},
+			metric:   "external_dns_management_anomaly_guard_paused_zones",
+			zone:     OtherZones,
+			expected: 2,
+		},
+		{
+			name: "maximum canary age of bucketed zones",
+			report: func() {
+				ReportZoneCanaryAge(zone("z2"), 10*time.Second)
+				ReportZoneCanaryAge(zone("z3"), 30*time.Second)
+			},
+			metric:   "external_dns_management_zone_canary_age_seconds",
+			zone:     OtherZones,
+			expected: 30,
+		},
+		{
+			name: "maximum zone state age of bucketed zones",
+			report: func() {
+				ReportZoneStateAge(zone("z2"), 20*time.Second)
+				ReportZoneStateAge(zone("z3"), 5*time.Second)
+				DeleteZoneStateAge(zone("z2"))
+			},
+			metric:   "external_dns_management_zone_state_age_seconds",
+			zone:     OtherZones,
+			expected: 5,
+		},
+	}
+	for _, entry := range table {
+		resetZoneLabelScope("z1")
+		entry.report()
+		value := gathered(t, entry.metric, entry.zone)
+		if value == nil {
+			t.Errorf("Failed: %s: metric %s for zone %s not found", entry.name, entry.metric, entry.zone)
+			continue
+		}
+		if *value != entry.expected {
+			t.Errorf("Failed: %s: expected %v, got %v", entry.name, entry.expected, *value)
+		}
+	}
+	resetZoneLabelScope(ZoneLabelsAll)
+}
+
+func TestZoneLabelScopeDetailedZonesNotBucketed(t *testing.T) {
+	resetZoneLabelScope("z1")
+	ReportZoneEntries(zone("z1"), 3, 1)
+	ReportZoneEntries(zone("z2"), 4, 2)
+
+	if v := testutil.ToFloat64(Entries.WithLabelValues(scopeTestType, "z1")); v != 3 {
+		t.Errorf("Failed: expected 3 entries for detailed zone, got %v", v)
+	}
+	if v := testutil.ToFloat64(Entries.WithLabelValues(scopeTestType, OtherZones)); v != 4 {
+		t.Errorf("Failed: expected 4 entries for bucketed zones, got %v", v)
+	}
+	if gathered(t, "external_dns_management_dns_entries", "z2") != nil {
+		t.Errorf("Failed: bucketed zone reported with detailed label")
+	}
+	resetZoneLabelScope(ZoneLabelsAll)
+}
+
+func TestZoneLabelScopeDelete(t *testing.T) {
+	resetZoneLabelScope("z1")
+	ReportZoneEntries(zone("z1"), 3, 1)
+	ReportZoneCanaryAge(zone("z1"), time.Minute)
+	ReportZoneEntries(zone("z2"), 4, 2)
+	ReportZoneEntries(zone("z3"), 5, 3)
+	ReportZoneCanaryAge(zone("z3"), time.Minute)
+
+	DeleteZone(zone("z1"))
+	for _, name := range []string{"external_dns_management_dns_entries", "external_dns_management_dns_entries_stale",
+		"external_dns_management_zone_canary_age_seconds"} {
+		if gathered(t, name, "z1") != nil {
+			t.Errorf("Failed: %s of deleted detailed zone still reported", name)
+		}
+	}
+
+	DeleteZone(zone("z3"))
+	if v := gathered(t, "external_dns_management_dns_entries", OtherZones); v == nil || *v != 4 {
+		t.Errorf("Failed: expected 4 entries for remaining bucketed zone, got %v", v)
+	}
+	if gathered(t, "external_dns_management_zone_canary_age_seconds", OtherZones) != nil {
+		t.Errorf("Failed: canary age of bucketed zones still reported without remaining canary")
+	}
+
+	DeleteZone(zone("z2"))
+	for _, name := range []string{"external_dns_management_dns_entries", "external_dns_management_dns_entries_stale",
+		"external_dns_management_flapping_entries", "external_dns_management_anomaly_guard_paused_zones"} {
+		if gathered(t, name, OtherZones) != nil {
+			t.Errorf("Failed: %s of bucketed zones still reported after deletion of all zones", name)
+		}
+	}
+	resetZoneLabelScope(ZoneLabelsAll)
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// pendingRetryAfter is the delay suggested to clients exceeding their pending changes
+const pendingRetryAfter = 10 * time.Second
+
+type clientState struct {
+	limiter  *rate.Limiter
+	pending  int
+	lastUsed time.Time
+}
+
+// clientLimiter enforces the per client limits of the remote access server,
+// so that a single misbehaving client cannot exhaust the quota of the provider accounts.
+type clientLimiter struct {
+	lock    sync.Mutex
+	limits  common.ClientLimits
+	clients map[string]*clientState
+}
+
+func newClientLimiter(limits common.ClientLimits) *clientLimiter {
+	return &clientLimiter{
+		limits:  limits,
+		clients: map[string]*clientState{},
+	}
+}
+
+func (l *clientLimiter) _getClientState(namespace, clientID string) *clientState {
+	key := namespace + "/" + clientID
+	cstate := l.clients[key]
+	if cstate == nil {
+		cstate = &clientState{}
+		if l.limits.QPS > 0 {
+			burst := l.limits.Burst
+			if burst < 1 {
+				burst = 1
+			}
+			cstate.limiter = rate.NewLimiter(rate.Limit(l.limits.QPS), burst)
+		}
+		l.clients[key] = cstate
+	}
+	cstate.lastUsed = time.Now()
+	return cstate
+}
+
+// accept checks the rate limit and the pending changes of a client.
+// On success, the returned function must be called after the request has been processed
+// to release the pending changes.
+func (l *clientLimiter) accept(namespace, clientID, requestType string, changes int) (func(), error) {
+	if !l.limits.IsLimited() {
+		return func() {}, nil
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	cstate := l._getClientState(namespace, clientID)
+	if cstate.limiter != nil {
+		r := cstate.limiter.Reserve()
+		if delay := r.Delay(); delay > 0 {
+			r.Cancel()
+			metrics.AddRemoteAccessThrottledRequests(namespace, clientID, requestType, "ratelimit")
+			return nil, throttledError(fmt.Sprintf("rate limit of client %s exceeded", clientID), delay)
+		}
+	}
+	if l.limits.MaxPendingChanges > 0 && changes > 0 {
+		// a single request exceeding the limit is accepted if there are no other pending changes
+		if cstate.pending > 0 && cstate.pending+changes > l.limits.MaxPendingChanges {
+			metrics.AddRemoteAccessThrottledRequests(namespace, clientID, requestType, "pending")
+			return nil, throttledError(fmt.Sprintf("too many pending changes of client %s (%d)", clientID, cstate.pending), pendingRetryAfter)
+		}
+		cstate.pending += changes
+	}
+
+	return func() {
+		if l.limits.MaxPendingChanges > 0 && changes > 0 {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+			cstate.pending -= changes
+		}
+	}, nil
+}
+
+// cleanup removes the states of clients idle since the given time.
+func (l *clientLimiter) cleanup(idleSince time.Time) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	count := 0
+	for key, cstate := range l.clients {
+		if cstate.pending == 0 && cstate.lastUsed.Before(idleSince) {
+			delete(l.clients, key)
+			count++
+		}
+	}
+	return count
+}
+
+// throttledError signals backpressure to the client with the gRPC status code
+// ResourceExhausted and the suggested delay as RetryInfo detail.
+func throttledError(msg string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
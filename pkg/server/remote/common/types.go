@@ -18,4 +18,43 @@ package common
 
 const InvalidToken = "[invalid token]"
 
+const (
+	// ProtocolVersion1 is the initial protocol version (reported as 0 by old clients and servers)
+	ProtocolVersion1 int32 = 1
+	// ProtocolVersion2 adds the capability exchange on login and the routing policies of record sets
+	ProtocolVersion2 int32 = 2
+	// ProtocolVersion3 adds delta transfers of zone states based on sync tokens
+	ProtocolVersion3 int32 = 3
+
+	// ProtocolVersion is the latest protocol version supported by this implementation
+	ProtocolVersion = ProtocolVersion3
+)
+
+// NegotiateProtocolVersion returns the highest protocol version supported by both sides.
+func NegotiateProtocolVersion(peerVersion int32) int32 {
+	if peerVersion < ProtocolVersion1 {
+		return ProtocolVersion1
+	}
+	if peerVersion > ProtocolVersion {
+		return ProtocolVersion
+	}
+	return peerVersion
+}
+
 type DNSSets map[string]*DNSSet
+
+// ClientLimits restricts the requests of a single remote access client
+// (identified by namespace and client ID) to protect the quota of the provider accounts.
+type ClientLimits struct {
+	// QPS is the maximum number of requests per second of a client (0 disables the rate limit)
+	QPS float32
+	// Burst is the number of burst requests of a client
+	Burst int
+	// MaxPendingChanges is the maximum number of change requests of a client waiting for or in execution (0: unlimited)
+	MaxPendingChanges int
+}
+
+// IsLimited returns true if any limit is set
+func (l ClientLimits) IsLimited() bool {
+	return l.QPS > 0 || l.MaxPendingChanges > 0
+}
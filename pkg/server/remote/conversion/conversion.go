@@ -34,9 +34,10 @@ func MarshalDNSSets(local dns.DNSSets) common.DNSSets {
 
 func MarshalDNSSet(local *dns.DNSSet) *common.DNSSet {
 	remote := &common.DNSSet{
-		DnsName:     local.Name,
-		UpdateGroup: local.UpdateGroup,
-		Records:     map[string]*common.RecordSet{},
+		DnsName:       local.Name,
+		UpdateGroup:   local.UpdateGroup,
+		Records:       map[string]*common.RecordSet{},
+		SetIdentifier: local.SetIdentifier,
 	}
 	for typ, rs := range local.Sets {
 		remote.Records[typ] = MarshalRecordSet(rs)
@@ -52,15 +53,28 @@ func MarshalRecordSet(local *dns.RecordSet) *common.RecordSet {
 	for _, v := range local.Records {
 		remote.Record = append(remote.Record, &common.RecordSet_Record{Value: v.Value})
 	}
+	remote.RoutingPolicy = MarshalRoutingPolicy(local.RoutingPolicy)
 	return remote
 }
 
+func MarshalRoutingPolicy(local *dns.RoutingPolicy) *common.RoutingPolicy {
+	if local == nil {
+		return nil
+	}
+	return &common.RoutingPolicy{
+		Type:          local.Type,
+		SetIdentifier: local.SetIdentifier,
+		Parameters:    local.Parameters,
+	}
+}
+
 func MarshalPartialDNSSet(local *dns.DNSSet, recordType string) *common.PartialDNSSet {
 	return &common.PartialDNSSet{
-		DnsName:     local.Name,
-		UpdateGroup: local.UpdateGroup,
-		RecordType:  recordType,
-		RecordSet:   MarshalRecordSet(local.Sets[recordType]),
+		DnsName:       local.Name,
+		UpdateGroup:   local.UpdateGroup,
+		RecordType:    recordType,
+		RecordSet:     MarshalRecordSet(local.Sets[recordType]),
+		SetIdentifier: local.SetIdentifier,
 	}
 }
 
@@ -75,6 +89,7 @@ func UnmarshalDNSSets(remote common.DNSSets) dns.DNSSets {
 func UnmarshalDNSSet(remote *common.DNSSet) *dns.DNSSet {
 	local := dns.NewDNSSet(remote.DnsName)
 	local.UpdateGroup = remote.UpdateGroup
+	local.SetIdentifier = remote.SetIdentifier
 
 	for typ, rs := range remote.Records {
 		local.Sets[typ] = UnmarshalRecordSet(rs)
@@ -87,12 +102,23 @@ func UnmarshalRecordSet(rs *common.RecordSet) *dns.RecordSet {
 	for _, v := range rs.Record {
 		local.Add(&dns.Record{Value: v.Value})
 	}
+	local.RoutingPolicy = UnmarshalRoutingPolicy(rs.RoutingPolicy)
+	return local
+}
+
+func UnmarshalRoutingPolicy(remote *common.RoutingPolicy) *dns.RoutingPolicy {
+	if remote == nil {
+		return nil
+	}
+	local := dns.NewRoutingPolicy(remote.Type, remote.Parameters)
+	local.SetIdentifier = remote.SetIdentifier
 	return local
 }
 
 func UnmarshalPartialDNSSet(remote *common.PartialDNSSet) *dns.DNSSet {
 	local := dns.NewDNSSet(remote.DnsName)
 	local.UpdateGroup = remote.UpdateGroup
+	local.SetIdentifier = remote.SetIdentifier
 
 	local.Sets[remote.RecordType] = UnmarshalRecordSet(remote.RecordSet)
 	return local
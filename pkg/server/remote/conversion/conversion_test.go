@@ -30,12 +30,22 @@ func TestMarshalDNSSets(t *testing.T) {
 	rsc := dns.NewRecordSet(dns.RS_TXT, 200, []*dns.Record{{Value: "foo"}, {Value: "bar"}})
 	sets1.AddRecordSet("b.a", rsb)
 	sets1.AddRecordSet("c.a", rsc)
+	sets2 := dns.DNSSets{}
+	rsw1 := dns.NewRecordSet(dns.RS_A, 100, []*dns.Record{{Value: "1.1.1.1"}})
+	rsw1.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_WEIGHTED, map[string]string{"weight": "10"})
+	rsw1.RoutingPolicy.SetIdentifier = "blue"
+	rsw2 := dns.NewRecordSet(dns.RS_A, 100, []*dns.Record{{Value: "1.1.1.2"}})
+	rsw2.RoutingPolicy = dns.NewRoutingPolicy(dns.RP_WEIGHTED, map[string]string{"weight": "90"})
+	rsw2.RoutingPolicy.SetIdentifier = "green"
+	sets2.AddRecordSet("w.a", rsw1)
+	sets2.AddRecordSet("w.a", rsw2)
 	table := []struct {
 		name string
 		sets dns.DNSSets
 	}{
 		{"empty", dns.DNSSets{}},
 		{"sets1", sets1},
+		{"routing policies", sets2},
 	}
 
 	for _, item := range table {
@@ -0,0 +1,86 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+)
+
+// maxZoneSnapshots is the number of zone state snapshots kept per zone as base for delta transfers.
+// As multiple clients may synchronize the same zone, more than the last snapshot is kept.
+const maxZoneSnapshots = 4
+
+// zoneSnapshot is a zone state transferred to a client, identified by its sync token.
+type zoneSnapshot struct {
+	token   string
+	dnsSets common.DNSSets
+}
+
+var syncTokenCounter uint64
+
+func newSyncToken() string {
+	return fmt.Sprintf("%x-%x", time.Now().UnixNano(), atomic.AddUint64(&syncTokenCounter, 1))
+}
+
+// zoneStateResponse creates the response for the current DNS sets of a zone.
+// If the client sends the sync token of a known snapshot, only the DNS sets changed
+// since this snapshot are transferred. Must be called with locked handler state.
+func (h *handlerState) zoneStateResponse(zoneid, syncToken string, dnsSets common.DNSSets) *common.ZoneState {
+	result := &common.ZoneState{DnsSets: dnsSets, SyncToken: newSyncToken()}
+	snapshots := h.snapshots[zoneid]
+	if base := findZoneSnapshot(snapshots, syncToken); base != nil {
+		result.Delta = true
+		result.DnsSets = common.DNSSets{}
+		for key, set := range dnsSets {
+			if old := base.dnsSets[key]; old == nil || !proto.Equal(old, set) {
+				result.DnsSets[key] = set
+			}
+		}
+		for key := range base.dnsSets {
+			if dnsSets[key] == nil {
+				result.DeletedDnsSets = append(result.DeletedDnsSets, key)
+			}
+		}
+		sort.Strings(result.DeletedDnsSets)
+	}
+
+	snapshots = append(snapshots, &zoneSnapshot{token: result.SyncToken, dnsSets: dnsSets})
+	if len(snapshots) > maxZoneSnapshots {
+		snapshots = snapshots[len(snapshots)-maxZoneSnapshots:]
+	}
+	h.snapshots[zoneid] = snapshots
+	return result
+}
+
+func findZoneSnapshot(snapshots []*zoneSnapshot, syncToken string) *zoneSnapshot {
+	if syncToken == "" {
+		return nil
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.token == syncToken {
+			return snapshot
+		}
+	}
+	return nil
+}
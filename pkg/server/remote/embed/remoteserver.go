@@ -45,9 +45,10 @@ type RemoteAccessServerConfig struct {
 	CACertFilename       string
 	SecretName           resources.ObjectName
 	ServerSecretProvider ServerSecretProvider
+	ClientLimits         common.ClientLimits
 }
 
-type CreateServerFunc func(logctx logger.LogContext) common.RemoteProviderServer
+type CreateServerFunc func(logctx logger.LogContext, limits common.ClientLimits) common.RemoteProviderServer
 
 var serverFunc CreateServerFunc
 
@@ -81,7 +82,7 @@ func StartDNSHandlerServer(logctx logger.LogContext, config *RemoteAccessServerC
 		return nil, err
 	}
 	s := grpc.NewServer(grpc.Creds(creds))
-	server := serverFunc(logctx)
+	server := serverFunc(logctx, config.ClientLimits)
 	common.RegisterRemoteProviderServer(s, server)
 	logctx.Infof("DNSHandler server listening at %v", lis.Addr())
 	go func() {
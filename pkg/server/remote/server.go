@@ -26,12 +26,14 @@ import (
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	_ "google.golang.org/grpc/encoding/gzip" // register gzip compression for clients requesting it
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
@@ -42,6 +44,7 @@ type server struct {
 	spinning        time.Duration
 	logctx          logger.LogContext
 	namespaceStates map[string]*namespaceState
+	clientLimiter   *clientLimiter
 
 	tokenTTL           time.Duration
 	tokenCleanupTicker *time.Ticker
@@ -49,17 +52,21 @@ type server struct {
 	common.UnimplementedRemoteProviderServer
 }
 
-func CreateServer(logctx logger.LogContext) common.RemoteProviderServer {
-	return newServer(logctx)
+func CreateServer(logctx logger.LogContext, limits common.ClientLimits) common.RemoteProviderServer {
+	if limits.IsLimited() {
+		logctx.Infof("client limits: %.1f qps, burst %d, max pending changes %d", limits.QPS, limits.Burst, limits.MaxPendingChanges)
+	}
+	return newServer(logctx, limits)
 }
 
-func newServer(logctx logger.LogContext) *server {
+func newServer(logctx logger.LogContext, limits common.ClientLimits) *server {
 	id, _ := randonString(8)
 	s := &server{
 		serverID:        id,
 		spinning:        15 * time.Second,
 		logctx:          logctx,
 		namespaceStates: map[string]*namespaceState{},
+		clientLimiter:   newClientLimiter(limits),
 		tokenTTL:        2 * time.Hour,
 	}
 
@@ -123,7 +130,7 @@ func (s *server) ProviderRemovedEvent(logger logger.LogContext, objectName resou
 
 type reportFunc func(err error)
 
-func (s *server) checkAuth(token, requestType, zoneid string) (*namespaceState, logger.LogContext, reportFunc, error) {
+func (s *server) checkAuth(token, requestType, zoneid string, changes int) (*namespaceState, logger.LogContext, reportFunc, error) {
 	start := time.Now()
 	parts := strings.SplitN(token, "|", 2)
 	namespace := parts[0]
@@ -138,7 +145,13 @@ func (s *server) checkAuth(token, requestType, zoneid string) (*namespaceState,
 		return nil, logctx, nil, err
 	}
 
+	release, err := s.clientLimiter.accept(namespace, clientID, requestType, changes)
+	if err != nil {
+		return nil, logctx, nil, err
+	}
+
 	rf := func(err error) {
+		release()
 		d := time.Now().Sub(start)
 		code := ""
 		if err != nil {
@@ -175,7 +188,15 @@ func (s *server) Login(ctx context.Context, request *common.LoginRequest) (*comm
 	}
 
 	token := nsState.generateAndAddToken(s.tokenTTL, rnd, request.CliendID, s.serverID)
-	return &common.LoginResponse{Token: token}, nil
+	version := common.NegotiateProtocolVersion(request.ProtocolVersion)
+	capabilities := nsState.getCapabilities()
+	logctx.Infof("protocol version %d (client %d), record types %v, routing policies %v",
+		version, request.ProtocolVersion, capabilities.RecordTypes, capabilities.RoutingPolicyTypes)
+	return &common.LoginResponse{
+		Token:           token,
+		ProtocolVersion: version,
+		Capabilities:    capabilities,
+	}, nil
 }
 
 func (s *server) checkNamespaceAuthorization(ctx context.Context, namespace string) (string, error) {
@@ -214,10 +235,13 @@ func (s *server) cleanupTokens() {
 		count += nsState.cleanupTokens(now)
 	}
 	s.logctx.Infof("token cleanup of %d outdated tokens", count)
+	if removed := s.clientLimiter.cleanup(now.Add(-s.tokenTTL)); removed > 0 {
+		s.logctx.Infof("removed limiter states of %d idle clients", removed)
+	}
 }
 
 func (s *server) GetZones(_ context.Context, request *common.GetZonesRequest) (*common.Zones, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZones", "")
+	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZones", "", 0)
 	if err != nil {
 		logctx.Warn(err)
 		return nil, err
@@ -252,8 +276,8 @@ func (s *server) getZones(nsState *namespaceState, logctx logger.LogContext) (*c
 	return result, nil
 }
 
-func (s *server) GetZoneState(_ context.Context, request *common.GetZoneStateRequest) (*common.ZoneState, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZoneState", request.Zoneid)
+func (s *server) GetZoneState(ctx context.Context, request *common.GetZoneStateRequest) (*common.ZoneState, error) {
+	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZoneState", request.Zoneid, 0)
 	if err != nil {
 		logctx.Warn(err)
 		return nil, err
@@ -261,12 +285,12 @@ func (s *server) GetZoneState(_ context.Context, request *common.GetZoneStateReq
 	logctx = logctx.NewContext("zoneid", request.Zoneid)
 	logctx.Info("GetZoneState")
 
-	res, err := s.getZoneState(nsState, logctx, request.Zoneid)
+	res, err := s.getZoneState(ctx, nsState, logctx, request.Zoneid, request.SyncToken)
 	report(err)
 	return res, err
 }
 
-func (s *server) getZoneState(nsState *namespaceState, logctx logger.LogContext, zoneid string) (*common.ZoneState, error) {
+func (s *server) getZoneState(ctx context.Context, nsState *namespaceState, logctx logger.LogContext, zoneid, syncToken string) (*common.ZoneState, error) {
 	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
 	if err != nil {
 		return nil, err
@@ -277,18 +301,22 @@ func (s *server) getZoneState(nsState *namespaceState, logctx logger.LogContext,
 	}
 	defer hstate.lock.Unlock()
 
-	state, err := hstate.handler.GetZoneState(zone)
+	state, err := hstate.handler.GetZoneState(ctx, zone)
 	if err != nil {
 		return nil, err
 	}
-	result := &common.ZoneState{DnsSets: conversion.MarshalDNSSets(state.GetDNSSets())}
-	logctx.Infof("GetZoneState: %d DNSSets", len(result.GetDnsSets()))
+	result := hstate.zoneStateResponse(zoneid, syncToken, conversion.MarshalDNSSets(state.GetDNSSets()))
+	if result.Delta {
+		logctx.Infof("GetZoneState: delta with %d changed and %d deleted DNSSets", len(result.GetDnsSets()), len(result.GetDeletedDnsSets()))
+	} else {
+		logctx.Infof("GetZoneState: %d DNSSets", len(result.GetDnsSets()))
+	}
 
 	return result, nil
 }
 
-func (s *server) Execute(_ context.Context, request *common.ExecuteRequest) (*common.ExecuteResponse, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "Execute", request.Zoneid)
+func (s *server) Execute(ctx context.Context, request *common.ExecuteRequest) (*common.ExecuteResponse, error) {
+	nsState, logctx, report, err := s.checkAuth(request.Token, "Execute", request.Zoneid, len(request.ChangeRequest))
 	if err != nil {
 		logctx.Warn(err)
 		return nil, err
@@ -296,12 +324,12 @@ func (s *server) Execute(_ context.Context, request *common.ExecuteRequest) (*co
 	logctx = logctx.NewContext("zoneid", request.Zoneid)
 	logctx.Infof("Execute: %d changes", len(request.ChangeRequest))
 
-	res, err := s.execute(nsState, logctx, request.Zoneid, request.ChangeRequest)
+	res, err := s.execute(ctx, nsState, logctx, request.Zoneid, request.ChangeRequest)
 	report(err)
 	return res, err
 }
 
-func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zoneid string, changeRequests []*common.ChangeRequest) (*common.ExecuteResponse, error) {
+func (s *server) execute(ctx context.Context, nsState *namespaceState, logctx logger.LogContext, zoneid string, changeRequests []*common.ChangeRequest) (*common.ExecuteResponse, error) {
 	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
 	if err != nil {
 		return nil, err
@@ -313,7 +341,7 @@ func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zone
 	}
 	defer hstate.lock.Unlock()
 
-	state, err := hstate.handler.GetZoneState(zone)
+	state, err := hstate.handler.GetZoneState(ctx, zone)
 	if err != nil {
 		return nil, err
 	}
@@ -329,15 +357,42 @@ func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zone
 		if err != nil {
 			return nil, err
 		}
+		if err := validateChangeRequest(hstate.handler, req); err != nil {
+			done.SetInvalid(err)
+			continue
+		}
 		requests = append(requests, req)
 	}
-	err = hstate.handler.ExecuteRequests(memLogger, zone, state, requests)
+	err = hstate.handler.ExecuteRequests(ctx, memLogger, zone, state, requests)
 	return &common.ExecuteResponse{
 		ChangeResponse: responses,
 		LogMessage:     memLogger.entries,
 	}, err
 }
 
+// validateChangeRequest checks the record type and routing policy of a change request,
+// as remote clients only validate against the announced capabilities.
+func validateChangeRequest(handler provider.LightDNSHandler, req *provider.ChangeRequest) error {
+	set := req.Addition
+	if set == nil {
+		set = req.Deletion
+	}
+	if set == nil {
+		return nil
+	}
+	rs := set.Sets[req.Type]
+	if rs == nil {
+		return nil
+	}
+	if rs.Type != dns.RS_META && !handler.SupportsRecordType(rs.Type) {
+		return fmt.Errorf("record type %s not supported", rs.Type)
+	}
+	if rs.RoutingPolicy != nil {
+		return handler.ValidateRoutingPolicy(rs.RoutingPolicy)
+	}
+	return nil
+}
+
 func newDoneHandler(response *common.ChangeResponse) provider.DoneHandler {
 	return &serverDoneHandler{response: response}
 }
@@ -360,6 +415,11 @@ func (dh *serverDoneHandler) Failed(err error) {
 	dh.response.ErrorMessage = err.Error()
 }
 
-func (dh *serverDoneHandler) Throttled() {
+func (dh *serverDoneHandler) Blocked(reason string, planned []string) {
+	dh.response.State = common.ChangeResponse_FAILED
+	dh.response.ErrorMessage = fmt.Sprintf("%s: %s", reason, strings.Join(planned, ", "))
+}
+
+func (dh *serverDoneHandler) Throttled(retryAfter time.Duration) {
 	dh.response.State = common.ChangeResponse_THROTTLED
 }
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
@@ -49,6 +51,8 @@ type handlerState struct {
 	name    string
 	handler provider.LightDNSHandler
 	zones   atomic.Value
+	// snapshots are the last zone states transferred per zone, used as base for delta transfers
+	snapshots map[zoneid][]*zoneSnapshot
 }
 
 type tokenState struct {
@@ -69,7 +73,7 @@ func (s *namespaceState) updateHandler(logger logger.LogContext, name string, ha
 	defer s.lock.Unlock()
 
 	mod := false
-	zones, err := handler.GetZones()
+	zones, err := handler.GetZones(context.Background())
 	if err != nil {
 		logger.Errorf("handler.GetZones failed: %w", err)
 	}
@@ -84,10 +88,11 @@ func (s *namespaceState) updateHandler(logger logger.LogContext, name string, ha
 	} else {
 		ctx := context.TODO()
 		hstate = &handlerState{
-			lock:    dnsutils.NewTryLock(ctx),
-			name:    name,
-			handler: handler,
-			zones:   atomic.Value{},
+			lock:      dnsutils.NewTryLock(ctx),
+			name:      name,
+			handler:   handler,
+			zones:     atomic.Value{},
+			snapshots: map[zoneid][]*zoneSnapshot{},
 		}
 		hstate.zones.Store(zones)
 		s.handlers[name] = hstate
@@ -186,6 +191,36 @@ func (s *namespaceState) getAllZones(spinning time.Duration) ([]provider.DNSHost
 	return allZones, nil
 }
 
+// getCapabilities returns the record types and routing policy types supported by all handlers of the namespace.
+func (s *namespaceState) getCapabilities() *common.Capabilities {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	capabilities := &common.Capabilities{}
+	if len(s.handlers) == 0 {
+		return capabilities
+	}
+outerRecordTypes:
+	for _, rtype := range dns.ManagedRecordTypes {
+		for _, hstate := range s.handlers {
+			if !hstate.handler.SupportsRecordType(rtype) {
+				continue outerRecordTypes
+			}
+		}
+		capabilities.RecordTypes = append(capabilities.RecordTypes, rtype)
+	}
+outerRoutingPolicyTypes:
+	for _, ptype := range dns.RoutingPolicyTypes {
+		for _, hstate := range s.handlers {
+			if !utils.NewStringSet(hstate.handler.SupportedRoutingPolicyTypes()...).Contains(ptype) {
+				continue outerRoutingPolicyTypes
+			}
+		}
+		capabilities.RoutingPolicyTypes = append(capabilities.RoutingPolicyTypes, ptype)
+	}
+	return capabilities
+}
+
 func (s *namespaceState) lockupZone(spinning time.Duration, zoneid string) (*handlerState, provider.DNSHostedZone, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -205,13 +240,32 @@ func (h *handlerState) getCachedZones() []provider.DNSHostedZone {
 	return v.(provider.DNSHostedZones)
 }
 
+// cleanupSnapshots drops the zone state snapshots of zones no longer provided by the handler.
+func (h *handlerState) cleanupSnapshots(zones provider.DNSHostedZones) {
+	for id := range h.snapshots {
+		found := false
+		for _, zone := range zones {
+			if zone.Id().ID == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			delete(h.snapshots, id)
+		}
+	}
+}
+
 func (h *handlerState) getZones(spinning time.Duration) ([]provider.DNSHostedZone, error) {
 	if !h.lock.TryLockSpinning(spinning) {
 		return nil, fmt.Errorf("busy")
 	}
 	defer h.lock.Unlock()
 
-	zones, err := h.handler.GetZones()
+	zones, err := h.handler.GetZones(context.Background())
 	h.zones.Store(zones)
+	if err == nil {
+		h.cleanupSnapshots(zones)
+	}
 	return zones, err
 }
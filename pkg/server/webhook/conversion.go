@@ -0,0 +1,141 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1beta1"
+)
+
+// ConversionPath is the path of the conversion webhook.
+const ConversionPath = "/convert"
+
+// maxRequestSize limits the size of a conversion review (the API server sends at most 3MB per object).
+const maxRequestSize = 32 * 1024 * 1024
+
+// NewConversionHandler creates the handler for the conversion reviews of the API server
+// converting DNSEntry and DNSProvider objects between the versions v1alpha1 and v1beta1.
+func NewConversionHandler(log logger.LogContext) http.Handler {
+	return &conversionHandler{log: log}
+}
+
+type conversionHandler struct {
+	log logger.LogContext
+}
+
+func (this *conversionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot read request: %s", err), http.StatusBadRequest)
+		return
+	}
+	review := &apiext.ConversionReview{}
+	if err := json.Unmarshal(body, review); err != nil || review.Request == nil {
+		http.Error(w, "invalid conversion review", http.StatusBadRequest)
+		return
+	}
+	review.Response = ConvertReview(review.Request)
+	if review.Response.Result.Status != metav1.StatusSuccess {
+		this.log.Warnf("conversion to %s failed: %s", review.Request.DesiredAPIVersion, review.Response.Result.Message)
+	}
+	review.Request = nil
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		this.log.Warnf("cannot write conversion response: %s", err)
+	}
+}
+
+// ConvertReview converts the objects of a conversion request.
+func ConvertReview(req *apiext.ConversionRequest) *apiext.ConversionResponse {
+	resp := &apiext.ConversionResponse{UID: req.UID}
+	for _, obj := range req.Objects {
+		converted, err := ConvertObject(obj.Raw, req.DesiredAPIVersion)
+		if err != nil {
+			resp.ConvertedObjects = nil
+			resp.Result = metav1.Status{Status: metav1.StatusFailure, Message: err.Error()}
+			return resp
+		}
+		resp.ConvertedObjects = append(resp.ConvertedObjects, runtime.RawExtension{Raw: converted})
+	}
+	resp.Result = metav1.Status{Status: metav1.StatusSuccess}
+	return resp
+}
+
+// ConvertObject converts the JSON representation of a DNSEntry or DNSProvider to the desired API version.
+func ConvertObject(raw []byte, desiredAPIVersion string) ([]byte, error) {
+	meta := &metav1.TypeMeta{}
+	if err := json.Unmarshal(raw, meta); err != nil {
+		return nil, fmt.Errorf("cannot decode object: %w", err)
+	}
+	if meta.APIVersion == desiredAPIVersion {
+		return raw, nil
+	}
+	from, to := meta.APIVersion, desiredAPIVersion
+	alpha, beta := v1alpha1.SchemeGroupVersion.String(), v1beta1.SchemeGroupVersion.String()
+	if !(from == alpha && to == beta) && !(from == beta && to == alpha) {
+		return nil, fmt.Errorf("unsupported conversion of %s from %s to %s", meta.Kind, from, to)
+	}
+
+	var converted interface{}
+	switch meta.Kind {
+	case v1alpha1.DNSEntryKind:
+		if to == beta {
+			in := &v1alpha1.DNSEntry{}
+			if err := json.Unmarshal(raw, in); err != nil {
+				return nil, err
+			}
+			converted = v1beta1.ConvertEntryFromV1alpha1(in)
+		} else {
+			in := &v1beta1.DNSEntry{}
+			if err := json.Unmarshal(raw, in); err != nil {
+				return nil, err
+			}
+			converted = v1beta1.ConvertEntryToV1alpha1(in)
+		}
+	case v1alpha1.DNSProviderKind:
+		if to == beta {
+			in := &v1alpha1.DNSProvider{}
+			if err := json.Unmarshal(raw, in); err != nil {
+				return nil, err
+			}
+			converted = v1beta1.ConvertProviderFromV1alpha1(in)
+		} else {
+			in := &v1beta1.DNSProvider{}
+			if err := json.Unmarshal(raw, in); err != nil {
+				return nil, err
+			}
+			converted = v1beta1.ConvertProviderToV1alpha1(in)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", meta.Kind)
+	}
+	return json.Marshal(converted)
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"encoding/json"
+	"testing"
+
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1beta1"
+)
+
+func TestConvertReview(t *testing.T) {
+	entry := &v1alpha1.DNSEntry{
+		TypeMeta:   metav1.TypeMeta{APIVersion: v1alpha1.SchemeGroupVersion.String(), Kind: v1alpha1.DNSEntryKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "e1", Namespace: "default"},
+		Spec:       v1alpha1.DNSEntrySpec{DNSName: "www.example.com", TXT: []v1alpha1.TXTRecord{{Value: "text"}}},
+	}
+	raw, _ := json.Marshal(entry)
+	req := &apiext.ConversionRequest{
+		UID:               "uid",
+		DesiredAPIVersion: v1beta1.SchemeGroupVersion.String(),
+		Objects:           []runtime.RawExtension{{Raw: raw}},
+	}
+	resp := ConvertReview(req)
+	if resp.UID != "uid" || resp.Result.Status != metav1.StatusSuccess || len(resp.ConvertedObjects) != 1 {
+		t.Fatalf("Failed: unexpected response %v", resp)
+	}
+	converted := &v1beta1.DNSEntry{}
+	if err := json.Unmarshal(resp.ConvertedObjects[0].Raw, converted); err != nil {
+		t.Fatalf("Failed: %s", err)
+	}
+	if converted.APIVersion != v1beta1.SchemeGroupVersion.String() || converted.Spec.Records == nil || converted.Spec.Records.TXT[0] != "text" {
+		t.Errorf("Failed: unexpected object %v", converted)
+	}
+
+	req.DesiredAPIVersion = "dns.gardener.cloud/v1"
+	if resp := ConvertReview(req); resp.Result.Status != metav1.StatusFailure || resp.ConvertedObjects != nil {
+		t.Errorf("Failed: expected failure for unknown version, got %v", resp)
+	}
+}
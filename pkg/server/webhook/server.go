@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package webhook
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+)
+
+// file names of the server certificate in the certificate directory
+const (
+	CertFile = "tls.crt"
+	KeyFile  = "tls.key"
+	CAFile   = "ca.crt"
+)
+
+// StartServer starts an HTTPS server for the conversion webhook on the given port.
+// The server certificate is read from the certificate directory and reloaded if the files
+// are changed (e.g. by a certificate rotation). The server is stopped with the context.
+func StartServer(ctx context.Context, log logger.LogContext, port int, certDir string) error {
+	certs := &certificateLoader{
+		certFile: filepath.Join(certDir, CertFile),
+		keyFile:  filepath.Join(certDir, KeyFile),
+	}
+	if _, err := certs.GetCertificate(nil); err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle(ConversionPath, NewConversionHandler(log))
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: certs.GetCertificate,
+		},
+	}
+	log.Infof("conversion webhook listening at %v", lis.Addr())
+	go func() {
+		if err := server.ServeTLS(lis, "", ""); err != nil && err != http.ErrServerClosed {
+			log.Errorf("conversion webhook failed: %s", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	return nil
+}
+
+// certificateLoader provides the server certificate and reloads it if the files have been modified.
+type certificateLoader struct {
+	certFile string
+	keyFile  string
+
+	lock     sync.Mutex
+	cert     *tls.Certificate
+	modified time.Time
+}
+
+func (this *certificateLoader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	modified, err := latestModification(this.certFile, this.keyFile)
+	if err != nil {
+		if this.cert != nil {
+			return this.cert, nil
+		}
+		return nil, err
+	}
+	if this.cert == nil || modified.After(this.modified) {
+		cert, err := tls.LoadX509KeyPair(this.certFile, this.keyFile)
+		if err != nil {
+			if this.cert != nil {
+				// keep the actual certificate while the files are updated
+				return this.cert, nil
+			}
+			return nil, fmt.Errorf("cannot load certificate of conversion webhook: %w", err)
+		}
+		this.cert = &cert
+		this.modified = modified
+	}
+	return this.cert, nil
+}
+
+func latestModification(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -63,6 +63,7 @@ const (
 	PrivateZones
 	Quotas4PerMin
 	RemoveAccess
+	Transactional
 )
 
 type TestEnv struct {
@@ -238,6 +239,8 @@ func (te *TestEnv) BuildProviderConfigEx(input mock.MockConfig, failOptions ...P
 			input.FailGetZones = true
 		case FailDeleteEntry:
 			input.FailDeleteEntry = true
+		case Transactional:
+			input.Transactional = true
 		case FailSecondZoneWithSameBaseDomain:
 			input.Zones = append(input.Zones, mock.MockZone{
 				ZonePrefix: te.ZonePrefix + ":second",